@@ -6,12 +6,14 @@ import (
 	"log"
 	"os"
 
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/joho/godotenv"
+
+	"github.com/Adityanrhm/wallet-twin/internal/database"
 )
 
+// migrationsURL adalah lokasi migration files, relatif dari root repo.
+const migrationsURL = "file://migrations"
+
 func main() {
 	// Load .env file
 	_ = godotenv.Load()
@@ -28,7 +30,7 @@ func main() {
 	cmd := os.Args[1]
 
 	// Create migrator
-	m, err := migrate.New("file://migrations", dbURL)
+	m, err := database.NewMigrator(dbURL, migrationsURL)
 	if err != nil {
 		log.Fatalf("Failed to create migrator: %v", err)
 	}
@@ -36,11 +38,19 @@ func main() {
 
 	switch cmd {
 	case "up":
-		fmt.Println("⬆️  Running migrations...")
-		if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-			log.Fatalf("Migration failed: %v", err)
+		flags := flag.NewFlagSet("up", flag.ExitOnError)
+		dryRun := flags.Bool("dry-run", false, "Print the migrations that would run without executing them")
+		to := flags.Uint("to", 0, "Stop at this migration version instead of the latest")
+		_ = flags.Parse(os.Args[2:])
+
+		runUp(m, *to, *dryRun)
+
+	case "status":
+		status, err := m.Status()
+		if err != nil {
+			log.Fatalf("Failed to get status: %v", err)
 		}
-		fmt.Println("✅ Migrations completed!")
+		printStatus(status)
 
 	case "down":
 		fmt.Println("⬇️  Rolling back last migration...")
@@ -80,6 +90,77 @@ func main() {
 	}
 }
 
+// runUp menjalankan migration pending (sampai versi to jika > 0).
+// Dengan dryRun, hanya daftar migration yang akan dijalankan yang dicetak.
+func runUp(m *database.Migrator, to uint, dryRun bool) {
+	status, err := m.Status()
+	if err != nil {
+		log.Fatalf("Failed to get status: %v", err)
+	}
+
+	if status.Dirty {
+		fmt.Println(status.DirtyHint())
+		os.Exit(1)
+	}
+	if err := status.CheckUpTarget(to); err != nil {
+		log.Fatalf("Cannot migrate: %v", err)
+	}
+
+	pending := status.PendingUpTo(to)
+	if len(pending) == 0 {
+		fmt.Println("✅ Nothing to migrate, database is up to date")
+		return
+	}
+
+	if dryRun {
+		fmt.Printf("🔍 Dry run: %d migration(s) would run:\n", len(pending))
+		for _, mig := range pending {
+			fmt.Printf("   %06d  %s\n", mig.Version, mig.Name)
+		}
+		return
+	}
+
+	fmt.Printf("⬆️  Running %d migration(s)...\n", len(pending))
+	if to != 0 {
+		err = m.Migrate(to)
+	} else {
+		err = m.Up()
+	}
+	if err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+
+	for _, mig := range pending {
+		fmt.Printf("   ✅ %06d  %s\n", mig.Version, mig.Name)
+	}
+	fmt.Println("✅ Migrations completed!")
+}
+
+// printStatus mencetak semua migration beserta state-nya.
+func printStatus(status *database.MigrationStatus) {
+	if status.Dirty {
+		fmt.Printf("⚠️  DIRTY: database is at version %d, which did not finish\n\n", status.Version)
+	}
+
+	for _, mig := range status.Migrations {
+		icon := "✅"
+		switch mig.State {
+		case database.MigrationPending:
+			icon = "⏳"
+		case database.MigrationDirty:
+			icon = "❌"
+		}
+		fmt.Printf("%s %06d  %-40s %s\n", icon, mig.Version, mig.Name, mig.State)
+	}
+
+	fmt.Printf("\n📌 Current version: %d, pending: %d\n", status.Version, len(status.Pending()))
+
+	if status.Dirty {
+		fmt.Println()
+		fmt.Println(status.DirtyHint())
+	}
+}
+
 func getDBURL() string {
 	host := getEnv("WT_DATABASE_HOST", "localhost")
 	port := getEnv("WT_DATABASE_PORT", "5432")
@@ -100,18 +181,23 @@ func getEnv(key, defaultVal string) string {
 }
 
 func printUsage() {
-	fmt.Println(`
+	fmt.Print(`
 Usage: go run cmd/migrate/main.go <command>
 
 Commands:
   up       Run all pending migrations
+             --dry-run  Print what would run without executing
+             --to N     Stop at version N
+  status   List migrations with applied/pending state
   down     Rollback last migration
   reset    Drop all tables
   version  Show current migration version
   force N  Force set migration version to N
 
 Example:
-  go run cmd/migrate/main.go up
+  go run cmd/migrate/main.go status
+  go run cmd/migrate/main.go up --dry-run
+  go run cmd/migrate/main.go up --to 9
 `)
 	flag.PrintDefaults()
 }
@@ -5,35 +5,18 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/Adityanrhm/wallet-twin/internal/app"
 	"github.com/Adityanrhm/wallet-twin/internal/cli"
 )
 
 // main adalah entry point aplikasi.
 //
 // Flow:
-//  1. Initialize App dengan semua dependencies
-//  2. Run CLI commands (Cobra)
-//  3. Cleanup saat exit
+//  1. Run CLI commands (Cobra)
+//  2. App di-initialize oleh CLI sebelum command dijalankan
+//  3. Cleanup dilakukan oleh CLI saat command selesai
 func main() {
-	// Initialize application
-	application, err := app.New("./config")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to initialize application: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Pastikan cleanup dilakukan saat exit
-	defer func() {
-		if err := application.Close(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error during cleanup: %v\n", err)
-		}
-	}()
-
-	// Run CLI commands
-	if err := cli.Execute(application); err != nil {
+	if err := cli.Execute("./config"); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
-
@@ -25,6 +25,8 @@ type Repos struct {
 	Budget      repository.BudgetRepository
 	Recurring   repository.RecurringRepository
 	Goal        repository.GoalRepository
+	Integrity   repository.IntegrityRepository
+	Rule        repository.RuleRepository
 }
 
 // App adalah struct utama yang menyimpan semua dependencies aplikasi.
@@ -37,6 +39,13 @@ type App struct {
 
 	// Repos menyimpan semua repository instances
 	Repos *Repos
+
+	// TxManager menjalankan operasi multi-repository secara atomic
+	TxManager repository.TransactionManager
+
+	// Demo bernilai true jika App berjalan dengan data in-memory
+	// (lihat NewDemo). DB selalu nil dalam mode ini.
+	Demo bool
 }
 
 // New membuat instance baru dari App dengan semua dependencies.
@@ -53,27 +62,38 @@ func New(configPath string) (*App, error) {
 	}
 
 	// 3. Connect ke database
-	db, err := database.NewPostgres(cfg.Database.ConnectionString())
+	// Pool size dari config (database.max_conns, database.min_conns)
+	poolConfig := database.DefaultPoolConfig()
+	poolConfig.MaxConns = int32(cfg.Database.MaxConns)
+	poolConfig.MinConns = int32(cfg.Database.MinConns)
+
+	db, err := database.NewPostgres(cfg.Database.ConnectionString(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// 4. Initialize repositories
+	// 4. Initialize repositories; setiap query dibatasi database.query_timeout
+	// Repository memakai db, bukan pool miliknya sendiri, supaya Reconfigure berlaku
+	// untuk semuanya
+	pool := postgres.WithQueryTimeout(db, cfg.Database.QueryTimeout)
 	repos := &Repos{
-		Wallet:      postgres.NewWalletRepository(db.Pool),
-		Category:    postgres.NewCategoryRepository(db.Pool),
-		Transaction: postgres.NewTransactionRepository(db.Pool),
-		Transfer:    postgres.NewTransferRepository(db.Pool),
-		Budget:      postgres.NewBudgetRepository(db.Pool),
-		Recurring:   postgres.NewRecurringRepository(db.Pool),
-		Goal:        postgres.NewGoalRepository(db.Pool),
+		Wallet:      postgres.NewWalletRepository(pool),
+		Category:    postgres.NewCategoryRepository(pool),
+		Transaction: postgres.NewTransactionRepository(pool),
+		Transfer:    postgres.NewTransferRepository(pool),
+		Budget:      postgres.NewBudgetRepository(pool),
+		Recurring:   postgres.NewRecurringRepository(pool),
+		Goal:        postgres.NewGoalRepository(pool),
+		Integrity:   postgres.NewIntegrityRepository(pool),
+		Rule:        postgres.NewRuleRepository(pool),
 	}
 
 	// 5. Return App dengan semua dependencies
 	return &App{
-		Config: cfg,
-		DB:     db,
-		Repos:  repos,
+		Config:    cfg,
+		DB:        db,
+		Repos:     repos,
+		TxManager: postgres.NewTransactionManager(pool),
 	}, nil
 }
 
@@ -0,0 +1,234 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/config"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+)
+
+// NewDemo membuat App yang berjalan tanpa database.
+//
+// Semua repository menggunakan implementasi in-memory (package memory)
+// yang sudah diisi sample data: wallets, categories, transactions,
+// budgets, dan goals. Cocok untuk screenshot, demo, dan development UI.
+//
+// Perubahan yang dilakukan selama demo hilang saat aplikasi ditutup.
+//
+//	app, err := app.NewDemo("./config")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer app.Close()
+func NewDemo(configPath string) (*App, error) {
+	// Config tetap di-load supaya currency dan tampilan sama dengan mode normal
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store := memory.NewStore()
+	repos := &Repos{
+		Wallet:      memory.NewWalletRepository(store),
+		Category:    memory.NewCategoryRepository(store),
+		Transaction: memory.NewTransactionRepository(store),
+		Transfer:    memory.NewTransferRepository(store),
+		Budget:      memory.NewBudgetRepository(store),
+		Recurring:   memory.NewRecurringRepository(store),
+		Goal:        memory.NewGoalRepository(store),
+		Integrity:   memory.NewIntegrityRepository(store),
+		Rule:        memory.NewRuleRepository(store),
+	}
+
+	if err := seedDemoData(context.Background(), repos, cfg.App.Currency, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to seed demo data: %w", err)
+	}
+
+	return &App{
+		Config:    cfg,
+		Repos:     repos,
+		TxManager: memory.NewTransactionManager(store),
+		Demo:      true,
+	}, nil
+}
+
+// demoTransaction adalah template transaksi sample.
+type demoTransaction struct {
+	wallet      int
+	category    string
+	txType      models.TransactionType
+	amount      int64
+	description string
+	daysAgo     int
+
+	// hour adalah jam transaksi, supaya heatmap di tab Insights terisi.
+	hour int
+}
+
+// seedDemoData mengisi repositories dengan sample data.
+//
+// Saldo wallet dihitung dari saldo awal ditambah semua transaksi sample,
+// jadi angka di dashboard konsisten dengan history transaksi.
+func seedDemoData(ctx context.Context, repos *Repos, currency string, now time.Time) error {
+	if currency == "" {
+		currency = "IDR"
+	}
+
+	// 1. Categories
+	categories := map[string]*models.Category{}
+	categoryDefs := []struct {
+		name  string
+		icon  string
+		color string
+		typ   models.CategoryType
+	}{
+		{"Salary", "💼", "#22C55E", models.CategoryTypeIncome},
+		{"Freelance", "💻", "#10B981", models.CategoryTypeIncome},
+		{"Food & Dining", "🍔", "#EF4444", models.CategoryTypeExpense},
+		{"Transportation", "🚗", "#F59E0B", models.CategoryTypeExpense},
+		{"Shopping", "🛍️", "#EC4899", models.CategoryTypeExpense},
+		{"Bills & Utilities", "💡", "#6366F1", models.CategoryTypeExpense},
+		{"Entertainment", "🎬", "#8B5CF6", models.CategoryTypeExpense},
+	}
+	for i, def := range categoryDefs {
+		c := models.NewCategory(def.name, def.typ)
+		c.Icon = def.icon
+		c.Color = def.color
+		c.SortOrder = i
+		if err := repos.Category.Create(ctx, c); err != nil {
+			return fmt.Errorf("failed to create category %s: %w", def.name, err)
+		}
+		categories[def.name] = c
+	}
+
+	// 2. Wallets (saldo awal, transaksi di-apply di bawah)
+	walletDefs := []struct {
+		name    string
+		typ     models.WalletType
+		icon    string
+		color   string
+		opening int64
+	}{
+		{"Cash", models.WalletTypeCash, "💵", "#22C55E", 750000},
+		{"BCA", models.WalletTypeBank, "🏦", "#3B82F6", 8500000},
+		{"GoPay", models.WalletTypeEWallet, "📱", "#10B981", 350000},
+	}
+	wallets := make([]*models.Wallet, len(walletDefs))
+	for i, def := range walletDefs {
+		w := models.NewWallet(def.name, def.typ)
+		w.Icon = def.icon
+		w.Color = def.color
+		w.Currency = currency
+		w.Balance = decimal.NewFromInt(def.opening)
+		w.CreatedAt = now.AddDate(0, -2, 0)
+		wallets[i] = w
+	}
+
+	// 3. Transactions
+	txDefs := []demoTransaction{
+		{1, "Salary", models.TransactionTypeIncome, 12000000, "Monthly salary", 25, 9},
+		{1, "Freelance", models.TransactionTypeIncome, 2500000, "Landing page project", 12, 16},
+		{1, "Bills & Utilities", models.TransactionTypeExpense, 450000, "Electricity", 20, 10},
+		{1, "Bills & Utilities", models.TransactionTypeExpense, 350000, "Internet", 18, 10},
+		{1, "Shopping", models.TransactionTypeExpense, 899000, "Running shoes", 9, 15},
+		{0, "Food & Dining", models.TransactionTypeExpense, 45000, "Nasi padang", 1, 12},
+		{0, "Food & Dining", models.TransactionTypeExpense, 32000, "Coffee", 2, 8},
+		{0, "Food & Dining", models.TransactionTypeExpense, 120000, "Dinner with friends", 4, 20},
+		{0, "Transportation", models.TransactionTypeExpense, 50000, "Parking & fuel", 6, 19},
+		{2, "Transportation", models.TransactionTypeExpense, 28000, "Ojek to office", 0, 7},
+		{2, "Transportation", models.TransactionTypeExpense, 31000, "Ojek home", 3, 18},
+		{2, "Food & Dining", models.TransactionTypeExpense, 85000, "Food delivery", 5, 21},
+		{2, "Entertainment", models.TransactionTypeExpense, 54990, "Streaming subscription", 15, 0},
+		{1, "Entertainment", models.TransactionTypeExpense, 150000, "Cinema tickets", 7, 21},
+	}
+	pending := make([]*models.Transaction, 0, len(txDefs))
+	for _, def := range txDefs {
+		wallet := wallets[def.wallet]
+		tx := models.NewTransaction(wallet.ID, def.txType, decimal.NewFromInt(def.amount))
+		tx.SetCategory(categories[def.category].ID)
+		tx.Description = def.description
+		day := now.AddDate(0, 0, -def.daysAgo)
+		tx.TransactionDate = time.Date(day.Year(), day.Month(), day.Day(), def.hour, 0, 0, 0, now.Location())
+		tx.CreatedAt = tx.TransactionDate
+
+		// Transaksi baru disimpan setelah wallet dibuat (foreign key),
+		// jadi efeknya ke balance di-apply duluan di sini.
+		if def.txType == models.TransactionTypeIncome {
+			wallet.Balance = wallet.Balance.Add(tx.Amount)
+		} else {
+			wallet.Balance = wallet.Balance.Sub(tx.Amount)
+		}
+		pending = append(pending, tx)
+	}
+
+	for _, w := range wallets {
+		if err := repos.Wallet.Create(ctx, w); err != nil {
+			return fmt.Errorf("failed to create wallet %s: %w", w.Name, err)
+		}
+	}
+	for _, tx := range pending {
+		if err := repos.Transaction.Create(ctx, tx); err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+	}
+
+	// 4. Budgets (bulan ini)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	budgetDefs := []struct {
+		category string
+		amount   int64
+	}{
+		{"Food & Dining", 1500000},
+		{"Transportation", 400000},
+		{"Entertainment", 150000},
+	}
+	for _, def := range budgetDefs {
+		b := models.NewBudget(categories[def.category].ID, decimal.NewFromInt(def.amount))
+		b.StartDate = monthStart
+		if err := repos.Budget.Create(ctx, b); err != nil {
+			return fmt.Errorf("failed to create budget: %w", err)
+		}
+	}
+
+	// 5. Goals
+	goalDefs := []struct {
+		name     string
+		icon     string
+		target   int64
+		saved    int64
+		deadline *time.Time
+	}{
+		{"Emergency Fund", "🛟", 30000000, 18500000, nil},
+		{"New Laptop", "💻", 20000000, 6000000, demoDate(now.AddDate(0, 6, 0))},
+		{"Bali Trip", "🏝️", 8000000, 8000000, nil},
+	}
+	for _, def := range goalDefs {
+		g := models.NewGoal(def.name, decimal.NewFromInt(def.target))
+		g.Icon = def.icon
+		g.Deadline = def.deadline
+		if def.saved >= def.target {
+			g.Status = models.GoalStatusCompleted
+		}
+		if err := repos.Goal.Create(ctx, g); err != nil {
+			return fmt.Errorf("failed to create goal %s: %w", def.name, err)
+		}
+
+		c := models.NewContribution(g.ID, decimal.NewFromInt(def.saved))
+		c.Note = "Initial savings"
+		if err := repos.Goal.AddContribution(ctx, c); err != nil {
+			return fmt.Errorf("failed to add contribution: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// demoDate mengembalikan pointer ke t.
+func demoDate(t time.Time) *time.Time {
+	return &t
+}
@@ -2,7 +2,6 @@ package cli
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
@@ -15,10 +14,11 @@ import (
 
 // budgetCmd adalah parent command untuk budget operations.
 var budgetCmd = &cobra.Command{
-	Use:     "budget",
-	Aliases: []string{"b"},
-	Short:   "📊 Manage budgets",
-	Long:    "Create and track spending budgets per category.",
+	Use:         "budget",
+	Aliases:     []string{"b"},
+	Short:       "📊 Manage budgets",
+	Annotations: requiresDB,
+	Long:        "Create and track spending budgets per category.",
 }
 
 // budgetListCmd menampilkan semua budgets dengan status.
@@ -33,6 +33,7 @@ var budgetListCmd = &cobra.Command{
 			application.Repos.Budget,
 			application.Repos.Transaction,
 		)
+		budgetService.SetMonthStartDay(application.Config.App.MonthStartDay)
 
 		statuses, err := budgetService.GetAllStatus(ctx)
 		if err != nil {
@@ -40,18 +41,18 @@ var budgetListCmd = &cobra.Command{
 		}
 
 		if len(statuses) == 0 {
-			fmt.Println("No active budgets. Create one with: wallet budget add")
+			fmt.Fprintln(stdout, "No active budgets. Create one with: wallet budget add")
 			return nil
 		}
 
-		fmt.Println(titleStyle.Render("\n📊 Budget Status\n"))
+		fmt.Fprintln(stdout, titleStyle.Render("\n📊 Budget Status\n"))
 
-		table := tablewriter.NewTable(os.Stdout)
+		table := tablewriter.NewTable(stdout)
 		table.Header("Category", "Budget", "Spent", "Remaining", "Progress")
 
 		for _, s := range statuses {
 			// Progress bar
-			progressBar := renderProgressBar(s.Progress, 10)
+			progressBar := newProgressBar(10).Render(s.Progress)
 
 			// Color based on status
 			remaining := formatMoney(s.Remaining)
@@ -60,7 +61,7 @@ var budgetListCmd = &cobra.Command{
 			}
 
 			table.Append([]string{
-				s.CategoryIcon + " " + s.CategoryName,
+				models.DisplayIcon(s.CategoryIcon) + " " + s.CategoryName,
 				formatMoney(s.Budget.Amount),
 				formatMoney(s.Spent),
 				remaining,
@@ -69,14 +70,37 @@ var budgetListCmd = &cobra.Command{
 		}
 
 		table.Render()
+
+		alerts, err := budgetService.CheckAlerts(ctx)
+		if err != nil {
+			return err
+		}
+		for _, a := range alerts {
+			name := models.DisplayIcon(a.Status.CategoryIcon) + " " + a.Status.CategoryName
+			if a.Level == service.BudgetAlertOver {
+				fmt.Fprintln(stdout, errorStyle.Render(fmt.Sprintf("🚨 %s is over budget (%.0f%%)", name, a.Status.Progress)))
+			} else {
+				fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf("⚠️  %s reached %.0f%% (warns at %.0f%%)",
+					name, a.Status.Progress, a.Status.Budget.WarnThreshold)))
+			}
+		}
 		return nil
 	},
 }
 
+// budgetBelowAverageRatio: warning jika budget lebih dari 30% di bawah
+// rata-rata pengeluaran bulanan kategori.
+var budgetBelowAverageRatio = decimal.NewFromFloat(0.7)
+
 // budgetAddCmd menambah budget baru.
 var budgetAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a new budget",
+	Long: `Add a spending budget for a category.
+
+The category's average monthly spend over the last 3 full months is shown
+first. Without --amount (in an interactive terminal) you are asked for the
+amount, defaulting to that average.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
@@ -84,10 +108,12 @@ var budgetAddCmd = &cobra.Command{
 			application.Repos.Budget,
 			application.Repos.Transaction,
 		)
+		budgetService.SetMonthStartDay(application.Config.App.MonthStartDay)
 
 		categoryID, _ := cmd.Flags().GetString("category")
 		amountStr, _ := cmd.Flags().GetString("amount")
 		period, _ := cmd.Flags().GetString("period")
+		warnAt, _ := cmd.Flags().GetFloat64("warn-at")
 
 		// Parse category ID
 		catID, err := parseUUID(categoryID)
@@ -95,31 +121,185 @@ var budgetAddCmd = &cobra.Command{
 			return fmt.Errorf("invalid category ID: %w", err)
 		}
 
+		// Tampilkan pengeluaran aktual kategori sebelum menentukan amount
+		now := time.Now()
+		txService := service.NewTransactionService(application.Repos.Transaction, application.Repos.Wallet, application.Repos.Category, application.TxManager)
+		stats, err := txService.GetCategoryStats(ctx, now)
+		if err != nil {
+			return err
+		}
+		average := decimal.Zero
+		if s, ok := stats[catID]; ok {
+			average = s.AverageMonthly
+			fmt.Fprintf(stdout, "📊 Average monthly spend (%d months): %s | last month: %s\n",
+				service.CategoryStatsMonths, moneyStyle.Render(formatMoney(s.AverageMonthly)), formatMoney(s.LastMonth))
+		} else {
+			fmt.Fprintf(stdout, "📊 No spending in this category in the last %d months\n", service.CategoryStatsMonths)
+		}
+
+		if amountStr == "" {
+			if !isInteractive() {
+				return fmt.Errorf("--amount is required when not running interactively")
+			}
+			amountStr = prompt("Budget amount", roundBudgetAmount(average).String())
+		}
+
 		// Parse amount
 		amount, err := decimal.NewFromString(amountStr)
 		if err != nil {
 			return fmt.Errorf("invalid amount: %w", err)
 		}
 
-		// Set start date (first of current month for monthly)
-		now := time.Now()
-		startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+		if average.IsPositive() && amount.LessThan(average.Mul(budgetBelowAverageRatio)) {
+			fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf(
+				"⚠️  This budget is more than 30%% below your average spend of %s", formatMoney(average))))
+		}
+
+		// Periode monthly dimulai di app.month_start_day
+		startDate := budgetService.PeriodStart(models.BudgetPeriodMonthly, now)
 
 		budget, err := budgetService.Create(ctx, service.CreateBudgetInput{
-			CategoryID: catID,
-			Amount:     amount,
-			Period:     models.BudgetPeriod(period),
-			StartDate:  startDate,
+			CategoryID:    catID,
+			Amount:        amount,
+			Period:        models.BudgetPeriod(period),
+			StartDate:     startDate,
+			WarnThreshold: warnAt,
 		})
 
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(successStyle.Render("✅ Budget created!"))
-		fmt.Printf("   💰 Amount: %s\n", formatMoney(budget.Amount))
-		fmt.Printf("   📅 Period: %s\n", budget.Period)
+		fmt.Fprintln(stdout, successStyle.Render("✅ Budget created!"))
+		fmt.Fprintf(stdout, "   💰 Amount: %s\n", formatMoney(budget.Amount))
+		fmt.Fprintf(stdout, "   📅 Period: %s\n", budget.Period)
+		fmt.Fprintf(stdout, "   🔔 Warn at: %.0f%%\n", budget.WarnThreshold)
+
+		return nil
+	},
+}
+
+// budgetUpdateCmd mengubah amount atau warn threshold budget.
+var budgetUpdateCmd = &cobra.Command{
+	Use:   "update [budget-id]",
+	Short: "Update a budget's amount or warning threshold",
+	Example: `  wallet budget update <budget-id> --warn-at 90
+  wallet budget update <budget-id> --amount 2500000`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		budgetService := service.NewBudgetService(
+			application.Repos.Budget,
+			application.Repos.Transaction,
+		)
+		budgetService.SetMonthStartDay(application.Config.App.MonthStartDay)
+
+		id, err := parseUUID(args[0])
+		if err != nil {
+			return err
+		}
+
+		input := service.UpdateBudgetInput{ID: id}
+		if cmd.Flags().Changed("amount") {
+			amountStr, _ := cmd.Flags().GetString("amount")
+			amount, err := decimal.NewFromString(amountStr)
+			if err != nil {
+				return fmt.Errorf("invalid amount: %w", err)
+			}
+			input.Amount = &amount
+		}
+		if cmd.Flags().Changed("warn-at") {
+			warnAt, _ := cmd.Flags().GetFloat64("warn-at")
+			input.WarnThreshold = &warnAt
+		}
+		if input.Amount == nil && input.WarnThreshold == nil {
+			return fmt.Errorf("nothing to update: use --amount or --warn-at")
+		}
+
+		budget, err := budgetService.Update(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Budget updated!"))
+		fmt.Fprintf(stdout, "   💰 Amount: %s\n", formatMoney(budget.Amount))
+		fmt.Fprintf(stdout, "   🔔 Warn at: %.0f%%\n", budget.WarnThreshold)
+
+		return nil
+	},
+}
+
+// budgetSimulateCmd menguji budget usulan terhadap pengeluaran masa lalu.
+var budgetSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "See how a proposed budget would have performed in past periods",
+	Long: `Compare a proposed budget against the category's actual spending in
+each completed period of the last N months. Nothing is saved.`,
+	Example: `  wallet budget simulate --category <id> --amount 1500000 --months 6
+  wallet budget simulate -c <id> -a 400000 -p weekly --months 3`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		budgetService := service.NewBudgetService(
+			application.Repos.Budget,
+			application.Repos.Transaction,
+		)
+		budgetService.SetMonthStartDay(application.Config.App.MonthStartDay)
+
+		categoryID, _ := cmd.Flags().GetString("category")
+		amountStr, _ := cmd.Flags().GetString("amount")
+		period, _ := cmd.Flags().GetString("period")
+		months, _ := cmd.Flags().GetInt("months")
+
+		catID, err := parseUUID(categoryID)
+		if err != nil {
+			return fmt.Errorf("invalid category ID: %w", err)
+		}
+
+		amount, err := decimal.NewFromString(amountStr)
+		if err != nil {
+			return fmt.Errorf("invalid amount: %w", err)
+		}
+
+		sim, err := budgetService.Simulate(ctx, catID, amount, models.BudgetPeriod(period), months)
+		if err != nil {
+			return err
+		}
+
+		if len(sim.Periods) == 0 {
+			fmt.Fprintf(stdout, "No completed %s period in the last %d months. Try a larger --months.\n", period, months)
+			return nil
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render(fmt.Sprintf("\n🧪 Budget Simulation: %s %s\n", formatMoney(sim.Amount), sim.Period)))
+
+		table := tablewriter.NewTable(stdout)
+		table.Header("Period", "Spent", "Difference", "Result")
 
+		for _, p := range sim.Periods {
+			label := p.Start.Format("2006-01-02") + " – " + p.End.Format("2006-01-02")
+			if sim.Period == models.BudgetPeriodMonthly {
+				label = p.Start.Format("Jan 2006")
+			}
+
+			result := successStyle.Render("✅ under")
+			if p.IsOver {
+				result = errorStyle.Render("⚠️ OVER")
+			}
+
+			table.Append([]string{
+				label,
+				formatMoney(p.Spent),
+				formatMoney(p.Difference),
+				result,
+			})
+		}
+
+		table.Render()
+
+		fmt.Fprintf(stdout, "Over budget in %d of %d periods | average: %s | max: %s\n",
+			sim.OverCount, len(sim.Periods), formatMoney(sim.AverageSpent), formatMoney(sim.MaxSpent))
 		return nil
 	},
 }
@@ -136,6 +316,7 @@ var budgetDeleteCmd = &cobra.Command{
 			application.Repos.Budget,
 			application.Repos.Transaction,
 		)
+		budgetService.SetMonthStartDay(application.Config.App.MonthStartDay)
 
 		id, err := parseUUID(args[0])
 		if err != nil {
@@ -146,7 +327,7 @@ var budgetDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Println(successStyle.Render("✅ Budget deleted!"))
+		fmt.Fprintln(stdout, successStyle.Render("✅ Budget deleted!"))
 		return nil
 	},
 }
@@ -157,37 +338,41 @@ func init() {
 
 	// budget add
 	budgetAddCmd.Flags().StringP("category", "c", "", "Category ID (required)")
-	budgetAddCmd.Flags().StringP("amount", "a", "", "Budget amount (required)")
+	budgetAddCmd.Flags().StringP("amount", "a", "", "Budget amount (prompted with the category average if omitted)")
 	budgetAddCmd.Flags().StringP("period", "p", "monthly", "Budget period: weekly, monthly, yearly")
+	budgetAddCmd.Flags().Float64("warn-at", models.DefaultWarnThreshold, "Warn when this percentage of the budget is spent (0-100)")
 	_ = budgetAddCmd.MarkFlagRequired("category")
-	_ = budgetAddCmd.MarkFlagRequired("amount")
+	_ = budgetAddCmd.RegisterFlagCompletionFunc("category", completeCategoryIDs)
+	_ = budgetAddCmd.RegisterFlagCompletionFunc("period",
+		cobra.FixedCompletions([]string{"weekly", "monthly", "yearly"}, cobra.ShellCompDirectiveNoFileComp))
 	budgetCmd.AddCommand(budgetAddCmd)
 
+	// budget update
+	budgetUpdateCmd.Flags().StringP("amount", "a", "", "New budget amount")
+	budgetUpdateCmd.Flags().Float64("warn-at", models.DefaultWarnThreshold, "Warn when this percentage of the budget is spent (0-100)")
+	budgetCmd.AddCommand(budgetUpdateCmd)
+
+	// budget simulate
+	budgetSimulateCmd.Flags().StringP("category", "c", "", "Category ID (required)")
+	budgetSimulateCmd.Flags().StringP("amount", "a", "", "Proposed budget amount (required)")
+	budgetSimulateCmd.Flags().StringP("period", "p", "monthly", "Budget period: weekly, monthly, yearly")
+	budgetSimulateCmd.Flags().Int("months", 6, "How many months of history to simulate")
+	_ = budgetSimulateCmd.MarkFlagRequired("category")
+	_ = budgetSimulateCmd.MarkFlagRequired("amount")
+	_ = budgetSimulateCmd.RegisterFlagCompletionFunc("category", completeCategoryIDs)
+	_ = budgetSimulateCmd.RegisterFlagCompletionFunc("period",
+		cobra.FixedCompletions([]string{"weekly", "monthly", "yearly"}, cobra.ShellCompDirectiveNoFileComp))
+	budgetCmd.AddCommand(budgetSimulateCmd)
+
 	// budget delete
 	budgetCmd.AddCommand(budgetDeleteCmd)
 }
 
-// renderProgressBar membuat visual progress bar.
-func renderProgressBar(progress float64, width int) string {
-	filled := int(progress / 100.0 * float64(width))
-	if filled > width {
-		filled = width
-	}
-
-	bar := ""
-	for i := 0; i < width; i++ {
-		if i < filled {
-			if progress > 100 {
-				bar += "🟥"
-			} else if progress > 80 {
-				bar += "🟨"
-			} else {
-				bar += "🟩"
-			}
-		} else {
-			bar += "⬜"
-		}
+// roundBudgetAmount membulatkan rata-rata pengeluaran menjadi angka budget
+// yang enak dibaca (ke atas, kelipatan 1000 untuk nilai >= 1000).
+func roundBudgetAmount(avg decimal.Decimal) decimal.Decimal {
+	if avg.LessThan(decimal.NewFromInt(1000)) {
+		return avg.Ceil()
 	}
-
-	return fmt.Sprintf("%s %.0f%%", bar, progress)
+	return avg.Shift(-3).Ceil().Shift(3)
 }
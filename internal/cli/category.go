@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// categoryCmd adalah parent command untuk category operations.
+var categoryCmd = &cobra.Command{
+	Use:         "category",
+	Aliases:     []string{"cat", "c"},
+	Short:       "🏷️  Manage categories",
+	Annotations: requiresDB,
+	Long:        "List and delete income and expense categories.",
+}
+
+// categoryListCmd menampilkan semua kategori.
+var categoryListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls", "l"},
+	Short:   "List all categories",
+	Long: `List all categories, with sub-categories under their parent.
+
+Use --with-stats to add the average monthly amount over the last 3 full
+months, last month's amount and the transaction count per category.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		categoryService := newCategoryService()
+
+		withStats, _ := cmd.Flags().GetBool("with-stats")
+
+		categories, err := categoryService.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(categories) == 0 {
+			fmt.Fprintln(stdout, "No categories found.")
+			return nil
+		}
+
+		var stats map[uuid.UUID]*service.CategoryStats
+		if withStats {
+			txService := service.NewTransactionService(application.Repos.Transaction, application.Repos.Wallet, application.Repos.Category, application.TxManager)
+			stats, err = txService.GetCategoryStats(ctx, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render("\n🏷️  Categories\n"))
+
+		table := tablewriter.NewTable(stdout)
+		header := []string{"ID", "Category", "Type"}
+		if withStats {
+			header = append(header,
+				fmt.Sprintf("Avg/Month (%dM)", service.CategoryStatsMonths),
+				"Last Month",
+				fmt.Sprintf("Tx (%dM)", service.CategoryStatsMonths),
+			)
+		}
+		table.Header(header)
+
+		for _, c := range orderCategories(categories) {
+			name := models.DisplayIcon(c.Icon) + " " + c.Name
+			if c.IsSubCategory() {
+				name = "  └ " + name
+			}
+
+			row := []string{c.ID.String(), name, string(c.Type)}
+			if withStats {
+				if s, ok := stats[c.ID]; ok {
+					row = append(row, formatMoney(s.AverageMonthly), formatMoney(s.LastMonth), strconv.Itoa(s.Count))
+				} else {
+					row = append(row, "-", "-", "0")
+				}
+			}
+			table.Append(row)
+		}
+
+		table.Render()
+		return nil
+	},
+}
+
+// categoryDeleteCmd menghapus kategori yang tidak dipakai, atau
+// memindahkan transaksi dan budget-nya dulu dengan --reassign.
+var categoryDeleteCmd = &cobra.Command{
+	Use:   "delete [category-id]",
+	Short: "Delete a category",
+	Long: `Delete a category.
+
+A category that is still used by transactions or budgets is not deleted:
+deleting it would remove its budgets and leave the transactions
+uncategorized. Use --reassign to move them to another category of the
+same type first.`,
+	Example: `  wallet category delete <category-id>
+  wallet category delete <category-id> --reassign <other-category-id>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		categoryService := newCategoryService()
+
+		id, err := parseUUID(args[0])
+		if err != nil {
+			return err
+		}
+
+		reassign, _ := cmd.Flags().GetString("reassign")
+		if reassign == "" {
+			if err := categoryService.Delete(ctx, id); err != nil {
+				return err
+			}
+			fmt.Fprintln(stdout, successStyle.Render("✅ Category deleted successfully!"))
+			return nil
+		}
+
+		to, err := parseUUID(reassign)
+		if err != nil {
+			return fmt.Errorf("invalid reassign category ID: %w", err)
+		}
+
+		usage, err := categoryService.DeleteAndReassign(ctx, id, to)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Category deleted successfully!"))
+		fmt.Fprintf(stdout, "   Moved %d transaction(s) and %d budget(s)\n", usage.Transactions, usage.Budgets)
+		return nil
+	},
+}
+
+// newCategoryService membuat CategoryService dari repositories aplikasi.
+func newCategoryService() *service.CategoryService {
+	return service.NewCategoryService(
+		application.Repos.Category,
+		application.Repos.Transaction,
+		application.Repos.Budget,
+		application.TxManager,
+	)
+}
+
+// orderCategories mengurutkan kategori sehingga sub-kategori tampil
+// tepat di bawah parent-nya. Urutan dari repository (type, sort_order)
+// dipertahankan di setiap level.
+func orderCategories(categories []*models.Category) []*models.Category {
+	children := make(map[uuid.UUID][]*models.Category)
+	known := make(map[uuid.UUID]bool, len(categories))
+	for _, c := range categories {
+		known[c.ID] = true
+	}
+
+	var roots []*models.Category
+	for _, c := range categories {
+		if c.IsSubCategory() && known[*c.ParentID] {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+			continue
+		}
+		roots = append(roots, c)
+	}
+
+	ordered := make([]*models.Category, 0, len(categories))
+	for _, root := range roots {
+		ordered = append(ordered, root)
+		ordered = append(ordered, children[root.ID]...)
+	}
+	return ordered
+}
+
+func init() {
+	// category list
+	categoryListCmd.Flags().Bool("with-stats", false, "Show 3-month average, last month and transaction count")
+	categoryCmd.AddCommand(categoryListCmd)
+
+	// category delete
+	categoryDeleteCmd.Flags().String("reassign", "", "Move transactions and budgets to this category before deleting")
+	categoryDeleteCmd.ValidArgsFunction = completeFirstArg(completeCategoryIDs)
+	_ = categoryDeleteCmd.RegisterFlagCompletionFunc("reassign", completeCategoryIDs)
+	categoryCmd.AddCommand(categoryDeleteCmd)
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// compareCmd membandingkan spending dua periode di terminal.
+var compareCmd = &cobra.Command{
+	Use:         "compare",
+	Short:       "📊 Compare spending between two periods",
+	Annotations: requiresDB,
+	Long: `Compare income, expense, and spending per category between two periods.
+
+A period is a month (YYYY-MM), a date range (YYYY-MM-DD..YYYY-MM-DD),
+this-month, last-month, this-year, last-year, ytd, last-ytd (the same
+stretch of the previous year), or a fiscal year such as FY2025. Months follow
+app.month_start_day and years follow app.fiscal_year_start_month. Categories are sorted by the largest change;
+(new) and (gone) mark categories with spending in only one period.
+Balance adjustments are not counted.
+
+Use 'wallet export compare' for the same comparison as an Excel workbook.`,
+	Example: `  wallet compare
+  wallet compare --a 2025-12 --b 2026-01
+  wallet compare --a last-ytd --b ytd          # this year so far vs last year
+  wallet compare --a FY2024 --b FY2025
+  wallet compare --a 2025-01-01..2025-03-31 --b 2025-04-01..2025-06-30`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		aStr, _ := cmd.Flags().GetString("a")
+		bStr, _ := cmd.Flags().GetString("b")
+
+		periodA, err := parsePeriod(aStr)
+		if err != nil {
+			return fmt.Errorf("invalid --a: %w", err)
+		}
+		periodB, err := parsePeriod(bStr)
+		if err != nil {
+			return fmt.Errorf("invalid --b: %w", err)
+		}
+
+		txService := service.NewTransactionService(application.Repos.Transaction, application.Repos.Wallet, application.Repos.Category, application.TxManager)
+		comparisonService := service.NewComparisonService(txService)
+
+		result, err := comparisonService.Compare(ctx, periodA, periodB)
+		if err != nil {
+			return err
+		}
+
+		now, fiscalStart := time.Now(), fiscalYearStartMonth()
+		fmt.Fprintln(stdout, titleStyle.Render(fmt.Sprintf("\n📊 %s vs %s\n",
+			periodTitle(aStr, now, fiscalStart), periodTitle(bStr, now, fiscalStart))))
+		fmt.Fprintf(stdout, "   A: %s – %s\n", formatDate(*periodA.StartDate), formatDate(*periodA.EndDate))
+		fmt.Fprintf(stdout, "   B: %s – %s\n\n", formatDate(*periodB.StartDate), formatDate(*periodB.EndDate))
+
+		if len(result.Categories) == 0 {
+			fmt.Fprintln(stdout, "No spending in either period.")
+		} else {
+			table := tablewriter.NewTable(stdout)
+			table.Header("Category", "A", "B", "Change", "%")
+			for _, c := range result.Categories {
+				table.Append(append([]string{c.Name}, changeCells(c.AmountChange, false, c.IsNew(), c.IsGone())...))
+			}
+			table.Render()
+		}
+
+		fmt.Fprintln(stdout)
+		table := tablewriter.NewTable(stdout)
+		table.Header("", "A", "B", "Change", "%")
+		table.Append(append([]string{"Income"}, changeCells(result.Income, true, false, false)...))
+		table.Append(append([]string{"Expense"}, changeCells(result.Expense, false, false, false)...))
+		table.Append(append([]string{"Net"}, changeCells(result.Net, true, false, false)...))
+		table.Render()
+
+		return nil
+	},
+}
+
+// changeCells memformat kolom A, B, Change, dan % untuk satu baris
+// compare. Panah ↑/↓ diwarnai hijau jika perubahannya baik: kenaikan
+// untuk income/net (higherIsBetter), penurunan untuk spending.
+func changeCells(c service.AmountChange, higherIsBetter, isNew, isGone bool) []string {
+	delta := c.Delta()
+
+	change := "-"
+	if !delta.IsZero() {
+		arrow := "↑ "
+		if delta.IsNegative() {
+			arrow = "↓ "
+		}
+		style := expenseStyle
+		if delta.IsPositive() == higherIsBetter {
+			style = incomeStyle
+		}
+		change = style.Render(arrow + formatMoney(delta.Abs()))
+	}
+
+	var pct string
+	switch {
+	case isNew:
+		pct = "(new)"
+	case isGone:
+		pct = "(gone)"
+	default:
+		pct = "-"
+		if p, ok := c.Percent(); ok {
+			pct = p.StringFixed(1) + "%"
+			if p.IsPositive() {
+				pct = "+" + pct
+			}
+		}
+	}
+
+	return []string{formatMoney(c.A), formatMoney(c.B), change, pct}
+}
+
+func init() {
+	compareCmd.Flags().String("a", "last-month", "First period: YYYY-MM, YYYY-MM-DD..YYYY-MM-DD, this-month, last-month, ytd, last-ytd or FY<year>")
+	compareCmd.Flags().String("b", "this-month", "Second period: YYYY-MM, YYYY-MM-DD..YYYY-MM-DD, this-month, last-month, ytd, last-ytd or FY<year>")
+
+	rootCmd.AddCommand(compareCmd)
+}
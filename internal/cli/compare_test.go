@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	out := string(runPiped(t, "compare", "--a", "this-month", "--b", "this-month"))
+	for _, want := range []string{"this-month vs this-month", "Income", "Expense", "Net"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "(new)") || strings.Contains(out, "(gone)") || strings.Contains(out, "↑") {
+		t.Errorf("comparing a period with itself should show no changes:\n%s", out)
+	}
+
+	t.Cleanup(func() {
+		_ = compareCmd.Flags().Set("a", "last-month")
+		_ = compareCmd.Flags().Set("b", "this-month")
+	})
+	last, err := parsePeriod("last-month")
+	if err != nil {
+		t.Fatalf("parsePeriod(last-month) error = %v", err)
+	}
+	now := time.Now()
+	if want := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.Local); !last.StartDate.Equal(want) {
+		t.Errorf("last-month starts %v, want %v", last.StartDate, want)
+	}
+	if _, err := parsePeriod("next-month"); err == nil {
+		t.Error("parsePeriod(next-month) error = nil, want error")
+	}
+}
+
+func TestParsePeriodAt_FiscalYear(t *testing.T) {
+	now := time.Date(2026, time.January, 15, 10, 0, 0, 0, time.Local)
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		period    string
+		wantStart time.Time
+		wantNext  time.Time
+	}{
+		{"this-year", date(2025, time.April, 1), date(2026, time.April, 1)},
+		{"last-year", date(2024, time.April, 1), date(2025, time.April, 1)},
+		{"FY2023", date(2023, time.April, 1), date(2024, time.April, 1)},
+		{"ytd", date(2025, time.April, 1), date(2026, time.January, 16)},
+		{"last-ytd", date(2024, time.April, 1), date(2025, time.January, 16)},
+	}
+	for _, tt := range tests {
+		f, err := parsePeriodAt(tt.period, now, 4, 1)
+		if err != nil {
+			t.Fatalf("parsePeriodAt(%s) error = %v", tt.period, err)
+		}
+		if !f.StartDate.Equal(tt.wantStart) {
+			t.Errorf("%s starts %v, want %v", tt.period, f.StartDate, tt.wantStart)
+		}
+		if want := tt.wantNext.Add(-time.Nanosecond); !f.EndDate.Equal(want) {
+			t.Errorf("%s ends %v, want %v", tt.period, f.EndDate, want)
+		}
+	}
+
+	if got, want := periodTitle("this-year", now, 4), "FY2025 (Apr 2025–Mar 2026)"; got != want {
+		t.Errorf("periodTitle(this-year) = %q, want %q", got, want)
+	}
+	if _, err := parsePeriodAt("FY25", now, 4, 1); err == nil {
+		t.Error("parsePeriodAt(FY25) error = nil, want error")
+	}
+}
+
+func TestParsePeriodAt_MonthStartDay(t *testing.T) {
+	// 26 Dec 2025 dengan anchor 25 sudah masuk periode January 2026
+	now := time.Date(2025, time.December, 26, 10, 0, 0, 0, time.Local)
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		period    string
+		wantStart time.Time
+		wantNext  time.Time
+	}{
+		{"this-month", date(2025, time.December, 25), date(2026, time.January, 25)},
+		{"last-month", date(2025, time.November, 25), date(2025, time.December, 25)},
+		{"2026-03", date(2026, time.February, 25), date(2026, time.March, 25)},
+		{"2025-12-01..2025-12-31", date(2025, time.December, 1), date(2026, time.January, 1)},
+	}
+	for _, tt := range tests {
+		f, err := parsePeriodAt(tt.period, now, 1, 25)
+		if err != nil {
+			t.Fatalf("parsePeriodAt(%s) error = %v", tt.period, err)
+		}
+		if !f.StartDate.Equal(tt.wantStart) {
+			t.Errorf("%s starts %v, want %v", tt.period, f.StartDate, tt.wantStart)
+		}
+		if want := tt.wantNext.Add(-time.Nanosecond); !f.EndDate.Equal(want) {
+			t.Errorf("%s ends %v, want %v", tt.period, f.EndDate, want)
+		}
+	}
+}
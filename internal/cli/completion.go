@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// completionCmd meng-generate script shell completion.
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "⌨️  Generate shell completion script",
+	Long: `Generate a shell completion script for wallet.
+
+Completion covers subcommands, flags, and wallet/goal/category IDs
+(looked up from the database while you type).
+
+Bash:
+  source <(wallet completion bash)
+  # permanent (Linux):
+  wallet completion bash > /etc/bash_completion.d/wallet
+
+Zsh:
+  wallet completion zsh > "${fpath[1]}/_wallet"
+
+Fish:
+  wallet completion fish > ~/.config/fish/completions/wallet.fish
+
+PowerShell:
+  wallet completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		return fmt.Errorf("unsupported shell: %s", args[0])
+	},
+}
+
+// completeWalletIDs melengkapi ID wallet aktif, dengan nama wallet sebagai deskripsi.
+func completeWalletIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := loadApp(cmd); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	isActive := true
+	wallets, err := application.Repos.Wallet.List(cmd.Context(), repository.WalletFilter{IsActive: &isActive})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(wallets))
+	for _, w := range wallets {
+		completions = append(completions, fmt.Sprintf("%s\t%s %s (%s)", w.ID, models.DisplayIcon(w.Icon), w.Name, w.Type))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeGoalIDs melengkapi ID goal aktif.
+func completeGoalIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := loadApp(cmd); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	status := models.GoalStatusActive
+	goals, err := application.Repos.Goal.List(cmd.Context(), repository.GoalFilter{Status: &status})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(goals))
+	for _, g := range goals {
+		completions = append(completions, fmt.Sprintf("%s\t%s %s", g.ID, models.DisplayIcon(g.Icon), g.Name))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCategoryIDs melengkapi ID kategori.
+func completeCategoryIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := loadApp(cmd); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	categories, err := application.Repos.Category.List(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(categories))
+	for _, c := range categories {
+		completions = append(completions, fmt.Sprintf("%s\t%s %s (%s)", c.ID, models.DisplayIcon(c.Icon), c.Name, c.Type))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFirstArg membungkus completion function supaya hanya dipakai
+// untuk argumen positional pertama (misalnya `wallet delete [wallet-id]`).
+func completeFirstArg(
+	fn func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective),
+) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fn(cmd, args, toComplete)
+	}
+}
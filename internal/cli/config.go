@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/config"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
+)
+
+// configSetters berisi key yang boleh di-set lewat `wallet config set`,
+// beserta fungsi normalisasi/validasi value-nya.
+var configSetters = map[string]func(value string) (string, error){
+	"app.default_wallet": func(value string) (string, error) {
+		if value == "" {
+			return "", nil // kosong = hapus default
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid wallet ID: %w", err)
+		}
+		return id.String(), nil
+	},
+	"app.month_start_day": func(value string) (string, error) {
+		day, err := strconv.Atoi(value)
+		if err != nil || day < 1 || day > utils.MaxMonthStartDay {
+			return "", fmt.Errorf("invalid value %q (use a day between 1 and %d)", value, utils.MaxMonthStartDay)
+		}
+		return strconv.Itoa(day), nil
+	},
+	"app.fiscal_year_start_month": func(value string) (string, error) {
+		month, err := strconv.Atoi(value)
+		if err != nil || month < 1 || month > 12 {
+			return "", fmt.Errorf("invalid value %q (use a month between 1 and 12)", value)
+		}
+		return strconv.Itoa(month), nil
+	},
+	"app.max_transaction_amount": func(value string) (string, error) {
+		limit, err := decimal.NewFromString(strings.TrimSpace(value))
+		if err != nil || limit.IsNegative() {
+			return "", fmt.Errorf("invalid value %q (use an amount, or 0 for no limit)", value)
+		}
+		return limit.String(), nil
+	},
+	"validation.reject_before_wallet_date": parseBoolValue,
+	"validation.strict_validation":         parseBoolValue,
+}
+
+// parseBoolValue menormalisasi value true/false untuk configSetters.
+func parseBoolValue(value string) (string, error) {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid value %q (use true or false)", value)
+	}
+	return strconv.FormatBool(enabled), nil
+}
+
+// configCmd adalah parent command untuk konfigurasi.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "⚙️ Manage configuration",
+}
+
+// configSetCmd menyimpan satu key ke config file.
+var configSetCmd = &cobra.Command{
+	Use:   "set [key] [value]",
+	Short: "Save a config value to config.yaml",
+	Long: `Save a config value to config.yaml in the config folder.
+
+Supported keys:
+  app.default_wallet   Wallet used by 'tx add' when --wallet is omitted
+                       (pass "" to clear it)
+  app.month_start_day  Day of the month a monthly period starts, 1-28
+                       (e.g. 25 for a salary cycle, default 1)
+  app.fiscal_year_start_month
+                       Month (1-12) the fiscal year starts, for yearly and
+                       year-to-date summaries (e.g. 4 for April, default 1)
+  app.max_transaction_amount
+                       Reject transactions above this amount, in the
+                       wallet's currency (0 = no limit, default 100000000)
+  validation.reject_before_wallet_date
+                       Reject transactions dated before the wallet was
+                       created or opened (true/false, default false)
+
+Environment variables (WT_APP_DEFAULT_WALLET, ...) still take precedence.`,
+	Example: `  wallet config set app.default_wallet 550e8400-e29b-41d4-a716-446655440000
+  wallet config set app.default_wallet ""
+  wallet config set app.month_start_day 25
+  wallet config set app.fiscal_year_start_month 4
+  wallet config set app.max_transaction_amount 5000000
+  wallet config set validation.reject_before_wallet_date true`,
+	Args: cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return configKeys(), cobra.ShellCompDirectiveNoFileComp
+		case 1:
+			if args[0] == "app.default_wallet" {
+				return completeWalletIDs(cmd, nil, toComplete)
+			}
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := strings.ToLower(strings.TrimSpace(args[0]))
+		normalize, ok := configSetters[key]
+		if !ok {
+			return fmt.Errorf("unknown config key %q (supported: %s)", args[0], strings.Join(configKeys(), ", "))
+		}
+
+		value, err := normalize(strings.TrimSpace(args[1]))
+		if err != nil {
+			return err
+		}
+
+		if err := config.Set(configPath, key, value); err != nil {
+			return err
+		}
+
+		if value == "" {
+			fmt.Fprintln(stdout, successStyle.Render(fmt.Sprintf("✅ Cleared %s", key)))
+		} else {
+			fmt.Fprintln(stdout, successStyle.Render(fmt.Sprintf("✅ Set %s = %s", key, value)))
+		}
+		fmt.Fprintf(stdout, "   📄 %s\n", config.FilePath(configPath))
+		return nil
+	},
+}
+
+// configKeys mengembalikan key yang didukung `config set`, terurut.
+func configKeys() []string {
+	keys := make([]string, 0, len(configSetters))
+	for key := range configSetters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	configCmd.AddCommand(configSetCmd)
+}
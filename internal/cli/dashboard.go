@@ -1,30 +1,119 @@
 package cli
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
+	"github.com/Adityanrhm/wallet-twin/internal/database"
 	"github.com/Adityanrhm/wallet-twin/internal/tui"
+	"github.com/Adityanrhm/wallet-twin/migrations"
 )
 
 // dashboardCmd membuka TUI dashboard.
 var dashboardCmd = &cobra.Command{
-	Use:     "dashboard",
-	Aliases: []string{"dash", "d"},
-	Short:   "🖥️ Open interactive TUI dashboard",
-	Long:    "Launch the interactive terminal UI dashboard with real-time updates.",
+	Use:         "dashboard",
+	Aliases:     []string{"tui", "dash", "d"},
+	Short:       "🖥️ Open interactive TUI dashboard",
+	Annotations: requiresDB,
+	Long: `Launch the interactive terminal UI dashboard with real-time updates.
+Running 'wallet' without a subcommand in a terminal does the same.
+
+Use --demo to explore the dashboard with sample data, without a database.
+Changes made in demo mode are not saved.
+
+Press p to hide every amount (e.g. while screen sharing); the choice is
+remembered for the next start. --private starts with amounts hidden.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Create dashboard model
-		model := tui.NewDashboard(application)
+		private, _ := cmd.Flags().GetBool("private")
+		return runDashboard(cmd.Context(), private)
+	},
+}
 
-		// Create and run Bubble Tea program
-		p := tea.NewProgram(model, tea.WithAltScreen())
+// runDashboard menjalankan TUI dashboard dengan application yang sudah
+// di-initialize. Jika private, semua nominal disembunyikan sejak awal.
+func runDashboard(ctx context.Context, private bool) error {
+	// Create dashboard model
+	model := tui.NewDashboard(ctx, application, tui.Options{StateDir: configPath, Private: private})
 
-		if _, err := p.Run(); err != nil {
-			return err
-		}
+	// Create and run Bubble Tea program
+	p := tea.NewProgram(model, tea.WithAltScreen())
 
+	if _, err := p.Run(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// runRoot dijalankan untuk `wallet` tanpa subcommand.
+//
+// Di terminal interaktif dashboard dibuka; jika stdin/stdout bukan
+// terminal (script, pipe) help ditampilkan. Database yang belum bisa
+// dipakai (koneksi gagal, schema belum di-migrate atau tertinggal)
+// menampilkan langkah setup, bukan error.
+func runRoot(cmd *cobra.Command, args []string) error {
+	if !isInteractive() || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return cmd.Help()
+	}
+
+	if err := loadApp(cmd); err != nil {
+		printSetupHint(err.Error())
 		return nil
-	},
+	}
+	if reason := checkSchema(cmd.Context()); reason != "" {
+		printSetupHint(reason)
+		return nil
+	}
+
+	return runDashboard(cmd.Context(), false)
 }
 
+// checkSchema membandingkan versi schema database dengan migration
+// terbaru di build ini. Return alasan dashboard belum bisa dibuka,
+// atau "" jika schema up to date.
+func checkSchema(ctx context.Context) string {
+	// Mode demo tidak punya database
+	if application.DB == nil {
+		return ""
+	}
+
+	version, dirty, err := application.DB.SchemaVersion(ctx)
+	switch {
+	case errors.Is(err, database.ErrSchemaNotInitialized):
+		return "The database has no tables yet."
+	case err != nil:
+		return err.Error()
+	case dirty:
+		return fmt.Sprintf("Migration %06d did not finish (the database is dirty).", version)
+	}
+
+	files, err := database.ListEmbeddedMigrations(migrations.FS)
+	if err != nil || len(files) == 0 {
+		return ""
+	}
+	if latest := files[len(files)-1].Version; version < latest {
+		return fmt.Sprintf("The database schema is at version %d, this build needs %d.", version, latest)
+	}
+	return ""
+}
+
+// printSetupHint menampilkan langkah setup saat dashboard belum bisa dibuka.
+func printSetupHint(reason string) {
+	fmt.Fprintln(stdout, warningStyle.Render("⚠️  Wallet Twin is not ready yet"))
+	fmt.Fprintf(stdout, "   %s\n\n", reason)
+	fmt.Fprintln(stdout, "   1. Set the database connection in config/config.yaml (or WT_DATABASE_* variables)")
+	fmt.Fprintln(stdout, "   2. Create or upgrade the schema: wallet init")
+	fmt.Fprintln(stdout, "   3. Run 'wallet' again")
+	fmt.Fprintln(stdout, "\n   Just looking around? Try: wallet dashboard --demo")
+}
+
+func init() {
+	dashboardCmd.Flags().Bool("demo", false, "Run with in-memory sample data (no database required)")
+	dashboardCmd.Flags().Bool("private", false, "Start with all amounts hidden (toggle with p)")
+}
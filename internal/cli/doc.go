@@ -7,6 +7,7 @@
 //
 //	wallet                    # root command - tampilkan help atau dashboard
 //	├── init                  # inisialisasi database
+//	├── db                    # migrate up/down/version/reset, ping, stats
 //	├── wallet                # sub-command untuk manage wallets
 //	│   ├── add              # tambah wallet baru
 //	│   ├── list             # list semua wallets
@@ -37,6 +38,10 @@
 // - Huh: Interactive forms dan prompts
 // - Lipgloss: Styling dan colors
 // - Table: Pretty table output
+//
+// Semua output command ditulis lewat writer stdout dan style di output.go.
+// Jika stdout bukan terminal, NO_COLOR di-set, atau flag --no-color dipakai,
+// output dirender tanpa ANSI escape code dan tanpa emoji dekoratif.
 package cli
 
 // TODO: Add command implementations
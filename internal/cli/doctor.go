@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// doctorSampleRows adalah jumlah maksimal contoh row per check.
+const doctorSampleRows = 5
+
+// doctorCmd memeriksa integritas data.
+var doctorCmd = &cobra.Command{
+	Use:         "doctor",
+	Short:       "🩺 Check data integrity",
+	Annotations: requiresDB,
+	Long: `Check the database for inconsistent data that silently skews totals:
+
+  - goal contributions whose goal no longer exists
+  - transactions, recurring and budgets pointing at a deleted category
+  - transactions whose wallet no longer exists
+  - wallets holding imports converted from another currency
+  - goals whose current amount differs from the sum of contributions
+
+Use --fix to apply the safe repairs (clear missing category references,
+recompute goal amounts from contributions).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		doctorService := service.NewDoctorService(application.Repos.Integrity)
+		fix, _ := cmd.Flags().GetBool("fix")
+
+		report, err := doctorService.Check(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render("\n🩺 Data Integrity Report\n"))
+		printDoctorReport(report)
+
+		if report.TotalIssues() == 0 {
+			fmt.Fprintln(stdout, successStyle.Render("\n✅ No problems found"))
+			return nil
+		}
+
+		if !fix {
+			fmt.Fprintf(stdout, "\n%d problem(s) found. Run 'wallet doctor --fix' to repair the fixable ones.\n", report.TotalIssues())
+			return nil
+		}
+
+		result, err := doctorService.Fix(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("\n🔧 Fixes applied"))
+		fmt.Fprintf(stdout, "   Category references cleared: %d\n", result.CategoryRefsCleared)
+		fmt.Fprintf(stdout, "   Goal amounts recomputed:     %d\n", result.GoalsRecomputed)
+
+		// Check ulang supaya user tahu apa yang masih perlu ditangani manual
+		report, err = doctorService.Check(ctx)
+		if err != nil {
+			return err
+		}
+		if remaining := report.TotalIssues(); remaining > 0 {
+			fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf("\n⚠️  %d problem(s) need manual attention", remaining)))
+		}
+
+		return nil
+	},
+}
+
+// printDoctorReport menampilkan hasil setiap check beserta contoh row.
+func printDoctorReport(report *service.DoctorReport) {
+	for _, check := range report.Checks {
+		if check.Count() == 0 {
+			fmt.Fprintf(stdout, "%s %s\n", successStyle.Render("✔"), check.Name)
+			continue
+		}
+
+		label := fmt.Sprintf("✘ %s: %d", check.Name, check.Count())
+		if check.Fixable {
+			label += " (fixable)"
+		}
+		fmt.Fprintln(stdout, errorStyle.Render(label))
+		fmt.Fprintf(stdout, "  %s\n", check.Description)
+
+		table := tablewriter.NewTable(stdout)
+		if len(check.Mismatches) > 0 {
+			table.Header("Goal", "Current", "Contributions")
+			for i, m := range check.Mismatches {
+				if i >= doctorSampleRows {
+					break
+				}
+				table.Append([]string{m.GoalName, formatMoney(m.CurrentAmount), formatMoney(m.ContributionSum)})
+			}
+		} else {
+			table.Header("Table", "ID", "Missing ID", "Detail")
+			for i, issue := range check.Issues {
+				if i >= doctorSampleRows {
+					break
+				}
+				missingID := "-"
+				if issue.MissingID != uuid.Nil {
+					missingID = issue.MissingID.String()
+				}
+				table.Append([]string{issue.Table, issue.ID.String(), missingID, truncate(issue.Detail, 30)})
+			}
+		}
+		table.Render()
+
+		if check.Count() > doctorSampleRows {
+			fmt.Fprintf(stdout, "  ... and %d more\n", check.Count()-doctorSampleRows)
+		}
+	}
+}
+
+func init() {
+	doctorCmd.Flags().Bool("fix", false, "Apply safe repairs")
+}
@@ -1,32 +1,61 @@
 package cli
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 
 	"github.com/Adityanrhm/wallet-twin/internal/export"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
-	"github.com/Adityanrhm/wallet-twin/internal/repository/postgres"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
 // exportCmd adalah parent command untuk export operations.
 var exportCmd = &cobra.Command{
-	Use:   "export",
-	Short: "📤 Export data to CSV/JSON/Excel/PDF",
-	Long:  "Export your financial data to various formats.",
+	Use:         "export",
+	Short:       "📤 Export data to CSV/JSON/Excel/PDF",
+	Annotations: requiresDB,
+	Long:        "Export your financial data to various formats.",
 }
 
 // exportAllCmd exports semua data ke JSON.
 var exportAllCmd = &cobra.Command{
 	Use:   "all",
 	Short: "Export all data to JSON (full backup)",
+	Long: `Export all data to a JSON backup, restored with 'wallet import backup'.
+
+With --since, only wallets, transactions and goals created or updated after
+that time are exported (categories are always included), together with a
+manifest recording the cutoff. Chain backups by passing the "until" time
+printed by the previous backup as the next --since, and restore the full
+backup first, then each incremental in order. Deleted rows are not
+recorded in incrementals; take a full backup now and then.`,
+	Example: `  wallet export all -o full.json
+  wallet export all --since 2026-01-31T02:00:00+07:00 -o nightly.json
+  wallet export all --since 2026-01-31`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
+		var since time.Time
+		if s, _ := cmd.Flags().GetString("since"); s != "" {
+			parsed, err := parseSince(s)
+			if err != nil {
+				return err
+			}
+			since = parsed
+		}
+
 		exporter := export.NewExporter(
 			application.Repos.Wallet,
 			application.Repos.Transaction,
@@ -35,22 +64,56 @@ var exportAllCmd = &cobra.Command{
 		)
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "" {
-			output = fmt.Sprintf("wallet-twin-backup-%s.json", time.Now().Format("20060102-150405"))
+		toStdout, err := exportToStdout(cmd, "json")
+		if err != nil {
+			return err
+		}
+		if output == "" && !toStdout {
+			kind := "backup"
+			if !since.IsZero() {
+				kind = "incremental"
+			}
+			output = fmt.Sprintf("wallet-twin-%s-%s.json", kind, time.Now().Format("20060102-150405"))
 		}
 
-		if err := exporter.ToJSON(ctx, output); err != nil {
+		until := time.Now()
+		if err := writeExport(output, toStdout, func(w io.Writer) error {
+			if since.IsZero() {
+				return exporter.ToJSONWriter(ctx, w)
+			}
+			return exporter.IncrementalToJSONWriter(ctx, w, since)
+		}); err != nil {
 			return err
 		}
+		if toStdout {
+			return nil
+		}
 
 		absPath, _ := filepath.Abs(output)
-		fmt.Println(successStyle.Render("✅ Export successful!"))
-		fmt.Printf("   📁 File: %s\n", absPath)
+		fmt.Fprintln(stdout, successStyle.Render("✅ Export successful!"))
+		fmt.Fprintf(stdout, "   📁 File: %s\n", absPath)
+		if !since.IsZero() {
+			fmt.Fprintf(stdout, "   🕒 Changes since: %s\n", since.Format(time.RFC3339))
+		}
+		fmt.Fprintf(stdout, "   ⏭️ Next incremental: --since %s\n", until.Format(time.RFC3339))
 
 		return nil
 	},
 }
 
+// parseSince mem-parse cutoff backup incremental: RFC 3339, atau tanggal
+// YYYY-MM-DD (tengah malam waktu lokal).
+func parseSince(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q (use RFC 3339, e.g. 2026-01-31T02:00:00+07:00, or YYYY-MM-DD)", s)
+	}
+	return t, nil
+}
+
 // exportTransactionsCmd exports transactions.
 var exportTransactionsCmd = &cobra.Command{
 	Use:     "transactions",
@@ -60,34 +123,70 @@ var exportTransactionsCmd = &cobra.Command{
 		ctx := cmd.Context()
 		format, _ := cmd.Flags().GetString("format")
 		output, _ := cmd.Flags().GetString("output")
+		clip, _ := cmd.Flags().GetBool("clip")
 
-		filter := repository.TransactionFilter{}
+		walletIDs, err := walletFilterIDs(cmd)
+		if err != nil {
+			return err
+		}
+		filter := repository.TransactionFilter{WalletIDs: walletIDs}
+
+		if clip {
+			if format != "markdown" && format != "md" {
+				return errors.New("--clip is only supported with --format markdown")
+			}
+
+			exporter := export.NewExporter(
+				application.Repos.Wallet,
+				application.Repos.Transaction,
+				application.Repos.Category,
+				application.Repos.Goal,
+			)
+			var buf bytes.Buffer
+			if err := exporter.TransactionsToMarkdownWriter(ctx, &buf, filter, application.Config.App.Currency); err != nil {
+				return err
+			}
+			copyOrPrint(buf.String())
+			return nil
+		}
+
+		toStdout, err := exportToStdout(cmd, format)
+		if err != nil {
+			return err
+		}
 
 		// Set default output filename based on format
-		if output == "" {
+		if output == "" && !toStdout {
 			ext := format
-			if format == "excel" {
+			switch format {
+			case "excel":
 				ext = "xlsx"
+			case "markdown":
+				ext = "md"
 			}
 			output = fmt.Sprintf("transactions-%s.%s", time.Now().Format("20060102"), ext)
 		}
 
-		var err error
+		var write func(w io.Writer) error
 		switch format {
 		case "pdf":
 			pdfExporter := export.NewPDFExporter(
 				application.Repos.Wallet,
 				application.Repos.Transaction,
+				application.Config.App.Currency,
 			)
-			err = pdfExporter.TransactionsToPDF(ctx, output, filter)
+			pdfExporter.SetLocale(application.Config.App.Locale)
+			write = func(w io.Writer) error { return pdfExporter.TransactionsToPDFWriter(ctx, w, filter) }
 
 		case "excel", "xlsx":
 			excelExporter := export.NewExcelExporter(
 				application.Repos.Wallet,
 				application.Repos.Transaction,
 				application.Repos.Category,
+				application.Config.App.Currency,
 			)
-			err = excelExporter.TransactionsToExcel(ctx, output, filter)
+			excelExporter.SetLocale(application.Config.App.Locale)
+			write = func(w io.Writer) error { return excelExporter.TransactionsToExcelWriter(ctx, w, filter) }
 
 		case "json":
 			exporter := export.NewExporter(
@@ -96,7 +195,18 @@ var exportTransactionsCmd = &cobra.Command{
 				application.Repos.Category,
 				application.Repos.Goal,
 			)
-			err = exporter.TransactionsToJSON(ctx, output, filter)
+			write = func(w io.Writer) error { return exporter.TransactionsToJSONWriter(ctx, w, filter) }
+
+		case "markdown", "md":
+			exporter := export.NewExporter(
+				application.Repos.Wallet,
+				application.Repos.Transaction,
+				application.Repos.Category,
+				application.Repos.Goal,
+			)
+			write = func(w io.Writer) error {
+				return exporter.TransactionsToMarkdownWriter(ctx, w, filter, application.Config.App.Currency)
+			}
 
 		default: // csv
 			exporter := export.NewExporter(
@@ -105,22 +215,247 @@ var exportTransactionsCmd = &cobra.Command{
 				application.Repos.Category,
 				application.Repos.Goal,
 			)
-			err = exporter.TransactionsToCSV(ctx, output, filter)
+			write = func(w io.Writer) error { return exporter.TransactionsToCSVWriter(ctx, w, filter) }
+		}
+
+		if err := writeExport(output, toStdout, write); err != nil {
+			return err
+		}
+		if toStdout {
+			return nil
+		}
+
+		absPath, _ := filepath.Abs(output)
+		fmt.Fprintln(stdout, successStyle.Render("✅ Transactions exported!"))
+		fmt.Fprintf(stdout, "   📁 File: %s\n", absPath)
+		fmt.Fprintf(stdout, "   📋 Format: %s\n", strings.ToUpper(format))
+
+		return nil
+	},
+}
+
+// exportCompareCmd membandingkan spending dua periode dalam satu workbook Excel.
+var exportCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Export a side-by-side comparison of two periods to Excel",
+	Long: `Export a side-by-side comparison of two periods to Excel.
+
+A period is a month (YYYY-MM), a date range (YYYY-MM-DD..YYYY-MM-DD),
+this-month, or last-month. Months follow app.month_start_day. The workbook has one sheet per period and a
+Comparison sheet with spending per category; increases above 20% are
+red, decreases green.`,
+	Example: `  wallet export compare --period1 2024-01 --period2 2025-01
+  wallet export compare --period1 2025-01-01..2025-03-31 --period2 2025-04-01..2025-06-30 -o q1-vs-q2.xlsx`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		period1Str, _ := cmd.Flags().GetString("period1")
+		period2Str, _ := cmd.Flags().GetString("period2")
+		output, _ := cmd.Flags().GetString("output")
+
+		if period1Str == "" || period2Str == "" {
+			return errors.New("both --period1 and --period2 are required")
 		}
 
+		period1, err := parsePeriod(period1Str)
 		if err != nil {
+			return fmt.Errorf("invalid --period1: %w", err)
+		}
+		period2, err := parsePeriod(period2Str)
+		if err != nil {
+			return fmt.Errorf("invalid --period2: %w", err)
+		}
+
+		if output == "" {
+			output = fmt.Sprintf("compare-%s-vs-%s.xlsx", period1Str, period2Str)
+			output = strings.ReplaceAll(output, "..", "_")
+		}
+
+		excelExporter := export.NewExcelExporter(
+			application.Repos.Wallet,
+			application.Repos.Transaction,
+			application.Repos.Category,
+			application.Config.App.Currency,
+		)
+		excelExporter.SetLocale(application.Config.App.Locale)
+		if err := excelExporter.ComparePeriodsToExcel(ctx, output, period1, period2); err != nil {
 			return err
 		}
 
 		absPath, _ := filepath.Abs(output)
-		fmt.Println(successStyle.Render("✅ Transactions exported!"))
-		fmt.Printf("   📁 File: %s\n", absPath)
-		fmt.Printf("   📋 Format: %s\n", strings.ToUpper(format))
+		fmt.Fprintln(stdout, successStyle.Render("✅ Comparison exported!"))
+		fmt.Fprintf(stdout, "   📁 File: %s\n", absPath)
 
 		return nil
 	},
 }
 
+// parsePeriod mengubah "YYYY-MM" (satu bulan penuh),
+// "YYYY-MM-DD..YYYY-MM-DD" (inklusif), "this-month", "last-month", atau
+// preset tahun fiskal (lihat fiscalPeriod) menjadi filter tanggal. Bulan
+// mengikuti app.month_start_day (sama dengan tx list --month), tahun
+// fiskal mengikuti app.fiscal_year_start_month.
+func parsePeriod(s string) (repository.TransactionFilter, error) {
+	return parsePeriodAt(s, time.Now(), fiscalYearStartMonth(), monthStartDay())
+}
+
+// fiscalYearStartMonth mengembalikan app.fiscal_year_start_month, atau 1
+// jika config belum dimuat.
+func fiscalYearStartMonth() int {
+	if application == nil || application.Config == nil {
+		return 1
+	}
+	return application.Config.App.FiscalYearStartMonth
+}
+
+// monthStartDay mengembalikan app.month_start_day, atau 1 jika config
+// belum dimuat.
+func monthStartDay() int {
+	if application == nil || application.Config == nil {
+		return 1
+	}
+	return application.Config.App.MonthStartDay
+}
+
+// parsePeriodAt adalah parsePeriod dengan waktu sekarang, bulan awal
+// tahun fiskal dan tanggal awal bulan eksplisit.
+func parsePeriodAt(s string, now time.Time, fiscalStart, anchorDay int) (repository.TransactionFilter, error) {
+	var start, end time.Time
+
+	if start, end, ok := fiscalPeriod(s, now, fiscalStart); ok {
+		end = end.Add(-time.Nanosecond)
+		return repository.TransactionFilter{StartDate: &start, EndDate: &end}, nil
+	}
+
+	// Nama periode yang memuat now, contoh 26 Dec dengan anchor 25 masuk
+	// periode January
+	year, month := utils.PeriodContaining(anchorDay, now)
+	thisMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	switch s {
+	case "this-month":
+		s = thisMonth.Format("2006-01")
+	case "last-month":
+		s = thisMonth.AddDate(0, -1, 0).Format("2006-01")
+	}
+
+	if from, to, ok := strings.Cut(s, ".."); ok {
+		var err error
+		if start, err = time.ParseInLocation("2006-01-02", from, time.Local); err != nil {
+			return repository.TransactionFilter{}, fmt.Errorf("invalid start date: %s", from)
+		}
+		if end, err = time.ParseInLocation("2006-01-02", to, time.Local); err != nil {
+			return repository.TransactionFilter{}, fmt.Errorf("invalid end date: %s", to)
+		}
+		if end.Before(start) {
+			return repository.TransactionFilter{}, errors.New("end date is before start date")
+		}
+		end = end.AddDate(0, 0, 1)
+	} else {
+		month, err := time.ParseInLocation("2006-01", s, time.Local)
+		if err != nil {
+			return repository.TransactionFilter{}, fmt.Errorf("expected YYYY-MM, YYYY-MM-DD..YYYY-MM-DD, this-month, last-month, this-year, last-year, ytd, last-ytd or FY<year>, got %q", s)
+		}
+		start, end = utils.PeriodBounds(anchorDay, month.Year(), month.Month(), time.Local)
+	}
+
+	// EndDate inklusif, jadi berhenti tepat sebelum hari berikutnya
+	end = end.Add(-time.Nanosecond)
+	return repository.TransactionFilter{StartDate: &start, EndDate: &end}, nil
+}
+
+// fiscalPeriod mengenali preset tahun fiskal dan mengembalikan rentangnya
+// (end eksklusif):
+//
+//   - this-year, last-year: tahun fiskal sekarang / sebelumnya
+//   - ytd: awal tahun fiskal sekarang sampai hari ini
+//   - last-ytd: rentang yang sama setahun sebelumnya, untuk "vs last year"
+//   - FY2025: tahun fiskal yang dimulai di 2025
+func fiscalPeriod(s string, now time.Time, fiscalStart int) (start, end time.Time, ok bool) {
+	fy := utils.FiscalYearContaining(fiscalStart, now)
+	switch s {
+	case "this-year":
+	case "last-year":
+		fy--
+	case "ytd":
+		start, end = utils.FiscalYearToDate(fiscalStart, now)
+		return start, end, true
+	case "last-ytd":
+		start, end = utils.FiscalYearToDate(fiscalStart, now.AddDate(-1, 0, 0))
+		return start, end, true
+	default:
+		digits, found := strings.CutPrefix(strings.ToUpper(s), "FY")
+		if !found || len(digits) != 4 {
+			return time.Time{}, time.Time{}, false
+		}
+		year, err := strconv.Atoi(digits)
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		fy = year
+	}
+	start, end = utils.FiscalYearBounds(fiscalStart, fy, now.Location())
+	return start, end, true
+}
+
+// periodTitle mengembalikan nama periode untuk judul compare: label tahun
+// fiskal untuk preset tahun (contoh "FY2025 (Apr 2025–Mar 2026)"), atau s
+// apa adanya.
+func periodTitle(s string, now time.Time, fiscalStart int) string {
+	start, _, ok := fiscalPeriod(s, now, fiscalStart)
+	if !ok {
+		return s
+	}
+	label := utils.FiscalYearLabel(fiscalStart, utils.FiscalYearContaining(fiscalStart, start))
+	switch s {
+	case "ytd", "last-ytd":
+		return s + " " + label
+	}
+	return label
+}
+
+// stdoutOutput adalah nilai --output yang berarti "tulis ke stdout".
+const stdoutOutput = "-"
+
+// ErrBinaryToStdout dikembalikan jika format binary (Excel/PDF) diminta
+// ke stdout tanpa --allow-binary, supaya terminal tidak dibanjiri byte acak.
+var ErrBinaryToStdout = errors.New("refusing to write a binary format to stdout; use -o <file> or add --allow-binary to pipe it")
+
+// isBinaryFormat mengecek apakah format export menghasilkan file binary.
+func isBinaryFormat(format string) bool {
+	switch format {
+	case "excel", "xlsx", "pdf":
+		return true
+	}
+	return false
+}
+
+// exportToStdout mengecek apakah export diminta ke stdout
+// (`-o -` atau --stdout). Format binary butuh --allow-binary.
+func exportToStdout(cmd *cobra.Command, format string) (bool, error) {
+	output, _ := cmd.Flags().GetString("output")
+	toStdout, _ := cmd.Flags().GetBool("stdout")
+	if output != stdoutOutput && !toStdout {
+		return false, nil
+	}
+
+	if allow, _ := cmd.Flags().GetBool("allow-binary"); isBinaryFormat(format) && !allow {
+		return false, fmt.Errorf("%w (format %s)", ErrBinaryToStdout, format)
+	}
+	return true, nil
+}
+
+// writeExport menjalankan write ke file output, atau ke stdout.
+//
+// Stdout ditulis lewat os.Stdout langsung, bukan writer CLI, karena
+// writer CLI membuang emoji saat output di-pipe dan akan merusak data
+// (icon wallet, deskripsi, byte Excel/PDF). File ditulis lewat
+// export.WriteFile, jadi export yang gagal tidak merusak file lama.
+func writeExport(output string, toStdout bool, write func(w io.Writer) error) error {
+	if toStdout {
+		return write(os.Stdout)
+	}
+	return export.WriteFile(output, write)
+}
+
 // exportWalletsCmd exports wallets.
 var exportWalletsCmd = &cobra.Command{
 	Use:   "wallets",
@@ -130,8 +465,13 @@ var exportWalletsCmd = &cobra.Command{
 		format, _ := cmd.Flags().GetString("format")
 		output, _ := cmd.Flags().GetString("output")
 
+		toStdout, err := exportToStdout(cmd, format)
+		if err != nil {
+			return err
+		}
+
 		// Set default output filename based on format
-		if output == "" {
+		if output == "" && !toStdout {
 			ext := format
 			if format == "excel" {
 				ext = "xlsx"
@@ -139,22 +479,26 @@ var exportWalletsCmd = &cobra.Command{
 			output = fmt.Sprintf("wallets-%s.%s", time.Now().Format("20060102"), ext)
 		}
 
-		var err error
+		var write func(w io.Writer) error
 		switch format {
 		case "pdf":
 			pdfExporter := export.NewPDFExporter(
 				application.Repos.Wallet,
 				application.Repos.Transaction,
+				application.Config.App.Currency,
 			)
-			err = pdfExporter.WalletsToPDF(ctx, output)
+			pdfExporter.SetLocale(application.Config.App.Locale)
+			write = func(w io.Writer) error { return pdfExporter.WalletsToPDFWriter(ctx, w) }
 
 		case "excel", "xlsx":
 			excelExporter := export.NewExcelExporter(
 				application.Repos.Wallet,
 				application.Repos.Transaction,
 				application.Repos.Category,
+				application.Config.App.Currency,
 			)
-			err = excelExporter.WalletsToExcel(ctx, output)
+			excelExporter.SetLocale(application.Config.App.Locale)
+			write = func(w io.Writer) error { return excelExporter.WalletsToExcelWriter(ctx, w) }
 
 		case "json":
 			exporter := export.NewExporter(
@@ -163,7 +507,7 @@ var exportWalletsCmd = &cobra.Command{
 				application.Repos.Category,
 				application.Repos.Goal,
 			)
-			err = exporter.WalletsToJSON(ctx, output)
+			write = func(w io.Writer) error { return exporter.WalletsToJSONWriter(ctx, w) }
 
 		default: // csv
 			exporter := export.NewExporter(
@@ -172,17 +516,134 @@ var exportWalletsCmd = &cobra.Command{
 				application.Repos.Category,
 				application.Repos.Goal,
 			)
-			err = exporter.WalletsToCSV(ctx, output)
+			write = func(w io.Writer) error { return exporter.WalletsToCSVWriter(ctx, w) }
+		}
+
+		if err := writeExport(output, toStdout, write); err != nil {
+			return err
 		}
+		if toStdout {
+			return nil
+		}
+
+		absPath, _ := filepath.Abs(output)
+		fmt.Fprintln(stdout, successStyle.Render("✅ Wallets exported!"))
+		fmt.Fprintf(stdout, "   📁 File: %s\n", absPath)
+		fmt.Fprintf(stdout, "   📋 Format: %s\n", strings.ToUpper(format))
+
+		return nil
+	},
+}
 
+// exportGoalCmd exports laporan satu goal (progress, kontribusi dan chart
+// bulanan) ke PDF atau Excel.
+var exportGoalCmd = &cobra.Command{
+	Use:               "goal [goal]",
+	Short:             "Export a goal statement to PDF/Excel",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeGoalIDs,
+	Example: `  wallet export goal "New Laptop"
+  wallet export goal "New Laptop" --format excel -o laptop.xlsx`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		if format != "pdf" && format != "excel" && format != "xlsx" {
+			return fmt.Errorf("unsupported format for goal statement: %s (use pdf or excel)", format)
+		}
+
+		goal, err := resolveGoal(ctx, args[0])
 		if err != nil {
 			return err
 		}
 
+		toStdout, err := exportToStdout(cmd, format)
+		if err != nil {
+			return err
+		}
+
+		if output == "" && !toStdout {
+			ext := format
+			if format == "excel" {
+				ext = "xlsx"
+			}
+			output = fmt.Sprintf("goal-%s.%s", time.Now().Format("20060102"), ext)
+		}
+
+		exporter := export.NewGoalStatementExporter(application.Repos.Goal, application.Config.App.Currency)
+		exporter.SetLocale(application.Config.App.Locale)
+		write := func(w io.Writer) error { return exporter.ToPDFWriter(ctx, w, goal.ID) }
+		if format != "pdf" {
+			write = func(w io.Writer) error { return exporter.ToExcelWriter(ctx, w, goal.ID) }
+		}
+
+		if err := writeExport(output, toStdout, write); err != nil {
+			return err
+		}
+		if toStdout {
+			return nil
+		}
+
 		absPath, _ := filepath.Abs(output)
-		fmt.Println(successStyle.Render("✅ Wallets exported!"))
-		fmt.Printf("   📁 File: %s\n", absPath)
-		fmt.Printf("   📋 Format: %s\n", strings.ToUpper(format))
+		fmt.Fprintln(stdout, successStyle.Render("✅ Goal statement exported!"))
+		fmt.Fprintf(stdout, "   🎯 Goal: %s\n", goal.Name)
+		fmt.Fprintf(stdout, "   📁 File: %s\n", absPath)
+		fmt.Fprintf(stdout, "   📋 Format: %s\n", strings.ToUpper(format))
+
+		return nil
+	},
+}
+
+// exportRecurringCmd exports jadwal recurring aktif ke Excel.
+var exportRecurringCmd = &cobra.Command{
+	Use:   "recurring",
+	Short: "Export the recurring schedule to Excel",
+	Long: `Export every active recurring transaction to Excel, one row each,
+sorted by next due date. Overdue due dates are highlighted in red.`,
+	Example: `  wallet export recurring
+  wallet export recurring -o schedule.xlsx`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		if format != "excel" && format != "xlsx" {
+			return fmt.Errorf("unsupported format for recurring schedule: %s (use excel)", format)
+		}
+
+		toStdout, err := exportToStdout(cmd, format)
+		if err != nil {
+			return err
+		}
+
+		if output == "" && !toStdout {
+			output = fmt.Sprintf("recurring-%s.xlsx", time.Now().Format("20060102"))
+		}
+
+		exporter := export.NewExcelExporter(
+			application.Repos.Wallet,
+			application.Repos.Transaction,
+			application.Repos.Category,
+			application.Config.App.Currency,
+		)
+		exporter.SetLocale(application.Config.App.Locale)
+		exporter.SetRecurringRepository(application.Repos.Recurring)
+
+		if err := writeExport(output, toStdout, func(w io.Writer) error {
+			return exporter.RecurringToExcelWriter(ctx, w)
+		}); err != nil {
+			return err
+		}
+		if toStdout {
+			return nil
+		}
+
+		absPath, _ := filepath.Abs(output)
+		fmt.Fprintln(stdout, successStyle.Render("✅ Recurring schedule exported!"))
+		fmt.Fprintf(stdout, "   📁 File: %s\n", absPath)
+		fmt.Fprintf(stdout, "   📋 Format: %s\n", strings.ToUpper(format))
 
 		return nil
 	},
@@ -190,46 +651,132 @@ var exportWalletsCmd = &cobra.Command{
 
 // importCmd adalah parent command untuk import operations.
 var importCmd = &cobra.Command{
-	Use:   "import",
-	Short: "📥 Import data from CSV/JSON",
-	Long:  "Import financial data from CSV or JSON files.",
+	Use:         "import",
+	Short:       "📥 Import data from CSV/JSON/OFX",
+	Annotations: requiresDB,
+	Long:        "Import financial data from CSV or JSON files, or bank statements (OFX).",
 }
 
 // importTransactionsCmd imports transactions from CSV.
 var importTransactionsCmd = &cobra.Command{
 	Use:   "transactions [file]",
 	Short: "Import transactions from CSV",
-	Args:  cobra.ExactArgs(1),
+	Long: `Import transactions from CSV.
+
+By default every row needs a "Wallet ID" column and wallet balances are
+not changed. With --wallet, all rows go into that wallet (the column is
+optional) through the normal transaction flow, so its balance reflects
+the import.
+
+An optional "Currency" column is checked against each row's wallet.
+Rows in another currency are rejected unless --convert-with-rate is
+given, in which case their amount is multiplied by the rate.
+
+For large files, --checkpoint records progress every 1,000 rows. If the
+import stops partway (crash, Ctrl+C), run the same command again to
+continue where it left off. The checkpoint file is deleted once the
+import completes. Not available with --wallet, which imports in date order.`,
+	Example: `  wallet import transactions export.csv
+  wallet import transactions bca-statement.csv --wallet BCA
+  wallet import transactions wise-usd.csv --wallet BCA --convert-with-rate 16250
+  wallet import transactions big.csv --checkpoint big.csv.checkpoint`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		txManager := postgres.NewTransactionManager(application.DB.Pool)
+		walletArg, _ := cmd.Flags().GetString("wallet")
+		checkpointFile, _ := cmd.Flags().GetString("checkpoint")
+		if checkpointFile != "" && walletArg != "" {
+			return errors.New("--checkpoint cannot be combined with --wallet")
+		}
+
+		var opts []export.ImporterOption
+		if checkpointFile != "" {
+			opts = append(opts, export.WithCheckpointFile(checkpointFile))
+		}
+
+		txManager := application.TxManager
 		importer := export.NewImporter(
 			application.Repos.Wallet,
 			application.Repos.Transaction,
 			application.Repos.Category,
 			application.Repos.Goal,
 			txManager,
+			opts...,
 		)
 
+		if rateStr, _ := cmd.Flags().GetString("convert-with-rate"); rateStr != "" {
+			rate, err := decimal.NewFromString(rateStr)
+			if err != nil || !rate.IsPositive() {
+				return fmt.Errorf("invalid --convert-with-rate: %s", rateStr)
+			}
+			importer.SetConversionRate(rate)
+		}
+
 		filename := args[0]
-		result, err := importer.TransactionsFromCSV(ctx, filename)
-		if err != nil {
-			return err
+
+		var result *export.ImportResult
+		var wallet *models.Wallet
+		var err error
+		if walletArg != "" {
+			wallet, err = resolveWallet(ctx, walletArg)
+			if err != nil {
+				return err
+			}
+
+			txService := service.NewTransactionService(
+				application.Repos.Transaction,
+				application.Repos.Wallet,
+				application.Repos.Category,
+				txManager,
+			)
+			txService.SetRejectBeforeWalletDate(application.Config.Validation.RejectBeforeWalletDate)
+			setRateProvider(txService)
+			setMaxAmount(txService)
+			txService.SetRuleRepository(application.Repos.Rule)
+			result, err = importer.TransactionsFromCSVToWallet(ctx, filename, wallet.ID, txService)
+			if err != nil {
+				return err
+			}
+		} else {
+			row, err := importer.ResumeFromCheckpoint(filename)
+			if err != nil {
+				return err
+			}
+			if row > 0 {
+				fmt.Fprintf(stdout, "⏩ Resuming after row %d (%s)\n", row, checkpointFile)
+			}
+
+			result, err = importer.TransactionsFromCSV(ctx, filename)
+			if err != nil {
+				if saved, _ := importer.ResumeFromCheckpoint(filename); saved > 0 {
+					fmt.Fprintf(stdout, "💾 Progress saved to %s, run the same command again to continue.\n", checkpointFile)
+				}
+				return err
+			}
 		}
 
-		fmt.Println(successStyle.Render("✅ Import completed!"))
-		fmt.Printf("   📊 Total rows: %d\n", result.TotalRows)
-		fmt.Printf("   ✅ Imported: %d\n", result.SuccessCount)
-		fmt.Printf("   ⏭️ Skipped: %d\n", result.SkippedCount)
+		fmt.Fprintln(stdout, successStyle.Render("✅ Import completed!"))
+		fmt.Fprintf(stdout, "   📊 Total rows: %d\n", result.TotalRows)
+		fmt.Fprintf(stdout, "   ✅ Imported: %d\n", result.SuccessCount)
+		if result.AlreadyImported > 0 {
+			fmt.Fprintf(stdout, "   ⏩ Already imported: %d\n", result.AlreadyImported)
+		}
+		fmt.Fprintf(stdout, "   ⏭️ Skipped: %d\n", result.SkippedCount)
+
+		if wallet != nil {
+			if updated, err := application.Repos.Wallet.GetByID(ctx, wallet.ID); err == nil {
+				fmt.Fprintf(stdout, "   💰 %s balance: %s\n", updated.Name, moneyStyle.Render(formatWalletMoney(updated.Balance, updated.Currency)))
+			}
+		}
 
 		if len(result.Errors) > 0 {
-			fmt.Println("\n⚠️ Errors:")
+			fmt.Fprintln(stdout, "\n⚠️ Errors:")
 			for _, e := range result.Errors[:min(5, len(result.Errors))] {
-				fmt.Printf("   - %s\n", e)
+				fmt.Fprintf(stdout, "   - %s\n", e)
 			}
 			if len(result.Errors) > 5 {
-				fmt.Printf("   ... and %d more\n", len(result.Errors)-5)
+				fmt.Fprintf(stdout, "   ... and %d more\n", len(result.Errors)-5)
 			}
 		}
 
@@ -237,15 +784,87 @@ var importTransactionsCmd = &cobra.Command{
 	},
 }
 
+// importBankCmd imports a bank statement (OFX) into one wallet.
+var importBankCmd = &cobra.Command{
+	Use:   "bank [wallet] [file.ofx]",
+	Short: "Import a bank statement (OFX) into a wallet",
+	Long: `Import transactions from an OFX 2.x statement downloaded from your bank.
+
+Positive amounts become income, negative amounts expense, and the wallet
+balance is updated. Each transaction's bank ID (FITID) is remembered, so
+importing the same or an overlapping statement again only adds the new
+transactions. If one transaction is rejected (for example the balance
+would go negative), nothing is imported.`,
+	Example: `  wallet import bank BCA bca-2024-01.ofx
+  wallet import bank <wallet-id> statement.ofx`,
+	Args: cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeWalletIDs(cmd, args, toComplete)
+		}
+		return []string{"ofx"}, cobra.ShellCompDirectiveFilterFileExt
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		wallet, err := resolveWallet(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		txService := service.NewTransactionService(
+			application.Repos.Transaction,
+			application.Repos.Wallet,
+			application.Repos.Category,
+			application.TxManager,
+		)
+		txService.SetRejectBeforeWalletDate(application.Config.Validation.RejectBeforeWalletDate)
+		setRateProvider(txService)
+		setMaxAmount(txService)
+		txService.SetRuleRepository(application.Repos.Rule)
+
+		walletService := service.NewWalletService(application.Repos.Wallet, application.Repos.Transfer, application.TxManager)
+		walletService.SetTransactionService(txService)
+
+		result, err := walletService.ImportFromBank(ctx, wallet.ID, args[1])
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Import completed!"))
+		fmt.Fprintf(stdout, "   📊 Total transactions: %d\n", result.TotalRows)
+		fmt.Fprintf(stdout, "   ✅ Imported: %d\n", result.SuccessCount)
+		if result.AlreadyImported > 0 {
+			fmt.Fprintf(stdout, "   ⏩ Already imported: %d\n", result.AlreadyImported)
+		}
+		if result.SkippedCount > 0 {
+			fmt.Fprintf(stdout, "   ⏭️ Skipped (zero amount): %d\n", result.SkippedCount)
+		}
+		if updated, err := application.Repos.Wallet.GetByID(ctx, wallet.ID); err == nil {
+			fmt.Fprintf(stdout, "   💰 %s balance: %s\n", updated.Name, moneyStyle.Render(formatWalletMoney(updated.Balance, updated.Currency)))
+		}
+
+		return nil
+	},
+}
+
 // importBackupCmd imports from JSON backup.
 var importBackupCmd = &cobra.Command{
 	Use:   "backup [file]",
 	Short: "Import from JSON backup",
-	Args:  cobra.ExactArgs(1),
+	Long: `Restore a full backup created by 'wallet export all'.
+
+Incremental backups ('wallet export all --since') replace rows that already
+exist by ID and add new ones. Restore the full backup first, then each
+incremental in the order they were taken.
+
+Use - as the file to read the backup from stdin:
+  ssh host wallet export all -o - | wallet import backup -`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		txManager := postgres.NewTransactionManager(application.DB.Pool)
+		txManager := application.TxManager
 		importer := export.NewImporter(
 			application.Repos.Wallet,
 			application.Repos.Transaction,
@@ -256,20 +875,33 @@ var importBackupCmd = &cobra.Command{
 
 		filename := args[0]
 
-		// Validate file extension
-		if !strings.HasSuffix(filename, ".json") {
-			return fmt.Errorf("backup file must be JSON format")
+		var result *export.ImportResult
+		var err error
+		if filename == "-" {
+			result, err = importer.FromJSONReader(ctx, cmd.InOrStdin())
+		} else {
+			// Validate file extension
+			if !strings.HasSuffix(filename, ".json") {
+				return fmt.Errorf("backup file must be JSON format")
+			}
+			result, err = importer.FromJSON(ctx, filename)
 		}
-
-		result, err := importer.FromJSON(ctx, filename)
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(successStyle.Render("✅ Backup restored!"))
-		fmt.Printf("   📊 Total items: %d\n", result.TotalRows)
-		fmt.Printf("   ✅ Imported: %d\n", result.SuccessCount)
-		fmt.Printf("   ⏭️ Skipped: %d\n", result.SkippedCount)
+		fmt.Fprintln(stdout, successStyle.Render("✅ Backup restored!"))
+		fmt.Fprintf(stdout, "   🏷️ Backup version: %s (schema %d)\n", result.BackupVersion, result.BackupSchemaVersion)
+		fmt.Fprintf(stdout, "   🕒 Exported at: %s\n", result.ExportedAt.Local().Format("2006-01-02 15:04"))
+		if result.Manifest != nil {
+			fmt.Fprintf(stdout, "   🔗 Incremental: changes since %s\n", result.Manifest.Since.Local().Format("2006-01-02 15:04"))
+		}
+		fmt.Fprintf(stdout, "   📊 Total items: %d\n", result.TotalRows)
+		fmt.Fprintf(stdout, "   ✅ Imported: %d\n", result.SuccessCount)
+		if result.Manifest != nil {
+			fmt.Fprintf(stdout, "   🔁 Replaced: %d\n", result.ReplacedCount)
+		}
+		fmt.Fprintf(stdout, "   ⏭️ Skipped: %d\n", result.SkippedCount)
 
 		return nil
 	},
@@ -277,22 +909,54 @@ var importBackupCmd = &cobra.Command{
 
 func init() {
 	// export all
-	exportAllCmd.Flags().StringP("output", "o", "", "Output filename")
+	exportAllCmd.Flags().StringP("output", "o", "", "Output filename (- for stdout)")
+	exportAllCmd.Flags().Bool("stdout", false, "Write to stdout (same as -o -)")
+	exportAllCmd.Flags().String("since", "", "Incremental backup: only changes after this time (RFC 3339 or YYYY-MM-DD)")
 	exportCmd.AddCommand(exportAllCmd)
 
 	// export transactions - supports pdf, excel, csv, json
-	exportTransactionsCmd.Flags().StringP("output", "o", "", "Output filename")
-	exportTransactionsCmd.Flags().StringP("format", "f", "csv", "Output format: csv, json, excel, pdf")
+	exportTransactionsCmd.Flags().StringP("output", "o", "", "Output filename (- for stdout)")
+	exportTransactionsCmd.Flags().Bool("stdout", false, "Write to stdout (same as -o -)")
+	exportTransactionsCmd.Flags().Bool("allow-binary", false, "Allow excel/pdf output to stdout")
+	exportTransactionsCmd.Flags().StringP("format", "f", "csv", "Output format: csv, json, excel, pdf, markdown")
+	exportTransactionsCmd.Flags().Bool("clip", false, "Copy the markdown table to the clipboard instead of writing a file")
+	exportTransactionsCmd.Flags().StringArrayP("wallet", "w", nil, "Only this wallet (ID or name); repeat for several wallets")
+	_ = exportTransactionsCmd.RegisterFlagCompletionFunc("wallet", completeWalletIDs)
 	exportCmd.AddCommand(exportTransactionsCmd)
 
 	// export wallets - supports pdf, excel, csv, json
-	exportWalletsCmd.Flags().StringP("output", "o", "", "Output filename")
+	exportCompareCmd.Flags().String("period1", "", "First period: YYYY-MM, YYYY-MM-DD..YYYY-MM-DD, ytd, last-ytd or FY<year> (required)")
+	exportCompareCmd.Flags().String("period2", "", "Second period: YYYY-MM, YYYY-MM-DD..YYYY-MM-DD, ytd, last-ytd or FY<year> (required)")
+	exportCompareCmd.Flags().StringP("output", "o", "", "Output filename")
+	exportCmd.AddCommand(exportCompareCmd)
+
+	exportWalletsCmd.Flags().StringP("output", "o", "", "Output filename (- for stdout)")
+	exportWalletsCmd.Flags().Bool("stdout", false, "Write to stdout (same as -o -)")
+	exportWalletsCmd.Flags().Bool("allow-binary", false, "Allow excel/pdf output to stdout")
 	exportWalletsCmd.Flags().StringP("format", "f", "csv", "Output format: csv, json, excel, pdf")
 	exportCmd.AddCommand(exportWalletsCmd)
 
+	exportGoalCmd.Flags().StringP("output", "o", "", "Output filename (- for stdout)")
+	exportGoalCmd.Flags().Bool("stdout", false, "Write to stdout (same as -o -)")
+	exportGoalCmd.Flags().Bool("allow-binary", false, "Allow excel/pdf output to stdout")
+	exportGoalCmd.Flags().StringP("format", "f", "pdf", "Output format: pdf, excel")
+	exportCmd.AddCommand(exportGoalCmd)
+
+	exportRecurringCmd.Flags().StringP("output", "o", "", "Output filename (- for stdout)")
+	exportRecurringCmd.Flags().Bool("stdout", false, "Write to stdout (same as -o -)")
+	exportRecurringCmd.Flags().Bool("allow-binary", false, "Allow excel output to stdout")
+	exportRecurringCmd.Flags().StringP("format", "f", "excel", "Output format: excel")
+	exportCmd.AddCommand(exportRecurringCmd)
+
 	// import transactions
+	importTransactionsCmd.Flags().String("wallet", "", "Import all rows into this wallet (ID or name) and update its balance")
+	importTransactionsCmd.Flags().String("convert-with-rate", "", "Convert rows whose Currency differs from the wallet using this rate")
+	importTransactionsCmd.Flags().String("checkpoint", "", "Save progress to this file and resume from it if the import stopped partway")
 	importCmd.AddCommand(importTransactionsCmd)
 
+	// import bank
+	importCmd.AddCommand(importBankCmd)
+
 	// import backup
 	importCmd.AddCommand(importBackupCmd)
 
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"github.com/Adityanrhm/wallet-twin/internal/app"
+	"github.com/Adityanrhm/wallet-twin/internal/export"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestExportToStdout(t *testing.T) {
+	out := runPiped(t, "export", "tx", "--format", "json", "-o", "-")
+
+	var txs []map[string]any
+	if err := json.Unmarshal(out, &txs); err != nil {
+		t.Fatalf("stdout is not a JSON array: %v\n%s", err, out)
+	}
+	if len(txs) == 0 {
+		t.Error("exported no transactions from demo data")
+	}
+
+	out = runPiped(t, "export", "wallets", "--stdout")
+	if !strings.HasPrefix(string(out), "ID,Name,") {
+		t.Errorf("CSV output = %q, want header first and no status message", firstLine(out))
+	}
+}
+
+func TestExportToStdout_RejectsBinary(t *testing.T) {
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	application = demo
+	t.Cleanup(func() { application = nil })
+
+	for _, format := range []string{"pdf", "excel"} {
+		rootCmd.SetArgs([]string{"export", "tx", "--format", format, "-o", "-"})
+		if err := rootCmd.Execute(); !errors.Is(err, ErrBinaryToStdout) {
+			t.Errorf("export tx --format %s -o - error = %v, want ErrBinaryToStdout", format, err)
+		}
+	}
+}
+
+func TestExportTransactions_WalletFilter(t *testing.T) {
+	t.Cleanup(func() {
+		flag := exportTransactionsCmd.Flags().Lookup("wallet")
+		_ = flag.Value.(pflag.SliceValue).Replace(nil)
+		flag.Changed = false
+	})
+
+	out := runPiped(t, "export", "tx", "--format", "json", "-o", "-", "--wallet", "Cash", "--wallet", "GoPay")
+
+	var txs []map[string]any
+	if err := json.Unmarshal(out, &txs); err != nil {
+		t.Fatalf("stdout is not a JSON array: %v\n%s", err, out)
+	}
+	if len(txs) == 0 {
+		t.Fatal("exported no transactions for Cash and GoPay")
+	}
+
+	walletNames := map[string]string{}
+	wallets, _ := application.Repos.Wallet.List(context.Background(), repository.WalletFilter{})
+	for _, w := range wallets {
+		walletNames[w.ID.String()] = w.Name
+	}
+	seen := map[string]bool{}
+	for _, tx := range txs {
+		name := walletNames[fmt.Sprint(tx["wallet_id"])]
+		if name != "Cash" && name != "GoPay" {
+			t.Errorf("exported transaction from wallet %q, want only Cash and GoPay", name)
+		}
+		seen[name] = true
+	}
+	if !seen["Cash"] || !seen["GoPay"] {
+		t.Errorf("exported wallets = %v, want both Cash and GoPay", seen)
+	}
+}
+
+func firstLine(b []byte) string {
+	line, _, _ := strings.Cut(string(b), "\n")
+	return line
+}
+
+func TestExportGoal(t *testing.T) {
+	t.Cleanup(func() { _ = exportGoalCmd.Flags().Set("output", "") })
+
+	output := filepath.Join(t.TempDir(), "laptop.pdf")
+	out := string(runPiped(t, "export", "goal", "new laptop", "-o", output))
+	if !strings.Contains(out, "New Laptop") {
+		t.Errorf("output missing goal name:\n%s", out)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("read statement: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "%PDF") {
+		t.Errorf("statement is not a PDF (%d bytes)", len(data))
+	}
+}
+
+func TestImportBank(t *testing.T) {
+	ofxFile := filepath.Join(t.TempDir(), "bca.ofx")
+	statement := `<?xml version="1.0" encoding="UTF-8"?>
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>IDR</CURDEF><BANKTRANLIST>
+<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20260102</DTPOSTED><TRNAMT>150000</TRNAMT><FITID>A1</FITID><NAME>TRANSFER IN</NAME></STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20260103</DTPOSTED><TRNAMT>-25000</TRNAMT><FITID>A2</FITID><NAME>INDOMARET</NAME></STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+	if err := os.WriteFile(ofxFile, []byte(statement), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out := string(runPiped(t, "import", "bank", "BCA", ofxFile))
+	for _, want := range []string{"Total transactions: 2", "Imported: 2", "BCA balance:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestImportBackup_Stdin(t *testing.T) {
+	backup := `{"exported_at":"2026-01-05T03:00:00Z","version":"v1.0.0","wallets":[` +
+		`{"id":"6f1c2c1e-5d6b-4a43-9d53-3f0b1c6f7a11","name":"Jago","type":"bank","balance":"0","currency":"IDR","is_active":true}]}`
+	rootCmd.SetIn(strings.NewReader(backup))
+	t.Cleanup(func() { rootCmd.SetIn(nil) })
+
+	out := string(runPiped(t, "import", "backup", "-"))
+	for _, want := range []string{"Backup restored!", "Backup version: v1.0.0 (schema 1)", "Exported at:", "Imported: 1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestBackupValidate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "backup.json")
+	runPiped(t, "export", "all", "-o", file)
+
+	out := string(execPiped(t, "backup", "validate", file))
+	if !strings.Contains(out, fmt.Sprintf("Backup is valid (schema %d)", export.BackupSchemaVersion)) {
+		t.Errorf("output = %q, want valid backup", out)
+	}
+
+	broken := `{"exported_at":"2026-01-05T03:00:00Z","version":"v1.0.0","wallets":[{"id":"6f1c2c1e-5d6b-4a43-9d53-3f0b1c6f7a11","nickname":"x"}]}`
+	rootCmd.SetIn(strings.NewReader(broken))
+	t.Cleanup(func() { rootCmd.SetIn(nil) })
+
+	rootCmd.SetArgs([]string{"backup", "validate", "-"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "schema issue") {
+		t.Errorf("backup validate on a broken backup error = %v, want schema issues", err)
+	}
+}
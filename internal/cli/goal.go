@@ -2,22 +2,27 @@ package cli
 
 import (
 	"fmt"
-	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/olekukonko/tablewriter"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 
+	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
 	"github.com/Adityanrhm/wallet-twin/internal/service"
 )
 
 // goalCmd adalah parent command untuk goal operations.
 var goalCmd = &cobra.Command{
-	Use:     "goal",
-	Aliases: []string{"g"},
-	Short:   "🎯 Manage savings goals",
-	Long:    "Create and track progress toward savings goals.",
+	Use:         "goal",
+	Aliases:     []string{"g"},
+	Short:       "🎯 Manage savings goals",
+	Annotations: requiresDB,
+	Long:        "Create and track progress toward savings goals.",
 }
 
 // goalListCmd menampilkan semua goals.
@@ -28,7 +33,7 @@ var goalListCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		goalService := service.NewGoalService(application.Repos.Goal)
+		goalService := service.NewGoalService(application.Repos.Goal, application.Repos.Wallet, application.TxManager)
 
 		showAll, _ := cmd.Flags().GetBool("all")
 
@@ -44,26 +49,31 @@ var goalListCmd = &cobra.Command{
 		}
 
 		if len(goals) == 0 {
-			fmt.Println("No goals found. Create one with: wallet goal add")
+			fmt.Fprintln(stdout, "No goals found. Create one with: wallet goal add")
 			return nil
 		}
 
-		fmt.Println(titleStyle.Render("\n🎯 Savings Goals\n"))
+		fmt.Fprintln(stdout, titleStyle.Render("\n🎯 Savings Goals\n"))
 
-		table := tablewriter.NewTable(os.Stdout)
+		table := tablewriter.NewTable(stdout)
 		table.Header("Name", "Progress", "Current", "Target", "Status")
 
 		for _, g := range goals {
-			progress := g.GetProgress()
-			progressBar := renderProgressBar(progress, 8)
+			progressBar := newProgressBar(8).Render(g.GetProgress())
 
 			statusIcon := "🔄"
 			if g.IsCompleted() {
 				statusIcon = "✅"
 			}
 
+			// Goal net worth: Current adalah total saldo wallet
+			name := models.DisplayIcon(g.Icon) + " " + g.Name
+			if g.IsNetWorth() {
+				name += " (net worth)"
+			}
+
 			table.Append([]string{
-				g.Icon + " " + g.Name,
+				name,
 				progressBar,
 				formatMoney(g.CurrentAmount),
 				formatMoney(g.TargetAmount),
@@ -76,6 +86,230 @@ var goalListCmd = &cobra.Command{
 	},
 }
 
+// goalShowCmd menampilkan detail satu goal beserta kontribusi terakhir.
+var goalShowCmd = &cobra.Command{
+	Use:     "show [goal]",
+	Short:   "Show goal details and recent contributions",
+	Example: `  wallet goal show "New Laptop"`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		goalService := service.NewGoalService(application.Repos.Goal, application.Repos.Wallet, application.TxManager)
+
+		goal, err := resolveGoal(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		progress, err := goalService.GetProgress(ctx, goal.ID)
+		if err != nil {
+			return err
+		}
+		goal = progress.Goal // CurrentAmount goal net worth sudah dihitung
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		contributions, err := goalService.GetContributions(ctx, goal.ID, repository.ListParams{Limit: limit})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render(fmt.Sprintf("\n%s %s\n", models.DisplayIcon(goal.Icon), goal.Name)))
+		fmt.Fprintf(stdout, "   ID:        %s\n", goal.ID)
+		fmt.Fprintf(stdout, "   Status:    %s\n", goal.Status)
+		if goal.IsNetWorth() {
+			fmt.Fprintln(stdout, "   Kind:      net worth (sum of all wallet balances)")
+		}
+		fmt.Fprintf(stdout, "   Progress:  %s\n", newProgressBar(20).Render(progress.Progress))
+		fmt.Fprintf(stdout, "   Current:   %s\n", moneyStyle.Render(formatMoney(goal.CurrentAmount)))
+		fmt.Fprintf(stdout, "   Target:    %s\n", formatMoney(goal.TargetAmount))
+		fmt.Fprintf(stdout, "   Remaining: %s\n", formatMoney(progress.Remaining))
+
+		switch {
+		case goal.Deadline == nil:
+			fmt.Fprintln(stdout, "   Deadline:  none")
+		case progress.DaysUntilDeadline < 0:
+			fmt.Fprintf(stdout, "   Deadline:  %s (passed)\n", formatDate(*goal.Deadline))
+		default:
+			fmt.Fprintf(stdout, "   Deadline:  %s (%d days left)\n", formatDate(*goal.Deadline), progress.DaysUntilDeadline)
+		}
+		if progress.RequiredPerMonth.IsPositive() {
+			fmt.Fprintf(stdout, "   Needed:    %s / month\n", formatMoney(progress.RequiredPerMonth))
+		}
+
+		switch {
+		case progress.IsCompleted:
+			fmt.Fprintln(stdout, "   Track:     "+successStyle.Render("✅ reached"))
+		case goal.Deadline == nil:
+			fmt.Fprintln(stdout, "   Track:     no deadline")
+		case progress.OnTrack:
+			fmt.Fprintln(stdout, "   Track:     "+successStyle.Render("✅ on track"))
+		default:
+			fmt.Fprintln(stdout, "   Track:     "+warningStyle.Render("⚠️  behind schedule"))
+		}
+
+		if goal.IsNetWorth() {
+			return nil
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render("\n📜 Recent Contributions\n"))
+		if len(contributions) == 0 {
+			fmt.Fprintln(stdout, "   No contributions yet. Add one with: wallet goal contribute")
+			return nil
+		}
+		for _, c := range contributions {
+			line := fmt.Sprintf("   %s  %s", formatDate(c.CreatedAt), incomeStyle.Render("+"+formatMoney(c.Amount)))
+			if c.Note != "" {
+				line += "  " + c.Note
+			}
+			fmt.Fprintln(stdout, line)
+		}
+
+		return nil
+	},
+}
+
+// goalForecastCmd menampilkan proyeksi saldo goal bulan demi bulan.
+var goalForecastCmd = &cobra.Command{
+	Use:   "forecast [goal]",
+	Short: "Project a goal's balance month by month",
+	Long: `Project a goal's balance month by month with a fixed monthly contribution,
+until the target is reached or 36 months have passed. The month the goal is
+reached is highlighted.
+
+Without --monthly-contribution, the average of past contributions per month
+(from the first contribution until this month) is used.`,
+	Example: `  wallet goal forecast "New Laptop"
+  wallet goal forecast "New Laptop" --monthly-contribution 1500000`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		goalService := service.NewGoalService(application.Repos.Goal, application.Repos.Wallet, application.TxManager)
+
+		goal, err := resolveGoal(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		goal, err = goalService.GetByID(ctx, goal.ID)
+		if err != nil {
+			return err
+		}
+		if goal.IsCompleted() {
+			fmt.Fprintf(stdout, "%s is already reached.\n", goal.Name)
+			return nil
+		}
+
+		now := time.Now()
+		monthly := decimal.Zero
+		source := "average of past contributions"
+		if cmd.Flags().Changed("monthly-contribution") {
+			amountStr, _ := cmd.Flags().GetString("monthly-contribution")
+			monthly, err = decimal.NewFromString(amountStr)
+			if err != nil {
+				return fmt.Errorf("invalid monthly contribution: %w", err)
+			}
+			if !monthly.IsPositive() {
+				return fmt.Errorf("monthly contribution must be positive")
+			}
+			source = "fixed"
+		} else {
+			monthly, err = goalService.AverageMonthlyContribution(ctx, goal.ID, now)
+			if err != nil {
+				return err
+			}
+			if !monthly.IsPositive() {
+				return fmt.Errorf("%s has no contributions to average; pass --monthly-contribution", goal.Name)
+			}
+		}
+
+		months := service.ForecastGoal(goal, monthly, now)
+
+		fmt.Fprintln(stdout, titleStyle.Render(fmt.Sprintf("\n📈 %s %s Forecast\n", models.DisplayIcon(goal.Icon), goal.Name)))
+		fmt.Fprintf(stdout, "   Current:      %s of %s\n", formatMoney(goal.CurrentAmount), formatMoney(goal.TargetAmount))
+		fmt.Fprintf(stdout, "   Contribution: %s / month (%s)\n\n", moneyStyle.Render(formatMoney(monthly)), source)
+
+		table := tablewriter.NewTable(stdout)
+		table.Header("Month", "Projected Balance", "% Complete")
+
+		for _, m := range months {
+			row := []string{
+				m.Month.Format("Jan 2006"),
+				formatMoney(m.Balance),
+				fmt.Sprintf("%.1f%%", m.Progress),
+			}
+			if m.Reached {
+				for i := range row {
+					row[i] = successStyle.Render(row[i])
+				}
+			}
+			table.Append(row)
+		}
+
+		table.Render()
+
+		last := months[len(months)-1]
+		if last.Reached {
+			fmt.Fprintf(stdout, "\n   %s\n", successStyle.Render("🎉 Reached in "+last.Month.Format("Jan 2006")))
+		} else {
+			fmt.Fprintf(stdout, "\n   %s\n", warningStyle.Render(fmt.Sprintf("⚠️  Not reached within %d months", service.GoalForecastMonths)))
+		}
+		return nil
+	},
+}
+
+// goalCompletedCmd menampilkan goal yang tercapai dalam satu tahun.
+var goalCompletedCmd = &cobra.Command{
+	Use:   "completed",
+	Short: "List goals completed in a year",
+	Long: `List goals marked completed during a calendar year, most recent first.
+
+The completion date is the goal's last update, so editing a completed goal
+moves it to the year of the edit.`,
+	Example: `  wallet goal completed
+  wallet goal completed --year 2025`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		goalService := service.NewGoalService(application.Repos.Goal, application.Repos.Wallet, application.TxManager)
+
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = time.Now().Year()
+		}
+
+		goals, err := goalService.GetCompletedGoals(ctx, year)
+		if err != nil {
+			return err
+		}
+
+		if len(goals) == 0 {
+			fmt.Fprintf(stdout, "No goals completed in %d.\n", year)
+			return nil
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render(fmt.Sprintf("\n🏆 Goals Completed in %d\n", year)))
+
+		table := tablewriter.NewTable(stdout)
+		table.Header("Name", "Saved", "Target", "Completed")
+
+		total := decimal.Zero
+		for _, g := range goals {
+			table.Append([]string{
+				models.DisplayIcon(g.Icon) + " " + g.Name,
+				formatMoney(g.CurrentAmount),
+				formatMoney(g.TargetAmount),
+				formatDate(g.UpdatedAt),
+			})
+			total = total.Add(g.CurrentAmount)
+		}
+
+		table.Render()
+		fmt.Fprintf(stdout, "\n   %d goal(s), %s saved\n", len(goals), moneyStyle.Render(formatMoney(total)))
+		return nil
+	},
+}
+
 // goalAddCmd menambah goal baru.
 var goalAddCmd = &cobra.Command{
 	Use:   "add",
@@ -83,12 +317,26 @@ var goalAddCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		goalService := service.NewGoalService(application.Repos.Goal)
+		goalService := service.NewGoalService(application.Repos.Goal, application.Repos.Wallet, application.TxManager)
 
 		name, _ := cmd.Flags().GetString("name")
 		targetStr, _ := cmd.Flags().GetString("target")
 		desc, _ := cmd.Flags().GetString("description")
 		icon, _ := cmd.Flags().GetString("icon")
+		netWorth, _ := cmd.Flags().GetBool("net-worth")
+
+		kind := models.GoalKindSavings
+		if netWorth {
+			kind = models.GoalKindNetWorth
+			if !cmd.Flags().Changed("icon") {
+				icon = "🌐"
+			}
+		}
+
+		icon, err := resolveIcon(icon, "🎯")
+		if err != nil {
+			return err
+		}
 
 		// Parse target
 		target, err := decimal.NewFromString(targetStr)
@@ -101,15 +349,19 @@ var goalAddCmd = &cobra.Command{
 			Description:  desc,
 			TargetAmount: target,
 			Icon:         icon,
+			Kind:         kind,
 		})
 
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(successStyle.Render("✅ Goal created!"))
-		fmt.Printf("   🎯 %s %s\n", goal.Icon, goal.Name)
-		fmt.Printf("   💰 Target: %s\n", formatMoney(goal.TargetAmount))
+		fmt.Fprintln(stdout, successStyle.Render("✅ Goal created!"))
+		fmt.Fprintf(stdout, "   🎯 %s %s\n", goal.Icon, goal.Name)
+		fmt.Fprintf(stdout, "   💰 Target: %s\n", formatMoney(goal.TargetAmount))
+		if goal.IsNetWorth() {
+			fmt.Fprintf(stdout, "   🌐 Net worth now: %s (tracks all wallet balances, no contributions needed)\n", formatMoney(goal.CurrentAmount))
+		}
 
 		return nil
 	},
@@ -123,7 +375,7 @@ var goalContributeCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		goalService := service.NewGoalService(application.Repos.Goal)
+		goalService := service.NewGoalService(application.Repos.Goal, application.Repos.Wallet, application.TxManager)
 
 		goalID, _ := cmd.Flags().GetString("goal")
 		amountStr, _ := cmd.Flags().GetString("amount")
@@ -153,12 +405,12 @@ var goalContributeCmd = &cobra.Command{
 		// Get updated progress
 		progress, _ := goalService.GetProgress(ctx, gID)
 
-		fmt.Println(successStyle.Render("✅ Contribution added!"))
-		fmt.Printf("   💰 Amount: %s\n", formatMoney(amount))
+		fmt.Fprintln(stdout, successStyle.Render("✅ Contribution added!"))
+		fmt.Fprintf(stdout, "   💰 Amount: %s\n", formatMoney(amount))
 		if progress != nil {
-			fmt.Printf("   📊 Progress: %.1f%%\n", progress.Progress)
+			fmt.Fprintf(stdout, "   📊 Progress: %.1f%%\n", progress.Progress)
 			if progress.IsCompleted {
-				fmt.Println("   🎉 Goal completed!")
+				fmt.Fprintln(stdout, "   🎉 Goal completed!")
 			}
 		}
 
@@ -166,6 +418,52 @@ var goalContributeCmd = &cobra.Command{
 	},
 }
 
+// goalAutoContributeCmd menyapu surplus saldo wallet ke goal prioritas.
+var goalAutoContributeCmd = &cobra.Command{
+	Use:   "auto-contribute",
+	Short: "Sweep wallet balance above --keep into the top-priority goal",
+	Long: `Move the wallet balance above --keep into the active goal closest to
+completion. The wallet balance is reduced by the contributed amount, and the
+contribution never exceeds what the goal still needs.
+
+Handy at month end, e.g. from cron.`,
+	Example: `  wallet goal auto-contribute --wallet BCA --keep 1000000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		goalService := service.NewGoalService(application.Repos.Goal, application.Repos.Wallet, application.TxManager)
+
+		walletArg, _ := cmd.Flags().GetString("wallet")
+		keepStr, _ := cmd.Flags().GetString("keep")
+
+		wallet, err := resolveWallet(ctx, walletArg)
+		if err != nil {
+			return err
+		}
+
+		keep, err := decimal.NewFromString(keepStr)
+		if err != nil {
+			return fmt.Errorf("invalid keep amount: %w", err)
+		}
+
+		amount, err := goalService.AutoContributeFromSurplus(ctx, wallet.ID, keep)
+		if err != nil {
+			return err
+		}
+
+		if amount.IsZero() {
+			fmt.Fprintf(stdout, "No surplus: %s balance is not above %s\n",
+				wallet.Name, formatWalletMoney(keep, wallet.Currency))
+			return nil
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Surplus contributed!"))
+		fmt.Fprintf(stdout, "   💰 Amount: %s from %s\n", formatWalletMoney(amount, wallet.Currency), wallet.Name)
+
+		return nil
+	},
+}
+
 // goalDeleteCmd menghapus goal.
 var goalDeleteCmd = &cobra.Command{
 	Use:   "delete [goal-id]",
@@ -174,7 +472,7 @@ var goalDeleteCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		goalService := service.NewGoalService(application.Repos.Goal)
+		goalService := service.NewGoalService(application.Repos.Goal, application.Repos.Wallet, application.TxManager)
 
 		id, err := parseUUID(args[0])
 		if err != nil {
@@ -185,21 +483,158 @@ var goalDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Println(successStyle.Render("✅ Goal deleted!"))
+		fmt.Fprintln(stdout, successStyle.Render("✅ Goal deleted!"))
 		return nil
 	},
 }
 
+// goalReorderCmd mengatur urutan prioritas goal aktif.
+var goalReorderCmd = &cobra.Command{
+	Use:   "reorder [goal...]",
+	Short: "Set the priority order of active goals",
+	Long: `Set the order goals are listed in, highest priority first.
+
+Pass goal IDs or names in the new order, or run without arguments to pick
+the order from a numbered list (e.g. "3 1 2"). Goals left out keep their
+current order after the ones listed.`,
+	Example: `  wallet goal reorder
+  wallet goal reorder "New Laptop" "Emergency Fund"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		goalService := service.NewGoalService(application.Repos.Goal, application.Repos.Wallet, application.TxManager)
+
+		goals, err := goalService.ListActive(ctx)
+		if err != nil {
+			return err
+		}
+		if len(goals) == 0 {
+			fmt.Fprintln(stdout, "No active goals to reorder. Create one with: wallet goal add")
+			return nil
+		}
+
+		var order []int
+		if len(args) > 0 {
+			order, err = goalOrderFromArgs(goals, args)
+		} else {
+			if !isInteractive() {
+				return fmt.Errorf("goal reorder needs a terminal; pass the goals in the new order instead")
+			}
+			fmt.Fprintln(stdout, titleStyle.Render("\n🎯 Active Goals\n"))
+			current := make([]string, len(goals))
+			for i, g := range goals {
+				fmt.Fprintf(stdout, "  %d. %s %s\n", i+1, models.DisplayIcon(g.Icon), g.Name)
+				current[i] = strconv.Itoa(i + 1)
+			}
+			fmt.Fprintln(stdout)
+			order, err = parseGoalOrder(prompt("New order", strings.Join(current, " ")), len(goals))
+		}
+		if err != nil {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(order))
+		for i, idx := range order {
+			ids[i] = goals[idx].ID
+		}
+		if err := goalService.Reorder(ctx, ids); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Goals reordered!"))
+		for i, idx := range order {
+			fmt.Fprintf(stdout, "  %d. %s %s\n", i+1, models.DisplayIcon(goals[idx].Icon), goals[idx].Name)
+		}
+		return nil
+	},
+}
+
+// parseGoalOrder mem-parse urutan baru dari nomor 1..n yang dipisah spasi
+// atau koma, lalu mengembalikan index (0-based) semua n goal. Nomor yang
+// tidak disebut ditambahkan di akhir sesuai urutan semula.
+//
+//	parseGoalOrder("3 1", 4) // [2 0 1 3]
+func parseGoalOrder(input string, n int) ([]int, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' })
+
+	seen := make([]bool, n)
+	order := make([]int, 0, n)
+	for _, f := range fields {
+		num, err := strconv.Atoi(f)
+		if err != nil || num < 1 || num > n {
+			return nil, fmt.Errorf("invalid goal number %q (expected 1-%d)", f, n)
+		}
+		if seen[num-1] {
+			return nil, fmt.Errorf("goal %d listed more than once", num)
+		}
+		seen[num-1] = true
+		order = append(order, num-1)
+	}
+
+	return appendUnlisted(order, seen), nil
+}
+
+// goalOrderFromArgs mencari index goal dari ID atau nama (tidak
+// case-sensitive), dengan aturan yang sama seperti parseGoalOrder.
+func goalOrderFromArgs(goals []*models.Goal, args []string) ([]int, error) {
+	seen := make([]bool, len(goals))
+	order := make([]int, 0, len(goals))
+	for _, arg := range args {
+		idx := -1
+		for i, g := range goals {
+			if g.ID.String() == arg || strings.EqualFold(g.Name, arg) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("active goal %q not found", arg)
+		}
+		if seen[idx] {
+			return nil, fmt.Errorf("goal %q listed more than once", arg)
+		}
+		seen[idx] = true
+		order = append(order, idx)
+	}
+
+	return appendUnlisted(order, seen), nil
+}
+
+// appendUnlisted menambahkan index yang belum seen ke akhir order.
+func appendUnlisted(order []int, seen []bool) []int {
+	for i, ok := range seen {
+		if !ok {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
 func init() {
 	// goal list
 	goalListCmd.Flags().BoolP("all", "a", false, "Show all goals including completed")
 	goalCmd.AddCommand(goalListCmd)
 
+	// goal show
+	goalShowCmd.Flags().IntP("limit", "l", 10, "Number of recent contributions to show")
+	goalShowCmd.ValidArgsFunction = completeFirstArg(completeGoalIDs)
+	goalCmd.AddCommand(goalShowCmd)
+
+	// goal forecast
+	goalForecastCmd.Flags().String("monthly-contribution", "", "Fixed contribution per month (default: average of past contributions)")
+	goalForecastCmd.ValidArgsFunction = completeFirstArg(completeGoalIDs)
+	goalCmd.AddCommand(goalForecastCmd)
+
+	// goal completed
+	goalCompletedCmd.Flags().IntP("year", "y", 0, "Calendar year (default: current year)")
+	goalCmd.AddCommand(goalCompletedCmd)
+
 	// goal add
 	goalAddCmd.Flags().StringP("name", "n", "", "Goal name (required)")
 	goalAddCmd.Flags().StringP("target", "t", "", "Target amount (required)")
 	goalAddCmd.Flags().StringP("description", "d", "", "Description")
-	goalAddCmd.Flags().StringP("icon", "i", "🎯", "Goal icon")
+	goalAddCmd.Flags().StringP("icon", "i", "🎯", `Goal icon: one emoji, an icon name like "house", or "pick" to choose from a list`)
+	goalAddCmd.Flags().Bool("net-worth", false, "Track the sum of all wallet balances toward the target instead of contributions")
 	_ = goalAddCmd.MarkFlagRequired("name")
 	_ = goalAddCmd.MarkFlagRequired("target")
 	goalCmd.AddCommand(goalAddCmd)
@@ -210,8 +645,21 @@ func init() {
 	goalContributeCmd.Flags().StringP("note", "n", "", "Contribution note")
 	_ = goalContributeCmd.MarkFlagRequired("goal")
 	_ = goalContributeCmd.MarkFlagRequired("amount")
+	_ = goalContributeCmd.RegisterFlagCompletionFunc("goal", completeGoalIDs)
 	goalCmd.AddCommand(goalContributeCmd)
 
+	// goal auto-contribute
+	goalAutoContributeCmd.Flags().StringP("wallet", "w", "", "Wallet ID or name (required)")
+	goalAutoContributeCmd.Flags().StringP("keep", "k", "0", "Balance to keep in the wallet")
+	_ = goalAutoContributeCmd.MarkFlagRequired("wallet")
+	_ = goalAutoContributeCmd.RegisterFlagCompletionFunc("wallet", completeWalletIDs)
+	goalCmd.AddCommand(goalAutoContributeCmd)
+
 	// goal delete
+	goalDeleteCmd.ValidArgsFunction = completeFirstArg(completeGoalIDs)
 	goalCmd.AddCommand(goalDeleteCmd)
+
+	// goal reorder
+	goalReorderCmd.ValidArgsFunction = completeGoalIDs
+	goalCmd.AddCommand(goalReorderCmd)
 }
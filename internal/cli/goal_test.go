@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestParseGoalOrder(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []int
+		wantErr bool
+	}{
+		{input: "3 1 2", want: []int{2, 0, 1}},
+		{input: "3,1", want: []int{2, 0, 1}},
+		{input: "", want: []int{0, 1, 2}},
+		{input: "2 2", wantErr: true},
+		{input: "4", wantErr: true},
+		{input: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseGoalOrder(tt.input, 3)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseGoalOrder(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseGoalOrder(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestGoalReorder(t *testing.T) {
+	out := string(runPiped(t, "goal", "reorder", "new laptop"))
+	laptop, emergency := strings.Index(out, "New Laptop"), strings.Index(out, "Emergency Fund")
+	if laptop < 0 || emergency < laptop {
+		t.Errorf("output does not list New Laptop first:\n%s", out)
+	}
+
+	status := models.GoalStatusActive
+	goals, err := application.Repos.Goal.List(context.Background(), repository.GoalFilter{Status: &status})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(goals) == 0 || goals[0].Name != "New Laptop" {
+		t.Errorf("first goal = %v, want New Laptop", goals)
+	}
+}
+
+func TestGoalShow(t *testing.T) {
+	out := string(runPiped(t, "goal", "show", "emergency fund"))
+	for _, want := range []string{"Emergency Fund", "Remaining:", "Deadline:  none", "Initial savings"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	rootCmd.SetArgs([]string{"goal", "show", "Nope"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "goal not found: Nope") {
+		t.Errorf("goal show unknown error = %v, want goal not found", err)
+	}
+}
+
+func TestGoalCompleted(t *testing.T) {
+	out := string(runPiped(t, "goal", "completed"))
+	if !strings.Contains(out, "Bali Trip") || strings.Contains(out, "New Laptop") {
+		t.Errorf("output should list only the completed Bali Trip goal:\n%s", out)
+	}
+
+	t.Cleanup(func() { _ = goalCompletedCmd.Flags().Set("year", "0") })
+	out = string(runPiped(t, "goal", "completed", "--year", "2000"))
+	if !strings.Contains(out, "No goals completed in 2000") {
+		t.Errorf("output for 2000 = %q, want no goals", out)
+	}
+
+	// Demo goal dibuat hari ini, jadi tahun lalu kosong
+	last := time.Now().Year() - 1
+	goals, err := application.Repos.Goal.ListCompletedInPeriod(context.Background(),
+		time.Date(last, 1, 1, 0, 0, 0, 0, time.Local), time.Date(last, 12, 31, 23, 59, 59, 0, time.Local))
+	if err != nil || len(goals) != 0 {
+		t.Errorf("ListCompletedInPeriod(last year) = %v, %v; want none", goals, err)
+	}
+}
+
+func TestGoalForecast(t *testing.T) {
+	flag := goalForecastCmd.Flags().Lookup("monthly-contribution")
+	t.Cleanup(func() { flag.Value.Set(""); flag.Changed = false })
+
+	now := time.Now()
+	next := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.Local).Format("Jan 2006")
+	out := string(runPiped(t, "goal", "forecast", "new laptop", "--monthly-contribution", "100000000"))
+	for _, want := range []string{"PROJECTED BALANCE", "100.0%", "Reached in " + next} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	// Tanpa flag: rata-rata kontribusi
+	flag.Value.Set("")
+	flag.Changed = false
+	out = string(runPiped(t, "goal", "forecast", "emergency fund"))
+	if !strings.Contains(out, "average of past contributions") {
+		t.Errorf("output does not use the contribution average:\n%s", out)
+	}
+}
+
+func TestGoalAdd_NetWorth(t *testing.T) {
+	t.Cleanup(func() {
+		_ = goalAddCmd.Flags().Set("net-worth", "false")
+		_ = goalAddCmd.Flags().Set("name", "")
+		_ = goalAddCmd.Flags().Set("target", "")
+	})
+
+	out := string(runPiped(t, "goal", "add", "-n", "Net Worth 1B", "-t", "1000000000", "--net-worth"))
+	if !strings.Contains(out, "Net worth now:") {
+		t.Errorf("goal add output missing the live net worth:\n%s", out)
+	}
+
+	out = string(execPiped(t, "goal", "list"))
+	if !strings.Contains(out, "Net Worth 1B (net worth)") {
+		t.Errorf("goal list does not mark the net worth goal:\n%s", out)
+	}
+
+	goals, err := application.Repos.Goal.List(context.Background(), repository.GoalFilter{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, g := range goals {
+		if g.Name == "Net Worth 1B" && g.Kind != models.GoalKindNetWorth {
+			t.Errorf("Kind = %s, want net_worth", g.Kind)
+		}
+	}
+}
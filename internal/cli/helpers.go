@@ -1,10 +1,310 @@
 package cli
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/config"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/tui/components/progress"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
+// setRateProvider mengaktifkan snapshot kurs (Transaction.RateToBase) di
+// txService jika app.rates dikonfigurasi.
+func setRateProvider(txService *service.TransactionService) {
+	cfg := application.Config.App
+	// Sudah divalidasi saat config di-load (Config.Validate)
+	rates, _ := cfg.ExchangeRates()
+	if len(rates) == 0 {
+		return
+	}
+	txService.SetRateProvider(service.NewStaticRates(cfg.Currency, rates), cfg.Currency)
+}
+
+// setMaxAmount mengaktifkan batas app.max_transaction_amount di txService.
+func setMaxAmount(txService *service.TransactionService) {
+	// Sudah divalidasi saat config di-load (Config.Validate)
+	limit, _ := application.Config.App.MaxTransactionLimit()
+	txService.SetMaxAmount(limit)
+}
+
+// walletCurrencies memetakan wallet ID ke currency-nya, supaya nominal
+// transaksi ditampilkan dengan desimal sesuai currency wallet masing-masing.
+// Jika gagal, map kosong dikembalikan dan formatter jatuh ke currency default.
+func walletCurrencies(ctx context.Context) map[uuid.UUID]string {
+	currencies := make(map[uuid.UUID]string)
+
+	wallets, err := application.Repos.Wallet.List(ctx, repository.WalletFilter{})
+	if err != nil {
+		return currencies
+	}
+	for _, w := range wallets {
+		currencies[w.ID] = w.Currency
+	}
+	return currencies
+}
+
 // parseUUID memparse string menjadi UUID.
 func parseUUID(s string) (uuid.UUID, error) {
 	return uuid.Parse(s)
 }
+
+// defaultWalletID mengembalikan app.default_wallet dari config.
+// uuid.Nil jika belum di-set.
+func defaultWalletID() (uuid.UUID, error) {
+	id, err := application.Config.App.DefaultWallet()
+	if errors.Is(err, config.ErrNoDefaultWallet) {
+		return uuid.Nil, nil
+	}
+	return id, err
+}
+
+// resolveWallet mencari wallet berdasarkan ID atau nama (case-insensitive).
+// Nama yang dipakai lebih dari satu wallet dianggap ambigu, user harus
+// memakai ID.
+//
+//	wallet, err := resolveWallet(ctx, "bca")
+func resolveWallet(ctx context.Context, idOrName string) (*models.Wallet, error) {
+	if id, err := uuid.Parse(idOrName); err == nil {
+		wallet, err := application.Repos.Wallet.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("wallet not found: %w", err)
+		}
+		return wallet, nil
+	}
+
+	wallets, err := application.Repos.Wallet.List(ctx, repository.WalletFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+
+	var match *models.Wallet
+	for _, w := range wallets {
+		if !strings.EqualFold(w.Name, strings.TrimSpace(idOrName)) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("wallet name %q is ambiguous, use the wallet ID", idOrName)
+		}
+		match = w
+	}
+	if match == nil {
+		return nil, fmt.Errorf("wallet not found: %s", idOrName)
+	}
+	return match, nil
+}
+
+// resolveGoal mencari goal berdasarkan ID atau nama (case-insensitive),
+// dengan aturan yang sama seperti resolveWallet.
+//
+//	goal, err := resolveGoal(ctx, "new laptop")
+func resolveGoal(ctx context.Context, idOrName string) (*models.Goal, error) {
+	if id, err := uuid.Parse(idOrName); err == nil {
+		goal, err := application.Repos.Goal.GetByID(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("goal not found: %s (see: wallet goal list)", idOrName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get goal: %w", err)
+		}
+		return goal, nil
+	}
+
+	goals, err := application.Repos.Goal.List(ctx, repository.GoalFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list goals: %w", err)
+	}
+
+	var match *models.Goal
+	for _, g := range goals {
+		if !strings.EqualFold(g.Name, strings.TrimSpace(idOrName)) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("goal name %q is ambiguous, use the goal ID", idOrName)
+		}
+		match = g
+	}
+	if match == nil {
+		return nil, fmt.Errorf("goal not found: %s (see: wallet goal list)", idOrName)
+	}
+	return match, nil
+}
+
+// resolveCategory mencari category berdasarkan ID atau nama
+// (case-insensitive, lihat CategoryRepository.GetByName).
+//
+//	category, err := resolveCategory(ctx, "groceries")
+func resolveCategory(ctx context.Context, idOrName string) (*models.Category, error) {
+	var (
+		category *models.Category
+		err      error
+	)
+	if id, parseErr := uuid.Parse(idOrName); parseErr == nil {
+		category, err = application.Repos.Category.GetByID(ctx, id)
+	} else {
+		category, err = application.Repos.Category.GetByName(ctx, strings.TrimSpace(idOrName))
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("category not found: %s (see: wallet category list)", idOrName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return category, nil
+}
+
+// walletFilterIDs me-resolve flag --wallet yang bisa diulang (ID atau
+// nama) menjadi daftar wallet ID untuk TransactionFilter.WalletIDs.
+// Return nil jika flag tidak dipakai (semua wallet).
+//
+//	wallet tx summary --wallet "Card A" --wallet "Card B"
+func walletFilterIDs(cmd *cobra.Command) ([]uuid.UUID, error) {
+	args, _ := cmd.Flags().GetStringArray("wallet")
+
+	var ids []uuid.UUID
+	for _, arg := range args {
+		wallet, err := resolveWallet(cmd.Context(), arg)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, wallet.ID)
+	}
+	return ids, nil
+}
+
+// isInteractive mengecek apakah stdin terhubung ke terminal.
+// False saat dijalankan dari script, cron, atau pipe.
+func isInteractive() bool {
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// confirm menampilkan prompt [y/N] dan membaca jawaban dari stdin.
+// Default (enter saja) adalah "no".
+func confirm(prompt string) bool {
+	fmt.Fprintf(stdout, "%s [y/N]: ", prompt)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// formatDate memformat tanggal untuk output CLI sesuai config
+// (relatif seperti "Today"/"Kemarin", atau ISO jika absolute_dates aktif).
+func formatDate(t time.Time) string {
+	cfg := application.Config.App
+	return utils.FormatDate(t, time.Now(), cfg.Locale, cfg.AbsoluteDates)
+}
+
+// formatLongDate memformat tanggal lengkap sesuai app.locale
+// ("02 Jan 2006" untuk id-ID, "Jan 02, 2006" untuk en-US).
+func formatLongDate(t time.Time) string {
+	return utils.FormatLongDate(t, application.Config.App.Locale)
+}
+
+// prompt menampilkan pertanyaan dengan nilai default dan membaca jawaban.
+// Jawaban kosong (enter saja) mengembalikan def.
+func prompt(label, def string) string {
+	fmt.Fprintf(stdout, "%s [%s]: ", label, def)
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return def
+	}
+	return answer
+}
+
+// newProgressBar membuat progress bar untuk output CLI (dengan label persentase).
+// Jika output tidak mendukung warna (pipe, NO_COLOR), bar memakai glyph polos # dan -.
+func newProgressBar(width int) progress.Bar {
+	bar := progress.New(width)
+	bar.ShowPercent = true
+	if plainOutput {
+		bar.Theme = progress.PlainTheme()
+	}
+	return bar
+}
+
+// copyOrPrint menyalin text ke clipboard sistem. Jika tidak ada tool
+// clipboard (atau copy gagal), text ditulis ke os.Stdout apa adanya
+// (emoji tidak dibuang, lihat writeExport) beserta notice di stderr.
+func copyOrPrint(text string) {
+	clip, err := utils.SystemClipboard()
+	if err == nil {
+		err = clip.Copy(text)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, warningStyle.Render(fmt.Sprintf("⚠️  Could not copy to clipboard (%v), printing instead", err)))
+		fmt.Fprint(os.Stdout, text)
+		return
+	}
+	fmt.Fprintln(stdout, successStyle.Render("📋 Copied to clipboard!"))
+}
+
+// iconPickerValue adalah nilai --icon yang membuka icon picker.
+const iconPickerValue = "pick"
+
+// iconPickerColumns adalah jumlah icon per baris di icon picker.
+const iconPickerColumns = 4
+
+// resolveIcon mengembalikan icon dari flag --icon. Untuk "--icon pick"
+// icon picker ditampilkan, dengan def sebagai pilihan default.
+// Validasi icon (satu emoji atau nama icon) dilakukan oleh model.
+func resolveIcon(icon, def string) (string, error) {
+	if icon != iconPickerValue {
+		return icon, nil
+	}
+	if !isInteractive() {
+		return "", errors.New("--icon pick needs an interactive terminal; pass an emoji or icon name instead")
+	}
+	return pickIcon(def), nil
+}
+
+// pickIcon menampilkan icon yang umum dipakai (models.IconGroups) dalam
+// grid bernomor per tema, lalu membaca pilihan: nomor, nama icon, atau
+// emoji lain. Jawaban kosong mengembalikan def.
+func pickIcon(def string) string {
+	fmt.Fprintln(stdout, titleStyle.Render("\n🎨 Pick an icon\n"))
+
+	var icons []models.NamedIcon
+	for _, group := range models.IconGroups {
+		fmt.Fprintf(stdout, "   %s\n", group.Name)
+		for i, icon := range group.Icons {
+			icons = append(icons, icon)
+			cell := fmt.Sprintf("%3d %s %s", len(icons), icon.Emoji, icon.Name)
+			if (i+1)%iconPickerColumns == 0 || i == len(group.Icons)-1 {
+				fmt.Fprintln(stdout, "   "+cell)
+			} else {
+				fmt.Fprint(stdout, "   "+utils.PadRight(cell, 18))
+			}
+		}
+		fmt.Fprintln(stdout)
+	}
+
+	answer := prompt("Icon (number, name or emoji)", def)
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(icons) {
+		return icons[n-1].Emoji
+	}
+	return answer
+}
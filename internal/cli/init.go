@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/database"
+)
+
+// initCmd menjalankan migration database (setara `migrate up`).
+var initCmd = &cobra.Command{
+	Use:         "init",
+	Short:       "🗄️ Initialize or upgrade the database schema",
+	Annotations: requiresDB,
+	Long: `Apply pending database migrations.
+
+Use --check to see every migration with its applied/pending state and the
+migrations that would run, without changing anything. A dirty database
+(a migration that failed halfway) is reported with repair steps.`,
+	Example: `  wallet init
+  wallet init --check
+  wallet init --to 9`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		check, _ := cmd.Flags().GetBool("check")
+		to, _ := cmd.Flags().GetUint("to")
+
+		migrator, err := newMigrator(cmd)
+		if err != nil {
+			return err
+		}
+		defer migrator.Close()
+
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		if check {
+			printMigrationStatus(status)
+		}
+
+		if status.Dirty {
+			printDirtyHint(status)
+			return errors.New("database is in a dirty state")
+		}
+		if err := status.CheckUpTarget(to); err != nil {
+			return err
+		}
+
+		pending := status.PendingUpTo(to)
+		if len(pending) == 0 {
+			fmt.Fprintln(stdout, successStyle.Render("✅ Database is up to date"))
+			return nil
+		}
+
+		if check {
+			fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf("\n⏳ %d migration(s) would run:", len(pending))))
+			for _, mig := range pending {
+				fmt.Fprintf(stdout, "   %06d  %s\n", mig.Version, mig.Name)
+			}
+			fmt.Fprintln(stdout, "\nRun 'wallet init' to apply them.")
+			return nil
+		}
+
+		if to != 0 {
+			err = migrator.Migrate(to)
+		} else {
+			err = migrator.Up()
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render(fmt.Sprintf("✅ Applied %d migration(s)", len(pending))))
+		for _, mig := range pending {
+			fmt.Fprintf(stdout, "   %06d  %s\n", mig.Version, mig.Name)
+		}
+		return nil
+	},
+}
+
+// printMigrationStatus menampilkan semua migration beserta state-nya.
+func printMigrationStatus(status *database.MigrationStatus) {
+	fmt.Fprintln(stdout, titleStyle.Render("\n🗄️ Migration Status\n"))
+
+	if status.Dirty {
+		fmt.Fprintln(stdout, errorStyle.Render(fmt.Sprintf("❌ DIRTY: version %d did not finish\n", status.Version)))
+	}
+
+	table := tablewriter.NewTable(stdout)
+	table.Header("Version", "Name", "State")
+	for _, mig := range status.Migrations {
+		state := string(mig.State)
+		switch mig.State {
+		case database.MigrationApplied:
+			state = successStyle.Render(state)
+		case database.MigrationPending:
+			state = warningStyle.Render(state)
+		case database.MigrationDirty:
+			state = errorStyle.Render(state)
+		}
+		table.Append([]string{fmt.Sprintf("%06d", mig.Version), mig.Name, state})
+	}
+	table.Render()
+
+	fmt.Fprintf(stdout, "Current version: %d, pending: %d\n", status.Version, len(status.Pending()))
+}
+
+// printDirtyHint menampilkan langkah perbaikan dirty state.
+func printDirtyHint(status *database.MigrationStatus) {
+	fmt.Fprintln(stdout, errorStyle.Render("\n❌ The database is in a dirty state"))
+	fmt.Fprintln(stdout, status.DirtyHint())
+	fmt.Fprintln(stdout, "\nforce is available via: go run cmd/migrate/main.go force <version>")
+}
+
+func init() {
+	initCmd.Flags().Bool("check", false, "Show migration status and what would run, without applying")
+	initCmd.Flags().Uint("to", 0, "Stop at this migration version instead of the latest")
+	initCmd.Flags().String("migrations", "migrations", "Path to the migrations folder")
+}
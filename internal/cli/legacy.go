@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// migrateCmd adalah parent command untuk migrasi data lama (bukan
+// schema; lihat `wallet db migrate`).
+var migrateCmd = &cobra.Command{
+	Use:         "migrate",
+	Short:       "🧳 Convert legacy data to newer features",
+	Annotations: requiresDB,
+}
+
+// migrateTransfersCmd mengubah pasangan transaksi transfer lama menjadi
+// record Transfer.
+var migrateTransfersCmd = &cobra.Command{
+	Use:   "transfers",
+	Short: "Convert legacy paired transactions into transfers",
+	Long: `Before transfers existed, a transfer was recorded as an expense in one
+wallet and an income in another, both tagged "transfer". This finds those
+pairs (same amount, same day, different wallets with the same currency)
+and replaces each pair with a transfer, in a single database transaction.
+
+Wallet balances do not change. Tagged transactions without a match are
+listed and left as they are.`,
+	Example: `  wallet migrate transfers --dry-run
+  wallet migrate transfers`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		legacyService := service.NewLegacyTransferService(
+			application.Repos.Transaction,
+			application.Repos.Transfer,
+			application.Repos.Wallet,
+			application.TxManager,
+		)
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		scan, err := legacyService.Find(ctx)
+		if err != nil {
+			return err
+		}
+
+		refs, err := service.LoadRefData(ctx, application.Repos.Wallet, application.Repos.Category)
+		if err != nil {
+			return err
+		}
+
+		if len(scan.Pairs) == 0 {
+			fmt.Fprintln(stdout, "No legacy transfers found.")
+		} else {
+			currencies := walletCurrencies(ctx)
+
+			table := tablewriter.NewTable(stdout)
+			table.Header("Date", "From", "To", "Amount", "Description")
+			for _, p := range scan.Pairs {
+				table.Append([]string{
+					formatDate(p.Expense.TransactionDate),
+					refs.WalletName(p.Expense.WalletID),
+					refs.WalletName(p.Income.WalletID),
+					formatMoneyIn(p.Expense.Amount, p.Expense.WalletID, currencies),
+					p.Expense.Description,
+				})
+			}
+			table.Render()
+		}
+
+		if n := len(scan.Unmatched); n > 0 {
+			fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf(
+				"⚠️  %d transaction(s) tagged transfer have no matching pair and are left unchanged", n)))
+		}
+
+		if len(scan.Pairs) == 0 {
+			return nil
+		}
+		if dryRun {
+			fmt.Fprintf(stdout, "\nDry run: %d pair(s) would be converted. Run without --dry-run to apply.\n", len(scan.Pairs))
+			return nil
+		}
+
+		transfers, err := legacyService.Convert(ctx, scan.Pairs)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render(fmt.Sprintf("✅ Converted %d pair(s) into transfers", len(transfers))))
+		return nil
+	},
+}
+
+func init() {
+	migrateTransfersCmd.Flags().Bool("dry-run", false, "Show the pairs that would be converted without changing anything")
+
+	migrateCmd.AddCommand(migrateTransfersCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
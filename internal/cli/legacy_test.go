@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/app"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestMigrateTransfers(t *testing.T) {
+	ctx := context.Background()
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	application = demo
+
+	wallets, err := demo.Repos.Wallet.List(ctx, repository.WalletFilter{})
+	if err != nil || len(wallets) < 2 {
+		t.Fatalf("List() = %d wallets, %v; want at least 2", len(wallets), err)
+	}
+	from, to := wallets[0], wallets[1]
+
+	day := time.Date(2023, 5, 10, 9, 0, 0, 0, time.Local)
+	for _, tx := range []*models.Transaction{
+		models.NewTransaction(from.ID, models.TransactionTypeExpense, decimal.NewFromInt(250000)),
+		models.NewTransaction(to.ID, models.TransactionTypeIncome, decimal.NewFromInt(250000)),
+	} {
+		tx.Description = "Top up"
+		tx.TransactionDate = day
+		tx.Tags = []string{models.TagTransfer}
+		if err := demo.Repos.Transaction.Create(ctx, tx); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	t.Cleanup(func() { _ = migrateTransfersCmd.Flags().Set("dry-run", "false") })
+	out := string(execPiped(t, "migrate", "transfers", "--dry-run"))
+	if !strings.Contains(out, "Dry run: 1 pair(s)") || !strings.Contains(out, "Top up") {
+		t.Errorf("dry run output = %q, want the pair listed", out)
+	}
+	if transfers, _ := demo.Repos.Transfer.List(ctx, repository.TransferFilter{}, repository.ListParams{Limit: 100}); countNote(transfers, "Top up") != 0 {
+		t.Fatal("dry run created a transfer")
+	}
+
+	_ = migrateTransfersCmd.Flags().Set("dry-run", "false")
+	out = string(execPiped(t, "migrate", "transfers"))
+	if !strings.Contains(out, "Converted 1 pair(s)") {
+		t.Errorf("output = %q, want 1 pair converted", out)
+	}
+	transfers, err := demo.Repos.Transfer.List(ctx, repository.TransferFilter{}, repository.ListParams{Limit: 100})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if countNote(transfers, "Top up") != 1 {
+		t.Error("legacy pair was not converted into a transfer")
+	}
+}
+
+// countNote menghitung transfer dengan note tersebut.
+func countNote(transfers []*models.Transfer, note string) int {
+	n := 0
+	for _, tr := range transfers {
+		if tr.Note == note {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/database"
+)
+
+// dbPingTimeout adalah batas waktu `db ping`.
+const dbPingTimeout = 5 * time.Second
+
+// dbCmd adalah parent command untuk operasi database.
+var dbCmd = &cobra.Command{
+	Use:         "db",
+	Short:       "🗄️ Database migrations and health checks",
+	Annotations: requiresDB,
+	Long: `Manage the database schema and check the connection.
+
+'wallet db migrate' replaces 'go run cmd/migrate/main.go', which is kept
+for existing scripts.`,
+}
+
+// dbMigrateCmd adalah parent command untuk migration.
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Run database migrations",
+	Example: `  wallet db migrate up
+  wallet db migrate down
+  wallet db migrate version
+  wallet db migrate reset`,
+}
+
+// dbMigrateUpCmd menjalankan semua migration yang pending.
+var dbMigrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrator, err := newMigrator(cmd)
+		if err != nil {
+			return err
+		}
+		defer migrator.Close()
+
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		if status.Dirty {
+			printDirtyHint(status)
+			return errors.New("database is in a dirty state")
+		}
+
+		pending := status.Pending()
+		if len(pending) == 0 {
+			fmt.Fprintln(stdout, successStyle.Render("✅ Database is up to date"))
+			return nil
+		}
+
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render(fmt.Sprintf("✅ Applied %d migration(s)", len(pending))))
+		for _, mig := range pending {
+			fmt.Fprintf(stdout, "   %06d  %s\n", mig.Version, mig.Name)
+		}
+		return nil
+	},
+}
+
+// dbMigrateDownCmd me-rollback migration terakhir.
+var dbMigrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back the last migration",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrator, err := newMigrator(cmd)
+		if err != nil {
+			return err
+		}
+		defer migrator.Close()
+
+		if err := migrator.Steps(-1); err != nil {
+			return err
+		}
+
+		// Status, bukan Version: Version gagal jika semua migration sudah di-rollback
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, successStyle.Render(fmt.Sprintf("✅ Rolled back, now at version %d", status.Version)))
+		return nil
+	},
+}
+
+// dbMigrateVersionCmd menampilkan versi migration saat ini.
+var dbMigrateVersionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show the current migration version",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrator, err := newMigrator(cmd)
+		if err != nil {
+			return err
+		}
+		defer migrator.Close()
+
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "📌 Current version: %d (latest: %d, pending: %d)\n",
+			status.Version, status.Latest(), len(status.Pending()))
+		if status.Dirty {
+			fmt.Fprintln(stdout, errorStyle.Render("❌ Dirty: the last migration did not finish"))
+		}
+		return nil
+	},
+}
+
+// dbMigrateResetCmd menghapus semua tabel (Migrator.Drop).
+var dbMigrateResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Drop all tables (ALL DATA IS LOST)",
+	Long: `Drop every table, including schema_migrations, without running the
+down migrations. Run 'wallet db migrate up' afterwards to recreate the schema.
+
+You are asked to confirm unless --yes is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+			if !isInteractive() {
+				return errors.New("refusing to reset without --yes in a non-interactive shell")
+			}
+			if !confirm(warningStyle.Render("⚠️  This deletes ALL data. Continue?")) {
+				fmt.Fprintln(stdout, "Cancelled.")
+				return nil
+			}
+		}
+
+		migrator, err := newMigrator(cmd)
+		if err != nil {
+			return err
+		}
+		defer migrator.Close()
+
+		if err := migrator.Drop(); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Database reset"))
+		fmt.Fprintln(stdout, "   Run 'wallet db migrate up' to recreate the schema.")
+		return nil
+	},
+}
+
+// dbPingCmd mengecek koneksi database.
+var dbPingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Check the database connection",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), dbPingTimeout)
+		defer cancel()
+
+		start := time.Now()
+		if err := application.DB.Ping(ctx); err != nil {
+			return fmt.Errorf("database ping failed: %w", err)
+		}
+
+		db := application.Config.Database
+		fmt.Fprintln(stdout, successStyle.Render(fmt.Sprintf("✅ %s@%s:%d is reachable (%s)",
+			db.Name, db.Host, db.Port, time.Since(start).Round(time.Millisecond))))
+		return nil
+	},
+}
+
+// dbStatsCmd menampilkan statistik connection pool.
+var dbStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show connection pool statistics",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stats := application.DB.Stats()
+
+		fmt.Fprintln(stdout, titleStyle.Render("\n🗄️ Connection Pool\n"))
+		fmt.Fprintf(stdout, "   Total:        %d / %d\n", stats.TotalConns(), stats.MaxConns())
+		fmt.Fprintf(stdout, "   Acquired:     %d\n", stats.AcquiredConns())
+		fmt.Fprintf(stdout, "   Idle:         %d\n", stats.IdleConns())
+		fmt.Fprintf(stdout, "   Constructing: %d\n", stats.ConstructingConns())
+		fmt.Fprintf(stdout, "   Acquires:     %d (%d canceled, %d waited)\n",
+			stats.AcquireCount(), stats.CanceledAcquireCount(), stats.EmptyAcquireCount())
+		fmt.Fprintf(stdout, "   Acquire time: %s\n", stats.AcquireDuration().Round(time.Microsecond))
+		fmt.Fprintf(stdout, "   New conns:    %d\n", stats.NewConnsCount())
+		return nil
+	},
+}
+
+// newMigrator membuat Migrator untuk database dari config, dengan
+// migration files dari flag --migrations.
+func newMigrator(cmd *cobra.Command) (*database.Migrator, error) {
+	dir, _ := cmd.Flags().GetString("migrations")
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid migrations path: %w", err)
+	}
+
+	return database.NewMigrator(application.Config.Database.ConnectionString(), "file://"+filepath.ToSlash(absDir))
+}
+
+func init() {
+	dbMigrateCmd.PersistentFlags().String("migrations", "migrations", "Path to the migrations folder")
+	dbMigrateResetCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+
+	dbMigrateCmd.AddCommand(dbMigrateUpCmd)
+	dbMigrateCmd.AddCommand(dbMigrateDownCmd)
+	dbMigrateCmd.AddCommand(dbMigrateVersionCmd)
+	dbMigrateCmd.AddCommand(dbMigrateResetCmd)
+
+	dbCmd.AddCommand(dbMigrateCmd)
+	dbCmd.AddCommand(dbPingCmd)
+	dbCmd.AddCommand(dbStatsCmd)
+}
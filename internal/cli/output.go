@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
+)
+
+// Styles untuk output berwarna.
+//
+// Semua output command ditulis ke stdout (bukan langsung ke os.Stdout),
+// supaya di mode plain styling dan emoji dekoratif bisa dimatikan
+// dari satu tempat (lihat setupOutput).
+var (
+	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
+	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+	titleStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+	moneyStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	incomeStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	expenseStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	sectionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Underline(true)
+)
+
+// stdout adalah writer untuk semua output command.
+// Di mode plain, stdout membuang emoji dekoratif sebelum menulis ke os.Stdout.
+var stdout io.Writer = os.Stdout
+
+// plainOutput true jika output tanpa warna dan tanpa emoji dekoratif.
+var plainOutput bool
+
+// setupOutput memilih mode output untuk invocation ini.
+//
+// Mode plain aktif jika salah satu:
+//   - flag --no-color
+//   - environment variable NO_COLOR di-set (https://no-color.org)
+//   - stdout bukan terminal (di-pipe atau di-redirect ke file)
+//
+// Dua yang pertama dicek lewat utils.IsColorDisabled, supaya package lain
+// (TUI) memakai aturan yang sama. Di mode plain semua lipgloss style
+// dirender tanpa ANSI escape code.
+func setupOutput(cmd *cobra.Command) {
+	noColor, _ := cmd.Flags().GetBool("no-color")
+	utils.SetColorDisabled(noColor)
+
+	plainOutput = utils.IsColorDisabled() || !term.IsTerminal(int(os.Stdout.Fd()))
+	if !plainOutput {
+		stdout = os.Stdout
+		return
+	}
+
+	lipgloss.SetColorProfile(termenv.Ascii)
+	stdout = &plainWriter{w: os.Stdout}
+}
+
+// plainWriter membuang emoji dekoratif (beserta spasi setelahnya)
+// dari output, misalnya "✅ Wallet created!" menjadi "Wallet created!".
+type plainWriter struct {
+	w io.Writer
+
+	// pending menyimpan byte UTF-8 yang terpotong di akhir Write sebelumnya.
+	pending []byte
+}
+
+// Write menulis p tanpa emoji dekoratif.
+// Return len(p) supaya caller (fmt, tablewriter) tidak menganggap short write.
+func (pw *plainWriter) Write(p []byte) (int, error) {
+	buf := append(pw.pending, p...)
+	pw.pending = nil
+
+	out := make([]byte, 0, len(buf))
+	skipSpace := false
+	for len(buf) > 0 {
+		if !utf8.FullRune(buf) {
+			pw.pending = append(pw.pending, buf...)
+			break
+		}
+
+		r, size := utf8.DecodeRune(buf)
+		chunk := buf[:size]
+		buf = buf[size:]
+
+		switch {
+		case isDecorativeRune(r):
+			skipSpace = true
+			continue
+		case r == ' ' && skipSpace:
+			continue
+		}
+
+		skipSpace = false
+		out = append(out, chunk...)
+	}
+
+	if _, err := pw.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// isDecorativeRune mengecek apakah r adalah emoji/simbol dekoratif.
+// Panah (→) dan box-drawing (└) tidak termasuk karena membawa arti.
+func isDecorativeRune(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // emoji & pictographs (💰, 📊, 🏷)
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // misc technical (⏭, ⌨, ⏰)
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // misc symbols & dingbats (⚠, ✅, ❌)
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // ⭐, ⬆
+		return true
+	case r == 0xFE0F || r == 0x200D: // variation selector & zero-width joiner
+		return true
+	}
+	return false
+}
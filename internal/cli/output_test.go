@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Adityanrhm/wallet-twin/internal/app"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
+)
+
+// runPiped menjalankan root command dengan os.Stdout diganti pipe,
+// sama seperti `wallet ... > out.txt`, lalu mengembalikan output-nya.
+func runPiped(t *testing.T, args ...string) []byte {
+	t.Helper()
+
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	application = demo
+
+	return execPiped(t, args...)
+}
+
+// execPiped seperti runPiped, tapi memakai application yang sudah ada,
+// untuk command lanjutan yang harus melihat perubahan command sebelumnya.
+func execPiped(t *testing.T, args ...string) []byte {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+
+	origStdout := os.Stdout
+	os.Stdout = w
+	t.Cleanup(func() {
+		os.Stdout = origStdout
+		stdout = origStdout
+		application = nil
+	})
+
+	// Baca di goroutine terpisah supaya buffer pipe tidak penuh.
+	captured := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		captured <- data
+	}()
+
+	rootCmd.SetArgs(args)
+	execErr := rootCmd.Execute()
+	_ = w.Close()
+	out := <-captured
+
+	if execErr != nil {
+		t.Fatalf("%v: error = %v", args, execErr)
+	}
+	return out
+}
+
+func TestPipedOutputHasNoANSI(t *testing.T) {
+	commands := [][]string{
+		{"wallet", "list"},
+		{"tx", "list"},
+		{"tx", "summary"},
+		{"goal", "list"},
+		{"category", "list"},
+	}
+
+	for _, args := range commands {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			out := runPiped(t, args...)
+
+			if len(out) == 0 {
+				t.Fatal("output is empty")
+			}
+			if i := bytes.IndexByte(out, 0x1b); i >= 0 {
+				t.Errorf("output contains ESC at byte %d:\n%q", i, out)
+			}
+		})
+	}
+}
+
+func TestNoColorFlag(t *testing.T) {
+	t.Setenv(utils.NoColorEnv, "")
+	t.Cleanup(func() { utils.SetColorDisabled(false) })
+
+	runPiped(t, "--no-color", "wallet", "list")
+	if !utils.IsColorDisabled() {
+		t.Error("IsColorDisabled() = false after --no-color")
+	}
+
+	// Flag tidak terbawa ke invocation berikutnya
+	runPiped(t, "--no-color=false", "wallet", "list")
+	if utils.IsColorDisabled() {
+		t.Error("IsColorDisabled() = true without --no-color")
+	}
+
+	t.Setenv(utils.NoColorEnv, "1")
+	runPiped(t, "--no-color=false", "wallet", "list")
+	if !utils.IsColorDisabled() {
+		t.Error("IsColorDisabled() = false with NO_COLOR set")
+	}
+}
+
+func TestPlainWriter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"✅ Wallet created!\n", "Wallet created!\n"},
+		{"   📁 File: backup.json\n", "   File: backup.json\n"},
+		{"⚠️  Over budget\n", "Over budget\n"},
+		{"Food → Snacks  └ Coffee\n", "Food → Snacks  └ Coffee\n"},
+		{"Rp 50.000\n", "Rp 50.000\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		pw := &plainWriter{w: &buf}
+
+		n, err := pw.Write([]byte(tt.in))
+		if err != nil || n != len(tt.in) {
+			t.Errorf("Write(%q) = %d, %v; want %d, nil", tt.in, n, err, len(tt.in))
+		}
+		if buf.String() != tt.want {
+			t.Errorf("Write(%q) wrote %q, want %q", tt.in, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestPlainWriter_SplitRune(t *testing.T) {
+	var buf bytes.Buffer
+	pw := &plainWriter{w: &buf}
+
+	// "💰 Total" dipotong di tengah byte emoji.
+	in := []byte("💰 Total")
+	_, _ = pw.Write(in[:2])
+	_, _ = pw.Write(in[2:])
+
+	if buf.String() != "Total" {
+		t.Errorf("wrote %q, want %q", buf.String(), "Total")
+	}
+}
@@ -0,0 +1,282 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/export"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// recurringCmd adalah parent command untuk recurring transactions.
+var recurringCmd = &cobra.Command{
+	Use:         "recurring",
+	Aliases:     []string{"rec", "r"},
+	Short:       "🔁 Manage recurring transactions",
+	Annotations: requiresDB,
+	Long:        "Process recurring transactions, run the background scheduler and export the schedule to a calendar.",
+}
+
+// recurringDaemonCmd menjalankan scheduler sebagai long-running process.
+//
+// Setiap putaran: process recurring yang jatuh tempo + roll over budget.
+// Berhenti dengan bersih saat menerima SIGINT/SIGTERM.
+var recurringDaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run the recurring/budget scheduler in watch mode",
+	Example: `  wallet recurring daemon --interval 1h --jitter 5m
+  wallet recurring daemon --once`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		jitter, _ := cmd.Flags().GetDuration("jitter")
+		once, _ := cmd.Flags().GetBool("once")
+
+		if interval <= 0 {
+			return fmt.Errorf("interval must be positive")
+		}
+		if jitter < 0 {
+			return fmt.Errorf("jitter must not be negative")
+		}
+
+		recurringService := newRecurringService()
+		budgetService := service.NewBudgetService(
+			application.Repos.Budget,
+			application.Repos.Transaction,
+		)
+		budgetService.SetMonthStartDay(application.Config.App.MonthStartDay)
+
+		scheduler := service.NewScheduler(recurringService, budgetService, service.SchedulerOptions{
+			Interval: interval,
+			Jitter:   jitter,
+			Logf: func(format string, args ...interface{}) {
+				fmt.Fprintf(stdout, "[%s] %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, args...))
+			},
+		})
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if once {
+			return scheduler.RunOnce(ctx)
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render(fmt.Sprintf("🔁 Scheduler started (every %s, jitter %s)", interval, jitter)))
+		if err := scheduler.Run(ctx); err != nil && err != context.Canceled {
+			return err
+		}
+
+		fmt.Fprintln(stdout, "Scheduler stopped.")
+		return nil
+	},
+}
+
+// recurringDueCmd menampilkan recurring yang jatuh tempo.
+var recurringDueCmd = &cobra.Command{
+	Use:   "due",
+	Short: "List recurring transactions that are due",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		due, err := newRecurringService().GetDue(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(due) == 0 {
+			fmt.Fprintln(stdout, "No recurring transactions are due.")
+			return nil
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render("\n🔁 Due Recurring Transactions\n"))
+
+		currencies := walletCurrencies(ctx)
+
+		table := tablewriter.NewTable(stdout)
+		table.Header("ID", "Due", "Type", "Amount", "Frequency", "Description")
+
+		for _, rec := range due {
+			table.Append([]string{
+				rec.ID.String(),
+				formatDate(rec.NextDue),
+				string(rec.Type),
+				formatMoneyIn(rec.Amount, rec.WalletID, currencies),
+				rec.Frequency.String(),
+				truncate(rec.Description, 30),
+			})
+		}
+
+		table.Render()
+		fmt.Fprintln(stdout, "\nProcess selected items with: wallet recurring process <id>...")
+		return nil
+	},
+}
+
+// recurringProcessCmd men-generate transaksi untuk recurring tertentu saja.
+var recurringProcessCmd = &cobra.Command{
+	Use:   "process [id...]",
+	Short: "Generate transactions for specific due recurring transactions",
+	Example: `  wallet recurring due
+  wallet recurring process 3f2b... 9a1c...`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		recurringService := newRecurringService()
+		currencies := walletCurrencies(ctx)
+
+		failed := 0
+		for _, arg := range args {
+			id, err := parseUUID(arg)
+			if err != nil {
+				fmt.Fprintln(stdout, errorStyle.Render(fmt.Sprintf("❌ %s: %v", arg, err)))
+				failed++
+				continue
+			}
+
+			tx, err := recurringService.ProcessOne(ctx, id)
+			if err != nil {
+				fmt.Fprintln(stdout, errorStyle.Render(fmt.Sprintf("❌ %s: %v", arg, err)))
+				failed++
+				continue
+			}
+
+			fmt.Fprintf(stdout, "%s %s %s %s\n",
+				successStyle.Render("✅"),
+				formatMoneyIn(tx.Amount, tx.WalletID, currencies),
+				string(tx.Type),
+				truncate(tx.Description, 30),
+			)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d recurring transaction(s) failed", failed, len(args))
+		}
+		return nil
+	},
+}
+
+// recurringExportICalCmd menulis jadwal recurring ke file iCalendar
+// supaya tagihan muncul di kalender HP.
+var recurringExportICalCmd = &cobra.Command{
+	Use:   "export-ical",
+	Short: "Export upcoming recurring transactions to an iCalendar (.ics) file",
+	Long: `Export the recurring schedule as an iCalendar file that phone and
+desktop calendars can import or subscribe to.
+
+By default every due date in the next --days days is written as its own
+all-day event. Event IDs are derived from the recurring transaction and
+the date, so importing a newer file updates existing events instead of
+duplicating them.
+
+With --use-rrule, each active recurring transaction becomes a single
+repeating event (RRULE, ending at its end date) and --days is ignored.`,
+	Example: `  wallet recurring export-ical --days 365 -o bills.ics
+  wallet recurring export-ical --use-rrule -o bills.ics
+  wallet recurring export-ical -o - > bills.ics`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		days, _ := cmd.Flags().GetInt("days")
+		useRRule, _ := cmd.Flags().GetBool("use-rrule")
+		output, _ := cmd.Flags().GetString("output")
+
+		if days <= 0 {
+			return fmt.Errorf("days must be positive")
+		}
+
+		toStdout, err := exportToStdout(cmd, "ics")
+		if err != nil {
+			return err
+		}
+		if output == "" && !toStdout {
+			output = fmt.Sprintf("recurring-%s.ics", time.Now().Format("20060102"))
+		}
+
+		refs, err := service.LoadRefData(ctx, application.Repos.Wallet, application.Repos.Category)
+		if err != nil {
+			return err
+		}
+
+		recurringService := newRecurringService()
+		currency := application.Config.App.Currency
+		now := time.Now()
+
+		var (
+			write  func(w io.Writer) error
+			events int
+		)
+		if useRRule {
+			recurrings, err := recurringService.ListActive(ctx)
+			if err != nil {
+				return err
+			}
+			events = len(recurrings)
+			write = func(w io.Writer) error {
+				return export.WriteRecurringICalRRule(w, recurrings, refs, currency, now)
+			}
+		} else {
+			from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+			schedule, err := recurringService.GenerateSchedule(ctx, from, from.AddDate(0, 0, days))
+			if err != nil {
+				return err
+			}
+			events = len(schedule)
+			write = func(w io.Writer) error {
+				return export.WriteRecurringICal(w, schedule, refs, currency, now)
+			}
+		}
+
+		if err := writeExport(output, toStdout, write); err != nil {
+			return err
+		}
+		if toStdout {
+			return nil
+		}
+
+		absPath, _ := filepath.Abs(output)
+		fmt.Fprintln(stdout, successStyle.Render("✅ Recurring calendar exported!"))
+		fmt.Fprintf(stdout, "   📁 File: %s\n", absPath)
+		fmt.Fprintf(stdout, "   📅 Events: %d\n", events)
+		return nil
+	},
+}
+
+// newRecurringService membuat RecurringService dari repositories aplikasi.
+func newRecurringService() *service.RecurringService {
+	txService := service.NewTransactionService(
+		application.Repos.Transaction,
+		application.Repos.Wallet,
+		application.Repos.Category,
+		application.TxManager,
+	)
+	setRateProvider(txService)
+	setMaxAmount(txService)
+	return service.NewRecurringService(application.Repos.Recurring, txService)
+}
+
+func init() {
+	// recurring daemon
+	recurringDaemonCmd.Flags().Duration("interval", service.DefaultSchedulerInterval, "Time between runs")
+	recurringDaemonCmd.Flags().Duration("jitter", time.Minute, "Maximum random delay added to each interval")
+	recurringDaemonCmd.Flags().Bool("once", false, "Run a single pass and exit")
+	recurringCmd.AddCommand(recurringDaemonCmd)
+
+	// recurring due / process
+	recurringCmd.AddCommand(recurringDueCmd)
+	recurringCmd.AddCommand(recurringProcessCmd)
+
+	// recurring export-ical
+	recurringExportICalCmd.Flags().Int("days", 365, "Number of days ahead to include")
+	recurringExportICalCmd.Flags().Bool("use-rrule", false, "Write one repeating event per recurring transaction")
+	recurringExportICalCmd.Flags().StringP("output", "o", "", "Output filename (- for stdout)")
+	recurringExportICalCmd.Flags().Bool("stdout", false, "Write to stdout (same as -o -)")
+	recurringCmd.AddCommand(recurringExportICalCmd)
+}
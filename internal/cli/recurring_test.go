@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/app"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestRecurringExportICal(t *testing.T) {
+	ctx := context.Background()
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	application = demo
+
+	wallets, err := demo.Repos.Wallet.List(ctx, repository.WalletFilter{})
+	if err != nil || len(wallets) == 0 {
+		t.Fatalf("List() = %d wallets, %v; want at least 1", len(wallets), err)
+	}
+
+	now := time.Now()
+	netflix := models.NewRecurringTransaction(wallets[0].ID, models.TransactionTypeExpense, decimal.NewFromInt(186000), models.RecurringMonthly,
+		time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1))
+	netflix.Description = "Netflix"
+	if err := demo.Repos.Recurring.Create(ctx, netflix); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	output := filepath.Join(t.TempDir(), "bills.ics")
+	t.Cleanup(func() {
+		_ = recurringExportICalCmd.Flags().Set("output", "")
+		_ = recurringExportICalCmd.Flags().Set("days", "365")
+	})
+
+	out := string(execPiped(t, "recurring", "export-ical", "--days", "45", "-o", output))
+	if !strings.Contains(out, "Recurring calendar exported") || !strings.Contains(out, "Events: 2") {
+		t.Errorf("output = %q, want 2 monthly events in 45 days", out)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if got := strings.Count(string(data), "BEGIN:VEVENT"); got != 2 {
+		t.Errorf("file has %d events, want 2", got)
+	}
+	if !strings.Contains(string(data), "Netflix") {
+		t.Errorf("file does not mention Netflix:\n%s", data)
+	}
+}
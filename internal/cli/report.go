@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/export"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// reportCmd adalah parent command untuk laporan.
+var reportCmd = &cobra.Command{
+	Use:         "report",
+	Short:       "🧾 Reports",
+	Annotations: requiresDB,
+}
+
+// reportSharedCmd menghitung settle-up pengeluaran bersama.
+var reportSharedCmd = &cobra.Command{
+	Use:   "shared",
+	Short: "Settle up expenses shared with someone else",
+	Long: `Sum the expenses tagged as shared in a period, split them between you
+and your partner, and show who owes whom.
+
+--split is your share in percent (50 splits evenly, 60 means you pay 60%).
+Transactions that also carry the reimbursed tag are settlements, not
+expenses: an income is money your partner paid you back, an expense is
+money you sent them. Other shared incomes are refunds and reduce the
+shared spend. Transactions with the --exclude tag are ignored.
+
+A period is a month (YYYY-MM), a date range (YYYY-MM-DD..YYYY-MM-DD),
+this-month, last-month, this-year, last-year, ytd, last-ytd or FY<year>.
+Months follow app.month_start_day.
+
+Use -o to write the shared transactions as CSV for your partner; the
+Partner Owes column adds up to the balance.`,
+	Example: `  wallet report shared
+  wallet report shared --tag shared --split 60 --period last-month
+  wallet report shared --exclude personal --partner Sari
+  wallet report shared -o shared-january.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		periodStr, _ := cmd.Flags().GetString("period")
+		tag, _ := cmd.Flags().GetString("tag")
+		reimbursedTag, _ := cmd.Flags().GetString("reimbursed-tag")
+		excludeTag, _ := cmd.Flags().GetString("exclude")
+		split, _ := cmd.Flags().GetFloat64("split")
+		partner, _ := cmd.Flags().GetString("partner")
+		output, _ := cmd.Flags().GetString("output")
+
+		filter, err := parsePeriod(periodStr)
+		if err != nil {
+			return fmt.Errorf("invalid --period: %w", err)
+		}
+
+		refs, err := service.LoadRefData(ctx, application.Repos.Wallet, application.Repos.Category)
+		if err != nil {
+			return err
+		}
+
+		txService := service.NewTransactionService(application.Repos.Transaction, application.Repos.Wallet, application.Repos.Category, application.TxManager)
+		report, err := service.NewSharedExpenseService(txService).Report(ctx, filter, service.SharedExpenseOptions{
+			Tag:           tag,
+			ReimbursedTag: reimbursedTag,
+			ExcludeTag:    excludeTag,
+			Split:         decimal.NewFromFloat(split),
+		}, refs)
+		if err != nil {
+			return err
+		}
+
+		if output == stdoutOutput {
+			return writeExport(output, true, func(w io.Writer) error {
+				return export.WriteSharedExpensesCSV(w, report, refs)
+			})
+		}
+
+		printSharedReport(report, periodTitle(periodStr, time.Now(), fiscalYearStartMonth()), tag, partner)
+
+		if output != "" {
+			if err := writeExport(output, false, func(w io.Writer) error {
+				return export.WriteSharedExpensesCSV(w, report, refs)
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintln(stdout, successStyle.Render(fmt.Sprintf("\n✅ Shared transactions exported to %s", output)))
+		}
+		return nil
+	},
+}
+
+// printSharedReport menampilkan breakdown per kategori, total, dan siapa
+// berutang ke siapa.
+func printSharedReport(report *service.SharedExpenseReport, period, tag, partner string) {
+	partnerSplit := decimal.NewFromInt(100).Sub(report.Split)
+	fmt.Fprintln(stdout, titleStyle.Render(fmt.Sprintf("\n🤝 Shared expenses – %s\n", period)))
+	fmt.Fprintf(stdout, "   Tag #%s, split you %s%% / %s %s%%\n\n", strings.TrimPrefix(tag, "#"), report.Split, partner, partnerSplit)
+
+	if len(report.Expenses) == 0 && len(report.Reimbursements) == 0 {
+		fmt.Fprintln(stdout, "No shared transactions in this period.")
+		return
+	}
+
+	if len(report.Categories) > 0 {
+		table := tablewriter.NewTable(stdout)
+		table.Header("Category", "Total", "You", partner)
+		for _, c := range report.Categories {
+			table.Append([]string{c.Name, formatMoney(c.Total), formatMoney(c.YourShare), formatMoney(c.PartnerShare)})
+		}
+		table.Render()
+		fmt.Fprintln(stdout)
+	}
+
+	fmt.Fprintf(stdout, "   Shared spend:   %s (%d transactions)\n", moneyStyle.Render(formatMoney(report.Total)), len(report.Expenses))
+	fmt.Fprintf(stdout, "   Your share:     %s\n", formatMoney(report.YourShare))
+	fmt.Fprintf(stdout, "   %s's share: %s\n", partner, formatMoney(report.PartnerShare))
+	if len(report.Reimbursements) > 0 {
+		fmt.Fprintf(stdout, "   Reimbursed:     %s (%d transactions)\n", formatMoney(report.Reimbursed), len(report.Reimbursements))
+	}
+	fmt.Fprintln(stdout)
+
+	switch balance := report.Balance(); {
+	case balance.IsPositive():
+		fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf("💸 %s owes you %s", partner, formatMoney(balance))))
+	case balance.IsNegative():
+		fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf("💸 You owe %s %s", partner, formatMoney(balance.Neg()))))
+	default:
+		fmt.Fprintln(stdout, successStyle.Render("✅ All settled"))
+	}
+}
+
+func init() {
+	reportSharedCmd.Flags().String("period", "this-month", "Period: YYYY-MM, YYYY-MM-DD..YYYY-MM-DD, this-month, last-month, ytd or FY<year>")
+	reportSharedCmd.Flags().String("tag", service.DefaultSharedTag, "Tag of shared expenses")
+	reportSharedCmd.Flags().String("reimbursed-tag", service.DefaultReimbursedTag, "Tag of settlements between you and your partner")
+	reportSharedCmd.Flags().String("exclude", "", "Ignore transactions with this tag")
+	reportSharedCmd.Flags().Float64("split", 50, "Your share in percent (0-100)")
+	reportSharedCmd.Flags().String("partner", "Partner", "Name of the person you share expenses with")
+	reportSharedCmd.Flags().StringP("output", "o", "", "Write the shared transactions as CSV (- for stdout)")
+
+	reportCmd.AddCommand(reportSharedCmd)
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/app"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestReportShared(t *testing.T) {
+	ctx := context.Background()
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	application = demo
+
+	wallets, err := demo.Repos.Wallet.List(ctx, repository.WalletFilter{})
+	if err != nil || len(wallets) == 0 {
+		t.Fatalf("demo wallets = %d, error = %v", len(wallets), err)
+	}
+	for _, tx := range []struct {
+		txType models.TransactionType
+		amount int64
+		tags   []string
+	}{
+		{models.TransactionTypeExpense, 300000, []string{"shared"}},
+		{models.TransactionTypeExpense, 100000, []string{"shared", "personal"}},
+		{models.TransactionTypeIncome, 50000, []string{"shared", "reimbursed"}},
+	} {
+		created := models.NewTransaction(wallets[0].ID, tx.txType, decimal.NewFromInt(tx.amount))
+		created.Description = "Shared test"
+		created.TransactionDate = time.Now()
+		created.Tags = tx.tags
+		if err := demo.Repos.Transaction.Create(ctx, created); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		for _, name := range []string{"split", "exclude", "partner", "output"} {
+			_ = reportSharedCmd.Flags().Lookup(name).Value.Set(reportSharedCmd.Flags().Lookup(name).DefValue)
+		}
+	})
+
+	file := filepath.Join(t.TempDir(), "shared.csv")
+	out := string(execPiped(t, "report", "shared", "--split", "60", "--exclude", "personal", "--partner", "Sari", "-o", file))
+	// 300.000 x 40% = 120.000, dikurangi 50.000 yang sudah dibayar
+	for _, want := range []string{"Split you 60% / Sari 40%", "Sari owes you", "70.000", "exported to"} {
+		if !strings.Contains(strings.ToLower(out), strings.ToLower(want)) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Header, expense, reimbursement, total
+	if len(records) != 4 || records[3][6] != "70000.00" {
+		t.Errorf("CSV = %q, want 2 rows and a total of 70000.00", records)
+	}
+}
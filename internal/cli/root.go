@@ -22,9 +22,16 @@
 package cli
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
 	"github.com/spf13/cobra"
 
 	"github.com/Adityanrhm/wallet-twin/internal/app"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
 )
 
 // rootCmd adalah command utama.
@@ -44,39 +51,158 @@ A CLI personal finance application for tracking income,
 expenses, transfers, budgets, and savings goals.
 
 Get started:
+  wallet                 Open interactive TUI dashboard (in a terminal)
   wallet wallet add      Add a new wallet
   wallet tx add          Add a new transaction
   wallet dashboard       Open interactive TUI dashboard
 `,
+	PersistentPreRunE: initApp,
+	RunE:              runRoot,
+}
+
+// annotationRequiresDB menandai command yang butuh App (koneksi database,
+// atau data demo dengan --demo). Berlaku juga untuk semua subcommand-nya.
+const annotationRequiresDB = "wallet-twin/requires-db"
+
+// requiresDB dipasang sebagai Annotations di command group yang butuh App:
+//
+//	var walletCmd = &cobra.Command{
+//	    Use:         "wallet",
+//	    Annotations: requiresDB,
+//	}
+var requiresDB = map[string]string{annotationRequiresDB: "true"}
+
+// needsApp mengecek apakah cmd atau salah satu parent-nya punya
+// annotation requiresDB.
+func needsApp(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[annotationRequiresDB] == "true" {
+			return true
+		}
+	}
+	return false
 }
 
-// application adalah pointer ke app.App yang di-set saat Execute.
+// application adalah pointer ke app.App yang di-set sebelum command dijalankan.
 var application *app.App
 
+// configPath adalah lokasi folder config, di-set saat Execute.
+var configPath string
+
 // Execute menjalankan root command.
 //
 // Ini adalah satu-satunya "public" function di package cli.
 // Dipanggil dari main.go:
 //
-//	if err := cli.Execute(application); err != nil {
+//	if err := cli.Execute("./config"); err != nil {
 //	    os.Exit(1)
 //	}
-func Execute(app *app.App) error {
-	application = app
-	return rootCmd.Execute()
+//
+// App di-initialize di PersistentPreRunE (setelah flags di-parse),
+// supaya flag seperti `dashboard --demo` bisa memilih backend
+// sebelum koneksi database dibuka.
+//
+// Context command dibatalkan saat Ctrl+C, jadi query yang sedang
+// berjalan ikut dibatalkan.
+func Execute(path string) error {
+	configPath = path
+
+	defer func() {
+		if application != nil {
+			if err := application.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error during cleanup: %v\n", err)
+			}
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return describeError(ctx, rootCmd.ExecuteContext(ctx))
+}
+
+// describeError membuat error timeout dan Ctrl+C lebih jelas untuk user.
+func describeError(ctx context.Context, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, repository.ErrQueryTimeout):
+		return fmt.Errorf("%w\n  Check the database connection, or raise database.query_timeout in config.yaml", err)
+	case errors.Is(err, context.Canceled) && ctx.Err() != nil:
+		return errors.New("interrupted")
+	}
+	return err
+}
+
+// initApp menyiapkan output dan meng-initialize application secara lazy:
+// hanya command dengan annotation requiresDB yang membuka koneksi database.
+// Command lain (config, version, completion) tetap jalan walau database
+// belum ada; `wallet` tanpa subcommand memanggil loadApp sendiri (runRoot).
+//
+// Request shell completion (__complete) tidak di-initialize di sini;
+// completion function yang butuh data memanggil loadApp sendiri supaya
+// tab-complete subcommand tetap jalan walau database tidak tersedia.
+func initApp(cmd *cobra.Command, args []string) error {
+	if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+		return nil
+	}
+	setupOutput(cmd)
+	if !needsApp(cmd) {
+		return nil
+	}
+	return loadApp(cmd)
+}
+
+// loadApp membuat application jika belum ada.
+//
+// Jika command punya flag --demo yang aktif, App dibuat dengan data
+// in-memory (app.NewDemo) tanpa menyentuh database.
+func loadApp(cmd *cobra.Command) error {
+	if application != nil {
+		return nil
+	}
+
+	var err error
+	if demo, _ := cmd.Flags().GetBool("demo"); demo {
+		application, err = app.NewDemo(configPath)
+	} else {
+		application, err = app.New(configPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+
+	if absolute, _ := cmd.Flags().GetBool("absolute-dates"); absolute {
+		application.Config.App.AbsoluteDates = true
+	}
+
+	return nil
 }
 
 // init adalah special function Go yang dipanggil otomatis.
 // Di sini kita add semua subcommands ke root.
 func init() {
-	// Disable default completion command
+	// Default completion command diganti completionCmd (lihat completion.go)
+	// yang tidak butuh koneksi database.
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colors and emoji (also when NO_COLOR is set or output is piped)")
+	rootCmd.PersistentFlags().Bool("absolute-dates", false, "Show dates as YYYY-MM-DD instead of Today, Yesterday, ...")
+
 	// Add subcommands
+	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(dbCmd)
 	rootCmd.AddCommand(walletCmd)
 	rootCmd.AddCommand(transactionCmd)
 	rootCmd.AddCommand(transferCmd)
+	rootCmd.AddCommand(categoryCmd)
 	rootCmd.AddCommand(budgetCmd)
 	rootCmd.AddCommand(goalCmd)
+	rootCmd.AddCommand(recurringCmd)
 	rootCmd.AddCommand(dashboardCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(versionCmd)
 }
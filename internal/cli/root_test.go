@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestNeedsApp(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"tx", "add"}, true},
+		{[]string{"wallet", "list"}, true},
+		{[]string{"db", "migrate", "up"}, true},
+		{[]string{"dashboard"}, true},
+		{[]string{"export", "tx"}, true},
+		{[]string{"version"}, false},
+		{[]string{"config", "set"}, false},
+		{[]string{"completion"}, false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		cmd, _, err := rootCmd.Find(tt.args)
+		if err != nil {
+			t.Fatalf("Find(%v) error = %v", tt.args, err)
+		}
+		if got := needsApp(cmd); got != tt.want {
+			t.Errorf("needsApp(%s) = %v, want %v", cmd.CommandPath(), got, tt.want)
+		}
+	}
+}
+
+func TestRootWithoutTerminalShowsHelp(t *testing.T) {
+	out := string(runPiped(t, []string{}...))
+
+	if !strings.Contains(out, "Available Commands:") {
+		t.Errorf("output = %q, want help text", firstLine([]byte(out)))
+	}
+}
+
+func TestDescribeError(t *testing.T) {
+	timeout := fmt.Errorf("failed to list wallets: %w", &repository.QueryTimeoutError{Timeout: 10 * time.Second})
+	if got := describeError(context.Background(), timeout).Error(); !strings.Contains(got, "timed out after 10s") ||
+		!strings.Contains(got, "database.query_timeout") {
+		t.Errorf("describeError(timeout) = %q, want timeout and hint", got)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := describeError(cancelled, fmt.Errorf("failed: %w", context.Canceled)); got.Error() != "interrupted" {
+		t.Errorf("describeError(Ctrl+C) = %q, want interrupted", got)
+	}
+
+	other := errors.New("boom")
+	if got := describeError(context.Background(), other); got != other {
+		t.Errorf("describeError(other) = %v, want unchanged", got)
+	}
+}
@@ -0,0 +1,346 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// ruleCmd adalah parent command untuk categorization rule.
+var ruleCmd = &cobra.Command{
+	Use:         "rule",
+	Short:       "🪄 Manage auto-categorization rules",
+	Annotations: requiresDB,
+	Long: `Rules fill in the category of transactions added without one.
+
+Rules are checked by priority (lowest first, then oldest first); the first
+rule that matches and whose category has the transaction's type (income
+or expense) wins. Descriptions and tags are matched case-insensitively.`,
+}
+
+// ruleAddCmd membuat rule baru.
+var ruleAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a categorization rule",
+	Long: `Add a categorization rule. --match is one of:
+
+  wallet                 every transaction in --wallet
+  description_contains   description contains --pattern
+  description_regex      description matches the regular expression --pattern
+  tag                    transaction has the tag --pattern
+
+Invalid regular expressions are rejected when the rule is added.`,
+	Example: `  wallet rule add --match wallet --wallet Gojek --category Transport
+  wallet rule add --match description_contains --pattern spotify --category Entertainment
+  wallet rule add --match description_regex --pattern "^(indomaret|alfamart)" --category Groceries --priority 10`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		matchType, _ := cmd.Flags().GetString("match")
+		pattern, _ := cmd.Flags().GetString("pattern")
+		walletArg, _ := cmd.Flags().GetString("wallet")
+		categoryArg, _ := cmd.Flags().GetString("category")
+		priority, _ := cmd.Flags().GetInt("priority")
+
+		category, err := resolveCategory(ctx, categoryArg)
+		if err != nil {
+			return err
+		}
+
+		input := service.CreateRuleInput{
+			Priority:   priority,
+			MatchType:  models.RuleMatchType(matchType),
+			Pattern:    pattern,
+			CategoryID: category.ID,
+		}
+		if walletArg != "" {
+			wallet, err := resolveWallet(ctx, walletArg)
+			if err != nil {
+				return err
+			}
+			input.WalletID = &wallet.ID
+		}
+
+		rule, err := newRuleService().Create(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		refs, err := service.LoadRefData(ctx, application.Repos.Wallet, application.Repos.Category)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Rule added!"))
+		fmt.Fprintf(stdout, "   🔎 %s\n", describeRule(rule, refs))
+		fmt.Fprintf(stdout, "   📂 %s\n", category.Name)
+		fmt.Fprintf(stdout, "   🆔 %s\n", rule.ID)
+		return nil
+	},
+}
+
+// ruleListCmd menampilkan rule urut pengecekan.
+var ruleListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls", "l"},
+	Short:   "List categorization rules in the order they are checked",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		rules, err := newRuleService().List(ctx)
+		if err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			fmt.Fprintln(stdout, "No rules found. Add one with 'wallet rule add'.")
+			return nil
+		}
+
+		refs, err := service.LoadRefData(ctx, application.Repos.Wallet, application.Repos.Category)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render("\n🪄 Categorization Rules\n"))
+
+		table := tablewriter.NewTable(stdout)
+		table.Header("ID", "Priority", "Match", "Category")
+		for _, r := range rules {
+			table.Append([]string{
+				r.ID.String(),
+				strconv.Itoa(r.Priority),
+				describeRule(r, refs),
+				refs.CategoryName(&r.CategoryID),
+			})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+// ruleDeleteCmd menghapus rule.
+var ruleDeleteCmd = &cobra.Command{
+	Use:   "delete [rule-id]",
+	Short: "Delete a categorization rule",
+	Long:  "Delete a categorization rule. Transactions it already categorized keep their category.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := parseUUID(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := newRuleService().Delete(cmd.Context(), id); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, successStyle.Render("✅ Rule deleted successfully!"))
+		return nil
+	},
+}
+
+// ruleTestCmd menampilkan rule yang akan mengkategorikan transaksi
+// dengan description tertentu, tanpa mencatat apa pun.
+var ruleTestCmd = &cobra.Command{
+	Use:   "test [description]",
+	Short: "Show which rule would categorize a transaction",
+	Example: `  wallet rule test "GOJEK ride to office"
+  wallet rule test "Salary" --type income
+  wallet rule test "Lunch" --wallet BCA --tag work`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		txType, _ := cmd.Flags().GetString("type")
+		walletArg, _ := cmd.Flags().GetString("wallet")
+		tags, _ := cmd.Flags().GetStringArray("tag")
+
+		tx := &models.Transaction{
+			Type:        models.TransactionType(txType),
+			Description: args[0],
+			Tags:        tags,
+		}
+		if !tx.Type.IsValid() {
+			return models.ErrTransactionInvalidType
+		}
+		if walletArg != "" {
+			wallet, err := resolveWallet(ctx, walletArg)
+			if err != nil {
+				return err
+			}
+			tx.WalletID = wallet.ID
+		}
+
+		rule, err := newRuleService().Test(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			fmt.Fprintln(stdout, "No rule matches; the transaction would stay uncategorized.")
+			return nil
+		}
+
+		refs, err := service.LoadRefData(ctx, application.Repos.Wallet, application.Repos.Category)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "📂 %s\n", refs.CategoryName(&rule.CategoryID))
+		fmt.Fprintf(stdout, "   🔎 %s (priority %d)\n", describeRule(rule, refs), rule.Priority)
+		fmt.Fprintf(stdout, "   🆔 %s\n", rule.ID)
+		return nil
+	},
+}
+
+// txAutocategorizeCmd menerapkan rule ke transaksi lama tanpa kategori.
+var txAutocategorizeCmd = &cobra.Command{
+	Use:   "autocategorize",
+	Short: "Categorize existing uncategorized transactions with rules",
+	Long: `Apply categorization rules (see 'wallet rule') to transactions that have
+no category yet. The matches are listed first and applied after you
+confirm, in a single database transaction. Only the category changes, so
+wallet balances do not.
+
+System transactions (adjustments, revaluations, transfers) are skipped.`,
+	Example: `  wallet tx autocategorize --dry-run
+  wallet tx autocategorize --yes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		ruleService := newRuleService()
+		preview, err := ruleService.PreviewAutocategorize(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(preview.Matches) == 0 {
+			fmt.Fprintln(stdout, "No uncategorized transactions match a rule.")
+		} else {
+			refs, err := service.LoadRefData(ctx, application.Repos.Wallet, application.Repos.Category)
+			if err != nil {
+				return err
+			}
+			currencies := walletCurrencies(ctx)
+
+			table := tablewriter.NewTable(stdout)
+			table.Header("Date", "Description", "Amount", "Wallet", "Category")
+			for _, m := range preview.Matches {
+				table.Append([]string{
+					formatDate(m.Transaction.TransactionDate),
+					truncate(m.Transaction.Description, 30),
+					formatMoneyIn(m.Transaction.Amount, m.Transaction.WalletID, currencies),
+					refs.WalletName(m.Transaction.WalletID),
+					refs.CategoryName(&m.Rule.CategoryID),
+				})
+			}
+			table.Render()
+		}
+
+		if preview.Unmatched > 0 {
+			fmt.Fprintf(stdout, "%d uncategorized transaction(s) match no rule and are left unchanged.\n", preview.Unmatched)
+		}
+
+		if len(preview.Matches) == 0 {
+			return nil
+		}
+		if dryRun {
+			fmt.Fprintf(stdout, "\nDry run: %d transaction(s) would be categorized. Run without --dry-run to apply.\n", len(preview.Matches))
+			return nil
+		}
+		if !yes {
+			if !isInteractive() {
+				return errors.New("refusing to categorize without --yes in a non-interactive shell")
+			}
+			if !confirm(fmt.Sprintf("Categorize %d transaction(s)?", len(preview.Matches))) {
+				fmt.Fprintln(stdout, "Cancelled.")
+				return nil
+			}
+		}
+
+		n, err := ruleService.ApplyAutocategorize(ctx, preview.Matches)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, successStyle.Render(fmt.Sprintf("✅ Categorized %d transaction(s)", n)))
+		return nil
+	},
+}
+
+// newRuleService membuat RuleService dari repository aplikasi.
+func newRuleService() *service.RuleService {
+	return service.NewRuleService(
+		application.Repos.Rule,
+		application.Repos.Transaction,
+		application.Repos.Wallet,
+		application.Repos.Category,
+		application.TxManager,
+	)
+}
+
+// describeRule menulis kondisi rule, contoh `description contains "gojek"`.
+func describeRule(r *models.CategorizationRule, refs *service.RefData) string {
+	switch r.MatchType {
+	case models.RuleMatchWallet:
+		walletID := uuid.Nil
+		if r.WalletID != nil {
+			walletID = *r.WalletID
+		}
+		return "wallet is " + refs.WalletName(walletID)
+	case models.RuleMatchDescriptionContains:
+		return fmt.Sprintf("description contains %q", r.Pattern)
+	case models.RuleMatchDescriptionRegex:
+		return fmt.Sprintf("description matches /%s/", r.Pattern)
+	case models.RuleMatchTag:
+		return "tagged " + r.Pattern
+	}
+	return string(r.MatchType)
+}
+
+func init() {
+	matchTypes := []string{
+		string(models.RuleMatchWallet),
+		string(models.RuleMatchDescriptionContains),
+		string(models.RuleMatchDescriptionRegex),
+		string(models.RuleMatchTag),
+	}
+
+	ruleAddCmd.Flags().String("match", "", "Match type: wallet, description_contains, description_regex or tag")
+	ruleAddCmd.Flags().String("pattern", "", "Text, regular expression or tag to match (not used with --match wallet)")
+	ruleAddCmd.Flags().StringP("wallet", "w", "", "Wallet (ID or name) for --match wallet")
+	ruleAddCmd.Flags().StringP("category", "c", "", "Category (ID or name) to assign")
+	ruleAddCmd.Flags().Int("priority", 0, "Lower priorities are checked first")
+	_ = ruleAddCmd.MarkFlagRequired("match")
+	_ = ruleAddCmd.MarkFlagRequired("category")
+	_ = ruleAddCmd.RegisterFlagCompletionFunc("match",
+		cobra.FixedCompletions(matchTypes, cobra.ShellCompDirectiveNoFileComp))
+	_ = ruleAddCmd.RegisterFlagCompletionFunc("wallet", completeWalletIDs)
+	_ = ruleAddCmd.RegisterFlagCompletionFunc("category", completeCategoryIDs)
+	ruleCmd.AddCommand(ruleAddCmd)
+
+	ruleCmd.AddCommand(ruleListCmd)
+	ruleCmd.AddCommand(ruleDeleteCmd)
+
+	ruleTestCmd.Flags().StringP("type", "t", "expense", "Transaction type: income or expense")
+	ruleTestCmd.Flags().StringP("wallet", "w", "", "Wallet (ID or name) of the transaction")
+	ruleTestCmd.Flags().StringArray("tag", nil, "Tag of the transaction; repeat for several")
+	_ = ruleTestCmd.RegisterFlagCompletionFunc("wallet", completeWalletIDs)
+	ruleCmd.AddCommand(ruleTestCmd)
+
+	rootCmd.AddCommand(ruleCmd)
+
+	txAutocategorizeCmd.Flags().Bool("dry-run", false, "Show the matches without changing anything")
+	txAutocategorizeCmd.Flags().BoolP("yes", "y", false, "Apply without asking for confirmation")
+	transactionCmd.AddCommand(txAutocategorizeCmd)
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/app"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestRuleAndAutocategorize(t *testing.T) {
+	ctx := context.Background()
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	application = demo
+
+	wallets, err := demo.Repos.Wallet.List(ctx, repository.WalletFilter{})
+	if err != nil || len(wallets) == 0 {
+		t.Fatalf("List() = %d wallets, %v; want at least 1", len(wallets), err)
+	}
+	tx := models.NewTransaction(wallets[0].ID, models.TransactionTypeExpense, decimal.NewFromInt(54990))
+	tx.Description = "SPOTIFY Premium"
+	if err := demo.Repos.Transaction.Create(ctx, tx); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		for _, name := range []string{"match", "pattern", "category"} {
+			_ = ruleAddCmd.Flags().Set(name, "")
+		}
+		_ = txAutocategorizeCmd.Flags().Set("dry-run", "false")
+		_ = txAutocategorizeCmd.Flags().Set("yes", "false")
+	})
+
+	out := string(execPiped(t, "rule", "add", "--match", "description_contains", "--pattern", "spotify", "--category", "Entertainment"))
+	if !strings.Contains(out, `description contains "spotify"`) {
+		t.Errorf("rule add output = %q, want the rule described", out)
+	}
+
+	out = string(execPiped(t, "rule", "test", "Spotify Family"))
+	if !strings.Contains(out, "Entertainment") {
+		t.Errorf("rule test output = %q, want Entertainment", out)
+	}
+
+	out = string(execPiped(t, "tx", "autocategorize", "--dry-run"))
+	if !strings.Contains(out, "Dry run: 1 transaction(s)") || !strings.Contains(out, "SPOTIFY Premium") {
+		t.Errorf("dry run output = %q, want the transaction listed", out)
+	}
+	if got, _ := demo.Repos.Transaction.GetByID(ctx, tx.ID); got.CategoryID != nil {
+		t.Fatal("dry run changed the category")
+	}
+
+	_ = txAutocategorizeCmd.Flags().Set("dry-run", "false")
+	out = string(execPiped(t, "tx", "autocategorize", "--yes"))
+	if !strings.Contains(out, "Categorized 1 transaction(s)") {
+		t.Errorf("output = %q, want 1 transaction categorized", out)
+	}
+	if got, _ := demo.Repos.Transaction.GetByID(ctx, tx.ID); got.CategoryID == nil {
+		t.Error("transaction was not categorized")
+	}
+}
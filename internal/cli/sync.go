@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/export"
+)
+
+// syncTokenEnv adalah environment variable fallback untuk --token,
+// supaya token tidak harus muncul di shell history.
+const syncTokenEnv = "WT_SYNC_TOKEN"
+
+// syncCmd adalah parent command untuk sinkronisasi antar instance.
+var syncCmd = &cobra.Command{
+	Use:         "sync",
+	Short:       "🔄 Sync data with another Wallet Twin instance",
+	Annotations: requiresDB,
+	Long: `Sync data between Wallet Twin instances over HTTP.
+
+Run "wallet sync serve" on the machine that has the data, then
+"wallet sync pull --from http://host:8080" on the other machine.`,
+}
+
+// syncPullCmd mengambil export dari instance lain lalu merge ke lokal.
+var syncPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Fetch data from another instance and merge it locally",
+	Long: `Fetch the full export from another instance and merge it into this one.
+
+Rows are matched by ID:
+  - missing locally         → created
+  - same updated_at         → skipped
+  - different updated_at    → the newer side wins and the row is listed as a conflict
+
+Nothing is written unless the whole export was received and merged successfully.`,
+	Example: `  wallet sync pull --from http://laptop:8080 --token s3cret`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		from, _ := cmd.Flags().GetString("from")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		token, err := syncToken(cmd)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "📡 Fetching data from %s...\n", from)
+		data, err := export.FetchRemote(ctx, from, token, &http.Client{Timeout: timeout})
+		if err != nil {
+			return err
+		}
+
+		importer := export.NewImporter(
+			application.Repos.Wallet,
+			application.Repos.Transaction,
+			application.Repos.Category,
+			application.Repos.Goal,
+			application.TxManager,
+		)
+
+		result, err := importer.Merge(ctx, data)
+		if err != nil {
+			return fmt.Errorf("merge failed, no changes were saved: %w", err)
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Sync complete!"))
+		for _, s := range result.Stats {
+			fmt.Fprintf(stdout, "   %-13s created %d, updated %d, skipped %d\n", s.Entity+":", s.Created, s.Updated, s.Skipped)
+		}
+
+		if len(result.Conflicts) > 0 {
+			fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf("\n⚠️  %d conflict(s) resolved by newer updated_at:", len(result.Conflicts))))
+			for _, c := range result.Conflicts {
+				fmt.Fprintf(stdout, "   %s %s (%s): kept %s (local %s, remote %s)\n",
+					c.Entity, c.Name, c.ID,
+					c.Kept,
+					c.LocalUpdatedAt.Format(time.DateTime),
+					c.RemoteUpdatedAt.Format(time.DateTime),
+				)
+			}
+		}
+
+		return nil
+	},
+}
+
+// syncServeCmd menjalankan HTTP server yang menyediakan GET /export.
+var syncServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve this instance's data for sync pull",
+	Long: `Start an HTTP server that exposes GET /export for "wallet sync pull".
+
+Every request must send "Authorization: Bearer <token>".`,
+	Example: `  wallet sync serve --addr :8080 --token s3cret`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		token, err := syncToken(cmd)
+		if err != nil {
+			return err
+		}
+
+		exporter := export.NewExporter(
+			application.Repos.Wallet,
+			application.Repos.Transaction,
+			application.Repos.Category,
+			application.Repos.Goal,
+		)
+
+		mux := http.NewServeMux()
+		mux.Handle(export.ExportPath, export.NewExportHandler(exporter, token))
+
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		fmt.Fprintf(stdout, "🔄 Serving %s on %s (Ctrl+C to stop)\n", export.ExportPath, addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("server error: %w", err)
+		}
+		return nil
+	},
+}
+
+// syncToken mengambil token dari --token atau WT_SYNC_TOKEN.
+func syncToken(cmd *cobra.Command) (string, error) {
+	token, _ := cmd.Flags().GetString("token")
+	if token == "" {
+		token = os.Getenv(syncTokenEnv)
+	}
+	if token == "" {
+		return "", fmt.Errorf("token is required (use --token or %s)", syncTokenEnv)
+	}
+	return token, nil
+}
+
+func init() {
+	// sync pull
+	syncPullCmd.Flags().String("from", "", "Base URL of the remote instance (e.g. http://laptop:8080)")
+	syncPullCmd.Flags().String("token", "", "Bearer token of the remote instance (or set "+syncTokenEnv+")")
+	syncPullCmd.Flags().Duration("timeout", export.DefaultRemoteTimeout, "Timeout for the whole request")
+	_ = syncPullCmd.MarkFlagRequired("from")
+	syncCmd.AddCommand(syncPullCmd)
+
+	// sync serve
+	syncServeCmd.Flags().String("addr", ":8080", "Address to listen on")
+	syncServeCmd.Flags().String("token", "", "Bearer token required from clients (or set "+syncTokenEnv+")")
+	syncCmd.AddCommand(syncServeCmd)
+}
@@ -1,27 +1,34 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
 	"github.com/olekukonko/tablewriter"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 
+	"github.com/Adityanrhm/wallet-twin/internal/export"
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
-	"github.com/Adityanrhm/wallet-twin/internal/repository/postgres"
 	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
 // transactionCmd adalah parent command untuk transactions.
 var transactionCmd = &cobra.Command{
-	Use:     "transaction",
-	Aliases: []string{"tx", "t"},
-	Short:   "📝 Manage transactions",
-	Long:    "Add, list, and delete income/expense transactions.",
+	Use:         "transaction",
+	Aliases:     []string{"tx", "t"},
+	Short:       "📝 Manage transactions",
+	Annotations: requiresDB,
+	Long:        "Add, list, and delete income/expense transactions.",
 }
 
 // txListCmd menampilkan transactions.
@@ -29,24 +36,64 @@ var txListCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls", "l"},
 	Short:   "List transactions",
+	Long: `List recent transactions.
+
+With --group-by, all transactions matching the filters are listed (not just
+--limit) in one section per category, wallet or day, each with its
+subtotal. Categories and wallets are sorted by subtotal, largest first;
+days newest first. The grand total at the end is checked against
+'wallet tx summary' for the same filters.`,
+	Example: `  wallet tx list
+  wallet tx list --month 2026-01 --group-by category
+  wallet tx list --wallet BCA --group-by day`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		txManager := postgres.NewTransactionManager(application.DB.Pool)
+		txManager := application.TxManager
 		txService := service.NewTransactionService(
 			application.Repos.Transaction,
 			application.Repos.Wallet,
+			application.Repos.Category,
 			txManager,
 		)
 
 		limit, _ := cmd.Flags().GetInt("limit")
 		txType, _ := cmd.Flags().GetString("type")
 
-		filter := repository.TransactionFilter{}
+		walletIDs, err := walletFilterIDs(cmd)
+		if err != nil {
+			return err
+		}
+
+		filter := repository.TransactionFilter{WalletIDs: walletIDs}
 		if txType != "" {
 			t := models.TransactionType(txType)
 			filter.Type = &t
 		}
+		if search, _ := cmd.Flags().GetString("search"); search != "" {
+			filter.Search = &search
+			filter.SearchNotes, _ = cmd.Flags().GetBool("include-notes")
+		}
+		if monthStr, _ := cmd.Flags().GetString("month"); monthStr != "" {
+			t, err := time.ParseInLocation("2006-01", monthStr, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid --month %q, expected YYYY-MM", monthStr)
+			}
+			txService.SetMonthStartDay(application.Config.App.MonthStartDay)
+			period := txService.MonthFilter(t.Year(), t.Month())
+			filter.StartDate, filter.EndDate = period.StartDate, period.EndDate
+		}
+
+		if groupBy, _ := cmd.Flags().GetString("group-by"); groupBy != "" {
+			key, err := service.ParseGroupKey(groupBy)
+			if err != nil {
+				return err
+			}
+			if md, _ := cmd.Flags().GetBool("md"); md {
+				return errors.New("--group-by cannot be combined with --md")
+			}
+			return printGroupedTransactions(ctx, txService, filter, key)
+		}
 
 		params := repository.ListParams{Limit: limit, Offset: 0}
 		transactions, err := txService.List(ctx, filter, params)
@@ -55,27 +102,40 @@ var txListCmd = &cobra.Command{
 		}
 
 		if len(transactions) == 0 {
-			fmt.Println("No transactions found. Add one with: wallet tx add")
+			fmt.Fprintln(stdout, "No transactions found. Add one with: wallet tx add")
 			return nil
 		}
 
-		fmt.Println(titleStyle.Render("\n📝 Recent Transactions\n"))
-
-		table := tablewriter.NewTable(os.Stdout)
-		table.Header("Date", "Type", "Amount", "Description")
+		// Nama wallet/category dan currency untuk semua baris
+		refs, err := service.LoadRefData(ctx, application.Repos.Wallet, application.Repos.Category)
+		if err != nil {
+			return err
+		}
 
-		for _, tx := range transactions {
-			typeIcon := "📈"
-			if tx.Type == models.TransactionTypeExpense {
-				typeIcon = "📉"
+		asMarkdown, _ := cmd.Flags().GetBool("md")
+		clip, _ := cmd.Flags().GetBool("clip")
+		if asMarkdown || clip {
+			var buf bytes.Buffer
+			if err := export.WriteTransactionsMarkdown(&buf, transactions, refs, application.Config.App.Currency); err != nil {
+				return err
 			}
+			if clip {
+				copyOrPrint(buf.String())
+			} else {
+				// Data, bukan pesan CLI: jangan lewat stdout yang membuang
+				// emoji saat di-pipe (lihat writeExport)
+				fmt.Fprint(os.Stdout, buf.String())
+			}
+			return nil
+		}
 
-			table.Append([]string{
-				tx.TransactionDate.Format("02 Jan"),
-				typeIcon + " " + string(tx.Type),
-				formatMoney(tx.Amount),
-				truncate(tx.Description, 30),
-			})
+		fmt.Fprintln(stdout, titleStyle.Render("\n📝 Recent Transactions\n"))
+
+		table := tablewriter.NewTable(stdout)
+		table.Header("Date", "Type", "Amount", "Category", "Description")
+
+		for _, tx := range transactions {
+			table.Append(transactionRow(tx, refs))
 		}
 
 		table.Render()
@@ -83,6 +143,86 @@ var txListCmd = &cobra.Command{
 	},
 }
 
+// transactionRow adalah satu baris tabel `tx list`.
+func transactionRow(tx *models.Transaction, refs *service.RefData) []string {
+	typeIcon := "📈"
+	if tx.Type == models.TransactionTypeExpense {
+		typeIcon = "📉"
+	}
+
+	return []string{
+		formatDate(tx.TransactionDate),
+		typeIcon + " " + string(tx.Type),
+		formatWalletMoney(tx.Amount, refs.WalletCurrency(tx.WalletID, application.Config.App.Currency)),
+		truncate(refs.CategoryName(tx.CategoryID), 16),
+		truncate(tx.Description, 30),
+	}
+}
+
+// printGroupedTransactions mencetak `tx list --group-by`: satu section
+// per group dengan subtotal dan tabel transaksinya (di-indent), lalu
+// grand total. Jika grand total berbeda dengan GetSummary untuk filter
+// yang sama, warning dicetak karena berarti List dan GetSummary tidak
+// menerapkan filter dengan cara yang sama.
+func printGroupedTransactions(
+	ctx context.Context,
+	txService *service.TransactionService,
+	filter repository.TransactionFilter,
+	key service.GroupKey,
+) error {
+	refs, err := service.LoadRefData(ctx, application.Repos.Wallet, application.Repos.Category)
+	if err != nil {
+		return err
+	}
+
+	grouped, err := txService.ListGrouped(ctx, filter, key, refs)
+	if err != nil {
+		return err
+	}
+	if len(grouped.Groups) == 0 {
+		fmt.Fprintln(stdout, "No transactions found. Add one with: wallet tx add")
+		return nil
+	}
+
+	fmt.Fprintln(stdout, titleStyle.Render("\n📝 Transactions by "+string(key)+"\n"))
+
+	for _, g := range grouped.Groups {
+		label := g.Label
+		if key == service.GroupByDay {
+			label = formatDate(g.Day)
+		}
+		fmt.Fprintf(stdout, "%s  %s  (%d tx)\n", sectionStyle.Render(label), moneyStyle.Render(formatMoney(g.Subtotal())), len(g.Transactions))
+		if !g.Income.IsZero() && !g.Expense.IsZero() {
+			fmt.Fprintf(stdout, "  📈 %s  📉 %s\n", incomeStyle.Render(formatMoney(g.Income)), expenseStyle.Render(formatMoney(g.Expense)))
+		}
+
+		var buf bytes.Buffer
+		table := tablewriter.NewTable(&buf)
+		table.Header("Date", "Type", "Amount", "Category", "Description")
+		for _, tx := range g.Transactions {
+			table.Append(transactionRow(tx, refs))
+		}
+		table.Render()
+		for _, line := range strings.SplitAfter(buf.String(), "\n") {
+			if line != "" {
+				fmt.Fprint(stdout, "  "+line)
+			}
+		}
+		fmt.Fprintln(stdout)
+	}
+
+	fmt.Fprintf(stdout, "📝 Total: %d transactions\n", grouped.Count)
+	fmt.Fprintf(stdout, "📈 Income:  %s\n", incomeStyle.Render(formatMoney(grouped.Income)))
+	fmt.Fprintf(stdout, "📉 Expense: %s\n", expenseStyle.Render(formatMoney(grouped.Expense)))
+
+	if !grouped.MatchesSummary() {
+		fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf(
+			"⚠️  Totals differ from the summary for the same filters (%d transactions, income %s, expense %s)",
+			grouped.Summary.Count, formatMoney(grouped.Summary.TotalIncome), formatMoney(grouped.Summary.TotalExpense))))
+	}
+	return nil
+}
+
 // txAddCmd menambah transaction baru.
 var txAddCmd = &cobra.Command{
 	Use:   "add",
@@ -90,23 +230,54 @@ var txAddCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		txManager := postgres.NewTransactionManager(application.DB.Pool)
+		txManager := application.TxManager
 		txService := service.NewTransactionService(
 			application.Repos.Transaction,
 			application.Repos.Wallet,
+			application.Repos.Category,
 			txManager,
 		)
+		txService.SetRejectBeforeWalletDate(application.Config.Validation.RejectsBeforeWalletDate())
+		txService.SetWarningHandler(printValidationWarning)
+		setRateProvider(txService)
+		setMaxAmount(txService)
+		txService.SetRuleRepository(application.Repos.Rule)
 
 		walletID, _ := cmd.Flags().GetString("wallet")
 		txType, _ := cmd.Flags().GetString("type")
 		amountStr, _ := cmd.Flags().GetString("amount")
 		desc, _ := cmd.Flags().GetString("description")
+		note, _ := cmd.Flags().GetString("note")
 		dateStr, _ := cmd.Flags().GetString("date")
+		categoryStr, _ := cmd.Flags().GetString("category")
+		skipConfirm, _ := cmd.Flags().GetBool("yes")
+		idempotencyKey, _ := cmd.Flags().GetString("idempotency-key")
+		currency, _ := cmd.Flags().GetString("currency")
+
+		// Parse wallet ID; kosong berarti pakai app.default_wallet
+		var wID uuid.UUID
+		if walletID != "" {
+			parsed, err := parseUUID(walletID)
+			if err != nil {
+				return fmt.Errorf("invalid wallet ID: %w", err)
+			}
+			wID = parsed
+		} else {
+			defaultID, err := defaultWalletID()
+			if err != nil {
+				return err
+			}
+			txService.SetDefaultWallet(defaultID)
+		}
 
-		// Parse wallet ID
-		wID, err := parseUUID(walletID)
-		if err != nil {
-			return fmt.Errorf("invalid wallet ID: %w", err)
+		// Parse category ID (optional)
+		var categoryID *uuid.UUID
+		if categoryStr != "" {
+			cID, err := parseUUID(categoryStr)
+			if err != nil {
+				return fmt.Errorf("invalid category ID: %w", err)
+			}
+			categoryID = &cID
 		}
 
 		// Parse amount
@@ -124,27 +295,62 @@ var txAddCmd = &cobra.Command{
 			}
 		}
 
+		input := service.CreateTransactionInput{
+			WalletID:       wID,
+			CategoryID:     categoryID,
+			Type:           models.TransactionType(txType),
+			Amount:         amount,
+			Description:    desc,
+			Note:           note,
+			Date:           date,
+			IdempotencyKey: idempotencyKey,
+		}
+
+		// Amount dalam currency asing: dikonversi ke currency wallet
+		if currency != "" {
+			input.Amount = decimal.Zero
+			input.OriginalAmount = &amount
+			input.OriginalCurrency = currency
+		}
+
+		// Fat-finger protection; CheckAnomaly mengonversi OriginalAmount
+		// dulu, jadi --currency dicek dengan nominal currency wallet
+		if !skipConfirm {
+			proceed, err := confirmLargeTransaction(cmd, txService, input)
+			if err != nil {
+				return err
+			}
+			if !proceed {
+				fmt.Fprintln(stdout, "Cancelled.")
+				return nil
+			}
+		}
+
 		// Create transaction
-		tx, err := txService.Create(ctx, service.CreateTransactionInput{
-			WalletID:    wID,
-			Type:        models.TransactionType(txType),
-			Amount:      amount,
-			Description: desc,
-			Date:        date,
-		})
+		tx, replayed, err := txService.CreateIdempotent(ctx, input)
 
 		if err != nil {
 			return err
 		}
 
+		// Key yang sama sudah pernah dipakai: transaksi lama dikembalikan
+		if replayed {
+			fmt.Fprintln(stdout, warningStyle.Render("ℹ️  Already recorded with this idempotency key, nothing added"))
+			fmt.Fprintf(stdout, "   🆔 %s\n", tx.ID)
+			return nil
+		}
+
 		typeIcon := "📈"
 		if tx.Type == models.TransactionTypeExpense {
 			typeIcon = "📉"
 		}
 
-		fmt.Println(successStyle.Render("✅ Transaction added!"))
-		fmt.Printf("   %s %s: %s\n", typeIcon, tx.Type, formatMoney(tx.Amount))
-		fmt.Printf("   📝 %s\n", tx.Description)
+		fmt.Fprintln(stdout, successStyle.Render("✅ Transaction added!"))
+		fmt.Fprintf(stdout, "   %s %s: %s\n", typeIcon, tx.Type, formatMoneyIn(tx.Amount, tx.WalletID, walletCurrencies(ctx)))
+		if tx.OriginalAmount != nil {
+			fmt.Fprintf(stdout, "   💱 from %s\n", utils.FormatMoney(*tx.OriginalAmount, tx.OriginalCurrency))
+		}
+		fmt.Fprintf(stdout, "   📝 %s\n", tx.Description)
 
 		return nil
 	},
@@ -158,10 +364,11 @@ var txDeleteCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		txManager := postgres.NewTransactionManager(application.DB.Pool)
+		txManager := application.TxManager
 		txService := service.NewTransactionService(
 			application.Repos.Transaction,
 			application.Repos.Wallet,
+			application.Repos.Category,
 			txManager,
 		)
 
@@ -174,69 +381,441 @@ var txDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Println(successStyle.Render("✅ Transaction deleted and balance rolled back!"))
+		fmt.Fprintln(stdout, successStyle.Render("✅ Transaction deleted and balance rolled back!"))
+		return nil
+	},
+}
+
+// txAttachCmd menyimpan path/URL bukti transaksi (foto struk).
+var txAttachCmd = &cobra.Command{
+	Use:   "attach [transaction-id] [path]",
+	Short: "Attach a receipt (file path or URL) to a transaction",
+	Long: `Attach a receipt to a transaction.
+
+The receipt is stored as a reference only: a local file path (saved as an
+absolute path, the file must exist) or an http(s) URL.`,
+	Example: `  wallet tx attach 3f2a... ~/receipts/lunch.jpg
+  wallet tx attach 3f2a... https://drive.example.com/receipt.jpg`,
+	Args: cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		// Argumen kedua adalah path, pakai completion file bawaan shell
+		if len(args) == 1 {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		txService := service.NewTransactionService(
+			application.Repos.Transaction,
+			application.Repos.Wallet,
+			application.Repos.Category,
+			application.TxManager,
+		)
+
+		id, err := parseUUID(args[0])
+		if err != nil {
+			return err
+		}
+
+		attachment, err := resolveAttachment(args[1])
+		if err != nil {
+			return err
+		}
+
+		if err := txService.AttachReceipt(ctx, id, attachment); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Receipt attached!"))
+		fmt.Fprintf(stdout, "   📎 %s\n", attachment)
+		return nil
+	},
+}
+
+// txRecategorizeCmd mengganti kategori transaksi tanpa mengubah saldo.
+var txRecategorizeCmd = &cobra.Command{
+	Use:   "recategorize [transaction-id]",
+	Short: "Change the category of a transaction",
+	Long: `Change the category of a transaction. Only the category is updated, so
+wallet balances do not change. The category must have the same type
+(income or expense) as the transaction.`,
+	Example: `  wallet tx recategorize 3f2a... --category Groceries
+  wallet tx recategorize 3f2a... -c 9b1c...`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		categoryArg, _ := cmd.Flags().GetString("category")
+
+		id, err := parseUUID(args[0])
+		if err != nil {
+			return err
+		}
+
+		category, err := resolveCategory(ctx, categoryArg)
+		if err != nil {
+			return err
+		}
+
+		txService := service.NewTransactionService(
+			application.Repos.Transaction,
+			application.Repos.Wallet,
+			application.Repos.Category,
+			application.TxManager,
+		)
+
+		tx, err := txService.Recategorize(ctx, id, &category.ID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Category updated!"))
+		fmt.Fprintf(stdout, "   📝 %s\n", tx.Description)
+		fmt.Fprintf(stdout, "   📂 %s\n", category.Name)
 		return nil
 	},
 }
 
+// txTagCmd menambah/menghapus tag transaksi tanpa mengubah saldo.
+var txTagCmd = &cobra.Command{
+	Use:   "tag [transaction-id]",
+	Short: "Add or remove tags on a transaction",
+	Long: `Add or remove tags on a transaction. Only the tags are updated, so
+wallet balances do not change. Tags are stored in lowercase. System tags
+(adjustment, revaluation, transfer) cannot be changed.`,
+	Example: `  wallet tx tag 3f2a... --add work --add reimbursable
+  wallet tx tag 3f2a... --remove lunch`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		add, _ := cmd.Flags().GetStringArray("add")
+		remove, _ := cmd.Flags().GetStringArray("remove")
+
+		if len(add) == 0 && len(remove) == 0 {
+			return errors.New("nothing to change: use --add or --remove")
+		}
+
+		id, err := parseUUID(args[0])
+		if err != nil {
+			return err
+		}
+
+		txService := service.NewTransactionService(
+			application.Repos.Transaction,
+			application.Repos.Wallet,
+			application.Repos.Category,
+			application.TxManager,
+		)
+
+		tx, err := txService.UpdateTags(ctx, id, add, remove)
+		if err != nil {
+			return err
+		}
+
+		tags := "(none)"
+		if len(tx.Tags) > 0 {
+			tags = strings.Join(tx.Tags, ", ")
+		}
+		fmt.Fprintln(stdout, successStyle.Render("✅ Tags updated!"))
+		fmt.Fprintf(stdout, "   📝 %s\n", tx.Description)
+		fmt.Fprintf(stdout, "   🏷️  %s\n", tags)
+		return nil
+	},
+}
+
+// resolveAttachment memvalidasi path attachment dari user.
+//
+// URL http(s) disimpan apa adanya. Path lokal harus menunjuk ke file
+// yang ada dan disimpan sebagai absolute path, supaya tetap valid
+// walaupun command dijalankan dari folder lain.
+func resolveAttachment(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("attachment path is required")
+	}
+
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path, nil
+	}
+
+	// Cek sebelum filepath.Abs, karena Abs akan menghilangkan ".."
+	if strings.Contains(path, "..") {
+		return "", models.ErrAttachmentInvalidPath
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot read attachment: %w", err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("attachment must be a file, got directory: %s", path)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve attachment path: %w", err)
+	}
+	return abs, nil
+}
+
 // txSummaryCmd menampilkan ringkasan transaksi.
 var txSummaryCmd = &cobra.Command{
 	Use:     "summary",
 	Aliases: []string{"sum"},
 	Short:   "Show transaction summary for current month",
+	Long: `Show income, expense and net for one month, one year or the year to date.
+
+With app.month_start_day set (e.g. 25 for a salary cycle), a month runs from
+that day of the previous month: --month 2026-01 covers 25 Dec – 24 Jan.
+
+Years follow app.fiscal_year_start_month and are named by the year they
+start in: with 4 (April), --period year --year 2025 covers Apr 2025 – Mar
+2026. --ytd sums from the start of the current fiscal year to today.`,
+	Example: `  wallet tx summary
+  wallet tx summary --month 2026-01
+  wallet tx summary --period year
+  wallet tx summary --period year --year 2025
+  wallet tx summary --ytd
+  wallet tx summary --wallet "Card A" --wallet "Card B"   # combined spend of two cards`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		txManager := postgres.NewTransactionManager(application.DB.Pool)
+		txManager := application.TxManager
 		txService := service.NewTransactionService(
 			application.Repos.Transaction,
 			application.Repos.Wallet,
+			application.Repos.Category,
 			txManager,
 		)
+		anchor := application.Config.App.MonthStartDay
+		txService.SetMonthStartDay(anchor)
+		fiscalStart := application.Config.App.FiscalYearStartMonth
+		txService.SetFiscalYearStartMonth(fiscalStart)
 
-		now := time.Now()
-		summary, err := txService.GetMonthlySummary(ctx, now.Year(), now.Month())
+		filter, title, err := summaryPeriod(cmd, txService, anchor, fiscalStart, time.Now())
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(titleStyle.Render("\n📊 Monthly Summary - " + now.Format("January 2006") + "\n"))
+		walletIDs, err := walletFilterIDs(cmd)
+		if err != nil {
+			return err
+		}
+		filter.WalletIDs = walletIDs
+
+		summary, err := txService.GetSummary(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render("\n📊 "+title+"\n"))
 
-		incomeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
-		expenseStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+		fmt.Fprintf(stdout, "📈 Income:  %s\n", incomeStyle.Render(formatMoney(summary.TotalIncome)))
+		fmt.Fprintf(stdout, "📉 Expense: %s\n", expenseStyle.Render(formatMoney(summary.TotalExpense)))
+		fmt.Fprintf(stdout, "💰 Net:     %s\n", moneyStyle.Render(formatMoney(summary.Net)))
+		fmt.Fprintf(stdout, "📝 Total transactions: %d\n\n", summary.Count)
+
+		flows, err := txService.GetCashFlowByWalletType(ctx, filter)
+		if err != nil {
+			return err
+		}
+		merchants, err := txService.GetTopMerchants(ctx, filter, service.TopMerchantsLimit)
+		if err != nil {
+			return err
+		}
 
-		fmt.Printf("📈 Income:  %s\n", incomeStyle.Render(formatMoney(summary.TotalIncome)))
-		fmt.Printf("📉 Expense: %s\n", expenseStyle.Render(formatMoney(summary.TotalExpense)))
-		fmt.Printf("💰 Net:     %s\n", moneyStyle.Render(formatMoney(summary.Net)))
-		fmt.Printf("📝 Total transactions: %d\n\n", summary.Count)
+		printCashFlowByWalletType(flows)
+		printTopMerchants(merchants)
 
 		return nil
 	},
 }
 
+// summaryPeriod membaca --period, --month, --year dan --ytd tx summary
+// dan mengembalikan filter periode beserta judulnya.
+func summaryPeriod(cmd *cobra.Command, txService *service.TransactionService, anchor, fiscalStart int, now time.Time) (repository.TransactionFilter, string, error) {
+	period, _ := cmd.Flags().GetString("period")
+	monthStr, _ := cmd.Flags().GetString("month")
+	fy, _ := cmd.Flags().GetInt("year")
+	ytd, _ := cmd.Flags().GetBool("ytd")
+
+	if ytd {
+		if monthStr != "" || cmd.Flags().Changed("year") {
+			return repository.TransactionFilter{}, "", errors.New("--ytd cannot be combined with --month or --year")
+		}
+		label := utils.FiscalYearLabel(fiscalStart, utils.FiscalYearContaining(fiscalStart, now))
+		return txService.YearToDateFilter(now), "Year-to-date Summary - " + label + " to " + now.Format("02 Jan"), nil
+	}
+
+	switch period {
+	case "month":
+		if cmd.Flags().Changed("year") {
+			return repository.TransactionFilter{}, "", errors.New("--year needs --period year")
+		}
+		year, month := utils.PeriodContaining(anchor, now)
+		if monthStr != "" {
+			t, err := time.ParseInLocation("2006-01", monthStr, time.Local)
+			if err != nil {
+				return repository.TransactionFilter{}, "", fmt.Errorf("invalid --month %q, expected YYYY-MM", monthStr)
+			}
+			year, month = t.Year(), t.Month()
+		}
+		return txService.MonthFilter(year, month), "Monthly Summary - " + utils.PeriodLabel(anchor, year, month), nil
+
+	case "year":
+		if monthStr != "" {
+			return repository.TransactionFilter{}, "", errors.New("--month needs --period month")
+		}
+		if !cmd.Flags().Changed("year") {
+			fy = utils.FiscalYearContaining(fiscalStart, now)
+		}
+		return txService.YearFilter(fy), "Yearly Summary - " + utils.FiscalYearLabel(fiscalStart, fy), nil
+	}
+	return repository.TransactionFilter{}, "", fmt.Errorf("invalid --period %q (use month or year)", period)
+}
+
+// printCashFlowByWalletType menampilkan uang masuk/keluar per tipe wallet.
+func printCashFlowByWalletType(flows []*repository.WalletTypeCashFlow) {
+	fmt.Fprintln(stdout, titleStyle.Render("🏦 By wallet type"))
+	for _, f := range flows {
+		fmt.Fprintf(stdout, "   %s in %s  out %s\n",
+			utils.PadRight(string(f.WalletType), 12),
+			incomeStyle.Render(utils.PadRight(formatMoney(f.Inflow), 14)),
+			expenseStyle.Render(formatMoney(f.Outflow)))
+	}
+	fmt.Fprintln(stdout)
+}
+
+// printTopMerchants menampilkan merchant dengan pengeluaran terbesar.
+func printTopMerchants(merchants []*service.MerchantSummary) {
+	fmt.Fprintln(stdout, titleStyle.Render("🛒 Top merchants"))
+	if len(merchants) == 0 {
+		fmt.Fprintln(stdout, "   No merchants in this period")
+		fmt.Fprintln(stdout)
+		return
+	}
+	for i, m := range merchants {
+		fmt.Fprintf(stdout, "   %2d. %s %s  %d×\n",
+			i+1, utils.PadRight(utils.TruncateWidth(m.Name, 24), 24),
+			expenseStyle.Render(utils.PadRight(formatMoney(m.Total), 14)), m.Count)
+	}
+	fmt.Fprintln(stdout)
+}
+
 func init() {
 	// tx list
 	txListCmd.Flags().IntP("limit", "l", 10, "Number of transactions to show")
 	txListCmd.Flags().StringP("type", "t", "", "Filter by type: income or expense")
+	txListCmd.Flags().Bool("md", false, "Print as a Markdown table")
+	txListCmd.Flags().Bool("clip", false, "Copy the Markdown table to the clipboard")
+	txListCmd.Flags().StringArrayP("wallet", "w", nil, "Only this wallet (ID or name); repeat for several wallets")
+	txListCmd.Flags().String("search", "", "Only transactions whose description contains this text")
+	txListCmd.Flags().Bool("include-notes", false, "Also match --search against transaction notes")
+	txListCmd.Flags().String("month", "", "Only transactions in this month (YYYY-MM, follows app.month_start_day)")
+	txListCmd.Flags().String("group-by", "", "Group by category, wallet or day, with subtotals (lists all matches, ignores --limit)")
+	_ = txListCmd.RegisterFlagCompletionFunc("wallet", completeWalletIDs)
 	transactionCmd.AddCommand(txListCmd)
 
 	// tx add
-	txAddCmd.Flags().StringP("wallet", "w", "", "Wallet ID (required)")
+	txAddCmd.Flags().StringP("wallet", "w", "", "Wallet ID (default: app.default_wallet from config)")
 	txAddCmd.Flags().StringP("type", "t", "expense", "Transaction type: income or expense")
 	txAddCmd.Flags().StringP("amount", "a", "", "Amount (required)")
 	txAddCmd.Flags().StringP("description", "d", "", "Description")
+	txAddCmd.Flags().String("note", "", "Longer note, may span lines (max 4096 bytes); shown in the dashboard detail view and exports")
 	txAddCmd.Flags().StringP("date", "D", "", "Transaction date (YYYY-MM-DD)")
-	_ = txAddCmd.MarkFlagRequired("wallet")
+	txAddCmd.Flags().StringP("category", "c", "", "Category ID")
+	txAddCmd.Flags().BoolP("yes", "y", false, "Skip confirmation for unusually large amounts")
+	txAddCmd.Flags().String("idempotency-key", "", "Unique key for scripts; re-running with the same key does not add a duplicate")
+	txAddCmd.Flags().String("currency", "", "Currency of --amount if not the wallet's (e.g. USD); converted with app.rates")
 	_ = txAddCmd.MarkFlagRequired("amount")
+	_ = txAddCmd.RegisterFlagCompletionFunc("wallet", completeWalletIDs)
+	_ = txAddCmd.RegisterFlagCompletionFunc("category", completeCategoryIDs)
+	_ = txAddCmd.RegisterFlagCompletionFunc("type",
+		cobra.FixedCompletions([]string{"income", "expense"}, cobra.ShellCompDirectiveNoFileComp))
 	transactionCmd.AddCommand(txAddCmd)
 
 	// tx delete
 	transactionCmd.AddCommand(txDeleteCmd)
 
+	// tx attach
+	transactionCmd.AddCommand(txAttachCmd)
+
+	// tx recategorize / tag
+	txRecategorizeCmd.Flags().StringP("category", "c", "", "New category (ID or name)")
+	_ = txRecategorizeCmd.MarkFlagRequired("category")
+	_ = txRecategorizeCmd.RegisterFlagCompletionFunc("category", completeCategoryIDs)
+	transactionCmd.AddCommand(txRecategorizeCmd)
+
+	txTagCmd.Flags().StringArray("add", nil, "Tag to add; repeat for several")
+	txTagCmd.Flags().StringArray("remove", nil, "Tag to remove; repeat for several")
+	transactionCmd.AddCommand(txTagCmd)
+
 	// tx summary
+	txSummaryCmd.Flags().String("period", "month", "Period to summarize: month or year (fiscal, see app.fiscal_year_start_month)")
+	txSummaryCmd.Flags().String("month", "", "Month to summarize as YYYY-MM (default: current period)")
+	txSummaryCmd.Flags().Int("year", 0, "Fiscal year to summarize with --period year, named by its start year (default: current)")
+	txSummaryCmd.Flags().Bool("ytd", false, "Summarize from the start of the current fiscal year to today")
+	_ = txSummaryCmd.RegisterFlagCompletionFunc("period",
+		cobra.FixedCompletions([]string{"month", "year"}, cobra.ShellCompDirectiveNoFileComp))
+	txSummaryCmd.Flags().StringArrayP("wallet", "w", nil, "Only this wallet (ID or name); repeat to combine several wallets")
+	_ = txSummaryCmd.RegisterFlagCompletionFunc("wallet", completeWalletIDs)
 	transactionCmd.AddCommand(txSummaryCmd)
 }
 
+// confirmLargeTransaction mengecek anomaly dan minta konfirmasi user
+// jika amount jauh di atas kebiasaan.
+//
+// Di mode non-interactive (script/pipe) warning hanya di-print dan
+// transaksi tetap lanjut, supaya automation tidak pernah ter-block.
+func confirmLargeTransaction(cmd *cobra.Command, txService *service.TransactionService, input service.CreateTransactionInput) (bool, error) {
+	ctx := cmd.Context()
+
+	absolute, err := application.Config.Validation.LargeAbsoluteAmount()
+	if err != nil {
+		return false, err
+	}
+
+	warning, err := txService.CheckAnomaly(ctx, input, service.AnomalyThresholds{
+		Multiplier: application.Config.Validation.LargeMultiplier,
+		Absolute:   absolute,
+	})
+	if err != nil {
+		return false, err
+	}
+	if warning == nil {
+		return true, nil
+	}
+
+	label := "uncategorized"
+	if input.CategoryID != nil {
+		if category, err := application.Repos.Category.GetByID(ctx, *input.CategoryID); err == nil {
+			label = category.Name
+		}
+	}
+
+	message := warningStyle.Render("⚠️  " + warning.Message(label, input.Type))
+	if !isInteractive() {
+		fmt.Fprintln(os.Stderr, message)
+		return true, nil
+	}
+
+	fmt.Fprintln(stdout, message)
+	return confirm("Continue?"), nil
+}
+
+// printValidationWarning menampilkan warning sanity check dari service
+// (misalnya transaksi sebelum wallet dibuat) dengan warna kuning. Di mode
+// non-interactive warning ditulis ke stderr supaya output tetap bersih.
+func printValidationWarning(warning error) {
+	message := warningStyle.Render("⚠️  " + warning.Error() + " (set validation.strict_validation to reject)")
+	if !isInteractive() {
+		fmt.Fprintln(os.Stderr, message)
+		return
+	}
+	fmt.Fprintln(stdout, message)
+}
+
 // truncate memotong string jika terlalu panjang.
 func truncate(s string, max int) string {
 	if len(s) <= max {
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/app"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+func TestTxList_GroupBy(t *testing.T) {
+	t.Cleanup(func() { _ = txListCmd.Flags().Set("group-by", "") })
+
+	tests := []struct {
+		key   string
+		order []string // label section yang harus muncul berurutan
+	}{
+		{key: "category", order: []string{"Salary", "Food & Dining", "Transportation"}},
+		{key: "wallet", order: []string{"BCA", "Cash", "GoPay"}},
+		{key: "day", order: []string{"Hari ini", "Kemarin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			out := string(runPiped(t, "tx", "list", "--group-by", tt.key))
+
+			last := -1
+			for _, label := range tt.order {
+				idx := strings.Index(out, "\n"+label+"  ")
+				if idx < 0 {
+					t.Fatalf("missing section %q:\n%s", label, out)
+				}
+				if idx < last {
+					t.Errorf("section %q out of order:\n%s", label, out)
+				}
+				last = idx
+			}
+			if !strings.Contains(out, "Total: 14 transactions") {
+				t.Errorf("missing grand total:\n%s", out)
+			}
+			if strings.Contains(out, "Totals differ") {
+				t.Errorf("grand total does not match the summary:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestTxList_GroupByInvalid(t *testing.T) {
+	runPiped(t, "wallet", "list")
+	t.Cleanup(func() { _ = txListCmd.Flags().Set("group-by", "") })
+
+	rootCmd.SetArgs([]string{"tx", "list", "--group-by", "month"})
+	if err := rootCmd.Execute(); !errors.Is(err, service.ErrInvalidGroupKey) {
+		t.Errorf("tx list --group-by month error = %v, want ErrInvalidGroupKey", err)
+	}
+}
+
+func TestTxList_MarkdownKeepsEmoji(t *testing.T) {
+	t.Cleanup(func() {
+		_ = txListCmd.Flags().Set("md", "false")
+		_ = txListCmd.Flags().Set("clip", "false")
+	})
+	// Tanpa tool clipboard, --clip jatuh ke print
+	t.Setenv("PATH", "")
+
+	for _, flag := range []string{"--md", "--clip"} {
+		t.Run(flag, func(t *testing.T) {
+			demo, err := app.NewDemo("")
+			if err != nil {
+				t.Fatalf("NewDemo() error = %v", err)
+			}
+			application = demo
+
+			wallets, err := demo.Repos.Wallet.List(context.Background(), repository.WalletFilter{})
+			if err != nil || len(wallets) == 0 {
+				t.Fatalf("List() = %d wallets, %v; want at least 1", len(wallets), err)
+			}
+			tx := models.NewTransaction(wallets[0].ID, models.TransactionTypeExpense, decimal.NewFromInt(25000))
+			tx.Description = "Kopi ☕ dan mie 🍜"
+			if err := demo.Repos.Transaction.Create(context.Background(), tx); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			// execPiped: stdout bukan terminal, jadi mode plain aktif
+			out := string(execPiped(t, "tx", "list", flag))
+			if !strings.Contains(out, "| Kopi ☕ dan mie 🍜 ") {
+				t.Errorf("tx list %s output lost the emoji:\n%s", flag, out)
+			}
+		})
+	}
+}
+
+func TestTxAdd_ForeignCurrency(t *testing.T) {
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	application = demo
+	demo.Config.App.Rates = map[string]string{"USD": "16000"}
+
+	wallets, err := demo.Repos.Wallet.List(context.Background(), repository.WalletFilter{})
+	if err != nil || len(wallets) == 0 {
+		t.Fatalf("List() = %d wallets, %v; want at least 1", len(wallets), err)
+	}
+	// Wallet dengan saldo terbesar, supaya expense 160.000 selalu cukup
+	wallet := wallets[0]
+	for _, w := range wallets {
+		if w.Balance.GreaterThan(wallet.Balance) {
+			wallet = w
+		}
+	}
+
+	t.Cleanup(func() {
+		for _, name := range []string{"wallet", "amount", "description", "currency"} {
+			_ = txAddCmd.Flags().Set(name, "")
+		}
+		_ = txAddCmd.Flags().Set("yes", "false")
+	})
+
+	out := string(execPiped(t, "tx", "add", "-w", wallet.ID.String(), "-a", "10", "--currency", "USD", "-d", "Coffee", "-y"))
+	if !strings.Contains(out, "expense: 160.000") {
+		t.Errorf("output = %q, want the amount converted to 160.000", out)
+	}
+	if !strings.Contains(out, "from $10.00") {
+		t.Errorf("output = %q, want the original amount", out)
+	}
+}
+
+func TestTxAdd_IdempotencyKeyReplay(t *testing.T) {
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	application = demo
+
+	wallets, err := demo.Repos.Wallet.List(context.Background(), repository.WalletFilter{})
+	if err != nil || len(wallets) == 0 {
+		t.Fatalf("List() = %d wallets, %v; want at least 1", len(wallets), err)
+	}
+
+	t.Cleanup(func() {
+		for _, name := range []string{"wallet", "amount", "description", "idempotency-key"} {
+			_ = txAddCmd.Flags().Set(name, "")
+		}
+		_ = txAddCmd.Flags().Set("type", "expense")
+		_ = txAddCmd.Flags().Set("yes", "false")
+	})
+
+	args := []string{"tx", "add", "-w", wallets[0].ID.String(), "-t", "income", "-a", "1000", "-d", "Refund", "--idempotency-key", "refund-1", "-y"}
+	if out := string(execPiped(t, args...)); !strings.Contains(out, "Transaction added!") {
+		t.Errorf("first tx add output = %q, want the transaction added", out)
+	}
+	if out := string(execPiped(t, args...)); !strings.Contains(out, "Already recorded with this idempotency key") {
+		t.Errorf("repeated tx add output = %q, want the replay notice", out)
+	}
+}
+
+func TestTxSummary_Year(t *testing.T) {
+	t.Cleanup(func() {
+		for _, name := range []string{"period", "year", "ytd"} {
+			f := txSummaryCmd.Flags().Lookup(name)
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		}
+	})
+
+	out := string(runPiped(t, "tx", "summary", "--period", "year"))
+	if !strings.Contains(out, "Yearly Summary") || !strings.Contains(out, "Income") {
+		t.Errorf("yearly summary output:\n%s", out)
+	}
+
+	out = string(runPiped(t, "tx", "summary", "--ytd"))
+	if !strings.Contains(out, "Year-to-date Summary") {
+		t.Errorf("year-to-date summary output:\n%s", out)
+	}
+
+	rootCmd.SetArgs([]string{"tx", "summary", "--ytd=false", "--period", "week"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("--period week error = nil, want error")
+	}
+}
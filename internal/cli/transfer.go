@@ -6,20 +6,20 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 
-	"github.com/Adityanrhm/wallet-twin/internal/repository/postgres"
 	"github.com/Adityanrhm/wallet-twin/internal/service"
 )
 
 // transferCmd adalah command untuk transfer antar wallet.
 var transferCmd = &cobra.Command{
-	Use:     "transfer",
-	Aliases: []string{"tf"},
-	Short:   "🔄 Transfer money between wallets",
-	Long:    "Transfer money from one wallet to another, with optional fee.",
+	Use:         "transfer",
+	Aliases:     []string{"tf"},
+	Short:       "🔄 Transfer money between wallets",
+	Annotations: requiresDB,
+	Long:        "Transfer money from one wallet to another, with optional fee.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		txManager := postgres.NewTransactionManager(application.DB.Pool)
+		txManager := application.TxManager
 		transferService := service.NewTransferService(
 			application.Repos.Transfer,
 			application.Repos.Wallet,
@@ -31,6 +31,7 @@ var transferCmd = &cobra.Command{
 		amountStr, _ := cmd.Flags().GetString("amount")
 		feeStr, _ := cmd.Flags().GetString("fee")
 		note, _ := cmd.Flags().GetString("note")
+		rateStr, _ := cmd.Flags().GetString("rate")
 
 		// Parse IDs
 		fromUUID, err := parseUUID(fromID)
@@ -58,6 +59,15 @@ var transferCmd = &cobra.Command{
 			}
 		}
 
+		// Parse exchange rate (hanya untuk wallet beda currency)
+		rate := decimal.Zero
+		if rateStr != "" {
+			rate, err = decimal.NewFromString(rateStr)
+			if err != nil {
+				return fmt.Errorf("invalid rate: %w", err)
+			}
+		}
+
 		// Create transfer
 		transfer, err := transferService.Create(ctx, service.CreateTransferInput{
 			FromWalletID: fromUUID,
@@ -65,20 +75,26 @@ var transferCmd = &cobra.Command{
 			Amount:       amount,
 			Fee:          fee,
 			Note:         note,
+			ExchangeRate: rate,
 		})
 
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(successStyle.Render("✅ Transfer successful!"))
-		fmt.Printf("   💸 Amount: %s\n", formatMoney(transfer.Amount))
+		currencies := walletCurrencies(ctx)
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Transfer successful!"))
+		fmt.Fprintf(stdout, "   💸 Amount: %s\n", formatMoneyIn(transfer.Amount, transfer.FromWalletID, currencies))
 		if !transfer.Fee.IsZero() {
-			fmt.Printf("   💳 Fee: %s\n", formatMoney(transfer.Fee))
-			fmt.Printf("   📉 Total deducted: %s\n", formatMoney(transfer.TotalDeducted()))
+			fmt.Fprintf(stdout, "   💳 Fee: %s\n", formatMoneyIn(transfer.Fee, transfer.FromWalletID, currencies))
+			fmt.Fprintf(stdout, "   📉 Total deducted: %s\n", formatMoneyIn(transfer.TotalDeducted(), transfer.FromWalletID, currencies))
+		}
+		if transfer.ExchangeRate != nil {
+			fmt.Fprintf(stdout, "   💱 Received: %s (rate %s)\n", formatMoneyIn(transfer.ReceivedAmount(), transfer.ToWalletID, currencies), transfer.ExchangeRate.String())
 		}
 		if transfer.Note != "" {
-			fmt.Printf("   📝 Note: %s\n", transfer.Note)
+			fmt.Fprintf(stdout, "   📝 Note: %s\n", transfer.Note)
 		}
 
 		return nil
@@ -91,8 +107,11 @@ func init() {
 	transferCmd.Flags().StringP("amount", "a", "", "Amount to transfer (required)")
 	transferCmd.Flags().StringP("fee", "F", "0", "Transfer fee")
 	transferCmd.Flags().StringP("note", "n", "", "Transfer note")
+	transferCmd.Flags().String("rate", "", "Exchange rate for wallets with different currencies (destination = amount * rate)")
 
 	_ = transferCmd.MarkFlagRequired("from")
 	_ = transferCmd.MarkFlagRequired("to")
+	_ = transferCmd.RegisterFlagCompletionFunc("from", completeWalletIDs)
+	_ = transferCmd.RegisterFlagCompletionFunc("to", completeWalletIDs)
 	_ = transferCmd.MarkFlagRequired("amount")
 }
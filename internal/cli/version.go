@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Adityanrhm/wallet-twin/internal/database"
+	"github.com/Adityanrhm/wallet-twin/internal/version"
+	"github.com/Adityanrhm/wallet-twin/migrations"
+)
+
+// versionCmd menampilkan informasi build dan versi schema yang diharapkan.
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "🏷️  Show version and build information",
+	Long: `Show the installed wallet-twin version, git commit, build date,
+Go version and the latest database migration bundled in this build.
+
+Compare the schema version with 'wallet db migrate version' to see whether
+the database needs migrating.
+
+With --check-update the latest GitHub release is checked (3s timeout);
+a failed check only prints a warning.`,
+	Example: `  wallet version
+  wallet version --check-update`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := version.Get()
+
+		schema := "unknown"
+		if files, err := database.ListEmbeddedMigrations(migrations.FS); err == nil && len(files) > 0 {
+			latest := files[len(files)-1]
+			schema = fmt.Sprintf("%06d (%s)", latest.Version, latest.Name)
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render("💰 Wallet Twin "+info.Version))
+		fmt.Fprintf(stdout, "   Commit:     %s\n", info.Commit)
+		fmt.Fprintf(stdout, "   Built:      %s\n", info.Date)
+		fmt.Fprintf(stdout, "   Go:         %s\n", info.GoVersion)
+		fmt.Fprintf(stdout, "   Schema:     %s\n", schema)
+
+		if check, _ := cmd.Flags().GetBool("check-update"); check {
+			checkUpdate(cmd.Context(), info.Version)
+		}
+		return nil
+	},
+}
+
+// checkUpdate membandingkan versi ini dengan release GitHub terbaru.
+// Gagal cek (offline, rate limit) hanya warning, bukan error.
+func checkUpdate(ctx context.Context, current string) {
+	ctx, cancel := context.WithTimeout(ctx, version.DefaultUpdateTimeout)
+	defer cancel()
+
+	fmt.Fprintln(stdout)
+	release, err := version.LatestRelease(ctx, nil, version.LatestReleaseURL)
+	if err != nil {
+		fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf("⚠️  Could not check for updates: %v", err)))
+		return
+	}
+
+	switch {
+	case version.IsNewer(release.TagName, current):
+		fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf("⬆️  A newer version is available: %s (you have %s)", release.TagName, current)))
+		if release.HTMLURL != "" {
+			fmt.Fprintf(stdout, "   %s\n", release.HTMLURL)
+		}
+	case current == version.DevelVersion:
+		fmt.Fprintf(stdout, "ℹ️  Latest release is %s (this is a development build)\n", release.TagName)
+	default:
+		fmt.Fprintln(stdout, successStyle.Render(fmt.Sprintf("✅ You are on the latest version (%s)", release.TagName)))
+	}
+}
+
+func init() {
+	versionCmd.Flags().Bool("check-update", false, "Check GitHub for a newer release")
+}
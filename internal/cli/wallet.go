@@ -1,10 +1,11 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"time"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
 	"github.com/olekukonko/tablewriter"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
@@ -12,22 +13,16 @@ import (
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
 	"github.com/Adityanrhm/wallet-twin/internal/service"
-)
-
-// Styles untuk output berwarna
-var (
-	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
-	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
-	titleStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
-	moneyStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
 // walletCmd adalah parent command untuk wallet operations.
 var walletCmd = &cobra.Command{
-	Use:     "wallet",
-	Aliases: []string{"w"},
-	Short:   "💼 Manage your wallets",
-	Long:    "Add, list, update, and delete wallets (accounts).",
+	Use:         "wallet",
+	Aliases:     []string{"w"},
+	Short:       "💼 Manage your wallets",
+	Annotations: requiresDB,
+	Long:        "Add, list, update, and delete wallets (accounts).",
 }
 
 // walletListCmd menampilkan semua wallets.
@@ -37,9 +32,14 @@ var walletListCmd = &cobra.Command{
 	Short:   "List all wallets",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
-		walletService := service.NewWalletService(application.Repos.Wallet)
+		walletService := service.NewWalletService(application.Repos.Wallet, application.Repos.Transfer, application.TxManager)
 
 		showAll, _ := cmd.Flags().GetBool("all")
+		showInactive, _ := cmd.Flags().GetBool("inactive")
+
+		if showInactive {
+			return printInactiveWallets(cmd, walletService)
+		}
 
 		filter := repository.WalletFilter{}
 		if !showAll {
@@ -53,14 +53,14 @@ var walletListCmd = &cobra.Command{
 		}
 
 		if len(wallets) == 0 {
-			fmt.Println("No wallets found. Create one with: wallet wallet add")
+			fmt.Fprintln(stdout, "No wallets found. Create one with: wallet wallet add")
 			return nil
 		}
 
 		// Print table
-		fmt.Println(titleStyle.Render("\n💼 Your Wallets\n"))
+		fmt.Fprintln(stdout, titleStyle.Render("\n💼 Your Wallets\n"))
 
-		table := tablewriter.NewTable(os.Stdout)
+		table := tablewriter.NewTable(stdout)
 		table.Header("Name", "Type", "Balance", "Currency", "Status")
 
 		for _, w := range wallets {
@@ -70,9 +70,9 @@ var walletListCmd = &cobra.Command{
 			}
 
 			table.Append([]string{
-				w.Icon + " " + w.Name,
+				models.DisplayIcon(w.Icon) + " " + w.Name,
 				string(w.Type),
-				formatMoney(w.Balance),
+				formatWalletMoney(w.Balance, w.Currency),
 				w.Currency,
 				status,
 			})
@@ -82,25 +82,79 @@ var walletListCmd = &cobra.Command{
 
 		// Total
 		total, _ := walletService.GetTotalBalance(ctx)
-		fmt.Printf("\n💰 Total Balance: %s\n\n", moneyStyle.Render(formatMoney(total)))
+		fmt.Fprintf(stdout, "\n💰 Total Balance: %s\n\n", moneyStyle.Render(formatMoney(total)))
 
 		return nil
 	},
 }
 
+// printInactiveWallets menampilkan wallet nonaktif beserta tanggal terakhir dipakai.
+func printInactiveWallets(cmd *cobra.Command, walletService *service.WalletService) error {
+	infos, err := walletService.GetInactiveWallets(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if len(infos) == 0 {
+		fmt.Fprintln(stdout, "No inactive wallets.")
+		return nil
+	}
+
+	fmt.Fprintln(stdout, titleStyle.Render("\n🗄️  Inactive Wallets\n"))
+
+	table := tablewriter.NewTable(stdout)
+	table.Header("Name", "Type", "Balance", "Currency", "Last Used")
+
+	for _, w := range infos {
+		lastUsed := "never"
+		if w.LastTransactionDate != nil {
+			lastUsed = formatLongDate(*w.LastTransactionDate)
+		}
+
+		table.Append([]string{
+			models.DisplayIcon(w.Icon) + " " + w.Name,
+			string(w.Type),
+			formatWalletMoney(w.Balance, w.Currency),
+			w.Currency,
+			lastUsed,
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
 // walletAddCmd menambah wallet baru.
 var walletAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a new wallet",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
-		walletService := service.NewWalletService(application.Repos.Wallet)
+		walletService := service.NewWalletService(application.Repos.Wallet, application.Repos.Transfer, application.TxManager)
 
 		name, _ := cmd.Flags().GetString("name")
 		walletType, _ := cmd.Flags().GetString("type")
 		currency, _ := cmd.Flags().GetString("currency")
 		balance, _ := cmd.Flags().GetString("balance")
 		icon, _ := cmd.Flags().GetString("icon")
+		color, _ := cmd.Flags().GetString("color")
+		opened, _ := cmd.Flags().GetString("opened")
+		lowBalance, _ := cmd.Flags().GetString("low-balance")
+
+		icon, err := resolveIcon(icon, "💰")
+		if err != nil {
+			return err
+		}
+
+		// Parse opening date (optional)
+		var openingDate *time.Time
+		if opened != "" {
+			date, err := time.ParseInLocation("2006-01-02", opened, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid opening date (use YYYY-MM-DD): %w", err)
+			}
+			openingDate = &date
+		}
 
 		// Parse balance
 		initialBalance := decimal.Zero
@@ -112,6 +166,16 @@ var walletAddCmd = &cobra.Command{
 			}
 		}
 
+		// Parse low balance threshold (optional)
+		var threshold *decimal.Decimal
+		if lowBalance != "" {
+			d, err := decimal.NewFromString(lowBalance)
+			if err != nil {
+				return fmt.Errorf("invalid low balance threshold: %w", err)
+			}
+			threshold = &d
+		}
+
 		// Create wallet
 		wallet, err := walletService.Create(ctx, service.CreateWalletInput{
 			Name:           name,
@@ -119,16 +183,23 @@ var walletAddCmd = &cobra.Command{
 			Currency:       currency,
 			InitialBalance: initialBalance,
 			Icon:           icon,
+			Color:          color,
+			OpeningDate:    openingDate,
+
+			LowBalanceThreshold: threshold,
 		})
 
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(successStyle.Render("✅ Wallet created successfully!"))
-		fmt.Printf("   ID: %s\n", wallet.ID)
-		fmt.Printf("   Name: %s %s\n", wallet.Icon, wallet.Name)
-		fmt.Printf("   Balance: %s %s\n", wallet.Currency, formatMoney(wallet.Balance))
+		fmt.Fprintln(stdout, successStyle.Render("✅ Wallet created successfully!"))
+		fmt.Fprintf(stdout, "   ID: %s\n", wallet.ID)
+		fmt.Fprintf(stdout, "   Name: %s %s\n", wallet.Icon, wallet.Name)
+		fmt.Fprintf(stdout, "   Balance: %s %s\n", wallet.Currency, formatWalletMoney(wallet.Balance, wallet.Currency))
+		if wallet.OpeningDate != nil {
+			fmt.Fprintf(stdout, "   Opened: %s\n", formatDate(*wallet.OpeningDate))
+		}
 
 		return nil
 	},
@@ -141,7 +212,7 @@ var walletDeleteCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
-		walletService := service.NewWalletService(application.Repos.Wallet)
+		walletService := service.NewWalletService(application.Repos.Wallet, application.Repos.Transfer, application.TxManager)
 
 		id, err := parseUUID(args[0])
 		if err != nil {
@@ -152,7 +223,7 @@ var walletDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Println(successStyle.Render("✅ Wallet deleted successfully!"))
+		fmt.Fprintln(stdout, successStyle.Render("✅ Wallet deleted successfully!"))
 		return nil
 	},
 }
@@ -162,17 +233,358 @@ var walletBalanceCmd = &cobra.Command{
 	Use:     "balance",
 	Aliases: []string{"bal"},
 	Short:   "Show total balance across all wallets",
+	Long: `Show the total balance of your wallets.
+
+By default only active wallets count, the same total the dashboard shows.
+Archived (deleted) wallets keep their last balance; use --include-archived
+for the all-time total including them.`,
+	Example: `  wallet wallet balance
+  wallet wallet balance --include-archived`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
-		walletService := service.NewWalletService(application.Repos.Wallet)
+		walletService := service.NewWalletService(application.Repos.Wallet, application.Repos.Transfer, application.TxManager)
 
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+
+		title := "\n💰 Total Balance"
 		total, err := walletService.GetTotalBalance(ctx)
+		if includeArchived {
+			title += " (including archived wallets)"
+			total, err = walletService.GetTotalBalanceIncludingArchived(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		low, err := walletService.GetLowBalanceWallets(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render(title))
+		fmt.Fprintf(stdout, "%s %s\n\n", application.Config.App.Currency, moneyStyle.Render(formatMoney(total)))
+
+		for _, w := range low {
+			fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf("⚠️  Low balance: %s %s is below %s",
+				w.Name, formatWalletMoney(w.Balance, w.Currency), formatWalletMoney(*w.LowBalanceThreshold, w.Currency))))
+		}
+		if len(low) > 0 {
+			fmt.Fprintln(stdout)
+		}
+
+		return nil
+	},
+}
+
+// walletAdjustCmd mengoreksi saldo wallet dengan transaksi adjustment.
+var walletAdjustCmd = &cobra.Command{
+	Use:   "adjust [wallet-id]",
+	Short: "Correct a wallet balance with an adjustment transaction",
+	Long: `Bring a wallet to its real balance by recording an auditable adjustment.
+
+The difference is recorded as an income or expense in the "Adjustment"
+category and tagged so monthly summaries and reports exclude it.`,
+	Example: `  wallet wallet adjust 3f2a... --to 1250000 --reason "Cash count"`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		txService := service.NewTransactionService(application.Repos.Transaction, application.Repos.Wallet, application.Repos.Category, application.TxManager)
+		categoryService := newCategoryService()
+
+		id, err := parseUUID(args[0])
+		if err != nil {
+			return err
+		}
+
+		to, _ := cmd.Flags().GetString("to")
+		reason, _ := cmd.Flags().GetString("reason")
+
+		target, err := decimal.NewFromString(to)
+		if err != nil {
+			return fmt.Errorf("invalid target balance: %w", err)
+		}
+
+		wallet, err := application.Repos.Wallet.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("wallet not found: %w", err)
+		}
+
+		catType := models.CategoryTypeIncome
+		if target.LessThan(wallet.Balance) {
+			catType = models.CategoryTypeExpense
+		}
+		category, err := categoryService.GetAdjustmentCategory(ctx, catType)
+		if err != nil {
+			return err
+		}
+
+		tx, err := txService.Adjust(ctx, service.AdjustBalanceInput{
+			WalletID:      id,
+			TargetBalance: target,
+			CategoryID:    &category.ID,
+			Reason:        reason,
+		})
+		if errors.Is(err, service.ErrNoAdjustmentNeeded) {
+			fmt.Fprintln(stdout, successStyle.Render("✅ Balance already matches, nothing to adjust."))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		sign := "+"
+		if tx.Type.IsExpense() {
+			sign = "-"
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Balance adjusted!"))
+		fmt.Fprintf(stdout, "   Wallet: %s %s\n", models.DisplayIcon(wallet.Icon), wallet.Name)
+		fmt.Fprintf(stdout, "   Adjustment: %s%s\n", sign, formatWalletMoney(tx.Amount, wallet.Currency))
+		fmt.Fprintf(stdout, "   New balance: %s %s\n", wallet.Currency, moneyStyle.Render(formatWalletMoney(target, wallet.Currency)))
+
+		return nil
+	},
+}
+
+// walletShowCmd menampilkan detail satu wallet.
+var walletShowCmd = &cobra.Command{
+	Use:   "show [wallet-id]",
+	Short: "Show wallet details (and gain/loss for investment wallets)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		id, err := parseUUID(args[0])
+		if err != nil {
+			return err
+		}
+
+		wallet, err := application.Repos.Wallet.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("wallet not found: %w", err)
+		}
+
+		status := "✅ active"
+		if !wallet.IsActive {
+			status = "❌ inactive"
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render(fmt.Sprintf("\n%s %s\n", models.DisplayIcon(wallet.Icon), wallet.Name)))
+		fmt.Fprintf(stdout, "   ID:       %s\n", wallet.ID)
+		fmt.Fprintf(stdout, "   Type:     %s\n", wallet.Type)
+		fmt.Fprintf(stdout, "   Status:   %s\n", status)
+		fmt.Fprintf(stdout, "   Balance:  %s %s\n", wallet.Currency, moneyStyle.Render(formatWalletMoney(wallet.Balance, wallet.Currency)))
+		if wallet.LowBalanceThreshold != nil {
+			alert := "below " + formatWalletMoney(*wallet.LowBalanceThreshold, wallet.Currency)
+			if wallet.IsLowBalance() {
+				alert = warningStyle.Render("⚠️  " + alert)
+			}
+			fmt.Fprintf(stdout, "   Alert:    %s\n", alert)
+		}
+
+		if wallet.Type != models.WalletTypeInvestment {
+			return nil
+		}
+
+		investmentService := service.NewInvestmentService(application.Repos.Wallet, application.Repos.Transaction, application.Repos.Transfer, application.TxManager)
+		perf, err := investmentService.GetPerformance(ctx, wallet.ID)
+		if err != nil {
+			return err
+		}
+
+		gainStyle := incomeStyle
+		sign := "+"
+		if perf.UnrealizedGain.IsNegative() {
+			gainStyle, sign = expenseStyle, ""
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render("\n📈 Investment\n"))
+		fmt.Fprintf(stdout, "   Contributed:     %s\n", formatWalletMoney(perf.Contributed, wallet.Currency))
+		fmt.Fprintf(stdout, "   Current value:   %s\n", formatWalletMoney(perf.CurrentValue, wallet.Currency))
+		fmt.Fprintf(stdout, "   Unrealized gain: %s\n", gainStyle.Render(fmt.Sprintf("%s%s (%s%.2f%%)",
+			sign, formatWalletMoney(perf.UnrealizedGain, wallet.Currency), sign, perf.GainPercent)))
+		if !perf.RealizedGain.IsZero() {
+			fmt.Fprintf(stdout, "   Realized gain:   %s\n", formatWalletMoney(perf.RealizedGain, wallet.Currency))
+		}
+		if perf.LastRevaluation != nil {
+			fmt.Fprintf(stdout, "   Last valued:     %s (%d revaluations)\n", formatDate(*perf.LastRevaluation), perf.Revaluations)
+		} else {
+			fmt.Fprintln(stdout, "   Not revalued yet. Record the market value with: wallet wallet revalue")
+		}
+
+		return nil
+	},
+}
+
+// walletAlertCmd mengatur alert saldo rendah wallet.
+var walletAlertCmd = &cobra.Command{
+	Use:   "alert [wallet]",
+	Short: "Set or clear the low balance alert of a wallet",
+	Long: `Warn when the wallet balance drops below --below. The warning shows up in
+'wallet balance' and the dashboard. Use --off to remove the alert.`,
+	Example: `  wallet wallet alert BCA --below 500000
+  wallet wallet alert BCA --off`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		walletService := service.NewWalletService(application.Repos.Wallet, application.Repos.Transfer, application.TxManager)
+
+		belowStr, _ := cmd.Flags().GetString("below")
+		off, _ := cmd.Flags().GetBool("off")
+
+		wallet, err := resolveWallet(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		input := service.UpdateWalletInput{ID: wallet.ID, ClearLowBalanceThreshold: off}
+		if belowStr != "" {
+			below, err := decimal.NewFromString(belowStr)
+			if err != nil {
+				return fmt.Errorf("invalid amount: %w", err)
+			}
+			input.LowBalanceThreshold = &below
+		}
+
+		wallet, err = walletService.Update(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		if wallet.LowBalanceThreshold == nil {
+			fmt.Fprintln(stdout, successStyle.Render("✅ Low balance alert removed from "+wallet.Name))
+			return nil
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Low balance alert set!"))
+		fmt.Fprintf(stdout, "   Wallet: %s %s\n", models.DisplayIcon(wallet.Icon), wallet.Name)
+		fmt.Fprintf(stdout, "   Alert below: %s\n", formatWalletMoney(*wallet.LowBalanceThreshold, wallet.Currency))
+		if wallet.IsLowBalance() {
+			fmt.Fprintln(stdout, warningStyle.Render(fmt.Sprintf("   ⚠️  Balance is already below: %s",
+				formatWalletMoney(wallet.Balance, wallet.Currency))))
+		}
+
+		return nil
+	},
+}
+
+// walletRevalueCmd mencatat nilai pasar terbaru wallet investment.
+var walletRevalueCmd = &cobra.Command{
+	Use:   "revalue [wallet-id]",
+	Short: "Record the current market value of an investment wallet",
+	Long: `Set an investment wallet's balance to its current market value.
+
+The change is recorded as a revaluation entry: it moves the balance but is
+excluded from income/expense summaries, so 'wallet show' can separate what
+you contributed from unrealized gain or loss.`,
+	Example: `  wallet wallet revalue 3f2a... --value 15250000 --note "NAV update"`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		investmentService := service.NewInvestmentService(application.Repos.Wallet, application.Repos.Transaction, application.Repos.Transfer, application.TxManager)
+
+		id, err := parseUUID(args[0])
+		if err != nil {
+			return err
+		}
+
+		valueStr, _ := cmd.Flags().GetString("value")
+		note, _ := cmd.Flags().GetString("note")
+
+		value, err := decimal.NewFromString(valueStr)
+		if err != nil {
+			return fmt.Errorf("invalid value: %w", err)
+		}
+
+		tx, err := investmentService.Revalue(ctx, service.RevalueInput{
+			WalletID: id,
+			Value:    value,
+			Note:     note,
+		})
+		if errors.Is(err, service.ErrValueUnchanged) {
+			fmt.Fprintln(stdout, successStyle.Render("✅ Value unchanged, nothing to record."))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		wallet, err := application.Repos.Wallet.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("wallet not found: %w", err)
+		}
+
+		change := incomeStyle.Render("+" + formatWalletMoney(tx.Amount, wallet.Currency))
+		if tx.Type.IsExpense() {
+			change = expenseStyle.Render("-" + formatWalletMoney(tx.Amount, wallet.Currency))
+		}
+
+		fmt.Fprintln(stdout, successStyle.Render("✅ Value updated!"))
+		fmt.Fprintf(stdout, "   Wallet: %s %s\n", models.DisplayIcon(wallet.Icon), wallet.Name)
+		fmt.Fprintf(stdout, "   Change: %s\n", change)
+		fmt.Fprintf(stdout, "   Value:  %s %s\n", wallet.Currency, moneyStyle.Render(formatWalletMoney(wallet.Balance, wallet.Currency)))
+
+		return nil
+	},
+}
+
+// walletAuditCmd menampilkan riwayat perubahan saldo wallet.
+var walletAuditCmd = &cobra.Command{
+	Use:   "audit [wallet]",
+	Short: "Show the history of balance changes of a wallet",
+	Long: `List every change to the wallet balance, newest first: the old and new
+balance, why it changed, and the transaction, transfer or goal that caused
+it. Use it to answer "why is my balance this number?".
+
+Reasons: transaction_created, transaction_deleted, import, recurring,
+transfer, adjustment, revaluation and goal_contribution. Changes made before the
+audit log existed are not listed.`,
+	Example: `  wallet wallet audit BCA
+  wallet wallet audit BCA --limit 100`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		walletService := service.NewWalletService(application.Repos.Wallet, application.Repos.Transfer, application.TxManager)
+
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		wallet, err := resolveWallet(ctx, args[0])
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(titleStyle.Render("\n💰 Total Balance"))
-		fmt.Printf("%s %s\n\n", application.Config.App.Currency, moneyStyle.Render(formatMoney(total)))
+		entries, err := walletService.ListBalanceAudit(ctx, wallet.ID, repository.ListParams{Limit: limit})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, titleStyle.Render(fmt.Sprintf("\n🔍 Balance changes – %s %s\n", models.DisplayIcon(wallet.Icon), wallet.Name)))
+		if len(entries) == 0 {
+			fmt.Fprintln(stdout, "No balance changes recorded yet.")
+			return nil
+		}
+
+		table := tablewriter.NewTable(stdout)
+		table.Header("Date", "Reason", "Change", "Old", "New", "Reference")
+		for _, e := range entries {
+			change := incomeStyle.Render("+" + formatWalletMoney(e.Delta(), wallet.Currency))
+			if e.Delta().IsNegative() {
+				change = expenseStyle.Render("-" + formatWalletMoney(e.Delta().Neg(), wallet.Currency))
+			}
+			reference := "-"
+			if e.ReferenceID != nil {
+				reference = e.ReferenceID.String()
+			}
+			table.Append([]string{
+				e.CreatedAt.Local().Format("2006-01-02 15:04"),
+				string(e.Reason),
+				change,
+				formatWalletMoney(e.OldBalance, wallet.Currency),
+				formatWalletMoney(e.NewBalance, wallet.Currency),
+				reference,
+			})
+		}
+		table.Render()
 
 		return nil
 	},
@@ -181,25 +593,84 @@ var walletBalanceCmd = &cobra.Command{
 func init() {
 	// wallet list
 	walletListCmd.Flags().BoolP("all", "a", false, "Show all wallets including inactive")
+	walletListCmd.Flags().Bool("inactive", false, "Show only inactive wallets with their last-used date")
+	walletListCmd.MarkFlagsMutuallyExclusive("all", "inactive")
 	walletCmd.AddCommand(walletListCmd)
 
 	// wallet add
 	walletAddCmd.Flags().StringP("name", "n", "", "Wallet name (required)")
-	walletAddCmd.Flags().StringP("type", "t", "cash", "Wallet type: cash, bank, ewallet")
+	walletAddCmd.Flags().StringP("type", "t", "cash", "Wallet type: cash, bank, ewallet, investment")
 	walletAddCmd.Flags().StringP("currency", "c", "IDR", "Currency code")
 	walletAddCmd.Flags().StringP("balance", "b", "0", "Initial balance")
-	walletAddCmd.Flags().StringP("icon", "i", "💰", "Wallet icon")
+	walletAddCmd.Flags().StringP("icon", "i", "💰", `Wallet icon: one emoji, an icon name like "bank", or "pick" to choose from a list`)
+	walletAddCmd.Flags().String("color", "", "Wallet color as #RRGGBB, used in the dashboard and Excel exports")
+	walletAddCmd.Flags().String("opened", "", "Date the wallet was opened (YYYY-MM-DD), if earlier than today")
+	walletAddCmd.Flags().String("low-balance", "", "Warn when the balance drops below this amount")
 	_ = walletAddCmd.MarkFlagRequired("name")
 	walletCmd.AddCommand(walletAddCmd)
 
 	// wallet delete
+	walletDeleteCmd.ValidArgsFunction = completeFirstArg(completeWalletIDs)
 	walletCmd.AddCommand(walletDeleteCmd)
 
 	// wallet balance
+	walletBalanceCmd.Flags().Bool("include-archived", false, "Include archived wallets in the total")
 	walletCmd.AddCommand(walletBalanceCmd)
+
+	// wallet adjust
+	walletAdjustCmd.Flags().String("to", "", "Actual balance of the wallet (required)")
+	walletAdjustCmd.Flags().StringP("reason", "r", "", "Reason for the adjustment")
+	_ = walletAdjustCmd.MarkFlagRequired("to")
+	walletAdjustCmd.ValidArgsFunction = completeFirstArg(completeWalletIDs)
+	walletCmd.AddCommand(walletAdjustCmd)
+
+	// wallet show
+	walletShowCmd.ValidArgsFunction = completeFirstArg(completeWalletIDs)
+	walletCmd.AddCommand(walletShowCmd)
+
+	// wallet revalue
+	walletRevalueCmd.Flags().String("value", "", "Current market value of the wallet (required)")
+	walletRevalueCmd.Flags().StringP("note", "n", "", "Note, e.g. \"NAV update\"")
+	_ = walletRevalueCmd.MarkFlagRequired("value")
+	walletRevalueCmd.ValidArgsFunction = completeFirstArg(completeWalletIDs)
+	walletCmd.AddCommand(walletRevalueCmd)
+
+	// wallet alert
+	walletAlertCmd.Flags().String("below", "", "Warn when the balance drops below this amount")
+	walletAlertCmd.Flags().Bool("off", false, "Remove the low balance alert")
+	walletAlertCmd.MarkFlagsOneRequired("below", "off")
+	walletAlertCmd.MarkFlagsMutuallyExclusive("below", "off")
+	walletAlertCmd.ValidArgsFunction = completeFirstArg(completeWalletIDs)
+	walletCmd.AddCommand(walletAlertCmd)
+
+	// wallet audit
+	walletAuditCmd.Flags().IntP("limit", "l", 20, "Maximum number of changes to show (max 100)")
+	walletAuditCmd.ValidArgsFunction = completeFirstArg(completeWalletIDs)
+	walletCmd.AddCommand(walletAuditCmd)
 }
 
-// formatMoney memformat decimal sebagai string dengan thousand separator.
+// formatMoney memformat nominal dalam currency default (app.currency di config),
+// dengan thousand separator dan jumlah desimal sesuai currency.
+// Dipakai untuk total dan nominal yang tidak terikat ke satu wallet.
 func formatMoney(d decimal.Decimal) string {
-	return d.StringFixed(0)
+	currency := ""
+	if application != nil {
+		currency = application.Config.App.Currency
+	}
+	return utils.FormatAmount(d, currency)
+}
+
+// formatWalletMoney memformat nominal dalam currency wallet,
+// misalnya 1,234.56 untuk wallet USD dan 1.234 untuk wallet IDR.
+func formatWalletMoney(d decimal.Decimal, currency string) string {
+	return utils.FormatAmount(d, currency)
+}
+
+// formatMoneyIn memformat nominal milik walletID memakai currencies
+// (lihat walletCurrencies), atau currency default jika wallet tidak dikenal.
+func formatMoneyIn(d decimal.Decimal, walletID uuid.UUID, currencies map[uuid.UUID]string) string {
+	if currency, ok := currencies[walletID]; ok {
+		return formatWalletMoney(d, currency)
+	}
+	return formatMoney(d)
 }
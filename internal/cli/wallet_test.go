@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/app"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestWalletAdd_Icon(t *testing.T) {
+	t.Cleanup(func() { _ = walletAddCmd.Flags().Set("icon", "💰") })
+
+	runPiped(t, "wallet", "add", "--name", "Travel Fund", "--icon", "plane")
+
+	wallets, err := application.Repos.Wallet.List(context.Background(), repository.WalletFilter{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, w := range wallets {
+		if w.Name == "Travel Fund" {
+			if w.Icon != "✈️" {
+				t.Errorf("Icon = %q, want the emoji for \"plane\"", w.Icon)
+			}
+			return
+		}
+	}
+	t.Fatal("wallet was not created")
+}
+
+func TestWalletAdd_InvalidIcon(t *testing.T) {
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	application = demo
+	t.Cleanup(func() {
+		application = nil
+		_ = walletAddCmd.Flags().Set("icon", "💰")
+	})
+
+	rootCmd.SetArgs([]string{"wallet", "add", "--name", "Snacks", "--icon", "🍔🍟"})
+	if err := rootCmd.Execute(); !errors.Is(err, models.ErrInvalidIcon) {
+		t.Errorf("wallet add --icon 🍔🍟 error = %v, want ErrInvalidIcon", err)
+	}
+}
+
+func TestWalletAlert(t *testing.T) {
+	// Changed juga di-reset supaya flag group below/off tidak bentrok
+	resetFlags := func() {
+		for name, value := range map[string]string{"below": "", "off": "false"} {
+			f := walletAlertCmd.Flags().Lookup(name)
+			_ = f.Value.Set(value)
+			f.Changed = false
+		}
+	}
+	t.Cleanup(resetFlags)
+
+	// Saldo demo BCA jauh di bawah 1 miliar
+	out := string(runPiped(t, "wallet", "alert", "bca", "--below", "1000000000"))
+	if !strings.Contains(out, "Low balance alert set") || !strings.Contains(out, "already below") {
+		t.Errorf("alert output = %q, want alert set and already below", out)
+	}
+
+	out = string(execPiped(t, "wallet", "balance"))
+	if !strings.Contains(out, "Low balance: BCA") {
+		t.Errorf("balance output missing low balance warning:\n%s", out)
+	}
+
+	resetFlags()
+	execPiped(t, "wallet", "alert", "bca", "--off")
+	wallets, err := application.Repos.Wallet.List(context.Background(), repository.WalletFilter{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, w := range wallets {
+		if w.LowBalanceThreshold != nil {
+			t.Errorf("%s still has a low balance threshold after --off", w.Name)
+		}
+	}
+}
+
+func TestWalletBalance_IncludeArchived(t *testing.T) {
+	t.Cleanup(func() { _ = walletBalanceCmd.Flags().Set("include-archived", "false") })
+
+	ctx := context.Background()
+	runPiped(t, "wallet", "balance")
+
+	wallets, err := application.Repos.Wallet.List(ctx, repository.WalletFilter{})
+	if err != nil || len(wallets) == 0 {
+		t.Fatalf("demo wallets = %d, error = %v", len(wallets), err)
+	}
+	archived := wallets[0]
+	if err := application.Repos.Wallet.Delete(ctx, archived.ID); err != nil {
+		t.Fatal(err)
+	}
+	active, err := application.Repos.Wallet.GetTotalBalance(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := active.Add(archived.Balance)
+
+	out := string(execPiped(t, "wallet", "balance"))
+	if !strings.Contains(out, formatMoney(active)) || strings.Contains(out, "including archived") {
+		t.Errorf("balance output = %q, want the active total %s", out, formatMoney(active))
+	}
+
+	out = string(execPiped(t, "wallet", "balance", "--include-archived"))
+	if !strings.Contains(out, formatMoney(all)) || !strings.Contains(out, "including archived") {
+		t.Errorf("balance --include-archived output = %q, want %s", out, formatMoney(all))
+	}
+}
+
+func TestWalletAudit(t *testing.T) {
+	t.Cleanup(func() { _ = walletAdjustCmd.Flags().Set("to", "") })
+
+	runPiped(t, "wallet", "list")
+	bca, err := resolveWallet(context.Background(), "bca")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	execPiped(t, "wallet", "adjust", bca.ID.String(), "--to", "1234567")
+
+	out := string(execPiped(t, "wallet", "audit", "bca"))
+	if !strings.Contains(out, "Balance changes") || !strings.Contains(out, "adjustment") || !strings.Contains(out, formatWalletMoney(decimal.NewFromInt(1234567), bca.Currency)) {
+		t.Errorf("audit output = %q, want the adjustment to 1.234.567", out)
+	}
+}
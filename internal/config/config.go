@@ -34,10 +34,18 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/viper"
+
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
 // Config adalah struct utama yang menyimpan semua konfigurasi aplikasi.
@@ -61,6 +69,9 @@ type Config struct {
 
 	// TUI berisi konfigurasi Terminal UI
 	TUI TUIConfig `mapstructure:"tui"`
+
+	// Validation berisi threshold untuk soft warning transaksi
+	Validation ValidationConfig `mapstructure:"validation"`
 }
 
 // DatabaseConfig menyimpan konfigurasi koneksi PostgreSQL.
@@ -90,6 +101,20 @@ type DatabaseConfig struct {
 	// SSLMode mengatur mode SSL untuk koneksi
 	// Options: disable, require, verify-ca, verify-full
 	SSLMode string `mapstructure:"ssl_mode"`
+
+	// QueryTimeout adalah batas waktu setiap query (default: 10s).
+	// Query yang lebih lama dibatalkan supaya koneksi yang menggantung
+	// tidak membekukan CLI atau dashboard. 0 berarti tanpa batas.
+	// Contoh: "10s", "1m"
+	QueryTimeout time.Duration `mapstructure:"query_timeout"`
+
+	// MaxConns adalah jumlah maksimal koneksi di pool (default: 10).
+	// Contoh: 3 untuk laptop developer, 20 untuk server.
+	MaxConns int `mapstructure:"max_conns"`
+
+	// MinConns adalah jumlah koneksi idle yang selalu disiapkan
+	// (default: 2). Tidak boleh lebih dari MaxConns.
+	MinConns int `mapstructure:"min_conns"`
 }
 
 // AppConfig menyimpan konfigurasi umum aplikasi.
@@ -104,6 +129,81 @@ type AppConfig struct {
 	// Locale untuk formatting tanggal dan angka
 	// Contoh: "id-ID", "en-US"
 	Locale string `mapstructure:"locale"`
+
+	// AbsoluteDates menampilkan tanggal dalam format ISO (2006-01-02)
+	// alih-alih "Today", "Yesterday", "Sen", dst.
+	// Bisa juga diaktifkan per command dengan flag --absolute-dates.
+	AbsoluteDates bool `mapstructure:"absolute_dates"`
+
+	// DefaultWalletID adalah wallet yang dipakai jika --wallet tidak diberikan.
+	// Disimpan sebagai string; gunakan DefaultWallet() untuk mem-parse.
+	// Set dengan: wallet config set app.default_wallet <id>
+	DefaultWalletID string `mapstructure:"default_wallet"`
+
+	// MonthStartDay adalah tanggal (1-28) awal "bulan" untuk summary,
+	// dashboard, dan budget. Contoh 25 untuk gajian tanggal 25: periode
+	// January berjalan 25 Dec – 24 Jan. Default 1 (bulan kalender).
+	MonthStartDay int `mapstructure:"month_start_day"`
+
+	// FiscalYearStartMonth adalah bulan (1-12) awal tahun fiskal untuk
+	// summary tahunan dan year-to-date. Contoh 4: FY2025 berjalan
+	// Apr 2025 – Mar 2026. Default 1 (tahun kalender).
+	FiscalYearStartMonth int `mapstructure:"fiscal_year_start_month"`
+
+	// Rates adalah kurs currency lain ke Currency: 1 unit = nilai ini.
+	// Disimpan sebagai string supaya presisi decimal terjaga. Kurs saat
+	// transaksi dibuat disimpan di transaksi (rate_to_base), jadi
+	// mengubah kurs di sini tidak mengubah laporan bulan-bulan lalu.
+	// Contoh: rates: {USD: "16250", SGD: "12100"}
+	Rates map[string]string `mapstructure:"rates"`
+
+	// MaxTransactionAmount adalah amount maksimal satu transaksi, dalam
+	// currency wallet. Transaksi di atasnya ditolak (bukan sekadar
+	// warning seperti validation.large_absolute), untuk mencegah salah
+	// ketik nol. Disimpan sebagai string supaya presisi decimal terjaga.
+	// "0" atau kosong = tanpa batas. Default "100000000".
+	MaxTransactionAmount string `mapstructure:"max_transaction_amount"`
+}
+
+// ExchangeRates mem-parse Rates. Key di-uppercase karena viper menyimpan
+// key config dalam huruf kecil.
+func (a *AppConfig) ExchangeRates() (map[string]decimal.Decimal, error) {
+	rates := make(map[string]decimal.Decimal, len(a.Rates))
+	for currency, value := range a.Rates {
+		rate, err := decimal.NewFromString(strings.TrimSpace(value))
+		if err != nil || !rate.IsPositive() {
+			return nil, fmt.Errorf("app.rates.%s must be a positive number", currency)
+		}
+		rates[strings.ToUpper(currency)] = rate
+	}
+	return rates, nil
+}
+
+// MaxTransactionLimit mem-parse MaxTransactionAmount sebagai decimal.
+// Return 0 (tanpa batas) jika kosong.
+func (a *AppConfig) MaxTransactionLimit() (decimal.Decimal, error) {
+	if strings.TrimSpace(a.MaxTransactionAmount) == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(strings.TrimSpace(a.MaxTransactionAmount))
+}
+
+// ErrNoDefaultWallet dikembalikan DefaultWallet jika app.default_wallet kosong.
+var ErrNoDefaultWallet = errors.New("no default wallet configured (set one with: wallet config set app.default_wallet <id>)")
+
+// DefaultWallet mem-parse DefaultWalletID sebagai UUID.
+// Return ErrNoDefaultWallet jika belum di-set.
+func (a *AppConfig) DefaultWallet() (uuid.UUID, error) {
+	id := strings.TrimSpace(a.DefaultWalletID)
+	if id == "" {
+		return uuid.Nil, ErrNoDefaultWallet
+	}
+
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("app.default_wallet is not a valid wallet ID: %w", err)
+	}
+	return parsed, nil
 }
 
 // TUIConfig menyimpan konfigurasi untuk Terminal UI.
@@ -116,7 +216,57 @@ type TUIConfig struct {
 	RefreshRate int `mapstructure:"refresh_rate"`
 }
 
-// Load membaca konfigurasi dari environment variables.
+// ValidationConfig menyimpan threshold untuk deteksi transaksi
+// yang nilainya tidak wajar (fat-finger protection).
+//
+// Threshold amount hanya soft warning - transaksi tetap bisa dibuat
+// setelah konfirmasi. Transaksi sebelum wallet dimulai juga hanya
+// warning, kecuali RejectBeforeWalletDate atau StrictValidation aktif.
+type ValidationConfig struct {
+	// LargeMultiplier: warning jika amount > N × rata-rata 90 hari kategori.
+	// 0 = nonaktif.
+	LargeMultiplier float64 `mapstructure:"large_multiplier"`
+
+	// LargeAbsolute: warning jika amount melebihi nilai ini.
+	// Disimpan sebagai string supaya presisi decimal terjaga.
+	// "0" atau kosong = nonaktif.
+	LargeAbsolute string `mapstructure:"large_absolute"`
+
+	// RejectBeforeWalletDate: tolak transaksi yang tanggalnya sebelum
+	// wallet dibuat (atau opening date wallet). Default false supaya
+	// user lama yang sudah backdate transaksi tidak kaget.
+	RejectBeforeWalletDate bool `mapstructure:"reject_before_wallet_date"`
+
+	// StrictValidation mengubah semua sanity check yang biasanya hanya
+	// warning menjadi error. Saat ini sama dengan RejectBeforeWalletDate.
+	StrictValidation bool `mapstructure:"strict_validation"`
+}
+
+// RejectsBeforeWalletDate mengembalikan true jika transaksi sebelum
+// wallet dimulai harus ditolak, bukan hanya di-warning.
+func (v *ValidationConfig) RejectsBeforeWalletDate() bool {
+	return v.RejectBeforeWalletDate || v.StrictValidation
+}
+
+// LargeAbsoluteAmount mem-parse LargeAbsolute sebagai decimal.
+// Return 0 jika kosong.
+func (v *ValidationConfig) LargeAbsoluteAmount() (decimal.Decimal, error) {
+	if strings.TrimSpace(v.LargeAbsolute) == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(strings.TrimSpace(v.LargeAbsolute))
+}
+
+// configFileName adalah nama file config di dalam folder config.
+const configFileName = "config.yaml"
+
+// FilePath mengembalikan lokasi config file di dalam folder path.
+func FilePath(path string) string {
+	return filepath.Join(path, configFileName)
+}
+
+// Load membaca konfigurasi dari config file (path/config.yaml, opsional)
+// dan environment variables.
 //
 // Environment Variable Format:
 //
@@ -132,10 +282,20 @@ type TUIConfig struct {
 //	if err != nil {
 //	    log.Fatalf("Failed to load config: %v", err)
 //	}
-func Load(_ string) (*Config, error) {
+func Load(path string) (*Config, error) {
 	// 1. Set default values
 	setDefaults()
 
+	// Config file opsional - tidak ada file berarti pakai default + env
+	if path != "" {
+		if _, err := os.Stat(FilePath(path)); err == nil {
+			viper.SetConfigFile(FilePath(path))
+			if err := viper.ReadInConfig(); err != nil {
+				return nil, fmt.Errorf("error reading config file: %w", err)
+			}
+		}
+	}
+
 	// 2. Enable automatic environment variable binding
 	// Prefix "WT" → WT_DATABASE_HOST, WT_APP_NAME, dll
 	viper.SetEnvPrefix("WT")
@@ -156,6 +316,35 @@ func Load(_ string) (*Config, error) {
 	return &cfg, nil
 }
 
+// Set menyimpan satu key ke path/config.yaml.
+//
+// Key lain yang sudah ada di file tetap dipertahankan. Folder dan file
+// dibuat jika belum ada. Environment variable tetap punya prioritas
+// lebih tinggi saat Load.
+//
+//	err := config.Set("./config", "app.default_wallet", walletID.String())
+func Set(path, key, value string) error {
+	v := viper.New()
+	file := FilePath(path)
+	v.SetConfigFile(file)
+
+	if _, err := os.Stat(file); err == nil {
+		if err := v.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	v.Set(key, value)
+
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return fmt.Errorf("failed to create config folder: %w", err)
+	}
+	if err := v.WriteConfigAs(file); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
 // setDefaults mengatur nilai default untuk semua konfigurasi.
 //
 // Defaults digunakan ketika:
@@ -172,15 +361,29 @@ func setDefaults() {
 	viper.SetDefault("database.user", "postgres")
 	viper.SetDefault("database.password", "postgres")
 	viper.SetDefault("database.ssl_mode", "disable")
+	viper.SetDefault("database.query_timeout", "10s")
+	viper.SetDefault("database.max_conns", 10)
+	viper.SetDefault("database.min_conns", 2)
 
 	// App defaults
 	viper.SetDefault("app.name", "Wallet Twin")
 	viper.SetDefault("app.currency", "IDR")
 	viper.SetDefault("app.locale", "id-ID")
+	viper.SetDefault("app.absolute_dates", false)
+	viper.SetDefault("app.default_wallet", "")
+	viper.SetDefault("app.month_start_day", 1)
+	viper.SetDefault("app.fiscal_year_start_month", 1)
+	viper.SetDefault("app.max_transaction_amount", "100000000")
 
 	// TUI defaults
 	viper.SetDefault("tui.theme", "default")
 	viper.SetDefault("tui.refresh_rate", 1000)
+
+	// Validation defaults
+	viper.SetDefault("validation.large_multiplier", 5.0)
+	viper.SetDefault("validation.large_absolute", "0")
+	viper.SetDefault("validation.reject_before_wallet_date", false)
+	viper.SetDefault("validation.strict_validation", false)
 }
 
 // ConnectionString membuat PostgreSQL connection string dari DatabaseConfig.
@@ -216,7 +419,12 @@ func (d *DatabaseConfig) ConnectionString() string {
 // - Database host tidak kosong
 // - Database port dalam range valid (1-65535)
 // - Database name tidak kosong
+// - Pool size: max_conns minimal 1, min_conns 0 sampai max_conns
 // - Currency code valid (3 karakter)
+// - Default wallet (jika di-set) adalah UUID valid
+// - Month start day dalam range 1-28
+// - Fiscal year start month dalam range 1-12
+// - Threshold validation tidak negatif
 //
 // Return error jika ada validasi yang gagal.
 func (c *Config) Validate() error {
@@ -230,11 +438,51 @@ func (c *Config) Validate() error {
 	if c.Database.Name == "" {
 		return fmt.Errorf("database name is required")
 	}
+	if c.Database.QueryTimeout < 0 {
+		return fmt.Errorf("database.query_timeout must not be negative")
+	}
+	if c.Database.MaxConns < 1 {
+		return fmt.Errorf("database.max_conns must be at least 1")
+	}
+	if c.Database.MinConns < 0 || c.Database.MinConns > c.Database.MaxConns {
+		return fmt.Errorf("database.min_conns must be between 0 and database.max_conns")
+	}
 
 	// Validate app config
 	if len(c.App.Currency) != 3 {
 		return fmt.Errorf("currency must be a 3-letter ISO code (e.g., IDR, USD)")
 	}
+	if _, err := c.App.DefaultWallet(); err != nil && !errors.Is(err, ErrNoDefaultWallet) {
+		return err
+	}
+	if c.App.MonthStartDay < 1 || c.App.MonthStartDay > utils.MaxMonthStartDay {
+		return fmt.Errorf("app.month_start_day must be between 1 and %d", utils.MaxMonthStartDay)
+	}
+	if c.App.FiscalYearStartMonth < 1 || c.App.FiscalYearStartMonth > 12 {
+		return fmt.Errorf("app.fiscal_year_start_month must be between 1 and 12")
+	}
+	if _, err := c.App.ExchangeRates(); err != nil {
+		return err
+	}
+	maxAmount, err := c.App.MaxTransactionLimit()
+	if err != nil {
+		return fmt.Errorf("app.max_transaction_amount must be a number: %w", err)
+	}
+	if maxAmount.IsNegative() {
+		return fmt.Errorf("app.max_transaction_amount must not be negative")
+	}
+
+	// Validate validation thresholds
+	if c.Validation.LargeMultiplier < 0 {
+		return fmt.Errorf("validation.large_multiplier must not be negative")
+	}
+	absolute, err := c.Validation.LargeAbsoluteAmount()
+	if err != nil {
+		return fmt.Errorf("validation.large_absolute must be a number: %w", err)
+	}
+	if absolute.IsNegative() {
+		return fmt.Errorf("validation.large_absolute must not be negative")
+	}
 
 	return nil
 }
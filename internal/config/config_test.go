@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAppConfig_DefaultWallet(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    uuid.UUID
+		wantErr bool
+	}{
+		{"unset", "", uuid.Nil, true},
+		{"valid", id.String(), id, false},
+		{"padded", "  " + id.String() + " ", id, false},
+		{"invalid", "bca", uuid.Nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := AppConfig{DefaultWalletID: tt.value}
+			got, err := app.DefaultWallet()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DefaultWallet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DefaultWallet() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (&AppConfig{}).DefaultWallet(); !errors.Is(err, ErrNoDefaultWallet) {
+		t.Errorf("DefaultWallet() on empty config error = %v, want ErrNoDefaultWallet", err)
+	}
+}
+
+func TestAppConfig_ExchangeRates(t *testing.T) {
+	app := AppConfig{Rates: map[string]string{"usd": "16250", "SGD": " 12100.5 "}}
+	rates, err := app.ExchangeRates()
+	if err != nil {
+		t.Fatalf("ExchangeRates() error = %v", err)
+	}
+	if got := rates["USD"].String(); got != "16250" {
+		t.Errorf("USD = %s, want 16250", got)
+	}
+	if got := rates["SGD"].String(); got != "12100.5" {
+		t.Errorf("SGD = %s, want 12100.5", got)
+	}
+
+	for _, bad := range []string{"abc", "0", "-1"} {
+		app := AppConfig{Rates: map[string]string{"usd": bad}}
+		if _, err := app.ExchangeRates(); err == nil {
+			t.Errorf("ExchangeRates() with %q: want error", bad)
+		}
+	}
+}
+
+func TestAppConfig_MaxTransactionLimit(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "", want: "0"},
+		{value: " 50000000 ", want: "50000000"},
+		{value: "1e6", want: "1000000"},
+		{value: "50jt", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		app := AppConfig{MaxTransactionAmount: tt.value}
+		got, err := app.MaxTransactionLimit()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("MaxTransactionLimit(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got.String() != tt.want {
+			t.Errorf("MaxTransactionLimit(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSet_LoadRoundTrip(t *testing.T) {
+	dir := t.TempDir() + "/config"
+	id := uuid.New()
+
+	if err := Set(dir, "app.default_wallet", id.String()); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := Set(dir, "app.currency", "USD"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, err := cfg.App.DefaultWallet(); err != nil || got != id {
+		t.Errorf("DefaultWallet() = %s, %v, want %s (earlier key kept)", got, err, id)
+	}
+	if cfg.App.Currency != "USD" {
+		t.Errorf("Currency = %q, want USD", cfg.App.Currency)
+	}
+	if cfg.Validation.RejectBeforeWalletDate {
+		t.Error("RejectBeforeWalletDate should default to false")
+	}
+
+	if err := Set(dir, "validation.reject_before_wallet_date", "true"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	cfg, err = Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.Validation.RejectBeforeWalletDate {
+		t.Error("RejectBeforeWalletDate = false after Set(true)")
+	}
+	if cfg.Validation.StrictValidation {
+		t.Error("StrictValidation should default to false")
+	}
+	if cfg.App.MonthStartDay != 1 {
+		t.Errorf("MonthStartDay = %d, want default 1", cfg.App.MonthStartDay)
+	}
+	if cfg.Database.QueryTimeout != 10*time.Second {
+		t.Errorf("QueryTimeout = %s, want default 10s", cfg.Database.QueryTimeout)
+	}
+	if cfg.Database.MaxConns != 10 || cfg.Database.MinConns != 2 {
+		t.Errorf("MaxConns, MinConns = %d, %d, want defaults 10, 2", cfg.Database.MaxConns, cfg.Database.MinConns)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with defaults error = %v", err)
+	}
+	cfg.Database.MinConns = 11
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() with min_conns > max_conns: want error")
+	}
+	cfg.Database.MinConns = 2
+	if limit, err := cfg.App.MaxTransactionLimit(); err != nil || limit.String() != "100000000" {
+		t.Errorf("MaxTransactionLimit() = %s, %v, want default 100000000", limit, err)
+	}
+
+	// config set menyimpan value sebagai string; Load tetap harus bisa
+	// men-decode-nya ke int
+	if err := Set(dir, "app.month_start_day", "25"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	cfg, err = Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.App.MonthStartDay != 25 {
+		t.Errorf("MonthStartDay = %d, want 25", cfg.App.MonthStartDay)
+	}
+}
@@ -32,8 +32,11 @@ package database
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	// Blank import untuk driver PostgreSQL
 	// Driver ini perlu di-import agar golang-migrate tau cara connect ke PostgreSQL
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -49,9 +52,13 @@ import (
 // - Down: Rollback semua migrations
 // - Steps: Apply/rollback N migrations
 // - Version: Cek versi migration saat ini
+// - Status: Daftar migration beserta state applied/pending
 type Migrator struct {
 	// migrate adalah instance golang-migrate
 	migrate *migrate.Migrate
+
+	// sourceURL dipakai Status untuk membaca daftar migration files
+	sourceURL string
 }
 
 // NewMigrator membuat instance Migrator baru.
@@ -81,7 +88,7 @@ func NewMigrator(databaseURL, migrationsPath string) (*Migrator, error) {
 		return nil, fmt.Errorf("failed to create migrator: %w", err)
 	}
 
-	return &Migrator{migrate: m}, nil
+	return &Migrator{migrate: m, sourceURL: migrationsPath}, nil
 }
 
 // Up menjalankan semua pending migrations.
@@ -133,6 +140,17 @@ func (m *Migrator) Down() error {
 	return nil
 }
 
+// Drop menghapus semua tabel di database, termasuk schema_migrations,
+// tanpa menjalankan down migrations.
+//
+// WARNING: Semua data hilang! Dipakai oleh `migrate reset`.
+func (m *Migrator) Drop() error {
+	if err := m.migrate.Drop(); err != nil {
+		return fmt.Errorf("failed to drop database: %w", err)
+	}
+	return nil
+}
+
 // Steps menjalankan N migrations ke atas (n > 0) atau ke bawah (n < 0).
 //
 // Parameters:
@@ -162,6 +180,23 @@ func (m *Migrator) Steps(n int) error {
 	return nil
 }
 
+// Migrate menjalankan migration sampai tepat di version (naik atau turun).
+//
+// Berbeda dengan Steps, target-nya adalah nomor versi, bukan jumlah step.
+// Dipakai oleh `migrate up --to N`.
+//
+//	err := migrator.Migrate(5) // apply sampai 000005, sisanya tetap pending
+func (m *Migrator) Migrate(version uint) error {
+	err := m.migrate.Migrate(version)
+	if err != nil {
+		if errors.Is(err, migrate.ErrNoChange) {
+			return nil
+		}
+		return fmt.Errorf("failed to migrate to version %d: %w", version, err)
+	}
+	return nil
+}
+
 // Version mengembalikan versi migration saat ini.
 //
 // Return:
@@ -183,6 +218,34 @@ func (m *Migrator) Version() (uint, bool, error) {
 	return m.migrate.Version()
 }
 
+// Status membandingkan migration files dengan versi di schema_migrations.
+//
+// golang-migrate hanya menyimpan versi terakhir (dan flag dirty), jadi
+// semua migration dengan versi <= versi saat ini dianggap applied.
+//
+//	status, err := migrator.Status()
+//	for _, mig := range status.Pending() {
+//	    fmt.Println("pending:", mig.Version, mig.Name)
+//	}
+func (m *Migrator) Status() (*MigrationStatus, error) {
+	files, err := ListMigrations(m.sourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	version, dirty, err := m.migrate.Version()
+	hasVersion := true
+	if errors.Is(err, migrate.ErrNilVersion) {
+		// Belum ada migration yang dijalankan
+		hasVersion, err = false, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration version: %w", err)
+	}
+
+	return newMigrationStatus(files, version, hasVersion, dirty), nil
+}
+
 // Force sets migration version tanpa menjalankan migration.
 //
 // WARNING: Gunakan dengan sangat hati-hati!
@@ -216,3 +279,200 @@ func (m *Migrator) Close() error {
 	}
 	return dbErr
 }
+
+// MigrationFile adalah satu migration di source (pasangan .up/.down.sql).
+type MigrationFile struct {
+	// Version adalah nomor versi, contoh 10 untuk 000010_xxx.up.sql
+	Version uint
+
+	// Name adalah bagian nama file setelah versi, contoh "add_transaction_attachment"
+	Name string
+}
+
+// ListMigrations membaca semua migration dari source, urut versi.
+//
+//	files, err := database.ListMigrations("file://./migrations")
+func ListMigrations(sourceURL string) ([]MigrationFile, error) {
+	driver, err := source.Open(sourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations source: %w", err)
+	}
+	defer driver.Close()
+
+	return listMigrations(driver)
+}
+
+// ListEmbeddedMigrations membaca migration dari fs.FS (biasanya
+// migrations.FS yang di-embed ke binary), urut versi.
+//
+//	files, err := database.ListEmbeddedMigrations(migrations.FS)
+func ListEmbeddedMigrations(fsys fs.FS) ([]MigrationFile, error) {
+	driver, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+	}
+	defer driver.Close()
+
+	return listMigrations(driver)
+}
+
+// listMigrations mengiterasi semua versi di driver.
+func listMigrations(driver source.Driver) ([]MigrationFile, error) {
+	var files []MigrationFile
+	version, err := driver.First()
+	for err == nil {
+		name := ""
+		if r, identifier, readErr := driver.ReadUp(version); readErr == nil {
+			r.Close()
+			name = identifier
+		}
+		files = append(files, MigrationFile{Version: version, Name: name})
+
+		version, err = driver.Next(version)
+	}
+
+	// First/Next mengembalikan fs.ErrNotExist jika tidak ada migration lagi
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	return files, nil
+}
+
+// MigrationState adalah state satu migration di database.
+type MigrationState string
+
+const (
+	// MigrationApplied sudah dijalankan sampai selesai
+	MigrationApplied MigrationState = "applied"
+
+	// MigrationPending belum dijalankan
+	MigrationPending MigrationState = "pending"
+
+	// MigrationDirty gagal di tengah jalan; schema mungkin setengah jadi
+	MigrationDirty MigrationState = "dirty"
+)
+
+// MigrationInfo adalah migration file beserta state-nya.
+type MigrationInfo struct {
+	MigrationFile
+	State MigrationState
+}
+
+// MigrationStatus adalah hasil Migrator.Status.
+type MigrationStatus struct {
+	// Version adalah versi di schema_migrations (0 jika HasVersion false).
+	Version uint
+
+	// HasVersion false jika belum pernah ada migration yang dijalankan.
+	HasVersion bool
+
+	// Dirty true jika migration Version gagal di tengah jalan.
+	// Up/Migrate akan menolak jalan sampai di-Force.
+	Dirty bool
+
+	// Migrations adalah semua migration di source, urut versi.
+	Migrations []MigrationInfo
+}
+
+// newMigrationStatus menggabungkan daftar file dengan versi database.
+func newMigrationStatus(files []MigrationFile, version uint, hasVersion, dirty bool) *MigrationStatus {
+	status := &MigrationStatus{
+		Version:    version,
+		HasVersion: hasVersion,
+		Dirty:      dirty,
+	}
+
+	for _, f := range files {
+		var state MigrationState
+		switch {
+		case !hasVersion || f.Version > version:
+			state = MigrationPending
+		case f.Version == version && dirty:
+			state = MigrationDirty
+		default:
+			state = MigrationApplied
+		}
+		status.Migrations = append(status.Migrations, MigrationInfo{MigrationFile: f, State: state})
+	}
+
+	return status
+}
+
+// Pending mengembalikan migration yang belum dijalankan.
+func (s *MigrationStatus) Pending() []MigrationInfo {
+	return s.PendingUpTo(0)
+}
+
+// PendingUpTo mengembalikan migration pending dengan versi <= target,
+// yaitu yang akan dijalankan oleh Migrate(target). Target 0 berarti semua.
+// Dipakai untuk dry-run.
+func (s *MigrationStatus) PendingUpTo(target uint) []MigrationInfo {
+	var pending []MigrationInfo
+	for _, m := range s.Migrations {
+		if m.State != MigrationPending {
+			continue
+		}
+		if target != 0 && m.Version > target {
+			break
+		}
+		pending = append(pending, m)
+	}
+	return pending
+}
+
+// CheckUpTarget memvalidasi target `up --to N` sebelum dijalankan:
+// database tidak boleh dirty, N harus ada di source, dan N tidak boleh
+// di bawah versi saat ini (itu rollback, bukan up). Target 0 berarti latest.
+func (s *MigrationStatus) CheckUpTarget(target uint) error {
+	if s.Dirty {
+		return fmt.Errorf("database is dirty at version %d", s.Version)
+	}
+	if target == 0 {
+		return nil
+	}
+	if s.HasVersion && target < s.Version {
+		return fmt.Errorf("target version %d is below the current version %d; roll back with down instead", target, s.Version)
+	}
+	for _, m := range s.Migrations {
+		if m.Version == target {
+			return nil
+		}
+	}
+	return fmt.Errorf("no migration with version %d (latest is %d)", target, s.Latest())
+}
+
+// Latest mengembalikan versi migration tertinggi di source (0 jika kosong).
+func (s *MigrationStatus) Latest() uint {
+	if len(s.Migrations) == 0 {
+		return 0
+	}
+	return s.Migrations[len(s.Migrations)-1].Version
+}
+
+// DirtyHint mengembalikan langkah perbaikan untuk dirty state,
+// atau string kosong jika status tidak dirty. Command (force/up)
+// ditulis tanpa prefix supaya bisa dipakai oleh cmd/migrate dan wallet init.
+func (s *MigrationStatus) DirtyHint() string {
+	if !s.Dirty {
+		return ""
+	}
+
+	// Versi sebelum migration yang gagal; -1 berarti belum ada migration
+	previous := -1
+	file := fmt.Sprintf("%06d_*.up.sql", s.Version)
+	for _, m := range s.Migrations {
+		if m.Version < s.Version {
+			previous = int(m.Version)
+		}
+		if m.Version == s.Version {
+			file = fmt.Sprintf("%06d_%s.up.sql", m.Version, m.Name)
+		}
+	}
+
+	return fmt.Sprintf(`Migration %d failed halfway; the schema may be partially applied.
+  1. Compare the database with migrations/%s and fix it by hand
+  2. If the migration is now fully applied:  force %d
+     If you reverted its changes to retry:   force %d
+  3. Run up again`, s.Version, file, s.Version, previous)
+}
@@ -0,0 +1,261 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/version"
+)
+
+// BackupSchemaVersion adalah versi format backup (ExportData) yang ditulis
+// build ini. Naikkan setiap kali field model yang ikut di backup berubah,
+// dan tambahkan migrasi dari versi sebelumnya di backupMigrations.
+//
+// Riwayat:
+//
+//	1: backup tanpa schema_version (versi awal)
+//	2: schema_version ditulis; wallet tanpa is_active/currency dan goal
+//	   tanpa status di-default seperti NewWallet/NewGoal
+//	3: manifest untuk backup incremental; goal tanpa kind di-default
+//	   savings
+//	4: goal_contributions (history kontribusi goal)
+const BackupSchemaVersion = 4
+
+// MaxBackupMajorVersion adalah major version tertinggi yang format
+// backup-nya dikenali build ini. Hanya dipakai untuk backup schema 1
+// (tanpa schema_version); backup yang lebih baru dicek lewat
+// BackupSchemaVersion.
+const MaxBackupMajorVersion = 1
+
+// backupMigration mengubah backup mentah dari satu schema version ke
+// versi berikutnya. Dikerjakan pada JSON mentah supaya field yang tidak
+// ada bisa dibedakan dari field yang bernilai kosong.
+type backupMigration func(envelope map[string]json.RawMessage) error
+
+// backupMigrations di-index dengan versi asal: backupMigrations[1]
+// mengubah schema 1 menjadi 2, dan seterusnya.
+var backupMigrations = map[int]backupMigration{
+	1: migrateBackupV1,
+	2: migrateBackupV2,
+	3: migrateBackupV3,
+}
+
+var (
+	// ErrNotBackup dikembalikan jika JSON bukan full backup (ExportData),
+	// misalnya hasil `wallet export wallets -f json`.
+	ErrNotBackup = errors.New("not a wallet-twin backup")
+
+	// ErrUnsupportedBackupVersion dikembalikan untuk backup dari major
+	// version yang lebih baru dari MaxBackupMajorVersion.
+	ErrUnsupportedBackupVersion = errors.New("unsupported backup version")
+)
+
+// decodeBackup membaca dan memvalidasi full backup dari r: harus berupa
+// object dengan exported_at dan version yang dikenali. Array (export per
+// entity) ditolak dengan petunjuk command yang benar. Backup dari schema
+// lama dimigrasi ke BackupSchemaVersion sebelum di-decode.
+func decodeBackup(r io.Reader) (*ExportData, error) {
+	envelope, schema, err := readBackup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate backup: %w", err)
+	}
+	var data ExportData
+	if err := json.Unmarshal(migrated, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	if data.ExportedAt.IsZero() {
+		return nil, fmt.Errorf("%w: exported_at is empty", ErrNotBackup)
+	}
+	data.SchemaVersion = schema
+
+	return &data, nil
+}
+
+// readBackup membaca envelope backup mentah dari r dan memigrasinya ke
+// BackupSchemaVersion. schema adalah versi asli backup sebelum migrasi.
+func readBackup(r io.Reader) (envelope map[string]json.RawMessage, schema int, err error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return nil, 0, fmt.Errorf("%w: %s", ErrNotBackup, describeArrayExport(trimmed))
+	}
+
+	if err := json.Unmarshal(trimmed, &envelope); err != nil || envelope == nil {
+		return nil, 0, fmt.Errorf("%w: expected a JSON object from 'wallet export all'", ErrNotBackup)
+	}
+	for _, key := range []string{"exported_at", "version"} {
+		if _, ok := envelope[key]; !ok {
+			return nil, 0, fmt.Errorf("%w: missing %q (create the backup with 'wallet export all')", ErrNotBackup, key)
+		}
+	}
+
+	schema, err = backupSchemaVersion(envelope)
+	if err != nil {
+		return nil, 0, err
+	}
+	for v := schema; v < BackupSchemaVersion; v++ {
+		if err := backupMigrations[v](envelope); err != nil {
+			return nil, 0, fmt.Errorf("failed to migrate backup from schema %d: %w", v, err)
+		}
+	}
+	envelope["schema_version"] = json.RawMessage(strconv.Itoa(BackupSchemaVersion))
+
+	return envelope, schema, nil
+}
+
+// backupSchemaVersion mengembalikan schema version backup. Backup tanpa
+// schema_version adalah schema 1, yang versinya dicek lewat version build
+// pembuatnya (checkBackupVersion).
+func backupSchemaVersion(envelope map[string]json.RawMessage) (int, error) {
+	var buildVersion string
+	if err := json.Unmarshal(envelope["version"], &buildVersion); err != nil {
+		return 0, fmt.Errorf("%w: version must be a string", ErrNotBackup)
+	}
+
+	raw, ok := envelope["schema_version"]
+	if !ok {
+		if err := checkBackupVersion(buildVersion); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	var schema int
+	if err := json.Unmarshal(raw, &schema); err != nil || schema < 1 {
+		return 0, fmt.Errorf("%w: invalid schema_version %s", ErrNotBackup, raw)
+	}
+	if schema > BackupSchemaVersion {
+		return 0, fmt.Errorf("%w: backup schema %d was created by wallet-twin %s, this build (%s) reads schemas up to %d; upgrade wallet-twin to restore it",
+			ErrUnsupportedBackupVersion, schema, buildVersion, version.String(), BackupSchemaVersion)
+	}
+	return schema, nil
+}
+
+// checkBackupVersion menerima "devel"/"unknown" (build tanpa versi) dan
+// semver dengan major <= MaxBackupMajorVersion.
+func checkBackupVersion(v string) error {
+	switch v {
+	case "":
+		return fmt.Errorf("%w: version is empty", ErrNotBackup)
+	case version.DevelVersion, version.Unknown:
+		return nil
+	}
+
+	major, ok := version.Major(v)
+	if !ok {
+		return fmt.Errorf("%w: unrecognized version %q", ErrUnsupportedBackupVersion, v)
+	}
+	if major > MaxBackupMajorVersion {
+		return fmt.Errorf("%w: backup was created by wallet-twin %s, this build (%s) reads backups up to v%d.x; upgrade wallet-twin to restore it",
+			ErrUnsupportedBackupVersion, v, version.String(), MaxBackupMajorVersion)
+	}
+	return nil
+}
+
+// describeArrayExport menebak export per entity dari field elemen
+// pertama, untuk pesan error yang menunjuk ke command yang benar.
+func describeArrayExport(data []byte) string {
+	const generic = "got a JSON array; a full backup is an object created by 'wallet export all'"
+
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil || len(items) == 0 {
+		return generic
+	}
+
+	first := items[0]
+	has := func(key string) bool {
+		_, ok := first[key]
+		return ok
+	}
+
+	var kind string
+	switch {
+	case has("wallet_id") && has("transaction_date"):
+		kind = "transactions export ('wallet export transactions'); import it with 'wallet import transactions' from CSV"
+	case has("balance") && has("currency"):
+		kind = "wallets export ('wallet export wallets')"
+	case has("target_amount"):
+		kind = "goals list"
+	default:
+		return generic
+	}
+	return fmt.Sprintf("this looks like a %s; restore a full backup created by 'wallet export all'", kind)
+}
+
+// migrateBackupV1 mengisi field yang tidak ada di backup schema 1 dengan
+// default dari NewWallet dan NewGoal. Tanpa ini wallet lama ter-restore
+// sebagai diarsipkan (is_active false) dan tanpa currency.
+func migrateBackupV1(envelope map[string]json.RawMessage) error {
+	if err := setMissingFields(envelope, "wallets", map[string]any{
+		"is_active": true,
+		"currency":  "IDR",
+	}); err != nil {
+		return err
+	}
+	return setMissingFields(envelope, "goals", map[string]any{
+		"status": models.GoalStatusActive,
+	})
+}
+
+// migrateBackupV2 mengisi kind goal yang tidak ada di backup schema 2.
+func migrateBackupV2(envelope map[string]json.RawMessage) error {
+	return setMissingFields(envelope, "goals", map[string]any{
+		"kind": models.GoalKindSavings,
+	})
+}
+
+// migrateBackupV3 mengisi goal_contributions kosong: backup schema 3
+// tidak menyimpan history kontribusi.
+func migrateBackupV3(envelope map[string]json.RawMessage) error {
+	if _, ok := envelope["goal_contributions"]; !ok {
+		envelope["goal_contributions"] = json.RawMessage("[]")
+	}
+	return nil
+}
+
+// setMissingFields mengisi field yang tidak ada pada setiap object di
+// envelope[key] dengan nilai default.
+func setMissingFields(envelope map[string]json.RawMessage, key string, defaults map[string]any) error {
+	raw, ok := envelope[key]
+	if !ok || string(raw) == "null" {
+		return nil
+	}
+
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	for _, item := range items {
+		for field, value := range defaults {
+			if _, ok := item[field]; ok {
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			item[field] = encoded
+		}
+	}
+
+	updated, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	envelope[key] = updated
+	return nil
+}
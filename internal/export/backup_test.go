@@ -0,0 +1,302 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+)
+
+func TestImporter_FromJSONReader(t *testing.T) {
+	ctx := context.Background()
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	tx := models.NewTransaction(wallet.ID, models.TransactionTypeExpense, decimal.NewFromInt(25000))
+	exportedAt := time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(ExportData{
+		ExportedAt:    exportedAt,
+		Version:       "v1.4.0",
+		SchemaVersion: BackupSchemaVersion,
+		Wallets:       []*models.Wallet{wallet},
+		Transactions:  []*models.Transaction{tx},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	store := memory.NewStore()
+	result, err := newMemoryImporter(store).FromJSONReader(ctx, &buf)
+	if err != nil {
+		t.Fatalf("FromJSONReader() error = %v", err)
+	}
+
+	if result.SuccessCount != 2 {
+		t.Errorf("SuccessCount = %d, want 2", result.SuccessCount)
+	}
+	if result.BackupVersion != "v1.4.0" {
+		t.Errorf("BackupVersion = %q, want v1.4.0", result.BackupVersion)
+	}
+	if !result.ExportedAt.Equal(exportedAt) {
+		t.Errorf("ExportedAt = %v, want %v", result.ExportedAt, exportedAt)
+	}
+
+	txs, err := memory.NewTransactionRepository(store).List(ctx, repository.TransactionFilter{}, repository.ListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 {
+		t.Errorf("imported %d transactions, want 1", len(txs))
+	}
+}
+
+func TestImporter_FromJSONReader_RejectsWrongShape(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantHint string
+	}{
+		{
+			name:     "wallets export",
+			input:    `[{"id":"6f1c2c1e-5d6b-4a43-9d53-3f0b1c6f7a11","name":"BCA","type":"bank","balance":"100","currency":"IDR"}]`,
+			wantHint: "wallet export wallets",
+		},
+		{
+			name:     "transactions export",
+			input:    `[{"wallet_id":"6f1c2c1e-5d6b-4a43-9d53-3f0b1c6f7a11","type":"expense","amount":"100","transaction_date":"2025-01-01T00:00:00Z"}]`,
+			wantHint: "wallet export transactions",
+		},
+		{
+			name:     "empty array",
+			input:    `[]`,
+			wantHint: "wallet export all",
+		},
+		{
+			name:     "missing envelope",
+			input:    `{"wallets":[]}`,
+			wantHint: `"exported_at"`,
+		},
+		{
+			name:     "missing version",
+			input:    `{"exported_at":"2025-01-01T00:00:00Z","wallets":[]}`,
+			wantHint: `"version"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewStore()
+			_, err := newMemoryImporter(store).FromJSONReader(context.Background(), strings.NewReader(tt.input))
+			if !errors.Is(err, ErrNotBackup) {
+				t.Fatalf("error = %v, want ErrNotBackup", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantHint) {
+				t.Errorf("error = %q, want hint %q", err, tt.wantHint)
+			}
+
+			wallets, _ := memory.NewWalletRepository(store).List(context.Background(), repository.WalletFilter{})
+			if len(wallets) != 0 {
+				t.Errorf("imported %d wallets from a rejected backup", len(wallets))
+			}
+		})
+	}
+}
+
+func TestImporter_FromJSONReader_Versions(t *testing.T) {
+	tests := []struct {
+		version string
+		wantErr error
+	}{
+		{version: "v1.2.0"},
+		{version: "1.0.0"},
+		{version: "v0.0.0-20250101000000-abcdef123456"},
+		{version: "devel"},
+		{version: "v2.0.0", wantErr: ErrUnsupportedBackupVersion},
+		{version: "v10.1.0-rc1", wantErr: ErrUnsupportedBackupVersion},
+		{version: "next", wantErr: ErrUnsupportedBackupVersion},
+		{version: "", wantErr: ErrNotBackup},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			input := `{"exported_at":"2025-01-01T00:00:00Z","version":"` + tt.version + `","wallets":[]}`
+			_, err := newMemoryImporter(memory.NewStore()).FromJSONReader(context.Background(), strings.NewReader(input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == ErrUnsupportedBackupVersion && !strings.Contains(err.Error(), tt.version) {
+				t.Errorf("error = %q, want it to mention %q", err, tt.version)
+			}
+		})
+	}
+}
+
+func TestImporter_FromJSONReader_MigratesSchemaV1(t *testing.T) {
+	ctx := context.Background()
+
+	// Backup schema 1: tanpa schema_version, wallet tanpa is_active dan
+	// currency, goal tanpa status.
+	input := `{"exported_at":"2024-06-01T00:00:00Z","version":"v1.0.0",
+		"wallets":[
+			{"id":"6f1c2c1e-5d6b-4a43-9d53-3f0b1c6f7a11","name":"BCA","type":"bank","balance":"100000"},
+			{"id":"0b7d3f9e-1a0c-4d8e-8f57-2c7a9e0d4b22","name":"Old","type":"cash","balance":"0","currency":"USD","is_active":false}
+		],
+		"goals":[{"id":"9a4e2b6c-3f1d-4c5a-8e7b-1d2c3b4a5f33","name":"Laptop","target_amount":"1000000","current_amount":"0"}]}`
+
+	store := memory.NewStore()
+	result, err := newMemoryImporter(store).FromJSONReader(ctx, strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("FromJSONReader() error = %v", err)
+	}
+	if result.BackupSchemaVersion != 1 {
+		t.Errorf("BackupSchemaVersion = %d, want 1", result.BackupSchemaVersion)
+	}
+
+	wallets, err := memory.NewWalletRepository(store).List(ctx, repository.WalletFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]*models.Wallet)
+	for _, w := range wallets {
+		byName[w.Name] = w
+	}
+	if bca := byName["BCA"]; bca == nil || !bca.IsActive || bca.Currency != "IDR" {
+		t.Errorf("BCA = %+v, want active IDR wallet", bca)
+	}
+	// Field yang ada di backup tidak ditimpa default.
+	if old := byName["Old"]; old == nil || old.IsActive || old.Currency != "USD" {
+		t.Errorf("Old = %+v, want archived USD wallet", old)
+	}
+
+	goals, err := memory.NewGoalRepository(store).List(ctx, repository.GoalFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(goals) != 1 || goals[0].Status != models.GoalStatusActive {
+		t.Errorf("goals = %+v, want one active goal", goals)
+	}
+}
+
+func TestImporter_FromJSONReader_FutureSchema(t *testing.T) {
+	input := fmt.Sprintf(`{"exported_at":"2030-01-01T00:00:00Z","version":"v1.9.0","schema_version":%d,"wallets":[]}`,
+		BackupSchemaVersion+1)
+
+	_, err := newMemoryImporter(memory.NewStore()).FromJSONReader(context.Background(), strings.NewReader(input))
+	if !errors.Is(err, ErrUnsupportedBackupVersion) {
+		t.Fatalf("error = %v, want ErrUnsupportedBackupVersion", err)
+	}
+	if !strings.Contains(err.Error(), "upgrade") {
+		t.Errorf("error = %q, want a hint to upgrade", err)
+	}
+}
+
+func TestImporter_FromJSONReader_SchemaVersionWinsOverBuildVersion(t *testing.T) {
+	// Build v2 yang masih menulis schema yang dikenali tetap bisa di-restore.
+	input := fmt.Sprintf(`{"exported_at":"2030-01-01T00:00:00Z","version":"v2.0.0","schema_version":%d,"wallets":[]}`,
+		BackupSchemaVersion)
+
+	if _, err := newMemoryImporter(memory.NewStore()).FromJSONReader(context.Background(), strings.NewReader(input)); err != nil {
+		t.Fatalf("FromJSONReader() error = %v", err)
+	}
+}
+
+func TestValidateBackup(t *testing.T) {
+	// Schema 1: field yang diisi migrasi tidak dilaporkan, field yang
+	// tetap hilang atau tidak dikenal dilaporkan dengan path-nya.
+	input := `{"exported_at":"2024-06-01T00:00:00Z","version":"v1.0.0",
+		"wallets":[{"id":"6f1c2c1e-5d6b-4a43-9d53-3f0b1c6f7a11","name":"BCA","type":"bank","balance":"1e5",
+			"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","version":1,"nickname":"utama"}],
+		"categories":[],"transactions":[{"id":"0b7d3f9e-1a0c-4d8e-8f57-2c7a9e0d4b22","type":"transfer"}],
+		"goals":[],"extra":true}`
+
+	result, err := ValidateBackup(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ValidateBackup() error = %v", err)
+	}
+	if result.SchemaVersion != 1 {
+		t.Errorf("SchemaVersion = %d, want 1", result.SchemaVersion)
+	}
+
+	var got []string
+	for _, issue := range result.Issues {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`$: unknown field "extra"`,
+		`$.transactions[0]: missing required field "created_at"`,
+		`$.transactions[0]: missing required field "updated_at"`,
+		`$.transactions[0]: missing required field "wallet_id"`,
+		`$.transactions[0]: missing required field "amount"`,
+		`$.transactions[0]: missing required field "transaction_date"`,
+		`$.transactions[0].type: value "transfer" is not one of "income", "expense"`,
+		`$.wallets[0].balance: value "1e5" does not match ^-?[0-9]+(\.[0-9]+)?$`,
+		`$.wallets[0]: unknown field "nickname"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateBackup_NotBackup(t *testing.T) {
+	_, err := ValidateBackup(strings.NewReader(`[{"id":"x","balance":"1","currency":"IDR"}]`))
+	if !errors.Is(err, ErrNotBackup) {
+		t.Fatalf("error = %v, want ErrNotBackup", err)
+	}
+}
+
+func TestBackupSchema_MatchesSchemaVersion(t *testing.T) {
+	var schema struct {
+		ID         string `json:"$id"`
+		Properties struct {
+			SchemaVersion struct {
+				Enum []int `json:"enum"`
+			} `json:"schema_version"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(BackupSchema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	// Naikkan schema file bersama BackupSchemaVersion
+	if name := fmt.Sprintf("backup.v%d.schema.json", BackupSchemaVersion); !strings.HasSuffix(schema.ID, name) {
+		t.Errorf("$id = %q, want it to end with %q", schema.ID, name)
+	}
+	if len(schema.Properties.SchemaVersion.Enum) != 1 || schema.Properties.SchemaVersion.Enum[0] != BackupSchemaVersion {
+		t.Errorf("schema_version enum = %v, want [%d]", schema.Properties.SchemaVersion.Enum, BackupSchemaVersion)
+	}
+}
+
+func TestImporter_FromJSONReader_ValidatesCategories(t *testing.T) {
+	ctx := context.Background()
+	input := fmt.Sprintf(`{"exported_at":"2026-01-05T03:00:00Z","version":"v1.0.0","schema_version":%d,"categories":[
+		{"id":"6f1c2c1e-5d6b-4a43-9d53-3f0b1c6f7a11","name":"  Food  ","type":"expense"},
+		{"id":"0b7d3f9e-1a0c-4d8e-8f57-2c7a9e0d4b22","name":" ","type":"expense"},
+		{"id":"9a4e2b6c-3f1d-4c5a-8e7b-1d2c3b4a5f33","name":"Transfer","type":"transfer"}]}`, BackupSchemaVersion)
+
+	store := memory.NewStore()
+	result, err := newMemoryImporter(store).FromJSONReader(ctx, strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("FromJSONReader() error = %v", err)
+	}
+	if result.SuccessCount != 1 || result.SkippedCount != 2 {
+		t.Errorf("result = %d imported, %d skipped; want 1, 2 (%v)", result.SuccessCount, result.SkippedCount, result.Errors)
+	}
+
+	categories, err := memory.NewCategoryRepository(store).List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 1 || categories[0].Name != "Food" {
+		t.Errorf("categories = %+v, want only the trimmed Food", categories)
+	}
+}
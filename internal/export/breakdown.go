@@ -0,0 +1,35 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// loadBreakdown mengambil cash flow per tipe wallet (semua tipe, yang
+// kosong bernilai 0) dan top merchants untuk laporan transaksi.
+// Sama dengan `wallet tx summary`; adjustment tidak ikut.
+func loadBreakdown(
+	ctx context.Context,
+	txRepo repository.TransactionRepository,
+	filter repository.TransactionFilter,
+) ([]*repository.WalletTypeCashFlow, []*service.MerchantSummary, error) {
+	filter.Type = nil
+	filter.ExcludeAdjustments = true
+	flows, err := txRepo.GetCashFlowByWalletType(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get cash flow by wallet type: %w", err)
+	}
+
+	expense := models.TransactionTypeExpense
+	filter.Type = &expense
+	totals, err := txRepo.GetDescriptionTotals(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get merchant totals: %w", err)
+	}
+
+	return service.CompleteWalletTypes(flows), service.TopMerchants(totals, service.TopMerchantsLimit), nil
+}
@@ -0,0 +1,163 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// checkpointEvery adalah default jumlah row antar penulisan checkpoint.
+const checkpointEvery = 1000
+
+// ErrCheckpointMismatch dikembalikan jika checkpoint file dibuat untuk
+// CSV lain (isi file berbeda), supaya row yang salah tidak di-skip.
+var ErrCheckpointMismatch = errors.New("checkpoint belongs to a different CSV file; delete it to start over")
+
+// ImporterOption mengatur opsi tambahan Importer (lihat NewImporter).
+type ImporterOption func(*Importer)
+
+// WithCheckpointFile membuat TransactionsFromCSV mencatat row terakhir
+// yang sudah di-import ke path setiap 1.000 row (dan saat import
+// dibatalkan). Jika import gagal di tengah jalan, menjalankan ulang
+// import yang sama melanjutkan dari checkpoint. File dihapus setelah
+// import selesai.
+//
+//	importer := export.NewImporter(walletRepo, txRepo, categoryRepo, goalRepo, txManager,
+//	    export.WithCheckpointFile("big.csv.checkpoint"))
+func WithCheckpointFile(path string) ImporterOption {
+	return func(i *Importer) {
+		i.checkpointFile = path
+	}
+}
+
+// importCheckpoint adalah isi checkpoint file.
+type importCheckpoint struct {
+	// FileSHA256 adalah hash isi CSV, untuk memastikan checkpoint dipakai
+	// untuk file yang sama.
+	FileSHA256 string `json:"file_sha256"`
+
+	// Row adalah nomor row terakhir (tanpa header) yang sudah diproses.
+	Row int `json:"row"`
+
+	// Offset adalah posisi byte setelah Row, tempat reader di-seek saat resume.
+	Offset int64 `json:"offset"`
+}
+
+// csvCheckpoint mengelola checkpoint satu import.
+type csvCheckpoint struct {
+	path  string
+	state importCheckpoint
+}
+
+// ResumeFromCheckpoint mengembalikan nomor row terakhir dari filename yang
+// sudah di-import menurut checkpoint file (WithCheckpointFile).
+// Return 0 jika checkpoint tidak diset atau belum ada.
+//
+//	if row, _ := importer.ResumeFromCheckpoint("big.csv"); row > 0 {
+//	    fmt.Printf("Resuming after row %d\n", row)
+//	}
+func (i *Importer) ResumeFromCheckpoint(filename string) (int, error) {
+	checkpoint, err := i.openCheckpoint(filename)
+	if err != nil || checkpoint == nil {
+		return 0, err
+	}
+	return checkpoint.state.Row, nil
+}
+
+// openCheckpoint membaca checkpoint untuk filename. Return nil jika
+// WithCheckpointFile tidak dipakai; checkpoint kosong (Row 0) jika
+// file checkpoint belum ada.
+func (i *Importer) openCheckpoint(filename string) (*csvCheckpoint, error) {
+	if i.checkpointFile == "" {
+		return nil, nil
+	}
+
+	digest, err := fileSHA256(filename)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &csvCheckpoint{path: i.checkpointFile, state: importCheckpoint{FileSHA256: digest}}
+
+	data, err := os.ReadFile(i.checkpointFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var saved importCheckpoint
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %w", i.checkpointFile, err)
+	}
+	if saved.FileSHA256 != digest {
+		return nil, fmt.Errorf("%s: %w", i.checkpointFile, ErrCheckpointMismatch)
+	}
+
+	checkpoint.state = saved
+	return checkpoint, nil
+}
+
+// resumePoint mengembalikan state checkpoint untuk readTransactionsCSV,
+// atau nil jika tidak ada yang perlu di-skip.
+func (c *csvCheckpoint) resumePoint() *importCheckpoint {
+	if c == nil || c.state.Row == 0 {
+		return nil
+	}
+	return &c.state
+}
+
+// idempotencyKey adalah key transaksi untuk row, supaya row setelah
+// checkpoint terakhir yang sudah tersimpan sebelum crash tidak dobel.
+func (c *csvCheckpoint) idempotencyKey(row int) string {
+	return "import:" + c.state.FileSHA256[:16] + ":" + strconv.Itoa(row)
+}
+
+// save menulis checkpoint lewat file sementara + rename, supaya checkpoint
+// tidak setengah tertulis jika proses mati saat menulis.
+func (c *csvCheckpoint) save(row int, offset int64) error {
+	c.state.Row = row
+	c.state.Offset = offset
+
+	data, err := json.Marshal(c.state)
+	if err != nil {
+		return err
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// remove menghapus checkpoint file setelah import selesai.
+func (c *csvCheckpoint) remove() error {
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
+
+// fileSHA256 menghitung hash isi file (hex).
+func fileSHA256(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,241 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
+)
+
+// comparisonThreshold adalah perubahan (dalam persen) yang membuat row di
+// sheet Comparison diberi warna: naik > 20% merah, turun > 20% hijau.
+const comparisonThreshold = 20
+
+// exportPageSize adalah ukuran halaman saat membaca semua transaksi
+// (maksimal yang diizinkan ListParams).
+const exportPageSize = 100
+
+// Style row sheet Comparison.
+var (
+	increasedRowStyle = &excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"FEE2E2"}, Pattern: 1},
+		Font: &excelize.Font{Color: "B91C1C"},
+	}
+
+	decreasedRowStyle = &excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"DCFCE7"}, Pattern: 1},
+		Font: &excelize.Font{Color: "15803D"},
+	}
+)
+
+// ComparePeriodsToExcel membuat workbook perbandingan dua rentang tanggal,
+// misalnya Januari 2024 vs Januari 2025:
+//   - sheet "Period 1" dan "Period 2": transaksi masing-masing periode
+//   - sheet "Comparison": expense per kategori (Category, Period1 Spent,
+//     Period2 Spent, Change %), row yang naik > 20% berwarna merah dan
+//     yang turun > 20% berwarna hijau
+//
+// Adjustment tidak dihitung sebagai spending. Kategori yang baru muncul di
+// periode 2 ditandai "new" di kolom Change %.
+//
+//	jan24 := repository.TransactionFilter{StartDate: &start24, EndDate: &end24}
+//	jan25 := repository.TransactionFilter{StartDate: &start25, EndDate: &end25}
+//	err := excelExporter.ComparePeriodsToExcel(ctx, "jan.xlsx", jan24, jan25)
+func (e *ExcelExporter) ComparePeriodsToExcel(ctx context.Context, filename string, period1, period2 repository.TransactionFilter) error {
+	periods := []repository.TransactionFilter{period1, period2}
+	transactions := make([][]*models.Transaction, len(periods))
+	for i, filter := range periods {
+		txs, err := listAllTransactions(ctx, e.transactionRepo, filter)
+		if err != nil {
+			return err
+		}
+		transactions[i] = txs
+	}
+
+	refs, err := loadRefData(ctx, e.refs, e.walletRepo, e.categoryRepo)
+	if err != nil {
+		return err
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyleID, _ := f.NewStyle(headerStyle)
+	titleStyleID, _ := f.NewStyle(titleStyle)
+	money := newMoneyStyles(f)
+
+	for i, filter := range periods {
+		sheetName := fmt.Sprintf("Period %d", i+1)
+		if i == 0 {
+			f.SetSheetName("Sheet1", sheetName)
+		} else {
+			f.NewSheet(sheetName)
+		}
+
+		f.SetCellValue(sheetName, "A1", fmt.Sprintf("📅 Period %d: %s", i+1, periodLabel(filter, e.locale)))
+		f.SetCellStyle(sheetName, "A1", "A1", titleStyleID)
+
+		headers := []string{"Date", "Type", "Amount", "Description", "Category"}
+		for col, h := range headers {
+			cell := fmt.Sprintf("%c3", 'A'+col)
+			f.SetCellValue(sheetName, cell, h)
+			f.SetCellStyle(sheetName, cell, cell, headerStyleID)
+		}
+		f.SetColWidth(sheetName, "A", "A", 15)
+		f.SetColWidth(sheetName, "B", "B", 12)
+		f.SetColWidth(sheetName, "C", "C", 18)
+		f.SetColWidth(sheetName, "D", "D", 40)
+		f.SetColWidth(sheetName, "E", "E", 20)
+
+		for j, tx := range transactions[i] {
+			row := j + 4
+			f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), tx.TransactionDate.Format("02-Jan-2006"))
+			f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), string(tx.Type))
+			f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), excelNumber(tx.Amount))
+
+			style := incomeStyle
+			if tx.Type == models.TransactionTypeExpense {
+				style = expenseStyle
+			}
+			f.SetCellStyle(sheetName, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), money.get(style, e.currency))
+
+			f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), tx.Description)
+			f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), refs.CategoryName(tx.CategoryID))
+		}
+	}
+
+	// Comparison sheet
+	sheetName := "Comparison"
+	comparisonIndex, _ := f.NewSheet(sheetName)
+	f.SetActiveSheet(comparisonIndex)
+
+	headers := []string{"Category", "Period1 Spent", "Period2 Spent", "Change %"}
+	for col, h := range headers {
+		cell := fmt.Sprintf("%c1", 'A'+col)
+		f.SetCellValue(sheetName, cell, h)
+		f.SetCellStyle(sheetName, cell, cell, headerStyleID)
+	}
+	f.SetColWidth(sheetName, "A", "A", 25)
+	f.SetColWidth(sheetName, "B", "C", 18)
+	f.SetColWidth(sheetName, "D", "D", 12)
+
+	increasedID, _ := f.NewStyle(increasedRowStyle)
+	decreasedID, _ := f.NewStyle(decreasedRowStyle)
+
+	for i, c := range compareSpending(transactions[0], transactions[1], refs) {
+		row := i + 2
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), c.category)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), excelNumber(c.spent1))
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), excelNumber(c.spent2))
+		f.SetCellStyle(sheetName, fmt.Sprintf("B%d", row), fmt.Sprintf("C%d", row), money.get(moneyStyle, e.currency))
+
+		change, ok := c.changePercent()
+		if ok {
+			f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), excelNumber(change.Round(1)))
+		} else {
+			f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), "new")
+		}
+
+		switch {
+		case !ok || change.GreaterThan(decimal.NewFromInt(comparisonThreshold)):
+			f.SetCellStyle(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("D%d", row), increasedID)
+		case change.LessThan(decimal.NewFromInt(-comparisonThreshold)):
+			f.SetCellStyle(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("D%d", row), decreasedID)
+		}
+	}
+
+	return f.SaveAs(filename)
+}
+
+// listAllTransactions membaca semua transaksi yang cocok dengan filter,
+// per halaman karena ListParams membatasi Limit.
+func listAllTransactions(ctx context.Context, repo repository.TransactionRepository, filter repository.TransactionFilter) ([]*models.Transaction, error) {
+	var all []*models.Transaction
+	for offset := 0; ; offset += exportPageSize {
+		txs, err := repo.List(ctx, filter, repository.ListParams{Limit: exportPageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		all = append(all, txs...)
+
+		if len(txs) < exportPageSize {
+			return all, nil
+		}
+	}
+}
+
+// categoryComparison adalah total expense satu kategori di dua periode.
+type categoryComparison struct {
+	category string
+	spent1   decimal.Decimal
+	spent2   decimal.Decimal
+}
+
+// changePercent menghitung perubahan spent1 → spent2 dalam persen.
+// ok false jika periode 1 kosong (kategori baru), karena persentase
+// dari nol tidak terdefinisi.
+func (c categoryComparison) changePercent() (decimal.Decimal, bool) {
+	if c.spent1.IsZero() {
+		return decimal.Zero, false
+	}
+	return c.spent2.Sub(c.spent1).Div(c.spent1).Mul(decimal.NewFromInt(100)), true
+}
+
+// compareSpending menjumlahkan expense per kategori untuk dua periode.
+// Adjustment dilewati; kategori yang kosong di kedua periode tidak ikut.
+// Hasil diurutkan berdasarkan spending periode 2 terbesar.
+func compareSpending(period1, period2 []*models.Transaction, refs *service.RefData) []*categoryComparison {
+	byName := make(map[string]*categoryComparison)
+	add := func(txs []*models.Transaction, second bool) {
+		for _, tx := range txs {
+			if tx.Type != models.TransactionTypeExpense || tx.IsAdjustment() {
+				continue
+			}
+			name := refs.CategoryName(tx.CategoryID)
+			c, ok := byName[name]
+			if !ok {
+				c = &categoryComparison{category: name}
+				byName[name] = c
+			}
+			if second {
+				c.spent2 = c.spent2.Add(tx.Amount)
+			} else {
+				c.spent1 = c.spent1.Add(tx.Amount)
+			}
+		}
+	}
+	add(period1, false)
+	add(period2, true)
+
+	comparisons := make([]*categoryComparison, 0, len(byName))
+	for _, c := range byName {
+		comparisons = append(comparisons, c)
+	}
+	sort.Slice(comparisons, func(i, j int) bool {
+		if !comparisons[i].spent2.Equal(comparisons[j].spent2) {
+			return comparisons[i].spent2.GreaterThan(comparisons[j].spent2)
+		}
+		return comparisons[i].category < comparisons[j].category
+	})
+	return comparisons
+}
+
+// periodLabel memformat rentang tanggal filter sesuai locale, contoh
+// "01 Jan 2025 – 31 Jan 2025".
+func periodLabel(filter repository.TransactionFilter, locale string) string {
+	format := func(t *time.Time, open string) string {
+		if t == nil {
+			return open
+		}
+		return utils.FormatLongDate(*t, locale)
+	}
+	return format(filter.StartDate, "beginning") + " – " + format(filter.EndDate, "now")
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+)
+
+func TestExcelExporter_ComparePeriodsToExcel(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	walletRepo := memory.NewWalletRepository(store)
+	txRepo := memory.NewTransactionRepository(store)
+	categoryRepo := memory.NewCategoryRepository(store)
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	if err := walletRepo.Create(ctx, wallet); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+
+	categories := map[string]*models.Category{}
+	for _, name := range []string{"Food", "Transport", "Bills", "Travel"} {
+		c := models.NewCategory(name, models.CategoryTypeExpense)
+		if err := categoryRepo.Create(ctx, c); err != nil {
+			t.Fatalf("create category: %v", err)
+		}
+		categories[name] = c
+	}
+
+	addExpense := func(year int, category string, amount int64) {
+		tx := models.NewTransaction(wallet.ID, models.TransactionTypeExpense, decimal.NewFromInt(amount))
+		tx.CategoryID = &categories[category].ID
+		tx.TransactionDate = time.Date(year, 1, 15, 0, 0, 0, 0, time.Local)
+		if err := txRepo.Create(ctx, tx); err != nil {
+			t.Fatalf("create transaction: %v", err)
+		}
+	}
+	// Food +50% (merah), Transport -50% (hijau), Bills +10% (tanpa warna), Travel baru
+	addExpense(2024, "Food", 1000000)
+	addExpense(2024, "Transport", 400000)
+	addExpense(2024, "Bills", 500000)
+	addExpense(2025, "Food", 1500000)
+	addExpense(2025, "Transport", 200000)
+	addExpense(2025, "Bills", 550000)
+	addExpense(2025, "Travel", 300000)
+
+	january := func(year int) repository.TransactionFilter {
+		start := time.Date(year, 1, 1, 0, 0, 0, 0, time.Local)
+		end := start.AddDate(0, 1, 0).Add(-time.Nanosecond)
+		return repository.TransactionFilter{StartDate: &start, EndDate: &end}
+	}
+
+	e := NewExcelExporter(walletRepo, txRepo, categoryRepo, "IDR")
+	filename := filepath.Join(t.TempDir(), "compare.xlsx")
+	if err := e.ComparePeriodsToExcel(ctx, filename, january(2024), january(2025)); err != nil {
+		t.Fatalf("ComparePeriodsToExcel() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(filename)
+	if err != nil {
+		t.Fatalf("open workbook: %v", err)
+	}
+	defer f.Close()
+
+	if got := f.GetSheetList(); len(got) != 3 || got[0] != "Period 1" || got[1] != "Period 2" || got[2] != "Comparison" {
+		t.Fatalf("sheets = %v, want [Period 1 Period 2 Comparison]", got)
+	}
+
+	rows, err := f.GetRows("Comparison")
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("Comparison rows = %d, want header + 4 categories", len(rows))
+	}
+	if rows[0][0] != "Category" || rows[0][3] != "Change %" {
+		t.Errorf("header = %v", rows[0])
+	}
+
+	fills := map[string]string{}
+	changes := map[string]string{}
+	for i, row := range rows[1:] {
+		cell := fmt.Sprintf("A%d", i+2)
+		styleID, _ := f.GetCellStyle("Comparison", cell)
+		style, _ := f.GetStyle(styleID)
+		if len(style.Fill.Color) > 0 {
+			fills[row[0]] = style.Fill.Color[0]
+		}
+		changes[row[0]] = row[3]
+	}
+
+	wantFills := map[string]string{"Food": "FEE2E2", "Transport": "DCFCE7", "Travel": "FEE2E2"}
+	for category, want := range wantFills {
+		if fills[category] != want {
+			t.Errorf("%s fill = %q, want %q", category, fills[category], want)
+		}
+	}
+	if _, ok := fills["Bills"]; ok {
+		t.Errorf("Bills (+10%%) should not be coloured")
+	}
+	if changes["Food"] != "50" || changes["Transport"] != "-50" || changes["Travel"] != "new" {
+		t.Errorf("Change %% = %v", changes)
+	}
+}
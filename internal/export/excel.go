@@ -3,12 +3,17 @@ package export
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/xuri/excelize/v2"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
 // ExcelExporter creates professional Excel reports.
@@ -16,21 +21,50 @@ type ExcelExporter struct {
 	walletRepo      repository.WalletRepository
 	transactionRepo repository.TransactionRepository
 	categoryRepo    repository.CategoryRepository
+	currency        string
+
+	// refs diisi lewat SetRefData; nil berarti di-load per export.
+	refs *service.RefData
+
+	// recurringRepo diisi lewat SetRecurringRepository, hanya dipakai
+	// RecurringToExcel.
+	recurringRepo repository.RecurringRepository
+
+	// locale untuk tanggal di teks report (judul, periode), lihat SetLocale.
+	locale string
 }
 
 // NewExcelExporter creates a new ExcelExporter.
+//
+// currency adalah currency default (app.currency) untuk total dan untuk
+// transaksi yang wallet-nya tidak ditemukan. Nominal per wallet/transaksi
+// memakai currency wallet-nya sendiri.
 func NewExcelExporter(
 	walletRepo repository.WalletRepository,
 	transactionRepo repository.TransactionRepository,
 	categoryRepo repository.CategoryRepository,
+	currency string,
 ) *ExcelExporter {
 	return &ExcelExporter{
 		walletRepo:      walletRepo,
 		transactionRepo: transactionRepo,
 		categoryRepo:    categoryRepo,
+		currency:        currency,
 	}
 }
 
+// SetRefData membuat export memakai refs untuk currency/warna wallet dan
+// nama category, bukan me-load daftar wallet dan category sendiri.
+func (e *ExcelExporter) SetRefData(refs *service.RefData) {
+	e.refs = refs
+}
+
+// SetLocale mengatur locale (app.locale) untuk tanggal di teks report.
+// Kosong berarti English.
+func (e *ExcelExporter) SetLocale(locale string) {
+	e.locale = locale
+}
+
 // Excel styles
 var (
 	headerStyle = &excelize.Style{
@@ -65,17 +99,17 @@ var (
 	}
 
 	incomeStyle = &excelize.Style{
-		Font: &excelize.Font{Color: "16A34A"},
+		Font:   &excelize.Font{Color: "16A34A"},
 		NumFmt: 4,
 	}
 
 	expenseStyle = &excelize.Style{
-		Font: &excelize.Font{Color: "DC2626"},
+		Font:   &excelize.Font{Color: "DC2626"},
 		NumFmt: 4,
 	}
 
 	moneyStyle = &excelize.Style{
-		NumFmt: 4,
+		NumFmt:    4,
 		Alignment: &excelize.Alignment{Horizontal: "right"},
 	}
 
@@ -88,8 +122,102 @@ var (
 	}
 )
 
+// defaultWalletAccent dipakai untuk wallet tanpa Color (warna header).
+const defaultWalletAccent = "4F46E5"
+
+// accentStyles membuat style aksen per warna wallet (border kiri tebal
+// dan teks tebal berwarna) dan meng-cache ID-nya.
+type accentStyles struct {
+	f   *excelize.File
+	ids map[string]int
+}
+
+func newAccentStyles(f *excelize.File) *accentStyles {
+	return &accentStyles{f: f, ids: make(map[string]int)}
+}
+
+// get mengembalikan style ID untuk warna wallet (#RRGGBB atau kosong).
+func (a *accentStyles) get(color string) int {
+	color = strings.ToUpper(strings.TrimPrefix(color, "#"))
+	if color == "" {
+		color = defaultWalletAccent
+	}
+	if id, ok := a.ids[color]; ok {
+		return id
+	}
+
+	id, _ := a.f.NewStyle(&excelize.Style{
+		Font:   &excelize.Font{Bold: true, Color: color},
+		Border: []excelize.Border{{Type: "left", Color: color, Style: 5}},
+	})
+	a.ids[color] = id
+	return id
+}
+
+// excelMoneyFormat mengembalikan number format Excel dengan simbol dan
+// jumlah desimal sesuai currency: `"Rp "#,##0` untuk IDR, `"$"#,##0.00`
+// untuk USD. Currency kosong menghasilkan angka tanpa simbol.
+func excelMoneyFormat(currency string) string {
+	format := "#,##0"
+	if decimals := utils.CurrencyDecimals(currency); decimals > 0 {
+		format += "." + strings.Repeat("0", int(decimals))
+	}
+	if currency == "" {
+		return format
+	}
+	return `"` + utils.CurrencySymbol(currency) + `"` + format
+}
+
+// excelNumber mengubah decimal menjadi float64 untuk cell Excel
+// (Excel sendiri menyimpan angka sebagai float).
+func excelNumber(d decimal.Decimal) float64 {
+	f, _ := d.Float64()
+	return f
+}
+
+// moneyStyles membuat style angka per currency dan meng-cache ID-nya,
+// karena satu sheet bisa berisi wallet dengan currency berbeda.
+type moneyStyles struct {
+	f   *excelize.File
+	ids map[moneyStyleKey]int
+}
+
+type moneyStyleKey struct {
+	base     *excelize.Style
+	currency string
+}
+
+func newMoneyStyles(f *excelize.File) *moneyStyles {
+	return &moneyStyles{f: f, ids: make(map[moneyStyleKey]int)}
+}
+
+// get mengembalikan style ID untuk base dengan number format currency.
+func (m *moneyStyles) get(base *excelize.Style, currency string) int {
+	key := moneyStyleKey{base: base, currency: currency}
+	if id, ok := m.ids[key]; ok {
+		return id
+	}
+
+	style := *base
+	format := excelMoneyFormat(currency)
+	style.NumFmt = 0
+	style.CustomNumFmt = &format
+
+	id, _ := m.f.NewStyle(&style)
+	m.ids[key] = id
+	return id
+}
+
 // TransactionsToExcel exports transactions to a professional Excel file.
 func (e *ExcelExporter) TransactionsToExcel(ctx context.Context, filename string, filter repository.TransactionFilter) error {
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.TransactionsToExcelWriter(ctx, w, filter)
+	})
+}
+
+// TransactionsToExcelWriter menulis workbook transaksi (.xlsx) ke w,
+// misalnya stdout untuk `export tx -f excel -o - --allow-binary`.
+func (e *ExcelExporter) TransactionsToExcelWriter(ctx context.Context, w io.Writer, filter repository.TransactionFilter) error {
 	f := excelize.NewFile()
 	defer f.Close()
 
@@ -97,18 +225,21 @@ func (e *ExcelExporter) TransactionsToExcel(ctx context.Context, filename string
 	f.SetSheetName("Sheet1", sheetName)
 
 	// Get data
-	params := repository.ListParams{Limit: 10000, Offset: 0}
-	transactions, err := e.transactionRepo.List(ctx, filter, params)
+	transactions, err := listAllTransactions(ctx, e.transactionRepo, filter)
 	if err != nil {
-		return fmt.Errorf("failed to get transactions: %w", err)
+		return err
+	}
+
+	refs, err := loadRefData(ctx, e.refs, e.walletRepo, e.categoryRepo)
+	if err != nil {
+		return err
 	}
 
 	// Create styles
 	headerStyleID, _ := f.NewStyle(headerStyle)
 	titleStyleID, _ := f.NewStyle(titleStyle)
-	incomeStyleID, _ := f.NewStyle(incomeStyle)
-	expenseStyleID, _ := f.NewStyle(expenseStyle)
-	moneyStyleID, _ := f.NewStyle(moneyStyle)
+	money := newMoneyStyles(f)
+	accents := newAccentStyles(f)
 
 	// Title
 	f.SetCellValue(sheetName, "A1", "📊 Transaction Report")
@@ -116,7 +247,7 @@ func (e *ExcelExporter) TransactionsToExcel(ctx context.Context, filename string
 	f.MergeCell(sheetName, "A1", "F1")
 
 	// Subtitle
-	f.SetCellValue(sheetName, "A2", fmt.Sprintf("Generated: %s", time.Now().Format("02 January 2006, 15:04")))
+	f.SetCellValue(sheetName, "A2", generatedLabel(time.Now(), e.locale))
 
 	// Headers
 	headers := []string{"Date", "Type", "Amount", "Description", "Wallet ID", "Category"}
@@ -135,32 +266,33 @@ func (e *ExcelExporter) TransactionsToExcel(ctx context.Context, filename string
 	f.SetColWidth(sheetName, "F", "F", 20)
 
 	// Data rows
-	var totalIncome, totalExpense float64
+	totalIncome, totalExpense := decimal.Zero, decimal.Zero
 	for i, tx := range transactions {
 		row := i + 5
-		
+
 		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), tx.TransactionDate.Format("02-Jan-2006"))
 		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), string(tx.Type))
-		
-		amount, _ := tx.Amount.Float64()
-		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), amount)
-		
+
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), excelNumber(tx.Amount))
+
+		currency := refs.WalletCurrency(tx.WalletID, e.currency)
 		if tx.Type == models.TransactionTypeIncome {
-			f.SetCellStyle(sheetName, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), incomeStyleID)
-			totalIncome += amount
+			f.SetCellStyle(sheetName, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), money.get(incomeStyle, currency))
+			totalIncome = totalIncome.Add(tx.Amount)
 		} else {
-			f.SetCellStyle(sheetName, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), expenseStyleID)
-			totalExpense += amount
+			f.SetCellStyle(sheetName, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), money.get(expenseStyle, currency))
+			totalExpense = totalExpense.Add(tx.Amount)
 		}
 
 		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), tx.Description)
 		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), tx.WalletID.String())
-		
-		categoryName := "-"
-		if tx.CategoryID != nil {
-			categoryName = tx.CategoryID.String()[:8] + "..."
+		if wallet, ok := refs.Wallet(tx.WalletID); ok {
+			f.SetCellStyle(sheetName, fmt.Sprintf("E%d", row), fmt.Sprintf("E%d", row), accents.get(wallet.Color))
+		} else {
+			f.SetCellStyle(sheetName, fmt.Sprintf("E%d", row), fmt.Sprintf("E%d", row), accents.get(""))
 		}
-		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), categoryName)
+
+		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), refs.CategoryName(tx.CategoryID))
 	}
 
 	// Summary section
@@ -169,25 +301,97 @@ func (e *ExcelExporter) TransactionsToExcel(ctx context.Context, filename string
 	f.SetCellStyle(sheetName, fmt.Sprintf("A%d", summaryRow), fmt.Sprintf("A%d", summaryRow), titleStyleID)
 
 	f.SetCellValue(sheetName, fmt.Sprintf("A%d", summaryRow+1), "Total Income:")
-	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryRow+1), totalIncome)
-	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", summaryRow+1), fmt.Sprintf("B%d", summaryRow+1), incomeStyleID)
+	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryRow+1), excelNumber(totalIncome))
+	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", summaryRow+1), fmt.Sprintf("B%d", summaryRow+1), money.get(incomeStyle, e.currency))
 
 	f.SetCellValue(sheetName, fmt.Sprintf("A%d", summaryRow+2), "Total Expense:")
-	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryRow+2), totalExpense)
-	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", summaryRow+2), fmt.Sprintf("B%d", summaryRow+2), expenseStyleID)
+	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryRow+2), excelNumber(totalExpense))
+	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", summaryRow+2), fmt.Sprintf("B%d", summaryRow+2), money.get(expenseStyle, e.currency))
 
 	f.SetCellValue(sheetName, fmt.Sprintf("A%d", summaryRow+3), "Net:")
-	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryRow+3), totalIncome-totalExpense)
-	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", summaryRow+3), fmt.Sprintf("B%d", summaryRow+3), moneyStyleID)
+	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryRow+3), excelNumber(totalIncome.Sub(totalExpense)))
+	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", summaryRow+3), fmt.Sprintf("B%d", summaryRow+3), money.get(moneyStyle, e.currency))
 
 	f.SetCellValue(sheetName, fmt.Sprintf("A%d", summaryRow+4), "Total Transactions:")
 	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryRow+4), len(transactions))
 
-	return f.SaveAs(filename)
+	if err := e.writeBreakdownSheet(ctx, f, filter, money); err != nil {
+		return err
+	}
+
+	_, err = f.WriteTo(w)
+	return err
+}
+
+// writeBreakdownSheet menambahkan sheet "Breakdown" berisi cash flow per
+// tipe wallet dan top merchants untuk filter.
+func (e *ExcelExporter) writeBreakdownSheet(ctx context.Context, f *excelize.File, filter repository.TransactionFilter, money *moneyStyles) error {
+	flows, merchants, err := loadBreakdown(ctx, e.transactionRepo, filter)
+	if err != nil {
+		return err
+	}
+
+	sheetName := "Breakdown"
+	if _, err := f.NewSheet(sheetName); err != nil {
+		return fmt.Errorf("failed to create sheet: %w", err)
+	}
+	headerStyleID, _ := f.NewStyle(headerStyle)
+	titleStyleID, _ := f.NewStyle(titleStyle)
+
+	f.SetColWidth(sheetName, "A", "A", 28)
+	f.SetColWidth(sheetName, "B", "C", 18)
+
+	// Cash flow per tipe wallet
+	f.SetCellValue(sheetName, "A1", "🏦 Cash Flow by Wallet Type")
+	f.SetCellStyle(sheetName, "A1", "A1", titleStyleID)
+	for i, h := range []string{"Wallet Type", "Inflow", "Outflow"} {
+		cell := fmt.Sprintf("%c3", 'A'+i)
+		f.SetCellValue(sheetName, cell, h)
+		f.SetCellStyle(sheetName, cell, cell, headerStyleID)
+	}
+	row := 4
+	for _, flow := range flows {
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), string(flow.WalletType))
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), excelNumber(flow.Inflow))
+		f.SetCellStyle(sheetName, fmt.Sprintf("B%d", row), fmt.Sprintf("B%d", row), money.get(incomeStyle, e.currency))
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), excelNumber(flow.Outflow))
+		f.SetCellStyle(sheetName, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), money.get(expenseStyle, e.currency))
+		row++
+	}
+
+	// Top merchants
+	row += 2
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "🛒 Top Merchants")
+	f.SetCellStyle(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("A%d", row), titleStyleID)
+	row += 2
+	if len(merchants) == 0 {
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "No merchants in this period")
+		return nil
+	}
+	for i, h := range []string{"Merchant", "Total", "Transactions"} {
+		cell := fmt.Sprintf("%c%d", 'A'+i, row)
+		f.SetCellValue(sheetName, cell, h)
+		f.SetCellStyle(sheetName, cell, cell, headerStyleID)
+	}
+	for _, m := range merchants {
+		row++
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), m.Name)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), excelNumber(m.Total))
+		f.SetCellStyle(sheetName, fmt.Sprintf("B%d", row), fmt.Sprintf("B%d", row), money.get(expenseStyle, e.currency))
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), m.Count)
+	}
+	return nil
 }
 
 // WalletsToExcel exports wallets to a professional Excel file.
 func (e *ExcelExporter) WalletsToExcel(ctx context.Context, filename string) error {
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.WalletsToExcelWriter(ctx, w)
+	})
+}
+
+// WalletsToExcelWriter menulis workbook wallets (.xlsx) ke w.
+func (e *ExcelExporter) WalletsToExcelWriter(ctx context.Context, w io.Writer) error {
 	f := excelize.NewFile()
 	defer f.Close()
 
@@ -202,14 +406,15 @@ func (e *ExcelExporter) WalletsToExcel(ctx context.Context, filename string) err
 	// Create styles
 	headerStyleID, _ := f.NewStyle(headerStyle)
 	titleStyleID, _ := f.NewStyle(titleStyle)
-	moneyStyleID, _ := f.NewStyle(moneyStyle)
+	money := newMoneyStyles(f)
+	accents := newAccentStyles(f)
 
 	// Title
 	f.SetCellValue(sheetName, "A1", "💼 Wallet Summary")
 	f.SetCellStyle(sheetName, "A1", "A1", titleStyleID)
 	f.MergeCell(sheetName, "A1", "E1")
 
-	f.SetCellValue(sheetName, "A2", fmt.Sprintf("Generated: %s", time.Now().Format("02 January 2006, 15:04")))
+	f.SetCellValue(sheetName, "A2", generatedLabel(time.Now(), e.locale))
 
 	// Headers
 	headers := []string{"Name", "Type", "Balance", "Currency", "Status"}
@@ -227,23 +432,23 @@ func (e *ExcelExporter) WalletsToExcel(ctx context.Context, filename string) err
 	f.SetColWidth(sheetName, "E", "E", 12)
 
 	// Data
-	var totalBalance float64
+	totalBalance := decimal.Zero
 	for i, w := range wallets {
 		row := i + 5
-		
+
 		name := w.Name
 		if w.Icon != "" {
 			name = w.Icon + " " + w.Name
 		}
 		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), name)
+		f.SetCellStyle(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("A%d", row), accents.get(w.Color))
 		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), string(w.Type))
-		
-		balance, _ := w.Balance.Float64()
-		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), balance)
-		f.SetCellStyle(sheetName, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), moneyStyleID)
-		
+
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), excelNumber(w.Balance))
+		f.SetCellStyle(sheetName, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), money.get(moneyStyle, w.Currency))
+
 		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), w.Currency)
-		
+
 		status := "Active"
 		if !w.IsActive {
 			status = "Inactive"
@@ -251,7 +456,7 @@ func (e *ExcelExporter) WalletsToExcel(ctx context.Context, filename string) err
 		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), status)
 
 		if w.IsActive {
-			totalBalance += balance
+			totalBalance = totalBalance.Add(w.Balance)
 		}
 	}
 
@@ -259,8 +464,9 @@ func (e *ExcelExporter) WalletsToExcel(ctx context.Context, filename string) err
 	totalRow := len(wallets) + 6
 	f.SetCellValue(sheetName, fmt.Sprintf("A%d", totalRow), "TOTAL BALANCE:")
 	f.SetCellStyle(sheetName, fmt.Sprintf("A%d", totalRow), fmt.Sprintf("A%d", totalRow), titleStyleID)
-	f.SetCellValue(sheetName, fmt.Sprintf("C%d", totalRow), totalBalance)
-	f.SetCellStyle(sheetName, fmt.Sprintf("C%d", totalRow), fmt.Sprintf("C%d", totalRow), moneyStyleID)
+	f.SetCellValue(sheetName, fmt.Sprintf("C%d", totalRow), excelNumber(totalBalance))
+	f.SetCellStyle(sheetName, fmt.Sprintf("C%d", totalRow), fmt.Sprintf("C%d", totalRow), money.get(moneyStyle, e.currency))
 
-	return f.SaveAs(filename)
+	_, err = f.WriteTo(w)
+	return err
 }
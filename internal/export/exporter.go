@@ -13,6 +13,9 @@
 //
 //	// Export ke JSON
 //	err := exporter.WalletsToJSON(ctx, "wallets.json")
+//
+//	// Streaming ke io.Writer (stdout, buffer, dll)
+//	err := exporter.TransactionsToCSVWriter(ctx, os.Stdout, filter)
 package export
 
 import (
@@ -20,11 +23,16 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
+	"github.com/Adityanrhm/wallet-twin/internal/version"
 )
 
 // Exporter handles data export operations.
@@ -33,6 +41,9 @@ type Exporter struct {
 	transactionRepo repository.TransactionRepository
 	categoryRepo    repository.CategoryRepository
 	goalRepo        repository.GoalRepository
+
+	// refs diisi lewat SetRefData; nil berarti di-load per export.
+	refs *service.RefData
 }
 
 // NewExporter creates a new Exporter.
@@ -50,30 +61,38 @@ func NewExporter(
 	}
 }
 
+// SetRefData membuat export memakai refs untuk nama/currency wallet dan
+// nama category, bukan me-load daftar wallet dan category sendiri.
+// Berguna jika beberapa export berjalan sekaligus.
+func (e *Exporter) SetRefData(refs *service.RefData) {
+	e.refs = refs
+}
+
 // ==================== CSV Export ====================
 
 // TransactionsToCSV exports transactions to a CSV file.
 func (e *Exporter) TransactionsToCSV(ctx context.Context, filename string, filter repository.TransactionFilter) error {
-	// Get transactions
-	params := repository.ListParams{Limit: 10000, Offset: 0}
-	transactions, err := e.transactionRepo.List(ctx, filter, params)
-	if err != nil {
-		return fmt.Errorf("failed to get transactions: %w", err)
-	}
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.TransactionsToCSVWriter(ctx, w, filter)
+	})
+}
 
-	// Create file
-	file, err := os.Create(filename)
+// TransactionsToCSVWriter menulis transaksi dalam format CSV langsung ke w.
+//
+// Berguna untuk streaming ke stdout atau testing dengan bytes.Buffer
+// tanpa menyentuh filesystem.
+func (e *Exporter) TransactionsToCSVWriter(ctx context.Context, w io.Writer, filter repository.TransactionFilter) error {
+	// Get transactions
+	transactions, err := listAllTransactions(ctx, e.transactionRepo, filter)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		return err
 	}
-	defer file.Close()
 
 	// Write CSV
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	writer := csv.NewWriter(w)
 
 	// Header
-	header := []string{"ID", "Date", "Type", "Amount", "Description", "Wallet ID", "Category ID", "Tags"}
+	header := []string{"ID", "Date", "Type", "Amount", "Description", "Wallet ID", "Category ID", "Tags", "Note"}
 	if err := writer.Write(header); err != nil {
 		return fmt.Errorf("failed to write header: %w", err)
 	}
@@ -104,6 +123,7 @@ func (e *Exporter) TransactionsToCSV(ctx context.Context, filename string, filte
 			tx.WalletID.String(),
 			categoryID,
 			tags,
+			tx.Note,
 		}
 
 		if err := writer.Write(row); err != nil {
@@ -111,24 +131,25 @@ func (e *Exporter) TransactionsToCSV(ctx context.Context, filename string, filte
 		}
 	}
 
-	return nil
+	writer.Flush()
+	return writer.Error()
 }
 
 // WalletsToCSV exports wallets to a CSV file.
 func (e *Exporter) WalletsToCSV(ctx context.Context, filename string) error {
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.WalletsToCSVWriter(ctx, w)
+	})
+}
+
+// WalletsToCSVWriter menulis daftar wallet dalam format CSV langsung ke w.
+func (e *Exporter) WalletsToCSVWriter(ctx context.Context, w io.Writer) error {
 	wallets, err := e.walletRepo.List(ctx, repository.WalletFilter{})
 	if err != nil {
 		return fmt.Errorf("failed to get wallets: %w", err)
 	}
 
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	writer := csv.NewWriter(w)
 
 	// Header
 	header := []string{"ID", "Name", "Type", "Balance", "Currency", "Color", "Icon", "Is Active", "Created At"}
@@ -155,107 +176,199 @@ func (e *Exporter) WalletsToCSV(ctx context.Context, filename string) error {
 		}
 	}
 
-	return nil
+	writer.Flush()
+	return writer.Error()
 }
 
 // ==================== JSON Export ====================
 
 // ExportData adalah struktur untuk full backup.
+// Version berisi versi build yang membuat backup (version.String()),
+// SchemaVersion berisi versi format backup (BackupSchemaVersion).
 type ExportData struct {
-	ExportedAt   time.Time            `json:"exported_at"`
-	Version      string               `json:"version"`
-	Wallets      []*models.Wallet     `json:"wallets"`
-	Categories   []*models.Category   `json:"categories"`
-	Transactions []*models.Transaction `json:"transactions"`
-	Goals        []*models.Goal       `json:"goals"`
+	ExportedAt    time.Time             `json:"exported_at"`
+	Version       string                `json:"version"`
+	SchemaVersion int                   `json:"schema_version"`
+	Wallets       []*models.Wallet      `json:"wallets"`
+	Categories    []*models.Category    `json:"categories"`
+	Transactions  []*models.Transaction `json:"transactions"`
+	Goals         []*models.Goal        `json:"goals"`
+
+	// GoalContributions adalah history kontribusi semua goal. Restore
+	// tidak menambahkannya lagi ke current_amount goal.
+	GoalContributions []*models.GoalContribution `json:"goal_contributions"`
+
+	// Manifest hanya ada di backup incremental (IncrementalToJSONWriter).
+	Manifest *BackupManifest `json:"manifest,omitempty"`
 }
 
 // ToJSON exports all data to a JSON file (full backup).
 func (e *Exporter) ToJSON(ctx context.Context, filename string) error {
-	// Get all data
-	wallets, err := e.walletRepo.List(ctx, repository.WalletFilter{})
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.ToJSONWriter(ctx, w)
+	})
+}
+
+// ToJSONWriter menulis full backup dalam format JSON langsung ke w.
+func (e *Exporter) ToJSONWriter(ctx context.Context, w io.Writer) error {
+	data, err := e.collectBackup(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get wallets: %w", err)
+		return err
 	}
 
-	categories, err := e.categoryRepo.List(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get categories: %w", err)
+	if err := encodeJSON(w, data); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
 	}
 
-	params := repository.ListParams{Limit: 100000, Offset: 0}
-	transactions, err := e.transactionRepo.List(ctx, repository.TransactionFilter{}, params)
+	return nil
+}
+
+// collectBackup mengambil semua data untuk backup. ExportedAt diisi
+// sebelum data dibaca, supaya perubahan selama export tetap masuk
+// backup incremental berikutnya.
+func (e *Exporter) collectBackup(ctx context.Context) (*ExportData, error) {
+	exportedAt := time.Now()
+
+	// Get all data
+	wallets, err := e.walletRepo.List(ctx, repository.WalletFilter{})
 	if err != nil {
-		return fmt.Errorf("failed to get transactions: %w", err)
+		return nil, fmt.Errorf("failed to get wallets: %w", err)
 	}
 
-	goals, err := e.goalRepo.List(ctx, repository.GoalFilter{})
+	categories, err := e.categoryRepo.List(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get goals: %w", err)
+		return nil, fmt.Errorf("failed to get categories: %w", err)
 	}
 
-	// Create export data
-	data := ExportData{
-		ExportedAt:   time.Now(),
-		Version:      "1.0.0",
-		Wallets:      wallets,
-		Categories:   categories,
-		Transactions: transactions,
-		Goals:        goals,
+	// Per halaman: ListParams membatasi Limit, jadi satu List besar
+	// hanya mengembalikan halaman pertama
+	transactions, err := listAllTransactions(ctx, e.transactionRepo, repository.TransactionFilter{})
+	if err != nil {
+		return nil, err
 	}
 
-	// Write to file
-	file, err := os.Create(filename)
+	goals, err := e.goalRepo.List(ctx, repository.GoalFilter{})
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		return nil, fmt.Errorf("failed to get goals: %w", err)
 	}
-	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-
-	if err := encoder.Encode(data); err != nil {
-		return fmt.Errorf("failed to encode JSON: %w", err)
+	contributions := []*models.GoalContribution{}
+	for _, g := range goals {
+		page, err := listAllContributions(ctx, e.goalRepo, g.ID)
+		if err != nil {
+			return nil, err
+		}
+		contributions = append(contributions, page...)
 	}
 
-	return nil
+	return &ExportData{
+		ExportedAt:        exportedAt,
+		Version:           version.String(),
+		SchemaVersion:     BackupSchemaVersion,
+		Wallets:           wallets,
+		Categories:        categories,
+		Transactions:      transactions,
+		Goals:             goals,
+		GoalContributions: contributions,
+	}, nil
 }
 
 // WalletsToJSON exports wallets to a JSON file.
 func (e *Exporter) WalletsToJSON(ctx context.Context, filename string) error {
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.WalletsToJSONWriter(ctx, w)
+	})
+}
+
+// WalletsToJSONWriter menulis daftar wallet dalam format JSON langsung ke w.
+func (e *Exporter) WalletsToJSONWriter(ctx context.Context, w io.Writer) error {
 	wallets, err := e.walletRepo.List(ctx, repository.WalletFilter{})
 	if err != nil {
 		return fmt.Errorf("failed to get wallets: %w", err)
 	}
 
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-
-	return encoder.Encode(wallets)
+	return encodeJSON(w, wallets)
 }
 
 // TransactionsToJSON exports transactions to a JSON file.
 func (e *Exporter) TransactionsToJSON(ctx context.Context, filename string, filter repository.TransactionFilter) error {
-	params := repository.ListParams{Limit: 100000, Offset: 0}
-	transactions, err := e.transactionRepo.List(ctx, filter, params)
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.TransactionsToJSONWriter(ctx, w, filter)
+	})
+}
+
+// TransactionsToJSONWriter menulis transaksi dalam format JSON langsung ke w.
+func (e *Exporter) TransactionsToJSONWriter(ctx context.Context, w io.Writer, filter repository.TransactionFilter) error {
+	transactions, err := listAllTransactions(ctx, e.transactionRepo, filter)
 	if err != nil {
-		return fmt.Errorf("failed to get transactions: %w", err)
+		return err
 	}
 
-	file, err := os.Create(filename)
+	return encodeJSON(w, transactions)
+}
+
+// ==================== Helpers ====================
+
+// WriteFile menyerahkan penulisan isi file ke fn. Dipakai semua export
+// berbasis filename, dan oleh CLI untuk --output.
+//
+// Isi ditulis ke file sementara di direktori yang sama lalu di-rename
+// ke filename jika fn dan Close berhasil, seperti checkpoint import. Jika
+// query atau penulisan gagal, file lama tetap utuh dan tidak ada file
+// kosong yang tertinggal. Error dari Close ikut dikembalikan supaya data
+// yang gagal di-flush ke disk tidak hilang diam-diam.
+func WriteFile(filename string, fn func(w io.Writer) error) (err error) {
+	file, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*.tmp")
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
-	defer file.Close()
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	// CreateTemp membuat file 0600; export biasa dibaca aplikasi lain
+	if err := file.Chmod(0o644); err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	if err := fn(file); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+	if err := os.Rename(file.Name(), filename); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// generatedLabel adalah baris "Generated: ..." di bawah judul report,
+// tanggalnya mengikuti locale (lihat utils.FormatLongDate).
+func generatedLabel(t time.Time, locale string) string {
+	return fmt.Sprintf("Generated: %s, %s", utils.FormatLongDate(t, locale), t.Format("15:04"))
+}
 
-	encoder := json.NewEncoder(file)
+// encodeJSON menulis v sebagai JSON dengan indentasi 2 spasi.
+func encodeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
 
-	return encoder.Encode(transactions)
+// loadRefData mengembalikan shared jika ada (lihat SetRefData), atau
+// me-load RefData baru: satu query wallet dan satu query category.
+// categoryRepo boleh nil.
+func loadRefData(
+	ctx context.Context,
+	shared *service.RefData,
+	walletRepo repository.WalletRepository,
+	categoryRepo repository.CategoryRepository,
+) (*service.RefData, error) {
+	if shared != nil {
+		return shared, nil
+	}
+	return service.LoadRefData(ctx, walletRepo, categoryRepo)
 }
@@ -0,0 +1,447 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// Fake repositories for testing.
+// Interface di-embed supaya fake cukup meng-override method yang dipakai.
+
+type fakeWalletRepo struct {
+	repository.WalletRepository
+	wallets   []*models.Wallet
+	listCalls int
+}
+
+func (f *fakeWalletRepo) List(ctx context.Context, filter repository.WalletFilter) ([]*models.Wallet, error) {
+	f.listCalls++
+	return f.wallets, nil
+}
+
+type fakeTransactionRepo struct {
+	repository.TransactionRepository
+	transactions      []*models.Transaction
+	cashFlows         []*repository.WalletTypeCashFlow
+	descriptionTotals []*repository.DescriptionTotal
+}
+
+func (f *fakeTransactionRepo) List(ctx context.Context, filter repository.TransactionFilter, params repository.ListParams) ([]*models.Transaction, error) {
+	params.Validate()
+	if params.Offset >= len(f.transactions) {
+		return nil, nil
+	}
+	return f.transactions[params.Offset:min(params.Offset+params.Limit, len(f.transactions))], nil
+}
+
+func (f *fakeTransactionRepo) GetCashFlowByWalletType(ctx context.Context, filter repository.TransactionFilter) ([]*repository.WalletTypeCashFlow, error) {
+	return f.cashFlows, nil
+}
+
+func (f *fakeTransactionRepo) GetDescriptionTotals(ctx context.Context, filter repository.TransactionFilter) ([]*repository.DescriptionTotal, error) {
+	return f.descriptionTotals, nil
+}
+
+type fakeCategoryRepo struct {
+	repository.CategoryRepository
+	categories []*models.Category
+	listCalls  int
+}
+
+func (f *fakeCategoryRepo) List(ctx context.Context) ([]*models.Category, error) {
+	f.listCalls++
+	return f.categories, nil
+}
+
+type fakeGoalRepo struct {
+	repository.GoalRepository
+	goals []*models.Goal
+}
+
+func (f *fakeGoalRepo) List(ctx context.Context, filter repository.GoalFilter) ([]*models.Goal, error) {
+	return f.goals, nil
+}
+
+func newTestExporter() (*Exporter, *models.Wallet, *models.Transaction) {
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	wallet.Balance = decimal.NewFromInt(1500000)
+	wallet.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tx := models.NewTransaction(wallet.ID, models.TransactionTypeExpense, decimal.NewFromInt(25000))
+	tx.Description = "Makan, siang"
+	tx.TransactionDate = time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	tx.Tags = []string{"food", "lunch"}
+
+	e := NewExporter(
+		&fakeWalletRepo{wallets: []*models.Wallet{wallet}},
+		&fakeTransactionRepo{transactions: []*models.Transaction{tx}},
+		&fakeCategoryRepo{},
+		&fakeGoalRepo{},
+	)
+	return e, wallet, tx
+}
+
+// Tests
+
+func TestExporter_TransactionsToCSVWriter(t *testing.T) {
+	e, wallet, tx := newTestExporter()
+
+	var buf bytes.Buffer
+	if err := e.TransactionsToCSVWriter(context.Background(), &buf, repository.TransactionFilter{}); err != nil {
+		t.Fatalf("TransactionsToCSVWriter() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2 (header + 1 row)", len(records))
+	}
+
+	want := []string{
+		tx.ID.String(), "2025-01-15", "expense", "25000", "Makan, siang",
+		wallet.ID.String(), "", "food;lunch",
+	}
+	for i, v := range want {
+		if records[1][i] != v {
+			t.Errorf("column %q = %q, want %q", records[0][i], records[1][i], v)
+		}
+	}
+}
+
+func TestWriteFile_KeepsExistingFileOnError(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "transactions.csv")
+	if err := os.WriteFile(filename, []byte("old export"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	errQuery := errors.New("query failed")
+	err := WriteFile(filename, func(w io.Writer) error {
+		if _, err := io.WriteString(w, "partial"); err != nil {
+			return err
+		}
+		return errQuery
+	})
+	if !errors.Is(err, errQuery) {
+		t.Fatalf("WriteFile() error = %v, want %v", err, errQuery)
+	}
+	if got, _ := os.ReadFile(filename); string(got) != "old export" {
+		t.Errorf("file = %q after failed export, want old content kept", got)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("dir has %d entries after failed export, want only the old file", len(entries))
+	}
+
+	if err := WriteFile(filename, func(w io.Writer) error {
+		_, err := io.WriteString(w, "new export")
+		return err
+	}); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	info, _ := os.Stat(filename)
+	if got, _ := os.ReadFile(filename); string(got) != "new export" || info.Mode().Perm() != 0o644 {
+		t.Errorf("file = %q (%v), want new export with mode 0644", got, info.Mode().Perm())
+	}
+}
+
+func TestExporter_WalletsToCSVWriter(t *testing.T) {
+	e, wallet, _ := newTestExporter()
+
+	var buf bytes.Buffer
+	if err := e.WalletsToCSVWriter(context.Background(), &buf); err != nil {
+		t.Fatalf("WalletsToCSVWriter() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "ID,Name,Type,Balance") {
+		t.Errorf("unexpected header: %q", lines[0])
+	}
+	wantRow := wallet.ID.String() + ",BCA,bank,1500000,IDR"
+	if !strings.HasPrefix(lines[1], wantRow) {
+		t.Errorf("row = %q, want prefix %q", lines[1], wantRow)
+	}
+}
+
+func TestExporter_JSONWriters(t *testing.T) {
+	e, wallet, tx := newTestExporter()
+	ctx := context.Background()
+
+	t.Run("wallets", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := e.WalletsToJSONWriter(ctx, &buf); err != nil {
+			t.Fatalf("WalletsToJSONWriter() error = %v", err)
+		}
+
+		var got []*models.Wallet
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if len(got) != 1 || got[0].ID != wallet.ID {
+			t.Errorf("got %+v, want wallet %s", got, wallet.ID)
+		}
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := e.TransactionsToJSONWriter(ctx, &buf, repository.TransactionFilter{}); err != nil {
+			t.Fatalf("TransactionsToJSONWriter() error = %v", err)
+		}
+
+		var got []*models.Transaction
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if len(got) != 1 || !got[0].Amount.Equal(tx.Amount) {
+			t.Errorf("got %+v, want amount %s", got, tx.Amount)
+		}
+	})
+
+	t.Run("full backup", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := e.ToJSONWriter(ctx, &buf); err != nil {
+			t.Fatalf("ToJSONWriter() error = %v", err)
+		}
+
+		var got ExportData
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if got.Version == "" || got.ExportedAt.IsZero() {
+			t.Error("expected version and exported_at to be set")
+		}
+		if got.SchemaVersion != BackupSchemaVersion {
+			t.Errorf("schema_version = %d, want %d", got.SchemaVersion, BackupSchemaVersion)
+		}
+		if len(got.Wallets) != 1 || len(got.Transactions) != 1 {
+			t.Errorf("got %d wallets, %d transactions; want 1, 1", len(got.Wallets), len(got.Transactions))
+		}
+		if got.Wallets[0].ID == uuid.Nil {
+			t.Error("expected wallet ID to round-trip")
+		}
+	})
+}
+
+func TestExcelExporter_WalletColorAccent(t *testing.T) {
+	colored := models.NewWallet("BCA", models.WalletTypeBank)
+	colored.Color = "#10B981"
+	plain := models.NewWallet("Cash", models.WalletTypeCash)
+
+	e := NewExcelExporter(&fakeWalletRepo{wallets: []*models.Wallet{colored, plain}}, &fakeTransactionRepo{}, &fakeCategoryRepo{}, "IDR")
+
+	filename := t.TempDir() + "/wallets.xlsx"
+	if err := e.WalletsToExcel(context.Background(), filename); err != nil {
+		t.Fatalf("WalletsToExcel() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(filename)
+	if err != nil {
+		t.Fatalf("failed to open export: %v", err)
+	}
+	defer f.Close()
+
+	for cell, want := range map[string]string{"A5": "10B981", "A6": defaultWalletAccent} {
+		id, err := f.GetCellStyle("Wallets", cell)
+		if err != nil {
+			t.Fatalf("GetCellStyle(%s) error = %v", cell, err)
+		}
+		style, err := f.GetStyle(id)
+		if err != nil {
+			t.Fatalf("GetStyle(%s) error = %v", cell, err)
+		}
+		if style.Font == nil || !strings.EqualFold(style.Font.Color, want) {
+			t.Errorf("%s font = %+v, want color %s", cell, style.Font, want)
+		}
+	}
+}
+
+func TestPDFMoney(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     string
+	}{
+		{"1234.56", "USD", "$1,234.56"},
+		{"-5", "USD", "-$5.00"},
+		{"50000", "IDR", "Rp 50.000"},
+		{"10", "EUR", "EUR 10.00"}, // € tidak ada di font PDF bawaan
+		{"10", "chf", "CHF 10.00"},
+	}
+	for _, tt := range tests {
+		if got := pdfMoney(decimal.RequireFromString(tt.amount), tt.currency); got != tt.want {
+			t.Errorf("pdfMoney(%s, %s) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestExcelExporter_CurrencyNumFmt(t *testing.T) {
+	usd := models.NewWallet("Wise", models.WalletTypeBank)
+	usd.Currency = "USD"
+	idr := models.NewWallet("BCA", models.WalletTypeBank)
+
+	e := NewExcelExporter(&fakeWalletRepo{wallets: []*models.Wallet{usd, idr}}, &fakeTransactionRepo{}, &fakeCategoryRepo{}, "IDR")
+
+	var buf bytes.Buffer
+	if err := e.WalletsToExcelWriter(context.Background(), &buf); err != nil {
+		t.Fatalf("WalletsToExcelWriter() error = %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("failed to open export: %v", err)
+	}
+	defer f.Close()
+
+	for cell, want := range map[string]string{"C5": `"$"#,##0.00`, "C6": `"Rp "#,##0`} {
+		id, err := f.GetCellStyle("Wallets", cell)
+		if err != nil {
+			t.Fatalf("GetCellStyle(%s) error = %v", cell, err)
+		}
+		style, err := f.GetStyle(id)
+		if err != nil {
+			t.Fatalf("GetStyle(%s) error = %v", cell, err)
+		}
+		if style.CustomNumFmt == nil || *style.CustomNumFmt != want {
+			t.Errorf("%s number format = %v, want %s", cell, style.CustomNumFmt, want)
+		}
+	}
+}
+
+func TestExcelExporter_TransactionsBreakdown(t *testing.T) {
+	tests := []struct {
+		name string
+		repo *fakeTransactionRepo
+		want map[string]string
+	}{
+		{
+			name: "empty month",
+			repo: &fakeTransactionRepo{},
+			want: map[string]string{"A4": "cash", "B4": "0", "C7": "0", "A12": "No merchants in this period"},
+		},
+		{
+			name: "with data",
+			repo: &fakeTransactionRepo{
+				cashFlows: []*repository.WalletTypeCashFlow{
+					{WalletType: models.WalletTypeBank, Inflow: decimal.NewFromInt(5000), Outflow: decimal.NewFromInt(1200), Count: 2},
+				},
+				descriptionTotals: []*repository.DescriptionTotal{
+					{Description: "indomaret 0423", Total: decimal.NewFromInt(100), Count: 1},
+					{Description: "indomaret 0817", Total: decimal.NewFromInt(250), Count: 1},
+				},
+			},
+			want: map[string]string{"A5": "bank", "B5": "5000", "C5": "1200", "A12": "Merchant", "A13": "indomaret", "B13": "350", "C13": "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewExcelExporter(&fakeWalletRepo{}, tt.repo, &fakeCategoryRepo{}, "IDR")
+
+			var buf bytes.Buffer
+			if err := e.TransactionsToExcelWriter(context.Background(), &buf, repository.TransactionFilter{}); err != nil {
+				t.Fatalf("TransactionsToExcelWriter() error = %v", err)
+			}
+
+			f, err := excelize.OpenReader(&buf)
+			if err != nil {
+				t.Fatalf("failed to open export: %v", err)
+			}
+			defer f.Close()
+
+			for cell, want := range tt.want {
+				got, err := f.GetCellValue("Breakdown", cell, excelize.Options{RawCellValue: true})
+				if err != nil {
+					t.Fatalf("GetCellValue(%s) error = %v", cell, err)
+				}
+				if got != want {
+					t.Errorf("Breakdown!%s = %q, want %q", cell, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExport_RefDataQueries(t *testing.T) {
+	ctx := context.Background()
+	wallets := []*models.Wallet{
+		models.NewWallet("BCA", models.WalletTypeBank),
+		models.NewWallet("Cash", models.WalletTypeCash),
+	}
+	categories := []*models.Category{
+		models.NewCategory("Food", models.CategoryTypeExpense),
+		models.NewCategory("Transport", models.CategoryTypeExpense),
+	}
+
+	// 10k transaksi: jumlah query referensi tidak boleh ikut bertambah
+	transactions := make([]*models.Transaction, 10000)
+	for i := range transactions {
+		tx := models.NewTransaction(wallets[i%2].ID, models.TransactionTypeExpense, decimal.NewFromInt(int64(1000+i)))
+		tx.SetCategory(categories[i%2].ID)
+		tx.Description = fmt.Sprintf("Transaction %d", i)
+		transactions[i] = tx
+	}
+
+	walletRepo := &fakeWalletRepo{wallets: wallets}
+	categoryRepo := &fakeCategoryRepo{categories: categories}
+	txRepo := &fakeTransactionRepo{transactions: transactions}
+
+	exporters := []struct {
+		name string
+		run  func() error
+	}{
+		{"markdown", func() error {
+			e := NewExporter(walletRepo, txRepo, categoryRepo, &fakeGoalRepo{})
+			return e.TransactionsToMarkdownWriter(ctx, io.Discard, repository.TransactionFilter{}, "IDR")
+		}},
+		{"excel", func() error {
+			e := NewExcelExporter(walletRepo, txRepo, categoryRepo, "IDR")
+			return e.TransactionsToExcelWriter(ctx, io.Discard, repository.TransactionFilter{})
+		}},
+	}
+
+	for _, ex := range exporters {
+		t.Run(ex.name, func(t *testing.T) {
+			walletRepo.listCalls, categoryRepo.listCalls = 0, 0
+			if err := ex.run(); err != nil {
+				t.Fatalf("export error = %v", err)
+			}
+			if walletRepo.listCalls != 1 || categoryRepo.listCalls != 1 {
+				t.Errorf("export made %d wallet and %d category queries, want exactly 1 each",
+					walletRepo.listCalls, categoryRepo.listCalls)
+			}
+		})
+	}
+
+	// Dengan SetRefData, export tidak query referensi sama sekali
+	refs := service.NewRefData(wallets, categories)
+	walletRepo.listCalls, categoryRepo.listCalls = 0, 0
+	e := NewExcelExporter(walletRepo, txRepo, categoryRepo, "IDR")
+	e.SetRefData(refs)
+	if err := e.TransactionsToExcelWriter(ctx, io.Discard, repository.TransactionFilter{}); err != nil {
+		t.Fatalf("export error = %v", err)
+	}
+	if walletRepo.listCalls+categoryRepo.listCalls != 0 {
+		t.Errorf("export with shared RefData made %d reference queries, want 0", walletRepo.listCalls+categoryRepo.listCalls)
+	}
+}
@@ -0,0 +1,430 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
+)
+
+// goalChartMonths adalah jumlah bulan terakhir di chart kontribusi.
+const goalChartMonths = 12
+
+// GoalStatementExporter membuat statement satu goal (PDF atau Excel):
+// data goal, progress, proyeksi (setoran per bulan, on track), chart
+// kontribusi per bulan, dan tabel semua kontribusi dengan running balance.
+type GoalStatementExporter struct {
+	goalRepo repository.GoalRepository
+	currency string
+
+	// locale untuk tanggal di teks statement, lihat SetLocale.
+	locale string
+}
+
+// NewGoalStatementExporter membuat GoalStatementExporter. currency adalah
+// currency default (app.currency), sama dengan yang dipakai goal.
+func NewGoalStatementExporter(goalRepo repository.GoalRepository, currency string) *GoalStatementExporter {
+	return &GoalStatementExporter{
+		goalRepo: goalRepo,
+		currency: currency,
+	}
+}
+
+// SetLocale mengatur locale (app.locale) untuk tanggal di teks statement.
+// Kosong berarti English.
+func (e *GoalStatementExporter) SetLocale(locale string) {
+	e.locale = locale
+}
+
+// goalStatement adalah data yang dibutuhkan satu statement.
+type goalStatement struct {
+	progress      *service.GoalProgress
+	contributions []contributionBalance
+	months        []monthlyContribution
+	generatedAt   time.Time
+}
+
+// contributionBalance adalah satu kontribusi beserta total terkumpul
+// sampai kontribusi itu.
+type contributionBalance struct {
+	contribution *models.GoalContribution
+	balance      decimal.Decimal
+}
+
+// monthlyContribution adalah total kontribusi dalam satu bulan.
+type monthlyContribution struct {
+	month time.Time // Tanggal 1 bulan tersebut
+	total decimal.Decimal
+}
+
+// loadStatement mengambil goal dan seluruh kontribusinya.
+func (e *GoalStatementExporter) loadStatement(ctx context.Context, goalID uuid.UUID) (*goalStatement, error) {
+	goal, err := e.goalRepo.GetByID(ctx, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+
+	contributions, err := listAllContributions(ctx, e.goalRepo, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rows := runningBalance(contributions)
+	return &goalStatement{
+		progress:      service.NewGoalProgress(goal, now),
+		contributions: rows,
+		months:        monthlyContributions(rows, now, goalChartMonths),
+		generatedAt:   now,
+	}, nil
+}
+
+// listAllContributions membaca semua halaman kontribusi goal.
+func listAllContributions(ctx context.Context, repo repository.GoalRepository, goalID uuid.UUID) ([]*models.GoalContribution, error) {
+	var all []*models.GoalContribution
+	for offset := 0; ; offset += exportPageSize {
+		page, err := repo.GetContributions(ctx, goalID, repository.ListParams{Limit: exportPageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contributions: %w", err)
+		}
+		all = append(all, page...)
+
+		if len(page) < exportPageSize {
+			return all, nil
+		}
+	}
+}
+
+// runningBalance mengurutkan kontribusi dari yang terlama dan menghitung
+// total terkumpul setelah setiap kontribusi.
+func runningBalance(contributions []*models.GoalContribution) []contributionBalance {
+	sorted := append([]*models.GoalContribution(nil), contributions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	rows := make([]contributionBalance, len(sorted))
+	balance := decimal.Zero
+	for i, c := range sorted {
+		balance = balance.Add(c.Amount)
+		rows[i] = contributionBalance{contribution: c, balance: balance}
+	}
+	return rows
+}
+
+// monthlyContributions menjumlahkan kontribusi per bulan untuk n bulan
+// terakhir sampai bulan now. Bulan tanpa kontribusi tetap ada (total nol)
+// supaya chart tidak melompati bulan. Kosong jika tidak ada kontribusi.
+func monthlyContributions(rows []contributionBalance, now time.Time, n int) []monthlyContribution {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	last := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	first := last.AddDate(0, -(n - 1), 0)
+
+	months := make([]monthlyContribution, n)
+	for i := range months {
+		months[i].month = first.AddDate(0, i, 0)
+	}
+	for _, row := range rows {
+		created := row.contribution.CreatedAt.In(now.Location())
+		idx := (created.Year()-first.Year())*12 + int(created.Month()-first.Month())
+		if idx >= 0 && idx < n {
+			months[idx].total = months[idx].total.Add(row.contribution.Amount)
+		}
+	}
+	return months
+}
+
+// ToPDFWriter menulis statement goal (PDF) ke w.
+func (e *GoalStatementExporter) ToPDFWriter(ctx context.Context, w io.Writer, goalID uuid.UUID) error {
+	st, err := e.loadStatement(ctx, goalID)
+	if err != nil {
+		return err
+	}
+	p := st.progress
+	goal := p.Goal
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFillColor(79, 70, 229)
+	pdf.Rect(0, 0, 210, 35, "F")
+
+	pdf.SetFont("Arial", "B", 20)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetY(12)
+	pdf.CellFormat(0, 10, "GOAL STATEMENT", "", 1, "C", false, 0, "")
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 6, generatedLabel(st.generatedAt, e.locale), "", 1, "C", false, 0, "")
+
+	// Goal
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetY(42)
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(0, 9, goal.Name, "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	if goal.Description != "" {
+		pdf.MultiCell(0, 5, goal.Description, "", "L", false)
+	}
+	deadline := "none"
+	if goal.Deadline != nil {
+		deadline = utils.FormatLongDate(*goal.Deadline, e.locale)
+		if p.DaysUntilDeadline >= 0 {
+			deadline += fmt.Sprintf(" (%d days left)", p.DaysUntilDeadline)
+		} else {
+			deadline += " (passed)"
+		}
+	}
+	pdf.CellFormat(0, 6, fmt.Sprintf("Status: %s   Deadline: %s", goal.Status, deadline), "", 1, "L", false, 0, "")
+
+	// Progress bar
+	pdf.Ln(3)
+	y := pdf.GetY()
+	pdf.SetFillColor(226, 232, 240)
+	pdf.RoundedRect(15, y, 180, 8, 2, "1234", "F")
+	if fill := 180 * min(p.Progress, 100) / 100; fill > 0 {
+		pdf.SetFillColor(16, 185, 129)
+		pdf.RoundedRect(15, y, fill, 8, 2, "1234", "F")
+	}
+	pdf.SetY(y + 9)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("%.1f%% of %s", p.Progress, pdfMoney(goal.TargetAmount, e.currency)), "", 1, "C", false, 0, "")
+
+	// Angka & proyeksi
+	pdf.Ln(2)
+	track := "On track"
+	switch {
+	case p.IsCompleted:
+		track = "Reached"
+	case goal.Deadline == nil:
+		track = "No deadline"
+	case !p.OnTrack:
+		track = "Behind schedule"
+	}
+	perMonth := "-"
+	if p.RequiredPerMonth.IsPositive() {
+		perMonth = pdfMoney(p.RequiredPerMonth, e.currency)
+	}
+	stats := [][2]string{
+		{"Saved", pdfMoney(goal.CurrentAmount, e.currency)},
+		{"Remaining", pdfMoney(p.Remaining, e.currency)},
+		{"Needed / month", perMonth},
+		{"Status", track},
+	}
+	y = pdf.GetY()
+	pdf.SetFillColor(248, 250, 252)
+	pdf.RoundedRect(15, y, 180, 16, 3, "1234", "F")
+	for i, stat := range stats {
+		x := 15 + float64(i)*45
+		pdf.SetXY(x, y+2)
+		pdf.SetFont("Arial", "", 8)
+		pdf.SetTextColor(100, 116, 139)
+		pdf.CellFormat(45, 5, stat[0], "", 0, "C", false, 0, "")
+		pdf.SetXY(x, y+8)
+		pdf.SetFont("Arial", "B", 10)
+		pdf.SetTextColor(0, 0, 0)
+		if stat[1] == "Behind schedule" {
+			pdf.SetTextColor(220, 38, 38)
+		}
+		pdf.CellFormat(45, 6, stat[1], "", 0, "C", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetY(y + 22)
+
+	if len(st.contributions) == 0 {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(0, 10, "CONTRIBUTIONS", "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		pdf.CellFormat(0, 7, "No contributions yet.", "", 1, "L", false, 0, "")
+		return pdf.Output(w)
+	}
+
+	// Chart kontribusi per bulan
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 10, fmt.Sprintf("MONTHLY CONTRIBUTIONS (LAST %d MONTHS)", goalChartMonths), "", 1, "L", false, 0, "")
+	bars := make([]chartBar, len(st.months))
+	for i, m := range st.months {
+		value, _ := m.total.Float64()
+		bars[i] = chartBar{label: m.month.Format("Jan 06"), value: value}
+	}
+	y = pdf.GetY()
+	pdfBarChart(pdf, 15, y, 180, 45, bars)
+	pdf.SetY(y + 52)
+
+	// Tabel kontribusi
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 10, "CONTRIBUTIONS", "", 1, "L", false, 0, "")
+
+	colWidths := []float64{30, 40, 45, 65}
+	headers := []string{"Date", "Amount", "Running Balance", "Note"}
+	pdf.SetFillColor(79, 70, 229)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Arial", "B", 10)
+	for i, h := range headers {
+		pdf.CellFormat(colWidths[i], 8, h, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Arial", "", 9)
+	for i, row := range st.contributions {
+		if i%2 == 0 {
+			pdf.SetFillColor(248, 250, 252)
+		} else {
+			pdf.SetFillColor(255, 255, 255)
+		}
+
+		note := row.contribution.Note
+		if len(note) > 35 {
+			note = note[:32] + "..."
+		}
+		pdf.CellFormat(colWidths[0], 7, row.contribution.CreatedAt.Format("02-Jan-06"), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colWidths[1], 7, pdfMoney(row.contribution.Amount, e.currency), "1", 0, "R", true, 0, "")
+		pdf.CellFormat(colWidths[2], 7, pdfMoney(row.balance, e.currency), "1", 0, "R", true, 0, "")
+		pdf.CellFormat(colWidths[3], 7, note, "1", 0, "L", true, 0, "")
+		pdf.Ln(-1)
+
+		if pdf.GetY() > 270 {
+			pdf.AddPage()
+			pdf.SetY(20)
+		}
+	}
+
+	pdf.SetY(-20)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.SetTextColor(150, 150, 150)
+	pdf.CellFormat(0, 10, fmt.Sprintf("Wallet Twin - %d contributions", len(st.contributions)), "", 0, "C", false, 0, "")
+
+	return pdf.Output(w)
+}
+
+// ToExcelWriter menulis statement goal (Excel) ke w: sheet "Goal" berisi
+// ringkasan dan kontribusi, sheet "Monthly" berisi total per bulan
+// beserta column chart.
+func (e *GoalStatementExporter) ToExcelWriter(ctx context.Context, w io.Writer, goalID uuid.UUID) error {
+	st, err := e.loadStatement(ctx, goalID)
+	if err != nil {
+		return err
+	}
+	p := st.progress
+	goal := p.Goal
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyleID, _ := f.NewStyle(headerStyle)
+	titleStyleID, _ := f.NewStyle(titleStyle)
+	money := newMoneyStyles(f)
+	moneyStyleID := money.get(moneyStyle, e.currency)
+
+	sheet := "Goal"
+	f.SetSheetName("Sheet1", sheet)
+	f.SetCellValue(sheet, "A1", goal.Name)
+	f.SetCellStyle(sheet, "A1", "A1", titleStyleID)
+
+	deadline := "none"
+	if goal.Deadline != nil {
+		deadline = goal.Deadline.Format("2006-01-02")
+	}
+	track := "on track"
+	switch {
+	case p.IsCompleted:
+		track = "reached"
+	case goal.Deadline == nil:
+		track = "no deadline"
+	case !p.OnTrack:
+		track = "behind schedule"
+	}
+
+	summary := []struct {
+		label string
+		value interface{}
+		money bool
+	}{
+		{"Status", string(goal.Status), false},
+		{"Target", excelNumber(goal.TargetAmount), true},
+		{"Saved", excelNumber(goal.CurrentAmount), true},
+		{"Remaining", excelNumber(p.Remaining), true},
+		{"Progress", fmt.Sprintf("%.1f%%", p.Progress), false},
+		{"Deadline", deadline, false},
+		{"Needed / month", excelNumber(p.RequiredPerMonth), true},
+		{"Track", track, false},
+	}
+	for i, s := range summary {
+		row := i + 3
+		f.SetCellValue(sheet, fmt.Sprintf("A%d", row), s.label)
+		f.SetCellValue(sheet, fmt.Sprintf("B%d", row), s.value)
+		if s.money {
+			f.SetCellStyle(sheet, fmt.Sprintf("B%d", row), fmt.Sprintf("B%d", row), moneyStyleID)
+		}
+	}
+
+	start := len(summary) + 4
+	headers := []string{"Date", "Amount", "Running Balance", "Note"}
+	for i, h := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, start)
+		f.SetCellValue(sheet, cell, h)
+		f.SetCellStyle(sheet, cell, cell, headerStyleID)
+	}
+	if len(st.contributions) == 0 {
+		f.SetCellValue(sheet, fmt.Sprintf("A%d", start+1), "No contributions yet.")
+	}
+	for i, row := range st.contributions {
+		r := start + 1 + i
+		f.SetCellValue(sheet, fmt.Sprintf("A%d", r), row.contribution.CreatedAt.Format("2006-01-02"))
+		f.SetCellValue(sheet, fmt.Sprintf("B%d", r), excelNumber(row.contribution.Amount))
+		f.SetCellValue(sheet, fmt.Sprintf("C%d", r), excelNumber(row.balance))
+		f.SetCellValue(sheet, fmt.Sprintf("D%d", r), row.contribution.Note)
+		f.SetCellStyle(sheet, fmt.Sprintf("B%d", r), fmt.Sprintf("C%d", r), moneyStyleID)
+	}
+	f.SetColWidth(sheet, "A", "A", 18)
+	f.SetColWidth(sheet, "B", "C", 20)
+	f.SetColWidth(sheet, "D", "D", 40)
+
+	if len(st.months) > 0 {
+		monthly := "Monthly"
+		f.NewSheet(monthly)
+		f.SetCellValue(monthly, "A1", "Month")
+		f.SetCellValue(monthly, "B1", "Contributed")
+		f.SetCellStyle(monthly, "A1", "B1", headerStyleID)
+		for i, m := range st.months {
+			f.SetCellValue(monthly, fmt.Sprintf("A%d", i+2), m.month.Format("Jan 2006"))
+			f.SetCellValue(monthly, fmt.Sprintf("B%d", i+2), excelNumber(m.total))
+		}
+		f.SetCellStyle(monthly, "B2", fmt.Sprintf("B%d", len(st.months)+1), moneyStyleID)
+		f.SetColWidth(monthly, "A", "B", 16)
+
+		last := len(st.months) + 1
+		if err := f.AddChart(monthly, "D2", &excelize.Chart{
+			Type: excelize.Col,
+			Series: []excelize.ChartSeries{{
+				Name:       "Monthly!$B$1",
+				Categories: fmt.Sprintf("Monthly!$A$2:$A$%d", last),
+				Values:     fmt.Sprintf("Monthly!$B$2:$B$%d", last),
+			}},
+			Title:  []excelize.RichTextRun{{Text: "Monthly contributions"}},
+			Legend: excelize.ChartLegend{Position: "none"},
+		}); err != nil {
+			return fmt.Errorf("failed to add chart: %w", err)
+		}
+	}
+
+	_, err = f.WriteTo(w)
+	return err
+}
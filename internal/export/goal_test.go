@@ -0,0 +1,140 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+)
+
+func contributionAt(amount int64, at time.Time) *models.GoalContribution {
+	c := models.NewContribution(models.NewID(), decimal.NewFromInt(amount))
+	c.CreatedAt = at
+	return c
+}
+
+func TestRunningBalance(t *testing.T) {
+	jan := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	rows := runningBalance([]*models.GoalContribution{
+		contributionAt(300, jan.AddDate(0, 2, 0)),
+		contributionAt(100, jan),
+		contributionAt(200, jan.AddDate(0, 1, 0)),
+	})
+
+	want := []int64{100, 300, 600}
+	if len(rows) != len(want) {
+		t.Fatalf("len(rows) = %d, want %d", len(rows), len(want))
+	}
+	for i, row := range rows {
+		if !row.balance.Equal(decimal.NewFromInt(want[i])) {
+			t.Errorf("rows[%d].balance = %s, want %d", i, row.balance, want[i])
+		}
+	}
+}
+
+func TestMonthlyContributions(t *testing.T) {
+	now := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+	rows := runningBalance([]*models.GoalContribution{
+		contributionAt(100, time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)),
+		contributionAt(50, time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC)),
+		contributionAt(200, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)),
+		contributionAt(999, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)), // di luar window
+	})
+
+	months := monthlyContributions(rows, now, 3)
+	want := []struct {
+		month time.Month
+		total int64
+	}{{time.April, 150}, {time.May, 0}, {time.June, 200}}
+	if len(months) != len(want) {
+		t.Fatalf("len(months) = %d, want %d", len(months), len(want))
+	}
+	for i, m := range months {
+		if m.month.Month() != want[i].month || !m.total.Equal(decimal.NewFromInt(want[i].total)) {
+			t.Errorf("months[%d] = %s %s, want %s %d", i, m.month.Format("2006-01"), m.total, want[i].month, want[i].total)
+		}
+	}
+
+	if got := monthlyContributions(nil, now, 3); got != nil {
+		t.Errorf("monthlyContributions(nil) = %v, want nil", got)
+	}
+}
+
+func newTestGoalStatement(t *testing.T, contributions ...int64) (*GoalStatementExporter, *models.Goal) {
+	t.Helper()
+	ctx := context.Background()
+	goalRepo := memory.NewGoalRepository(memory.NewStore())
+
+	goal := models.NewGoal("New Laptop", decimal.NewFromInt(20000000))
+	deadline := time.Now().AddDate(0, 6, 0)
+	goal.Deadline = &deadline
+	if err := goalRepo.Create(ctx, goal); err != nil {
+		t.Fatalf("create goal: %v", err)
+	}
+	for i, amount := range contributions {
+		c := contributionAt(amount, time.Now().AddDate(0, -i, 0))
+		c.GoalID = goal.ID
+		if err := goalRepo.AddContribution(ctx, c); err != nil {
+			t.Fatalf("add contribution: %v", err)
+		}
+	}
+	goal, err := goalRepo.GetByID(ctx, goal.ID)
+	if err != nil {
+		t.Fatalf("get goal: %v", err)
+	}
+
+	return NewGoalStatementExporter(goalRepo, "IDR"), goal
+}
+
+func TestGoalStatementExporter_ToPDFWriter(t *testing.T) {
+	for name, contributions := range map[string][]int64{
+		"with contributions": {1500000, 2000000, 500000},
+		"no contributions":   nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			exporter, goal := newTestGoalStatement(t, contributions...)
+
+			var buf bytes.Buffer
+			if err := exporter.ToPDFWriter(context.Background(), &buf, goal.ID); err != nil {
+				t.Fatalf("ToPDFWriter() error = %v", err)
+			}
+			if !strings.HasPrefix(buf.String(), "%PDF") {
+				t.Errorf("output does not start with %%PDF (%d bytes)", buf.Len())
+			}
+		})
+	}
+}
+
+func TestGoalStatementExporter_ToExcelWriter(t *testing.T) {
+	exporter, goal := newTestGoalStatement(t, 1500000, 2000000)
+
+	var buf bytes.Buffer
+	if err := exporter.ToExcelWriter(context.Background(), &buf, goal.ID); err != nil {
+		t.Fatalf("ToExcelWriter() error = %v", err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("open workbook: %v", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) != 2 || sheets[0] != "Goal" || sheets[1] != "Monthly" {
+		t.Fatalf("sheets = %v, want [Goal Monthly]", sheets)
+	}
+	rows, err := f.GetRows("Monthly")
+	if err != nil {
+		t.Fatalf("read Monthly sheet: %v", err)
+	}
+	if len(rows) != goalChartMonths+1 {
+		t.Errorf("Monthly rows = %d, want header + %d months", len(rows), goalChartMonths)
+	}
+}
@@ -0,0 +1,189 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
+)
+
+const (
+	// icalProdID mengidentifikasi aplikasi pembuat file (RFC 5545 3.7.3).
+	icalProdID = "-//Wallet Twin//Recurring Schedule//EN"
+
+	// icalLineLimit adalah panjang maksimum satu baris dalam octet, tanpa
+	// CRLF (RFC 5545 3.1). Baris yang lebih panjang di-fold.
+	icalLineLimit = 75
+
+	// icalDateLayout adalah format value DATE ("20250115").
+	icalDateLayout = "20060102"
+)
+
+// icalTextEscaper meng-escape value TEXT (RFC 5545 3.3.11).
+var icalTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// icalFrequencies memetakan frequency recurring ke FREQ di RRULE.
+var icalFrequencies = map[models.RecurringFrequency]string{
+	models.RecurringDaily:   "DAILY",
+	models.RecurringWeekly:  "WEEKLY",
+	models.RecurringMonthly: "MONTHLY",
+	models.RecurringYearly:  "YEARLY",
+}
+
+// WriteRecurringICal menulis jadwal recurring (hasil
+// RecurringService.GenerateSchedule) sebagai file iCalendar (RFC 5545):
+// satu VEVENT all-day per jatuh tempo, contoh summary
+// "💸 Netflix — Rp 186.000".
+//
+// UID dibentuk dari recurring ID + tanggal, jadi import ulang file baru
+// meng-update event yang sama di kalender, bukan menduplikasinya.
+// stamp dipakai sebagai DTSTAMP semua event.
+func WriteRecurringICal(w io.Writer, schedule []*service.ScheduledOccurrence, refs *service.RefData, currency string, stamp time.Time) error {
+	ic := &icalWriter{w: w}
+	ic.begin(stamp)
+	for _, o := range schedule {
+		ic.event(icalEvent{
+			uid:         fmt.Sprintf("%s-%s@wallet-twin", o.RecurringID, o.ScheduledDate.Format(icalDateLayout)),
+			summary:     icalSummary(o.Type, o.Description, o.Amount, refs.WalletCurrency(o.WalletID, currency)),
+			description: "Wallet: " + refs.WalletName(o.WalletID),
+			date:        o.ScheduledDate,
+		})
+	}
+	ic.end()
+	return ic.err
+}
+
+// WriteRecurringICalRRule menulis satu VEVENT per recurring dengan RRULE
+// (FREQ dari frequency, UNTIL dari EndDate), mulai dari NextDue. Jadwal
+// di-expand oleh aplikasi kalender, jadi tidak ada batas window.
+//
+// Recurring yang EndDate-nya sudah lewat sebelum NextDue dilewati.
+// Frequency yang tidak dikenal ditulis sebagai satu event tanpa RRULE.
+func WriteRecurringICalRRule(w io.Writer, recurrings []*models.RecurringTransaction, refs *service.RefData, currency string, stamp time.Time) error {
+	ic := &icalWriter{w: w}
+	ic.begin(stamp)
+	for _, r := range recurrings {
+		if r.EndDate != nil && r.EndDate.Before(r.NextDue) {
+			continue
+		}
+
+		rrule := ""
+		if freq, ok := icalFrequencies[r.Frequency]; ok {
+			rrule = "FREQ=" + freq
+			if r.EndDate != nil {
+				rrule += ";UNTIL=" + r.EndDate.Format(icalDateLayout)
+			}
+		}
+
+		ic.event(icalEvent{
+			uid:         fmt.Sprintf("%s@wallet-twin", r.ID),
+			summary:     icalSummary(r.Type, r.Description, r.Amount, refs.WalletCurrency(r.WalletID, currency)),
+			description: fmt.Sprintf("Wallet: %s\nEvery: %s", refs.WalletName(r.WalletID), r.Frequency),
+			date:        r.NextDue,
+			rrule:       rrule,
+		})
+	}
+	ic.end()
+	return ic.err
+}
+
+// icalSummary membuat judul event: icon type, description, lalu nominal.
+func icalSummary(txType models.TransactionType, description string, amount decimal.Decimal, currency string) string {
+	icon := "💸"
+	if txType == models.TransactionTypeIncome {
+		icon = "💰"
+	}
+	if description == "" {
+		description = "Recurring " + string(txType)
+	}
+	return fmt.Sprintf("%s %s — %s", icon, description, utils.FormatMoney(amount, currency))
+}
+
+// icalEvent adalah isi satu VEVENT all-day.
+type icalEvent struct {
+	uid         string
+	summary     string
+	description string
+	date        time.Time
+	rrule       string // Kosong berarti event tunggal
+}
+
+// icalWriter menulis content line iCalendar (CRLF, fold 75 octet) dan
+// menyimpan error pertama supaya caller cukup mengecek err di akhir.
+type icalWriter struct {
+	w     io.Writer
+	err   error
+	stamp string
+}
+
+func (ic *icalWriter) begin(stamp time.Time) {
+	ic.stamp = stamp.UTC().Format("20060102T150405Z")
+	ic.line("BEGIN:VCALENDAR")
+	ic.line("VERSION:2.0")
+	ic.line("PRODID:" + icalProdID)
+	ic.line("CALSCALE:GREGORIAN")
+	ic.line("METHOD:PUBLISH")
+}
+
+func (ic *icalWriter) event(e icalEvent) {
+	// Tanggal disimpan sebagai DATE; pakai tanggal kalender apa adanya
+	start := time.Date(e.date.Year(), e.date.Month(), e.date.Day(), 0, 0, 0, 0, time.UTC)
+
+	ic.line("BEGIN:VEVENT")
+	ic.line("UID:" + e.uid)
+	ic.line("DTSTAMP:" + ic.stamp)
+	ic.line("DTSTART;VALUE=DATE:" + start.Format(icalDateLayout))
+	ic.line("DTEND;VALUE=DATE:" + start.AddDate(0, 0, 1).Format(icalDateLayout))
+	if e.rrule != "" {
+		ic.line("RRULE:" + e.rrule)
+	}
+	ic.line("SUMMARY:" + icalTextEscaper.Replace(e.summary))
+	ic.line("DESCRIPTION:" + icalTextEscaper.Replace(e.description))
+	ic.line("TRANSP:TRANSPARENT")
+	ic.line("END:VEVENT")
+}
+
+func (ic *icalWriter) end() {
+	ic.line("END:VCALENDAR")
+}
+
+func (ic *icalWriter) line(s string) {
+	if ic.err != nil {
+		return
+	}
+	_, ic.err = io.WriteString(ic.w, foldICalLine(s))
+}
+
+// foldICalLine memecah content line menjadi baris fisik maksimal 75
+// octet; baris lanjutan diawali satu spasi. Potongan tidak pernah
+// membelah karakter UTF-8 (emoji di summary).
+func foldICalLine(s string) string {
+	var b strings.Builder
+	limit := icalLineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = icalLineLimit - 1 // Spasi di awal ikut dihitung
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	return b.String()
+}
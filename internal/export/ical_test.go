@@ -0,0 +1,197 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// icalFixture adalah wallet dan recurring dengan ID tetap supaya output
+// bisa dibandingkan dengan golden file.
+func icalFixture() (*service.RefData, []*models.RecurringTransaction) {
+	bca := models.NewWallet("BCA", models.WalletTypeBank)
+	bca.ID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	wise := models.NewWallet("Wise", models.WalletTypeBank)
+	wise.ID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	wise.Currency = "USD"
+
+	netflix := models.NewRecurringTransaction(bca.ID, models.TransactionTypeExpense, decimal.NewFromInt(186000), models.RecurringMonthly,
+		time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC))
+	netflix.ID = uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")
+	netflix.Description = "Netflix"
+	end := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	netflix.EndDate = &end
+
+	hosting := models.NewRecurringTransaction(wise.ID, models.TransactionTypeExpense, decimal.RequireFromString("1250.5"), models.RecurringYearly,
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	hosting.ID = uuid.MustParse("bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb")
+	hosting.Description = "Hosting; domains, mail and a rather long description to force folding"
+
+	salary := models.NewRecurringTransaction(bca.ID, models.TransactionTypeIncome, decimal.NewFromInt(10000000), models.RecurringMonthly,
+		time.Date(2025, 1, 25, 0, 0, 0, 0, time.UTC))
+	salary.ID = uuid.MustParse("cccccccc-cccc-cccc-cccc-cccccccccccc")
+	salary.Description = "Salary"
+
+	return service.NewRefData([]*models.Wallet{bca, wise}, nil), []*models.RecurringTransaction{netflix, hosting, salary}
+}
+
+var icalStamp = time.Date(2025, 1, 10, 8, 30, 0, 0, time.UTC)
+
+func TestWriteRecurringICal(t *testing.T) {
+	refs, recurrings := icalFixture()
+
+	var schedule []*service.ScheduledOccurrence
+	for _, r := range recurrings {
+		schedule = append(schedule, &service.ScheduledOccurrence{
+			RecurringID:   r.ID,
+			WalletID:      r.WalletID,
+			Description:   r.Description,
+			Type:          r.Type,
+			Amount:        r.Amount,
+			ScheduledDate: r.NextDue,
+		})
+	}
+	// Jatuh tempo berikutnya Netflix: UID berbeda karena tanggalnya beda
+	next := *schedule[0]
+	next.ScheduledDate = next.ScheduledDate.AddDate(0, 1, 0)
+	schedule = append(schedule, &next)
+
+	var buf bytes.Buffer
+	if err := WriteRecurringICal(&buf, schedule, refs, "IDR", icalStamp); err != nil {
+		t.Fatalf("WriteRecurringICal() error = %v", err)
+	}
+
+	assertGolden(t, "recurring.ics.golden", buf.Bytes())
+	events := parseICal(t, buf.Bytes())
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+	if got := events[0]["SUMMARY"]; got != `💸 Netflix — Rp 186.000` {
+		t.Errorf("SUMMARY = %q", got)
+	}
+	if got := events[1]["SUMMARY"]; !strings.Contains(got, `Hosting\; domains\, mail`) || !strings.HasSuffix(got, `$1\,250.50`) {
+		t.Errorf("SUMMARY = %q, want escaped ; and , and the wallet's currency", got)
+	}
+	if events[0]["UID"] == events[3]["UID"] {
+		t.Errorf("occurrences on different dates share UID %q", events[0]["UID"])
+	}
+	if got := events[3]["DTSTART;VALUE=DATE"]; got != "20250215" {
+		t.Errorf("DTSTART = %q, want 20250215", got)
+	}
+}
+
+func TestWriteRecurringICalRRule(t *testing.T) {
+	refs, recurrings := icalFixture()
+
+	// Sudah berakhir sebelum jatuh tempo berikutnya: dilewati
+	ended := models.NewRecurringTransaction(recurrings[0].WalletID, models.TransactionTypeExpense, decimal.NewFromInt(1), models.RecurringWeekly,
+		time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	endDate := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	ended.EndDate = &endDate
+
+	var buf bytes.Buffer
+	if err := WriteRecurringICalRRule(&buf, append(recurrings, ended), refs, "IDR", icalStamp); err != nil {
+		t.Fatalf("WriteRecurringICalRRule() error = %v", err)
+	}
+
+	assertGolden(t, "recurring_rrule.ics.golden", buf.Bytes())
+	events := parseICal(t, buf.Bytes())
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3 (ended recurring skipped)", len(events))
+	}
+	if got := events[0]["RRULE"]; got != "FREQ=MONTHLY;UNTIL=20250615" {
+		t.Errorf("RRULE = %q, want FREQ=MONTHLY;UNTIL=20250615", got)
+	}
+	if got := events[1]["RRULE"]; got != "FREQ=YEARLY" {
+		t.Errorf("RRULE = %q, want FREQ=YEARLY", got)
+	}
+	if got := events[0]["UID"]; got != "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa@wallet-twin" {
+		t.Errorf("UID = %q, want the recurring ID", got)
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("💸", 40)
+	folded := foldICalLine(line)
+
+	for _, physical := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if len(physical) > icalLineLimit {
+			t.Errorf("line %q is %d octets, want <= %d", physical, len(physical), icalLineLimit)
+		}
+		if !utf8.ValidString(physical) {
+			t.Errorf("line %q splits a UTF-8 character", physical)
+		}
+	}
+	if got := strings.ReplaceAll(folded, "\r\n ", ""); got != line+"\r\n" {
+		t.Errorf("unfolded = %q, want the original line", got)
+	}
+}
+
+// parseICal adalah parser minimal RFC 5545 untuk test: mengecek CRLF,
+// panjang baris, pasangan BEGIN/END dan property wajib VEVENT, lalu
+// mengembalikan property tiap event (nama beserta parameter sebagai key).
+func parseICal(t *testing.T, data []byte) []map[string]string {
+	t.Helper()
+
+	s := string(data)
+	if !strings.HasSuffix(s, "\r\n") {
+		t.Fatalf("calendar does not end with CRLF")
+	}
+	for _, physical := range strings.Split(strings.TrimSuffix(s, "\r\n"), "\r\n") {
+		if len(physical) > icalLineLimit {
+			t.Errorf("line %q is %d octets, want <= %d", physical, len(physical), icalLineLimit)
+		}
+		if strings.Contains(physical, "\n") {
+			t.Errorf("line %q contains a bare LF", physical)
+		}
+	}
+
+	var (
+		events []map[string]string
+		stack  []string
+		event  map[string]string
+	)
+	for _, line := range strings.Split(strings.TrimSuffix(strings.ReplaceAll(s, "\r\n ", ""), "\r\n"), "\r\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			t.Fatalf("line %q has no value", line)
+		}
+		switch name {
+		case "BEGIN":
+			stack = append(stack, value)
+			if value == "VEVENT" {
+				event = map[string]string{}
+			}
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1] != value {
+				t.Fatalf("END:%s does not match BEGIN %v", value, stack)
+			}
+			stack = stack[:len(stack)-1]
+			if value == "VEVENT" {
+				for _, required := range []string{"UID", "DTSTAMP", "DTSTART;VALUE=DATE"} {
+					if event[required] == "" {
+						t.Errorf("event %v is missing %s", event, required)
+					}
+				}
+				events = append(events, event)
+				event = nil
+			}
+		default:
+			if event != nil {
+				event[name] = value
+			}
+		}
+	}
+	if len(stack) != 0 {
+		t.Fatalf("unclosed components %v", stack)
+	}
+	return events
+}
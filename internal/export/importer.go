@@ -4,10 +4,11 @@ package export
 import (
 	"context"
 	"encoding/csv"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +17,8 @@ import (
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
 // Importer handles data import operations.
@@ -25,6 +28,15 @@ type Importer struct {
 	categoryRepo    repository.CategoryRepository
 	goalRepo        repository.GoalRepository
 	txManager       repository.TransactionManager
+
+	// conversionRate dipakai untuk row CSV yang currency-nya berbeda
+	// dengan wallet (lihat SetConversionRate). Zero berarti row ditolak.
+	conversionRate decimal.Decimal
+
+	// checkpointFile diisi lewat WithCheckpointFile; kosong berarti
+	// TransactionsFromCSV tidak menyimpan checkpoint.
+	checkpointFile  string
+	checkpointEvery int
 }
 
 // NewImporter creates a new Importer.
@@ -34,40 +46,242 @@ func NewImporter(
 	categoryRepo repository.CategoryRepository,
 	goalRepo repository.GoalRepository,
 	txManager repository.TransactionManager,
+	opts ...ImporterOption,
 ) *Importer {
-	return &Importer{
+	i := &Importer{
 		walletRepo:      walletRepo,
 		transactionRepo: transactionRepo,
 		categoryRepo:    categoryRepo,
 		goalRepo:        goalRepo,
 		txManager:       txManager,
+		checkpointEvery: checkpointEvery,
 	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// SetConversionRate mengatur kurs untuk row CSV yang kolom Currency-nya
+// berbeda dengan currency wallet: amount dikalikan rate lalu dibulatkan
+// 2 desimal. Tanpa rate (zero), row seperti itu ditolak.
+//
+//	importer.SetConversionRate(decimal.NewFromInt(16250)) // USD → IDR
+func (i *Importer) SetConversionRate(rate decimal.Decimal) {
+	i.conversionRate = rate
 }
 
 // ImportResult contains the result of an import operation.
 type ImportResult struct {
-	TotalRows     int
-	SuccessCount  int
-	SkippedCount  int
-	Errors        []string
+	TotalRows    int
+	SuccessCount int
+	SkippedCount int
+	Errors       []string
+
+	// AlreadyImported adalah row yang sudah di-import oleh run sebelumnya
+	// (lihat WithCheckpointFile), tidak dihitung di SuccessCount.
+	AlreadyImported int
+
+	// BackupVersion, BackupSchemaVersion dan ExportedAt diisi dari
+	// backup JSON (FromJSON); kosong untuk import CSV.
+	BackupVersion       string
+	BackupSchemaVersion int
+	ExportedAt          time.Time
+
+	// Manifest diisi untuk backup incremental; ReplacedCount adalah row
+	// yang sudah ada dan diganti isi backup (termasuk di SuccessCount).
+	Manifest      *BackupManifest
+	ReplacedCount int
 }
 
 // ==================== CSV Import ====================
 
 // TransactionsFromCSV imports transactions from a CSV file.
+//
+// Kategori bisa diisi lewat kolom "category id" (UUID) atau
+// "category name" (case-insensitive). Row dengan nama kategori yang
+// tidak ditemukan di-skip dan dicatat di ImportResult.Errors.
+//
+// Delimiter (`,`, `;` atau tab) dideteksi dari header lewat
+// utils.DetectCSVDelimiter.
+//
+// Kolom "currency" opsional. Jika diisi dan berbeda dengan currency
+// wallet, row ditolak kecuali kurs diset lewat SetConversionRate.
+//
+// Transaksi disimpan langsung lewat repository, jadi saldo wallet
+// TIDAK berubah. Pakai TransactionsFromCSVToWallet jika saldo harus ikut.
+//
+// Dengan WithCheckpointFile, row yang sudah diproses run sebelumnya
+// di-skip (reader di-seek ke offset checkpoint) dan dihitung di
+// ImportResult.AlreadyImported. Setiap transaksi diberi idempotency key
+// per row, jadi row setelah checkpoint terakhir yang sempat tersimpan
+// sebelum crash juga tidak dobel. Jika ctx dibatalkan, checkpoint
+// disimpan lalu import berhenti dengan error.
 func (i *Importer) TransactionsFromCSV(ctx context.Context, filename string) (*ImportResult, error) {
+	checkpoint, err := i.openCheckpoint(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, result, err := i.readTransactionsCSV(ctx, filename, nil, checkpoint.resumePoint())
+	if err != nil {
+		return nil, err
+	}
+
+	var last *csvTransactionRow
+	for idx := range rows {
+		row := &rows[idx]
+
+		if err := ctx.Err(); err != nil {
+			if checkpoint != nil && last != nil {
+				if saveErr := checkpoint.save(last.number, last.offset); saveErr != nil {
+					return result, saveErr
+				}
+			}
+			return result, fmt.Errorf("import interrupted at row %d: %w", row.number, err)
+		}
+
+		if checkpoint != nil {
+			row.tx.IdempotencyKey = checkpoint.idempotencyKey(row.number)
+		}
+
+		// Create transaction (without balance update for import)
+		err := i.transactionRepo.Create(ctx, row.tx)
+		switch {
+		case errors.Is(err, repository.ErrDuplicateKey) && row.tx.IdempotencyKey != "":
+			result.AlreadyImported++
+		case err != nil:
+			result.Errors = append(result.Errors, fmt.Sprintf("row %d: %v", row.number, err))
+			result.SkippedCount++
+		default:
+			result.SuccessCount++
+		}
+
+		last = row
+		if checkpoint != nil && row.number-checkpoint.state.Row >= i.checkpointEvery {
+			if err := checkpoint.save(row.number, row.offset); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if checkpoint != nil {
+		if err := checkpoint.remove(); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// TransactionsFromCSVToWallet mengimport semua row CSV ke satu wallet
+// lewat TransactionService, sehingga saldo wallet ikut ter-update.
+//
+// Kolom "wallet id" tidak wajib dan diabaikan jika ada. Row diproses
+// urut tanggal (mutasi bank biasanya terbaru di atas), supaya expense
+// tidak ditolak karena income sebelumnya belum masuk. Row yang tidak
+// valid atau ditolak service di-skip dan dicatat di ImportResult.Errors.
+//
+// Semua row dicoba sekaligus lewat BulkCreate (satu update saldo untuk
+// seluruh file). Jika ada row yang ditolak validasi
+// (service.ErrBulkInputInvalid), row diproses ulang satu per satu lewat
+// Create supaya hanya row itu yang di-skip. Error lain, misalnya dari
+// database, dikembalikan tanpa mengulang row.
+//
+//	result, err := importer.TransactionsFromCSVToWallet(ctx, "bca.csv", wallet.ID, txService)
+func (i *Importer) TransactionsFromCSVToWallet(
+	ctx context.Context,
+	filename string,
+	walletID uuid.UUID,
+	txService *service.TransactionService,
+) (*ImportResult, error) {
+	rows, result, err := i.readTransactionsCSV(ctx, filename, &walletID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rows, func(a, b int) bool {
+		return rows[a].tx.TransactionDate.Before(rows[b].tx.TransactionDate)
+	})
+
+	inputs := make([]service.CreateTransactionInput, len(rows))
+	for idx, row := range rows {
+		inputs[idx] = service.CreateTransactionInput{
+			WalletID:    walletID,
+			CategoryID:  row.tx.CategoryID,
+			Type:        row.tx.Type,
+			Amount:      row.tx.Amount,
+			Description: row.tx.Description,
+			Tags:        row.tx.Tags,
+			Date:        row.tx.TransactionDate,
+			Note:        row.tx.Note,
+
+			OriginalCurrency: row.tx.OriginalCurrency,
+		}
+	}
+
+	if len(inputs) > 0 {
+		_, err := txService.BulkCreate(ctx, inputs)
+		if err == nil {
+			result.SuccessCount += len(inputs)
+			return result, nil
+		}
+		if !errors.Is(err, service.ErrBulkInputInvalid) {
+			return nil, fmt.Errorf("failed to import transactions: %w", err)
+		}
+	}
+
+	for idx, row := range rows {
+		_, err := txService.Create(ctx, inputs[idx])
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("row %d: %v", row.number, err))
+			result.SkippedCount++
+			continue
+		}
+
+		result.SuccessCount++
+	}
+
+	return result, nil
+}
+
+// csvTransactionRow adalah satu row CSV yang sudah di-parse.
+type csvTransactionRow struct {
+	number int   // Nomor row (tanpa header), untuk pesan error
+	offset int64 // Posisi byte setelah row, untuk checkpoint
+	tx     *models.Transaction
+}
+
+// readTransactionsCSV membaca dan mem-parse semua row CSV.
+//
+// Row yang gagal di-parse sudah dicatat di ImportResult (TotalRows,
+// SkippedCount, Errors); caller tinggal menyimpan row yang dikembalikan.
+// Jika walletID tidak nil, kolom "wallet id" tidak wajib dan semua row
+// di-assign ke wallet tersebut. Jika resume tidak nil, row sampai
+// resume.Row di-skip dengan seek ke resume.Offset.
+func (i *Importer) readTransactionsCSV(
+	ctx context.Context,
+	filename string,
+	walletID *uuid.UUID,
+	resume *importCheckpoint,
+) ([]csvTransactionRow, *ImportResult, error) {
 	file, err := os.Open(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
+	comma, err := utils.DetectCSVDelimiter(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to detect delimiter: %w", err)
+	}
 	reader := csv.NewReader(file)
+	reader.Comma = comma
 
 	// Read header
 	header, err := reader.Read()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read header: %w", err)
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
 	}
 
 	// Create column index map
@@ -77,14 +291,36 @@ func (i *Importer) TransactionsFromCSV(ctx context.Context, filename string) (*I
 	}
 
 	// Required columns
-	requiredCols := []string{"date", "type", "amount", "wallet id"}
+	requiredCols := []string{"date", "type", "amount"}
+	if walletID == nil {
+		requiredCols = append(requiredCols, "wallet id")
+	}
 	for _, col := range requiredCols {
 		if _, ok := colIndex[col]; !ok {
-			return nil, fmt.Errorf("missing required column: %s", col)
+			return nil, nil, fmt.Errorf("missing required column: %s", col)
 		}
 	}
 
 	result := &ImportResult{}
+	var rows []csvTransactionRow
+
+	// Lanjutkan dari checkpoint: header sudah dibaca, langsung ke row berikutnya.
+	// InputOffset reader baru dihitung dari posisi seek.
+	var baseOffset int64
+	if resume != nil {
+		baseOffset = resume.Offset
+		if _, err := file.Seek(resume.Offset, io.SeekStart); err != nil {
+			return nil, nil, fmt.Errorf("failed to seek to checkpoint: %w", err)
+		}
+		reader = csv.NewReader(file)
+		reader.Comma = comma
+		result.TotalRows = resume.Row
+		result.AlreadyImported = resume.Row
+	}
+
+	// Cache nama kategori → ID supaya nama yang sama tidak di-query berulang
+	categoryIDs := make(map[string]uuid.UUID)
+	walletCurrencies := make(map[uuid.UUID]string)
 
 	// Read rows
 	for {
@@ -100,27 +336,29 @@ func (i *Importer) TransactionsFromCSV(ctx context.Context, filename string) (*I
 		result.TotalRows++
 
 		// Parse row
-		tx, err := i.parseTransactionRow(row, colIndex)
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("row %d: %v", result.TotalRows, err))
-			result.SkippedCount++
-			continue
+		tx, err := i.parseTransactionRow(ctx, row, colIndex, categoryIDs, walletID)
+		if err == nil {
+			err = i.checkCurrency(ctx, tx, walletCurrencies)
 		}
-
-		// Create transaction (without balance update for import)
-		if err := i.transactionRepo.Create(ctx, tx); err != nil {
+		if err != nil {
 			result.Errors = append(result.Errors, fmt.Sprintf("row %d: %v", result.TotalRows, err))
 			result.SkippedCount++
 			continue
 		}
 
-		result.SuccessCount++
+		rows = append(rows, csvTransactionRow{number: result.TotalRows, offset: baseOffset + reader.InputOffset(), tx: tx})
 	}
 
-	return result, nil
+	return rows, result, nil
 }
 
-func (i *Importer) parseTransactionRow(row []string, colIndex map[string]int) (*models.Transaction, error) {
+func (i *Importer) parseTransactionRow(
+	ctx context.Context,
+	row []string,
+	colIndex map[string]int,
+	categoryIDs map[string]uuid.UUID,
+	walletOverride *uuid.UUID,
+) (*models.Transaction, error) {
 	getValue := func(col string) string {
 		if idx, ok := colIndex[col]; ok && idx < len(row) {
 			return strings.TrimSpace(row[idx])
@@ -148,20 +386,31 @@ func (i *Importer) parseTransactionRow(row []string, colIndex map[string]int) (*
 		return nil, fmt.Errorf("invalid amount: %s", amountStr)
 	}
 
-	// Parse wallet ID
-	walletIDStr := getValue("wallet id")
-	walletID, err := uuid.Parse(walletIDStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid wallet id: %s", walletIDStr)
+	// Parse wallet ID, kecuali semua row di-assign ke satu wallet
+	var walletID uuid.UUID
+	if walletOverride != nil {
+		walletID = *walletOverride
+	} else {
+		walletIDStr := getValue("wallet id")
+		walletID, err = uuid.Parse(walletIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid wallet id: %s", walletIDStr)
+		}
 	}
 
-	// Optional: category ID
+	// Optional: category ID, atau category name jika ID kosong
 	var categoryID *uuid.UUID
 	if catIDStr := getValue("category id"); catIDStr != "" {
 		catID, err := uuid.Parse(catIDStr)
 		if err == nil {
 			categoryID = &catID
 		}
+	} else if catName := getValue("category name"); catName != "" {
+		catID, err := i.resolveCategoryName(ctx, catName, categoryIDs)
+		if err != nil {
+			return nil, err
+		}
+		categoryID = &catID
 	}
 
 	// Optional: description
@@ -174,19 +423,84 @@ func (i *Importer) parseTransactionRow(row []string, colIndex map[string]int) (*
 	}
 
 	return &models.Transaction{
-		BaseModel:       models.BaseModel{ID: models.NewID()},
-		WalletID:        walletID,
-		CategoryID:      categoryID,
-		Type:            txType,
-		Amount:          amount,
-		Description:     description,
-		Tags:            tags,
-		TransactionDate: date,
+		BaseModel:        models.BaseModel{ID: models.NewID()},
+		WalletID:         walletID,
+		CategoryID:       categoryID,
+		Type:             txType,
+		Amount:           amount,
+		Description:      description,
+		Note:             getValue("note"),
+		Tags:             tags,
+		TransactionDate:  date,
+		OriginalCurrency: strings.ToUpper(getValue("currency")),
 	}, nil
 }
 
+// checkCurrency membandingkan OriginalCurrency row dengan currency wallet.
+//
+// Row tanpa currency lolos apa adanya. Row beda currency ditolak, atau
+// dikonversi dengan conversionRate jika diset; OriginalCurrency tetap
+// disimpan supaya `wallet doctor` bisa melaporkan wallet yang berisi
+// transaksi hasil konversi. currencies adalah cache wallet ID → currency.
+func (i *Importer) checkCurrency(ctx context.Context, tx *models.Transaction, currencies map[uuid.UUID]string) error {
+	if tx.OriginalCurrency == "" {
+		return nil
+	}
+
+	walletCurrency, ok := currencies[tx.WalletID]
+	if !ok {
+		wallet, err := i.walletRepo.GetByID(ctx, tx.WalletID)
+		if err != nil {
+			return fmt.Errorf("wallet not found: %w", err)
+		}
+		walletCurrency = strings.ToUpper(wallet.Currency)
+		currencies[tx.WalletID] = walletCurrency
+	}
+
+	if tx.OriginalCurrency == walletCurrency {
+		return nil
+	}
+
+	if i.conversionRate.IsZero() {
+		return fmt.Errorf("currency %s does not match wallet currency %s (use --convert-with-rate)",
+			tx.OriginalCurrency, walletCurrency)
+	}
+
+	tx.Amount = tx.Amount.Mul(i.conversionRate).Round(2)
+	return nil
+}
+
+// resolveCategoryName mencari ID kategori berdasarkan nama (case-insensitive).
+func (i *Importer) resolveCategoryName(ctx context.Context, name string, cache map[string]uuid.UUID) (uuid.UUID, error) {
+	key := strings.ToLower(name)
+	if id, ok := cache[key]; ok {
+		return id, nil
+	}
+
+	category, err := i.categoryRepo.GetByName(ctx, name)
+	if errors.Is(err, repository.ErrNotFound) {
+		return uuid.Nil, fmt.Errorf("category not found: %s", name)
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to resolve category %s: %w", name, err)
+	}
+
+	cache[key] = category.ID
+	return category.ID, nil
+}
+
 // ==================== JSON Import ====================
 
+// parentsFirst mengurutkan kategori induk sebelum sub-kategori, supaya
+// parent_id sub-kategori sudah ada saat di-restore.
+func parentsFirst(categories []*models.Category) []*models.Category {
+	sorted := append([]*models.Category(nil), categories...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return !sorted[a].IsSubCategory() && sorted[b].IsSubCategory()
+	})
+	return sorted
+}
+
 // FromJSON imports all data from a JSON backup file.
 func (i *Importer) FromJSON(ctx context.Context, filename string) (*ImportResult, error) {
 	file, err := os.Open(filename)
@@ -195,12 +509,37 @@ func (i *Importer) FromJSON(ctx context.Context, filename string) (*ImportResult
 	}
 	defer file.Close()
 
-	var data ExportData
-	if err := json.NewDecoder(file).Decode(&data); err != nil {
-		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	return i.FromJSONReader(ctx, file)
+}
+
+// FromJSONReader meng-import full backup dari r (misalnya stdin).
+// Backup divalidasi dulu sebelum ada data yang ditulis: JSON yang bukan
+// hasil `wallet export all` dan backup dari versi yang lebih baru ditolak
+// (ErrNotBackup, ErrUnsupportedBackupVersion); backup dari schema lama
+// dimigrasi dulu (lihat BackupSchemaVersion).
+//
+// Backup incremental (ExportData.IsIncremental) di-apply dengan upsert:
+// row yang ID-nya sudah ada diganti, bukan di-skip. Restore full backup
+// dulu, lalu setiap incremental sesuai urutan.
+func (i *Importer) FromJSONReader(ctx context.Context, r io.Reader) (*ImportResult, error) {
+	data, err := decodeBackup(r)
+	if err != nil {
+		return nil, err
 	}
 
-	result := &ImportResult{}
+	result := &ImportResult{
+		BackupVersion:       data.Version,
+		BackupSchemaVersion: data.SchemaVersion,
+		ExportedAt:          data.ExportedAt,
+	}
+
+	if data.IsIncremental() {
+		result.Manifest = data.Manifest
+		if err := i.applyIncremental(ctx, data, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
 
 	// Import in transaction for atomicity
 	err = i.txManager.WithTransaction(ctx, func(ctx context.Context) error {
@@ -215,10 +554,15 @@ func (i *Importer) FromJSON(ctx context.Context, filename string) (*ImportResult
 			}
 		}
 
-		// Import categories
-		for _, c := range data.Categories {
+		// Import categories, parent dulu supaya parent_id valid. Kategori
+		// dari backup divalidasi seperti CategoryService.Create
+		for _, c := range parentsFirst(data.Categories) {
 			result.TotalRows++
-			if err := i.categoryRepo.Create(ctx, c); err != nil {
+			err := c.Validate()
+			if err == nil {
+				err = i.categoryRepo.Create(ctx, c)
+			}
+			if err != nil {
 				result.Errors = append(result.Errors, fmt.Sprintf("category %s: %v", c.Name, err))
 				result.SkippedCount++
 			} else {
@@ -248,6 +592,18 @@ func (i *Importer) FromJSON(ctx context.Context, filename string) (*ImportResult
 			}
 		}
 
+		// Import goal contributions; current_amount goal di backup sudah
+		// termasuk kontribusi ini, jadi tidak ditambahkan lagi
+		for _, c := range data.GoalContributions {
+			result.TotalRows++
+			if err := i.goalRepo.RestoreContribution(ctx, c); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("goal contribution %s: %v", c.ID, err))
+				result.SkippedCount++
+			} else {
+				result.SuccessCount++
+			}
+		}
+
 		return nil
 	})
 
@@ -0,0 +1,409 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+func TestImporter_TransactionsFromCSV_CategoryName(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	walletRepo := memory.NewWalletRepository(store)
+	txRepo := memory.NewTransactionRepository(store)
+	categoryRepo := memory.NewCategoryRepository(store)
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	if err := walletRepo.Create(ctx, wallet); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+	food := models.NewCategory("Food & Dining", models.CategoryTypeExpense)
+	if err := categoryRepo.Create(ctx, food); err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+
+	csv := strings.Join([]string{
+		"Date,Type,Amount,Description,Wallet ID,Category Name",
+		"2025-01-02,expense,50000,Lunch," + wallet.ID.String() + ",food & dining",
+		"2025-01-03,expense,20000,Coffee," + wallet.ID.String() + ",FOOD & DINING",
+		"2025-01-04,expense,75000,Cinema," + wallet.ID.String() + ",Entertainment",
+		"2025-01-05,income,100000,Refund," + wallet.ID.String() + ",",
+	}, "\n")
+	filename := filepath.Join(t.TempDir(), "transactions.csv")
+	if err := os.WriteFile(filename, []byte(csv), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	importer := NewImporter(walletRepo, txRepo, categoryRepo, memory.NewGoalRepository(store), memory.NewTransactionManager(store))
+	result, err := importer.TransactionsFromCSV(ctx, filename)
+	if err != nil {
+		t.Fatalf("TransactionsFromCSV() error = %v", err)
+	}
+
+	if result.TotalRows != 4 || result.SuccessCount != 3 || result.SkippedCount != 1 {
+		t.Errorf("result = %+v, want 4 rows, 3 imported, 1 skipped", result)
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "category not found: Entertainment") {
+		t.Errorf("Errors = %v, want category not found for Entertainment", result.Errors)
+	}
+
+	transactions, _ := txRepo.List(ctx, repository.TransactionFilter{CategoryID: &food.ID}, repository.ListParams{})
+	if len(transactions) != 2 {
+		t.Fatalf("transactions in Food & Dining = %d, want 2", len(transactions))
+	}
+	total := transactions[0].Amount.Add(transactions[1].Amount)
+	if !total.Equal(decimal.NewFromInt(70000)) {
+		t.Errorf("total = %s, want 70000", total)
+	}
+}
+
+func TestImporter_TransactionsFromCSV_SemicolonDelimiter(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	walletRepo := memory.NewWalletRepository(store)
+	txRepo := memory.NewTransactionRepository(store)
+
+	wallet := models.NewWallet("Sparkasse", models.WalletTypeBank)
+	if err := walletRepo.Create(ctx, wallet); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+
+	csv := strings.Join([]string{
+		"Date;Type;Amount;Description;Wallet ID",
+		"2025-01-02;expense;50000;Lunch, with team;" + wallet.ID.String(),
+		"2025-01-03;income;100000;Refund;" + wallet.ID.String(),
+	}, "\n")
+	filename := filepath.Join(t.TempDir(), "transactions.csv")
+	if err := os.WriteFile(filename, []byte(csv), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	importer := NewImporter(walletRepo, txRepo, memory.NewCategoryRepository(store), memory.NewGoalRepository(store), memory.NewTransactionManager(store))
+	result, err := importer.TransactionsFromCSV(ctx, filename)
+	if err != nil {
+		t.Fatalf("TransactionsFromCSV() error = %v", err)
+	}
+	if result.SuccessCount != 2 || len(result.Errors) != 0 {
+		t.Fatalf("result = %+v, want 2 imported without errors", result)
+	}
+
+	search := "lunch"
+	transactions, _ := txRepo.List(ctx, repository.TransactionFilter{Search: &search}, repository.ListParams{})
+	if len(transactions) != 1 || transactions[0].Description != "Lunch, with team" {
+		t.Errorf("transactions matching lunch = %v, want description with comma kept", transactions)
+	}
+}
+
+func TestImporter_TransactionsFromCSVToWallet(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	walletRepo := memory.NewWalletRepository(store)
+	txRepo := memory.NewTransactionRepository(store)
+	txManager := memory.NewTransactionManager(store)
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	wallet.Balance = decimal.NewFromInt(100000)
+	if err := walletRepo.Create(ctx, wallet); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+
+	// Tanpa kolom Wallet ID, urutan terbaru di atas seperti mutasi bank
+	csv := strings.Join([]string{
+		"Date,Type,Amount,Description",
+		"2025-01-05,expense,400000,Rent",
+		"2025-01-04,expense,abc,Broken amount",
+		"2025-01-03,refund,10000,Unknown type",
+		"2025-01-02,income,500000,Salary",
+		"2025-01-01,expense,-5000,Negative",
+	}, "\n")
+	filename := filepath.Join(t.TempDir(), "statement.csv")
+	if err := os.WriteFile(filename, []byte(csv), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	importer := NewImporter(walletRepo, txRepo, memory.NewCategoryRepository(store), memory.NewGoalRepository(store), txManager)
+	txService := service.NewTransactionService(txRepo, walletRepo, memory.NewCategoryRepository(store), txManager)
+
+	result, err := importer.TransactionsFromCSVToWallet(ctx, filename, wallet.ID, txService)
+	if err != nil {
+		t.Fatalf("TransactionsFromCSVToWallet() error = %v", err)
+	}
+
+	if result.TotalRows != 5 || result.SuccessCount != 2 || result.SkippedCount != 3 {
+		t.Errorf("result = %+v, want 5 rows, 2 imported, 3 skipped", result)
+	}
+	for _, want := range []string{"row 2: invalid amount", "row 3: invalid type", "row 5:"} {
+		found := false
+		for _, e := range result.Errors {
+			found = found || strings.HasPrefix(e, want)
+		}
+		if !found {
+			t.Errorf("Errors = %v, want entry starting with %q", result.Errors, want)
+		}
+	}
+
+	// 100.000 + 500.000 (salary) - 400.000 (rent)
+	got, _ := walletRepo.GetByID(ctx, wallet.ID)
+	if !got.Balance.Equal(decimal.NewFromInt(200000)) {
+		t.Errorf("balance = %s, want 200000", got.Balance)
+	}
+}
+
+// failingBatchTxRepo menggagalkan CreateBatch seperti error database.
+type failingBatchTxRepo struct {
+	repository.TransactionRepository
+	err error
+}
+
+func (f failingBatchTxRepo) CreateBatch(ctx context.Context, txs []*models.Transaction) error {
+	return f.err
+}
+
+func TestImporter_TransactionsFromCSVToWallet_DatabaseError(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	walletRepo := memory.NewWalletRepository(store)
+	txRepo := memory.NewTransactionRepository(store)
+	txManager := memory.NewTransactionManager(store)
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	wallet.Balance = decimal.NewFromInt(100000)
+	if err := walletRepo.Create(ctx, wallet); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+
+	csv := "Date,Type,Amount,Description\n2025-01-02,income,500000,Salary\n2025-01-05,expense,400000,Rent\n"
+	filename := filepath.Join(t.TempDir(), "statement.csv")
+	if err := os.WriteFile(filename, []byte(csv), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	errDB := errors.New("connection reset")
+	importer := NewImporter(walletRepo, txRepo, memory.NewCategoryRepository(store), memory.NewGoalRepository(store), txManager)
+	txService := service.NewTransactionService(failingBatchTxRepo{txRepo, errDB}, walletRepo, memory.NewCategoryRepository(store), txManager)
+
+	// Error database tidak di-retry per row, jadi tidak ada row yang
+	// tersimpan dua kali setelah batch yang gagal.
+	if _, err := importer.TransactionsFromCSVToWallet(ctx, filename, wallet.ID, txService); !errors.Is(err, errDB) {
+		t.Fatalf("TransactionsFromCSVToWallet() error = %v, want %v", err, errDB)
+	}
+	if all, _ := txRepo.List(ctx, repository.TransactionFilter{}, repository.ListParams{Limit: 10}); len(all) != 0 {
+		t.Errorf("got %d transactions, want none", len(all))
+	}
+	got, _ := walletRepo.GetByID(ctx, wallet.ID)
+	if !got.Balance.Equal(decimal.NewFromInt(100000)) {
+		t.Errorf("balance = %s, want unchanged 100000", got.Balance)
+	}
+}
+
+func TestImporter_TransactionsFromCSV_RequiresWalletID(t *testing.T) {
+	store := memory.NewStore()
+	filename := filepath.Join(t.TempDir(), "statement.csv")
+	if err := os.WriteFile(filename, []byte("Date,Type,Amount\n2025-01-02,income,1000\n"), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	importer := NewImporter(memory.NewWalletRepository(store), memory.NewTransactionRepository(store),
+		memory.NewCategoryRepository(store), memory.NewGoalRepository(store), memory.NewTransactionManager(store))
+
+	_, err := importer.TransactionsFromCSV(context.Background(), filename)
+	if err == nil || !strings.Contains(err.Error(), "missing required column: wallet id") {
+		t.Errorf("TransactionsFromCSV() error = %v, want missing wallet id column", err)
+	}
+}
+
+func TestImporter_TransactionsFromCSVToWallet_Currency(t *testing.T) {
+	csv := strings.Join([]string{
+		"Date,Type,Amount,Description,Currency",
+		"2025-01-02,income,500000,Salary,IDR",
+		"2025-01-03,expense,10,Domain,usd",
+		"2025-01-04,expense,20000,Lunch,",
+	}, "\n")
+
+	tests := []struct {
+		name        string
+		rate        decimal.Decimal
+		wantSuccess int
+		wantBalance int64
+		wantError   string
+	}{
+		{
+			name:        "without rate",
+			wantSuccess: 2,
+			wantBalance: 480000,
+			wantError:   "row 2: currency USD does not match wallet currency IDR",
+		},
+		{
+			name:        "with rate",
+			rate:        decimal.NewFromInt(16250),
+			wantSuccess: 3,
+			wantBalance: 317500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := memory.NewStore()
+			walletRepo := memory.NewWalletRepository(store)
+			txRepo := memory.NewTransactionRepository(store)
+			categoryRepo := memory.NewCategoryRepository(store)
+			txManager := memory.NewTransactionManager(store)
+
+			wallet := models.NewWallet("BCA", models.WalletTypeBank)
+			if err := walletRepo.Create(ctx, wallet); err != nil {
+				t.Fatalf("create wallet: %v", err)
+			}
+
+			filename := filepath.Join(t.TempDir(), "mixed.csv")
+			if err := os.WriteFile(filename, []byte(csv), 0o600); err != nil {
+				t.Fatalf("write csv: %v", err)
+			}
+
+			importer := NewImporter(walletRepo, txRepo, categoryRepo, memory.NewGoalRepository(store), txManager)
+			importer.SetConversionRate(tt.rate)
+			txService := service.NewTransactionService(txRepo, walletRepo, categoryRepo, txManager)
+
+			result, err := importer.TransactionsFromCSVToWallet(ctx, filename, wallet.ID, txService)
+			if err != nil {
+				t.Fatalf("TransactionsFromCSVToWallet() error = %v", err)
+			}
+
+			if result.SuccessCount != tt.wantSuccess {
+				t.Errorf("SuccessCount = %d, want %d (errors: %v)", result.SuccessCount, tt.wantSuccess, result.Errors)
+			}
+			if tt.wantError != "" && (len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0], tt.wantError)) {
+				t.Errorf("Errors = %v, want %q", result.Errors, tt.wantError)
+			}
+
+			got, _ := walletRepo.GetByID(ctx, wallet.ID)
+			if !got.Balance.Equal(decimal.NewFromInt(tt.wantBalance)) {
+				t.Errorf("balance = %s, want %d", got.Balance, tt.wantBalance)
+			}
+
+			// Currency asli tetap disimpan untuk `wallet doctor`
+			issues, _ := memory.NewIntegrityRepository(store).FindImportedCurrencyMismatches(ctx)
+			if wantIssues := tt.wantSuccess - 2; len(issues) != wantIssues {
+				t.Errorf("FindImportedCurrencyMismatches() = %d issues, want %d", len(issues), wantIssues)
+			}
+		})
+	}
+}
+
+// interruptingTransactionRepo membatalkan import setelah sejumlah Create,
+// seperti user menekan Ctrl+C di tengah import.
+type interruptingTransactionRepo struct {
+	repository.TransactionRepository
+	remaining int
+	onLast    func()
+}
+
+func (r *interruptingTransactionRepo) Create(ctx context.Context, tx *models.Transaction) error {
+	err := r.TransactionRepository.Create(ctx, tx)
+	if r.remaining--; r.remaining == 0 {
+		r.onLast()
+	}
+	return err
+}
+
+func TestImporter_TransactionsFromCSV_Checkpoint(t *testing.T) {
+	store := memory.NewStore()
+	walletRepo := memory.NewWalletRepository(store)
+	txRepo := memory.NewTransactionRepository(store)
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	if err := walletRepo.Create(context.Background(), wallet); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+
+	lines := []string{"Date,Type,Amount,Description,Wallet ID"}
+	for n := 1; n <= 7; n++ {
+		lines = append(lines, fmt.Sprintf("2025-01-%02d,expense,%d000,Row %d,%s", n, n, n, wallet.ID))
+	}
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "big.csv")
+	if err := os.WriteFile(filename, []byte(strings.Join(lines, "\n")), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	checkpointFile := filepath.Join(dir, "big.csv.checkpoint")
+
+	newImporter := func(repo repository.TransactionRepository) *Importer {
+		importer := NewImporter(walletRepo, repo, memory.NewCategoryRepository(store),
+			memory.NewGoalRepository(store), memory.NewTransactionManager(store), WithCheckpointFile(checkpointFile))
+		importer.checkpointEvery = 2
+		return importer
+	}
+
+	// Run pertama berhenti setelah row 5. Checkpoint saat itu masih di
+	// row 4; simpan isinya untuk mensimulasikan crash sebelum checkpoint
+	// berikutnya tertulis.
+	ctx, cancel := context.WithCancel(context.Background())
+	var crashed []byte
+	interrupting := &interruptingTransactionRepo{TransactionRepository: txRepo, remaining: 5, onLast: func() {
+		crashed, _ = os.ReadFile(checkpointFile)
+		cancel()
+	}}
+	if _, err := newImporter(interrupting).TransactionsFromCSV(ctx, filename); !errors.Is(err, context.Canceled) {
+		t.Fatalf("TransactionsFromCSV() error = %v, want context.Canceled", err)
+	}
+
+	importer := newImporter(txRepo)
+	if row, err := importer.ResumeFromCheckpoint(filename); err != nil || row != 5 {
+		t.Errorf("ResumeFromCheckpoint() = %d, %v, want 5 after the interruption", row, err)
+	}
+
+	if err := os.WriteFile(checkpointFile, crashed, 0o600); err != nil {
+		t.Fatalf("restore checkpoint: %v", err)
+	}
+	if row, _ := importer.ResumeFromCheckpoint(filename); row != 4 {
+		t.Fatalf("ResumeFromCheckpoint() = %d, want 4 (crash before the next checkpoint)", row)
+	}
+
+	result, err := importer.TransactionsFromCSV(context.Background(), filename)
+	if err != nil {
+		t.Fatalf("TransactionsFromCSV() resume error = %v", err)
+	}
+	if result.TotalRows != 7 || result.SuccessCount != 2 || result.AlreadyImported != 5 || result.SkippedCount != 0 {
+		t.Errorf("result = %+v, want 7 rows, 2 imported, 5 already imported", result)
+	}
+
+	transactions, _ := txRepo.List(context.Background(), repository.TransactionFilter{}, repository.ListParams{Limit: 100})
+	if len(transactions) != 7 {
+		t.Errorf("transactions = %d, want 7 (no duplicates)", len(transactions))
+	}
+	if _, err := os.Stat(checkpointFile); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("checkpoint file still exists after a completed import (stat error = %v)", err)
+	}
+}
+
+func TestImporter_TransactionsFromCSV_CheckpointOtherFile(t *testing.T) {
+	store := memory.NewStore()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "statement.csv")
+	if err := os.WriteFile(filename, []byte("Date,Type,Amount,Wallet ID\n"), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	checkpointFile := filepath.Join(dir, "import.checkpoint")
+	if err := os.WriteFile(checkpointFile, []byte(`{"file_sha256":"0000","row":10,"offset":100}`), 0o600); err != nil {
+		t.Fatalf("write checkpoint: %v", err)
+	}
+
+	importer := NewImporter(memory.NewWalletRepository(store), memory.NewTransactionRepository(store),
+		memory.NewCategoryRepository(store), memory.NewGoalRepository(store), memory.NewTransactionManager(store),
+		WithCheckpointFile(checkpointFile))
+
+	if _, err := importer.TransactionsFromCSV(context.Background(), filename); !errors.Is(err, ErrCheckpointMismatch) {
+		t.Errorf("TransactionsFromCSV() error = %v, want ErrCheckpointMismatch", err)
+	}
+}
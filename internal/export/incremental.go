@@ -0,0 +1,215 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// EntityGoalContributions adalah nama entity kontribusi goal di
+// BackupManifest.Counts. Kontribusi tidak ikut Merge.
+const EntityGoalContributions = "goal_contributions"
+
+// BackupManifest mencatat cutoff backup incremental, supaya backup bisa
+// dirangkai: full backup, lalu incremental dengan Since = Until backup
+// sebelumnya, dan seterusnya. Restore dijalankan berurutan.
+type BackupManifest struct {
+	// Since adalah cutoff: hanya row yang dibuat atau di-update setelah
+	// Since yang ikut.
+	Since time.Time `json:"since"`
+
+	// Until adalah waktu export dimulai; pakai sebagai Since backup
+	// berikutnya.
+	Until time.Time `json:"until"`
+
+	// Counts adalah jumlah row per entity (EntityWallets, dst).
+	Counts map[string]int `json:"counts"`
+}
+
+// IsIncremental true jika backup hanya berisi perubahan sejak cutoff
+// manifest.
+func (d *ExportData) IsIncremental() bool {
+	return d.Manifest != nil && !d.Manifest.Since.IsZero()
+}
+
+// IncrementalToJSONWriter menulis backup incremental ke w: wallet,
+// transaksi dan goal yang dibuat atau di-update setelah since, kontribusi
+// goal yang dibuat setelah since, plus manifest dengan cutoff-nya.
+// Category tidak punya updated_at dan jumlahnya kecil, jadi selalu ikut
+// semua.
+//
+// Row yang dihapus tidak tercatat di incremental; ambil full backup
+// berkala untuk membuang row seperti itu.
+//
+//	err := exporter.IncrementalToJSONWriter(ctx, w, lastBackup.Manifest.Until)
+func (e *Exporter) IncrementalToJSONWriter(ctx context.Context, w io.Writer, since time.Time) error {
+	data, err := e.collectBackup(ctx)
+	if err != nil {
+		return err
+	}
+
+	wallets := data.Wallets[:0]
+	for _, wallet := range data.Wallets {
+		if changedSince(wallet.BaseModel, since) {
+			wallets = append(wallets, wallet)
+		}
+	}
+	transactions := data.Transactions[:0]
+	for _, tx := range data.Transactions {
+		if changedSince(tx.BaseModel, since) {
+			transactions = append(transactions, tx)
+		}
+	}
+	goals := data.Goals[:0]
+	for _, g := range data.Goals {
+		if changedSince(g.BaseModel, since) {
+			goals = append(goals, g)
+		}
+	}
+	contributions := data.GoalContributions[:0]
+	for _, c := range data.GoalContributions {
+		if c.CreatedAt.After(since) {
+			contributions = append(contributions, c)
+		}
+	}
+	data.Wallets, data.Transactions, data.Goals, data.GoalContributions = wallets, transactions, goals, contributions
+
+	data.Manifest = &BackupManifest{
+		Since: since,
+		Until: data.ExportedAt,
+		Counts: map[string]int{
+			EntityWallets:      len(data.Wallets),
+			EntityCategories:   len(data.Categories),
+			EntityTransactions: len(data.Transactions),
+			EntityGoals:        len(data.Goals),
+
+			EntityGoalContributions: len(data.GoalContributions),
+		},
+	}
+
+	if err := encodeJSON(w, data); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+// changedSince true jika row dibuat atau di-update setelah since.
+func changedSince(m models.BaseModel, since time.Time) bool {
+	return m.CreatedAt.After(since) || m.UpdatedAt.After(since)
+}
+
+// applyIncremental me-restore backup incremental dengan upsert: row yang
+// ID-nya sudah ada diganti isi backup, row baru dibuat. Satu row gagal
+// membatalkan semuanya, supaya rangkaian backup tidak setengah
+// ter-apply.
+func (i *Importer) applyIncremental(ctx context.Context, data *ExportData, result *ImportResult) error {
+	return i.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// Mulai dari hitungan nol setiap percobaan (lihat Merge)
+		result.TotalRows, result.SuccessCount, result.ReplacedCount = 0, 0, 0
+
+		for _, remote := range data.Wallets {
+			local, err := i.walletRepo.GetByID(ctx, remote.ID)
+			if err := upsert(result, err, func() error { return i.walletRepo.Create(ctx, remote) }, func() error {
+				remote.Version = local.Version
+				return i.walletRepo.Update(ctx, remote)
+			}); err != nil {
+				return fmt.Errorf("wallet %s: %w", remote.Name, err)
+			}
+		}
+
+		// Parent dulu supaya parent_id valid
+		for _, parents := range []bool{true, false} {
+			for _, remote := range data.Categories {
+				if remote.IsSubCategory() == parents {
+					continue
+				}
+				if err := remote.Validate(); err != nil {
+					return fmt.Errorf("category %q: %w", remote.Name, err)
+				}
+				_, err := i.categoryRepo.GetByID(ctx, remote.ID)
+				if err := upsert(result, err, func() error { return i.categoryRepo.Create(ctx, remote) }, func() error {
+					return i.categoryRepo.Update(ctx, remote)
+				}); err != nil {
+					return fmt.Errorf("category %s: %w", remote.Name, err)
+				}
+			}
+		}
+
+		for _, remote := range data.Transactions {
+			_, err := i.transactionRepo.GetByID(ctx, remote.ID)
+			if err := upsert(result, err, func() error { return i.transactionRepo.Create(ctx, remote) }, func() error {
+				return i.transactionRepo.Update(ctx, remote)
+			}); err != nil {
+				return fmt.Errorf("transaction %s: %w", remote.ID, err)
+			}
+		}
+
+		for _, remote := range data.Goals {
+			local, err := i.goalRepo.GetByID(ctx, remote.ID)
+			if err := upsert(result, err, func() error { return i.goalRepo.Create(ctx, remote) }, func() error {
+				remote.Version = local.Version
+				return i.goalRepo.Update(ctx, remote)
+			}); err != nil {
+				return fmt.Errorf("goal %s: %w", remote.Name, err)
+			}
+		}
+
+		// Kontribusi tidak pernah diubah, jadi yang sudah ada di-skip.
+		// ID yang ada dibaca dulu per goal: unique violation di tengah
+		// database transaction membatalkan seluruh transaction.
+		existing := make(map[uuid.UUID]map[uuid.UUID]bool)
+		for _, c := range data.GoalContributions {
+			ids, ok := existing[c.GoalID]
+			if !ok {
+				local, err := listAllContributions(ctx, i.goalRepo, c.GoalID)
+				if err != nil {
+					return err
+				}
+				ids = make(map[uuid.UUID]bool, len(local))
+				for _, l := range local {
+					ids[l.ID] = true
+				}
+				existing[c.GoalID] = ids
+			}
+
+			result.TotalRows++
+			if ids[c.ID] {
+				continue
+			}
+			if err := i.goalRepo.RestoreContribution(ctx, c); err != nil {
+				return fmt.Errorf("goal contribution %s: %w", c.ID, err)
+			}
+			ids[c.ID] = true
+			result.SuccessCount++
+		}
+		return nil
+	})
+}
+
+// upsert menjalankan create jika getErr adalah ErrNotFound, atau replace
+// jika row sudah ada, lalu mencatatnya di result.
+func upsert(result *ImportResult, getErr error, create, replace func() error) error {
+	result.TotalRows++
+	switch {
+	case errors.Is(getErr, repository.ErrNotFound):
+		if err := create(); err != nil {
+			return fmt.Errorf("failed to create: %w", err)
+		}
+	case getErr != nil:
+		return getErr
+	default:
+		if err := replace(); err != nil {
+			return fmt.Errorf("failed to replace: %w", err)
+		}
+		result.ReplacedCount++
+	}
+	result.SuccessCount++
+	return nil
+}
@@ -0,0 +1,175 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+)
+
+func newMemoryExporter(store *memory.Store) *Exporter {
+	return NewExporter(
+		memory.NewWalletRepository(store),
+		memory.NewTransactionRepository(store),
+		memory.NewCategoryRepository(store),
+		memory.NewGoalRepository(store),
+	)
+}
+
+func TestIncrementalBackup_Chain(t *testing.T) {
+	ctx := context.Background()
+	source := memory.NewStore()
+	wallets := memory.NewWalletRepository(source)
+	txs := memory.NewTransactionRepository(source)
+
+	bca := models.NewWallet("BCA", models.WalletTypeBank)
+	cash := models.NewWallet("Cash", models.WalletTypeCash)
+	for _, w := range []*models.Wallet{bca, cash} {
+		if err := wallets.Create(ctx, w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := models.NewTransaction(bca.ID, models.TransactionTypeExpense, decimal.NewFromInt(10000))
+	old.Description = "Old lunch"
+	if err := txs.Create(ctx, old); err != nil {
+		t.Fatal(err)
+	}
+
+	var full bytes.Buffer
+	if err := newMemoryExporter(source).ToJSONWriter(ctx, &full); err != nil {
+		t.Fatalf("ToJSONWriter() error = %v", err)
+	}
+	cutoff := time.Now()
+	time.Sleep(time.Millisecond)
+
+	// Perubahan setelah full backup: satu wallet di-update, satu
+	// transaksi baru
+	if err := wallets.UpdateBalance(ctx, bca.ID, decimal.NewFromInt(90000), models.BalanceChange{Reason: models.BalanceReasonAdjustment}); err != nil {
+		t.Fatal(err)
+	}
+	fresh := models.NewTransaction(bca.ID, models.TransactionTypeExpense, decimal.NewFromInt(25000))
+	fresh.Description = "New dinner"
+	if err := txs.Create(ctx, fresh); err != nil {
+		t.Fatal(err)
+	}
+
+	var incremental bytes.Buffer
+	if err := newMemoryExporter(source).IncrementalToJSONWriter(ctx, &incremental, cutoff); err != nil {
+		t.Fatalf("IncrementalToJSONWriter() error = %v", err)
+	}
+
+	var data ExportData
+	if err := json.Unmarshal(incremental.Bytes(), &data); err != nil {
+		t.Fatal(err)
+	}
+	if !data.IsIncremental() || !data.Manifest.Since.Equal(cutoff) {
+		t.Fatalf("manifest = %+v, want since %v", data.Manifest, cutoff)
+	}
+	if data.Manifest.Until.Before(cutoff) {
+		t.Errorf("manifest until %v is before the cutoff", data.Manifest.Until)
+	}
+	if len(data.Wallets) != 1 || data.Wallets[0].ID != bca.ID {
+		t.Errorf("incremental wallets = %d, want only the updated BCA", len(data.Wallets))
+	}
+	if len(data.Transactions) != 1 || data.Transactions[0].ID != fresh.ID {
+		t.Errorf("incremental transactions = %d, want only the new one", len(data.Transactions))
+	}
+	if got := data.Manifest.Counts[EntityTransactions]; got != 1 {
+		t.Errorf("manifest transactions count = %d, want 1", got)
+	}
+
+	// Restore: full dulu, lalu incremental
+	target := memory.NewStore()
+	importer := newMemoryImporter(target)
+	if _, err := importer.FromJSONReader(ctx, &full); err != nil {
+		t.Fatalf("restore full error = %v", err)
+	}
+	result, err := importer.FromJSONReader(ctx, &incremental)
+	if err != nil {
+		t.Fatalf("restore incremental error = %v", err)
+	}
+	if result.Manifest == nil || result.SuccessCount != 2 || result.ReplacedCount != 1 {
+		t.Errorf("result = %d imported, %d replaced; want 2, 1", result.SuccessCount, result.ReplacedCount)
+	}
+
+	restored, err := memory.NewWalletRepository(target).GetByID(ctx, bca.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restored.Balance.Equal(decimal.NewFromInt(90000)) {
+		t.Errorf("BCA balance = %s, want the updated 90000", restored.Balance)
+	}
+	restoredTxs, err := memory.NewTransactionRepository(target).List(ctx, repository.TransactionFilter{}, repository.ListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restoredTxs) != 2 {
+		t.Errorf("restored %d transactions, want 2", len(restoredTxs))
+	}
+}
+
+func TestIncrementalBackup_GoalContributions(t *testing.T) {
+	ctx := context.Background()
+	source := memory.NewStore()
+	goals := memory.NewGoalRepository(source)
+
+	goal := models.NewGoal("Laptop", decimal.NewFromInt(1000000))
+	if err := goals.Create(ctx, goal); err != nil {
+		t.Fatal(err)
+	}
+	if err := goals.AddContribution(ctx, models.NewContribution(goal.ID, decimal.NewFromInt(100000))); err != nil {
+		t.Fatal(err)
+	}
+
+	var full bytes.Buffer
+	if err := newMemoryExporter(source).ToJSONWriter(ctx, &full); err != nil {
+		t.Fatalf("ToJSONWriter() error = %v", err)
+	}
+	cutoff := time.Now()
+	time.Sleep(time.Millisecond)
+
+	later := models.NewContribution(goal.ID, decimal.NewFromInt(50000))
+	if err := goals.AddContribution(ctx, later); err != nil {
+		t.Fatal(err)
+	}
+
+	var incremental bytes.Buffer
+	if err := newMemoryExporter(source).IncrementalToJSONWriter(ctx, &incremental, cutoff); err != nil {
+		t.Fatalf("IncrementalToJSONWriter() error = %v", err)
+	}
+
+	target := memory.NewStore()
+	importer := newMemoryImporter(target)
+	if _, err := importer.FromJSONReader(ctx, &full); err != nil {
+		t.Fatalf("restore full error = %v", err)
+	}
+	// Incremental di-restore dua kali: kontribusi yang sudah ada di-skip
+	for range 2 {
+		if _, err := importer.FromJSONReader(ctx, bytes.NewReader(incremental.Bytes())); err != nil {
+			t.Fatalf("restore incremental error = %v", err)
+		}
+	}
+
+	targetGoals := memory.NewGoalRepository(target)
+	contributions, err := targetGoals.GetContributions(ctx, goal.ID, repository.ListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contributions) != 2 || contributions[0].ID != later.ID {
+		t.Errorf("restored %d contributions, want both with the later one first", len(contributions))
+	}
+	restored, err := targetGoals.GetByID(ctx, goal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restored.CurrentAmount.Equal(decimal.NewFromInt(150000)) {
+		t.Errorf("current amount = %s, want 150000 (contributions are not added again)", restored.CurrentAmount)
+	}
+}
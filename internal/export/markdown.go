@@ -0,0 +1,170 @@
+package export
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
+)
+
+// markdownMinus adalah tanda minus (U+2212) untuk expense, lebih mudah
+// dibaca daripada hyphen di tabel yang dirender.
+const markdownMinus = "−"
+
+// TransactionsToMarkdown exports transactions to a Markdown file.
+func (e *Exporter) TransactionsToMarkdown(ctx context.Context, filename string, filter repository.TransactionFilter, currency string) error {
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.TransactionsToMarkdownWriter(ctx, w, filter, currency)
+	})
+}
+
+// TransactionsToMarkdownWriter menulis transaksi sebagai tabel Markdown ke w.
+//
+// currency adalah currency default (app.currency) untuk baris total dan
+// untuk transaksi yang wallet-nya tidak ditemukan.
+func (e *Exporter) TransactionsToMarkdownWriter(ctx context.Context, w io.Writer, filter repository.TransactionFilter, currency string) error {
+	transactions, err := listAllTransactions(ctx, e.transactionRepo, filter)
+	if err != nil {
+		return err
+	}
+
+	refs, err := loadRefData(ctx, e.refs, e.walletRepo, e.categoryRepo)
+	if err != nil {
+		return err
+	}
+
+	return WriteTransactionsMarkdown(w, transactions, refs, currency)
+}
+
+// WriteTransactionsMarkdown menulis transaksi sebagai tabel GitHub-flavored
+// Markdown, siap di-paste ke Notion/Obsidian:
+//
+//	| Date       | Wallet | Category | Description | Amount       |
+//	| :--------- | :----- | :------- | :---------- | -----------: |
+//	| 2025-01-15 | BCA    | Food     | Lunch       | −Rp 25.000   |
+//	| **Total**  |        |          |             | **−Rp 25.000** |
+//
+// Nama wallet dan category diambil dari refs (fallback "Unknown" dan
+// "Uncategorized"). Amount rata kanan dan diformat sesuai currency wallet; expense diberi
+// prefix "−". Baris terakhir berisi net (income - expense); jika ada lebih
+// dari satu currency, satu baris total per currency.
+// Pipe dan newline di dalam cell di-escape supaya tabel tidak rusak.
+func WriteTransactionsMarkdown(
+	w io.Writer,
+	transactions []*models.Transaction,
+	refs *service.RefData,
+	currency string,
+) error {
+	rows := [][]string{{"Date", "Wallet", "Category", "Description", "Amount"}}
+
+	// Net dijumlah per currency supaya wallet USD tidak tercampur ke total IDR
+	nets := map[string]decimal.Decimal{}
+	var netOrder []string
+	for _, tx := range transactions {
+		txCurrency := refs.WalletCurrency(tx.WalletID, currency)
+
+		amount := tx.Amount
+		if tx.Type == models.TransactionTypeExpense {
+			amount = amount.Neg()
+		}
+		if _, ok := nets[txCurrency]; !ok {
+			netOrder = append(netOrder, txCurrency)
+		}
+		nets[txCurrency] = nets[txCurrency].Add(amount)
+
+		rows = append(rows, []string{
+			tx.TransactionDate.Format("2006-01-02"),
+			refs.WalletName(tx.WalletID),
+			refs.CategoryName(tx.CategoryID),
+			tx.Description,
+			markdownMoney(amount, txCurrency),
+		})
+	}
+
+	if len(netOrder) == 0 {
+		netOrder = []string{currency}
+	}
+	for _, cur := range netOrder {
+		label := "**Total**"
+		if len(netOrder) > 1 {
+			label = "**Total " + strings.ToUpper(cur) + "**"
+		}
+		rows = append(rows, []string{label, "", "", "", "**" + markdownMoney(nets[cur], cur) + "**"})
+	}
+
+	return writeMarkdownTable(w, rows, []bool{false, false, false, false, true})
+}
+
+// markdownMoney memformat amount dengan prefix "−" untuk nilai negatif.
+func markdownMoney(d decimal.Decimal, currency string) string {
+	if d.IsNegative() {
+		return markdownMinus + utils.FormatMoney(d.Abs(), currency)
+	}
+	return utils.FormatMoney(d, currency)
+}
+
+// writeMarkdownTable menulis rows (baris pertama header) sebagai tabel
+// dengan kolom yang di-padding rata; rightAlign menandai kolom rata kanan.
+func writeMarkdownTable(w io.Writer, rows [][]string, rightAlign []bool) error {
+	widths := make([]int, len(rightAlign))
+	for i, row := range rows {
+		for j, cell := range row {
+			cell = escapeMarkdownCell(cell)
+			rows[i][j] = cell
+			widths[j] = max(widths[j], len([]rune(cell)), 3)
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for j, cell := range cells {
+			pad := strings.Repeat(" ", widths[j]-len([]rune(cell)))
+			if rightAlign[j] {
+				sb.WriteString(" " + pad + cell + " |")
+			} else {
+				sb.WriteString(" " + cell + pad + " |")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(rows[0])
+
+	sb.WriteString("|")
+	for j, width := range widths {
+		if rightAlign[j] {
+			sb.WriteString(" " + strings.Repeat("-", width-1) + ": |")
+		} else {
+			sb.WriteString(" :" + strings.Repeat("-", width-1) + " |")
+		}
+	}
+	sb.WriteString("\n")
+
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// markdownCellReplacer meng-escape karakter yang merusak tabel Markdown:
+// pipe menjadi \| dan newline menjadi <br>.
+var markdownCellReplacer = strings.NewReplacer(
+	"|", `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
+
+// escapeMarkdownCell meng-escape isi satu cell tabel Markdown.
+func escapeMarkdownCell(s string) string {
+	return markdownCellReplacer.Replace(strings.TrimSpace(s))
+}
@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+// assertGolden membandingkan got dengan testdata/name.
+// Jalankan `go test ./internal/export -update` untuk menulis ulang.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output mismatch with %s\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestWriteTransactionsMarkdown(t *testing.T) {
+	bca := models.NewWallet("BCA", models.WalletTypeBank)
+	usd := models.NewWallet("Wise", models.WalletTypeBank)
+	usd.Currency = "USD"
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	salary := models.NewCategory("Salary", models.CategoryTypeIncome)
+
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	newTx := func(wallet *models.Wallet, txType models.TransactionType, amount string, category *models.Category, desc string) *models.Transaction {
+		tx := models.NewTransaction(wallet.ID, txType, decimal.RequireFromString(amount))
+		tx.TransactionDate = date
+		tx.Description = desc
+		if category != nil {
+			tx.SetCategory(category.ID)
+		}
+		return tx
+	}
+
+	transactions := []*models.Transaction{
+		newTx(bca, models.TransactionTypeIncome, "5000000", salary, "January salary"),
+		newTx(bca, models.TransactionTypeExpense, "25000", food, "Nasi | ayam\nextra sambal"),
+		newTx(usd, models.TransactionTypeExpense, "12.5", nil, "Domain renewal\r\n(yearly)"),
+		newTx(models.NewWallet("Gone", models.WalletTypeCash), models.TransactionTypeExpense, "1000", nil, "  unknown wallet  "),
+	}
+
+	var buf bytes.Buffer
+	err := WriteTransactionsMarkdown(&buf, transactions, service.NewRefData([]*models.Wallet{bca, usd}, []*models.Category{food, salary}), "IDR")
+	if err != nil {
+		t.Fatalf("WriteTransactionsMarkdown() error = %v", err)
+	}
+
+	assertGolden(t, "transactions.md.golden", buf.Bytes())
+
+	// Setiap baris harus punya jumlah kolom yang sama (6 pipe tak ter-escape)
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if n := strings.Count(strings.ReplaceAll(line, `\|`, ""), "|"); n != 6 {
+			t.Errorf("line %q has %d column separators, want 6", line, n)
+		}
+	}
+}
+
+func TestWriteTransactionsMarkdown_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteTransactionsMarkdown(&buf, nil, nil, "USD"); err != nil {
+		t.Fatalf("WriteTransactionsMarkdown() error = %v", err)
+	}
+
+	assertGolden(t, "transactions_empty.md.golden", buf.Bytes())
+}
+
+func TestExporter_TransactionsToMarkdownWriter(t *testing.T) {
+	e, wallet, tx := newTestExporter()
+
+	var buf bytes.Buffer
+	if err := e.TransactionsToMarkdownWriter(context.Background(), &buf, repository.TransactionFilter{}, "IDR"); err != nil {
+		t.Fatalf("TransactionsToMarkdownWriter() error = %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"| " + wallet.Name + " ", tx.Description, "−Rp 25.000", "**Total**"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output = %q, want to contain %q", got, want)
+		}
+	}
+}
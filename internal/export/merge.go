@@ -0,0 +1,274 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// Nama entity di MergeResult, sesuai urutan merge.
+const (
+	EntityWallets      = "wallets"
+	EntityCategories   = "categories"
+	EntityTransactions = "transactions"
+	EntityGoals        = "goals"
+)
+
+// Sisi yang dipakai saat terjadi conflict.
+const (
+	KeptLocal  = "local"
+	KeptRemote = "remote"
+)
+
+// MergeStats adalah jumlah row per entity hasil merge.
+type MergeStats struct {
+	Entity  string
+	Created int
+	Updated int
+	Skipped int
+}
+
+// MergeConflict adalah row dengan ID yang sama di kedua sisi
+// tetapi updated_at berbeda.
+type MergeConflict struct {
+	Entity          string
+	ID              uuid.UUID
+	Name            string
+	LocalUpdatedAt  time.Time
+	RemoteUpdatedAt time.Time
+	// Kept adalah sisi yang menang (KeptLocal atau KeptRemote).
+	Kept string
+}
+
+// MergeResult berisi ringkasan merge per entity dan daftar conflict.
+type MergeResult struct {
+	Stats     []*MergeStats
+	Conflicts []MergeConflict
+}
+
+// ForEntity mengambil statistik untuk satu entity (misalnya EntityWallets).
+func (r *MergeResult) ForEntity(entity string) *MergeStats {
+	for _, s := range r.Stats {
+		if s.Entity == entity {
+			return s
+		}
+	}
+	return &MergeStats{Entity: entity}
+}
+
+// Merge menggabungkan data dari instance lain ke database lokal.
+//
+// Aturan per row (dicocokkan berdasarkan ID):
+//   - belum ada di lokal        → dibuat (created)
+//   - updated_at sama           → di-skip
+//   - updated_at remote lebih baru → lokal ditimpa (updated), dicatat sebagai conflict
+//   - updated_at lokal lebih baru  → di-skip, dicatat sebagai conflict
+//
+// Kategori tidak punya updated_at, jadi kategori yang sudah ada selalu di-skip.
+// Goal contributions tidak di-merge: current_amount goal sudah ikut di goal.
+//
+// PENTING: Semua perubahan dijalankan dalam satu database transaction.
+// Jika satu row gagal, tidak ada data yang tersimpan.
+//
+//	result, err := importer.Merge(ctx, data)
+//	fmt.Println(result.ForEntity(export.EntityWallets).Created)
+func (i *Importer) Merge(ctx context.Context, data *ExportData) (*MergeResult, error) {
+	var result *MergeResult
+
+	err := i.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// Mulai dari result baru setiap percobaan supaya hasil
+		// tidak tercampur dengan percobaan yang di-rollback.
+		result = &MergeResult{}
+
+		steps := []func(context.Context, *ExportData, *MergeResult) error{
+			i.mergeWallets,
+			i.mergeCategories,
+			i.mergeTransactions,
+			i.mergeGoals,
+		}
+		for _, step := range steps {
+			if err := step(ctx, data, result); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// mergeWallets menggabungkan wallets.
+func (i *Importer) mergeWallets(ctx context.Context, data *ExportData, result *MergeResult) error {
+	stats := &MergeStats{Entity: EntityWallets}
+	result.Stats = append(result.Stats, stats)
+
+	for _, remote := range data.Wallets {
+		local, err := i.walletRepo.GetByID(ctx, remote.ID)
+		if errors.Is(err, repository.ErrNotFound) {
+			if err := i.walletRepo.Create(ctx, remote); err != nil {
+				return fmt.Errorf("failed to create wallet %s: %w", remote.Name, err)
+			}
+			stats.Created++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get wallet %s: %w", remote.Name, err)
+		}
+
+		if !resolveConflict(result, stats, EntityWallets, remote.ID, remote.Name, local.UpdatedAt, remote.UpdatedAt) {
+			continue
+		}
+		// Version remote tidak berhubungan dengan version lokal; yang
+		// ditimpa adalah row lokal yang baru dibaca.
+		remote.Version = local.Version
+		if err := i.walletRepo.Update(ctx, remote); err != nil {
+			return fmt.Errorf("failed to update wallet %s: %w", remote.Name, err)
+		}
+	}
+	return nil
+}
+
+// mergeCategories membuat kategori yang belum ada.
+// Parent dibuat lebih dulu supaya foreign key parent_id valid.
+func (i *Importer) mergeCategories(ctx context.Context, data *ExportData, result *MergeResult) error {
+	stats := &MergeStats{Entity: EntityCategories}
+	result.Stats = append(result.Stats, stats)
+
+	ordered := make([]*models.Category, 0, len(data.Categories))
+	for _, c := range data.Categories {
+		if !c.IsSubCategory() {
+			ordered = append(ordered, c)
+		}
+	}
+	for _, c := range data.Categories {
+		if c.IsSubCategory() {
+			ordered = append(ordered, c)
+		}
+	}
+
+	for _, remote := range ordered {
+		exists, err := i.categoryRepo.Exists(ctx, remote.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check category %s: %w", remote.Name, err)
+		}
+		if exists {
+			stats.Skipped++
+			continue
+		}
+
+		if err := remote.Validate(); err != nil {
+			return fmt.Errorf("invalid category %q: %w", remote.Name, err)
+		}
+		if err := i.categoryRepo.Create(ctx, remote); err != nil {
+			return fmt.Errorf("failed to create category %s: %w", remote.Name, err)
+		}
+		stats.Created++
+	}
+	return nil
+}
+
+// mergeTransactions menggabungkan transaksi.
+//
+// Saldo wallet tidak dihitung ulang: saldo ikut dari wallet yang di-merge
+// sebelumnya, sama seperti restore backup.
+func (i *Importer) mergeTransactions(ctx context.Context, data *ExportData, result *MergeResult) error {
+	stats := &MergeStats{Entity: EntityTransactions}
+	result.Stats = append(result.Stats, stats)
+
+	for _, remote := range data.Transactions {
+		local, err := i.transactionRepo.GetByID(ctx, remote.ID)
+		if errors.Is(err, repository.ErrNotFound) {
+			if err := i.transactionRepo.Create(ctx, remote); err != nil {
+				return fmt.Errorf("failed to create transaction %s: %w", remote.ID, err)
+			}
+			stats.Created++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get transaction %s: %w", remote.ID, err)
+		}
+
+		if !resolveConflict(result, stats, EntityTransactions, remote.ID, remote.Description, local.UpdatedAt, remote.UpdatedAt) {
+			continue
+		}
+		if err := i.transactionRepo.Update(ctx, remote); err != nil {
+			return fmt.Errorf("failed to update transaction %s: %w", remote.ID, err)
+		}
+	}
+	return nil
+}
+
+// mergeGoals menggabungkan goals.
+func (i *Importer) mergeGoals(ctx context.Context, data *ExportData, result *MergeResult) error {
+	stats := &MergeStats{Entity: EntityGoals}
+	result.Stats = append(result.Stats, stats)
+
+	for _, remote := range data.Goals {
+		local, err := i.goalRepo.GetByID(ctx, remote.ID)
+		if errors.Is(err, repository.ErrNotFound) {
+			if err := i.goalRepo.Create(ctx, remote); err != nil {
+				return fmt.Errorf("failed to create goal %s: %w", remote.Name, err)
+			}
+			stats.Created++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get goal %s: %w", remote.Name, err)
+		}
+
+		if !resolveConflict(result, stats, EntityGoals, remote.ID, remote.Name, local.UpdatedAt, remote.UpdatedAt) {
+			continue
+		}
+		remote.Version = local.Version
+		if err := i.goalRepo.Update(ctx, remote); err != nil {
+			return fmt.Errorf("failed to update goal %s: %w", remote.Name, err)
+		}
+	}
+	return nil
+}
+
+// resolveConflict membandingkan updated_at row yang ada di kedua sisi,
+// mencatat hasilnya di stats/result, dan return true jika row lokal
+// harus ditimpa dengan versi remote.
+func resolveConflict(
+	result *MergeResult,
+	stats *MergeStats,
+	entity string,
+	id uuid.UUID,
+	name string,
+	localUpdatedAt, remoteUpdatedAt time.Time,
+) bool {
+	if remoteUpdatedAt.Equal(localUpdatedAt) {
+		stats.Skipped++
+		return false
+	}
+
+	conflict := MergeConflict{
+		Entity:          entity,
+		ID:              id,
+		Name:            name,
+		LocalUpdatedAt:  localUpdatedAt,
+		RemoteUpdatedAt: remoteUpdatedAt,
+		Kept:            KeptLocal,
+	}
+	if remoteUpdatedAt.After(localUpdatedAt) {
+		conflict.Kept = KeptRemote
+	}
+	result.Conflicts = append(result.Conflicts, conflict)
+
+	if conflict.Kept == KeptRemote {
+		stats.Updated++
+		return true
+	}
+	stats.Skipped++
+	return false
+}
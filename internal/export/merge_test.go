@@ -0,0 +1,201 @@
+package export
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/database"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/postgres"
+)
+
+func newMemoryImporter(store *memory.Store) *Importer {
+	return NewImporter(
+		memory.NewWalletRepository(store),
+		memory.NewTransactionRepository(store),
+		memory.NewCategoryRepository(store),
+		memory.NewGoalRepository(store),
+		memory.NewTransactionManager(store),
+	)
+}
+
+func TestImporter_Merge(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	walletRepo := memory.NewWalletRepository(store)
+	categoryRepo := memory.NewCategoryRepository(store)
+
+	// Data lokal: satu wallet yang sama dengan remote, satu kategori yang sama.
+	local := models.NewWallet("BCA", models.WalletTypeBank)
+	local.Balance = decimal.NewFromInt(100000)
+	if err := walletRepo.Create(ctx, local); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+	stale := models.NewWallet("Cash", models.WalletTypeCash)
+	if err := walletRepo.Create(ctx, stale); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	if err := categoryRepo.Create(ctx, food); err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+
+	localBCA, _ := walletRepo.GetByID(ctx, local.ID)
+	localCash, _ := walletRepo.GetByID(ctx, stale.ID)
+
+	// Remote: BCA lebih baru, Cash lebih lama, plus data baru.
+	remoteBCA := *localBCA
+	remoteBCA.Balance = decimal.NewFromInt(250000)
+	remoteBCA.UpdatedAt = localBCA.UpdatedAt.Add(time.Hour)
+
+	remoteCash := *localCash
+	remoteCash.Name = "Old Cash"
+	remoteCash.UpdatedAt = localCash.UpdatedAt.Add(-time.Hour)
+
+	gopay := models.NewWallet("GoPay", models.WalletTypeEWallet)
+	snacks := models.NewCategory("Snacks", models.CategoryTypeExpense)
+	snacks.ParentID = &food.ID
+	transport := models.NewCategory("Transport", models.CategoryTypeExpense)
+	tx := models.NewTransaction(gopay.ID, models.TransactionTypeExpense, decimal.NewFromInt(15000))
+	tx.CategoryID = &snacks.ID
+	goal := models.NewGoal("Laptop", decimal.NewFromInt(1000000))
+
+	data := &ExportData{
+		Wallets: []*models.Wallet{&remoteBCA, &remoteCash, gopay},
+		// Sub-kategori sengaja ditaruh sebelum parent-nya.
+		Categories:   []*models.Category{snacks, food, transport},
+		Transactions: []*models.Transaction{tx},
+		Goals:        []*models.Goal{goal},
+	}
+
+	result, err := newMemoryImporter(store).Merge(ctx, data)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	want := map[string]MergeStats{
+		EntityWallets:      {Created: 1, Updated: 1, Skipped: 1},
+		EntityCategories:   {Created: 2, Skipped: 1},
+		EntityTransactions: {Created: 1},
+		EntityGoals:        {Created: 1},
+	}
+	for entity, w := range want {
+		got := result.ForEntity(entity)
+		if got.Created != w.Created || got.Updated != w.Updated || got.Skipped != w.Skipped {
+			t.Errorf("%s = %+v, want created %d, updated %d, skipped %d",
+				entity, got, w.Created, w.Updated, w.Skipped)
+		}
+	}
+
+	if len(result.Conflicts) != 2 {
+		t.Fatalf("Conflicts = %+v, want 2", result.Conflicts)
+	}
+	kept := map[uuid.UUID]string{}
+	for _, c := range result.Conflicts {
+		kept[c.ID] = c.Kept
+	}
+	if kept[local.ID] != KeptRemote || kept[stale.ID] != KeptLocal {
+		t.Errorf("conflict resolution = %v, want BCA remote and Cash local", kept)
+	}
+
+	bca, _ := walletRepo.GetByID(ctx, local.ID)
+	if !bca.Balance.Equal(decimal.NewFromInt(250000)) {
+		t.Errorf("BCA balance = %s, want 250000 from newer remote", bca.Balance)
+	}
+	cash, _ := walletRepo.GetByID(ctx, stale.ID)
+	if cash.Name != "Cash" {
+		t.Errorf("Cash name = %q, want local name kept", cash.Name)
+	}
+}
+
+func TestImporter_Merge_RollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	walletRepo := memory.NewWalletRepository(store)
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	// Transaksi ke wallet yang tidak ada → Create gagal (foreign key),
+	// padahal wallet sebelumnya sudah dibuat.
+	orphan := models.NewTransaction(models.NewID(), models.TransactionTypeExpense, decimal.NewFromInt(1000))
+
+	data := &ExportData{
+		Wallets:      []*models.Wallet{wallet},
+		Transactions: []*models.Transaction{orphan},
+	}
+
+	if _, err := newMemoryImporter(store).Merge(ctx, data); err == nil {
+		t.Fatal("Merge() error = nil, want error")
+	}
+
+	if _, err := walletRepo.GetByID(ctx, wallet.ID); err == nil {
+		t.Error("wallet exists after failed merge, want rollback")
+	}
+}
+
+// TestImporter_Merge_RollsBackOnError_Postgres memastikan rollback juga
+// berlaku di postgres, bukan hanya di memory store yang di-snapshot.
+// Set WT_TEST_DATABASE_URL ke database KOSONG khusus test (lihat
+// TestMigrator_Database); semua tabel di-drop di akhir test.
+func TestImporter_Merge_RollsBackOnError_Postgres(t *testing.T) {
+	dbURL := os.Getenv("WT_TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("WT_TEST_DATABASE_URL not set")
+	}
+	ctx := context.Background()
+
+	migrator, err := database.NewMigrator(dbURL, "file://../../migrations")
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	defer migrator.Close()
+	t.Cleanup(func() { _ = migrator.Down() })
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	pool, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		t.Fatalf("pgxpool.New() error = %v", err)
+	}
+	defer pool.Close()
+
+	walletRepo := postgres.NewWalletRepository(pool)
+	transactionRepo := postgres.NewTransactionRepository(pool)
+	importer := NewImporter(
+		walletRepo,
+		transactionRepo,
+		postgres.NewCategoryRepository(pool),
+		postgres.NewGoalRepository(pool),
+		postgres.NewTransactionManager(pool),
+	)
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	tx := models.NewTransaction(wallet.ID, models.TransactionTypeExpense, decimal.NewFromInt(1000))
+	// target_amount 0 melanggar CHECK di tabel goals, jadi mergeGoals
+	// gagal setelah wallet dan transaksi sudah ditulis.
+	goal := models.NewGoal("Laptop", decimal.Zero)
+
+	data := &ExportData{
+		Wallets:      []*models.Wallet{wallet},
+		Transactions: []*models.Transaction{tx},
+		Goals:        []*models.Goal{goal},
+	}
+
+	if _, err := importer.Merge(ctx, data); err == nil {
+		t.Fatal("Merge() error = nil, want error")
+	}
+
+	if _, err := walletRepo.GetByID(ctx, wallet.ID); err == nil {
+		t.Error("wallet exists after failed merge, want rollback")
+	}
+	if _, err := transactionRepo.GetByID(ctx, tx.ID); err == nil {
+		t.Error("transaction exists after failed merge, want rollback")
+	}
+}
@@ -3,38 +3,99 @@ package export
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/jung-kurt/gofpdf"
+	"github.com/shopspring/decimal"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
 // PDFExporter creates professional PDF reports.
 type PDFExporter struct {
 	walletRepo      repository.WalletRepository
 	transactionRepo repository.TransactionRepository
+	currency        string
+
+	// refs diisi lewat SetRefData; nil berarti di-load per export.
+	refs *service.RefData
+
+	// locale untuk tanggal di teks report, lihat SetLocale.
+	locale string
 }
 
 // NewPDFExporter creates a new PDFExporter.
+//
+// currency adalah currency default (app.currency) untuk total dan untuk
+// transaksi yang wallet-nya tidak ditemukan. Nominal per wallet/transaksi
+// memakai currency wallet-nya sendiri.
 func NewPDFExporter(
 	walletRepo repository.WalletRepository,
 	transactionRepo repository.TransactionRepository,
+	currency string,
 ) *PDFExporter {
 	return &PDFExporter{
 		walletRepo:      walletRepo,
 		transactionRepo: transactionRepo,
+		currency:        currency,
+	}
+}
+
+// SetRefData membuat export memakai refs untuk currency wallet, bukan
+// me-load daftar wallet sendiri.
+func (e *PDFExporter) SetRefData(refs *service.RefData) {
+	e.refs = refs
+}
+
+// SetLocale mengatur locale (app.locale) untuk tanggal di teks report.
+// Kosong berarti English.
+func (e *PDFExporter) SetLocale(locale string) {
+	e.locale = locale
+}
+
+// pdfMoney memformat nominal untuk PDF dengan format currency yang sama
+// seperti CLI ("$1,234.56", "Rp 50.000"). Simbol non-ASCII (€, ¥, dll.)
+// diganti kode currency ("EUR 10.00") karena font PDF bawaan tidak punya
+// glyph-nya.
+func pdfMoney(d decimal.Decimal, currency string) string {
+	symbol := utils.CurrencySymbol(currency)
+	for _, r := range symbol {
+		if r >= utf8.RuneSelf {
+			symbol = strings.ToUpper(strings.TrimSpace(currency)) + " "
+			break
+		}
+	}
+
+	if d.Round(utils.CurrencyDecimals(currency)).IsNegative() {
+		return "-" + symbol + utils.FormatAmount(d.Abs(), currency)
 	}
+	return symbol + utils.FormatAmount(d, currency)
 }
 
 // TransactionsToPDF exports transactions to a professional PDF file.
 func (e *PDFExporter) TransactionsToPDF(ctx context.Context, filename string, filter repository.TransactionFilter) error {
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.TransactionsToPDFWriter(ctx, w, filter)
+	})
+}
+
+// TransactionsToPDFWriter menulis laporan transaksi (PDF) ke w.
+func (e *PDFExporter) TransactionsToPDFWriter(ctx context.Context, w io.Writer, filter repository.TransactionFilter) error {
 	// Get data
-	params := repository.ListParams{Limit: 1000, Offset: 0}
-	transactions, err := e.transactionRepo.List(ctx, filter, params)
+	transactions, err := listAllTransactions(ctx, e.transactionRepo, filter)
+	if err != nil {
+		return err
+	}
+
+	refs, err := loadRefData(ctx, e.refs, e.walletRepo, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get transactions: %w", err)
+		return err
 	}
 
 	// Create PDF
@@ -52,20 +113,19 @@ func (e *PDFExporter) TransactionsToPDF(ctx context.Context, filename string, fi
 	pdf.CellFormat(0, 10, "TRANSACTION REPORT", "", 1, "C", false, 0, "")
 
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(0, 6, fmt.Sprintf("Generated: %s", time.Now().Format("02 January 2006, 15:04")), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, generatedLabel(time.Now(), e.locale), "", 1, "C", false, 0, "")
 
 	// Reset colors
 	pdf.SetTextColor(0, 0, 0)
 	pdf.SetY(45)
 
 	// Summary box
-	var totalIncome, totalExpense float64
+	totalIncome, totalExpense := decimal.Zero, decimal.Zero
 	for _, tx := range transactions {
-		amount, _ := tx.Amount.Float64()
 		if tx.Type == models.TransactionTypeIncome {
-			totalIncome += amount
+			totalIncome = totalIncome.Add(tx.Amount)
 		} else {
-			totalExpense += amount
+			totalExpense = totalExpense.Add(tx.Amount)
 		}
 	}
 
@@ -79,18 +139,18 @@ func (e *PDFExporter) TransactionsToPDF(ctx context.Context, filename string, fi
 	pdf.CellFormat(60, 8, "", "", 1, "C", false, 0, "")
 
 	pdf.SetFont("Arial", "", 10)
-	
+
 	// Income
 	pdf.SetTextColor(22, 163, 74) // Green
-	pdf.CellFormat(60, 6, fmt.Sprintf("Income: Rp %.0f", totalIncome), "", 0, "C", false, 0, "")
-	
+	pdf.CellFormat(60, 6, "Income: "+pdfMoney(totalIncome, e.currency), "", 0, "C", false, 0, "")
+
 	// Expense
 	pdf.SetTextColor(220, 38, 38) // Red
-	pdf.CellFormat(60, 6, fmt.Sprintf("Expense: Rp %.0f", totalExpense), "", 0, "C", false, 0, "")
-	
+	pdf.CellFormat(60, 6, "Expense: "+pdfMoney(totalExpense, e.currency), "", 0, "C", false, 0, "")
+
 	// Net
 	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(60, 6, fmt.Sprintf("Net: Rp %.0f", totalIncome-totalExpense), "", 1, "C", false, 0, "")
+	pdf.CellFormat(60, 6, "Net: "+pdfMoney(totalIncome.Sub(totalExpense), e.currency), "", 1, "C", false, 0, "")
 
 	// Table header
 	pdf.SetY(85)
@@ -130,8 +190,7 @@ func (e *PDFExporter) TransactionsToPDF(ctx context.Context, filename string, fi
 		pdf.CellFormat(colWidths[1], 7, typeStr, "1", 0, "C", true, 0, "")
 		pdf.SetTextColor(0, 0, 0)
 
-		amount, _ := tx.Amount.Float64()
-		pdf.CellFormat(colWidths[2], 7, fmt.Sprintf("Rp %.0f", amount), "1", 0, "R", true, 0, "")
+		pdf.CellFormat(colWidths[2], 7, pdfMoney(tx.Amount, refs.WalletCurrency(tx.WalletID, e.currency)), "1", 0, "R", true, 0, "")
 
 		// Truncate description
 		desc := tx.Description
@@ -155,11 +214,84 @@ func (e *PDFExporter) TransactionsToPDF(ctx context.Context, filename string, fi
 	pdf.SetTextColor(150, 150, 150)
 	pdf.CellFormat(0, 10, fmt.Sprintf("Wallet Twin - Total: %d transactions", len(transactions)), "", 0, "C", false, 0, "")
 
-	return pdf.OutputFileAndClose(filename)
+	if err := e.writeBreakdownPage(ctx, pdf, filter); err != nil {
+		return err
+	}
+
+	return pdf.Output(w)
+}
+
+// writeBreakdownPage menambahkan halaman cash flow per tipe wallet dan
+// top merchants.
+func (e *PDFExporter) writeBreakdownPage(ctx context.Context, pdf *gofpdf.Fpdf, filter repository.TransactionFilter) error {
+	flows, merchants, err := loadBreakdown(ctx, e.transactionRepo, filter)
+	if err != nil {
+		return err
+	}
+
+	pdf.AddPage()
+	sectionHeader := func(title string, headers []string, widths []float64) {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.CellFormat(0, 10, title, "", 1, "L", false, 0, "")
+
+		pdf.SetFillColor(79, 70, 229)
+		pdf.SetTextColor(255, 255, 255)
+		pdf.SetFont("Arial", "B", 10)
+		for i, h := range headers {
+			pdf.CellFormat(widths[i], 8, h, "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont("Arial", "", 9)
+	}
+
+	// Cash flow per tipe wallet
+	widths := []float64{60, 60, 60}
+	sectionHeader("CASH FLOW BY WALLET TYPE", []string{"Wallet Type", "Inflow", "Outflow"}, widths)
+	for _, flow := range flows {
+		pdf.CellFormat(widths[0], 7, string(flow.WalletType), "1", 0, "L", false, 0, "")
+		pdf.SetTextColor(22, 163, 74)
+		pdf.CellFormat(widths[1], 7, pdfMoney(flow.Inflow, e.currency), "1", 0, "R", false, 0, "")
+		pdf.SetTextColor(220, 38, 38)
+		pdf.CellFormat(widths[2], 7, pdfMoney(flow.Outflow, e.currency), "1", 1, "R", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
+
+	// Top merchants
+	pdf.Ln(8)
+	if len(merchants) == 0 {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(0, 10, "TOP MERCHANTS", "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 9)
+		pdf.CellFormat(0, 7, "No merchants in this period", "", 1, "L", false, 0, "")
+		return nil
+	}
+
+	widths = []float64{15, 95, 45, 25}
+	sectionHeader("TOP MERCHANTS", []string{"#", "Merchant", "Total", "Count"}, widths)
+	for i, m := range merchants {
+		name := m.Name
+		if len(name) > 50 {
+			name = name[:47] + "..."
+		}
+		pdf.CellFormat(widths[0], 7, fmt.Sprintf("%d", i+1), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[1], 7, name, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 7, pdfMoney(m.Total, e.currency), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 7, fmt.Sprintf("%d", m.Count), "1", 1, "C", false, 0, "")
+	}
+	return nil
 }
 
 // WalletsToPDF exports wallets to a professional PDF file.
 func (e *PDFExporter) WalletsToPDF(ctx context.Context, filename string) error {
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.WalletsToPDFWriter(ctx, w)
+	})
+}
+
+// WalletsToPDFWriter menulis laporan wallets (PDF) ke w.
+func (e *PDFExporter) WalletsToPDFWriter(ctx context.Context, w io.Writer) error {
 	wallets, err := e.walletRepo.List(ctx, repository.WalletFilter{})
 	if err != nil {
 		return fmt.Errorf("failed to get wallets: %w", err)
@@ -179,28 +311,27 @@ func (e *PDFExporter) WalletsToPDF(ctx context.Context, filename string) error {
 	pdf.CellFormat(0, 10, "WALLET SUMMARY", "", 1, "C", false, 0, "")
 
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(0, 6, fmt.Sprintf("Generated: %s", time.Now().Format("02 January 2006, 15:04")), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, generatedLabel(time.Now(), e.locale), "", 1, "C", false, 0, "")
 
 	pdf.SetTextColor(0, 0, 0)
 	pdf.SetY(45)
 
 	// Calculate total
-	var totalBalance float64
+	totalBalance := decimal.Zero
 	for _, w := range wallets {
 		if w.IsActive {
-			bal, _ := w.Balance.Float64()
-			totalBalance += bal
+			totalBalance = totalBalance.Add(w.Balance)
 		}
 	}
 
 	// Total balance box
 	pdf.SetFillColor(16, 185, 129) // Green
 	pdf.RoundedRect(15, 45, 180, 25, 3, "1234", "F")
-	
+
 	pdf.SetY(52)
 	pdf.SetFont("Arial", "B", 14)
 	pdf.SetTextColor(255, 255, 255)
-	pdf.CellFormat(0, 10, fmt.Sprintf("Total Balance: Rp %.0f", totalBalance), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 10, "Total Balance: "+pdfMoney(totalBalance, e.currency), "", 1, "C", false, 0, "")
 
 	// Table
 	pdf.SetY(80)
@@ -237,8 +368,7 @@ func (e *PDFExporter) WalletsToPDF(ctx context.Context, filename string) error {
 		pdf.CellFormat(colWidths[0], 8, name, "1", 0, "L", true, 0, "")
 		pdf.CellFormat(colWidths[1], 8, string(w.Type), "1", 0, "C", true, 0, "")
 
-		balance, _ := w.Balance.Float64()
-		pdf.CellFormat(colWidths[2], 8, fmt.Sprintf("Rp %.0f", balance), "1", 0, "R", true, 0, "")
+		pdf.CellFormat(colWidths[2], 8, pdfMoney(w.Balance, w.Currency), "1", 0, "R", true, 0, "")
 		pdf.CellFormat(colWidths[3], 8, w.Currency, "1", 0, "C", true, 0, "")
 
 		status := "Active"
@@ -256,5 +386,5 @@ func (e *PDFExporter) WalletsToPDF(ctx context.Context, filename string) error {
 	pdf.SetTextColor(150, 150, 150)
 	pdf.CellFormat(0, 10, fmt.Sprintf("Wallet Twin - %d wallets", len(wallets)), "", 0, "C", false, 0, "")
 
-	return pdf.OutputFileAndClose(filename)
+	return pdf.Output(w)
 }
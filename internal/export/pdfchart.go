@@ -0,0 +1,53 @@
+package export
+
+import (
+	"github.com/jung-kurt/gofpdf"
+)
+
+// chartBar adalah satu batang di pdfBarChart.
+type chartBar struct {
+	label string
+	value float64
+}
+
+// pdfBarChart menggambar bar chart sederhana (rectangle gofpdf) di kotak
+// x, y, w, h (mm): satu batang per bar dengan label di bawahnya. Tinggi
+// batang relatif terhadap nilai terbesar; nilai nol atau negatif tidak
+// digambar. Label nilai tidak ditulis supaya chart tetap terbaca walau
+// nominalnya panjang.
+func pdfBarChart(pdf *gofpdf.Fpdf, x, y, w, h float64, bars []chartBar) {
+	if len(bars) == 0 {
+		return
+	}
+
+	const labelHeight = 5
+	plotHeight := h - labelHeight
+
+	peak := 0.0
+	for _, b := range bars {
+		peak = max(peak, b.value)
+	}
+
+	// Garis dasar
+	pdf.SetDrawColor(203, 213, 225)
+	pdf.Line(x, y+plotHeight, x+w, y+plotHeight)
+
+	slot := w / float64(len(bars))
+	barWidth := slot * 0.6
+
+	pdf.SetFont("Arial", "", 7)
+	pdf.SetTextColor(100, 116, 139)
+	pdf.SetFillColor(79, 70, 229)
+	for i, b := range bars {
+		left := x + float64(i)*slot
+		if peak > 0 && b.value > 0 {
+			height := plotHeight * b.value / peak
+			pdf.Rect(left+(slot-barWidth)/2, y+plotHeight-height, barWidth, height, "F")
+		}
+		pdf.SetXY(left, y+plotHeight)
+		pdf.CellFormat(slot, labelHeight, b.label, "", 0, "C", false, 0, "")
+	}
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetDrawColor(0, 0, 0)
+}
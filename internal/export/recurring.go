@@ -0,0 +1,128 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// overdueStyle menandai Next Due yang sudah lewat.
+var overdueStyle = &excelize.Style{
+	Font: &excelize.Font{Bold: true, Color: "DC2626"},
+}
+
+// SetRecurringRepository mengisi repository untuk RecurringToExcel.
+func (e *ExcelExporter) SetRecurringRepository(repo repository.RecurringRepository) {
+	e.recurringRepo = repo
+}
+
+// RecurringToExcel exports recurring transaksi aktif ke file Excel.
+func (e *ExcelExporter) RecurringToExcel(ctx context.Context, filename string) error {
+	return WriteFile(filename, func(w io.Writer) error {
+		return e.RecurringToExcelWriter(ctx, w)
+	})
+}
+
+// RecurringToExcelWriter menulis workbook jadwal recurring (.xlsx) ke w:
+// satu baris per recurring aktif, urut Next Due paling awal. Next Due
+// yang sudah lewat (sebelum hari ini) ditulis merah.
+func (e *ExcelExporter) RecurringToExcelWriter(ctx context.Context, w io.Writer) error {
+	if e.recurringRepo == nil {
+		return errors.New("recurring repository is not set")
+	}
+
+	active := true
+	recurrings, err := e.recurringRepo.List(ctx, repository.RecurringFilter{IsActive: &active})
+	if err != nil {
+		return fmt.Errorf("failed to get recurring transactions: %w", err)
+	}
+	sort.SliceStable(recurrings, func(i, j int) bool {
+		return recurrings[i].NextDue.Before(recurrings[j].NextDue)
+	})
+
+	refs, err := loadRefData(ctx, e.refs, e.walletRepo, e.categoryRepo)
+	if err != nil {
+		return err
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := "Recurring"
+	f.SetSheetName("Sheet1", sheetName)
+
+	// Create styles
+	headerStyleID, _ := f.NewStyle(headerStyle)
+	titleStyleID, _ := f.NewStyle(titleStyle)
+	overdueStyleID, _ := f.NewStyle(overdueStyle)
+	money := newMoneyStyles(f)
+	accents := newAccentStyles(f)
+
+	// Title
+	f.SetCellValue(sheetName, "A1", "🔁 Recurring Schedule")
+	f.SetCellStyle(sheetName, "A1", "A1", titleStyleID)
+	f.MergeCell(sheetName, "A1", "G1")
+
+	now := time.Now()
+	f.SetCellValue(sheetName, "A2", generatedLabel(now, e.locale))
+
+	// Headers
+	headers := []string{"Description", "Type", "Amount", "Frequency", "Next Due", "End Date", "Wallet"}
+	for i, h := range headers {
+		cell := fmt.Sprintf("%c4", 'A'+i)
+		f.SetCellValue(sheetName, cell, h)
+		f.SetCellStyle(sheetName, cell, cell, headerStyleID)
+	}
+
+	// Column widths
+	f.SetColWidth(sheetName, "A", "A", 35)
+	f.SetColWidth(sheetName, "B", "B", 12)
+	f.SetColWidth(sheetName, "C", "C", 18)
+	f.SetColWidth(sheetName, "D", "D", 12)
+	f.SetColWidth(sheetName, "E", "F", 15)
+	f.SetColWidth(sheetName, "G", "G", 25)
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for i, r := range recurrings {
+		row := i + 5
+
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), r.Description)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), string(r.Type))
+
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), excelNumber(r.Amount))
+		base := expenseStyle
+		if r.Type == models.TransactionTypeIncome {
+			base = incomeStyle
+		}
+		f.SetCellStyle(sheetName, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), money.get(base, refs.WalletCurrency(r.WalletID, e.currency)))
+
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), r.Frequency.String())
+
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), r.NextDue.Format("02-Jan-2006"))
+		if r.NextDue.Before(today) {
+			f.SetCellStyle(sheetName, fmt.Sprintf("E%d", row), fmt.Sprintf("E%d", row), overdueStyleID)
+		}
+
+		if r.EndDate != nil {
+			f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), r.EndDate.Format("02-Jan-2006"))
+		}
+
+		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), refs.WalletName(r.WalletID))
+		color := ""
+		if wallet, ok := refs.Wallet(r.WalletID); ok {
+			color = wallet.Color
+		}
+		f.SetCellStyle(sheetName, fmt.Sprintf("G%d", row), fmt.Sprintf("G%d", row), accents.get(color))
+	}
+
+	_, err = f.WriteTo(w)
+	return err
+}
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+)
+
+func TestExcelExporter_RecurringToExcel(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	walletRepo := memory.NewWalletRepository(store)
+	recurringRepo := memory.NewRecurringRepository(store)
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	if err := walletRepo.Create(ctx, wallet); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+
+	now := time.Now()
+	add := func(description string, nextDue time.Time, active bool) {
+		r := models.NewRecurringTransaction(wallet.ID, models.TransactionTypeExpense, decimal.NewFromInt(100000), models.RecurringMonthly, nextDue)
+		r.Description = description
+		r.IsActive = active
+		if err := recurringRepo.Create(ctx, r); err != nil {
+			t.Fatalf("create recurring: %v", err)
+		}
+	}
+	add("Netflix", now.AddDate(0, 0, 10), true)
+	add("Rent", now.AddDate(0, 0, -3), true)
+	add("Gym", now.AddDate(0, 0, 1), false)
+
+	e := NewExcelExporter(walletRepo, memory.NewTransactionRepository(store), memory.NewCategoryRepository(store), "IDR")
+	e.SetRecurringRepository(recurringRepo)
+
+	filename := filepath.Join(t.TempDir(), "recurring.xlsx")
+	if err := e.RecurringToExcel(ctx, filename); err != nil {
+		t.Fatalf("RecurringToExcel() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(filename)
+	if err != nil {
+		t.Fatalf("open workbook: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows("Recurring")
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("got %d rows, want title, generated, blank, header and 2 active recurrings", len(rows))
+	}
+	if rows[4][0] != "Rent" || rows[5][0] != "Netflix" {
+		t.Errorf("descriptions = %q, %q; want Rent then Netflix (next due ascending)", rows[4][0], rows[5][0])
+	}
+	if got := rows[4][6]; got != "BCA" {
+		t.Errorf("wallet = %q, want BCA", got)
+	}
+
+	font := func(cell string) *excelize.Font {
+		t.Helper()
+		id, err := f.GetCellStyle("Recurring", cell)
+		if err != nil {
+			t.Fatalf("GetCellStyle(%s) error = %v", cell, err)
+		}
+		style, err := f.GetStyle(id)
+		if err != nil {
+			t.Fatalf("GetStyle(%s) error = %v", cell, err)
+		}
+		return style.Font
+	}
+	if got := font("E5"); got == nil || got.Color != "DC2626" {
+		t.Errorf("overdue Next Due font = %+v, want red", got)
+	}
+	if got := font("E6"); got != nil && got.Color == "DC2626" {
+		t.Errorf("upcoming Next Due is red, want default")
+	}
+}
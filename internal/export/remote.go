@@ -0,0 +1,148 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportPath adalah endpoint HTTP yang men-stream full backup (ExportData).
+const ExportPath = "/export"
+
+// DefaultRemoteTimeout adalah batas waktu default untuk FetchRemote.
+const DefaultRemoteTimeout = 30 * time.Second
+
+// Error yang bisa di-cek caller saat mengambil data dari instance lain.
+var (
+	// ErrUnauthorized dikembalikan jika token ditolak server remote.
+	ErrUnauthorized = errors.New("remote rejected the token")
+
+	// ErrRemoteTimeout dikembalikan jika server remote tidak merespons tepat waktu.
+	ErrRemoteTimeout = errors.New("remote request timed out")
+)
+
+// NewExportHandler membuat http.Handler untuk GET /export.
+//
+// Request harus membawa header "Authorization: Bearer <token>".
+// Response adalah JSON yang sama dengan `wallet export all`.
+//
+//	mux := http.NewServeMux()
+//	mux.Handle(export.ExportPath, export.NewExportHandler(exporter, token))
+func NewExportHandler(exporter *Exporter, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		if !validToken(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "invalid or missing token")
+			return
+		}
+
+		// ToJSONWriter mengambil semua data sebelum menulis, jadi buffer
+		// dulu supaya error query tetap bisa dibalas dengan status 500.
+		var buf bytes.Buffer
+		if err := exporter.ToJSONWriter(r.Context(), &buf); err != nil {
+			writeError(w, http.StatusInternalServerError, "export failed")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+		_, _ = buf.WriteTo(w)
+	})
+}
+
+// validToken membandingkan bearer token secara constant-time.
+// Token kosong di server selalu ditolak.
+func validToken(r *http.Request, token string) bool {
+	if token == "" {
+		return false
+	}
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+// writeError menulis error dalam format JSON {"error": "..."}.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// FetchRemote mengambil full backup dari instance Wallet Twin lain.
+//
+// Body dibaca dan di-decode sampai selesai sebelum dikembalikan,
+// sehingga response yang terpotong selalu menjadi error dan tidak
+// pernah menghasilkan data parsial.
+//
+// Jika client nil, dipakai http.Client dengan DefaultRemoteTimeout.
+//
+//	data, err := export.FetchRemote(ctx, "http://laptop:8080", token, nil)
+func FetchRemote(ctx context.Context, baseURL, token string, client *http.Client) (*ExportData, error) {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultRemoteTimeout}
+	}
+
+	url := strings.TrimRight(baseURL, "/") + ExportPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote URL %q: %w", baseURL, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, remoteError(url, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return nil, ErrUnauthorized
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("remote returned %s", resp.Status)
+	}
+
+	var data ExportData
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		if isTimeout(err) {
+			return nil, fmt.Errorf("%w while reading %s", ErrRemoteTimeout, url)
+		}
+		return nil, fmt.Errorf("failed to read remote export (incomplete or invalid body): %w", err)
+	}
+
+	return &data, nil
+}
+
+// remoteError menerjemahkan error transport menjadi pesan yang jelas.
+func remoteError(url string, err error) error {
+	if isTimeout(err) {
+		return fmt.Errorf("%w: %s", ErrRemoteTimeout, url)
+	}
+	return fmt.Errorf("failed to reach %s: %w", url, err)
+}
+
+// isTimeout mengecek apakah err disebabkan timeout (client atau context).
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,175 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+)
+
+const testToken = "s3cret"
+
+// newFixtureExporter membuat Exporter di atas memory store berisi
+// satu wallet, satu kategori, satu transaksi dan satu goal.
+func newFixtureExporter(t *testing.T) (*Exporter, *models.Wallet) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+
+	walletRepo := memory.NewWalletRepository(store)
+	categoryRepo := memory.NewCategoryRepository(store)
+	txRepo := memory.NewTransactionRepository(store)
+	goalRepo := memory.NewGoalRepository(store)
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	wallet.Balance = decimal.NewFromInt(150000)
+	category := models.NewCategory("Food", models.CategoryTypeExpense)
+	tx := models.NewTransaction(wallet.ID, models.TransactionTypeExpense, decimal.NewFromInt(50000))
+	tx.CategoryID = &category.ID
+	goal := models.NewGoal("Laptop", decimal.NewFromInt(1000000))
+
+	if err := walletRepo.Create(ctx, wallet); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+	if err := categoryRepo.Create(ctx, category); err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	if err := txRepo.Create(ctx, tx); err != nil {
+		t.Fatalf("create transaction: %v", err)
+	}
+	if err := goalRepo.Create(ctx, goal); err != nil {
+		t.Fatalf("create goal: %v", err)
+	}
+
+	return NewExporter(walletRepo, txRepo, categoryRepo, goalRepo), wallet
+}
+
+func TestExportHandler(t *testing.T) {
+	exporter, wallet := newFixtureExporter(t)
+	handler := NewExportHandler(exporter, testToken)
+
+	tests := []struct {
+		name       string
+		method     string
+		auth       string
+		wantStatus int
+	}{
+		{"valid token", http.MethodGet, "Bearer " + testToken, http.StatusOK},
+		{"missing token", http.MethodGet, "", http.StatusUnauthorized},
+		{"wrong token", http.MethodGet, "Bearer nope", http.StatusUnauthorized},
+		{"wrong scheme", http.MethodGet, "Basic " + testToken, http.StatusUnauthorized},
+		{"wrong method", http.MethodPost, "Bearer " + testToken, http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, ExportPath, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var data ExportData
+			if err := json.NewDecoder(rec.Body).Decode(&data); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if len(data.Wallets) != 1 || data.Wallets[0].ID != wallet.ID {
+				t.Errorf("wallets = %v, want fixture wallet", data.Wallets)
+			}
+			if len(data.Categories) != 1 || len(data.Transactions) != 1 || len(data.Goals) != 1 {
+				t.Errorf("got %d categories, %d transactions, %d goals, want 1 each",
+					len(data.Categories), len(data.Transactions), len(data.Goals))
+			}
+		})
+	}
+}
+
+func TestExportHandler_EmptyTokenRejectsAll(t *testing.T) {
+	exporter, _ := newFixtureExporter(t)
+	handler := NewExportHandler(exporter, "")
+
+	req := httptest.NewRequest(http.MethodGet, ExportPath, nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestFetchRemote(t *testing.T) {
+	exporter, wallet := newFixtureExporter(t)
+	mux := http.NewServeMux()
+	mux.Handle(ExportPath, NewExportHandler(exporter, testToken))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	data, err := FetchRemote(context.Background(), server.URL+"/", testToken, server.Client())
+	if err != nil {
+		t.Fatalf("FetchRemote() error = %v", err)
+	}
+	if len(data.Wallets) != 1 || !data.Wallets[0].Balance.Equal(wallet.Balance) {
+		t.Errorf("wallets = %v, want fixture wallet with balance %s", data.Wallets, wallet.Balance)
+	}
+
+	_, err = FetchRemote(context.Background(), server.URL, "wrong", server.Client())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("FetchRemote() with wrong token error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestFetchRemote_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	_, err := FetchRemote(context.Background(), server.URL, testToken, client)
+	if !errors.Is(err, ErrRemoteTimeout) {
+		t.Errorf("FetchRemote() error = %v, want ErrRemoteTimeout", err)
+	}
+}
+
+func TestFetchRemote_TruncatedBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := `{"exported_at":"2025-01-01T00:00:00Z","version":"1.0.0","wallets":[{"id":`
+		w.Header().Set("Content-Type", "application/json")
+		// Content-Length lebih besar dari body → koneksi ditutup di tengah.
+		w.Header().Set("Content-Length", "4096")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	data, err := FetchRemote(context.Background(), server.URL, testToken, server.Client())
+	if err == nil {
+		t.Fatalf("FetchRemote() = %+v, want error for truncated body", data)
+	}
+	if !strings.Contains(err.Error(), "incomplete") {
+		t.Errorf("error = %v, want mention of incomplete body", err)
+	}
+}
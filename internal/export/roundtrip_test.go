@@ -0,0 +1,288 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+)
+
+// roundTripTransactions lebih dari satu halaman ListParams, supaya
+// export yang hanya membaca halaman pertama ketahuan.
+const roundTripTransactions = 150
+
+// roundTripFixture mengisi store dengan setiap entity backup: setiap
+// field opsional sekali terisi dan sekali nil/kosong, decimal ekstrem
+// (batas NUMERIC(15, 2) dan NUMERIC(20, 8)), timestamp kembar, dan
+// sub-kategori yang di-list sebelum induknya.
+func roundTripFixture(t *testing.T, store *memory.Store) {
+	t.Helper()
+	ctx := context.Background()
+	wallets := memory.NewWalletRepository(store)
+	categories := memory.NewCategoryRepository(store)
+	txs := memory.NewTransactionRepository(store)
+	goals := memory.NewGoalRepository(store)
+
+	// Lokasi non-UTC dan nanosecond: restore dibandingkan per microsecond
+	at := time.Date(2025, 3, 14, 9, 26, 53, 589793238, time.FixedZone("WIB", 7*3600))
+
+	full := models.NewWallet("BCA Utama", models.WalletTypeBank)
+	full.CreatedAt = at
+	full.Balance = decimal.RequireFromString("9999999999999.99")
+	full.Currency = "USD"
+	full.Color = "#1e88e5"
+	full.Icon = "🏦"
+	full.IsActive = false
+	opening := at.AddDate(-1, 0, 0)
+	full.OpeningDate = &opening
+	threshold := decimal.RequireFromString("0.01")
+	full.LowBalanceThreshold = &threshold
+
+	bare := models.NewWallet("Cash", models.WalletTypeCash)
+	bare.CreatedAt = at
+	bare.Balance = decimal.RequireFromString("-9999999999999.99")
+	for _, w := range []*models.Wallet{full, bare} {
+		if err := wallets.Create(ctx, w); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	parent := models.NewCategory("Makan", models.CategoryTypeExpense)
+	parent.Color = "#e53935"
+	parent.Icon = "🍜"
+	parent.SortOrder = 5
+	child := models.NewCategory("Kopi", models.CategoryTypeExpense)
+	child.ParentID = &parent.ID
+	income := models.NewCategory("Gaji", models.CategoryTypeIncome)
+	for _, c := range []*models.Category{parent, child, income} {
+		if err := categories.Create(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for n := range roundTripTransactions {
+		tx := models.NewTransaction(bare.ID, models.TransactionTypeExpense, decimal.NewFromInt(int64(n+1)))
+		// Setiap 10 transaksi berbagi timestamp yang sama
+		tx.TransactionDate = at.AddDate(0, 0, -n/10)
+		tx.CreatedAt = at.Add(-time.Duration(n/10) * time.Hour)
+		switch n % 3 {
+		case 0:
+			tx.Tags = nil
+		case 1:
+			tx.Tags = []string{}
+		case 2:
+			tx.Tags = []string{"kantor", "rutin"}
+		}
+		if err := txs.Create(ctx, tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rich := models.NewTransaction(full.ID, models.TransactionTypeIncome, decimal.RequireFromString("0.01"))
+	rich.CreatedAt = at
+	rich.TransactionDate = at
+	rich.CategoryID = &child.ID
+	rich.Description = "Langganan \"premium\" — café"
+	rich.Note = "baris 1\nbaris 2"
+	rich.Tags = []string{"a", "b,c", ""}
+	rich.Attachment = "receipts/2025/03.pdf"
+	rich.OriginalCurrency = "JPY"
+	original := decimal.RequireFromString("9999999999999.99")
+	rich.OriginalAmount = &original
+	rate := decimal.RequireFromString("999999999999.12345678")
+	rich.RateToBase = &rate
+	rich.IdempotencyKey = "idem-1"
+	rich.ExternalReference = "BCA-REF-0001"
+	if err := txs.Create(ctx, rich); err != nil {
+		t.Fatal(err)
+	}
+
+	fullGoal := models.NewGoal("Dana Darurat", decimal.RequireFromString("9999999999999.99"))
+	fullGoal.CreatedAt = at
+	fullGoal.Description = "6x pengeluaran"
+	fullGoal.CurrentAmount = decimal.RequireFromString("1234567.89")
+	fullGoal.Kind = models.GoalKindNetWorth
+	deadline := at.AddDate(1, 0, 0)
+	fullGoal.Deadline = &deadline
+	fullGoal.Status = models.GoalStatusCompleted
+	fullGoal.Color = "#43a047"
+	fullGoal.Icon = "🎯"
+	fullGoal.SortOrder = 3
+
+	bareGoal := models.NewGoal("Liburan", decimal.RequireFromString("0.01"))
+	bareGoal.CreatedAt = at
+	for _, g := range []*models.Goal{fullGoal, bareGoal} {
+		if err := goals.Create(ctx, g); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Dua kontribusi dengan created_at yang sama
+	for _, note := range []string{"", "bonus"} {
+		c := models.NewContribution(fullGoal.ID, decimal.RequireFromString("617283.94"))
+		c.Note = note
+		c.CreatedAt = at
+		if err := goals.RestoreContribution(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBackup_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := memory.NewStore()
+	roundTripFixture(t, source)
+
+	var backup bytes.Buffer
+	if err := newMemoryExporter(source).ToJSONWriter(ctx, &backup); err != nil {
+		t.Fatalf("ToJSONWriter() error = %v", err)
+	}
+
+	validation, err := ValidateBackup(bytes.NewReader(backup.Bytes()))
+	if err != nil {
+		t.Fatalf("ValidateBackup() error = %v", err)
+	}
+	for _, issue := range validation.Issues {
+		t.Errorf("schema issue: %s", issue)
+	}
+
+	restored := memory.NewStore()
+	result, err := newMemoryImporter(restored).FromJSONReader(ctx, bytes.NewReader(backup.Bytes()))
+	if err != nil {
+		t.Fatalf("FromJSONReader() error = %v", err)
+	}
+	if result.SkippedCount != 0 {
+		t.Fatalf("FromJSONReader() skipped %d rows: %v", result.SkippedCount, result.Errors)
+	}
+
+	want, err := newMemoryExporter(source).collectBackup(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := newMemoryExporter(restored).collectBackup(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(want.Transactions) != roundTripTransactions+1 {
+		t.Fatalf("source has %d transactions, want %d", len(want.Transactions), roundTripTransactions+1)
+	}
+
+	compareRoundTrip(t, "wallet", want.Wallets, got.Wallets, func(w *models.Wallet) uuid.UUID {
+		w.UpdatedAt, w.Version = time.Time{}, 0
+		w.CreatedAt = normalizeTime(w.CreatedAt)
+		w.OpeningDate = normalizeTimePtr(w.OpeningDate)
+		w.Balance = normalizeDecimal(w.Balance)
+		w.LowBalanceThreshold = normalizeDecimalPtr(w.LowBalanceThreshold)
+		return w.ID
+	})
+	compareRoundTrip(t, "category", want.Categories, got.Categories, func(c *models.Category) uuid.UUID {
+		return c.ID
+	})
+	compareRoundTrip(t, "transaction", want.Transactions, got.Transactions, func(tx *models.Transaction) uuid.UUID {
+		tx.UpdatedAt = time.Time{}
+		tx.CreatedAt = normalizeTime(tx.CreatedAt)
+		tx.TransactionDate = normalizeTime(tx.TransactionDate)
+		tx.Amount = normalizeDecimal(tx.Amount)
+		tx.OriginalAmount = normalizeDecimalPtr(tx.OriginalAmount)
+		tx.RateToBase = normalizeDecimalPtr(tx.RateToBase)
+		if len(tx.Tags) == 0 {
+			tx.Tags = nil
+		}
+		return tx.ID
+	})
+	compareRoundTrip(t, "goal", want.Goals, got.Goals, func(g *models.Goal) uuid.UUID {
+		g.UpdatedAt, g.Version = time.Time{}, 0
+		g.CreatedAt = normalizeTime(g.CreatedAt)
+		g.Deadline = normalizeTimePtr(g.Deadline)
+		g.TargetAmount = normalizeDecimal(g.TargetAmount)
+		g.CurrentAmount = normalizeDecimal(g.CurrentAmount)
+		return g.ID
+	})
+	compareRoundTrip(t, "goal contribution", want.GoalContributions, got.GoalContributions, func(c *models.GoalContribution) uuid.UUID {
+		c.CreatedAt = normalizeTime(c.CreatedAt)
+		c.Amount = normalizeDecimal(c.Amount)
+		return c.ID
+	})
+}
+
+// compareRoundTrip membandingkan want dan got per ID setelah normalize.
+//
+// Aturan normalisasi: updated_at dan version di-set ulang oleh restore,
+// jadi diabaikan; timestamp dibandingkan dalam UTC per microsecond
+// (presisi timestamptz); decimal dibandingkan berdasarkan nilai; tags nil
+// sama dengan tags kosong (keduanya tidak ditulis ke JSON).
+func compareRoundTrip[T any](t *testing.T, entity string, want, got []*T, normalize func(*T) uuid.UUID) {
+	t.Helper()
+
+	index := func(rows []*T) (map[uuid.UUID]*T, []uuid.UUID) {
+		byID := make(map[uuid.UUID]*T, len(rows))
+		ids := make([]uuid.UUID, 0, len(rows))
+		for _, row := range rows {
+			id := normalize(row)
+			byID[id] = row
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+		return byID, ids
+	}
+	wantByID, ids := index(want)
+	gotByID, _ := index(got)
+
+	if len(got) != len(want) {
+		t.Errorf("%s: restored %d rows, want %d", entity, len(got), len(want))
+	}
+	for _, id := range ids {
+		g, ok := gotByID[id]
+		if !ok {
+			t.Errorf("%s %s: missing after restore", entity, id)
+			continue
+		}
+		if w := wantByID[id]; !reflect.DeepEqual(w, g) {
+			t.Errorf("%s %s changed on round trip:\n got  %s\n want %s", entity, id, dumpJSON(g), dumpJSON(w))
+		}
+	}
+}
+
+func normalizeTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+func normalizeTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	n := normalizeTime(*t)
+	return &n
+}
+
+// normalizeDecimal membuat representasi internal decimal kanonik
+// (1.50 dan 1.5 punya exponent berbeda), supaya bisa di-DeepEqual.
+func normalizeDecimal(d decimal.Decimal) decimal.Decimal {
+	return decimal.RequireFromString(d.String())
+}
+
+func normalizeDecimalPtr(d *decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return nil
+	}
+	n := normalizeDecimal(*d)
+	return &n
+}
+
+func dumpJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return string(data)
+}
@@ -0,0 +1,289 @@
+package export
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// backupSchemaJSON adalah JSON Schema format backup BackupSchemaVersion.
+// Naikkan nama file bersama BackupSchemaVersion.
+//
+//go:embed schema/backup.v4.schema.json
+var backupSchemaJSON []byte
+
+// BackupSchema mengembalikan JSON Schema (draft 2020-12) format backup
+// yang ditulis build ini.
+func BackupSchema() []byte {
+	return bytes.Clone(backupSchemaJSON)
+}
+
+// SchemaIssue adalah satu pelanggaran schema di backup.
+type SchemaIssue struct {
+	// Path menunjuk ke value yang bermasalah, misalnya
+	// "$.wallets[0].currency".
+	Path string
+
+	// Message menjelaskan masalahnya, misalnya `missing required field "name"`.
+	Message string
+}
+
+// String mengembalikan issue dalam bentuk "path: message".
+func (i SchemaIssue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// BackupValidation adalah hasil ValidateBackup.
+type BackupValidation struct {
+	// SchemaVersion adalah schema version backup sebelum dimigrasi.
+	SchemaVersion int
+
+	// Issues kosong jika backup valid.
+	Issues []SchemaIssue
+}
+
+// Valid mengembalikan true jika backup tidak punya issue.
+func (v *BackupValidation) Valid() bool {
+	return len(v.Issues) == 0
+}
+
+// ValidateBackup mengecek backup dari r terhadap BackupSchema, setelah
+// dimigrasi seperti saat import. Field yang hilang, field yang tidak
+// dikenal, tipe dan format yang salah dilaporkan sebagai Issues. Error
+// hanya dikembalikan jika r bukan backup sama sekali (ErrNotBackup) atau
+// schema-nya lebih baru dari build ini (ErrUnsupportedBackupVersion).
+func ValidateBackup(r io.Reader) (*BackupValidation, error) {
+	envelope, schemaVersion, err := readBackup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := compiledBackupSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate backup: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(migrated))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	result := &BackupValidation{SchemaVersion: schemaVersion}
+	schema.validate(schema, doc, "$", &result.Issues)
+	return result, nil
+}
+
+var compiledBackupSchema = sync.OnceValues(func() (*jsonSchema, error) {
+	var s jsonSchema
+	if err := json.Unmarshal(backupSchemaJSON, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse backup schema: %w", err)
+	}
+	if err := s.compile(); err != nil {
+		return nil, fmt.Errorf("failed to parse backup schema: %w", err)
+	}
+	return &s, nil
+})
+
+// jsonSchema adalah subset JSON Schema yang dipakai backup schema: type,
+// required, properties, additionalProperties, items, enum, pattern dan
+// $ref ke #/$defs. Keyword lain (format, description) diabaikan.
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 schemaTypes            `json:"type"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []json.RawMessage      `json:"enum"`
+	Pattern              string                 `json:"pattern"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+
+	// Diisi oleh compile.
+	pattern    *regexp.Regexp
+	closed     bool
+	additional *jsonSchema
+}
+
+// schemaTypes menerima "type" berupa string atau array string.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("invalid type %s", data)
+	}
+	*t = list
+	return nil
+}
+
+// compile menyiapkan pattern dan additionalProperties secara rekursif.
+func (s *jsonSchema) compile() error {
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+
+	switch ap := bytes.TrimSpace(s.AdditionalProperties); {
+	case len(ap) == 0, string(ap) == "true":
+	case string(ap) == "false":
+		s.closed = true
+	default:
+		s.additional = &jsonSchema{}
+		if err := json.Unmarshal(ap, s.additional); err != nil {
+			return fmt.Errorf("invalid additionalProperties: %w", err)
+		}
+	}
+
+	children := []*jsonSchema{s.Items, s.additional}
+	for _, p := range s.Properties {
+		children = append(children, p)
+	}
+	for _, d := range s.Defs {
+		children = append(children, d)
+	}
+	for _, c := range children {
+		if c == nil {
+			continue
+		}
+		if err := c.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate mengecek v terhadap s dan menambahkan pelanggaran ke issues.
+// root dipakai untuk me-resolve $ref.
+func (s *jsonSchema) validate(root *jsonSchema, v any, path string, issues *[]SchemaIssue) {
+	add := func(format string, args ...any) {
+		*issues = append(*issues, SchemaIssue{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Ref != "" {
+		def, ok := root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if !ok {
+			add("unresolved schema reference %q", s.Ref)
+			return
+		}
+		def.validate(root, v, path, issues)
+		return
+	}
+
+	if len(s.Type) > 0 && !s.matchesType(v) {
+		add("expected %s, got %s", strings.Join(s.Type, " or "), jsonTypeName(v))
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		encoded, _ := json.Marshal(v)
+		found := false
+		allowed := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			allowed[i] = string(e)
+			if bytes.Equal(bytes.TrimSpace(e), encoded) {
+				found = true
+			}
+		}
+		if !found {
+			add("value %s is not one of %s", encoded, strings.Join(allowed, ", "))
+		}
+	}
+
+	switch val := v.(type) {
+	case string:
+		if s.pattern != nil && !s.pattern.MatchString(val) {
+			add("value %q does not match %s", val, s.Pattern)
+		}
+
+	case []any:
+		if s.Items != nil {
+			for i, item := range val {
+				s.Items.validate(root, item, fmt.Sprintf("%s[%d]", path, i), issues)
+			}
+		}
+
+	case map[string]any:
+		for _, field := range s.Required {
+			if _, ok := val[field]; !ok {
+				add("missing required field %q", field)
+			}
+		}
+
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			fieldPath := path + "." + k
+			if prop, ok := s.Properties[k]; ok {
+				prop.validate(root, val[k], fieldPath, issues)
+				continue
+			}
+			switch {
+			case s.closed:
+				add("unknown field %q", k)
+			case s.additional != nil:
+				s.additional.validate(root, val[k], fieldPath, issues)
+			}
+		}
+	}
+}
+
+// matchesType mengecek v terhadap salah satu tipe di s.Type.
+func (s *jsonSchema) matchesType(v any) bool {
+	for _, t := range s.Type {
+		switch got := jsonTypeName(v); {
+		case t == got:
+			return true
+		case t == "number" && got == "integer":
+			return true
+		}
+	}
+	return false
+}
+
+// jsonTypeName mengembalikan nama tipe JSON Schema untuk value hasil
+// decode dengan UseNumber.
+func jsonTypeName(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// Jenis baris di CSV pengeluaran bersama.
+const (
+	sharedKindExpense       = "expense"
+	sharedKindRefund        = "refund"
+	sharedKindReimbursement = "reimbursement"
+)
+
+// WriteSharedExpensesCSV menulis transaksi laporan pengeluaran bersama ke
+// w sebagai CSV, untuk dikirim ke partner. Satu baris per transaksi, urut
+// tanggal; kolom Partner Owes adalah pengaruh baris itu ke
+// SharedExpenseReport.Balance, jadi baris Total di akhir sama dengan
+// Balance.
+func WriteSharedExpensesCSV(w io.Writer, report *service.SharedExpenseReport, refs *service.RefData) error {
+	type row struct {
+		tx     *models.Transaction
+		kind   string
+		amount decimal.Decimal
+		owes   decimal.Decimal
+	}
+
+	var rows []row
+	for _, tx := range report.Expenses {
+		kind := sharedKindExpense
+		if tx.Type == models.TransactionTypeIncome {
+			kind = sharedKindRefund
+		}
+		rows = append(rows, row{tx: tx, kind: kind, amount: tx.AmountInBase(), owes: report.PartnerShareOf(tx)})
+	}
+	for _, tx := range report.Reimbursements {
+		owes := tx.AmountInBase()
+		if tx.Type == models.TransactionTypeIncome {
+			owes = owes.Neg()
+		}
+		rows = append(rows, row{tx: tx, kind: sharedKindReimbursement, amount: tx.AmountInBase(), owes: owes})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].tx.TransactionDate.Before(rows[j].tx.TransactionDate)
+	})
+
+	writer := csv.NewWriter(w)
+	header := []string{"Date", "Kind", "Description", "Category", "Wallet", "Amount", "Partner Owes"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, r := range rows {
+		record := []string{
+			r.tx.TransactionDate.Format("2006-01-02"),
+			r.kind,
+			r.tx.Description,
+			refs.CategoryName(r.tx.CategoryID),
+			refs.WalletName(r.tx.WalletID),
+			r.amount.StringFixed(2),
+			r.owes.StringFixed(2),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+
+	total := []string{"", "Total", "", "", "", report.Total.StringFixed(2), report.Balance().StringFixed(2)}
+	if err := writer.Write(total); err != nil {
+		return fmt.Errorf("failed to write row: %w", err)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,93 @@
+// Package models - BalanceAudit entity
+//
+// BalanceAudit mencatat setiap perubahan saldo wallet: saldo lama, saldo
+// baru, alasan, dan ID entity penyebabnya (transaksi, transfer, goal).
+// Dicatat oleh WalletRepository.UpdateBalance atau AdjustBalance di
+// database transaction yang sama dengan perubahan saldonya, jadi audit
+// tidak pernah tertinggal atau tersisa dari perubahan yang di-rollback.
+//
+// Dengan audit ini pertanyaan "kenapa saldo saya segini?" bisa dijawab
+// dengan menelusuri perubahan saldo satu per satu.
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// BalanceChangeReason adalah alasan perubahan saldo.
+type BalanceChangeReason string
+
+const (
+	// BalanceReasonTransactionCreated: transaksi income/expense dibuat.
+	// ReferenceID adalah ID transaksi.
+	BalanceReasonTransactionCreated BalanceChangeReason = "transaction_created"
+
+	// BalanceReasonTransactionDeleted: transaksi dihapus dan saldonya
+	// dikembalikan. ReferenceID adalah ID transaksi.
+	BalanceReasonTransactionDeleted BalanceChangeReason = "transaction_deleted"
+
+	// BalanceReasonImport: beberapa transaksi dibuat sekaligus
+	// (BulkCreate); satu entry per wallet, tanpa ReferenceID.
+	BalanceReasonImport BalanceChangeReason = "import"
+
+	// BalanceReasonRecurring: recurring yang jatuh tempo diproses
+	// sekaligus (RecurringService.ProcessDue); satu entry per wallet,
+	// tanpa ReferenceID.
+	BalanceReasonRecurring BalanceChangeReason = "recurring"
+
+	// BalanceReasonTransfer: transfer antar wallet. ReferenceID adalah
+	// ID transfer.
+	BalanceReasonTransfer BalanceChangeReason = "transfer"
+
+	// BalanceReasonAdjustment: koreksi saldo (wallet adjust).
+	// ReferenceID adalah ID transaksi adjustment.
+	BalanceReasonAdjustment BalanceChangeReason = "adjustment"
+
+	// BalanceReasonRevaluation: nilai wallet investment diperbarui.
+	// ReferenceID adalah ID transaksi revaluation.
+	BalanceReasonRevaluation BalanceChangeReason = "revaluation"
+
+	// BalanceReasonGoalContribution: surplus dipindahkan ke goal.
+	// ReferenceID adalah ID goal.
+	BalanceReasonGoalContribution BalanceChangeReason = "goal_contribution"
+)
+
+// BalanceChange adalah penyebab satu perubahan saldo, diberikan ke
+// WalletRepository.UpdateBalance dan AdjustBalance.
+//
+//	change := models.BalanceChange{Reason: models.BalanceReasonTransfer, ReferenceID: &transfer.ID}
+type BalanceChange struct {
+	Reason      BalanceChangeReason
+	ReferenceID *uuid.UUID
+}
+
+// BalanceAudit adalah satu perubahan saldo wallet yang tercatat.
+type BalanceAudit struct {
+	// ID adalah unique identifier.
+	ID uuid.UUID `json:"id" db:"id"`
+
+	// WalletID adalah wallet yang saldonya berubah.
+	WalletID uuid.UUID `json:"wallet_id" db:"wallet_id"`
+
+	// OldBalance dan NewBalance adalah saldo sebelum dan sesudah.
+	OldBalance decimal.Decimal `json:"old_balance" db:"old_balance"`
+	NewBalance decimal.Decimal `json:"new_balance" db:"new_balance"`
+
+	// Reason adalah alasan perubahan.
+	Reason BalanceChangeReason `json:"reason" db:"reason"`
+
+	// ReferenceID adalah ID entity penyebab (lihat konstanta Reason).
+	// nil jika tidak ada satu entity tertentu.
+	ReferenceID *uuid.UUID `json:"reference_id,omitempty" db:"reference_id"`
+
+	// CreatedAt adalah waktu perubahan.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Delta mengembalikan NewBalance - OldBalance.
+func (a *BalanceAudit) Delta() decimal.Decimal {
+	return a.NewBalance.Sub(a.OldBalance)
+}
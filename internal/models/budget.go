@@ -16,6 +16,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
 // BudgetPeriod adalah periode budget.
@@ -87,6 +89,11 @@ type Budget struct {
 	// Untuk monthly, biasanya tanggal 1.
 	StartDate time.Time `json:"start_date" db:"start_date"`
 
+	// PeriodStart adalah awal periode yang terakhir di-roll over
+	// (BudgetService.Rollover). StartDate tidak ikut berubah.
+	// Zero = StartDate (di-set oleh Validate).
+	PeriodStart time.Time `json:"period_start" db:"period_start"`
+
 	// EndDate adalah tanggal akhir budget (opsional).
 	// nil = budget berlaku selamanya (recurring).
 	EndDate *time.Time `json:"end_date,omitempty" db:"end_date"`
@@ -94,6 +101,11 @@ type Budget struct {
 	// IsActive menentukan apakah budget aktif.
 	IsActive bool `json:"is_active" db:"is_active"`
 
+	// WarnThreshold adalah persentase (0-100, eksklusif) saat budget mulai
+	// memberi warning. Alert kedua selalu di 100%.
+	// 0 = DefaultWarnThreshold (di-set oleh Validate).
+	WarnThreshold float64 `json:"warn_threshold" db:"warn_threshold"`
+
 	// CreatedAt timestamp.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
@@ -104,9 +116,16 @@ var (
 	ErrBudgetInvalidAmount = errors.New("budget amount must be positive")
 	ErrBudgetInvalidPeriod = errors.New("invalid budget period")
 	ErrBudgetInvalidDates  = errors.New("end date must be after start date")
+	ErrBudgetInvalidWarn   = errors.New("warn threshold must be between 0 and 100 (exclusive)")
 )
 
+// DefaultWarnThreshold adalah persentase warning budget jika
+// WarnThreshold tidak diisi.
+const DefaultWarnThreshold = 80.0
+
 // Validate memvalidasi budget.
+// WarnThreshold 0 diisi dengan DefaultWarnThreshold dan PeriodStart
+// kosong dengan StartDate.
 func (b *Budget) Validate() error {
 	if b.CategoryID == uuid.Nil {
 		return ErrBudgetNoCategory
@@ -120,6 +139,15 @@ func (b *Budget) Validate() error {
 	if b.EndDate != nil && b.EndDate.Before(b.StartDate) {
 		return ErrBudgetInvalidDates
 	}
+	if b.WarnThreshold == 0 {
+		b.WarnThreshold = DefaultWarnThreshold
+	}
+	if b.PeriodStart.IsZero() {
+		b.PeriodStart = b.StartDate
+	}
+	if b.WarnThreshold <= 0 || b.WarnThreshold >= 100 {
+		return ErrBudgetInvalidWarn
+	}
 	return nil
 }
 
@@ -127,14 +155,17 @@ func (b *Budget) Validate() error {
 //
 //	budget := models.NewBudget(foodCategoryID, decimal.NewFromInt(2000000))
 func NewBudget(categoryID uuid.UUID, amount decimal.Decimal) *Budget {
+	now := time.Now()
 	return &Budget{
-		ID:         NewID(),
-		CategoryID: categoryID,
-		Amount:     amount,
-		Period:     BudgetPeriodMonthly,
-		StartDate:  time.Now(),
-		IsActive:   true,
-		CreatedAt:  time.Now(),
+		ID:            NewID(),
+		CategoryID:    categoryID,
+		Amount:        amount,
+		Period:        BudgetPeriodMonthly,
+		StartDate:     now,
+		PeriodStart:   now,
+		IsActive:      true,
+		WarnThreshold: DefaultWarnThreshold,
+		CreatedAt:     now,
 	}
 }
 
@@ -171,3 +202,78 @@ func (b *Budget) GetRemaining(spent decimal.Decimal) decimal.Decimal {
 	}
 	return remaining
 }
+
+// CurrentPeriodStart menghitung awal periode budget yang sedang berjalan
+// pada waktu now, dengan StartDate sebagai anchor.
+//
+// Contoh: budget monthly dengan StartDate 1 Jan, now = 15 Mar
+// → return 1 Mar.
+//
+// Setiap periode dihitung dari StartDate, bukan dari periode sebelumnya,
+// jadi StartDate 31 Jan memberi 28 Feb lalu 31 Mar (bukan 28 Mar).
+//
+//	start := budget.CurrentPeriodStart(time.Now())
+func (b *Budget) CurrentPeriodStart(now time.Time) time.Time {
+	start := b.StartDate
+	for n := 1; ; n++ {
+		next := b.Period.addN(b.StartDate, n)
+		if next.After(now) || !next.After(start) {
+			return start
+		}
+		start = next
+	}
+}
+
+// PeriodWindow mengembalikan rentang [start, end) periode budget yang
+// memuat now; spending budget dihitung di rentang ini. Monthly mengikuti
+// monthStartDay (app.month_start_day) lewat utils.PeriodBounds, weekly
+// dan yearly dihitung dari StartDate (CurrentPeriodStart). start tidak
+// pernah sebelum StartDate.
+//
+//	start, end := budget.PeriodWindow(time.Now(), cfg.App.MonthStartDay)
+func (b *Budget) PeriodWindow(now time.Time, monthStartDay int) (start, end time.Time) {
+	if b.Period == BudgetPeriodMonthly {
+		year, month := utils.PeriodContaining(monthStartDay, now)
+		start, end = utils.PeriodBounds(monthStartDay, year, month, now.Location())
+	} else {
+		start = b.CurrentPeriodStart(now)
+		end = b.Period.AddTo(start)
+	}
+	if start.Before(b.StartDate) {
+		start = b.StartDate
+	}
+	return start, end
+}
+
+// AddTo memajukan t satu periode. Period yang tidak valid mengembalikan
+// t apa adanya. Monthly dan yearly berhenti di akhir bulan jika
+// tanggalnya tidak ada (31 Jan → 28/29 Feb, 29 Feb → 28 Feb), tidak
+// meluber ke bulan berikutnya seperti time.AddDate.
+//
+//	end := models.BudgetPeriodMonthly.AddTo(start) // start + 1 bulan
+func (p BudgetPeriod) AddTo(t time.Time) time.Time {
+	return p.addN(t, 1)
+}
+
+// addN memajukan t sebanyak n periode.
+func (p BudgetPeriod) addN(t time.Time, n int) time.Time {
+	switch p {
+	case BudgetPeriodWeekly:
+		return t.AddDate(0, 0, 7*n)
+	case BudgetPeriodMonthly:
+		return addMonthsClamped(t, n)
+	case BudgetPeriodYearly:
+		return addMonthsClamped(t, 12*n)
+	}
+	return t
+}
+
+// addMonthsClamped menambah n bulan ke t; tanggal dibatasi ke hari
+// terakhir bulan tujuan.
+func addMonthsClamped(t time.Time, n int) time.Time {
+	// Tanggal 1 tidak pernah meluber, jadi aman untuk mencari bulan tujuan
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return time.Date(first.Year(), first.Month(), min(t.Day(), lastDay),
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
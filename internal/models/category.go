@@ -43,6 +43,10 @@ func (t CategoryType) String() string {
 	return string(t)
 }
 
+// AdjustmentCategoryName adalah nama kategori untuk transaksi koreksi saldo.
+// Kategori ini ada untuk kedua tipe (income dan expense), lihat migration 000009.
+const AdjustmentCategoryName = "Adjustment"
+
 // Category merepresentasikan kategori transaksi.
 //
 // Category bisa memiliki parent (untuk sub-kategori).
@@ -81,7 +85,7 @@ type Category struct {
 	// Contoh: "#EF4444" (red), "#10B981" (green)
 	Color string `json:"color,omitempty" db:"color"`
 
-	// Icon adalah emoji atau nama icon.
+	// Icon adalah emoji atau nama icon (lihat NormalizeIcon).
 	// Contoh: "🍔", "💰", "🚗"
 	Icon string `json:"icon,omitempty" db:"icon"`
 
@@ -119,6 +123,12 @@ func (c *Category) Validate() error {
 	if !c.Type.IsValid() {
 		return ErrCategoryInvalidType
 	}
+
+	icon, err := NormalizeIcon(c.Icon)
+	if err != nil {
+		return err
+	}
+	c.Icon = icon
 	return nil
 }
 
@@ -48,6 +48,32 @@ func (s GoalStatus) String() string {
 	return string(s)
 }
 
+// GoalKind adalah jenis goal.
+type GoalKind string
+
+const (
+	// GoalKindSavings untuk goal yang diisi dengan kontribusi
+	GoalKindSavings GoalKind = "savings"
+
+	// GoalKindNetWorth untuk target total saldo semua wallet. CurrentAmount
+	// dihitung dari total saldo saat dibaca, bukan dari kontribusi.
+	GoalKindNetWorth GoalKind = "net_worth"
+)
+
+// IsValid mengecek apakah goal kind valid.
+func (k GoalKind) IsValid() bool {
+	switch k {
+	case GoalKindSavings, GoalKindNetWorth:
+		return true
+	}
+	return false
+}
+
+// String returns string representation.
+func (k GoalKind) String() string {
+	return string(k)
+}
+
 // Goal merepresentasikan target tabungan.
 //
 // Goal adalah entity untuk tracking progress menuju financial goal.
@@ -84,9 +110,13 @@ type Goal struct {
 	TargetAmount decimal.Decimal `json:"target_amount" db:"target_amount"`
 
 	// CurrentAmount adalah jumlah yang sudah terkumpul.
-	// Di-update setiap ada kontribusi.
+	// Di-update setiap ada kontribusi. Untuk GoalKindNetWorth diisi
+	// total saldo wallet oleh GoalService saat dibaca.
 	CurrentAmount decimal.Decimal `json:"current_amount" db:"current_amount"`
 
+	// Kind adalah jenis goal (default GoalKindSavings).
+	Kind GoalKind `json:"kind" db:"kind"`
+
 	// Deadline adalah target tanggal pencapaian (opsional).
 	// nil = tidak ada deadline.
 	Deadline *time.Time `json:"deadline,omitempty" db:"deadline"`
@@ -99,6 +129,14 @@ type Goal struct {
 
 	// Icon.
 	Icon string `json:"icon,omitempty" db:"icon"`
+
+	// SortOrder adalah urutan prioritas (kecil dulu), diatur lewat
+	// `wallet goal reorder`. 0 = belum diurutkan.
+	SortOrder int `json:"sort_order" db:"sort_order"`
+
+	// Version naik setiap goal di-update (optimistic locking, lihat
+	// Wallet.Version).
+	Version int `json:"version" db:"version"`
 }
 
 // GoalContribution merepresentasikan kontribusi ke goal.
@@ -131,12 +169,13 @@ type GoalContribution struct {
 
 // Validation errors
 var (
-	ErrGoalNameRequired     = errors.New("goal name is required")
-	ErrGoalNameTooLong      = errors.New("goal name must be less than 100 characters")
-	ErrGoalInvalidTarget    = errors.New("target amount must be positive")
-	ErrGoalInvalidStatus    = errors.New("invalid goal status")
-	ErrContributionInvalid  = errors.New("contribution amount must be positive")
-	ErrContributionNoGoal   = errors.New("goal is required for contribution")
+	ErrGoalNameRequired    = errors.New("goal name is required")
+	ErrGoalNameTooLong     = errors.New("goal name must be less than 100 characters")
+	ErrGoalInvalidTarget   = errors.New("target amount must be positive")
+	ErrGoalInvalidStatus   = errors.New("invalid goal status")
+	ErrGoalInvalidKind     = errors.New("invalid goal kind")
+	ErrContributionInvalid = errors.New("contribution amount must be positive")
+	ErrContributionNoGoal  = errors.New("goal is required for contribution")
 )
 
 // Validate memvalidasi goal.
@@ -154,6 +193,18 @@ func (g *Goal) Validate() error {
 	if !g.Status.IsValid() {
 		return ErrGoalInvalidStatus
 	}
+	if g.Kind == "" {
+		g.Kind = GoalKindSavings
+	}
+	if !g.Kind.IsValid() {
+		return ErrGoalInvalidKind
+	}
+
+	icon, err := NormalizeIcon(g.Icon)
+	if err != nil {
+		return err
+	}
+	g.Icon = icon
 	return nil
 }
 
@@ -177,6 +228,7 @@ func NewGoal(name string, target decimal.Decimal) *Goal {
 		Name:          name,
 		TargetAmount:  target,
 		CurrentAmount: decimal.Zero,
+		Kind:          GoalKindSavings,
 		Status:        GoalStatusActive,
 	}
 }
@@ -194,6 +246,11 @@ func NewContribution(goalID uuid.UUID, amount decimal.Decimal) *GoalContribution
 	}
 }
 
+// IsNetWorth true jika goal menargetkan total saldo wallet.
+func (g *Goal) IsNetWorth() bool {
+	return g.Kind == GoalKindNetWorth
+}
+
 // GetProgress menghitung persentase progress goal (0-100).
 //
 //	progress := goal.GetProgress() // 75.5
@@ -236,6 +293,50 @@ func (g *Goal) AddContribution(amount decimal.Decimal) {
 	g.CurrentAmount = g.CurrentAmount.Add(amount)
 }
 
+// IsOnTrack mengecek apakah progress tidak tertinggal dari waktu yang
+// sudah berjalan antara CreatedAt dan Deadline, contoh: setengah jalan
+// menuju deadline, minimal setengah target sudah terkumpul.
+// Goal tanpa deadline atau yang sudah tercapai selalu on track; deadline
+// yang sudah lewat tanpa tercapai tidak.
+//
+//	if !goal.IsOnTrack(time.Now()) {
+//	    fmt.Println("behind schedule")
+//	}
+func (g *Goal) IsOnTrack(now time.Time) bool {
+	if g.IsCompleted() || g.Deadline == nil {
+		return true
+	}
+	if !now.Before(*g.Deadline) {
+		return false
+	}
+
+	total := g.Deadline.Sub(g.CreatedAt)
+	if total <= 0 {
+		return false
+	}
+	elapsed := now.Sub(g.CreatedAt).Seconds() / total.Seconds()
+	return g.GetProgress()/100 >= elapsed
+}
+
+// RequiredPerMonth menghitung setoran per bulan supaya target tercapai
+// tepat di deadline: sisa dibagi jumlah bulan sampai deadline (bulan
+// yang belum penuh dihitung satu, minimal satu bulan). Nol jika goal
+// sudah tercapai atau tidak punya deadline.
+//
+//	perMonth := goal.RequiredPerMonth(time.Now()) // 2500000
+func (g *Goal) RequiredPerMonth(now time.Time) decimal.Decimal {
+	remaining := g.GetRemaining()
+	if g.Deadline == nil || remaining.IsZero() {
+		return decimal.Zero
+	}
+
+	months := 1
+	for d := now.AddDate(0, 1, 0); d.Before(*g.Deadline); d = d.AddDate(0, 1, 0) {
+		months++
+	}
+	return remaining.Div(decimal.NewFromInt(int64(months))).Round(2)
+}
+
 // DaysUntilDeadline menghitung hari tersisa sampai deadline.
 // Return -1 jika tidak ada deadline atau sudah lewat.
 //
@@ -0,0 +1,116 @@
+// Package models - Icon untuk wallet, category, dan goal
+//
+// Icon adalah satu emoji/karakter (satu grapheme cluster, termasuk
+// emoji ZWJ seperti 👨‍👩‍👧 dan bendera seperti 🇮🇩) atau nama icon dari
+// IconGroups ("food", "car", ...) yang diganti dengan emoji-nya.
+// Icon yang lebih panjang merusak alignment tabel di CLI dan TUI.
+package models
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// ErrInvalidIcon dikembalikan jika icon bukan satu emoji/karakter
+// dan bukan nama icon yang dikenal.
+var ErrInvalidIcon = errors.New(`icon must be a single emoji or character, or an icon name like "food"`)
+
+// NamedIcon adalah icon dengan nama, bisa dipakai sebagai pengganti emoji
+// (mis. --icon car).
+type NamedIcon struct {
+	Name  string
+	Emoji string
+}
+
+// IconGroup adalah kelompok icon per tema, untuk icon picker.
+type IconGroup struct {
+	Name  string
+	Icons []NamedIcon
+}
+
+// IconGroups adalah icon yang umum dipakai, dikelompokkan per tema.
+var IconGroups = []IconGroup{
+	{Name: "Food & Drink", Icons: []NamedIcon{
+		{"food", "🍔"}, {"restaurant", "🍽️"}, {"coffee", "☕"}, {"drink", "🥤"},
+		{"groceries", "🛒"}, {"snack", "🍪"},
+	}},
+	{Name: "Transport", Icons: []NamedIcon{
+		{"car", "🚗"}, {"motorbike", "🏍️"}, {"bus", "🚌"}, {"train", "🚆"},
+		{"plane", "✈️"}, {"fuel", "⛽"},
+	}},
+	{Name: "Home & Bills", Icons: []NamedIcon{
+		{"home", "🏠"}, {"electricity", "💡"}, {"water", "💧"}, {"phone", "📱"},
+		{"internet", "🌐"}, {"tools", "🔧"},
+	}},
+	{Name: "Money", Icons: []NamedIcon{
+		{"money", "💰"}, {"wallet", "👛"}, {"cash", "💵"}, {"bank", "🏦"}, {"card", "💳"},
+		{"salary", "💼"}, {"savings", "🐷"}, {"investment", "📈"}, {"gift", "🎁"},
+	}},
+	{Name: "Lifestyle", Icons: []NamedIcon{
+		{"shopping", "🛍️"}, {"health", "💊"}, {"education", "📚"}, {"entertainment", "🎬"},
+		{"travel", "🏖️"}, {"sport", "⚽"}, {"pet", "🐾"}, {"baby", "🍼"},
+	}},
+	{Name: "Goals", Icons: []NamedIcon{
+		{"goal", "🎯"}, {"house", "🏡"}, {"laptop", "💻"}, {"wedding", "💍"},
+		{"emergency", "🚑"}, {"star", "⭐"},
+	}},
+}
+
+// iconByName mencari emoji untuk nama icon (case-insensitive).
+func iconByName(name string) (string, bool) {
+	name = strings.ToLower(name)
+	for _, group := range IconGroups {
+		for _, icon := range group.Icons {
+			if icon.Name == name {
+				return icon.Emoji, true
+			}
+		}
+	}
+	return "", false
+}
+
+// NormalizeIcon memvalidasi icon dan mengembalikan bentuk yang disimpan:
+// nama icon diganti emoji-nya, spasi di-trim. Icon kosong valid (tanpa icon).
+//
+//	NormalizeIcon("car")   // "🚗", nil
+//	NormalizeIcon("🇮🇩")    // "🇮🇩", nil
+//	NormalizeIcon("$")     // "$", nil
+//	NormalizeIcon("🍔🍟")   // "", ErrInvalidIcon
+func NormalizeIcon(icon string) (string, error) {
+	icon = strings.TrimSpace(icon)
+	if icon == "" {
+		return "", nil
+	}
+	if emoji, ok := iconByName(icon); ok {
+		return emoji, nil
+	}
+
+	// Tepat satu grapheme cluster yang terlihat. Zero-width character
+	// (ZWJ, ZWSP, combining mark sendirian) punya width 0 atau menjadi
+	// cluster terpisah, jadi ikut ditolak.
+	_, rest, width, _ := uniseg.FirstGraphemeClusterInString(icon, -1)
+	if rest != "" || width == 0 {
+		return "", ErrInvalidIcon
+	}
+	return icon, nil
+}
+
+// DisplayIcon mengembalikan icon untuk ditampilkan. Icon lama yang tidak
+// lolos NormalizeIcon (disimpan sebelum validasi ada) dipotong ke grapheme
+// pertamanya supaya lebar kolom tabel tidak bergeser.
+//
+//	DisplayIcon("🍔🍟") // "🍔"
+//	DisplayIcon("food") // "🍔"
+func DisplayIcon(icon string) string {
+	if normalized, err := NormalizeIcon(icon); err == nil {
+		return normalized
+	}
+
+	cluster, _, width, _ := uniseg.FirstGraphemeClusterInString(strings.TrimSpace(icon), -1)
+	if width == 0 {
+		return ""
+	}
+	return cluster
+}
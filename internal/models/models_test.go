@@ -1,6 +1,8 @@
 package models
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -66,6 +68,39 @@ func TestWallet_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid color",
+			wallet: &Wallet{
+				BaseModel: BaseModel{ID: uuid.New()},
+				Name:      "BCA",
+				Type:      WalletTypeBank,
+				Currency:  "IDR",
+				Color:     "#7c3aed",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid color",
+			wallet: &Wallet{
+				BaseModel: BaseModel{ID: uuid.New()},
+				Name:      "BCA",
+				Type:      WalletTypeBank,
+				Currency:  "IDR",
+				Color:     "purple",
+			},
+			wantErr: true,
+		},
+		{
+			name: "short hex color",
+			wallet: &Wallet{
+				BaseModel: BaseModel{ID: uuid.New()},
+				Name:      "BCA",
+				Type:      WalletTypeBank,
+				Currency:  "IDR",
+				Color:     "#FFF",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -78,6 +113,52 @@ func TestWallet_Validate(t *testing.T) {
 	}
 }
 
+func TestWallet_Validate_NormalizesColor(t *testing.T) {
+	w := &Wallet{Name: "BCA", Type: WalletTypeBank, Currency: "idr", Color: " #7c3aed "}
+	if err := w.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if w.Color != "#7C3AED" {
+		t.Errorf("Color = %q, want #7C3AED", w.Color)
+	}
+}
+
+func TestWallet_LowBalanceThreshold(t *testing.T) {
+	threshold := func(v int64) *decimal.Decimal {
+		d := decimal.NewFromInt(v)
+		return &d
+	}
+
+	tests := []struct {
+		name      string
+		balance   int64
+		threshold *decimal.Decimal
+		wantLow   bool
+		wantErr   error
+	}{
+		{name: "no threshold", balance: 0, wantLow: false},
+		{name: "below", balance: 49999, threshold: threshold(50000), wantLow: true},
+		{name: "equal is not low", balance: 50000, threshold: threshold(50000), wantLow: false},
+		{name: "zero threshold", balance: 0, threshold: threshold(0), wantLow: false},
+		{name: "negative threshold", balance: 100, threshold: threshold(-1), wantErr: ErrWalletNegativeLowBalance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWallet("BCA", WalletTypeBank)
+			w.Balance = decimal.NewFromInt(tt.balance)
+			w.LowBalanceThreshold = tt.threshold
+
+			if err := w.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && w.IsLowBalance() != tt.wantLow {
+				t.Errorf("IsLowBalance() = %v, want %v", w.IsLowBalance(), tt.wantLow)
+			}
+		})
+	}
+}
+
 func TestTransaction_Validate(t *testing.T) {
 	walletID := uuid.New()
 
@@ -142,6 +223,54 @@ func TestTransaction_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid attachment",
+			tx: &Transaction{
+				BaseModel:       BaseModel{ID: uuid.New()},
+				WalletID:        walletID,
+				Type:            TransactionTypeExpense,
+				Amount:          decimal.NewFromInt(50000),
+				TransactionDate: time.Now(),
+				Attachment:      "/home/user/receipts/lunch.jpg",
+			},
+			wantErr: false,
+		},
+		{
+			name: "attachment with path traversal",
+			tx: &Transaction{
+				BaseModel:       BaseModel{ID: uuid.New()},
+				WalletID:        walletID,
+				Type:            TransactionTypeExpense,
+				Amount:          decimal.NewFromInt(50000),
+				TransactionDate: time.Now(),
+				Attachment:      "receipts/../../etc/passwd",
+			},
+			wantErr: true,
+		},
+		{
+			name: "attachment too long",
+			tx: &Transaction{
+				BaseModel:       BaseModel{ID: uuid.New()},
+				WalletID:        walletID,
+				Type:            TransactionTypeExpense,
+				Amount:          decimal.NewFromInt(50000),
+				TransactionDate: time.Now(),
+				Attachment:      "/" + strings.Repeat("a", MaxAttachmentLength),
+			},
+			wantErr: true,
+		},
+		{
+			name: "idempotency key too long",
+			tx: &Transaction{
+				BaseModel:       BaseModel{ID: uuid.New()},
+				WalletID:        walletID,
+				Type:            TransactionTypeExpense,
+				Amount:          decimal.NewFromInt(50000),
+				TransactionDate: time.Now(),
+				IdempotencyKey:  strings.Repeat("k", MaxIdempotencyKeyLength+1),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -154,6 +283,59 @@ func TestTransaction_Validate(t *testing.T) {
 	}
 }
 
+func TestCategory_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		category *Category
+		wantErr  error
+	}{
+		{
+			name:     "valid category",
+			category: &Category{ID: uuid.New(), Name: "Food", Type: CategoryTypeExpense},
+		},
+		{
+			name:     "empty name",
+			category: &Category{ID: uuid.New(), Name: "", Type: CategoryTypeExpense},
+			wantErr:  ErrCategoryNameRequired,
+		},
+		{
+			name:     "whitespace name",
+			category: &Category{ID: uuid.New(), Name: "   ", Type: CategoryTypeIncome},
+			wantErr:  ErrCategoryNameRequired,
+		},
+		{
+			name:     "name too long",
+			category: &Category{ID: uuid.New(), Name: strings.Repeat("a", 101), Type: CategoryTypeExpense},
+			wantErr:  ErrCategoryNameTooLong,
+		},
+		{
+			name:     "invalid type",
+			category: &Category{ID: uuid.New(), Name: "Food", Type: CategoryType("transfer")},
+			wantErr:  ErrCategoryInvalidType,
+		},
+		{
+			name:     "empty type",
+			category: &Category{ID: uuid.New(), Name: "Food"},
+			wantErr:  ErrCategoryInvalidType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.category.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Category.Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCategory_Validate_TrimsName(t *testing.T) {
+	category := NewCategory("  Food  ", CategoryTypeExpense)
+	if err := category.Validate(); err != nil || category.Name != "Food" {
+		t.Errorf("Category.Validate() = %v, Name = %q, want nil and \"Food\"", err, category.Name)
+	}
+}
+
 func TestGoal_GetProgress(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -206,6 +388,74 @@ func TestGoal_IsCompleted(t *testing.T) {
 	}
 }
 
+func TestGoal_IsOnTrack(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	deadline := created.AddDate(0, 0, 100)
+	halfway := created.AddDate(0, 0, 50)
+
+	tests := []struct {
+		name     string
+		current  int64
+		deadline *time.Time
+		now      time.Time
+		want     bool
+	}{
+		{"ahead at halfway", 600000, &deadline, halfway, true},
+		{"exactly at pace", 500000, &deadline, halfway, true},
+		{"behind at halfway", 400000, &deadline, halfway, false},
+		{"deadline passed", 900000, &deadline, deadline.AddDate(0, 0, 1), false},
+		{"completed after deadline", 1000000, &deadline, deadline.AddDate(0, 0, 1), true},
+		{"no deadline", 0, nil, halfway, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Goal{
+				BaseModel:     BaseModel{CreatedAt: created},
+				CurrentAmount: decimal.NewFromInt(tt.current),
+				TargetAmount:  decimal.NewFromInt(1000000),
+				Deadline:      tt.deadline,
+			}
+			if got := g.IsOnTrack(tt.now); got != tt.want {
+				t.Errorf("Goal.IsOnTrack() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoal_RequiredPerMonth(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	threeMonths := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	midMonth := time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)
+	passed := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		current  int64
+		deadline *time.Time
+		want     int64
+	}{
+		{"three months left", 400000, &threeMonths, 200000},
+		{"partial month counts as one", 400000, &midMonth, 300000},
+		{"deadline passed", 400000, &passed, 600000},
+		{"completed", 1000000, &threeMonths, 0},
+		{"no deadline", 0, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Goal{
+				CurrentAmount: decimal.NewFromInt(tt.current),
+				TargetAmount:  decimal.NewFromInt(1000000),
+				Deadline:      tt.deadline,
+			}
+			if got := g.RequiredPerMonth(now); !got.Equal(decimal.NewFromInt(tt.want)) {
+				t.Errorf("Goal.RequiredPerMonth() = %s, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTransfer_TotalDeducted(t *testing.T) {
 	transfer := &Transfer{
 		Amount: decimal.NewFromInt(500000),
@@ -219,3 +469,266 @@ func TestTransfer_TotalDeducted(t *testing.T) {
 		t.Errorf("Transfer.TotalDeducted() = %v, want %v", got, expected)
 	}
 }
+
+func TestBudget_CurrentPeriodStart(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		period BudgetPeriod
+		now    time.Time
+		want   time.Time
+	}{
+		{"monthly same period", BudgetPeriodMonthly, time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), start},
+		{"monthly two periods later", BudgetPeriodMonthly, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"weekly", BudgetPeriodWeekly, time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"yearly", BudgetPeriodYearly, time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"now before start", BudgetPeriodMonthly, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), start},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Budget{Period: tt.period, StartDate: start}
+			if got := b.CurrentPeriodStart(tt.now); !got.Equal(tt.want) {
+				t.Errorf("Budget.CurrentPeriodStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudget_CurrentPeriodStart_MonthEnd(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	b := &Budget{Period: BudgetPeriodMonthly, StartDate: date(2026, 1, 31)}
+
+	// Tanggal 31 tetap jadi anchor: Feb dibatasi ke 28, Mar kembali ke 31
+	tests := []struct {
+		now, want time.Time
+	}{
+		{date(2026, 2, 27), date(2026, 1, 31)},
+		{date(2026, 2, 28), date(2026, 2, 28)},
+		{date(2026, 3, 30), date(2026, 2, 28)},
+		{date(2026, 3, 31), date(2026, 3, 31)},
+		{date(2026, 5, 1), date(2026, 4, 30)},
+	}
+	for _, tt := range tests {
+		if got := b.CurrentPeriodStart(tt.now); !got.Equal(tt.want) {
+			t.Errorf("CurrentPeriodStart(%s) = %s, want %s", tt.now.Format("2006-01-02"), got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestBudget_PeriodWindow(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	now := date(2026, 1, 10)
+
+	tests := []struct {
+		name               string
+		budget             Budget
+		wantStart, wantEnd time.Time
+	}{
+		{"monthly anchor 25", Budget{Period: BudgetPeriodMonthly, StartDate: date(2025, 6, 1)},
+			date(2025, 12, 25), date(2026, 1, 25)},
+		{"monthly started mid period", Budget{Period: BudgetPeriodMonthly, StartDate: date(2026, 1, 5)},
+			date(2026, 1, 5), date(2026, 1, 25)},
+		{"weekly from start date", Budget{Period: BudgetPeriodWeekly, StartDate: date(2026, 1, 1)},
+			date(2026, 1, 8), date(2026, 1, 15)},
+	}
+	for _, tt := range tests {
+		start, end := tt.budget.PeriodWindow(now, 25)
+		if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+			t.Errorf("%s: PeriodWindow() = %s – %s, want %s – %s", tt.name,
+				start.Format("2006-01-02"), end.Format("2006-01-02"),
+				tt.wantStart.Format("2006-01-02"), tt.wantEnd.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestBudgetPeriod_AddTo_MonthEnd(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		period   BudgetPeriod
+		from, to time.Time
+	}{
+		{BudgetPeriodMonthly, date(2026, 1, 31), date(2026, 2, 28)},
+		{BudgetPeriodMonthly, date(2024, 1, 31), date(2024, 2, 29)},
+		{BudgetPeriodMonthly, date(2025, 12, 31), date(2026, 1, 31)},
+		{BudgetPeriodYearly, date(2024, 2, 29), date(2025, 2, 28)},
+		{BudgetPeriodWeekly, date(2026, 1, 31), date(2026, 2, 7)},
+	}
+	for _, tt := range tests {
+		if got := tt.period.AddTo(tt.from); !got.Equal(tt.to) {
+			t.Errorf("%s.AddTo(%s) = %s, want %s", tt.period, tt.from.Format("2006-01-02"), got.Format("2006-01-02"), tt.to.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestBudget_Validate_WarnThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		warn    float64
+		want    float64
+		wantErr bool
+	}{
+		{"zero uses default", 0, DefaultWarnThreshold, false},
+		{"custom", 70, 70, false},
+		{"just below 100", 99.5, 99.5, false},
+		{"negative", -10, 0, true},
+		{"100 is the over-budget alert", 100, 0, true},
+		{"above 100", 150, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBudget(uuid.New(), decimal.NewFromInt(100000))
+			b.WarnThreshold = tt.warn
+
+			err := b.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && b.WarnThreshold != tt.want {
+				t.Errorf("WarnThreshold = %v, want %v", b.WarnThreshold, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeIcon(t *testing.T) {
+	tests := []struct {
+		name    string
+		icon    string
+		want    string
+		wantErr bool
+	}{
+		{"empty", "", "", false},
+		{"emoji", "🍔", "🍔", false},
+		{"emoji with variation selector", "🛍️", "🛍️", false},
+		{"zwj sequence", "👨‍👩‍👧", "👨‍👩‍👧", false},
+		{"skin tone", "👍🏽", "👍🏽", false},
+		{"flag", "🇮🇩", "🇮🇩", false},
+		{"ascii character", "$", "$", false},
+		{"trimmed", " 💰 ", "💰", false},
+		{"named icon", "food", "🍔", false},
+		{"named icon is case-insensitive", "Car", "🚗", false},
+		{"two emoji", "🍔🍟", "", true},
+		{"two flags", "🇮🇩🇺🇸", "", true},
+		{"ascii word", "abc", "", true},
+		{"lone zero-width joiner", "\u200d", "", true},
+		{"zero-width space after emoji", "🍔\u200b", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeIcon(tt.icon)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidIcon) {
+					t.Fatalf("NormalizeIcon(%q) error = %v, want ErrInvalidIcon", tt.icon, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeIcon(%q) error = %v", tt.icon, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeIcon(%q) = %q, want %q", tt.icon, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayIcon(t *testing.T) {
+	tests := []struct {
+		icon string
+		want string
+	}{
+		{"🍔", "🍔"},
+		{"food", "🍔"},
+		{"🍔🍟", "🍔"},
+		{"👨‍👩‍👧👨‍👩‍👧", "👨‍👩‍👧"},
+		{"🇮🇩🇺🇸", "🇮🇩"},
+		{"abc", "a"},
+		{"\u200d🍔", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := DisplayIcon(tt.icon); got != tt.want {
+			t.Errorf("DisplayIcon(%q) = %q, want %q", tt.icon, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_Icon(t *testing.T) {
+	wallet := &Wallet{Name: "BCA", Type: WalletTypeBank, Currency: "IDR", Icon: "bank"}
+	if err := wallet.Validate(); err != nil || wallet.Icon != "🏦" {
+		t.Errorf("Wallet.Validate() = %v, Icon = %q, want nil and 🏦", err, wallet.Icon)
+	}
+
+	category := NewCategory("Food", CategoryTypeExpense)
+	category.Icon = "🍔🍟"
+	if err := category.Validate(); !errors.Is(err, ErrInvalidIcon) {
+		t.Errorf("Category.Validate() error = %v, want ErrInvalidIcon", err)
+	}
+
+	goal := &Goal{Name: "Trip", TargetAmount: decimal.NewFromInt(1000), Status: GoalStatusActive, Icon: "🇯🇵"}
+	if err := goal.Validate(); err != nil || goal.Icon != "🇯🇵" {
+		t.Errorf("Goal.Validate() = %v, Icon = %q, want nil and 🇯🇵", err, goal.Icon)
+	}
+}
+
+func TestRecurringTransaction_AdvanceNextDue_MonthEnd(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 9, 30, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name    string
+		nextDue time.Time
+		want    []time.Time
+	}{
+		{"Jan 31 to Feb 28", date(2025, 1, 31), []time.Time{date(2025, 2, 28), date(2025, 3, 31), date(2025, 4, 30)}},
+		{"leap year", date(2024, 1, 31), []time.Time{date(2024, 2, 29), date(2024, 3, 31)}},
+		{"Mar 31 to Apr 30", date(2025, 3, 31), []time.Time{date(2025, 4, 30), date(2025, 5, 31)}},
+		{"Dec 31 to Jan 31", date(2025, 12, 31), []time.Time{date(2026, 1, 31), date(2026, 2, 28)}},
+		{"Jan 30 to Feb 28", date(2025, 1, 30), []time.Time{date(2025, 2, 28), date(2025, 3, 30)}},
+		{"mid month", date(2025, 1, 15), []time.Time{date(2025, 2, 15), date(2025, 3, 15)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRecurringTransaction(uuid.New(), TransactionTypeExpense, decimal.NewFromInt(100), RecurringMonthly, tt.nextDue)
+			for i, want := range tt.want {
+				r.AdvanceNextDue()
+				if !r.NextDue.Equal(want) {
+					t.Fatalf("advance %d: NextDue = %s, want %s", i+1, r.NextDue.Format(time.DateTime), want.Format(time.DateTime))
+				}
+			}
+		})
+	}
+}
+
+func TestRecurringTransaction_AdvanceNextDue_WithoutDayOfMonth(t *testing.T) {
+	// Recurring lama (DayOfMonth 0) memakai tanggal NextDue saat pertama
+	// dimajukan
+	r := &RecurringTransaction{Frequency: RecurringMonthly, NextDue: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), IsActive: true}
+	r.AdvanceNextDue()
+	r.AdvanceNextDue()
+
+	if want := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC); !r.NextDue.Equal(want) || r.DayOfMonth != 31 {
+		t.Errorf("NextDue = %s, DayOfMonth = %d; want %s and 31", r.NextDue.Format(time.DateOnly), r.DayOfMonth, want.Format(time.DateOnly))
+	}
+
+	// SetNextDue mengganti tanggal asli
+	r.SetNextDue(time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC))
+	r.AdvanceNextDue()
+	if r.NextDue.Day() != 10 || r.DayOfMonth != 10 {
+		t.Errorf("after SetNextDue: NextDue = %s, DayOfMonth = %d; want the 10th", r.NextDue.Format(time.DateOnly), r.DayOfMonth)
+	}
+}
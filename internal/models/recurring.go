@@ -101,6 +101,12 @@ type RecurringTransaction struct {
 	// Ini yang di-check oleh scheduler.
 	NextDue time.Time `json:"next_due" db:"next_due"`
 
+	// DayOfMonth adalah tanggal jatuh tempo asli untuk frequency monthly
+	// (1-31). AdvanceNextDue memakainya supaya recurring tanggal 31 yang
+	// jatuh di 28 Feb kembali ke 31 Mar. 0 = diambil dari NextDue saat
+	// pertama kali dimajukan.
+	DayOfMonth int `json:"day_of_month,omitempty" db:"day_of_month"`
+
 	// EndDate adalah tanggal akhir recurring (opsional).
 	// nil = recurring selamanya.
 	EndDate *time.Time `json:"end_date,omitempty" db:"end_date"`
@@ -114,11 +120,12 @@ type RecurringTransaction struct {
 
 // Validation errors
 var (
-	ErrRecurringNoWallet        = errors.New("wallet is required")
-	ErrRecurringInvalidType     = errors.New("invalid transaction type")
-	ErrRecurringInvalidAmount   = errors.New("amount must be positive")
-	ErrRecurringInvalidFreq     = errors.New("invalid frequency")
-	ErrRecurringInvalidEndDate  = errors.New("end date must be after next due")
+	ErrRecurringNoWallet       = errors.New("wallet is required")
+	ErrRecurringInvalidType    = errors.New("invalid transaction type")
+	ErrRecurringInvalidAmount  = errors.New("amount must be positive")
+	ErrRecurringInvalidFreq    = errors.New("invalid frequency")
+	ErrRecurringInvalidEndDate = errors.New("end date must be after next due")
+	ErrRecurringInvalidDay     = errors.New("day of month must be between 1 and 31")
 )
 
 // Validate memvalidasi recurring transaction.
@@ -138,6 +145,9 @@ func (r *RecurringTransaction) Validate() error {
 	if r.EndDate != nil && r.EndDate.Before(r.NextDue) {
 		return ErrRecurringInvalidEndDate
 	}
+	if r.DayOfMonth < 0 || r.DayOfMonth > 31 {
+		return ErrRecurringInvalidDay
+	}
 	r.Description = strings.TrimSpace(r.Description)
 	return nil
 }
@@ -150,16 +160,27 @@ func NewRecurringTransaction(
 	freq RecurringFrequency,
 	nextDue time.Time,
 ) *RecurringTransaction {
-	return &RecurringTransaction{
+	r := &RecurringTransaction{
 		ID:        NewID(),
 		WalletID:  walletID,
 		Type:      txType,
 		Amount:    amount,
 		Frequency: freq,
-		NextDue:   nextDue,
 		IsActive:  true,
 		CreatedAt: time.Now(),
 	}
+	r.SetNextDue(nextDue)
+	return r
+}
+
+// SetNextDue mengganti jadwal recurring mulai dari t. Untuk frequency
+// monthly, tanggal t menjadi DayOfMonth yang baru.
+func (r *RecurringTransaction) SetNextDue(t time.Time) {
+	r.NextDue = t
+	r.DayOfMonth = 0
+	if r.Frequency == RecurringMonthly {
+		r.DayOfMonth = t.Day()
+	}
 }
 
 // IsDue mengecek apakah recurring sudah jatuh tempo.
@@ -174,6 +195,10 @@ func (r *RecurringTransaction) IsDue() bool {
 // AdvanceNextDue memajukan NextDue ke periode berikutnya.
 // Panggil setelah generate transaction.
 //
+// Monthly tidak memakai AddDate(0, 1, 0), yang menormalisasi 31 Feb
+// menjadi 3 Mar: tanggal DayOfMonth dipakai dan dipotong ke hari
+// terakhir bulan jika bulannya lebih pendek (31 Jan → 28 Feb → 31 Mar).
+//
 //	recurring.AdvanceNextDue()
 func (r *RecurringTransaction) AdvanceNextDue() {
 	switch r.Frequency {
@@ -182,7 +207,10 @@ func (r *RecurringTransaction) AdvanceNextDue() {
 	case RecurringWeekly:
 		r.NextDue = r.NextDue.AddDate(0, 0, 7)
 	case RecurringMonthly:
-		r.NextDue = r.NextDue.AddDate(0, 1, 0)
+		if r.DayOfMonth == 0 {
+			r.DayOfMonth = r.NextDue.Day()
+		}
+		r.NextDue = nextMonthOnDay(r.NextDue, r.DayOfMonth)
 	case RecurringYearly:
 		r.NextDue = r.NextDue.AddDate(1, 0, 0)
 	}
@@ -193,6 +221,20 @@ func (r *RecurringTransaction) AdvanceNextDue() {
 	}
 }
 
+// nextMonthOnDay mengembalikan tanggal day di bulan setelah t, dengan jam
+// dan timezone t. day dipotong ke hari terakhir bulan itu.
+func nextMonthOnDay(t time.Time, day int) time.Time {
+	year, month, _ := t.Date()
+	hour, min, sec := t.Clock()
+
+	// Hari ke-0 bulan berikutnya adalah hari terakhir bulan target
+	lastDay := time.Date(year, month+2, 0, 0, 0, 0, 0, t.Location()).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month+1, day, hour, min, sec, t.Nanosecond(), t.Location())
+}
+
 // ToTransaction mengkonversi recurring ke Transaction.
 // Panggil ini saat generate transaction dari recurring.
 //
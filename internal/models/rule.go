@@ -0,0 +1,153 @@
+// Package models - CategorizationRule entity
+//
+// Rule kategorisasi mengisi kategori transaksi yang dicatat tanpa
+// kategori, berdasarkan wallet, description, atau tag.
+//
+// Contoh:
+// - wallet = Gojek → Transport
+// - description mengandung "spotify" → Entertainment
+// - description cocok dengan regex "^(indomaret|alfamart)" → Groceries
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RuleMatchType adalah cara rule mencocokkan transaksi.
+type RuleMatchType string
+
+const (
+	// RuleMatchWallet cocok jika transaksi ada di wallet WalletID
+	RuleMatchWallet RuleMatchType = "wallet"
+
+	// RuleMatchDescriptionContains cocok jika description mengandung
+	// Pattern (tidak case-sensitive)
+	RuleMatchDescriptionContains RuleMatchType = "description_contains"
+
+	// RuleMatchDescriptionRegex cocok jika description cocok dengan
+	// regex Pattern (tidak case-sensitive)
+	RuleMatchDescriptionRegex RuleMatchType = "description_regex"
+
+	// RuleMatchTag cocok jika transaksi punya tag Pattern
+	RuleMatchTag RuleMatchType = "tag"
+)
+
+// IsValid mengecek apakah match type valid.
+func (t RuleMatchType) IsValid() bool {
+	switch t {
+	case RuleMatchWallet, RuleMatchDescriptionContains, RuleMatchDescriptionRegex, RuleMatchTag:
+		return true
+	}
+	return false
+}
+
+// String returns string representation.
+func (t RuleMatchType) String() string {
+	return string(t)
+}
+
+// MaxRulePatternLength adalah panjang maksimal Pattern. Regex Go (RE2)
+// selalu berjalan linear, jadi batas ini hanya menjaga ukuran regex yang
+// dikompilasi untuk setiap transaksi.
+const MaxRulePatternLength = 200
+
+// CategorizationRule memetakan transaksi yang cocok ke satu kategori.
+// Rule dengan Priority terkecil dicek dulu; rule pertama yang cocok
+// menang.
+//
+//	rule := models.NewCategorizationRule(models.RuleMatchDescriptionContains, transportID)
+//	rule.Pattern = "gojek"
+type CategorizationRule struct {
+	// ID adalah unique identifier.
+	ID uuid.UUID `json:"id" db:"id"`
+
+	// Priority menentukan urutan pengecekan (kecil dulu).
+	Priority int `json:"priority" db:"priority"`
+
+	// MatchType adalah cara rule mencocokkan transaksi.
+	MatchType RuleMatchType `json:"match_type" db:"match_type"`
+
+	// Pattern adalah teks, regex, atau tag yang dicocokkan. Kosong untuk
+	// RuleMatchWallet.
+	Pattern string `json:"pattern,omitempty" db:"pattern"`
+
+	// WalletID adalah wallet untuk RuleMatchWallet, nil untuk match type
+	// lain.
+	WalletID *uuid.UUID `json:"wallet_id,omitempty" db:"wallet_id"`
+
+	// CategoryID adalah kategori yang diberikan ke transaksi yang cocok.
+	CategoryID uuid.UUID `json:"category_id" db:"category_id"`
+
+	// CreatedAt timestamp. Rule dengan Priority sama dicek urut CreatedAt.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Validation errors
+var (
+	ErrRuleInvalidMatchType = errors.New("invalid rule match type")
+	ErrRulePatternRequired  = errors.New("rule pattern is required")
+	ErrRulePatternTooLong   = errors.New("rule pattern must be at most 200 characters")
+	ErrRuleInvalidRegex     = errors.New("invalid rule regex")
+	ErrRuleWalletRequired   = errors.New("wallet is required for a wallet rule")
+	ErrRuleCategoryRequired = errors.New("rule category is required")
+)
+
+// NewCategorizationRule membuat rule baru dengan priority 0.
+func NewCategorizationRule(matchType RuleMatchType, categoryID uuid.UUID) *CategorizationRule {
+	return &CategorizationRule{
+		ID:         NewID(),
+		MatchType:  matchType,
+		CategoryID: categoryID,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Validate memvalidasi rule. Regex dikompilasi di sini supaya rule yang
+// tidak valid ditolak saat dibuat, bukan saat transaksi dicatat.
+func (r *CategorizationRule) Validate() error {
+	if !r.MatchType.IsValid() {
+		return ErrRuleInvalidMatchType
+	}
+	if r.CategoryID == uuid.Nil {
+		return ErrRuleCategoryRequired
+	}
+
+	if r.MatchType == RuleMatchWallet {
+		if r.WalletID == nil || *r.WalletID == uuid.Nil {
+			return ErrRuleWalletRequired
+		}
+		r.Pattern = ""
+		return nil
+	}
+	r.WalletID = nil
+
+	r.Pattern = strings.TrimSpace(r.Pattern)
+	if r.Pattern == "" {
+		return ErrRulePatternRequired
+	}
+	if len(r.Pattern) > MaxRulePatternLength {
+		return ErrRulePatternTooLong
+	}
+	if r.MatchType == RuleMatchDescriptionRegex {
+		if _, err := r.CompileRegex(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CompileRegex mengompilasi Pattern rule RuleMatchDescriptionRegex,
+// tidak case-sensitive.
+func (r *CategorizationRule) CompileRegex() (*regexp.Regexp, error) {
+	re, err := regexp.Compile("(?i)" + r.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRuleInvalidRegex, err)
+	}
+	return re, nil
+}
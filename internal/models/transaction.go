@@ -98,6 +98,11 @@ type Transaction struct {
 	// Contoh: "Makan siang di warteg", "Gaji Januari"
 	Description string `json:"description,omitempty" db:"description"`
 
+	// Note adalah catatan panjang (boleh multi-line), mis. info garansi
+	// atau dengan siapa tagihan di-split. Tidak tampil di tabel list,
+	// hanya di detail dan export lengkap. Maksimal MaxNoteLength byte.
+	Note string `json:"note,omitempty" db:"note"`
+
 	// Tags adalah label tambahan untuk filtering.
 	// Contoh: ["work", "lunch"], ["monthly"]
 	Tags []string `json:"tags,omitempty" db:"tags"`
@@ -106,13 +111,89 @@ type Transaction struct {
 	// Bisa berbeda dengan CreatedAt (backdate transaction).
 	// Contoh: User input hari ini untuk transaksi kemarin.
 	TransactionDate time.Time `json:"transaction_date" db:"transaction_date"`
+
+	// Attachment adalah referensi ke bukti transaksi (foto struk),
+	// berupa path file atau URL. Optional, maksimal 500 karakter.
+	// Contoh: "/home/user/receipts/2025-01-02-lunch.jpg"
+	Attachment string `json:"attachment,omitempty" db:"attachment"`
+
+	// OriginalCurrency adalah currency asli transaksi hasil import CSV
+	// (kolom Currency). Kosong jika tidak diketahui. Jika berbeda dengan
+	// currency wallet, Amount sudah dikonversi ke currency wallet.
+	OriginalCurrency string `json:"original_currency,omitempty" db:"original_currency"`
+
+	// OriginalAmount adalah nominal dalam OriginalCurrency sebelum
+	// dikonversi ke currency wallet (Amount), untuk transaksi yang dicatat
+	// dalam currency asing. nil jika tidak dikonversi.
+	OriginalAmount *decimal.Decimal `json:"original_amount,omitempty" db:"original_amount"`
+
+	// IdempotencyKey adalah key unik dari pemanggil (script, recurring
+	// processor) supaya request yang diulang tidak membuat transaksi dobel.
+	// Optional, maksimal 255 karakter. Kosong berarti tanpa key.
+	// Contoh: "recurring:<id>:2025-01-05"
+	IdempotencyKey string `json:"idempotency_key,omitempty" db:"idempotency_key"`
+
+	// ExternalReference adalah ID transaksi dari bank (FITID di file OFX),
+	// unik per wallet, supaya file bank yang sama tidak di-import dua kali.
+	// Optional, maksimal 255 karakter.
+	ExternalReference string `json:"external_reference,omitempty" db:"external_reference"`
+
+	// RateToBase adalah kurs currency wallet ke currency default
+	// (app.currency) saat transaksi dibuat: Amount * RateToBase adalah
+	// nilainya dalam currency default. nil jika currency sama atau kurs
+	// tidak dikonfigurasi.
+	RateToBase *decimal.Decimal `json:"rate_to_base,omitempty" db:"rate_to_base"`
 }
 
+// MaxAttachmentLength adalah panjang maksimal Transaction.Attachment,
+// sama dengan ukuran kolom attachment di database.
+const MaxAttachmentLength = 500
+
+// MaxNoteLength adalah panjang maksimal Transaction.Note dalam byte.
+const MaxNoteLength = 4096
+
+// MaxIdempotencyKeyLength adalah panjang maksimal Transaction.IdempotencyKey,
+// sama dengan ukuran kolom idempotency_key di database.
+const MaxIdempotencyKeyLength = 255
+
+// MaxExternalReferenceLength adalah panjang maksimal
+// Transaction.ExternalReference, sama dengan ukuran kolom di database.
+const MaxExternalReferenceLength = 255
+
+// TagAdjustment adalah tag khusus untuk transaksi koreksi saldo.
+//
+// Transaksi adjustment dibuat oleh TransactionService.Adjust saat saldo
+// wallet tidak cocok dengan saldo sebenarnya. Tag ini dipakai untuk
+// mengecualikan adjustment dari laporan income/expense.
+const TagAdjustment = "adjustment"
+
+// TagRevaluation adalah tag untuk perubahan nilai pasar wallet investment.
+//
+// Transaksi revaluation dibuat oleh InvestmentService.Revalue dan selalu
+// juga diberi TagAdjustment, sehingga otomatis dikecualikan dari laporan
+// income/expense (TransactionFilter.ExcludeAdjustments). Income berarti
+// nilai naik, expense berarti nilai turun.
+const TagRevaluation = "revaluation"
+
+// TagTransfer adalah tag yang tidak boleh dipakai transaksi biasa.
+//
+// Transfer antar wallet bukan income/expense: uang hanya berpindah, jadi
+// transfer dicatat di tabel transfers (lihat Transfer), bukan sebagai
+// sepasang transaksi. Transaksi dengan tag ini ditolak oleh
+// TransactionService supaya transfer tidak bocor ke ringkasan income/expense.
+const TagTransfer = "transfer"
+
 // Validation errors
 var (
 	ErrTransactionInvalidType   = errors.New("invalid transaction type")
 	ErrTransactionInvalidAmount = errors.New("transaction amount must be positive")
 	ErrTransactionNoWallet      = errors.New("wallet is required")
+	ErrAttachmentTooLong        = errors.New("attachment must be at most 500 characters")
+	ErrAttachmentInvalidPath    = errors.New("attachment path must not contain '..'")
+	ErrIdempotencyKeyTooLong    = errors.New("idempotency key must be at most 255 characters")
+	ErrExternalReferenceTooLong = errors.New("external reference must be at most 255 characters")
+	ErrTransactionInvalidRate   = errors.New("rate to base must be positive")
+	ErrNoteTooLong              = errors.New("note must be at most 4096 bytes")
 )
 
 // Validate memvalidasi transaction.
@@ -127,9 +208,50 @@ func (t *Transaction) Validate() error {
 		return ErrTransactionInvalidAmount
 	}
 	t.Description = strings.TrimSpace(t.Description)
+
+	t.Note = strings.TrimSpace(t.Note)
+	if len(t.Note) > MaxNoteLength {
+		return ErrNoteTooLong
+	}
+
+	t.Attachment = strings.TrimSpace(t.Attachment)
+	if len(t.Attachment) > MaxAttachmentLength {
+		return ErrAttachmentTooLong
+	}
+	// Tolak path traversal supaya attachment tidak menunjuk keluar
+	// dari folder yang dimaksud user (misalnya "receipts/../../etc/passwd")
+	if strings.Contains(t.Attachment, "..") {
+		return ErrAttachmentInvalidPath
+	}
+
+	t.IdempotencyKey = strings.TrimSpace(t.IdempotencyKey)
+	if len(t.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return ErrIdempotencyKeyTooLong
+	}
+
+	t.ExternalReference = strings.TrimSpace(t.ExternalReference)
+	if len(t.ExternalReference) > MaxExternalReferenceLength {
+		return ErrExternalReferenceTooLong
+	}
+
+	if t.RateToBase != nil && !t.RateToBase.IsPositive() {
+		return ErrTransactionInvalidRate
+	}
+	if t.OriginalAmount != nil && !t.OriginalAmount.IsPositive() {
+		return ErrTransactionInvalidAmount
+	}
 	return nil
 }
 
+// AmountInBase mengembalikan Amount dalam currency default memakai
+// RateToBase, atau Amount apa adanya jika RateToBase nil.
+func (t *Transaction) AmountInBase() decimal.Decimal {
+	if t.RateToBase == nil {
+		return t.Amount
+	}
+	return t.Amount.Mul(*t.RateToBase).Round(2)
+}
+
 // NewTransaction membuat transaction baru dengan defaults.
 //
 //	tx := models.NewTransaction(walletID, models.TransactionTypeExpense, decimal.NewFromInt(50000))
@@ -176,6 +298,19 @@ func (t *Transaction) AddTag(tag string) {
 	t.Tags = append(t.Tags, tag)
 }
 
+// RemoveTag menghapus tag dari transaction. Tag yang tidak ada diabaikan.
+//
+//	tx.RemoveTag("lunch")
+func (t *Transaction) RemoveTag(tag string) {
+	tag = strings.TrimSpace(strings.ToLower(tag))
+	for i, existing := range t.Tags {
+		if existing == tag {
+			t.Tags = append(t.Tags[:i], t.Tags[i+1:]...)
+			return
+		}
+	}
+}
+
 // HasTag mengecek apakah transaction memiliki tag tertentu.
 //
 //	if tx.HasTag("work") {
@@ -190,3 +325,14 @@ func (t *Transaction) HasTag(tag string) bool {
 	}
 	return false
 }
+
+// IsAdjustment mengecek apakah transaction adalah koreksi saldo.
+func (t *Transaction) IsAdjustment() bool {
+	return t.HasTag(TagAdjustment)
+}
+
+// IsRevaluation mengecek apakah transaction adalah perubahan nilai pasar
+// wallet investment.
+func (t *Transaction) IsRevaluation() bool {
+	return t.HasTag(TagRevaluation)
+}
@@ -71,6 +71,14 @@ type Transfer struct {
 	// Note adalah catatan transfer.
 	Note string `json:"note,omitempty" db:"note"`
 
+	// ExchangeRate adalah kurs untuk transfer antar wallet beda currency
+	// (nil jika currency sama). Wallet tujuan menerima Amount * ExchangeRate.
+	//
+	// Contoh: transfer USD 100 ke wallet IDR dengan kurs 16.250
+	// - Wallet sumber: -USD 100
+	// - Wallet tujuan: +Rp 1.625.000
+	ExchangeRate *decimal.Decimal `json:"exchange_rate,omitempty" db:"exchange_rate"`
+
 	// CreatedAt timestamp.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
@@ -82,6 +90,7 @@ var (
 	ErrTransferNegativeFee   = errors.New("transfer fee cannot be negative")
 	ErrTransferNoFromWallet  = errors.New("source wallet is required")
 	ErrTransferNoToWallet    = errors.New("destination wallet is required")
+	ErrTransferInvalidRate   = errors.New("exchange rate must be positive")
 )
 
 // Validate memvalidasi transfer.
@@ -101,6 +110,9 @@ func (t *Transfer) Validate() error {
 	if t.Fee.IsNegative() {
 		return ErrTransferNegativeFee
 	}
+	if t.ExchangeRate != nil && !t.ExchangeRate.IsPositive() {
+		return ErrTransferInvalidRate
+	}
 	t.Note = strings.TrimSpace(t.Note)
 	return nil
 }
@@ -130,6 +142,16 @@ func (t *Transfer) TotalDeducted() decimal.Decimal {
 	return t.Amount.Add(t.Fee)
 }
 
+// ReceivedAmount menghitung jumlah yang masuk ke wallet tujuan, dalam
+// currency wallet tujuan: Amount * ExchangeRate, atau Amount jika
+// ExchangeRate nil. Hasil dibulatkan 2 desimal sesuai kolom balance.
+func (t *Transfer) ReceivedAmount() decimal.Decimal {
+	if t.ExchangeRate == nil {
+		return t.Amount
+	}
+	return t.Amount.Mul(*t.ExchangeRate).Round(2)
+}
+
 // SetFee sets the transfer fee.
 // Convenience method dengan validation.
 //
@@ -11,7 +11,9 @@ package models
 
 import (
 	"errors"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -32,6 +34,11 @@ const (
 
 	// WalletTypeEWallet untuk dompet digital
 	WalletTypeEWallet WalletType = "ewallet"
+
+	// WalletTypeInvestment untuk aset yang nilainya berubah mengikuti pasar
+	// (reksa dana, emas, saham). Perubahan nilai dicatat lewat revaluation,
+	// bukan income/expense.
+	WalletTypeInvestment WalletType = "investment"
 )
 
 // IsValid mengecek apakah wallet type valid.
@@ -41,7 +48,7 @@ const (
 //	}
 func (t WalletType) IsValid() bool {
 	switch t {
-	case WalletTypeCash, WalletTypeBank, WalletTypeEWallet:
+	case WalletTypeCash, WalletTypeBank, WalletTypeEWallet, WalletTypeInvestment:
 		return true
 	}
 	return false
@@ -106,31 +113,70 @@ type Wallet struct {
 	Color string `json:"color,omitempty" db:"color"`
 
 	// Icon adalah emoji atau nama icon.
-	// Optional.
+	// Optional. Nama icon diganti emoji-nya saat Validate (lihat NormalizeIcon).
 	// Contoh: "💰", "🏦", "wallet"
 	Icon string `json:"icon,omitempty" db:"icon"`
 
 	// IsActive menentukan apakah wallet ditampilkan.
 	// FALSE = soft deleted (tersembunyi tapi data tetap ada)
 	IsActive bool `json:"is_active" db:"is_active"`
+
+	// OpeningDate adalah tanggal wallet mulai dipakai, jika berbeda dengan
+	// CreatedAt (misalnya rekening lama yang baru dicatat).
+	// Optional. NULL = pakai CreatedAt (lihat StartDate).
+	OpeningDate *time.Time `json:"opening_date,omitempty" db:"opening_date"`
+
+	// LowBalanceThreshold adalah batas saldo rendah: wallet muncul di
+	// alert (lihat IsLowBalance) jika Balance di bawah nilai ini.
+	// Optional. NULL = tidak ada alert.
+	LowBalanceThreshold *decimal.Decimal `json:"low_balance_threshold,omitempty" db:"low_balance_threshold"`
+
+	// Version naik setiap wallet di-update (optimistic locking).
+	// Repository.Update hanya berhasil jika Version masih sama dengan
+	// yang tersimpan; selain itu return repository.ErrConflict.
+	Version int `json:"version" db:"version"`
+}
+
+// StartDate mengembalikan tanggal mulai wallet: OpeningDate jika diisi,
+// selain itu CreatedAt. Transaksi sebelum tanggal ini tidak masuk akal
+// untuk laporan balance-as-of.
+func (w *Wallet) StartDate() time.Time {
+	if w.OpeningDate != nil {
+		return *w.OpeningDate
+	}
+	return w.CreatedAt
+}
+
+// IsLowBalance mengecek apakah saldo wallet di bawah LowBalanceThreshold.
+// Selalu false jika threshold tidak diisi.
+func (w *Wallet) IsLowBalance() bool {
+	return w.LowBalanceThreshold != nil && w.Balance.LessThan(*w.LowBalanceThreshold)
 }
 
 // Validation errors
 var (
-	ErrWalletNameRequired    = errors.New("wallet name is required")
-	ErrWalletNameTooLong     = errors.New("wallet name must be less than 100 characters")
-	ErrWalletInvalidType     = errors.New("invalid wallet type")
-	ErrWalletInvalidCurrency = errors.New("currency must be a 3-letter ISO code")
-	ErrWalletNegativeBalance = errors.New("wallet balance cannot be negative")
+	ErrWalletNameRequired       = errors.New("wallet name is required")
+	ErrWalletNameTooLong        = errors.New("wallet name must be less than 100 characters")
+	ErrWalletInvalidType        = errors.New("invalid wallet type")
+	ErrWalletInvalidCurrency    = errors.New("currency must be a 3-letter ISO code")
+	ErrWalletNegativeBalance    = errors.New("wallet balance cannot be negative")
+	ErrWalletInvalidColor       = errors.New("wallet color must be a hex code like #7C3AED")
+	ErrWalletNegativeLowBalance = errors.New("low balance threshold cannot be negative")
 )
 
+// walletColorPattern adalah format warna wallet: #RRGGBB.
+var walletColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
 // Validate memvalidasi wallet sebelum disimpan.
 //
 // Validasi yang dilakukan:
 // - Name tidak kosong dan tidak terlalu panjang
-// - Type valid (cash, bank, ewallet)
+// - Type valid (cash, bank, ewallet, investment)
 // - Currency 3 karakter
 // - Balance tidak negatif
+// - Color kosong atau hex #RRGGBB (dinormalisasi ke uppercase)
+// - LowBalanceThreshold kosong atau tidak negatif
+// - Icon kosong, satu emoji, atau nama icon (lihat NormalizeIcon)
 //
 // Contoh:
 //
@@ -163,6 +209,27 @@ func (w *Wallet) Validate() error {
 		return ErrWalletNegativeBalance
 	}
 
+	// Validate color (optional, #RRGGBB)
+	w.Color = strings.TrimSpace(w.Color)
+	if w.Color != "" {
+		if !walletColorPattern.MatchString(w.Color) {
+			return ErrWalletInvalidColor
+		}
+		w.Color = strings.ToUpper(w.Color)
+	}
+
+	// Validate low balance threshold (optional)
+	if w.LowBalanceThreshold != nil && w.LowBalanceThreshold.IsNegative() {
+		return ErrWalletNegativeLowBalance
+	}
+
+	// Validate icon (optional, satu emoji atau nama icon)
+	icon, err := NormalizeIcon(w.Icon)
+	if err != nil {
+		return err
+	}
+	w.Icon = icon
+
 	return nil
 }
 
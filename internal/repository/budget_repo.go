@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/google/uuid"
@@ -29,8 +30,10 @@ type BudgetRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// GetBudgetStatus menghitung status semua budget aktif.
-	// Membandingkan budget amount dengan actual spending.
-	GetBudgetStatus(ctx context.Context) ([]*BudgetStatus, error)
+	// Membandingkan budget amount dengan actual spending di periode
+	// yang memuat now (lihat Budget.PeriodWindow); periode monthly
+	// dimulai di monthStartDay.
+	GetBudgetStatus(ctx context.Context, now time.Time, monthStartDay int) ([]*BudgetStatus, error)
 }
 
 // BudgetFilter adalah filter untuk query budgets.
@@ -56,6 +59,11 @@ type BudgetStatus struct {
 	// CategoryIcon adalah icon kategori.
 	CategoryIcon string
 
+	// PeriodStart dan PeriodEnd adalah window Spent dihitung
+	// (Budget.PeriodWindow); PeriodEnd eksklusif.
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+
 	// Spent adalah jumlah yang sudah dikeluarkan.
 	Spent decimal.Decimal
 
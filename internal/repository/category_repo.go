@@ -19,6 +19,16 @@ type CategoryRepository interface {
 	// GetByID mengambil category berdasarkan ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Category, error)
 
+	// Exists mengecek apakah category dengan ID tersebut ada.
+	// Lebih ringan dari GetByID jika hanya butuh validasi referensi.
+	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// GetByName mengambil category berdasarkan nama (case-insensitive, exact match).
+	// Jika ada beberapa kategori dengan nama sama, top-level category
+	// didahulukan, lalu diurutkan type, sort_order.
+	// Return ErrNotFound jika tidak ada.
+	GetByName(ctx context.Context, name string) (*models.Category, error)
+
 	// GetByType mengambil semua kategori berdasarkan tipe (income/expense).
 	// Ini yang paling sering digunakan untuk populate dropdown.
 	GetByType(ctx context.Context, catType models.CategoryType) ([]*models.Category, error)
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/google/uuid"
@@ -16,10 +17,18 @@ type GoalRepository interface {
 	// GetByID mengambil goal berdasarkan ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Goal, error)
 
-	// List mengambil semua goals dengan filter.
+	// Exists mengecek apakah goal dengan ID tersebut ada.
+	// Lebih ringan dari GetByID jika hanya butuh validasi referensi.
+	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// List mengambil semua goals dengan filter, diurutkan
+	// sort_order ASC, created_at DESC.
 	List(ctx context.Context, filter GoalFilter) ([]*models.Goal, error)
 
-	// Update memperbarui goal.
+	// Update memperbarui goal. Return ErrConflict jika goal.Version sudah
+	// tidak sama dengan yang tersimpan; jika berhasil, goal.Version
+	// dinaikkan. AddContribution, UpdateCurrentAmount, dan UpdateSortOrder
+	// juga menaikkan version.
 	Update(ctx context.Context, goal *models.Goal) error
 
 	// Delete menghapus goal.
@@ -29,11 +38,25 @@ type GoalRepository interface {
 	// Ini atomic operation yang juga update current_amount.
 	AddContribution(ctx context.Context, contribution *models.GoalContribution) error
 
-	// GetContributions mengambil history kontribusi untuk goal.
+	// RestoreContribution menyimpan kontribusi dari backup apa adanya
+	// (termasuk created_at) TANPA mengubah current_amount goal, karena
+	// current_amount di backup sudah termasuk kontribusi ini.
+	RestoreContribution(ctx context.Context, contribution *models.GoalContribution) error
+
+	// GetContributions mengambil history kontribusi untuk goal, terbaru
+	// dulu.
 	GetContributions(ctx context.Context, goalID uuid.UUID, params ListParams) ([]*models.GoalContribution, error)
 
 	// UpdateCurrentAmount mengupdate current_amount goal.
 	UpdateCurrentAmount(ctx context.Context, id uuid.UUID, amount decimal.Decimal) error
+
+	// UpdateSortOrder mengupdate sort_order goal. Row goal di-lock
+	// (FOR UPDATE) sampai transaction selesai.
+	UpdateSortOrder(ctx context.Context, id uuid.UUID, sortOrder int) error
+
+	// ListCompletedInPeriod mengambil goal berstatus completed dengan
+	// updated_at di antara from dan to (inklusif), terbaru dulu.
+	ListCompletedInPeriod(ctx context.Context, from, to time.Time) ([]*models.Goal, error)
 }
 
 // GoalFilter adalah filter untuk query goals.
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// IntegrityRepository mendefinisikan query untuk mendeteksi (dan memperbaiki)
+// data yang tidak konsisten, dipakai oleh `wallet doctor`.
+//
+// Foreign key di schema seharusnya mencegah orphan rows, tapi database yang
+// di-restore manual, di-migrate sebagian, atau diedit langsung bisa saja
+// kehilangan constraint tersebut. Orphan rows membuat total dan progress
+// diam-diam salah.
+type IntegrityRepository interface {
+	// FindOrphanContributions mengambil goal_contributions yang goal_id-nya
+	// tidak ada di tabel goals.
+	FindOrphanContributions(ctx context.Context) ([]*IntegrityIssue, error)
+
+	// FindMissingCategoryRefs mengambil transactions, recurring_transactions,
+	// dan budgets yang category_id-nya tidak ada di tabel categories.
+	FindMissingCategoryRefs(ctx context.Context) ([]*IntegrityIssue, error)
+
+	// FindMissingWalletRefs mengambil transactions yang wallet_id-nya
+	// tidak ada di tabel wallets.
+	FindMissingWalletRefs(ctx context.Context) ([]*IntegrityIssue, error)
+
+	// FindImportedCurrencyMismatches mengambil wallet yang berisi transaksi
+	// import dengan original_currency berbeda dari currency wallet. Satu
+	// issue per wallet per currency asal; MissingID selalu uuid.Nil.
+	FindImportedCurrencyMismatches(ctx context.Context) ([]*IntegrityIssue, error)
+
+	// FindGoalAmountMismatches mengambil goals yang current_amount-nya
+	// tidak sama dengan total kontribusinya.
+	FindGoalAmountMismatches(ctx context.Context) ([]*GoalAmountMismatch, error)
+
+	// ClearMissingCategoryRefs men-set NULL category_id yang tidak valid
+	// di transactions dan recurring_transactions.
+	// Budgets tidak disentuh karena category_id budget wajib diisi.
+	// Return jumlah row yang diperbaiki.
+	ClearMissingCategoryRefs(ctx context.Context) (int, error)
+
+	// RecomputeGoalAmounts men-set current_amount setiap goal yang
+	// tidak cocok menjadi total kontribusinya.
+	// Return jumlah goal yang diperbaiki.
+	RecomputeGoalAmounts(ctx context.Context) (int, error)
+}
+
+// IntegrityIssue adalah satu row yang mereferensikan data yang tidak ada.
+type IntegrityIssue struct {
+	// Table adalah tabel tempat row bermasalah berada.
+	Table string
+
+	// ID adalah primary key row bermasalah.
+	ID uuid.UUID
+
+	// MissingID adalah foreign key yang tidak ditemukan.
+	MissingID uuid.UUID
+
+	// Detail adalah konteks tambahan untuk laporan (deskripsi, jumlah, dll).
+	Detail string
+}
+
+// GoalAmountMismatch adalah goal yang current_amount-nya tidak cocok
+// dengan total kontribusi.
+type GoalAmountMismatch struct {
+	GoalID          uuid.UUID
+	GoalName        string
+	CurrentAmount   decimal.Decimal
+	ContributionSum decimal.Decimal
+}
@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// budgetRepository adalah implementasi in-memory untuk BudgetRepository.
+type budgetRepository struct {
+	store *Store
+}
+
+// NewBudgetRepository membuat BudgetRepository in-memory.
+func NewBudgetRepository(store *Store) repository.BudgetRepository {
+	return &budgetRepository{store: store}
+}
+
+// copyBudget meng-copy budget termasuk pointer EndDate.
+func copyBudget(b *models.Budget) *models.Budget {
+	out := *b
+	if b.EndDate != nil {
+		end := *b.EndDate
+		out.EndDate = &end
+	}
+	return &out
+}
+
+// Create menyimpan budget baru.
+func (r *budgetRepository) Create(ctx context.Context, budget *models.Budget) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.budgets[budget.ID]; ok {
+		return repository.ErrDuplicateKey
+	}
+	if _, ok := r.store.categories[budget.CategoryID]; !ok {
+		return repository.ErrForeignKeyViolation
+	}
+
+	if budget.CreatedAt.IsZero() {
+		budget.CreatedAt = time.Now()
+	}
+
+	r.store.budgets[budget.ID] = copyBudget(budget)
+	return nil
+}
+
+// GetByID mengambil budget berdasarkan ID.
+func (r *budgetRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Budget, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	b, ok := r.store.budgets[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return copyBudget(b), nil
+}
+
+// GetByCategory mengambil budget aktif terbaru untuk kategori.
+func (r *budgetRepository) GetByCategory(ctx context.Context, categoryID uuid.UUID) (*models.Budget, error) {
+	isActive := true
+	budgets, _ := r.List(ctx, repository.BudgetFilter{IsActive: &isActive, CategoryID: &categoryID})
+	if len(budgets) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return budgets[0], nil
+}
+
+// List mengambil budgets dengan filter, diurutkan created_at DESC.
+func (r *budgetRepository) List(ctx context.Context, filter repository.BudgetFilter) ([]*models.Budget, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var budgets []*models.Budget
+	for _, b := range r.store.budgets {
+		if filter.IsActive != nil && b.IsActive != *filter.IsActive {
+			continue
+		}
+		if filter.CategoryID != nil && b.CategoryID != *filter.CategoryID {
+			continue
+		}
+		if filter.Period != nil && b.Period != *filter.Period {
+			continue
+		}
+		budgets = append(budgets, copyBudget(b))
+	}
+
+	sort.Slice(budgets, func(i, j int) bool {
+		return budgets[i].CreatedAt.After(budgets[j].CreatedAt)
+	})
+	return budgets, nil
+}
+
+// Update memperbarui budget.
+func (r *budgetRepository) Update(ctx context.Context, budget *models.Budget) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.budgets[budget.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	if _, ok := r.store.categories[budget.CategoryID]; !ok {
+		return repository.ErrForeignKeyViolation
+	}
+
+	r.store.budgets[budget.ID] = copyBudget(budget)
+	return nil
+}
+
+// Delete menghapus budget.
+func (r *budgetRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.budgets[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.store.budgets, id)
+	return nil
+}
+
+// GetBudgetStatus menghitung status semua budget aktif.
+//
+// Spent = total expense di kategori budget dalam Budget.PeriodWindow
+// (dan sampai EndDate jika ada).
+func (r *budgetRepository) GetBudgetStatus(ctx context.Context, now time.Time, monthStartDay int) ([]*repository.BudgetStatus, error) {
+	isActive := true
+	budgets, _ := r.List(ctx, repository.BudgetFilter{IsActive: &isActive})
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var statuses []*repository.BudgetStatus
+	for _, b := range budgets {
+		category, ok := r.store.categories[b.CategoryID]
+		if !ok {
+			continue
+		}
+
+		start, end := b.PeriodWindow(now, monthStartDay)
+		spent := decimal.Zero
+		for _, tx := range r.store.transactions {
+			if tx.CategoryID == nil || *tx.CategoryID != b.CategoryID ||
+				tx.Type != models.TransactionTypeExpense ||
+				tx.TransactionDate.Before(start) || !tx.TransactionDate.Before(end) ||
+				(b.EndDate != nil && tx.TransactionDate.After(*b.EndDate)) {
+				continue
+			}
+			spent = spent.Add(tx.Amount)
+		}
+
+		s := &repository.BudgetStatus{
+			Budget:       b,
+			CategoryName: category.Name,
+			CategoryIcon: category.Icon,
+			PeriodStart:  start,
+			PeriodEnd:    end,
+			Spent:        spent,
+			Remaining:    b.GetRemaining(spent),
+			Progress:     b.CalculateProgress(spent),
+			IsOverBudget: b.IsOverBudget(spent),
+		}
+		statuses = append(statuses, s)
+	}
+
+	return statuses, nil
+}
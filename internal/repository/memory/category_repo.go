@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// categoryRepository adalah implementasi in-memory untuk CategoryRepository.
+type categoryRepository struct {
+	store *Store
+}
+
+// NewCategoryRepository membuat CategoryRepository in-memory.
+func NewCategoryRepository(store *Store) repository.CategoryRepository {
+	return &categoryRepository{store: store}
+}
+
+// Create menyimpan kategori baru.
+func (r *categoryRepository) Create(ctx context.Context, category *models.Category) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.categories[category.ID]; ok {
+		return repository.ErrDuplicateKey
+	}
+	if category.ParentID != nil {
+		if _, ok := r.store.categories[*category.ParentID]; !ok {
+			return repository.ErrForeignKeyViolation
+		}
+	}
+
+	if category.CreatedAt == "" {
+		category.CreatedAt = time.Now().Format(time.RFC3339)
+	}
+
+	c := *category
+	r.store.categories[category.ID] = &c
+	return nil
+}
+
+// GetByID mengambil kategori berdasarkan ID.
+func (r *categoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	c, ok := r.store.categories[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	out := *c
+	return &out, nil
+}
+
+// Exists mengecek apakah category dengan ID tersebut ada.
+func (r *categoryRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, ok := r.store.categories[id]
+	return ok, nil
+}
+
+// GetByName mengambil kategori berdasarkan nama (case-insensitive).
+func (r *categoryRepository) GetByName(ctx context.Context, name string) (*models.Category, error) {
+	name = strings.TrimSpace(name)
+	categories := r.collect(func(c *models.Category) bool {
+		return strings.EqualFold(c.Name, name)
+	})
+	if len(categories) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	sort.SliceStable(categories, func(i, j int) bool {
+		if categories[i].IsSubCategory() != categories[j].IsSubCategory() {
+			return !categories[i].IsSubCategory()
+		}
+		return categories[i].Type < categories[j].Type
+	})
+	return categories[0], nil
+}
+
+// GetByType mengambil top-level kategori berdasarkan tipe.
+func (r *categoryRepository) GetByType(ctx context.Context, catType models.CategoryType) ([]*models.Category, error) {
+	return r.collect(func(c *models.Category) bool {
+		return c.Type == catType && c.ParentID == nil
+	}), nil
+}
+
+// GetChildren mengambil sub-kategori.
+func (r *categoryRepository) GetChildren(ctx context.Context, parentID uuid.UUID) ([]*models.Category, error) {
+	return r.collect(func(c *models.Category) bool {
+		return c.ParentID != nil && *c.ParentID == parentID
+	}), nil
+}
+
+// List mengambil semua kategori, diurutkan type, sort_order, name.
+func (r *categoryRepository) List(ctx context.Context) ([]*models.Category, error) {
+	categories := r.collect(func(*models.Category) bool { return true })
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i].Type < categories[j].Type
+	})
+	return categories, nil
+}
+
+// collect mengambil copy kategori yang cocok, diurutkan sort_order, name.
+func (r *categoryRepository) collect(match func(*models.Category) bool) []*models.Category {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var categories []*models.Category
+	for _, c := range r.store.categories {
+		if match(c) {
+			out := *c
+			categories = append(categories, &out)
+		}
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].SortOrder != categories[j].SortOrder {
+			return categories[i].SortOrder < categories[j].SortOrder
+		}
+		return categories[i].Name < categories[j].Name
+	})
+	return categories
+}
+
+// Update memperbarui kategori.
+func (r *categoryRepository) Update(ctx context.Context, category *models.Category) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.categories[category.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	category.CreatedAt = existing.CreatedAt
+	c := *category
+	r.store.categories[category.ID] = &c
+	return nil
+}
+
+// Delete menghapus kategori.
+//
+// Meniru foreign key di database:
+// - transactions/recurring.category_id → SET NULL
+// - categories.parent_id → SET NULL
+// - budgets → CASCADE
+func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.categories[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.store.categories, id)
+
+	for txID, tx := range r.store.transactions {
+		if tx.CategoryID != nil && *tx.CategoryID == id {
+			out := copyTransaction(tx)
+			out.CategoryID = nil
+			r.store.transactions[txID] = out
+		}
+	}
+	for recID, rec := range r.store.recurrings {
+		if rec.CategoryID != nil && *rec.CategoryID == id {
+			out := *rec
+			out.CategoryID = nil
+			r.store.recurrings[recID] = &out
+		}
+	}
+	for childID, child := range r.store.categories {
+		if child.ParentID != nil && *child.ParentID == id {
+			out := *child
+			out.ParentID = nil
+			r.store.categories[childID] = &out
+		}
+	}
+	for budgetID, b := range r.store.budgets {
+		if b.CategoryID == id {
+			delete(r.store.budgets, budgetID)
+		}
+	}
+
+	return nil
+}
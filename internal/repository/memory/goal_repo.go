@@ -0,0 +1,273 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// goalRepository adalah implementasi in-memory untuk GoalRepository.
+type goalRepository struct {
+	store *Store
+}
+
+// NewGoalRepository membuat GoalRepository in-memory.
+func NewGoalRepository(store *Store) repository.GoalRepository {
+	return &goalRepository{store: store}
+}
+
+// copyGoal meng-copy goal termasuk pointer Deadline.
+func copyGoal(g *models.Goal) *models.Goal {
+	out := *g
+	if g.Deadline != nil {
+		deadline := *g.Deadline
+		out.Deadline = &deadline
+	}
+	return &out
+}
+
+// Create menyimpan goal baru.
+func (r *goalRepository) Create(ctx context.Context, goal *models.Goal) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.goals[goal.ID]; ok {
+		return repository.ErrDuplicateKey
+	}
+
+	now := time.Now()
+	if goal.CreatedAt.IsZero() {
+		goal.CreatedAt = now
+	}
+	goal.UpdatedAt = now
+	goal.Version = 1
+
+	r.store.goals[goal.ID] = copyGoal(goal)
+	return nil
+}
+
+// GetByID mengambil goal berdasarkan ID.
+func (r *goalRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Goal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	g, ok := r.store.goals[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return copyGoal(g), nil
+}
+
+// Exists mengecek apakah goal dengan ID tersebut ada.
+func (r *goalRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, ok := r.store.goals[id]
+	return ok, nil
+}
+
+// List mengambil goals dengan filter, diurutkan sort_order ASC lalu
+// created_at DESC.
+func (r *goalRepository) List(ctx context.Context, filter repository.GoalFilter) ([]*models.Goal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var goals []*models.Goal
+	for _, g := range r.store.goals {
+		if filter.Status != nil && g.Status != *filter.Status {
+			continue
+		}
+		goals = append(goals, copyGoal(g))
+	}
+
+	sort.Slice(goals, func(i, j int) bool {
+		if goals[i].SortOrder != goals[j].SortOrder {
+			return goals[i].SortOrder < goals[j].SortOrder
+		}
+		return goals[i].CreatedAt.After(goals[j].CreatedAt)
+	})
+	return goals, nil
+}
+
+// Update memperbarui goal jika goal.Version masih sama dengan yang
+// tersimpan, lalu menaikkan version.
+func (r *goalRepository) Update(ctx context.Context, goal *models.Goal) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.goals[goal.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if existing.Version != goal.Version {
+		return repository.ErrConflict
+	}
+
+	goal.CreatedAt = existing.CreatedAt
+	goal.UpdatedAt = time.Now()
+	goal.Version++
+
+	r.store.goals[goal.ID] = copyGoal(goal)
+	return nil
+}
+
+// Delete menghapus goal beserta kontribusinya (CASCADE).
+func (r *goalRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.goals[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.store.goals, id)
+
+	for cID, c := range r.store.contributions {
+		if c.GoalID == id {
+			delete(r.store.contributions, cID)
+		}
+	}
+
+	return nil
+}
+
+// AddContribution menambahkan kontribusi dan update current_amount goal.
+func (r *goalRepository) AddContribution(ctx context.Context, contribution *models.GoalContribution) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	goal, ok := r.store.goals[contribution.GoalID]
+	if !ok {
+		return repository.ErrForeignKeyViolation
+	}
+	if _, ok := r.store.contributions[contribution.ID]; ok {
+		return repository.ErrDuplicateKey
+	}
+
+	if contribution.CreatedAt.IsZero() {
+		contribution.CreatedAt = time.Now()
+	}
+
+	c := *contribution
+	r.store.contributions[contribution.ID] = &c
+
+	g := copyGoal(goal)
+	g.CurrentAmount = g.CurrentAmount.Add(contribution.Amount)
+	g.UpdatedAt = time.Now()
+	g.Version++
+	r.store.goals[g.ID] = g
+
+	return nil
+}
+
+// RestoreContribution menyimpan kontribusi tanpa mengubah current_amount
+// goal.
+func (r *goalRepository) RestoreContribution(ctx context.Context, contribution *models.GoalContribution) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.goals[contribution.GoalID]; !ok {
+		return repository.ErrForeignKeyViolation
+	}
+	if _, ok := r.store.contributions[contribution.ID]; ok {
+		return repository.ErrDuplicateKey
+	}
+	if contribution.CreatedAt.IsZero() {
+		contribution.CreatedAt = time.Now()
+	}
+
+	c := *contribution
+	r.store.contributions[contribution.ID] = &c
+	return nil
+}
+
+// GetContributions mengambil history kontribusi, terbaru dulu.
+func (r *goalRepository) GetContributions(
+	ctx context.Context,
+	goalID uuid.UUID,
+	params repository.ListParams,
+) ([]*models.GoalContribution, error) {
+	params.Validate()
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var contributions []*models.GoalContribution
+	for _, c := range r.store.contributions {
+		if c.GoalID == goalID {
+			out := *c
+			contributions = append(contributions, &out)
+		}
+	}
+
+	sort.Slice(contributions, func(i, j int) bool {
+		a, b := contributions[i], contributions[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID.String() > b.ID.String()
+	})
+	return paginate(contributions, params), nil
+}
+
+// UpdateCurrentAmount mengupdate current_amount goal.
+func (r *goalRepository) UpdateCurrentAmount(ctx context.Context, id uuid.UUID, amount decimal.Decimal) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.goals[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	g := copyGoal(existing)
+	g.CurrentAmount = amount
+	g.UpdatedAt = time.Now()
+	g.Version++
+	r.store.goals[id] = g
+	return nil
+}
+
+// UpdateSortOrder mengupdate sort_order goal.
+func (r *goalRepository) UpdateSortOrder(ctx context.Context, id uuid.UUID, sortOrder int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.goals[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	g := copyGoal(existing)
+	g.SortOrder = sortOrder
+	g.UpdatedAt = time.Now()
+	g.Version++
+	r.store.goals[id] = g
+	return nil
+}
+
+// ListCompletedInPeriod mengambil goal completed dengan updated_at di
+// antara from dan to (inklusif), terbaru dulu.
+func (r *goalRepository) ListCompletedInPeriod(ctx context.Context, from, to time.Time) ([]*models.Goal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var goals []*models.Goal
+	for _, g := range r.store.goals {
+		if g.Status != models.GoalStatusCompleted || g.UpdatedAt.Before(from) || g.UpdatedAt.After(to) {
+			continue
+		}
+		goals = append(goals, copyGoal(g))
+	}
+
+	sort.Slice(goals, func(i, j int) bool {
+		return goals[i].UpdatedAt.After(goals[j].UpdatedAt)
+	})
+	return goals, nil
+}
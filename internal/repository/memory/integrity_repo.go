@@ -0,0 +1,235 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// integrityRepository adalah implementasi in-memory untuk IntegrityRepository.
+//
+// Repository lain di package ini sudah menjaga foreign key, jadi orphan
+// hanya muncul jika store diubah langsung (misalnya di test).
+type integrityRepository struct {
+	store *Store
+}
+
+// NewIntegrityRepository membuat IntegrityRepository in-memory.
+func NewIntegrityRepository(store *Store) repository.IntegrityRepository {
+	return &integrityRepository{store: store}
+}
+
+// FindOrphanContributions mengambil kontribusi tanpa goal.
+func (r *integrityRepository) FindOrphanContributions(ctx context.Context) ([]*repository.IntegrityIssue, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var issues []*repository.IntegrityIssue
+	for _, c := range r.store.contributions {
+		if _, ok := r.store.goals[c.GoalID]; !ok {
+			issues = append(issues, &repository.IntegrityIssue{
+				Table:     "goal_contributions",
+				ID:        c.ID,
+				MissingID: c.GoalID,
+				Detail:    issueDetail(c.Amount, c.Note),
+			})
+		}
+	}
+
+	sortIssues(issues)
+	return issues, nil
+}
+
+// FindMissingCategoryRefs mengambil row dengan category_id yang tidak valid.
+func (r *integrityRepository) FindMissingCategoryRefs(ctx context.Context) ([]*repository.IntegrityIssue, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var issues []*repository.IntegrityIssue
+	for _, tx := range r.store.transactions {
+		if tx.CategoryID != nil && r.store.categories[*tx.CategoryID] == nil {
+			issues = append(issues, &repository.IntegrityIssue{
+				Table:     "transactions",
+				ID:        tx.ID,
+				MissingID: *tx.CategoryID,
+				Detail:    issueDetail(tx.Amount, tx.Description),
+			})
+		}
+	}
+	for _, rec := range r.store.recurrings {
+		if rec.CategoryID != nil && r.store.categories[*rec.CategoryID] == nil {
+			issues = append(issues, &repository.IntegrityIssue{
+				Table:     "recurring_transactions",
+				ID:        rec.ID,
+				MissingID: *rec.CategoryID,
+				Detail:    issueDetail(rec.Amount, rec.Description),
+			})
+		}
+	}
+	for _, b := range r.store.budgets {
+		if r.store.categories[b.CategoryID] == nil {
+			issues = append(issues, &repository.IntegrityIssue{
+				Table:     "budgets",
+				ID:        b.ID,
+				MissingID: b.CategoryID,
+				Detail:    issueDetail(b.Amount, string(b.Period)),
+			})
+		}
+	}
+
+	sortIssues(issues)
+	return issues, nil
+}
+
+// FindMissingWalletRefs mengambil transaksi dengan wallet_id yang tidak valid.
+func (r *integrityRepository) FindMissingWalletRefs(ctx context.Context) ([]*repository.IntegrityIssue, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var issues []*repository.IntegrityIssue
+	for _, tx := range r.store.transactions {
+		if r.store.wallets[tx.WalletID] == nil {
+			issues = append(issues, &repository.IntegrityIssue{
+				Table:     "transactions",
+				ID:        tx.ID,
+				MissingID: tx.WalletID,
+				Detail:    issueDetail(tx.Amount, tx.Description),
+			})
+		}
+	}
+
+	sortIssues(issues)
+	return issues, nil
+}
+
+// FindImportedCurrencyMismatches mengambil wallet dengan transaksi import
+// yang OriginalCurrency-nya berbeda dari currency wallet.
+func (r *integrityRepository) FindImportedCurrencyMismatches(ctx context.Context) ([]*repository.IntegrityIssue, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type key struct {
+		walletID uuid.UUID
+		currency string
+	}
+	counts := make(map[key]int)
+	for _, tx := range r.store.transactions {
+		wallet := r.store.wallets[tx.WalletID]
+		if wallet == nil || tx.OriginalCurrency == "" || tx.OriginalCurrency == wallet.Currency {
+			continue
+		}
+		counts[key{wallet.ID, tx.OriginalCurrency}]++
+	}
+
+	var issues []*repository.IntegrityIssue
+	for k, n := range counts {
+		wallet := r.store.wallets[k.walletID]
+		issues = append(issues, &repository.IntegrityIssue{
+			Table:  "wallets",
+			ID:     wallet.ID,
+			Detail: fmt.Sprintf("%s (%s): %d imported as %s", wallet.Name, wallet.Currency, n, k.currency),
+		})
+	}
+
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Detail < issues[j].Detail })
+	return issues, nil
+}
+
+// FindGoalAmountMismatches mengambil goals yang current_amount != total kontribusi.
+func (r *integrityRepository) FindGoalAmountMismatches(ctx context.Context) ([]*repository.GoalAmountMismatch, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.goalMismatches(), nil
+}
+
+// ClearMissingCategoryRefs men-set NULL category_id yang tidak valid.
+func (r *integrityRepository) ClearMissingCategoryRefs(ctx context.Context) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	fixed := 0
+	for id, tx := range r.store.transactions {
+		if tx.CategoryID != nil && r.store.categories[*tx.CategoryID] == nil {
+			out := copyTransaction(tx)
+			out.CategoryID = nil
+			r.store.transactions[id] = out
+			fixed++
+		}
+	}
+	for id, rec := range r.store.recurrings {
+		if rec.CategoryID != nil && r.store.categories[*rec.CategoryID] == nil {
+			out := copyRecurring(rec)
+			out.CategoryID = nil
+			r.store.recurrings[id] = out
+			fixed++
+		}
+	}
+
+	return fixed, nil
+}
+
+// RecomputeGoalAmounts men-set current_amount = total kontribusi.
+func (r *integrityRepository) RecomputeGoalAmounts(ctx context.Context) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mismatches := r.goalMismatches()
+	for _, m := range mismatches {
+		g := copyGoal(r.store.goals[m.GoalID])
+		g.CurrentAmount = m.ContributionSum
+		g.UpdatedAt = time.Now()
+		g.Version++
+		r.store.goals[g.ID] = g
+	}
+
+	return len(mismatches), nil
+}
+
+// goalMismatches menghitung mismatch (dipanggil dengan mu ter-lock).
+func (r *integrityRepository) goalMismatches() []*repository.GoalAmountMismatch {
+	sums := make(map[uuid.UUID]decimal.Decimal)
+	for _, c := range r.store.contributions {
+		sums[c.GoalID] = sums[c.GoalID].Add(c.Amount)
+	}
+
+	var mismatches []*repository.GoalAmountMismatch
+	for _, g := range r.store.goals {
+		sum := sums[g.ID]
+		if !g.CurrentAmount.Equal(sum) {
+			mismatches = append(mismatches, &repository.GoalAmountMismatch{
+				GoalID:          g.ID,
+				GoalName:        g.Name,
+				CurrentAmount:   g.CurrentAmount,
+				ContributionSum: sum,
+			})
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].GoalName < mismatches[j].GoalName
+	})
+	return mismatches
+}
+
+// issueDetail memformat detail issue seperti query PostgreSQL: "<amount> <text>".
+func issueDetail(amount decimal.Decimal, text string) string {
+	return strings.TrimSpace(amount.String() + " " + text)
+}
+
+// sortIssues mengurutkan issues supaya output deterministik.
+func sortIssues(issues []*repository.IntegrityIssue) {
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Table != issues[j].Table {
+			return issues[i].Table < issues[j].Table
+		}
+		return issues[i].ID.String() < issues[j].ID.String()
+	})
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+)
+
+// Foreign key sudah dijaga oleh repository lain, jadi orphan di test ini
+// dibuat dengan menulis langsung ke store - sama seperti database yang
+// kehilangan constraint.
+
+func TestIntegrityRepository_FindOrphanContributions(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewIntegrityRepository(store)
+
+	orphan := models.NewContribution(models.NewID(), decimal.NewFromInt(100))
+	store.contributions[orphan.ID] = orphan
+
+	issues, err := repo.FindOrphanContributions(ctx)
+	if err != nil {
+		t.Fatalf("FindOrphanContributions() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != orphan.ID || issues[0].MissingID != orphan.GoalID {
+		t.Errorf("FindOrphanContributions() = %+v, want the orphan contribution", issues)
+	}
+}
+
+func TestIntegrityRepository_MissingCategoryRefs_DetectAndFix(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewIntegrityRepository(store)
+	w := newTestWallet(t, store, 0)
+	missing := models.NewID()
+
+	tx := models.NewTransaction(w.ID, models.TransactionTypeExpense, decimal.NewFromInt(100))
+	tx.SetCategory(missing)
+	store.transactions[tx.ID] = tx
+
+	rec := models.NewRecurringTransaction(w.ID, models.TransactionTypeExpense, decimal.NewFromInt(50),
+		models.RecurringMonthly, time.Now())
+	rec.CategoryID = &missing
+	store.recurrings[rec.ID] = rec
+
+	budget := models.NewBudget(missing, decimal.NewFromInt(1000))
+	store.budgets[budget.ID] = budget
+
+	issues, err := repo.FindMissingCategoryRefs(ctx)
+	if err != nil {
+		t.Fatalf("FindMissingCategoryRefs() error = %v", err)
+	}
+	tables := map[string]bool{}
+	for _, issue := range issues {
+		tables[issue.Table] = true
+	}
+	for _, table := range []string{"transactions", "recurring_transactions", "budgets"} {
+		if !tables[table] {
+			t.Errorf("FindMissingCategoryRefs() missing %s issue, got %+v", table, issues)
+		}
+	}
+
+	fixed, err := repo.ClearMissingCategoryRefs(ctx)
+	if err != nil {
+		t.Fatalf("ClearMissingCategoryRefs() error = %v", err)
+	}
+	if fixed != 2 {
+		t.Errorf("ClearMissingCategoryRefs() = %d, want 2 (transaction + recurring)", fixed)
+	}
+
+	// Budget tidak bisa di-NULL-kan, jadi tetap dilaporkan
+	issues, _ = repo.FindMissingCategoryRefs(ctx)
+	if len(issues) != 1 || issues[0].Table != "budgets" {
+		t.Errorf("after fix FindMissingCategoryRefs() = %+v, want only the budget", issues)
+	}
+}
+
+func TestIntegrityRepository_FindMissingWalletRefs(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewIntegrityRepository(store)
+	newTestWallet(t, store, 0)
+
+	tx := models.NewTransaction(models.NewID(), models.TransactionTypeIncome, decimal.NewFromInt(100))
+	tx.Description = "Lost"
+	store.transactions[tx.ID] = tx
+
+	issues, err := repo.FindMissingWalletRefs(ctx)
+	if err != nil {
+		t.Fatalf("FindMissingWalletRefs() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].MissingID != tx.WalletID {
+		t.Fatalf("FindMissingWalletRefs() = %+v, want the orphan transaction", issues)
+	}
+	if issues[0].Detail != "100 Lost" {
+		t.Errorf("Detail = %q, want %q", issues[0].Detail, "100 Lost")
+	}
+}
+
+func TestIntegrityRepository_GoalAmounts_DetectAndFix(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewIntegrityRepository(store)
+	goalRepo := NewGoalRepository(store)
+
+	goal := models.NewGoal("Laptop", decimal.NewFromInt(1000))
+	_ = goalRepo.Create(ctx, goal)
+	_ = goalRepo.AddContribution(ctx, models.NewContribution(goal.ID, decimal.NewFromInt(300)))
+	_ = goalRepo.UpdateCurrentAmount(ctx, goal.ID, decimal.NewFromInt(999))
+
+	mismatches, err := repo.FindGoalAmountMismatches(ctx)
+	if err != nil {
+		t.Fatalf("FindGoalAmountMismatches() error = %v", err)
+	}
+	if len(mismatches) != 1 || !mismatches[0].ContributionSum.Equal(decimal.NewFromInt(300)) {
+		t.Fatalf("FindGoalAmountMismatches() = %+v, want one mismatch with sum 300", mismatches)
+	}
+
+	fixed, err := repo.RecomputeGoalAmounts(ctx)
+	if err != nil || fixed != 1 {
+		t.Fatalf("RecomputeGoalAmounts() = %d, %v, want 1, nil", fixed, err)
+	}
+
+	got, _ := goalRepo.GetByID(ctx, goal.ID)
+	if !got.CurrentAmount.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("CurrentAmount after fix = %s, want 300", got.CurrentAmount)
+	}
+	if mismatches, _ := repo.FindGoalAmountMismatches(ctx); len(mismatches) != 0 {
+		t.Errorf("mismatches after fix = %d, want 0", len(mismatches))
+	}
+}
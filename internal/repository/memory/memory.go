@@ -0,0 +1,166 @@
+// Package memory adalah implementasi in-memory dari semua repository interface.
+//
+// Data disimpan di map dalam satu Store yang di-share oleh semua repository,
+// sehingga query lintas entity (misalnya budget status yang butuh
+// transactions + categories) tetap konsisten.
+//
+// Kegunaan:
+// - Demo mode TUI tanpa PostgreSQL
+// - Unit test service layer tanpa database
+//
+// Semua repository thread-safe (dilindungi sync.RWMutex di Store).
+// Entity di-copy saat disimpan dan saat dibaca, jadi mengubah object
+// hasil GetByID tidak mengubah data sebelum Update dipanggil -
+// sama seperti perilaku implementasi PostgreSQL.
+//
+// Contoh penggunaan:
+//
+//	store := memory.NewStore()
+//	walletRepo := memory.NewWalletRepository(store)
+//	txManager := memory.NewTransactionManager(store)
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// Store menyimpan semua data in-memory.
+type Store struct {
+	mu sync.RWMutex
+
+	// txMu men-serialize WithTransaction supaya snapshot/rollback
+	// tidak saling menimpa.
+	txMu sync.Mutex
+
+	wallets       map[uuid.UUID]*models.Wallet
+	categories    map[uuid.UUID]*models.Category
+	transactions  map[uuid.UUID]*models.Transaction
+	transfers     map[uuid.UUID]*models.Transfer
+	budgets       map[uuid.UUID]*models.Budget
+	recurrings    map[uuid.UUID]*models.RecurringTransaction
+	goals         map[uuid.UUID]*models.Goal
+	contributions map[uuid.UUID]*models.GoalContribution
+	rules         map[uuid.UUID]*models.CategorizationRule
+
+	// balanceAudit urut waktu (append-only), jadi tidak perlu map.
+	balanceAudit []*models.BalanceAudit
+}
+
+// NewStore membuat Store kosong.
+func NewStore() *Store {
+	return &Store{
+		wallets:       make(map[uuid.UUID]*models.Wallet),
+		categories:    make(map[uuid.UUID]*models.Category),
+		transactions:  make(map[uuid.UUID]*models.Transaction),
+		transfers:     make(map[uuid.UUID]*models.Transfer),
+		budgets:       make(map[uuid.UUID]*models.Budget),
+		recurrings:    make(map[uuid.UUID]*models.RecurringTransaction),
+		goals:         make(map[uuid.UUID]*models.Goal),
+		contributions: make(map[uuid.UUID]*models.GoalContribution),
+		rules:         make(map[uuid.UUID]*models.CategorizationRule),
+	}
+}
+
+// snapshot meng-copy seluruh isi store (dipanggil dengan mu ter-lock).
+func (s *Store) snapshot() *Store {
+	return &Store{
+		wallets:       copyMap(s.wallets),
+		categories:    copyMap(s.categories),
+		transactions:  copyMap(s.transactions),
+		transfers:     copyMap(s.transfers),
+		budgets:       copyMap(s.budgets),
+		recurrings:    copyMap(s.recurrings),
+		goals:         copyMap(s.goals),
+		contributions: copyMap(s.contributions),
+		rules:         copyMap(s.rules),
+		balanceAudit:  slices.Clone(s.balanceAudit),
+	}
+}
+
+// restore mengembalikan isi store dari snapshot (dipanggil dengan mu ter-lock).
+func (s *Store) restore(snap *Store) {
+	s.wallets = snap.wallets
+	s.categories = snap.categories
+	s.transactions = snap.transactions
+	s.transfers = snap.transfers
+	s.budgets = snap.budgets
+	s.recurrings = snap.recurrings
+	s.goals = snap.goals
+	s.contributions = snap.contributions
+	s.rules = snap.rules
+	s.balanceAudit = snap.balanceAudit
+}
+
+// copyMap membuat shallow copy dari map. Value-nya tidak perlu di-copy
+// karena repository tidak pernah memutasi entity yang sudah tersimpan -
+// Update selalu mengganti pointer dengan copy baru.
+func copyMap[T any](m map[uuid.UUID]*T) map[uuid.UUID]*T {
+	out := make(map[uuid.UUID]*T, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// Compile-time check bahwa semua implementasi memenuhi interface.
+var (
+	_ repository.WalletRepository      = (*walletRepository)(nil)
+	_ repository.CategoryRepository    = (*categoryRepository)(nil)
+	_ repository.TransactionRepository = (*transactionRepository)(nil)
+	_ repository.TransferRepository    = (*transferRepository)(nil)
+	_ repository.BudgetRepository      = (*budgetRepository)(nil)
+	_ repository.RecurringRepository   = (*recurringRepository)(nil)
+	_ repository.GoalRepository        = (*goalRepository)(nil)
+	_ repository.IntegrityRepository   = (*integrityRepository)(nil)
+	_ repository.RuleRepository        = (*ruleRepository)(nil)
+	_ repository.TransactionManager    = (*transactionManager)(nil)
+)
+
+// ==================== Transaction Manager ====================
+
+// txKey adalah context key untuk menandai sedang di dalam transaksi.
+type txKey struct{}
+
+// transactionManager adalah implementasi in-memory untuk TransactionManager.
+type transactionManager struct {
+	store *Store
+}
+
+// NewTransactionManager membuat TransactionManager in-memory.
+//
+// Sebelum fn dijalankan, isi store di-snapshot. Jika fn return error,
+// store dikembalikan ke snapshot (rollback).
+func NewTransactionManager(store *Store) repository.TransactionManager {
+	return &transactionManager{store: store}
+}
+
+// WithTransaction menjalankan fn secara atomic.
+// Nested call ikut transaksi luar (tidak membuat snapshot baru).
+func (m *transactionManager) WithTransaction(ctx context.Context, fn repository.TxFunc) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+
+	m.store.txMu.Lock()
+	defer m.store.txMu.Unlock()
+
+	m.store.mu.RLock()
+	snap := m.store.snapshot()
+	m.store.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		m.store.mu.Lock()
+		m.store.restore(snap)
+		m.store.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,673 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func newTestWallet(t *testing.T, store *Store, balance int64) *models.Wallet {
+	t.Helper()
+
+	w := models.NewWallet("BCA", models.WalletTypeBank)
+	w.Balance = decimal.NewFromInt(balance)
+	if err := NewWalletRepository(store).Create(context.Background(), w); err != nil {
+		t.Fatalf("Create wallet error = %v", err)
+	}
+	return w
+}
+
+func TestWalletRepository_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewWalletRepository(store)
+	w := newTestWallet(t, store, 1000)
+
+	got, err := repo.GetByID(ctx, w.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	got.Name = "Changed"
+
+	again, _ := repo.GetByID(ctx, w.ID)
+	if again.Name != "BCA" {
+		t.Errorf("stored wallet was mutated through GetByID result: %q", again.Name)
+	}
+}
+
+func TestWalletRepository_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWalletRepository(NewStore())
+
+	if _, err := repo.GetByID(ctx, models.NewID()); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetByID() error = %v, want ErrNotFound", err)
+	}
+	if err := repo.UpdateBalance(ctx, models.NewID(), decimal.Zero, models.BalanceChange{Reason: models.BalanceReasonAdjustment}); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("UpdateBalance() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWalletRepository_UpdateVersion(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewWalletRepository(store)
+	w := newTestWallet(t, store, 1000)
+
+	stale, _ := repo.GetByID(ctx, w.ID)
+	fresh, _ := repo.GetByID(ctx, w.ID)
+
+	fresh.Name = "BCA Tabungan"
+	if err := repo.Update(ctx, fresh); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if fresh.Version != 2 {
+		t.Errorf("Version after Update = %d, want 2", fresh.Version)
+	}
+
+	stale.Color = "#7C3AED"
+	if err := repo.Update(ctx, stale); !errors.Is(err, repository.ErrConflict) {
+		t.Errorf("Update(stale) error = %v, want ErrConflict", err)
+	}
+
+	if err := repo.UpdateBalance(ctx, w.ID, decimal.NewFromInt(500), models.BalanceChange{Reason: models.BalanceReasonAdjustment}); err != nil {
+		t.Fatalf("UpdateBalance() error = %v", err)
+	}
+	if err := repo.Update(ctx, fresh); !errors.Is(err, repository.ErrConflict) {
+		t.Errorf("Update() after UpdateBalance error = %v, want ErrConflict", err)
+	}
+
+	got, _ := repo.GetByID(ctx, w.ID)
+	if got.Name != "BCA Tabungan" || got.Color != "" || got.Version != 3 {
+		t.Errorf("stored wallet = %q/%q v%d, want BCA Tabungan, no color, v3", got.Name, got.Color, got.Version)
+	}
+}
+
+func TestWalletRepository_BalanceAudit(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewWalletRepository(store)
+	w := newTestWallet(t, store, 1000)
+	other := newTestWallet(t, store, 0)
+
+	txID := models.NewID()
+	changes := []struct {
+		balance int64
+		change  models.BalanceChange
+	}{
+		{950, models.BalanceChange{Reason: models.BalanceReasonTransactionCreated, ReferenceID: &txID}},
+		{1000, models.BalanceChange{Reason: models.BalanceReasonTransactionDeleted, ReferenceID: &txID}},
+		{400, models.BalanceChange{Reason: models.BalanceReasonAdjustment}},
+	}
+	for _, c := range changes {
+		if err := repo.UpdateBalance(ctx, w.ID, decimal.NewFromInt(c.balance), c.change); err != nil {
+			t.Fatalf("UpdateBalance() error = %v", err)
+		}
+	}
+	if err := repo.UpdateBalance(ctx, other.ID, decimal.NewFromInt(5), models.BalanceChange{Reason: models.BalanceReasonImport}); err != nil {
+		t.Fatalf("UpdateBalance() error = %v", err)
+	}
+
+	audit, err := repo.ListBalanceAudit(ctx, w.ID, repository.ListParams{})
+	if err != nil {
+		t.Fatalf("ListBalanceAudit() error = %v", err)
+	}
+	if len(audit) != 3 {
+		t.Fatalf("got %d audit entries, want 3", len(audit))
+	}
+
+	// Terbaru dulu
+	latest := audit[0]
+	if latest.Reason != models.BalanceReasonAdjustment || !latest.OldBalance.Equal(decimal.NewFromInt(1000)) ||
+		!latest.NewBalance.Equal(decimal.NewFromInt(400)) || !latest.Delta().Equal(decimal.NewFromInt(-600)) {
+		t.Errorf("latest entry = %s %s → %s, want adjustment 1000 → 400", latest.Reason, latest.OldBalance, latest.NewBalance)
+	}
+	first := audit[2]
+	if first.Reason != models.BalanceReasonTransactionCreated || first.ReferenceID == nil || *first.ReferenceID != txID {
+		t.Errorf("first entry = %s ref %v, want transaction_created ref %s", first.Reason, first.ReferenceID, txID)
+	}
+
+	page, _ := repo.ListBalanceAudit(ctx, w.ID, repository.ListParams{Limit: 1, Offset: 1})
+	if len(page) != 1 || page[0].Reason != models.BalanceReasonTransactionDeleted {
+		t.Errorf("page = %v, want the transaction_deleted entry", page)
+	}
+
+	// AdjustBalance relatif terhadap saldo yang tersimpan
+	balance, err := repo.AdjustBalance(ctx, other.ID, decimal.NewFromInt(-8), models.BalanceChange{Reason: models.BalanceReasonRecurring})
+	if err != nil || !balance.Equal(decimal.NewFromInt(-3)) {
+		t.Fatalf("AdjustBalance() = %s, %v, want -3, nil", balance, err)
+	}
+	audit, _ = repo.ListBalanceAudit(ctx, other.ID, repository.ListParams{})
+	if len(audit) != 2 || !audit[0].OldBalance.Equal(decimal.NewFromInt(5)) || !audit[0].Delta().Equal(decimal.NewFromInt(-8)) {
+		t.Errorf("audit = %v, want recurring entry 5 → -3", audit)
+	}
+	if _, err := repo.AdjustBalance(ctx, models.NewID(), decimal.NewFromInt(1), models.BalanceChange{}); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("AdjustBalance() unknown wallet error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWalletRepository_ListSortBy(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewWalletRepository(store)
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, spec := range []struct {
+		name    string
+		balance int64
+	}{{"gopay", 1000000}, {"BCA", 50000}, {"Cash", 200000}} {
+		w := models.NewWallet(spec.name, models.WalletTypeBank)
+		w.Balance = decimal.NewFromInt(spec.balance)
+		w.CreatedAt = base.AddDate(0, 0, i)
+		if err := repo.Create(ctx, w); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		sortBy repository.WalletSortOrder
+		want   []string
+	}{
+		{repository.WalletSortCreatedAt, []string{"Cash", "BCA", "gopay"}},
+		{repository.WalletSortName, []string{"BCA", "Cash", "gopay"}},
+		{repository.WalletSortBalance, []string{"gopay", "Cash", "BCA"}},
+	}
+
+	for _, tt := range tests {
+		wallets, err := repo.List(ctx, repository.WalletFilter{SortBy: tt.sortBy})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		var got []string
+		for _, w := range wallets {
+			got = append(got, w.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("List(SortBy %q) = %v, want %v", tt.sortBy, got, tt.want)
+		}
+	}
+}
+
+func TestRepositories_Exists(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	w := newTestWallet(t, store, 0)
+
+	category := models.NewCategory("Food", models.CategoryTypeExpense)
+	if err := NewCategoryRepository(store).Create(ctx, category); err != nil {
+		t.Fatalf("Create category error = %v", err)
+	}
+	goal := models.NewGoal("Laptop", decimal.NewFromInt(1000))
+	if err := NewGoalRepository(store).Create(ctx, goal); err != nil {
+		t.Fatalf("Create goal error = %v", err)
+	}
+
+	checks := []struct {
+		name   string
+		exists func(context.Context, uuid.UUID) (bool, error)
+		id     uuid.UUID
+	}{
+		{"wallet", NewWalletRepository(store).Exists, w.ID},
+		{"category", NewCategoryRepository(store).Exists, category.ID},
+		{"goal", NewGoalRepository(store).Exists, goal.ID},
+	}
+	for _, c := range checks {
+		if ok, err := c.exists(ctx, c.id); err != nil || !ok {
+			t.Errorf("%s Exists(existing) = %v, %v, want true", c.name, ok, err)
+		}
+		if ok, err := c.exists(ctx, models.NewID()); err != nil || ok {
+			t.Errorf("%s Exists(missing) = %v, %v, want false", c.name, ok, err)
+		}
+	}
+}
+
+func TestTransactionRepository_ForeignKey(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTransactionRepository(NewStore())
+
+	tx := models.NewTransaction(models.NewID(), models.TransactionTypeExpense, decimal.NewFromInt(100))
+	if err := repo.Create(ctx, tx); !errors.Is(err, repository.ErrForeignKeyViolation) {
+		t.Errorf("Create() error = %v, want ErrForeignKeyViolation", err)
+	}
+}
+
+func TestTransactionRepository_ListFilterAndOrder(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewTransactionRepository(store)
+	w := newTestWallet(t, store, 0)
+
+	now := time.Now()
+	for i, amount := range []int64{100, 200, 300} {
+		tx := models.NewTransaction(w.ID, models.TransactionTypeExpense, decimal.NewFromInt(amount))
+		tx.TransactionDate = now.AddDate(0, 0, -i)
+		tx.Description = "Lunch"
+		if i == 2 {
+			tx.Description = "Groceries"
+		}
+		if err := repo.Create(ctx, tx); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	txs, err := repo.List(ctx, repository.TransactionFilter{}, repository.ListParams{Limit: 2})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("List() returned %d transactions, want 2", len(txs))
+	}
+	if !txs[0].Amount.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("List() first = %s, want newest (100)", txs[0].Amount)
+	}
+
+	search := "grocer"
+	txs, _ = repo.List(ctx, repository.TransactionFilter{Search: &search}, repository.ListParams{})
+	if len(txs) != 1 || !txs[0].Amount.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("List(search) = %d transactions, want only Groceries", len(txs))
+	}
+}
+
+func TestTransactionRepository_SearchNotes(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewTransactionRepository(store)
+	w := newTestWallet(t, store, 0)
+
+	tx := models.NewTransaction(w.ID, models.TransactionTypeExpense, decimal.NewFromInt(100))
+	tx.Description = "Dinner"
+	tx.Note = "Split with Budi\nHe owes 50k"
+	if err := repo.Create(ctx, tx); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	search := "budi"
+	txs, _ := repo.List(ctx, repository.TransactionFilter{Search: &search}, repository.ListParams{})
+	if len(txs) != 0 {
+		t.Errorf("List(search) = %d transactions, want 0 (notes not searched by default)", len(txs))
+	}
+
+	txs, _ = repo.List(ctx, repository.TransactionFilter{Search: &search, SearchNotes: true}, repository.ListParams{})
+	if len(txs) != 1 || txs[0].Note != tx.Note {
+		t.Errorf("List(search, SearchNotes) = %d transactions, want the dinner with its note", len(txs))
+	}
+}
+
+func TestTransactionRepository_ListMultipleWallets(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewTransactionRepository(store)
+	cardA := newTestWallet(t, store, 0)
+	cardB := newTestWallet(t, store, 0)
+	cash := newTestWallet(t, store, 0)
+
+	for _, w := range []*models.Wallet{cardA, cardB, cash} {
+		tx := models.NewTransaction(w.ID, models.TransactionTypeExpense, decimal.NewFromInt(100))
+		if err := repo.Create(ctx, tx); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	filter := repository.TransactionFilter{WalletIDs: []uuid.UUID{cardA.ID, cardB.ID}}
+	txs, _ := repo.List(ctx, filter, repository.ListParams{})
+	if len(txs) != 2 {
+		t.Errorf("List(WalletIDs) = %d transactions, want 2", len(txs))
+	}
+
+	summary, err := repo.GetSummary(ctx, filter)
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if !summary.TotalExpense.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("GetSummary(WalletIDs).TotalExpense = %s, want 200", summary.TotalExpense)
+	}
+
+	// WalletID lama tetap berlaku, digabung dengan WalletIDs
+	filter.WalletID = &cash.ID
+	txs, _ = repo.List(ctx, filter, repository.ListParams{})
+	if len(txs) != 3 {
+		t.Errorf("List(WalletID + WalletIDs) = %d transactions, want 3", len(txs))
+	}
+}
+
+func TestTransactionRepository_DeleteByWallet(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewTransactionRepository(store)
+	w := newTestWallet(t, store, 0)
+	other := newTestWallet(t, store, 0)
+
+	for _, walletID := range []uuid.UUID{w.ID, w.ID, other.ID} {
+		tx := models.NewTransaction(walletID, models.TransactionTypeIncome, decimal.NewFromInt(100))
+		if err := repo.Create(ctx, tx); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	deleted, err := repo.DeleteByWallet(ctx, w.ID)
+	if err != nil || deleted != 2 {
+		t.Fatalf("DeleteByWallet() = %d, %v, want 2, nil", deleted, err)
+	}
+
+	txs, _ := repo.List(ctx, repository.TransactionFilter{}, repository.ListParams{})
+	if len(txs) != 1 || txs[0].WalletID != other.ID {
+		t.Errorf("List() after DeleteByWallet = %d transactions, want only the other wallet's", len(txs))
+	}
+
+	// Wallet yang tidak ada (atau sudah kosong) bukan error
+	for _, id := range []uuid.UUID{models.NewID(), w.ID} {
+		deleted, err := repo.DeleteByWallet(ctx, id)
+		if err != nil || deleted != 0 {
+			t.Errorf("DeleteByWallet(%s) = %d, %v, want 0, nil", id, deleted, err)
+		}
+	}
+}
+
+func TestTransactionManager_RollbackOnError(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	walletRepo := NewWalletRepository(store)
+	txManager := NewTransactionManager(store)
+	w := newTestWallet(t, store, 1000)
+
+	errBoom := errors.New("boom")
+	err := txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := walletRepo.UpdateBalance(ctx, w.ID, decimal.NewFromInt(1), models.BalanceChange{Reason: models.BalanceReasonAdjustment}); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("WithTransaction() error = %v, want %v", err, errBoom)
+	}
+
+	got, _ := walletRepo.GetByID(ctx, w.ID)
+	if !got.Balance.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("balance after rollback = %s, want 1000", got.Balance)
+	}
+	if audit, _ := walletRepo.ListBalanceAudit(ctx, w.ID, repository.ListParams{}); len(audit) != 0 {
+		t.Errorf("got %d audit entries after rollback, want 0", len(audit))
+	}
+}
+
+func TestTransactionManager_Commit(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	walletRepo := NewWalletRepository(store)
+	txManager := NewTransactionManager(store)
+	w := newTestWallet(t, store, 1000)
+
+	err := txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// Nested call ikut transaksi luar
+		return txManager.WithTransaction(ctx, func(ctx context.Context) error {
+			return walletRepo.UpdateBalance(ctx, w.ID, decimal.NewFromInt(500), models.BalanceChange{Reason: models.BalanceReasonAdjustment})
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction() error = %v", err)
+	}
+
+	got, _ := walletRepo.GetByID(ctx, w.ID)
+	if !got.Balance.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("balance after commit = %s, want 500", got.Balance)
+	}
+}
+
+func TestCategoryRepository_DeleteCascades(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	categoryRepo := NewCategoryRepository(store)
+	txRepo := NewTransactionRepository(store)
+	budgetRepo := NewBudgetRepository(store)
+	w := newTestWallet(t, store, 0)
+
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	_ = categoryRepo.Create(ctx, food)
+
+	tx := models.NewTransaction(w.ID, models.TransactionTypeExpense, decimal.NewFromInt(100))
+	tx.SetCategory(food.ID)
+	_ = txRepo.Create(ctx, tx)
+
+	budget := models.NewBudget(food.ID, decimal.NewFromInt(1000))
+	_ = budgetRepo.Create(ctx, budget)
+
+	if err := categoryRepo.Delete(ctx, food.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	got, _ := txRepo.GetByID(ctx, tx.ID)
+	if got.CategoryID != nil {
+		t.Error("transaction category should be set to NULL")
+	}
+	if _, err := budgetRepo.GetByID(ctx, budget.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("budget should be deleted, GetByID() error = %v", err)
+	}
+}
+
+func TestBudgetRepository_GetBudgetStatus(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	categoryRepo := NewCategoryRepository(store)
+	txRepo := NewTransactionRepository(store)
+	budgetRepo := NewBudgetRepository(store)
+	w := newTestWallet(t, store, 0)
+
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	_ = categoryRepo.Create(ctx, food)
+
+	budget := models.NewBudget(food.ID, decimal.NewFromInt(1000))
+	budget.StartDate = time.Now().AddDate(0, 0, -7)
+	_ = budgetRepo.Create(ctx, budget)
+
+	// Satu transaksi di dalam periode, satu sebelum StartDate
+	inside := models.NewTransaction(w.ID, models.TransactionTypeExpense, decimal.NewFromInt(900))
+	inside.SetCategory(food.ID)
+	before := models.NewTransaction(w.ID, models.TransactionTypeExpense, decimal.NewFromInt(500))
+	before.SetCategory(food.ID)
+	before.TransactionDate = time.Now().AddDate(0, 0, -30)
+	_ = txRepo.Create(ctx, inside)
+	_ = txRepo.Create(ctx, before)
+
+	statuses, err := budgetRepo.GetBudgetStatus(ctx, time.Now(), 1)
+	if err != nil {
+		t.Fatalf("GetBudgetStatus() error = %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("GetBudgetStatus() returned %d statuses, want 1", len(statuses))
+	}
+
+	s := statuses[0]
+	if !s.Spent.Equal(decimal.NewFromInt(900)) {
+		t.Errorf("Spent = %s, want 900", s.Spent)
+	}
+	if s.Progress != 90 {
+		t.Errorf("Progress = %v, want 90", s.Progress)
+	}
+	if s.IsOverBudget {
+		t.Error("IsOverBudget = true, want false")
+	}
+}
+
+func TestBudgetRepository_GetBudgetStatus_MonthStartDay(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	categoryRepo := NewCategoryRepository(store)
+	txRepo := NewTransactionRepository(store)
+	budgetRepo := NewBudgetRepository(store)
+	w := newTestWallet(t, store, 0)
+
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	_ = categoryRepo.Create(ctx, food)
+
+	// StartDate lama dan belum pernah di-roll over
+	budget := models.NewBudget(food.ID, decimal.NewFromInt(1000))
+	budget.StartDate = time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)
+	_ = budgetRepo.Create(ctx, budget)
+
+	// Dengan anchor 25, 10 Jan 2026 ada di periode 25 Dec – 24 Jan
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.Local)
+	for _, tx := range []struct {
+		day    time.Time
+		amount int64
+	}{
+		{time.Date(2025, 12, 24, 0, 0, 0, 0, time.Local), 500}, // periode sebelumnya
+		{time.Date(2025, 12, 25, 0, 0, 0, 0, time.Local), 300},
+		{time.Date(2026, 1, 9, 0, 0, 0, 0, time.Local), 200},
+		{time.Date(2026, 1, 25, 0, 0, 0, 0, time.Local), 700}, // periode berikutnya
+	} {
+		expense := models.NewTransaction(w.ID, models.TransactionTypeExpense, decimal.NewFromInt(tx.amount))
+		expense.SetCategory(food.ID)
+		expense.TransactionDate = tx.day
+		_ = txRepo.Create(ctx, expense)
+	}
+
+	statuses, err := budgetRepo.GetBudgetStatus(ctx, now, 25)
+	if err != nil {
+		t.Fatalf("GetBudgetStatus() error = %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("GetBudgetStatus() returned %d statuses, want 1", len(statuses))
+	}
+	if got := statuses[0].Spent; !got.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("Spent = %s, want 500 (25 Dec – 24 Jan only)", got)
+	}
+}
+
+func TestGoalRepository_AddContribution(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGoalRepository(NewStore())
+
+	goal := models.NewGoal("Laptop", decimal.NewFromInt(1000))
+	_ = repo.Create(ctx, goal)
+
+	for _, amount := range []int64{300, 200} {
+		if err := repo.AddContribution(ctx, models.NewContribution(goal.ID, decimal.NewFromInt(amount))); err != nil {
+			t.Fatalf("AddContribution() error = %v", err)
+		}
+	}
+
+	got, _ := repo.GetByID(ctx, goal.ID)
+	if !got.CurrentAmount.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("CurrentAmount = %s, want 500", got.CurrentAmount)
+	}
+
+	contributions, _ := repo.GetContributions(ctx, goal.ID, repository.ListParams{})
+	if len(contributions) != 2 {
+		t.Errorf("GetContributions() returned %d, want 2", len(contributions))
+	}
+
+	err := repo.AddContribution(ctx, models.NewContribution(models.NewID(), decimal.NewFromInt(1)))
+	if !errors.Is(err, repository.ErrForeignKeyViolation) {
+		t.Errorf("AddContribution(unknown goal) error = %v, want ErrForeignKeyViolation", err)
+	}
+}
+
+func TestRecurringRepository_ListNextDueWindow(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewRecurringRepository(store)
+	w := newTestWallet(t, store, 0)
+
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	for _, days := range []int{-3, 0, 5, 7, 20} {
+		rec := &models.RecurringTransaction{
+			ID:        models.NewID(),
+			WalletID:  w.ID,
+			Type:      models.TransactionTypeExpense,
+			Amount:    decimal.NewFromInt(100),
+			Frequency: models.RecurringMonthly,
+			NextDue:   today.AddDate(0, 0, days),
+			IsActive:  true,
+		}
+		if err := repo.Create(ctx, rec); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	due, _ := repo.List(ctx, repository.RecurringFilter{NextDueBefore: &today})
+	if len(due) != 2 {
+		t.Errorf("List(NextDueBefore) = %d items, want 2 (overdue and today)", len(due))
+	}
+
+	week := today.AddDate(0, 0, 7)
+	upcoming, _ := repo.List(ctx, repository.RecurringFilter{NextDueAfter: &today, NextDueBefore: &week})
+	if len(upcoming) != 3 {
+		t.Fatalf("List(next 7 days) = %d items, want 3", len(upcoming))
+	}
+	if !upcoming[0].NextDue.Equal(today) || !upcoming[2].NextDue.Equal(week) {
+		t.Errorf("List(next 7 days) = %v .. %v, want %v .. %v (inclusive, next_due ASC)",
+			upcoming[0].NextDue, upcoming[2].NextDue, today, week)
+	}
+}
+
+func TestStore_ConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	walletRepo := NewWalletRepository(store)
+	txRepo := NewTransactionRepository(store)
+	w := newTestWallet(t, store, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			tx := models.NewTransaction(w.ID, models.TransactionTypeIncome, decimal.NewFromInt(1))
+			_ = txRepo.Create(ctx, tx)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = walletRepo.List(ctx, repository.WalletFilter{})
+		}()
+	}
+	wg.Wait()
+
+	txs, _ := txRepo.List(ctx, repository.TransactionFilter{}, repository.ListParams{})
+	if len(txs) != 20 {
+		t.Errorf("List() returned %d transactions, want 20", len(txs))
+	}
+}
+
+func TestTransactionRepository_GetLargest(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewTransactionRepository(store)
+	w := newTestWallet(t, store, 0)
+
+	now := time.Now()
+	expense := models.TransactionTypeExpense
+	for i, amount := range []int64{300, 900, 100, 500} {
+		tx := models.NewTransaction(w.ID, expense, decimal.NewFromInt(amount))
+		tx.TransactionDate = now.AddDate(0, 0, -i)
+		if err := repo.Create(ctx, tx); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	income := models.NewTransaction(w.ID, models.TransactionTypeIncome, decimal.NewFromInt(5000))
+	if err := repo.Create(ctx, income); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	txs, err := repo.GetLargest(ctx, repository.TransactionFilter{Type: &expense}, 3)
+	if err != nil {
+		t.Fatalf("GetLargest() error = %v", err)
+	}
+
+	want := []int64{900, 500, 300}
+	if len(txs) != len(want) {
+		t.Fatalf("GetLargest() returned %d transactions, want %d", len(txs), len(want))
+	}
+	for i, amount := range want {
+		if !txs[i].Amount.Equal(decimal.NewFromInt(amount)) {
+			t.Errorf("GetLargest()[%d] = %s, want %d", i, txs[i].Amount, amount)
+		}
+	}
+}
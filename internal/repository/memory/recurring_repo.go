@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// recurringRepository adalah implementasi in-memory untuk RecurringRepository.
+type recurringRepository struct {
+	store *Store
+}
+
+// NewRecurringRepository membuat RecurringRepository in-memory.
+func NewRecurringRepository(store *Store) repository.RecurringRepository {
+	return &recurringRepository{store: store}
+}
+
+// copyRecurring meng-copy recurring termasuk pointer fields.
+func copyRecurring(rec *models.RecurringTransaction) *models.RecurringTransaction {
+	out := *rec
+	if rec.CategoryID != nil {
+		categoryID := *rec.CategoryID
+		out.CategoryID = &categoryID
+	}
+	if rec.EndDate != nil {
+		end := *rec.EndDate
+		out.EndDate = &end
+	}
+	return &out
+}
+
+// checkRefs memastikan wallet dan category yang direferensikan ada.
+func (r *recurringRepository) checkRefs(rec *models.RecurringTransaction) error {
+	if _, ok := r.store.wallets[rec.WalletID]; !ok {
+		return repository.ErrForeignKeyViolation
+	}
+	if rec.CategoryID != nil {
+		if _, ok := r.store.categories[*rec.CategoryID]; !ok {
+			return repository.ErrForeignKeyViolation
+		}
+	}
+	return nil
+}
+
+// Create menyimpan recurring baru.
+func (r *recurringRepository) Create(ctx context.Context, recurring *models.RecurringTransaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.recurrings[recurring.ID]; ok {
+		return repository.ErrDuplicateKey
+	}
+	if err := r.checkRefs(recurring); err != nil {
+		return err
+	}
+
+	if recurring.CreatedAt.IsZero() {
+		recurring.CreatedAt = time.Now()
+	}
+
+	r.store.recurrings[recurring.ID] = copyRecurring(recurring)
+	return nil
+}
+
+// GetByID mengambil recurring berdasarkan ID.
+func (r *recurringRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.RecurringTransaction, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rec, ok := r.store.recurrings[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return copyRecurring(rec), nil
+}
+
+// List mengambil recurring dengan filter, diurutkan next_due ASC.
+func (r *recurringRepository) List(ctx context.Context, filter repository.RecurringFilter) ([]*models.RecurringTransaction, error) {
+	return r.collect(func(rec *models.RecurringTransaction) bool {
+		if filter.WalletID != nil && rec.WalletID != *filter.WalletID {
+			return false
+		}
+		if filter.IsActive != nil && rec.IsActive != *filter.IsActive {
+			return false
+		}
+		if filter.Type != nil && rec.Type != *filter.Type {
+			return false
+		}
+		if filter.Frequency != nil && rec.Frequency != *filter.Frequency {
+			return false
+		}
+		if filter.NextDueBefore != nil && rec.NextDue.After(*filter.NextDueBefore) {
+			return false
+		}
+		if filter.NextDueAfter != nil && rec.NextDue.Before(*filter.NextDueAfter) {
+			return false
+		}
+		return true
+	}), nil
+}
+
+// collect mengambil copy recurring yang cocok, diurutkan next_due ASC.
+func (r *recurringRepository) collect(match func(*models.RecurringTransaction) bool) []*models.RecurringTransaction {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var recurrings []*models.RecurringTransaction
+	for _, rec := range r.store.recurrings {
+		if match(rec) {
+			recurrings = append(recurrings, copyRecurring(rec))
+		}
+	}
+
+	sort.Slice(recurrings, func(i, j int) bool {
+		return recurrings[i].NextDue.Before(recurrings[j].NextDue)
+	})
+	return recurrings
+}
+
+// Update memperbarui recurring.
+func (r *recurringRepository) Update(ctx context.Context, recurring *models.RecurringTransaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.recurrings[recurring.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	if err := r.checkRefs(recurring); err != nil {
+		return err
+	}
+
+	r.store.recurrings[recurring.ID] = copyRecurring(recurring)
+	return nil
+}
+
+// Delete menghapus recurring.
+func (r *recurringRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.recurrings[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.store.recurrings, id)
+	return nil
+}
+
+// UpdateNextDue mengupdate next_due.
+func (r *recurringRepository) UpdateNextDue(ctx context.Context, id uuid.UUID, nextDue time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.recurrings[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	rec := copyRecurring(existing)
+	rec.NextDue = nextDue
+	r.store.recurrings[id] = rec
+	return nil
+}
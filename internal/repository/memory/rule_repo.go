@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// ruleRepository adalah implementasi in-memory untuk RuleRepository.
+type ruleRepository struct {
+	store *Store
+}
+
+// NewRuleRepository membuat RuleRepository in-memory.
+func NewRuleRepository(store *Store) repository.RuleRepository {
+	return &ruleRepository{store: store}
+}
+
+// copyRule meng-copy rule termasuk WalletID.
+func copyRule(rule *models.CategorizationRule) *models.CategorizationRule {
+	out := *rule
+	if rule.WalletID != nil {
+		id := *rule.WalletID
+		out.WalletID = &id
+	}
+	return &out
+}
+
+// Create menyimpan rule baru.
+func (r *ruleRepository) Create(ctx context.Context, rule *models.CategorizationRule) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.rules[rule.ID]; ok {
+		return repository.ErrDuplicateKey
+	}
+	if _, ok := r.store.categories[rule.CategoryID]; !ok {
+		return repository.ErrForeignKeyViolation
+	}
+	if rule.WalletID != nil {
+		if _, ok := r.store.wallets[*rule.WalletID]; !ok {
+			return repository.ErrForeignKeyViolation
+		}
+	}
+
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = time.Now()
+	}
+
+	r.store.rules[rule.ID] = copyRule(rule)
+	return nil
+}
+
+// GetByID mengambil rule berdasarkan ID.
+func (r *ruleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CategorizationRule, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rule, ok := r.store.rules[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return copyRule(rule), nil
+}
+
+// List mengambil semua rule urut priority lalu created_at.
+func (r *ruleRepository) List(ctx context.Context) ([]*models.CategorizationRule, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rules := make([]*models.CategorizationRule, 0, len(r.store.rules))
+	for _, rule := range r.store.rules {
+		rules = append(rules, copyRule(rule))
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+	})
+	return rules, nil
+}
+
+// Delete menghapus rule.
+func (r *ruleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.rules[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.store.rules, id)
+	return nil
+}
@@ -0,0 +1,688 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// transactionRepository adalah implementasi in-memory untuk TransactionRepository.
+type transactionRepository struct {
+	store *Store
+}
+
+// NewTransactionRepository membuat TransactionRepository in-memory.
+func NewTransactionRepository(store *Store) repository.TransactionRepository {
+	return &transactionRepository{store: store}
+}
+
+// copyTransaction meng-copy transaction termasuk slice Tags.
+func copyTransaction(tx *models.Transaction) *models.Transaction {
+	out := *tx
+	if tx.Tags != nil {
+		out.Tags = append([]string(nil), tx.Tags...)
+	}
+	if tx.RateToBase != nil {
+		rate := *tx.RateToBase
+		out.RateToBase = &rate
+	}
+	if tx.OriginalAmount != nil {
+		amount := *tx.OriginalAmount
+		out.OriginalAmount = &amount
+	}
+	return &out
+}
+
+// matchesSearch mengecek filter.Search (case-insensitive) di description,
+// dan juga di note jika filter.SearchNotes.
+func matchesSearch(tx *models.Transaction, filter repository.TransactionFilter) bool {
+	search := strings.ToLower(*filter.Search)
+	if strings.Contains(strings.ToLower(tx.Description), search) {
+		return true
+	}
+	return filter.SearchNotes && strings.Contains(strings.ToLower(tx.Note), search)
+}
+
+// checkTransactionRefs memastikan wallet dan category yang direferensikan ada.
+func (r *transactionRepository) checkTransactionRefs(tx *models.Transaction) error {
+	if _, ok := r.store.wallets[tx.WalletID]; !ok {
+		return repository.ErrForeignKeyViolation
+	}
+	if tx.CategoryID != nil {
+		if _, ok := r.store.categories[*tx.CategoryID]; !ok {
+			return repository.ErrForeignKeyViolation
+		}
+	}
+	return nil
+}
+
+// Create menyimpan transaction baru.
+func (r *transactionRepository) Create(ctx context.Context, tx *models.Transaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.create(tx)
+}
+
+// CreateBatch menyimpan banyak transaction sekaligus. Jika satu gagal,
+// yang sudah tersimpan dihapus lagi.
+func (r *transactionRepository) CreateBatch(ctx context.Context, txs []*models.Transaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i, tx := range txs {
+		if err := r.create(tx); err != nil {
+			for _, created := range txs[:i] {
+				delete(r.store.transactions, created.ID)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// create menyimpan satu transaction (dipanggil dengan mu ter-lock).
+func (r *transactionRepository) create(tx *models.Transaction) error {
+	if _, ok := r.store.transactions[tx.ID]; ok {
+		return repository.ErrDuplicateKey
+	}
+	if tx.IdempotencyKey != "" && r.findByIdempotencyKey(tx.IdempotencyKey) != nil {
+		return repository.ErrDuplicateKey
+	}
+	if tx.ExternalReference != "" && r.hasExternalReference(tx.WalletID, tx.ExternalReference) {
+		return repository.ErrDuplicateKey
+	}
+	if err := r.checkTransactionRefs(tx); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if tx.CreatedAt.IsZero() {
+		tx.CreatedAt = now
+	}
+	tx.UpdatedAt = now
+
+	r.store.transactions[tx.ID] = copyTransaction(tx)
+	return nil
+}
+
+// GetByID mengambil transaction berdasarkan ID.
+func (r *transactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tx, ok := r.store.transactions[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return copyTransaction(tx), nil
+}
+
+// GetByIdempotencyKey mengambil transaction berdasarkan idempotency key.
+func (r *transactionRepository) GetByIdempotencyKey(ctx context.Context, key string) (*models.Transaction, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tx := r.findByIdempotencyKey(key)
+	if tx == nil {
+		return nil, repository.ErrNotFound
+	}
+	return copyTransaction(tx), nil
+}
+
+// findByIdempotencyKey mencari transaction dengan key tertentu
+// (caller harus memegang lock).
+func (r *transactionRepository) findByIdempotencyKey(key string) *models.Transaction {
+	for _, tx := range r.store.transactions {
+		if tx.IdempotencyKey == key {
+			return tx
+		}
+	}
+	return nil
+}
+
+// ExistingExternalReferences mengembalikan refs yang sudah dipakai di wallet.
+func (r *transactionRepository) ExistingExternalReferences(ctx context.Context, walletID uuid.UUID, refs []string) ([]string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var existing []string
+	for _, ref := range refs {
+		if r.hasExternalReference(walletID, ref) {
+			existing = append(existing, ref)
+		}
+	}
+	return existing, nil
+}
+
+// hasExternalReference mengecek apakah ref sudah dipakai di wallet
+// (caller harus memegang lock).
+func (r *transactionRepository) hasExternalReference(walletID uuid.UUID, ref string) bool {
+	for _, tx := range r.store.transactions {
+		if tx.WalletID == walletID && tx.ExternalReference == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// List mengambil transactions dengan filter dan pagination.
+// Diurutkan transaction_date DESC, created_at DESC, id DESC.
+func (r *transactionRepository) List(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+	params repository.ListParams,
+) ([]*models.Transaction, error) {
+	params.Validate()
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	transactions := r.filter(filter)
+	sort.Slice(transactions, func(i, j int) bool {
+		a, b := transactions[i], transactions[j]
+		if !a.TransactionDate.Equal(b.TransactionDate) {
+			return a.TransactionDate.After(b.TransactionDate)
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID.String() > b.ID.String()
+	})
+
+	return paginate(transactions, params), nil
+}
+
+// filter mengembalikan copy transaksi yang cocok dengan filter
+// (caller harus memegang lock).
+func (r *transactionRepository) filter(filter repository.TransactionFilter) []*models.Transaction {
+	var result []*models.Transaction
+	for _, tx := range r.store.transactions {
+		if matchTransaction(tx, filter) {
+			result = append(result, copyTransaction(tx))
+		}
+	}
+	return result
+}
+
+// matchTransaction mengecek apakah tx cocok dengan filter.
+func matchTransaction(tx *models.Transaction, filter repository.TransactionFilter) bool {
+	if walletIDs := filter.AllWalletIDs(); len(walletIDs) > 0 && !slices.Contains(walletIDs, tx.WalletID) {
+		return false
+	}
+	if filter.CategoryID != nil && (tx.CategoryID == nil || *tx.CategoryID != *filter.CategoryID) {
+		return false
+	}
+	if filter.CategoryID == nil && filter.Uncategorized && tx.CategoryID != nil {
+		return false
+	}
+	if filter.Type != nil && tx.Type != *filter.Type {
+		return false
+	}
+	if filter.StartDate != nil && tx.TransactionDate.Before(*filter.StartDate) {
+		return false
+	}
+	if filter.EndDate != nil && tx.TransactionDate.After(*filter.EndDate) {
+		return false
+	}
+	if filter.Search != nil && *filter.Search != "" && !matchesSearch(tx, filter) {
+		return false
+	}
+	if len(filter.Tags) > 0 && !hasAnyTag(tx, filter.Tags) {
+		return false
+	}
+	if filter.ExcludeAdjustments && tx.IsAdjustment() {
+		return false
+	}
+	return true
+}
+
+// hasAnyTag mengecek apakah tx punya minimal satu dari tags.
+func hasAnyTag(tx *models.Transaction, tags []string) bool {
+	for _, want := range tags {
+		for _, t := range tx.Tags {
+			if t == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Update memperbarui transaction.
+func (r *transactionRepository) Update(ctx context.Context, tx *models.Transaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.transactions[tx.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if err := r.checkTransactionRefs(tx); err != nil {
+		return err
+	}
+
+	tx.CreatedAt = existing.CreatedAt
+	tx.UpdatedAt = time.Now()
+	// Sama dengan postgres: original_currency, original_amount dan
+	// rate_to_base hanya diisi saat transaksi dibuat
+	tx.OriginalCurrency = existing.OriginalCurrency
+	tx.OriginalAmount = existing.OriginalAmount
+	tx.RateToBase = existing.RateToBase
+
+	r.store.transactions[tx.ID] = copyTransaction(tx)
+	return nil
+}
+
+// UpdateCategoryAndTags hanya mengubah category_id dan tags.
+func (r *transactionRepository) UpdateCategoryAndTags(ctx context.Context, id uuid.UUID, categoryID *uuid.UUID, tags []string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.transactions[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	updated := copyTransaction(existing)
+	updated.CategoryID = categoryID
+	updated.Tags = append([]string(nil), tags...)
+	if err := r.checkTransactionRefs(updated); err != nil {
+		return err
+	}
+
+	updated.UpdatedAt = time.Now()
+	r.store.transactions[id] = updated
+	return nil
+}
+
+// Delete menghapus transaction.
+func (r *transactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.transactions[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.store.transactions, id)
+	return nil
+}
+
+// DeleteByWallet menghapus semua transaction milik wallet.
+func (r *transactionRepository) DeleteByWallet(ctx context.Context, walletID uuid.UUID) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var deleted int64
+	for id, tx := range r.store.transactions {
+		if tx.WalletID == walletID {
+			delete(r.store.transactions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// CountByCategory menghitung transaksi dengan category_id tersebut.
+func (r *transactionRepository) CountByCategory(ctx context.Context, categoryID uuid.UUID) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, tx := range r.store.transactions {
+		if tx.CategoryID != nil && *tx.CategoryID == categoryID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ReassignCategory memindahkan transaksi dari kategori from ke to.
+func (r *transactionRepository) ReassignCategory(ctx context.Context, from, to uuid.UUID) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.categories[to]; !ok {
+		return 0, repository.ErrForeignKeyViolation
+	}
+
+	var updated int64
+	for id, tx := range r.store.transactions {
+		if tx.CategoryID != nil && *tx.CategoryID == from {
+			out := copyTransaction(tx)
+			out.CategoryID = &to
+			out.UpdatedAt = time.Now()
+			r.store.transactions[id] = out
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// GetSummary menghitung total income dan expense.
+func (r *transactionRepository) GetSummary(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+) (*repository.TransactionSummary, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	summary := &repository.TransactionSummary{}
+	for _, tx := range r.store.transactions {
+		if !matchTransaction(tx, filter) {
+			continue
+		}
+		amount := summaryAmount(tx, filter)
+		switch tx.Type {
+		case models.TransactionTypeIncome:
+			summary.TotalIncome = summary.TotalIncome.Add(amount)
+		case models.TransactionTypeExpense:
+			summary.TotalExpense = summary.TotalExpense.Add(amount)
+		}
+		summary.Count++
+	}
+
+	summary.Net = summary.TotalIncome.Sub(summary.TotalExpense)
+	return summary, nil
+}
+
+// summaryAmount adalah amount tx untuk total: dikonversi dengan
+// rate_to_base jika filter.ConvertToBase.
+func summaryAmount(tx *models.Transaction, filter repository.TransactionFilter) decimal.Decimal {
+	if filter.ConvertToBase {
+		return tx.AmountInBase()
+	}
+	return tx.Amount
+}
+
+// GetByCategory menghitung total per kategori.
+//
+// Sama seperti versi PostgreSQL, semua kategori dengan tipe yang cocok
+// ikut di-return (termasuk yang total-nya 0), diurutkan total DESC.
+func (r *transactionRepository) GetByCategory(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+) ([]*repository.CategorySummary, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byID := make(map[uuid.UUID]*repository.CategorySummary)
+	var summaries []*repository.CategorySummary
+	for _, c := range r.store.categories {
+		if filter.Type != nil && string(c.Type) != string(*filter.Type) {
+			continue
+		}
+		s := &repository.CategorySummary{CategoryID: c.ID, CategoryName: c.Name}
+		byID[c.ID] = s
+		summaries = append(summaries, s)
+	}
+
+	dateFilter := repository.TransactionFilter{
+		StartDate:          filter.StartDate,
+		EndDate:            filter.EndDate,
+		ExcludeAdjustments: filter.ExcludeAdjustments,
+	}
+	grandTotal := decimal.Zero
+	for _, tx := range r.store.transactions {
+		if tx.CategoryID == nil || !matchTransaction(tx, dateFilter) {
+			continue
+		}
+		s, ok := byID[*tx.CategoryID]
+		if !ok {
+			continue
+		}
+		amount := summaryAmount(tx, filter)
+		s.Total = s.Total.Add(amount)
+		s.Count++
+		grandTotal = grandTotal.Add(amount)
+	}
+
+	if !grandTotal.IsZero() {
+		for _, s := range summaries {
+			pct, _ := s.Total.Div(grandTotal).Mul(decimal.NewFromInt(100)).Float64()
+			s.Percentage = pct
+		}
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Total.GreaterThan(summaries[j].Total)
+	})
+
+	return summaries, nil
+}
+
+// GetLargest mengambil transaksi dengan amount terbesar.
+func (r *transactionRepository) GetLargest(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+	limit int,
+) ([]*models.Transaction, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	transactions := r.filter(filter)
+	sort.Slice(transactions, func(i, j int) bool {
+		a, b := transactions[i], transactions[j]
+		if !a.Amount.Equal(b.Amount) {
+			return a.Amount.GreaterThan(b.Amount)
+		}
+		return a.TransactionDate.After(b.TransactionDate)
+	})
+
+	if limit >= 0 && len(transactions) > limit {
+		transactions = transactions[:limit]
+	}
+	return transactions, nil
+}
+
+// GetAmountStats menghitung rata-rata dan maksimum amount per kategori.
+func (r *transactionRepository) GetAmountStats(
+	ctx context.Context,
+	categoryID uuid.UUID,
+	txType models.TransactionType,
+	since time.Time,
+) (*repository.AmountStats, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	stats := &repository.AmountStats{}
+	total := decimal.Zero
+	for _, tx := range r.store.transactions {
+		if tx.CategoryID == nil || *tx.CategoryID != categoryID ||
+			tx.Type != txType || tx.TransactionDate.Before(since) || tx.IsAdjustment() {
+			continue
+		}
+		total = total.Add(tx.Amount)
+		if tx.Amount.GreaterThan(stats.Max) {
+			stats.Max = tx.Amount
+		}
+		stats.Count++
+	}
+
+	if stats.Count > 0 {
+		stats.Average = total.Div(decimal.NewFromInt(int64(stats.Count)))
+	}
+	return stats, nil
+}
+
+// GetMonthlyCategoryTotals menghitung total per kategori per bulan.
+func (r *transactionRepository) GetMonthlyCategoryTotals(
+	ctx context.Context,
+	start, end time.Time,
+) ([]*repository.MonthlyCategoryTotal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type key struct {
+		categoryID uuid.UUID
+		month      time.Time
+	}
+	byKey := make(map[key]*repository.MonthlyCategoryTotal)
+	var totals []*repository.MonthlyCategoryTotal
+
+	for _, tx := range r.store.transactions {
+		if tx.CategoryID == nil || tx.IsAdjustment() ||
+			tx.TransactionDate.Before(start) || !tx.TransactionDate.Before(end) {
+			continue
+		}
+		d := tx.TransactionDate
+		k := key{*tx.CategoryID, time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, d.Location())}
+		t, ok := byKey[k]
+		if !ok {
+			t = &repository.MonthlyCategoryTotal{CategoryID: k.categoryID, Month: k.month}
+			byKey[k] = t
+			totals = append(totals, t)
+		}
+		t.Total = t.Total.Add(tx.Amount)
+		t.Count++
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].CategoryID != totals[j].CategoryID {
+			return totals[i].CategoryID.String() < totals[j].CategoryID.String()
+		}
+		return totals[i].Month.Before(totals[j].Month)
+	})
+
+	return totals, nil
+}
+
+// GetWeekdayHourTotals menghitung total per hari dalam minggu dan jam.
+func (r *transactionRepository) GetWeekdayHourTotals(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+) ([]*repository.WeekdayHourTotal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type key struct {
+		weekday time.Weekday
+		hour    int
+	}
+	byKey := make(map[key]*repository.WeekdayHourTotal)
+	var totals []*repository.WeekdayHourTotal
+
+	for _, tx := range r.filter(filter) {
+		// Sama seperti postgres: jam dari created_at jika dicatat di hari yang sama
+		hour := repository.UnknownHour
+		created := tx.CreatedAt.In(tx.TransactionDate.Location())
+		if y, m, d := created.Date(); y == tx.TransactionDate.Year() && m == tx.TransactionDate.Month() && d == tx.TransactionDate.Day() {
+			hour = created.Hour()
+		}
+
+		k := key{tx.TransactionDate.Weekday(), hour}
+		t, ok := byKey[k]
+		if !ok {
+			t = &repository.WeekdayHourTotal{Weekday: k.weekday, Hour: k.hour}
+			byKey[k] = t
+			totals = append(totals, t)
+		}
+		t.Total = t.Total.Add(tx.Amount)
+		t.Count++
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Weekday != totals[j].Weekday {
+			return totals[i].Weekday < totals[j].Weekday
+		}
+		return totals[i].Hour < totals[j].Hour
+	})
+
+	return totals, nil
+}
+
+// GetCashFlowByWalletType menjumlahkan income dan expense per tipe wallet.
+func (r *transactionRepository) GetCashFlowByWalletType(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+) ([]*repository.WalletTypeCashFlow, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byType := make(map[models.WalletType]*repository.WalletTypeCashFlow)
+	var flows []*repository.WalletTypeCashFlow
+
+	for _, tx := range r.filter(filter) {
+		// Sama seperti JOIN di postgres: transaksi tanpa wallet tidak dihitung
+		wallet, ok := r.store.wallets[tx.WalletID]
+		if !ok {
+			continue
+		}
+
+		flow, ok := byType[wallet.Type]
+		if !ok {
+			flow = &repository.WalletTypeCashFlow{WalletType: wallet.Type}
+			byType[wallet.Type] = flow
+			flows = append(flows, flow)
+		}
+		if tx.Type == models.TransactionTypeIncome {
+			flow.Inflow = flow.Inflow.Add(tx.Amount)
+		} else {
+			flow.Outflow = flow.Outflow.Add(tx.Amount)
+		}
+		flow.Count++
+	}
+
+	sort.Slice(flows, func(i, j int) bool {
+		return flows[i].WalletType < flows[j].WalletType
+	})
+
+	return flows, nil
+}
+
+// GetDescriptionTotals menjumlahkan transaksi per description (lowercase, trim).
+func (r *transactionRepository) GetDescriptionTotals(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+) ([]*repository.DescriptionTotal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byDescription := make(map[string]*repository.DescriptionTotal)
+	var totals []*repository.DescriptionTotal
+
+	for _, tx := range r.filter(filter) {
+		description := strings.ToLower(strings.TrimSpace(tx.Description))
+		if description == "" {
+			continue
+		}
+
+		t, ok := byDescription[description]
+		if !ok {
+			t = &repository.DescriptionTotal{Description: description}
+			byDescription[description] = t
+			totals = append(totals, t)
+		}
+		t.Total = t.Total.Add(tx.Amount)
+		t.Count++
+	}
+
+	sort.SliceStable(totals, func(i, j int) bool {
+		if !totals[i].Total.Equal(totals[j].Total) {
+			return totals[i].Total.GreaterThan(totals[j].Total)
+		}
+		return totals[i].Description < totals[j].Description
+	})
+
+	return totals, nil
+}
+
+// paginate menerapkan LIMIT/OFFSET ke slice yang sudah diurutkan.
+func paginate[T any](items []T, params repository.ListParams) []T {
+	if params.Offset >= len(items) {
+		return nil
+	}
+	end := params.Offset + params.Limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[params.Offset:end]
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// transferRepository adalah implementasi in-memory untuk TransferRepository.
+type transferRepository struct {
+	store *Store
+}
+
+// NewTransferRepository membuat TransferRepository in-memory.
+func NewTransferRepository(store *Store) repository.TransferRepository {
+	return &transferRepository{store: store}
+}
+
+// Create menyimpan transfer baru.
+func (r *transferRepository) Create(ctx context.Context, transfer *models.Transfer) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.transfers[transfer.ID]; ok {
+		return repository.ErrDuplicateKey
+	}
+	if _, ok := r.store.wallets[transfer.FromWalletID]; !ok {
+		return repository.ErrForeignKeyViolation
+	}
+	if _, ok := r.store.wallets[transfer.ToWalletID]; !ok {
+		return repository.ErrForeignKeyViolation
+	}
+
+	if transfer.CreatedAt.IsZero() {
+		transfer.CreatedAt = time.Now()
+	}
+
+	t := *transfer
+	r.store.transfers[transfer.ID] = &t
+	return nil
+}
+
+// GetByID mengambil transfer berdasarkan ID.
+func (r *transferRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transfer, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	t, ok := r.store.transfers[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	out := *t
+	return &out, nil
+}
+
+// List mengambil transfers dengan filter, diurutkan created_at DESC.
+func (r *transferRepository) List(
+	ctx context.Context,
+	filter repository.TransferFilter,
+	params repository.ListParams,
+) ([]*models.Transfer, error) {
+	params.Validate()
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var transfers []*models.Transfer
+	for _, t := range r.store.transfers {
+		if filter.WalletID != nil && t.FromWalletID != *filter.WalletID && t.ToWalletID != *filter.WalletID {
+			continue
+		}
+		if filter.FromWalletID != nil && t.FromWalletID != *filter.FromWalletID {
+			continue
+		}
+		if filter.ToWalletID != nil && t.ToWalletID != *filter.ToWalletID {
+			continue
+		}
+		if filter.StartDate != nil && t.CreatedAt.Before(*filter.StartDate) {
+			continue
+		}
+		if filter.EndDate != nil && t.CreatedAt.After(*filter.EndDate) {
+			continue
+		}
+		out := *t
+		transfers = append(transfers, &out)
+	}
+
+	sort.Slice(transfers, func(i, j int) bool {
+		return transfers[i].CreatedAt.After(transfers[j].CreatedAt)
+	})
+	return paginate(transfers, params), nil
+}
@@ -0,0 +1,274 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// walletRepository adalah implementasi in-memory untuk WalletRepository.
+type walletRepository struct {
+	store *Store
+}
+
+// NewWalletRepository membuat WalletRepository in-memory.
+func NewWalletRepository(store *Store) repository.WalletRepository {
+	return &walletRepository{store: store}
+}
+
+// Create menyimpan wallet baru.
+func (r *walletRepository) Create(ctx context.Context, wallet *models.Wallet) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.wallets[wallet.ID]; ok {
+		return repository.ErrDuplicateKey
+	}
+
+	now := time.Now()
+	if wallet.CreatedAt.IsZero() {
+		wallet.CreatedAt = now
+	}
+	wallet.UpdatedAt = now
+	wallet.Version = 1
+
+	w := *wallet
+	r.store.wallets[wallet.ID] = &w
+	return nil
+}
+
+// GetByID mengambil wallet berdasarkan ID.
+func (r *walletRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Wallet, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	w, ok := r.store.wallets[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	out := *w
+	return &out, nil
+}
+
+// Exists mengecek apakah wallet dengan ID tersebut ada.
+func (r *walletRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, ok := r.store.wallets[id]
+	return ok, nil
+}
+
+// List mengambil wallets dengan filter, diurutkan created_at DESC.
+func (r *walletRepository) List(ctx context.Context, filter repository.WalletFilter) ([]*models.Wallet, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.list(filter), nil
+}
+
+// list adalah List tanpa locking (caller harus memegang lock).
+func (r *walletRepository) list(filter repository.WalletFilter) []*models.Wallet {
+	var wallets []*models.Wallet
+	for _, w := range r.store.wallets {
+		if filter.IsActive != nil && w.IsActive != *filter.IsActive {
+			continue
+		}
+		if filter.Type != nil && w.Type != *filter.Type {
+			continue
+		}
+		if filter.Currency != nil && w.Currency != *filter.Currency {
+			continue
+		}
+		out := *w
+		wallets = append(wallets, &out)
+	}
+
+	sort.SliceStable(wallets, func(i, j int) bool {
+		return wallets[i].CreatedAt.After(wallets[j].CreatedAt)
+	})
+	switch filter.SortBy {
+	case repository.WalletSortName:
+		sort.SliceStable(wallets, func(i, j int) bool {
+			return strings.ToLower(wallets[i].Name) < strings.ToLower(wallets[j].Name)
+		})
+	case repository.WalletSortBalance:
+		sort.SliceStable(wallets, func(i, j int) bool {
+			return wallets[i].Balance.GreaterThan(wallets[j].Balance)
+		})
+	}
+	return wallets
+}
+
+// Update memperbarui wallet jika wallet.Version masih sama dengan yang
+// tersimpan, lalu menaikkan version.
+func (r *walletRepository) Update(ctx context.Context, wallet *models.Wallet) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.wallets[wallet.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if existing.Version != wallet.Version {
+		return repository.ErrConflict
+	}
+
+	wallet.CreatedAt = existing.CreatedAt
+	wallet.UpdatedAt = time.Now()
+	wallet.Version++
+
+	w := *wallet
+	r.store.wallets[wallet.ID] = &w
+	return nil
+}
+
+// Delete melakukan soft delete (set is_active = false).
+func (r *walletRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.wallets[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	w := *existing
+	w.IsActive = false
+	w.UpdatedAt = time.Now()
+	w.Version++
+	r.store.wallets[id] = &w
+	return nil
+}
+
+// UpdateBalance mengupdate saldo wallet dan mencatat balance audit.
+func (r *walletRepository) UpdateBalance(ctx context.Context, id uuid.UUID, newBalance decimal.Decimal, change models.BalanceChange) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.wallets[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	now := time.Now()
+	w := *existing
+	w.Balance = newBalance
+	w.UpdatedAt = now
+	w.Version++
+	r.store.wallets[id] = &w
+
+	r.store.balanceAudit = append(r.store.balanceAudit, &models.BalanceAudit{
+		ID:          models.NewID(),
+		WalletID:    id,
+		OldBalance:  existing.Balance,
+		NewBalance:  newBalance,
+		Reason:      change.Reason,
+		ReferenceID: change.ReferenceID,
+		CreatedAt:   now,
+	})
+	return nil
+}
+
+// AdjustBalance menambahkan delta ke saldo wallet dan mencatat balance audit.
+func (r *walletRepository) AdjustBalance(ctx context.Context, id uuid.UUID, delta decimal.Decimal, change models.BalanceChange) (decimal.Decimal, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.wallets[id]
+	if !ok {
+		return decimal.Zero, repository.ErrNotFound
+	}
+
+	now := time.Now()
+	w := *existing
+	w.Balance = existing.Balance.Add(delta)
+	w.UpdatedAt = now
+	w.Version++
+	r.store.wallets[id] = &w
+
+	r.store.balanceAudit = append(r.store.balanceAudit, &models.BalanceAudit{
+		ID:          models.NewID(),
+		WalletID:    id,
+		OldBalance:  existing.Balance,
+		NewBalance:  w.Balance,
+		Reason:      change.Reason,
+		ReferenceID: change.ReferenceID,
+		CreatedAt:   now,
+	})
+	return w.Balance, nil
+}
+
+// ListBalanceAudit mengambil riwayat perubahan saldo wallet, terbaru dulu.
+func (r *walletRepository) ListBalanceAudit(ctx context.Context, walletID uuid.UUID, params repository.ListParams) ([]*models.BalanceAudit, error) {
+	params.Validate()
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var entries []*models.BalanceAudit
+	for i := len(r.store.balanceAudit) - 1; i >= 0; i-- {
+		if a := r.store.balanceAudit[i]; a.WalletID == walletID {
+			out := *a
+			entries = append(entries, &out)
+		}
+	}
+	return paginate(entries, params), nil
+}
+
+// GetTotalBalance menghitung total saldo semua wallet aktif.
+func (r *walletRepository) GetTotalBalance(ctx context.Context) (decimal.Decimal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	total := decimal.Zero
+	for _, w := range r.store.wallets {
+		if w.IsActive {
+			total = total.Add(w.Balance)
+		}
+	}
+	return total, nil
+}
+
+// ListWithLastTransaction mengambil wallets beserta tanggal transaksi terakhir.
+func (r *walletRepository) ListWithLastTransaction(
+	ctx context.Context,
+	filter repository.WalletFilter,
+) ([]*repository.WalletActivity, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	last := make(map[uuid.UUID]time.Time)
+	for _, tx := range r.store.transactions {
+		if tx.TransactionDate.After(last[tx.WalletID]) {
+			last[tx.WalletID] = tx.TransactionDate
+		}
+	}
+
+	var result []*repository.WalletActivity
+	for _, w := range r.list(filter) {
+		activity := &repository.WalletActivity{Wallet: w}
+		if t, ok := last[w.ID]; ok {
+			activity.LastTransactionDate = &t
+		}
+		result = append(result, activity)
+	}
+
+	// NULLS LAST, lalu terbaru dulu
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].LastTransactionDate, result[j].LastTransactionDate
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.After(*b)
+	})
+
+	return result, nil
+}
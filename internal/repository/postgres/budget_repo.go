@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
@@ -15,21 +15,26 @@ import (
 
 // budgetRepository adalah implementasi PostgreSQL untuk BudgetRepository.
 type budgetRepository struct {
-	pool *pgxpool.Pool
+	pool Querier
 }
 
 // NewBudgetRepository membuat BudgetRepository baru.
-func NewBudgetRepository(pool *pgxpool.Pool) repository.BudgetRepository {
-	return &budgetRepository{pool: pool}
+func NewBudgetRepository(pool Querier) repository.BudgetRepository {
+	return &budgetRepository{pool: withTx(pool)}
 }
 
 // Create menyimpan budget baru.
 func (r *budgetRepository) Create(ctx context.Context, budget *models.Budget) error {
 	query := `
-		INSERT INTO budgets (id, category_id, amount, period, start_date, end_date, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO budgets (id, category_id, amount, period, start_date, end_date, is_active, warn_threshold, period_start)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
+	periodStart := budget.PeriodStart
+	if periodStart.IsZero() {
+		periodStart = budget.StartDate
+	}
+
 	_, err := r.pool.Exec(ctx, query,
 		budget.ID,
 		budget.CategoryID,
@@ -38,6 +43,8 @@ func (r *budgetRepository) Create(ctx context.Context, budget *models.Budget) er
 		budget.StartDate,
 		budget.EndDate,
 		budget.IsActive,
+		budget.WarnThreshold,
+		periodStart,
 	)
 
 	return convertError(err)
@@ -46,7 +53,7 @@ func (r *budgetRepository) Create(ctx context.Context, budget *models.Budget) er
 // GetByID mengambil budget berdasarkan ID.
 func (r *budgetRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Budget, error) {
 	query := `
-		SELECT id, category_id, amount, period, start_date, end_date, is_active, created_at
+		SELECT id, category_id, amount, period, start_date, period_start, end_date, is_active, warn_threshold, created_at
 		FROM budgets
 		WHERE id = $1
 	`
@@ -58,8 +65,10 @@ func (r *budgetRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.B
 		&b.Amount,
 		&b.Period,
 		&b.StartDate,
+		&b.PeriodStart,
 		&b.EndDate,
 		&b.IsActive,
+		&b.WarnThreshold,
 		&b.CreatedAt,
 	)
 
@@ -73,7 +82,7 @@ func (r *budgetRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.B
 // GetByCategory mengambil budget aktif untuk kategori.
 func (r *budgetRepository) GetByCategory(ctx context.Context, categoryID uuid.UUID) (*models.Budget, error) {
 	query := `
-		SELECT id, category_id, amount, period, start_date, end_date, is_active, created_at
+		SELECT id, category_id, amount, period, start_date, period_start, end_date, is_active, warn_threshold, created_at
 		FROM budgets
 		WHERE category_id = $1 AND is_active = true
 		ORDER BY created_at DESC
@@ -87,8 +96,10 @@ func (r *budgetRepository) GetByCategory(ctx context.Context, categoryID uuid.UU
 		&b.Amount,
 		&b.Period,
 		&b.StartDate,
+		&b.PeriodStart,
 		&b.EndDate,
 		&b.IsActive,
+		&b.WarnThreshold,
 		&b.CreatedAt,
 	)
 
@@ -102,7 +113,7 @@ func (r *budgetRepository) GetByCategory(ctx context.Context, categoryID uuid.UU
 // List mengambil budgets dengan filter.
 func (r *budgetRepository) List(ctx context.Context, filter repository.BudgetFilter) ([]*models.Budget, error) {
 	query := `
-		SELECT id, category_id, amount, period, start_date, end_date, is_active, created_at
+		SELECT id, category_id, amount, period, start_date, period_start, end_date, is_active, warn_threshold, created_at
 		FROM budgets
 	`
 
@@ -149,8 +160,10 @@ func (r *budgetRepository) List(ctx context.Context, filter repository.BudgetFil
 			&b.Amount,
 			&b.Period,
 			&b.StartDate,
+			&b.PeriodStart,
 			&b.EndDate,
 			&b.IsActive,
+			&b.WarnThreshold,
 			&b.CreatedAt,
 		)
 		if err != nil {
@@ -166,10 +179,16 @@ func (r *budgetRepository) List(ctx context.Context, filter repository.BudgetFil
 func (r *budgetRepository) Update(ctx context.Context, budget *models.Budget) error {
 	query := `
 		UPDATE budgets
-		SET category_id = $2, amount = $3, period = $4, start_date = $5, end_date = $6, is_active = $7
+		SET category_id = $2, amount = $3, period = $4, start_date = $5, end_date = $6, is_active = $7,
+		    warn_threshold = $8, period_start = $9
 		WHERE id = $1
 	`
 
+	periodStart := budget.PeriodStart
+	if periodStart.IsZero() {
+		periodStart = budget.StartDate
+	}
+
 	result, err := r.pool.Exec(ctx, query,
 		budget.ID,
 		budget.CategoryID,
@@ -178,6 +197,8 @@ func (r *budgetRepository) Update(ctx context.Context, budget *models.Budget) er
 		budget.StartDate,
 		budget.EndDate,
 		budget.IsActive,
+		budget.WarnThreshold,
+		periodStart,
 	)
 
 	if err != nil {
@@ -208,21 +229,16 @@ func (r *budgetRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 // GetBudgetStatus menghitung status semua budget aktif.
-func (r *budgetRepository) GetBudgetStatus(ctx context.Context) ([]*repository.BudgetStatus, error) {
+//
+// Spent dihitung dalam Budget.PeriodWindow masing-masing budget. Window
+// dihitung di Go (utils.PeriodBounds), lalu dikirim sebagai array ke satu
+// query agregat.
+func (r *budgetRepository) GetBudgetStatus(ctx context.Context, now time.Time, monthStartDay int) ([]*repository.BudgetStatus, error) {
 	query := `
 		SELECT 
-			b.id, b.category_id, b.amount, b.period, b.start_date, b.end_date, b.is_active, b.created_at,
+			b.id, b.category_id, b.amount, b.period, b.start_date, b.period_start, b.end_date, b.is_active, b.warn_threshold, b.created_at,
 			c.name as category_name,
-			COALESCE(c.icon, '') as category_icon,
-			COALESCE(
-				(SELECT SUM(t.amount) 
-				 FROM transactions t 
-				 WHERE t.category_id = b.category_id 
-				   AND t.type = 'expense'
-				   AND t.transaction_date >= b.start_date
-				   AND (b.end_date IS NULL OR t.transaction_date <= b.end_date)
-				), 0
-			) as spent
+			COALESCE(c.icon, '') as category_icon
 		FROM budgets b
 		JOIN categories c ON c.id = b.category_id
 		WHERE b.is_active = true
@@ -233,7 +249,6 @@ func (r *budgetRepository) GetBudgetStatus(ctx context.Context) ([]*repository.B
 	if err != nil {
 		return nil, convertError(err)
 	}
-	defer rows.Close()
 
 	var statuses []*repository.BudgetStatus
 	for rows.Next() {
@@ -246,16 +261,73 @@ func (r *budgetRepository) GetBudgetStatus(ctx context.Context) ([]*repository.B
 			&b.Amount,
 			&b.Period,
 			&b.StartDate,
+			&b.PeriodStart,
 			&b.EndDate,
 			&b.IsActive,
+			&b.WarnThreshold,
 			&b.CreatedAt,
 			&s.CategoryName,
 			&s.CategoryIcon,
-			&s.Spent,
 		)
 		if err != nil {
+			rows.Close()
 			return nil, err
 		}
+		statuses = append(statuses, s)
+	}
+	// Tutup sebelum query berikutnya; di dalam transaction koneksinya sama
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, convertError(err)
+	}
+	if len(statuses) == 0 {
+		return statuses, nil
+	}
+
+	ids := make([]uuid.UUID, len(statuses))
+	starts := make([]time.Time, len(statuses))
+	ends := make([]time.Time, len(statuses))
+	for i, s := range statuses {
+		ids[i] = s.Budget.ID
+		s.PeriodStart, s.PeriodEnd = s.Budget.PeriodWindow(now, monthStartDay)
+		starts[i], ends[i] = s.PeriodStart, s.PeriodEnd
+	}
+
+	spentQuery := `
+		SELECT w.id, COALESCE(SUM(t.amount), 0)
+		FROM unnest($1::uuid[], $2::date[], $3::date[]) AS w(id, start_date, end_date)
+		JOIN budgets b ON b.id = w.id
+		LEFT JOIN transactions t
+			ON t.category_id = b.category_id
+			AND t.type = 'expense'
+			AND t.transaction_date >= w.start_date
+			AND t.transaction_date < w.end_date
+			AND (b.end_date IS NULL OR t.transaction_date <= b.end_date)
+		GROUP BY w.id
+	`
+
+	spentRows, err := r.pool.Query(ctx, spentQuery, ids, starts, ends)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer spentRows.Close()
+
+	spent := make(map[uuid.UUID]decimal.Decimal, len(statuses))
+	for spentRows.Next() {
+		var id uuid.UUID
+		var amount decimal.Decimal
+		if err := spentRows.Scan(&id, &amount); err != nil {
+			return nil, err
+		}
+		spent[id] = amount
+	}
+	if err := spentRows.Err(); err != nil {
+		return nil, convertError(err)
+	}
+
+	for _, s := range statuses {
+		b := s.Budget
+		s.Spent = spent[b.ID]
 
 		// Calculate remaining and progress
 		s.Remaining = b.Amount.Sub(s.Spent)
@@ -269,9 +341,7 @@ func (r *budgetRepository) GetBudgetStatus(ctx context.Context) ([]*repository.B
 		}
 
 		s.IsOverBudget = s.Spent.GreaterThan(b.Amount)
-
-		statuses = append(statuses, s)
 	}
 
-	return statuses, rows.Err()
+	return statuses, nil
 }
@@ -2,9 +2,9 @@ package postgres
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
@@ -12,12 +12,12 @@ import (
 
 // categoryRepository adalah implementasi PostgreSQL untuk CategoryRepository.
 type categoryRepository struct {
-	pool *pgxpool.Pool
+	pool Querier
 }
 
 // NewCategoryRepository membuat CategoryRepository baru.
-func NewCategoryRepository(pool *pgxpool.Pool) repository.CategoryRepository {
-	return &categoryRepository{pool: pool}
+func NewCategoryRepository(pool Querier) repository.CategoryRepository {
+	return &categoryRepository{pool: withTx(pool)}
 }
 
 // Create menyimpan category baru.
@@ -67,6 +67,47 @@ func (r *categoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models
 	return cat, nil
 }
 
+// Exists mengecek apakah category dengan ID tersebut ada, tanpa mengambil row-nya.
+func (r *categoryRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM categories WHERE id = $1)`
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return false, convertError(err)
+	}
+
+	return exists, nil
+}
+
+// GetByName mengambil category berdasarkan nama (case-insensitive).
+func (r *categoryRepository) GetByName(ctx context.Context, name string) (*models.Category, error) {
+	query := `
+		SELECT id, name, type, color, icon, parent_id, sort_order, created_at
+		FROM categories
+		WHERE LOWER(name) = LOWER($1)
+		ORDER BY parent_id NULLS FIRST, type, sort_order
+		LIMIT 1
+	`
+
+	cat := &models.Category{}
+	err := r.pool.QueryRow(ctx, query, strings.TrimSpace(name)).Scan(
+		&cat.ID,
+		&cat.Name,
+		&cat.Type,
+		&cat.Color,
+		&cat.Icon,
+		&cat.ParentID,
+		&cat.SortOrder,
+		&cat.CreatedAt,
+	)
+
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return cat, nil
+}
+
 // GetByType mengambil kategori berdasarkan tipe.
 // Hanya top-level categories (parent_id IS NULL).
 func (r *categoryRepository) GetByType(ctx context.Context, catType models.CategoryType) ([]*models.Category, error) {
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
@@ -15,19 +15,20 @@ import (
 
 // goalRepository adalah implementasi PostgreSQL untuk GoalRepository.
 type goalRepository struct {
-	pool *pgxpool.Pool
+	pool Querier
 }
 
 // NewGoalRepository membuat GoalRepository baru.
-func NewGoalRepository(pool *pgxpool.Pool) repository.GoalRepository {
-	return &goalRepository{pool: pool}
+func NewGoalRepository(pool Querier) repository.GoalRepository {
+	return &goalRepository{pool: withTx(pool)}
 }
 
-// Create menyimpan goal baru.
+// Create menyimpan goal baru. CreatedAt yang sudah diisi (restore
+// backup) dipertahankan.
 func (r *goalRepository) Create(ctx context.Context, goal *models.Goal) error {
 	query := `
-		INSERT INTO goals (id, name, description, target_amount, current_amount, deadline, status, color, icon)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO goals (id, name, description, target_amount, current_amount, deadline, status, color, icon, sort_order, kind, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE(NULLIF($11, ''), 'savings'), COALESCE($12, NOW()))
 	`
 
 	_, err := r.pool.Exec(ctx, query,
@@ -40,15 +41,25 @@ func (r *goalRepository) Create(ctx context.Context, goal *models.Goal) error {
 		goal.Status,
 		goal.Color,
 		goal.Icon,
+		goal.SortOrder,
+		goal.Kind,
+		createdAt(goal.CreatedAt),
 	)
+	if err != nil {
+		return convertError(err)
+	}
 
-	return convertError(err)
+	// version diisi DEFAULT 1 oleh database; kind kosong (backup lama)
+	// disimpan sebagai savings
+	goal.Version = 1
+	return nil
 }
 
 // GetByID mengambil goal berdasarkan ID.
 func (r *goalRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Goal, error) {
 	query := `
-		SELECT id, name, description, target_amount, current_amount, deadline, status, color, icon, created_at, updated_at
+		SELECT id, name, description, target_amount, current_amount, kind, deadline, status, color, icon, sort_order, version,
+		       created_at, updated_at
 		FROM goals
 		WHERE id = $1
 	`
@@ -60,10 +71,13 @@ func (r *goalRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Goa
 		&g.Description,
 		&g.TargetAmount,
 		&g.CurrentAmount,
+		&g.Kind,
 		&g.Deadline,
 		&g.Status,
 		&g.Color,
 		&g.Icon,
+		&g.SortOrder,
+		&g.Version,
 		&g.CreatedAt,
 		&g.UpdatedAt,
 	)
@@ -75,10 +89,23 @@ func (r *goalRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Goa
 	return g, nil
 }
 
+// Exists mengecek apakah goal dengan ID tersebut ada, tanpa mengambil row-nya.
+func (r *goalRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM goals WHERE id = $1)`
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return false, convertError(err)
+	}
+
+	return exists, nil
+}
+
 // List mengambil goals dengan filter.
 func (r *goalRepository) List(ctx context.Context, filter repository.GoalFilter) ([]*models.Goal, error) {
 	query := `
-		SELECT id, name, description, target_amount, current_amount, deadline, status, color, icon, created_at, updated_at
+		SELECT id, name, description, target_amount, current_amount, kind, deadline, status, color, icon, sort_order, version,
+		       created_at, updated_at
 		FROM goals
 	`
 
@@ -96,7 +123,7 @@ func (r *goalRepository) List(ctx context.Context, filter repository.GoalFilter)
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " ORDER BY created_at DESC"
+	query += " ORDER BY sort_order ASC, created_at DESC"
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -113,10 +140,13 @@ func (r *goalRepository) List(ctx context.Context, filter repository.GoalFilter)
 			&g.Description,
 			&g.TargetAmount,
 			&g.CurrentAmount,
+			&g.Kind,
 			&g.Deadline,
 			&g.Status,
 			&g.Color,
 			&g.Icon,
+			&g.SortOrder,
+			&g.Version,
 			&g.CreatedAt,
 			&g.UpdatedAt,
 		)
@@ -129,13 +159,15 @@ func (r *goalRepository) List(ctx context.Context, filter repository.GoalFilter)
 	return goals, rows.Err()
 }
 
-// Update memperbarui goal.
+// Update memperbarui goal dengan optimistic locking: row hanya diubah
+// jika version-nya masih goal.Version.
 func (r *goalRepository) Update(ctx context.Context, goal *models.Goal) error {
 	query := `
 		UPDATE goals
 		SET name = $2, description = $3, target_amount = $4, current_amount = $5, 
-		    deadline = $6, status = $7, color = $8, icon = $9
-		WHERE id = $1
+		    deadline = $6, status = $7, color = $8, icon = $9, sort_order = $10,
+		    version = version + 1
+		WHERE id = $1 AND version = $11
 	`
 
 	result, err := r.pool.Exec(ctx, query,
@@ -148,16 +180,27 @@ func (r *goalRepository) Update(ctx context.Context, goal *models.Goal) error {
 		goal.Status,
 		goal.Color,
 		goal.Icon,
+		goal.SortOrder,
+		goal.Version,
 	)
 
 	if err != nil {
 		return convertError(err)
 	}
 
+	// Tidak ada row berubah: goal tidak ada, atau version sudah naik
 	if result.RowsAffected() == 0 {
+		exists, err := r.Exists(ctx, goal.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return repository.ErrConflict
+		}
 		return repository.ErrNotFound
 	}
 
+	goal.Version++
 	return nil
 }
 
@@ -205,7 +248,7 @@ func (r *goalRepository) AddContribution(ctx context.Context, contribution *mode
 	// Update goal current_amount
 	updateQuery := `
 		UPDATE goals 
-		SET current_amount = current_amount + $2
+		SET current_amount = current_amount + $2, version = version + 1
 		WHERE id = $1
 	`
 	result, err := tx.Exec(ctx, updateQuery, contribution.GoalID, contribution.Amount)
@@ -220,6 +263,23 @@ func (r *goalRepository) AddContribution(ctx context.Context, contribution *mode
 	return tx.Commit(ctx)
 }
 
+// RestoreContribution menyimpan kontribusi dari backup apa adanya,
+// termasuk created_at, tanpa mengubah current_amount goal.
+func (r *goalRepository) RestoreContribution(ctx context.Context, contribution *models.GoalContribution) error {
+	query := `
+		INSERT INTO goal_contributions (id, goal_id, amount, note, created_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5, NOW()))
+	`
+	_, err := r.pool.Exec(ctx, query,
+		contribution.ID,
+		contribution.GoalID,
+		contribution.Amount,
+		contribution.Note,
+		createdAt(contribution.CreatedAt),
+	)
+	return convertError(err)
+}
+
 // GetContributions mengambil history kontribusi.
 func (r *goalRepository) GetContributions(
 	ctx context.Context,
@@ -232,7 +292,7 @@ func (r *goalRepository) GetContributions(
 		SELECT id, goal_id, amount, note, created_at
 		FROM goal_contributions
 		WHERE goal_id = $1
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 		LIMIT $2 OFFSET $3
 	`
 
@@ -263,7 +323,7 @@ func (r *goalRepository) GetContributions(
 
 // UpdateCurrentAmount mengupdate current_amount goal.
 func (r *goalRepository) UpdateCurrentAmount(ctx context.Context, id uuid.UUID, amount decimal.Decimal) error {
-	query := `UPDATE goals SET current_amount = $2 WHERE id = $1`
+	query := `UPDATE goals SET current_amount = $2, version = version + 1 WHERE id = $1`
 
 	result, err := r.pool.Exec(ctx, query, id, amount)
 	if err != nil {
@@ -276,3 +336,74 @@ func (r *goalRepository) UpdateCurrentAmount(ctx context.Context, id uuid.UUID,
 
 	return nil
 }
+
+// UpdateSortOrder mengupdate sort_order goal. Jika ctx membawa transaction
+// (TransactionManager.WithTransaction), query dijalankan di transaction
+// itu dan row goal tetap ter-lock sampai commit.
+func (r *goalRepository) UpdateSortOrder(ctx context.Context, id uuid.UUID, sortOrder int) error {
+	query := `
+		WITH locked AS (
+			SELECT id FROM goals WHERE id = $1 FOR UPDATE
+		)
+		UPDATE goals SET sort_order = $2, version = goals.version + 1
+		FROM locked
+		WHERE goals.id = locked.id
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, sortOrder)
+	if err != nil {
+		return convertError(err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListCompletedInPeriod mengambil goal completed yang terakhir di-update
+// di antara from dan to. updated_at dipakai sebagai tanggal selesai, jadi
+// goal completed yang diedit lagi ikut pindah periode.
+func (r *goalRepository) ListCompletedInPeriod(ctx context.Context, from, to time.Time) ([]*models.Goal, error) {
+	query := `
+		SELECT id, name, description, target_amount, current_amount, kind, deadline, status, color, icon, sort_order, version,
+		       created_at, updated_at
+		FROM goals
+		WHERE status = 'completed' AND updated_at BETWEEN $1 AND $2
+		ORDER BY updated_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+
+	var goals []*models.Goal
+	for rows.Next() {
+		g := &models.Goal{}
+		err := rows.Scan(
+			&g.ID,
+			&g.Name,
+			&g.Description,
+			&g.TargetAmount,
+			&g.CurrentAmount,
+			&g.Kind,
+			&g.Deadline,
+			&g.Status,
+			&g.Color,
+			&g.Icon,
+			&g.SortOrder,
+			&g.Version,
+			&g.CreatedAt,
+			&g.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, g)
+	}
+
+	return goals, rows.Err()
+}
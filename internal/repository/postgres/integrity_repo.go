@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// integrityRepository adalah implementasi PostgreSQL untuk IntegrityRepository.
+type integrityRepository struct {
+	pool Querier
+}
+
+// NewIntegrityRepository membuat IntegrityRepository baru.
+func NewIntegrityRepository(pool Querier) repository.IntegrityRepository {
+	return &integrityRepository{pool: withTx(pool)}
+}
+
+// FindOrphanContributions mengambil kontribusi tanpa goal.
+func (r *integrityRepository) FindOrphanContributions(ctx context.Context) ([]*repository.IntegrityIssue, error) {
+	query := `
+		SELECT 'goal_contributions', gc.id, gc.goal_id, gc.amount::text || ' ' || COALESCE(gc.note, '')
+		FROM goal_contributions gc
+		LEFT JOIN goals g ON g.id = gc.goal_id
+		WHERE g.id IS NULL
+		ORDER BY gc.created_at
+	`
+	return r.queryIssues(ctx, query)
+}
+
+// FindMissingCategoryRefs mengambil row dengan category_id yang tidak valid.
+func (r *integrityRepository) FindMissingCategoryRefs(ctx context.Context) ([]*repository.IntegrityIssue, error) {
+	query := `
+		SELECT 'transactions', t.id, t.category_id, t.amount::text || ' ' || COALESCE(t.description, '')
+		FROM transactions t
+		LEFT JOIN categories c ON c.id = t.category_id
+		WHERE t.category_id IS NOT NULL AND c.id IS NULL
+
+		UNION ALL
+
+		SELECT 'recurring_transactions', rt.id, rt.category_id, rt.amount::text || ' ' || COALESCE(rt.description, '')
+		FROM recurring_transactions rt
+		LEFT JOIN categories c ON c.id = rt.category_id
+		WHERE rt.category_id IS NOT NULL AND c.id IS NULL
+
+		UNION ALL
+
+		SELECT 'budgets', b.id, b.category_id, b.amount::text || ' ' || b.period::text
+		FROM budgets b
+		LEFT JOIN categories c ON c.id = b.category_id
+		WHERE c.id IS NULL
+	`
+	return r.queryIssues(ctx, query)
+}
+
+// FindMissingWalletRefs mengambil transaksi dengan wallet_id yang tidak valid.
+func (r *integrityRepository) FindMissingWalletRefs(ctx context.Context) ([]*repository.IntegrityIssue, error) {
+	query := `
+		SELECT 'transactions', t.id, t.wallet_id, t.amount::text || ' ' || COALESCE(t.description, '')
+		FROM transactions t
+		LEFT JOIN wallets w ON w.id = t.wallet_id
+		WHERE w.id IS NULL
+		ORDER BY t.transaction_date
+	`
+	return r.queryIssues(ctx, query)
+}
+
+// FindImportedCurrencyMismatches mengambil wallet dengan transaksi import
+// yang original_currency-nya berbeda dari currency wallet.
+func (r *integrityRepository) FindImportedCurrencyMismatches(ctx context.Context) ([]*repository.IntegrityIssue, error) {
+	query := `
+		SELECT 'wallets', w.id, '00000000-0000-0000-0000-000000000000'::uuid,
+		       w.name || ' (' || w.currency || '): ' || COUNT(*)::text || ' imported as ' || t.original_currency
+		FROM transactions t
+		JOIN wallets w ON w.id = t.wallet_id
+		WHERE t.original_currency IS NOT NULL AND t.original_currency <> w.currency
+		GROUP BY w.id, w.name, w.currency, t.original_currency
+		ORDER BY w.name, t.original_currency
+	`
+	return r.queryIssues(ctx, query)
+}
+
+// FindGoalAmountMismatches mengambil goals yang current_amount != SUM(kontribusi).
+func (r *integrityRepository) FindGoalAmountMismatches(ctx context.Context) ([]*repository.GoalAmountMismatch, error) {
+	query := `
+		SELECT g.id, g.name, g.current_amount, COALESCE(SUM(gc.amount), 0)
+		FROM goals g
+		LEFT JOIN goal_contributions gc ON gc.goal_id = g.id
+		GROUP BY g.id, g.name, g.current_amount
+		HAVING g.current_amount <> COALESCE(SUM(gc.amount), 0)
+		ORDER BY g.name
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find goal amount mismatches: %w", err)
+	}
+	defer rows.Close()
+
+	var mismatches []*repository.GoalAmountMismatch
+	for rows.Next() {
+		m := &repository.GoalAmountMismatch{}
+		if err := rows.Scan(&m.GoalID, &m.GoalName, &m.CurrentAmount, &m.ContributionSum); err != nil {
+			return nil, fmt.Errorf("failed to scan goal mismatch: %w", err)
+		}
+		mismatches = append(mismatches, m)
+	}
+
+	return mismatches, rows.Err()
+}
+
+// ClearMissingCategoryRefs men-set NULL category_id yang tidak valid.
+func (r *integrityRepository) ClearMissingCategoryRefs(ctx context.Context) (int, error) {
+	queries := []string{
+		`UPDATE transactions SET category_id = NULL
+		 WHERE category_id IS NOT NULL
+		   AND NOT EXISTS (SELECT 1 FROM categories c WHERE c.id = transactions.category_id)`,
+		`UPDATE recurring_transactions SET category_id = NULL
+		 WHERE category_id IS NOT NULL
+		   AND NOT EXISTS (SELECT 1 FROM categories c WHERE c.id = recurring_transactions.category_id)`,
+	}
+
+	fixed := 0
+	for _, query := range queries {
+		result, err := r.pool.Exec(ctx, query)
+		if err != nil {
+			return fixed, fmt.Errorf("failed to clear missing category refs: %w", err)
+		}
+		fixed += int(result.RowsAffected())
+	}
+
+	return fixed, nil
+}
+
+// RecomputeGoalAmounts men-set current_amount = SUM(kontribusi).
+func (r *integrityRepository) RecomputeGoalAmounts(ctx context.Context) (int, error) {
+	query := `
+		UPDATE goals g
+		SET current_amount = s.total, version = g.version + 1
+		FROM (
+			SELECT g2.id, COALESCE(SUM(gc.amount), 0) AS total
+			FROM goals g2
+			LEFT JOIN goal_contributions gc ON gc.goal_id = g2.id
+			GROUP BY g2.id
+		) s
+		WHERE g.id = s.id AND g.current_amount <> s.total
+	`
+
+	result, err := r.pool.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to recompute goal amounts: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// queryIssues menjalankan query yang return (table, id, missing_id, detail).
+func (r *integrityRepository) queryIssues(ctx context.Context, query string) ([]*repository.IntegrityIssue, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []*repository.IntegrityIssue
+	for rows.Next() {
+		issue := &repository.IntegrityIssue{}
+		if err := rows.Scan(&issue.Table, &issue.ID, &issue.MissingID, &issue.Detail); err != nil {
+			return nil, fmt.Errorf("failed to scan integrity issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, rows.Err()
+}
@@ -8,16 +8,19 @@
 //
 // Pattern yang digunakan:
 //
-// 1. Struct dengan pool: Setiap repository struct menyimpan reference ke pool.
+// 1. Struct dengan pool: Setiap repository struct menyimpan reference ke pool
+// sebagai Querier, supaya pool bisa dibungkus WithQueryTimeout.
 //
 //	type walletRepository struct {
-//	    pool *pgxpool.Pool
+//	    pool Querier
 //	}
 //
-// 2. Constructor dengan pool injection:
+// 2. Constructor dengan pool injection (*pgxpool.Pool memenuhi Querier).
+// Pool dibungkus withTx, jadi di dalam TransactionManager.WithTransaction
+// semua query otomatis berjalan di transaction tersebut:
 //
-//	func NewWalletRepository(pool *pgxpool.Pool) repository.WalletRepository {
-//	    return &walletRepository{pool: pool}
+//	func NewWalletRepository(pool Querier) repository.WalletRepository {
+//	    return &walletRepository{pool: withTx(pool)}
 //	}
 //
 // 3. Query methods menggunakan pool:
@@ -31,10 +34,10 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
 )
@@ -62,11 +65,13 @@ const (
 //	    return nil
 //	})
 type TransactionManager struct {
-	pool *pgxpool.Pool
+	pool Querier
 }
 
-// NewTransactionManager membuat TransactionManager baru.
-func NewTransactionManager(pool *pgxpool.Pool) *TransactionManager {
+// NewTransactionManager membuat TransactionManager baru. Berikan pool
+// yang sama dengan repositories (misalnya hasil WithQueryTimeout), supaya
+// statement di dalam transaction juga dibatasi timeout.
+func NewTransactionManager(pool Querier) *TransactionManager {
 	return &TransactionManager{pool: pool}
 }
 
@@ -78,9 +83,15 @@ func NewTransactionManager(pool *pgxpool.Pool) *TransactionManager {
 // 3. Jika fn return error -> Rollback
 // 4. Jika fn return nil -> Commit
 //
-// PENTING: Repository implementations harus check context untuk transaction.
-// Jika ada transaction di context, gunakan tx tersebut bukan pool.
-func (tm *TransactionManager) WithTransaction(ctx context.Context, fn repository.TxFunc) error {
+// Repositories di package ini menjalankan query di tx dari context (lihat
+// withTx), jadi semua tulisan di dalam fn ikut commit/rollback bersama.
+// Nested call ikut transaksi luar: fn langsung dijalankan dengan tx yang
+// sama, dan error-nya me-rollback seluruh transaksi luar.
+func (tm *TransactionManager) WithTransaction(ctx context.Context, fn repository.TxFunc) (err error) {
+	if GetTx(ctx) != nil {
+		return fn(ctx)
+	}
+
 	tx, err := tm.pool.Begin(ctx)
 	if err != nil {
 		return err
@@ -117,6 +128,56 @@ func GetTx(ctx context.Context) pgx.Tx {
 	return nil
 }
 
+// txQuerier menjalankan query di transaction dari context
+// (TransactionManager.WithTransaction) jika ada, dan di db jika tidak.
+type txQuerier struct {
+	db Querier
+}
+
+// withTx membungkus db dengan txQuerier. Dipanggil oleh setiap
+// constructor repository.
+func withTx(db Querier) Querier {
+	if q, ok := db.(*txQuerier); ok {
+		return q
+	}
+	return &txQuerier{db: db}
+}
+
+// conn mengembalikan tx dari ctx, atau db jika tidak ada.
+func (q *txQuerier) conn(ctx context.Context) Querier {
+	if tx := GetTx(ctx); tx != nil {
+		return tx
+	}
+	return q.db
+}
+
+func (q *txQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return q.conn(ctx).Exec(ctx, sql, args...)
+}
+
+func (q *txQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return q.conn(ctx).Query(ctx, sql, args...)
+}
+
+func (q *txQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return q.conn(ctx).QueryRow(ctx, sql, args...)
+}
+
+// Begin di dalam transaction membuat savepoint (pgx.Tx.Begin).
+func (q *txQuerier) Begin(ctx context.Context) (pgx.Tx, error) {
+	return q.conn(ctx).Begin(ctx)
+}
+
+// createdAt mengembalikan nil untuk waktu kosong, supaya INSERT dengan
+// COALESCE($n, NOW()) memakai waktu sekarang untuk row baru dan
+// mempertahankan created_at row dari backup.
+func createdAt(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
 // convertError mengkonversi PostgreSQL error ke repository error.
 // Ini membantu abstraksi sehingga caller tidak perlu depend pada pgx errors.
 func convertError(err error) error {
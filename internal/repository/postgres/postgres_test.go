@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// recordingConn mencatat statement yang dijalankan, untuk mengecek
+// apakah query berjalan di pool atau di transaction.
+type recordingConn struct {
+	execs int
+	tx    *recordingTx
+}
+
+func (c *recordingConn) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	c.execs++
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (c *recordingConn) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *recordingConn) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return nil
+}
+
+func (c *recordingConn) Begin(ctx context.Context) (pgx.Tx, error) {
+	c.tx = &recordingTx{}
+	return c.tx, nil
+}
+
+// recordingTx adalah pgx.Tx palsu; method yang tidak dipakai test panic
+// lewat embedded nil interface.
+type recordingTx struct {
+	pgx.Tx
+	execs                 int
+	committed, rolledBack bool
+}
+
+func (t *recordingTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	t.execs++
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (t *recordingTx) Commit(ctx context.Context) error {
+	t.committed = true
+	return nil
+}
+
+func (t *recordingTx) Rollback(ctx context.Context) error {
+	t.rolledBack = true
+	return nil
+}
+
+func TestTransactionManager_RepositoriesUseTx(t *testing.T) {
+	ctx := context.Background()
+	db := &recordingConn{}
+	tm := NewTransactionManager(db)
+	wallets := NewWalletRepository(db)
+	goals := NewGoalRepository(db)
+
+	errBoom := errors.New("boom")
+	err := tm.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := wallets.Delete(ctx, uuid.New()); err != nil {
+			return err
+		}
+		// Nested call ikut transaksi luar
+		return tm.WithTransaction(ctx, func(ctx context.Context) error {
+			if err := goals.UpdateSortOrder(ctx, uuid.New(), 1); err != nil {
+				return err
+			}
+			return errBoom
+		})
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("WithTransaction() error = %v, want %v", err, errBoom)
+	}
+
+	tx := db.tx
+	if db.execs != 0 || tx.execs != 2 {
+		t.Errorf("pool got %d statements and tx %d, want 0 and 2", db.execs, tx.execs)
+	}
+	if tx.committed || !tx.rolledBack {
+		t.Errorf("tx committed = %v, rolled back = %v, want a rollback only", tx.committed, tx.rolledBack)
+	}
+
+	// Di luar transaction query ke pool
+	if err := wallets.Delete(ctx, uuid.New()); err != nil || db.execs != 1 {
+		t.Errorf("Delete() outside a transaction = %v with %d pool statements, want nil and 1", err, db.execs)
+	}
+}
+
+func TestTransactionManager_Commit(t *testing.T) {
+	db := &recordingConn{}
+	tm := NewTransactionManager(WithQueryTimeout(db, 0))
+
+	err := tm.WithTransaction(context.Background(), func(ctx context.Context) error {
+		return NewWalletRepository(db).Delete(ctx, uuid.New())
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction() error = %v", err)
+	}
+	if !db.tx.committed || db.tx.rolledBack || db.tx.execs != 1 {
+		t.Errorf("tx committed = %v, rolled back = %v, statements = %d; want commit with 1 statement",
+			db.tx.committed, db.tx.rolledBack, db.tx.execs)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
@@ -15,20 +14,20 @@ import (
 
 // recurringRepository adalah implementasi PostgreSQL untuk RecurringRepository.
 type recurringRepository struct {
-	pool *pgxpool.Pool
+	pool Querier
 }
 
 // NewRecurringRepository membuat RecurringRepository baru.
-func NewRecurringRepository(pool *pgxpool.Pool) repository.RecurringRepository {
-	return &recurringRepository{pool: pool}
+func NewRecurringRepository(pool Querier) repository.RecurringRepository {
+	return &recurringRepository{pool: withTx(pool)}
 }
 
 // Create menyimpan recurring transaction baru.
 func (r *recurringRepository) Create(ctx context.Context, recurring *models.RecurringTransaction) error {
 	query := `
 		INSERT INTO recurring_transactions 
-			(id, wallet_id, category_id, type, amount, description, frequency, next_due, end_date, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			(id, wallet_id, category_id, type, amount, description, frequency, next_due, end_date, is_active, day_of_month)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	_, err := r.pool.Exec(ctx, query,
@@ -42,6 +41,7 @@ func (r *recurringRepository) Create(ctx context.Context, recurring *models.Recu
 		recurring.NextDue,
 		recurring.EndDate,
 		recurring.IsActive,
+		recurring.DayOfMonth,
 	)
 
 	return convertError(err)
@@ -51,7 +51,7 @@ func (r *recurringRepository) Create(ctx context.Context, recurring *models.Recu
 func (r *recurringRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.RecurringTransaction, error) {
 	query := `
 		SELECT id, wallet_id, category_id, type, amount, description, frequency, 
-		       next_due, end_date, is_active, created_at
+		       next_due, end_date, is_active, created_at, day_of_month
 		FROM recurring_transactions
 		WHERE id = $1
 	`
@@ -69,6 +69,7 @@ func (r *recurringRepository) GetByID(ctx context.Context, id uuid.UUID) (*model
 		&rec.EndDate,
 		&rec.IsActive,
 		&rec.CreatedAt,
+		&rec.DayOfMonth,
 	)
 
 	if err != nil {
@@ -85,7 +86,7 @@ func (r *recurringRepository) List(
 ) ([]*models.RecurringTransaction, error) {
 	query := `
 		SELECT id, wallet_id, category_id, type, amount, description, frequency,
-		       next_due, end_date, is_active, created_at
+		       next_due, end_date, is_active, created_at, day_of_month
 		FROM recurring_transactions
 	`
 
@@ -117,54 +118,25 @@ func (r *recurringRepository) List(
 		argIndex++
 	}
 
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	if filter.NextDueBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("next_due <= $%d", argIndex))
+		args = append(args, *filter.NextDueBefore)
+		argIndex++
 	}
 
-	query += " ORDER BY next_due ASC"
-
-	rows, err := r.pool.Query(ctx, query, args...)
-	if err != nil {
-		return nil, convertError(err)
+	if filter.NextDueAfter != nil {
+		conditions = append(conditions, fmt.Sprintf("next_due >= $%d", argIndex))
+		args = append(args, *filter.NextDueAfter)
+		argIndex++
 	}
-	defer rows.Close()
 
-	var recurrings []*models.RecurringTransaction
-	for rows.Next() {
-		rec := &models.RecurringTransaction{}
-		err := rows.Scan(
-			&rec.ID,
-			&rec.WalletID,
-			&rec.CategoryID,
-			&rec.Type,
-			&rec.Amount,
-			&rec.Description,
-			&rec.Frequency,
-			&rec.NextDue,
-			&rec.EndDate,
-			&rec.IsActive,
-			&rec.CreatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		recurrings = append(recurrings, rec)
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	return recurrings, rows.Err()
-}
-
-// GetDue mengambil recurring yang jatuh tempo (next_due <= today).
-func (r *recurringRepository) GetDue(ctx context.Context) ([]*models.RecurringTransaction, error) {
-	query := `
-		SELECT id, wallet_id, category_id, type, amount, description, frequency,
-		       next_due, end_date, is_active, created_at
-		FROM recurring_transactions
-		WHERE is_active = true AND next_due <= CURRENT_DATE
-		ORDER BY next_due ASC
-	`
+	query += " ORDER BY next_due ASC"
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, convertError(err)
 	}
@@ -185,6 +157,7 @@ func (r *recurringRepository) GetDue(ctx context.Context) ([]*models.RecurringTr
 			&rec.EndDate,
 			&rec.IsActive,
 			&rec.CreatedAt,
+			&rec.DayOfMonth,
 		)
 		if err != nil {
 			return nil, err
@@ -200,7 +173,7 @@ func (r *recurringRepository) Update(ctx context.Context, recurring *models.Recu
 	query := `
 		UPDATE recurring_transactions
 		SET wallet_id = $2, category_id = $3, type = $4, amount = $5, description = $6,
-		    frequency = $7, next_due = $8, end_date = $9, is_active = $10
+		    frequency = $7, next_due = $8, end_date = $9, is_active = $10, day_of_month = $11
 		WHERE id = $1
 	`
 
@@ -215,6 +188,7 @@ func (r *recurringRepository) Update(ctx context.Context, recurring *models.Recu
 		recurring.NextDue,
 		recurring.EndDate,
 		recurring.IsActive,
+		recurring.DayOfMonth,
 	)
 
 	if err != nil {
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// ruleRepository adalah implementasi PostgreSQL untuk RuleRepository.
+type ruleRepository struct {
+	pool Querier
+}
+
+// NewRuleRepository membuat RuleRepository baru.
+func NewRuleRepository(pool Querier) repository.RuleRepository {
+	return &ruleRepository{pool: withTx(pool)}
+}
+
+// Create menyimpan rule baru.
+func (r *ruleRepository) Create(ctx context.Context, rule *models.CategorizationRule) error {
+	query := `
+		INSERT INTO categorization_rules (id, priority, match_type, pattern, wallet_id, category_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = time.Now()
+	}
+
+	_, err := r.pool.Exec(ctx, query,
+		rule.ID,
+		rule.Priority,
+		rule.MatchType,
+		rule.Pattern,
+		rule.WalletID,
+		rule.CategoryID,
+		rule.CreatedAt,
+	)
+
+	return convertError(err)
+}
+
+// GetByID mengambil rule berdasarkan ID.
+func (r *ruleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CategorizationRule, error) {
+	query := `
+		SELECT id, priority, match_type, pattern, wallet_id, category_id, created_at
+		FROM categorization_rules
+		WHERE id = $1
+	`
+
+	rule := &models.CategorizationRule{}
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&rule.ID,
+		&rule.Priority,
+		&rule.MatchType,
+		&rule.Pattern,
+		&rule.WalletID,
+		&rule.CategoryID,
+		&rule.CreatedAt,
+	)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return rule, nil
+}
+
+// List mengambil semua rule urut priority lalu created_at.
+func (r *ruleRepository) List(ctx context.Context) ([]*models.CategorizationRule, error) {
+	query := `
+		SELECT id, priority, match_type, pattern, wallet_id, category_id, created_at
+		FROM categorization_rules
+		ORDER BY priority ASC, created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+
+	var rules []*models.CategorizationRule
+	for rows.Next() {
+		rule := &models.CategorizationRule{}
+		err := rows.Scan(
+			&rule.ID,
+			&rule.Priority,
+			&rule.MatchType,
+			&rule.Pattern,
+			&rule.WalletID,
+			&rule.CategoryID,
+			&rule.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// Delete menghapus rule.
+func (r *ruleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM categorization_rules WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return convertError(err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// Querier adalah operasi database yang dipakai repositories.
+// Dipenuhi oleh *database.PostgresDB, *pgxpool.Pool, pgx.Tx, dan wrapper
+// WithQueryTimeout.
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// WithQueryTimeout membungkus db supaya setiap query dibatasi timeout.
+// Query yang melewati batas dibatalkan dan mengembalikan
+// *repository.QueryTimeoutError. timeout <= 0 berarti tanpa batas.
+//
+//	db := postgres.WithQueryTimeout(pool, cfg.Database.QueryTimeout)
+//	walletRepo := postgres.NewWalletRepository(db)
+func WithQueryTimeout(db Querier, timeout time.Duration) Querier {
+	if timeout <= 0 {
+		return db
+	}
+	return &timeoutQuerier{db: db, timeout: timeout}
+}
+
+// timeoutQuerier menjalankan setiap query dengan context.WithTimeout.
+type timeoutQuerier struct {
+	db      Querier
+	timeout time.Duration
+}
+
+// Exec menjalankan statement dengan timeout.
+func (q *timeoutQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, q.timeout)
+	defer cancel()
+
+	tag, err := q.db.Exec(queryCtx, sql, args...)
+	return tag, q.convertError(ctx, queryCtx, err)
+}
+
+// Query menjalankan query dengan timeout. Timeout berlaku sampai rows
+// di-Close, jadi membaca rows juga dibatasi.
+func (q *timeoutQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, q.timeout)
+
+	rows, err := q.db.Query(queryCtx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, q.convertError(ctx, queryCtx, err)
+	}
+	return &timeoutRows{Rows: rows, q: q, parent: ctx, ctx: queryCtx, cancel: cancel}, nil
+}
+
+// QueryRow menjalankan query satu row dengan timeout sampai Scan selesai.
+func (q *timeoutQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	queryCtx, cancel := context.WithTimeout(ctx, q.timeout)
+	return &timeoutRow{row: q.db.QueryRow(queryCtx, sql, args...), q: q, parent: ctx, ctx: queryCtx, cancel: cancel}
+}
+
+// Begin memulai transaction. Timeout berlaku per statement di dalamnya,
+// bukan untuk umur transaction.
+func (q *timeoutQuerier) Begin(ctx context.Context) (pgx.Tx, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, q.timeout)
+	defer cancel()
+
+	tx, err := q.db.Begin(queryCtx)
+	if err != nil {
+		return nil, q.convertError(ctx, queryCtx, err)
+	}
+	return &timeoutTx{Tx: tx, q: &timeoutQuerier{db: tx, timeout: q.timeout}}, nil
+}
+
+// convertError mengganti error query yang dibatalkan karena timeout
+// dengan *repository.QueryTimeoutError. Jika parent yang dibatalkan
+// (Ctrl+C, deadline milik caller), error asli dikembalikan.
+func (q *timeoutQuerier) convertError(parent, queryCtx context.Context, err error) error {
+	if err == nil || parent.Err() != nil {
+		return err
+	}
+	if errors.Is(queryCtx.Err(), context.DeadlineExceeded) {
+		return &repository.QueryTimeoutError{Timeout: q.timeout}
+	}
+	return err
+}
+
+// timeoutRows membatalkan context query saat rows di-Close.
+type timeoutRows struct {
+	pgx.Rows
+	q      *timeoutQuerier
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
+
+func (r *timeoutRows) Err() error {
+	return r.q.convertError(r.parent, r.ctx, r.Rows.Err())
+}
+
+func (r *timeoutRows) Scan(dest ...any) error {
+	return r.q.convertError(r.parent, r.ctx, r.Rows.Scan(dest...))
+}
+
+// timeoutRow membatalkan context query setelah Scan.
+type timeoutRow struct {
+	row    pgx.Row
+	q      *timeoutQuerier
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRow) Scan(dest ...any) error {
+	defer r.cancel()
+	return r.q.convertError(r.parent, r.ctx, r.row.Scan(dest...))
+}
+
+// timeoutTx adalah pgx.Tx yang statement-nya dibatasi timeout.
+type timeoutTx struct {
+	pgx.Tx
+	q *timeoutQuerier
+}
+
+func (t *timeoutTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return t.q.Exec(ctx, sql, args...)
+}
+
+func (t *timeoutTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return t.q.Query(ctx, sql, args...)
+}
+
+func (t *timeoutTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return t.q.QueryRow(ctx, sql, args...)
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// blockingQuerier mensimulasikan koneksi yang menggantung: setiap query
+// baru selesai saat context-nya dibatalkan.
+type blockingQuerier struct{}
+
+func (blockingQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	<-ctx.Done()
+	return pgconn.CommandTag{}, ctx.Err()
+}
+
+func (blockingQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return blockingRow{ctx: ctx}
+}
+
+func (blockingQuerier) Begin(ctx context.Context) (pgx.Tx, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type blockingRow struct{ ctx context.Context }
+
+func (r blockingRow) Scan(dest ...any) error {
+	<-r.ctx.Done()
+	return r.ctx.Err()
+}
+
+func TestWithQueryTimeout_TimesOut(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWalletRepository(WithQueryTimeout(blockingQuerier{}, 20*time.Millisecond))
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{"QueryRow", func() error { _, err := repo.GetByID(ctx, uuid.New()); return err }},
+		{"Query", func() error { _, err := repo.List(ctx, repository.WalletFilter{}); return err }},
+		{"Exec", func() error { return repo.Delete(ctx, uuid.New()) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			if !errors.Is(err, repository.ErrQueryTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("error = %v, want QueryTimeoutError", err)
+			}
+			if want := "database query timed out after 20ms"; !strings.Contains(err.Error(), want) {
+				t.Errorf("error = %q, want it to contain %q", err, want)
+			}
+		})
+	}
+}
+
+func TestWithQueryTimeout_CancelPropagates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	repo := NewWalletRepository(WithQueryTimeout(blockingQuerier{}, time.Minute))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := repo.GetByID(ctx, uuid.New())
+		done <- err
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("error = %v, want context.Canceled", err)
+		}
+		if errors.Is(err, repository.ErrQueryTimeout) {
+			t.Errorf("error = %v, cancellation must not be reported as a timeout", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("query was not aborted after the context was cancelled")
+	}
+}
+
+func TestWithQueryTimeout_Disabled(t *testing.T) {
+	var db Querier = blockingQuerier{}
+	if got := WithQueryTimeout(db, 0); got != db {
+		t.Errorf("WithQueryTimeout(db, 0) = %T, want db unchanged", got)
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
@@ -15,20 +15,23 @@ import (
 
 // transactionRepository adalah implementasi PostgreSQL untuk TransactionRepository.
 type transactionRepository struct {
-	pool *pgxpool.Pool
+	pool Querier
 }
 
 // NewTransactionRepository membuat TransactionRepository baru.
-func NewTransactionRepository(pool *pgxpool.Pool) repository.TransactionRepository {
-	return &transactionRepository{pool: pool}
+func NewTransactionRepository(pool Querier) repository.TransactionRepository {
+	return &transactionRepository{pool: withTx(pool)}
 }
 
-// Create menyimpan transaction baru.
+// Create menyimpan transaction baru. CreatedAt yang sudah diisi (restore
+// backup) dipertahankan.
 func (r *transactionRepository) Create(ctx context.Context, tx *models.Transaction) error {
 	query := `
 		INSERT INTO transactions 
-			(id, wallet_id, category_id, type, amount, description, tags, transaction_date)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			(id, wallet_id, category_id, type, amount, description, tags, transaction_date, attachment,
+			 original_currency, idempotency_key, external_reference, rate_to_base, note, original_amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''), $13, $14, $15,
+			COALESCE($16, NOW()))
 	`
 
 	_, err := r.pool.Exec(ctx, query,
@@ -40,16 +43,89 @@ func (r *transactionRepository) Create(ctx context.Context, tx *models.Transacti
 		tx.Description,
 		tx.Tags,
 		tx.TransactionDate,
+		tx.Attachment,
+		tx.OriginalCurrency,
+		tx.IdempotencyKey,
+		tx.ExternalReference,
+		tx.RateToBase,
+		tx.Note,
+		tx.OriginalAmount,
+		createdAt(tx.CreatedAt),
 	)
 
 	return convertError(err)
 }
 
+// transactionInsertColumns adalah jumlah parameter per row di
+// transactionInsertRow.
+const transactionInsertColumns = 16
+
+// maxBatchInsertRows membatasi jumlah row per INSERT di CreateBatch,
+// jauh di bawah batas 65535 parameter PostgreSQL.
+const maxBatchInsertRows = 1000
+
+// CreateBatch menyimpan banyak transaction dengan multi-row INSERT, per
+// maxBatchInsertRows row. Kolom dan default-nya sama dengan Create.
+// Panggil dalam TransactionManager.WithTransaction supaya chunk yang
+// sudah masuk ikut di-rollback jika chunk berikutnya gagal.
+func (r *transactionRepository) CreateBatch(ctx context.Context, txs []*models.Transaction) error {
+	for start := 0; start < len(txs); start += maxBatchInsertRows {
+		chunk := txs[start:min(start+maxBatchInsertRows, len(txs))]
+
+		rows := make([]string, len(chunk))
+		args := make([]interface{}, 0, len(chunk)*transactionInsertColumns)
+		for i, tx := range chunk {
+			rows[i] = transactionInsertRow(i*transactionInsertColumns + 1)
+			args = append(args,
+				tx.ID,
+				tx.WalletID,
+				tx.CategoryID,
+				tx.Type,
+				tx.Amount,
+				tx.Description,
+				tx.Tags,
+				tx.TransactionDate,
+				tx.Attachment,
+				tx.OriginalCurrency,
+				tx.IdempotencyKey,
+				tx.ExternalReference,
+				tx.RateToBase,
+				tx.Note,
+				tx.OriginalAmount,
+				createdAt(tx.CreatedAt),
+			)
+		}
+
+		query := `
+			INSERT INTO transactions
+				(id, wallet_id, category_id, type, amount, description, tags, transaction_date, attachment,
+				 original_currency, idempotency_key, external_reference, rate_to_base, note, original_amount, created_at)
+			VALUES ` + strings.Join(rows, ", ")
+
+		if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+			return convertError(err)
+		}
+	}
+	return nil
+}
+
+// transactionInsertRow membuat placeholder VALUES satu row untuk
+// CreateBatch, mulai dari $first. Urutan dan NULLIF/COALESCE-nya sama
+// dengan query Create.
+func transactionInsertRow(first int) string {
+	p := make([]interface{}, transactionInsertColumns)
+	for i := range p {
+		p[i] = first + i
+	}
+	return fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, NULLIF($%d, ''), NULLIF($%d, ''), NULLIF($%d, ''), $%d, $%d, $%d, COALESCE($%d, NOW()))", p...)
+}
+
 // GetByID mengambil transaction berdasarkan ID.
 func (r *transactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
 	query := `
 		SELECT id, wallet_id, category_id, type, amount, description, tags, 
-		       transaction_date, created_at, updated_at
+		       transaction_date, attachment, COALESCE(original_currency, ''), COALESCE(idempotency_key, ''), COALESCE(external_reference, ''), rate_to_base, original_amount, note,
+		       created_at, updated_at
 		FROM transactions
 		WHERE id = $1
 	`
@@ -64,6 +140,51 @@ func (r *transactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*mod
 		&tx.Description,
 		&tx.Tags,
 		&tx.TransactionDate,
+		&tx.Attachment,
+		&tx.OriginalCurrency,
+		&tx.IdempotencyKey,
+		&tx.ExternalReference,
+		&tx.RateToBase,
+		&tx.OriginalAmount,
+		&tx.Note,
+		&tx.CreatedAt,
+		&tx.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return tx, nil
+}
+
+// GetByIdempotencyKey mengambil transaction berdasarkan idempotency key.
+func (r *transactionRepository) GetByIdempotencyKey(ctx context.Context, key string) (*models.Transaction, error) {
+	query := `
+		SELECT id, wallet_id, category_id, type, amount, description, tags,
+		       transaction_date, attachment, COALESCE(original_currency, ''), COALESCE(idempotency_key, ''), COALESCE(external_reference, ''), rate_to_base, original_amount, note,
+		       created_at, updated_at
+		FROM transactions
+		WHERE idempotency_key = $1
+	`
+
+	tx := &models.Transaction{}
+	err := r.pool.QueryRow(ctx, query, key).Scan(
+		&tx.ID,
+		&tx.WalletID,
+		&tx.CategoryID,
+		&tx.Type,
+		&tx.Amount,
+		&tx.Description,
+		&tx.Tags,
+		&tx.TransactionDate,
+		&tx.Attachment,
+		&tx.OriginalCurrency,
+		&tx.IdempotencyKey,
+		&tx.ExternalReference,
+		&tx.RateToBase,
+		&tx.OriginalAmount,
+		&tx.Note,
 		&tx.CreatedAt,
 		&tx.UpdatedAt,
 	)
@@ -75,6 +196,36 @@ func (r *transactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*mod
 	return tx, nil
 }
 
+// ExistingExternalReferences mengembalikan refs yang sudah dipakai di wallet.
+func (r *transactionRepository) ExistingExternalReferences(ctx context.Context, walletID uuid.UUID, refs []string) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT external_reference
+		FROM transactions
+		WHERE wallet_id = $1 AND external_reference = ANY($2)
+	`
+
+	rows, err := r.pool.Query(ctx, query, walletID, refs)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+
+	var existing []string
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			return nil, convertError(err)
+		}
+		existing = append(existing, ref)
+	}
+
+	return existing, rows.Err()
+}
+
 // List mengambil transactions dengan filter.
 func (r *transactionRepository) List(
 	ctx context.Context,
@@ -85,7 +236,8 @@ func (r *transactionRepository) List(
 
 	query := `
 		SELECT id, wallet_id, category_id, type, amount, description, tags,
-		       transaction_date, created_at, updated_at
+		       transaction_date, attachment, COALESCE(original_currency, ''), COALESCE(idempotency_key, ''), COALESCE(external_reference, ''), rate_to_base, original_amount, note,
+		       created_at, updated_at
 		FROM transactions
 	`
 
@@ -94,16 +246,18 @@ func (r *transactionRepository) List(
 	argIndex := 1
 
 	// Build WHERE clauses
-	if filter.WalletID != nil {
-		conditions = append(conditions, fmt.Sprintf("wallet_id = $%d", argIndex))
-		args = append(args, *filter.WalletID)
-		argIndex++
+	if walletIDs := filter.AllWalletIDs(); len(walletIDs) > 0 {
+		var condition string
+		condition, args, argIndex = inCondition("wallet_id", walletIDs, args, argIndex)
+		conditions = append(conditions, condition)
 	}
 
 	if filter.CategoryID != nil {
 		conditions = append(conditions, fmt.Sprintf("category_id = $%d", argIndex))
 		args = append(args, *filter.CategoryID)
 		argIndex++
+	} else if filter.Uncategorized {
+		conditions = append(conditions, "category_id IS NULL")
 	}
 
 	if filter.Type != nil {
@@ -125,16 +279,28 @@ func (r *transactionRepository) List(
 	}
 
 	if filter.Search != nil && *filter.Search != "" {
-		conditions = append(conditions, fmt.Sprintf("description ILIKE $%d", argIndex))
+		condition := fmt.Sprintf("description ILIKE $%d", argIndex)
+		if filter.SearchNotes {
+			condition = fmt.Sprintf("(description ILIKE $%[1]d OR note ILIKE $%[1]d)", argIndex)
+		}
+		conditions = append(conditions, condition)
 		args = append(args, "%"+*filter.Search+"%")
 		argIndex++
 	}
 
+	if filter.ExcludeAdjustments {
+		conditions = append(conditions, fmt.Sprintf("NOT ($%d = ANY(COALESCE(tags, '{}')))", argIndex))
+		args = append(args, models.TagAdjustment)
+		argIndex++
+	}
+
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " ORDER BY transaction_date DESC, created_at DESC"
+	// id sebagai tie-breaker supaya halaman tidak tumpang tindih untuk
+	// transaksi dengan tanggal dan created_at yang sama
+	query += " ORDER BY transaction_date DESC, created_at DESC, id DESC"
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
 	args = append(args, params.Limit, params.Offset)
 
@@ -156,6 +322,13 @@ func (r *transactionRepository) List(
 			&tx.Description,
 			&tx.Tags,
 			&tx.TransactionDate,
+			&tx.Attachment,
+			&tx.OriginalCurrency,
+			&tx.IdempotencyKey,
+			&tx.ExternalReference,
+			&tx.RateToBase,
+			&tx.OriginalAmount,
+			&tx.Note,
 			&tx.CreatedAt,
 			&tx.UpdatedAt,
 		)
@@ -173,7 +346,7 @@ func (r *transactionRepository) Update(ctx context.Context, tx *models.Transacti
 	query := `
 		UPDATE transactions
 		SET wallet_id = $2, category_id = $3, type = $4, amount = $5, 
-		    description = $6, tags = $7, transaction_date = $8
+		    description = $6, tags = $7, transaction_date = $8, attachment = $9, note = $10
 		WHERE id = $1
 	`
 
@@ -186,6 +359,8 @@ func (r *transactionRepository) Update(ctx context.Context, tx *models.Transacti
 		tx.Description,
 		tx.Tags,
 		tx.TransactionDate,
+		tx.Attachment,
+		tx.Note,
 	)
 
 	if err != nil {
@@ -199,6 +374,22 @@ func (r *transactionRepository) Update(ctx context.Context, tx *models.Transacti
 	return nil
 }
 
+// UpdateCategoryAndTags hanya mengubah category_id dan tags.
+func (r *transactionRepository) UpdateCategoryAndTags(ctx context.Context, id uuid.UUID, categoryID *uuid.UUID, tags []string) error {
+	query := `UPDATE transactions SET category_id = $2, tags = $3 WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, id, categoryID, tags)
+	if err != nil {
+		return convertError(err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
 // Delete menghapus transaction.
 func (r *transactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM transactions WHERE id = $1`
@@ -215,15 +406,52 @@ func (r *transactionRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+// DeleteByWallet menghapus semua transaction milik wallet.
+// Wallet tanpa transaksi (atau yang tidak ada) menghasilkan 0, bukan error.
+func (r *transactionRepository) DeleteByWallet(ctx context.Context, walletID uuid.UUID) (int64, error) {
+	query := `DELETE FROM transactions WHERE wallet_id = $1`
+
+	result, err := r.pool.Exec(ctx, query, walletID)
+	if err != nil {
+		return 0, convertError(err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// CountByCategory menghitung transaksi dengan category_id tersebut.
+func (r *transactionRepository) CountByCategory(ctx context.Context, categoryID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM transactions WHERE category_id = $1`
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, categoryID).Scan(&count); err != nil {
+		return 0, convertError(err)
+	}
+	return count, nil
+}
+
+// ReassignCategory memindahkan transaksi dari kategori from ke to.
+func (r *transactionRepository) ReassignCategory(ctx context.Context, from, to uuid.UUID) (int64, error) {
+	query := `UPDATE transactions SET category_id = $2 WHERE category_id = $1`
+
+	result, err := r.pool.Exec(ctx, query, from, to)
+	if err != nil {
+		return 0, convertError(err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
 // GetSummary menghitung total income dan expense.
 func (r *transactionRepository) GetSummary(
 	ctx context.Context,
 	filter repository.TransactionFilter,
 ) (*repository.TransactionSummary, error) {
+	amount := summaryAmount("", filter)
 	query := `
 		SELECT 
-			COALESCE(SUM(CASE WHEN type = 'income' THEN amount ELSE 0 END), 0) as total_income,
-			COALESCE(SUM(CASE WHEN type = 'expense' THEN amount ELSE 0 END), 0) as total_expense,
+			COALESCE(SUM(CASE WHEN type = 'income' THEN ` + amount + ` ELSE 0 END), 0) as total_income,
+			COALESCE(SUM(CASE WHEN type = 'expense' THEN ` + amount + ` ELSE 0 END), 0) as total_expense,
 			COUNT(*) as count
 		FROM transactions
 	`
@@ -232,10 +460,10 @@ func (r *transactionRepository) GetSummary(
 	var args []interface{}
 	argIndex := 1
 
-	if filter.WalletID != nil {
-		conditions = append(conditions, fmt.Sprintf("wallet_id = $%d", argIndex))
-		args = append(args, *filter.WalletID)
-		argIndex++
+	if walletIDs := filter.AllWalletIDs(); len(walletIDs) > 0 {
+		var condition string
+		condition, args, argIndex = inCondition("wallet_id", walletIDs, args, argIndex)
+		conditions = append(conditions, condition)
 	}
 
 	if filter.StartDate != nil {
@@ -250,6 +478,12 @@ func (r *transactionRepository) GetSummary(
 		argIndex++
 	}
 
+	if filter.ExcludeAdjustments {
+		conditions = append(conditions, fmt.Sprintf("NOT ($%d = ANY(COALESCE(tags, '{}')))", argIndex))
+		args = append(args, models.TagAdjustment)
+		argIndex++
+	}
+
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
@@ -270,6 +504,16 @@ func (r *transactionRepository) GetSummary(
 	return summary, nil
 }
 
+// summaryAmount adalah ekspresi amount untuk SUM: dikonversi dengan
+// rate_to_base jika filter.ConvertToBase. prefix adalah alias tabel
+// ("t.") atau "".
+func summaryAmount(prefix string, filter repository.TransactionFilter) string {
+	if filter.ConvertToBase {
+		return fmt.Sprintf("ROUND(%[1]samount * COALESCE(%[1]srate_to_base, 1), 2)", prefix)
+	}
+	return prefix + "amount"
+}
+
 // GetByCategory menghitung total per kategori.
 func (r *transactionRepository) GetByCategory(
 	ctx context.Context,
@@ -279,7 +523,7 @@ func (r *transactionRepository) GetByCategory(
 		SELECT 
 			c.id,
 			c.name,
-			COALESCE(SUM(t.amount), 0) as total,
+			COALESCE(SUM(` + summaryAmount("t.", filter) + `), 0) as total,
 			COUNT(t.id) as count
 		FROM categories c
 		LEFT JOIN transactions t ON t.category_id = c.id
@@ -308,6 +552,12 @@ func (r *transactionRepository) GetByCategory(
 		argIndex++
 	}
 
+	if filter.ExcludeAdjustments {
+		conditions = append(conditions, fmt.Sprintf("(NOT ($%d = ANY(COALESCE(t.tags, '{}'))) OR t.id IS NULL)", argIndex))
+		args = append(args, models.TagAdjustment)
+		argIndex++
+	}
+
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
@@ -343,3 +593,410 @@ func (r *transactionRepository) GetByCategory(
 
 	return summaries, rows.Err()
 }
+
+// GetLargest mengambil transaksi dengan amount terbesar.
+func (r *transactionRepository) GetLargest(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+	limit int,
+) ([]*models.Transaction, error) {
+	query := `
+		SELECT id, wallet_id, category_id, type, amount, description, tags,
+		       transaction_date, attachment, COALESCE(original_currency, ''), COALESCE(idempotency_key, ''), COALESCE(external_reference, ''), rate_to_base, original_amount, note,
+		       created_at, updated_at
+		FROM transactions
+	`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if walletIDs := filter.AllWalletIDs(); len(walletIDs) > 0 {
+		var condition string
+		condition, args, argIndex = inCondition("wallet_id", walletIDs, args, argIndex)
+		conditions = append(conditions, condition)
+	}
+
+	if filter.CategoryID != nil {
+		conditions = append(conditions, fmt.Sprintf("category_id = $%d", argIndex))
+		args = append(args, *filter.CategoryID)
+		argIndex++
+	}
+
+	if filter.Type != nil {
+		conditions = append(conditions, fmt.Sprintf("type = $%d", argIndex))
+		args = append(args, string(*filter.Type))
+		argIndex++
+	}
+
+	if filter.StartDate != nil {
+		conditions = append(conditions, fmt.Sprintf("transaction_date >= $%d", argIndex))
+		args = append(args, *filter.StartDate)
+		argIndex++
+	}
+
+	if filter.EndDate != nil {
+		conditions = append(conditions, fmt.Sprintf("transaction_date <= $%d", argIndex))
+		args = append(args, *filter.EndDate)
+		argIndex++
+	}
+
+	if filter.ExcludeAdjustments {
+		conditions = append(conditions, fmt.Sprintf("NOT ($%d = ANY(COALESCE(tags, '{}')))", argIndex))
+		args = append(args, models.TagAdjustment)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY amount DESC, transaction_date DESC"
+	query += fmt.Sprintf(" LIMIT $%d", argIndex)
+	args = append(args, limit)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+
+	var transactions []*models.Transaction
+	for rows.Next() {
+		tx := &models.Transaction{}
+		err := rows.Scan(
+			&tx.ID,
+			&tx.WalletID,
+			&tx.CategoryID,
+			&tx.Type,
+			&tx.Amount,
+			&tx.Description,
+			&tx.Tags,
+			&tx.TransactionDate,
+			&tx.Attachment,
+			&tx.OriginalCurrency,
+			&tx.IdempotencyKey,
+			&tx.ExternalReference,
+			&tx.RateToBase,
+			&tx.OriginalAmount,
+			&tx.Note,
+			&tx.CreatedAt,
+			&tx.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, tx)
+	}
+
+	return transactions, rows.Err()
+}
+
+// GetAmountStats menghitung rata-rata dan maksimum amount per kategori.
+func (r *transactionRepository) GetAmountStats(
+	ctx context.Context,
+	categoryID uuid.UUID,
+	txType models.TransactionType,
+	since time.Time,
+) (*repository.AmountStats, error) {
+	query := `
+		SELECT 
+			COALESCE(AVG(amount), 0) as average,
+			COALESCE(MAX(amount), 0) as max,
+			COUNT(*) as count
+		FROM transactions
+		WHERE category_id = $1 AND type = $2 AND transaction_date >= $3
+			AND NOT ($4 = ANY(COALESCE(tags, '{}')))
+	`
+
+	stats := &repository.AmountStats{}
+	err := r.pool.QueryRow(ctx, query, categoryID, txType, since, models.TagAdjustment).Scan(
+		&stats.Average,
+		&stats.Max,
+		&stats.Count,
+	)
+
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return stats, nil
+}
+
+// GetMonthlyCategoryTotals menghitung total per kategori per bulan.
+func (r *transactionRepository) GetMonthlyCategoryTotals(
+	ctx context.Context,
+	start, end time.Time,
+) ([]*repository.MonthlyCategoryTotal, error) {
+	query := `
+		SELECT
+			category_id,
+			date_trunc('month', transaction_date)::date as month,
+			SUM(amount) as total,
+			COUNT(*) as count
+		FROM transactions
+		WHERE category_id IS NOT NULL
+		  AND transaction_date >= $1 AND transaction_date < $2
+		  AND NOT ($3 = ANY(COALESCE(tags, '{}')))
+		GROUP BY category_id, month
+		ORDER BY category_id, month
+	`
+
+	rows, err := r.pool.Query(ctx, query, start, end, models.TagAdjustment)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+
+	var totals []*repository.MonthlyCategoryTotal
+	for rows.Next() {
+		t := &repository.MonthlyCategoryTotal{}
+		if err := rows.Scan(&t.CategoryID, &t.Month, &t.Total, &t.Count); err != nil {
+			return nil, convertError(err)
+		}
+		totals = append(totals, t)
+	}
+
+	return totals, rows.Err()
+}
+
+// GetWeekdayHourTotals menghitung total per hari dalam minggu dan jam.
+func (r *transactionRepository) GetWeekdayHourTotals(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+) ([]*repository.WeekdayHourTotal, error) {
+	// transaction_date bertipe DATE (EXTRACT HOUR selalu 0), jadi jam
+	// diambil dari created_at jika dicatat di hari yang sama
+	query := fmt.Sprintf(`
+		SELECT
+			EXTRACT(DOW FROM transaction_date)::int as weekday,
+			CASE WHEN created_at::date = transaction_date
+				THEN EXTRACT(HOUR FROM created_at)::int
+				ELSE %d
+			END as hour,
+			SUM(amount) as total,
+			COUNT(*) as count
+		FROM transactions
+	`, repository.UnknownHour)
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if walletIDs := filter.AllWalletIDs(); len(walletIDs) > 0 {
+		var condition string
+		condition, args, argIndex = inCondition("wallet_id", walletIDs, args, argIndex)
+		conditions = append(conditions, condition)
+	}
+
+	if filter.CategoryID != nil {
+		conditions = append(conditions, fmt.Sprintf("category_id = $%d", argIndex))
+		args = append(args, *filter.CategoryID)
+		argIndex++
+	}
+
+	if filter.Type != nil {
+		conditions = append(conditions, fmt.Sprintf("type = $%d", argIndex))
+		args = append(args, string(*filter.Type))
+		argIndex++
+	}
+
+	if filter.StartDate != nil {
+		conditions = append(conditions, fmt.Sprintf("transaction_date >= $%d", argIndex))
+		args = append(args, *filter.StartDate)
+		argIndex++
+	}
+
+	if filter.EndDate != nil {
+		conditions = append(conditions, fmt.Sprintf("transaction_date <= $%d", argIndex))
+		args = append(args, *filter.EndDate)
+		argIndex++
+	}
+
+	if filter.ExcludeAdjustments {
+		conditions = append(conditions, fmt.Sprintf("NOT ($%d = ANY(COALESCE(tags, '{}')))", argIndex))
+		args = append(args, models.TagAdjustment)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " GROUP BY weekday, hour ORDER BY weekday, hour"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+
+	var totals []*repository.WeekdayHourTotal
+	for rows.Next() {
+		t := &repository.WeekdayHourTotal{}
+		if err := rows.Scan(&t.Weekday, &t.Hour, &t.Total, &t.Count); err != nil {
+			return nil, convertError(err)
+		}
+		totals = append(totals, t)
+	}
+
+	return totals, rows.Err()
+}
+
+// GetCashFlowByWalletType menjumlahkan income dan expense per tipe wallet.
+func (r *transactionRepository) GetCashFlowByWalletType(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+) ([]*repository.WalletTypeCashFlow, error) {
+	query := `
+		SELECT
+			w.type,
+			COALESCE(SUM(CASE WHEN t.type = 'income' THEN t.amount ELSE 0 END), 0) as inflow,
+			COALESCE(SUM(CASE WHEN t.type = 'expense' THEN t.amount ELSE 0 END), 0) as outflow,
+			COUNT(*) as count
+		FROM transactions t
+		JOIN wallets w ON w.id = t.wallet_id
+	`
+
+	conditions, args := transactionConditions(filter, "t.")
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " GROUP BY w.type ORDER BY w.type"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+
+	var flows []*repository.WalletTypeCashFlow
+	for rows.Next() {
+		f := &repository.WalletTypeCashFlow{}
+		var walletType string
+		if err := rows.Scan(&walletType, &f.Inflow, &f.Outflow, &f.Count); err != nil {
+			return nil, err
+		}
+		f.WalletType = models.WalletType(walletType)
+		flows = append(flows, f)
+	}
+
+	return flows, rows.Err()
+}
+
+// GetDescriptionTotals menjumlahkan transaksi per lower(trim(description)).
+func (r *transactionRepository) GetDescriptionTotals(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+) ([]*repository.DescriptionTotal, error) {
+	query := `
+		SELECT
+			LOWER(TRIM(description)) as merchant,
+			SUM(amount) as total,
+			COUNT(*) as count
+		FROM transactions
+	`
+
+	conditions, args := transactionConditions(filter, "")
+	conditions = append(conditions, "TRIM(COALESCE(description, '')) <> ''")
+	query += " WHERE " + strings.Join(conditions, " AND ")
+	query += " GROUP BY merchant ORDER BY total DESC, merchant"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+
+	var totals []*repository.DescriptionTotal
+	for rows.Next() {
+		t := &repository.DescriptionTotal{}
+		if err := rows.Scan(&t.Description, &t.Total, &t.Count); err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+
+	return totals, rows.Err()
+}
+
+// transactionConditions membuat kondisi WHERE untuk filter. prefix adalah
+// alias tabel transactions beserta titiknya ("t.") untuk query dengan JOIN.
+func transactionConditions(filter repository.TransactionFilter, prefix string) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if walletIDs := filter.AllWalletIDs(); len(walletIDs) > 0 {
+		var condition string
+		condition, args, argIndex = inCondition(prefix+"wallet_id", walletIDs, args, argIndex)
+		conditions = append(conditions, condition)
+	}
+
+	if filter.CategoryID != nil {
+		conditions = append(conditions, fmt.Sprintf("%scategory_id = $%d", prefix, argIndex))
+		args = append(args, *filter.CategoryID)
+		argIndex++
+	}
+
+	if filter.Type != nil {
+		conditions = append(conditions, fmt.Sprintf("%stype = $%d", prefix, argIndex))
+		args = append(args, string(*filter.Type))
+		argIndex++
+	}
+
+	if filter.StartDate != nil {
+		conditions = append(conditions, fmt.Sprintf("%stransaction_date >= $%d", prefix, argIndex))
+		args = append(args, *filter.StartDate)
+		argIndex++
+	}
+
+	if filter.EndDate != nil {
+		conditions = append(conditions, fmt.Sprintf("%stransaction_date <= $%d", prefix, argIndex))
+		args = append(args, *filter.EndDate)
+		argIndex++
+	}
+
+	if filter.Search != nil && *filter.Search != "" {
+		condition := fmt.Sprintf("%sdescription ILIKE $%d", prefix, argIndex)
+		if filter.SearchNotes {
+			condition = fmt.Sprintf("(%[1]sdescription ILIKE $%[2]d OR %[1]snote ILIKE $%[2]d)", prefix, argIndex)
+		}
+		conditions = append(conditions, condition)
+		args = append(args, "%"+*filter.Search+"%")
+		argIndex++
+	}
+
+	if len(filter.Tags) > 0 {
+		conditions = append(conditions, fmt.Sprintf("%stags && $%d", prefix, argIndex))
+		args = append(args, filter.Tags)
+		argIndex++
+	}
+
+	if filter.ExcludeAdjustments {
+		conditions = append(conditions, fmt.Sprintf("NOT ($%d = ANY(COALESCE(%stags, '{}')))", argIndex, prefix))
+		args = append(args, models.TagAdjustment)
+	}
+
+	return conditions, args
+}
+
+// inCondition membuat kondisi "column IN ($n, $n+1, ...)" untuk ids,
+// menambahkan ids ke args, dan mengembalikan argIndex berikutnya.
+// Satu id menjadi "column = $n".
+//
+//	cond, args, argIndex = inCondition("wallet_id", ids, args, argIndex)
+//	// wallet_id IN ($1, $2)
+func inCondition(column string, ids []uuid.UUID, args []interface{}, argIndex int) (string, []interface{}, int) {
+	if len(ids) == 1 {
+		return fmt.Sprintf("%s = $%d", column, argIndex), append(args, ids[0]), argIndex + 1
+	}
+
+	placeholders := make([]string, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", argIndex)
+		args = append(args, id)
+		argIndex++
+	}
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", ")), args, argIndex
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestInCondition(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		ids      []uuid.UUID
+		args     []interface{}
+		argIndex int
+		want     string
+		wantNext int
+	}{
+		{"single id", []uuid.UUID{a}, nil, 1, "wallet_id = $1", 2},
+		{"several ids", []uuid.UUID{a, b, c}, nil, 1, "wallet_id IN ($1, $2, $3)", 4},
+		{"after other conditions", []uuid.UUID{a, b}, []interface{}{"expense", 42}, 3, "wallet_id IN ($3, $4)", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args, next := inCondition("wallet_id", tt.ids, tt.args, tt.argIndex)
+			if got != tt.want {
+				t.Errorf("condition = %q, want %q", got, tt.want)
+			}
+			if next != tt.wantNext {
+				t.Errorf("next argIndex = %d, want %d", next, tt.wantNext)
+			}
+			if len(args) != len(tt.args)+len(tt.ids) {
+				t.Fatalf("len(args) = %d, want %d", len(args), len(tt.args)+len(tt.ids))
+			}
+			for i, id := range tt.ids {
+				if args[len(tt.args)+i] != id {
+					t.Errorf("args[%d] = %v, want %v", len(tt.args)+i, args[len(tt.args)+i], id)
+				}
+			}
+		})
+	}
+}
+
+func TestTransactionConditions_Tags(t *testing.T) {
+	filter := repository.TransactionFilter{Tags: []string{models.TagTransfer}, ExcludeAdjustments: true}
+
+	conditions, args := transactionConditions(filter, "t.")
+	want := []string{"t.tags && $1", "NOT ($2 = ANY(COALESCE(t.tags, '{}')))"}
+	if len(conditions) != len(want) {
+		t.Fatalf("conditions = %q, want %q", conditions, want)
+	}
+	for i := range want {
+		if conditions[i] != want[i] {
+			t.Errorf("conditions[%d] = %q, want %q", i, conditions[i], want[i])
+		}
+	}
+	if tags, ok := args[0].([]string); !ok || len(tags) != 1 || tags[0] != models.TagTransfer {
+		t.Errorf("args[0] = %v, want the tags slice", args[0])
+	}
+}
+
+func TestTransactionInsertRow(t *testing.T) {
+	first := transactionInsertRow(1)
+	if !strings.HasPrefix(first, "($1, $2,") || !strings.HasSuffix(first, "COALESCE($16, NOW()))") {
+		t.Errorf("row 1 = %q, want $1..$16", first)
+	}
+
+	second := transactionInsertRow(transactionInsertColumns + 1)
+	if !strings.HasPrefix(second, "($17, $18,") || !strings.Contains(second, "NULLIF($26, '')") || !strings.HasSuffix(second, "COALESCE($32, NOW()))") {
+		t.Errorf("row 2 = %q, want $17..$32", second)
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
@@ -14,21 +14,25 @@ import (
 
 // transferRepository adalah implementasi PostgreSQL untuk TransferRepository.
 type transferRepository struct {
-	pool *pgxpool.Pool
+	pool Querier
 }
 
 // NewTransferRepository membuat TransferRepository baru.
-func NewTransferRepository(pool *pgxpool.Pool) repository.TransferRepository {
-	return &transferRepository{pool: pool}
+func NewTransferRepository(pool Querier) repository.TransferRepository {
+	return &transferRepository{pool: withTx(pool)}
 }
 
 // Create menyimpan transfer baru.
 func (r *transferRepository) Create(ctx context.Context, transfer *models.Transfer) error {
 	query := `
-		INSERT INTO transfers (id, from_wallet_id, to_wallet_id, amount, fee, note)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO transfers (id, from_wallet_id, to_wallet_id, amount, fee, note, exchange_rate, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
+	if transfer.CreatedAt.IsZero() {
+		transfer.CreatedAt = time.Now()
+	}
+
 	_, err := r.pool.Exec(ctx, query,
 		transfer.ID,
 		transfer.FromWalletID,
@@ -36,6 +40,8 @@ func (r *transferRepository) Create(ctx context.Context, transfer *models.Transf
 		transfer.Amount,
 		transfer.Fee,
 		transfer.Note,
+		transfer.ExchangeRate,
+		transfer.CreatedAt,
 	)
 
 	return convertError(err)
@@ -44,7 +50,7 @@ func (r *transferRepository) Create(ctx context.Context, transfer *models.Transf
 // GetByID mengambil transfer berdasarkan ID.
 func (r *transferRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transfer, error) {
 	query := `
-		SELECT id, from_wallet_id, to_wallet_id, amount, fee, note, created_at
+		SELECT id, from_wallet_id, to_wallet_id, amount, fee, note, exchange_rate, created_at
 		FROM transfers
 		WHERE id = $1
 	`
@@ -57,6 +63,7 @@ func (r *transferRepository) GetByID(ctx context.Context, id uuid.UUID) (*models
 		&t.Amount,
 		&t.Fee,
 		&t.Note,
+		&t.ExchangeRate,
 		&t.CreatedAt,
 	)
 
@@ -76,7 +83,7 @@ func (r *transferRepository) List(
 	params.Validate()
 
 	query := `
-		SELECT id, from_wallet_id, to_wallet_id, amount, fee, note, created_at
+		SELECT id, from_wallet_id, to_wallet_id, amount, fee, note, exchange_rate, created_at
 		FROM transfers
 	`
 
@@ -139,6 +146,7 @@ func (r *transferRepository) List(
 			&t.Amount,
 			&t.Fee,
 			&t.Note,
+			&t.ExchangeRate,
 			&t.CreatedAt,
 		)
 		if err != nil {
@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
@@ -15,7 +14,7 @@ import (
 
 // walletRepository adalah implementasi PostgreSQL untuk WalletRepository.
 type walletRepository struct {
-	pool *pgxpool.Pool
+	pool Querier
 }
 
 // NewWalletRepository membuat WalletRepository baru.
@@ -27,20 +26,22 @@ type walletRepository struct {
 //
 //	wallet := models.NewWallet("Cash", models.WalletTypeCash)
 //	err := walletRepo.Create(ctx, wallet)
-func NewWalletRepository(pool *pgxpool.Pool) repository.WalletRepository {
-	return &walletRepository{pool: pool}
+func NewWalletRepository(pool Querier) repository.WalletRepository {
+	return &walletRepository{pool: withTx(pool)}
 }
 
 // Create menyimpan wallet baru ke database.
 //
 // SQL yang dieksekusi:
 //
-//	INSERT INTO wallets (id, name, type, balance, currency, color, icon, is_active, created_at, updated_at)
-//	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+//	INSERT INTO wallets (id, name, type, balance, currency, color, icon, is_active, opening_date, low_balance_threshold, created_at)
+//	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE($11, NOW()))
+//
+// CreatedAt yang sudah diisi (restore backup) dipertahankan.
 func (r *walletRepository) Create(ctx context.Context, wallet *models.Wallet) error {
 	query := `
-		INSERT INTO wallets (id, name, type, balance, currency, color, icon, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO wallets (id, name, type, balance, currency, color, icon, is_active, opening_date, low_balance_threshold, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE($11, NOW()))
 	`
 
 	_, err := r.pool.Exec(ctx, query,
@@ -52,9 +53,17 @@ func (r *walletRepository) Create(ctx context.Context, wallet *models.Wallet) er
 		wallet.Color,
 		wallet.Icon,
 		wallet.IsActive,
+		wallet.OpeningDate,
+		wallet.LowBalanceThreshold,
+		createdAt(wallet.CreatedAt),
 	)
+	if err != nil {
+		return convertError(err)
+	}
 
-	return convertError(err)
+	// version diisi DEFAULT 1 oleh database
+	wallet.Version = 1
+	return nil
 }
 
 // GetByID mengambil wallet berdasarkan ID.
@@ -62,7 +71,8 @@ func (r *walletRepository) Create(ctx context.Context, wallet *models.Wallet) er
 // Return repository.ErrNotFound jika tidak ditemukan.
 func (r *walletRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Wallet, error) {
 	query := `
-		SELECT id, name, type, balance, currency, color, icon, is_active, created_at, updated_at
+		SELECT id, name, type, balance, currency, color, icon, is_active, opening_date, low_balance_threshold, version,
+		       created_at, updated_at
 		FROM wallets
 		WHERE id = $1
 	`
@@ -77,6 +87,9 @@ func (r *walletRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.W
 		&wallet.Color,
 		&wallet.Icon,
 		&wallet.IsActive,
+		&wallet.OpeningDate,
+		&wallet.LowBalanceThreshold,
+		&wallet.Version,
 		&wallet.CreatedAt,
 		&wallet.UpdatedAt,
 	)
@@ -88,6 +101,18 @@ func (r *walletRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.W
 	return wallet, nil
 }
 
+// Exists mengecek apakah wallet dengan ID tersebut ada, tanpa mengambil row-nya.
+func (r *walletRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM wallets WHERE id = $1)`
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return false, convertError(err)
+	}
+
+	return exists, nil
+}
+
 // List mengambil wallets dengan filter.
 //
 // Filter bersifat optional. Jika nil, tidak difilter.
@@ -95,7 +120,8 @@ func (r *walletRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.W
 func (r *walletRepository) List(ctx context.Context, filter repository.WalletFilter) ([]*models.Wallet, error) {
 	// Build query dinamis dengan WHERE clauses
 	query := `
-		SELECT id, name, type, balance, currency, color, icon, is_active, created_at, updated_at
+		SELECT id, name, type, balance, currency, color, icon, is_active, opening_date, low_balance_threshold, version,
+		       created_at, updated_at
 		FROM wallets
 	`
 
@@ -127,7 +153,14 @@ func (r *walletRepository) List(ctx context.Context, filter repository.WalletFil
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " ORDER BY created_at DESC"
+	switch filter.SortBy {
+	case repository.WalletSortName:
+		query += " ORDER BY LOWER(name), created_at DESC"
+	case repository.WalletSortBalance:
+		query += " ORDER BY balance DESC, created_at DESC"
+	default:
+		query += " ORDER BY created_at DESC"
+	}
 
 	// Execute query
 	rows, err := r.pool.Query(ctx, query, args...)
@@ -149,6 +182,9 @@ func (r *walletRepository) List(ctx context.Context, filter repository.WalletFil
 			&wallet.Color,
 			&wallet.Icon,
 			&wallet.IsActive,
+			&wallet.OpeningDate,
+			&wallet.LowBalanceThreshold,
+			&wallet.Version,
 			&wallet.CreatedAt,
 			&wallet.UpdatedAt,
 		)
@@ -161,14 +197,16 @@ func (r *walletRepository) List(ctx context.Context, filter repository.WalletFil
 	return wallets, rows.Err()
 }
 
-// Update memperbarui wallet.
+// Update memperbarui wallet dengan optimistic locking: row hanya diubah
+// jika version-nya masih wallet.Version.
 //
 // PENTING: updated_at dihandle oleh trigger di database.
 func (r *walletRepository) Update(ctx context.Context, wallet *models.Wallet) error {
 	query := `
 		UPDATE wallets
-		SET name = $2, type = $3, balance = $4, currency = $5, color = $6, icon = $7, is_active = $8
-		WHERE id = $1
+		SET name = $2, type = $3, balance = $4, currency = $5, color = $6, icon = $7, is_active = $8, opening_date = $9,
+		    low_balance_threshold = $10, version = version + 1
+		WHERE id = $1 AND version = $11
 	`
 
 	result, err := r.pool.Exec(ctx, query,
@@ -180,17 +218,28 @@ func (r *walletRepository) Update(ctx context.Context, wallet *models.Wallet) er
 		wallet.Color,
 		wallet.Icon,
 		wallet.IsActive,
+		wallet.OpeningDate,
+		wallet.LowBalanceThreshold,
+		wallet.Version,
 	)
 
 	if err != nil {
 		return convertError(err)
 	}
 
-	// Check if wallet was found
+	// Tidak ada row berubah: wallet tidak ada, atau version sudah naik
 	if result.RowsAffected() == 0 {
+		exists, err := r.Exists(ctx, wallet.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return repository.ErrConflict
+		}
 		return repository.ErrNotFound
 	}
 
+	wallet.Version++
 	return nil
 }
 
@@ -201,7 +250,7 @@ func (r *walletRepository) Update(ctx context.Context, wallet *models.Wallet) er
 // 2. Data bisa di-recover jika diperlukan
 // 3. Untuk reporting historical data
 func (r *walletRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE wallets SET is_active = false WHERE id = $1 AND is_active = true`
+	query := `UPDATE wallets SET is_active = false, version = version + 1 WHERE id = $1 AND is_active = true`
 
 	result, err := r.pool.Exec(ctx, query, id)
 	if err != nil {
@@ -218,11 +267,23 @@ func (r *walletRepository) Delete(ctx context.Context, id uuid.UUID) error {
 // UpdateBalance mengupdate saldo wallet secara atomic.
 //
 // Operasi ini menggunakan query langsung tanpa read-modify-write
-// untuk menghindari race condition pada concurrent access.
-func (r *walletRepository) UpdateBalance(ctx context.Context, id uuid.UUID, newBalance decimal.Decimal) error {
-	query := `UPDATE wallets SET balance = $2 WHERE id = $1`
+// untuk menghindari race condition pada concurrent access. Saldo lama
+// dibaca dengan FOR UPDATE dan row balance_audit ditulis di statement
+// yang sama, jadi audit selalu ikut commit/rollback bersama saldonya.
+func (r *walletRepository) UpdateBalance(ctx context.Context, id uuid.UUID, newBalance decimal.Decimal, change models.BalanceChange) error {
+	query := `
+		WITH prev AS (
+			SELECT balance FROM wallets WHERE id = $1 FOR UPDATE
+		), upd AS (
+			UPDATE wallets SET balance = $2, version = version + 1 WHERE id = $1
+			RETURNING id
+		)
+		INSERT INTO balance_audit (wallet_id, old_balance, new_balance, reason, reference_id)
+		SELECT upd.id, prev.balance, $2, $3, $4
+		FROM prev, upd
+	`
 
-	result, err := r.pool.Exec(ctx, query, id, newBalance)
+	result, err := r.pool.Exec(ctx, query, id, newBalance, change.Reason, change.ReferenceID)
 	if err != nil {
 		return convertError(err)
 	}
@@ -234,6 +295,76 @@ func (r *walletRepository) UpdateBalance(ctx context.Context, id uuid.UUID, newB
 	return nil
 }
 
+// AdjustBalance menambahkan delta ke saldo wallet.
+//
+// balance = balance + delta dihitung oleh UPDATE yang memegang row lock,
+// jadi perubahan concurrent di antara baca dan tulis tidak hilang. Row
+// balance_audit ditulis di statement yang sama.
+func (r *walletRepository) AdjustBalance(ctx context.Context, id uuid.UUID, delta decimal.Decimal, change models.BalanceChange) (decimal.Decimal, error) {
+	query := `
+		WITH upd AS (
+			UPDATE wallets SET balance = balance + $2, version = version + 1 WHERE id = $1
+			RETURNING id, balance
+		), audit AS (
+			INSERT INTO balance_audit (wallet_id, old_balance, new_balance, reason, reference_id)
+			SELECT id, balance - $2, balance, $3, $4
+			FROM upd
+		)
+		SELECT balance FROM upd
+	`
+
+	var balance decimal.Decimal
+	err := r.pool.QueryRow(ctx, query, id, delta, change.Reason, change.ReferenceID).Scan(&balance)
+	if err != nil {
+		return decimal.Zero, convertError(err)
+	}
+
+	return balance, nil
+}
+
+// ListBalanceAudit mengambil riwayat perubahan saldo wallet, terbaru dulu.
+func (r *walletRepository) ListBalanceAudit(
+	ctx context.Context,
+	walletID uuid.UUID,
+	params repository.ListParams,
+) ([]*models.BalanceAudit, error) {
+	params.Validate()
+
+	query := `
+		SELECT id, wallet_id, old_balance, new_balance, reason, reference_id, created_at
+		FROM balance_audit
+		WHERE wallet_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, walletID, params.Limit, params.Offset)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+
+	var entries []*models.BalanceAudit
+	for rows.Next() {
+		a := &models.BalanceAudit{}
+		err := rows.Scan(
+			&a.ID,
+			&a.WalletID,
+			&a.OldBalance,
+			&a.NewBalance,
+			&a.Reason,
+			&a.ReferenceID,
+			&a.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, a)
+	}
+
+	return entries, rows.Err()
+}
+
 // GetTotalBalance menghitung total saldo semua wallet aktif.
 //
 // Query menggunakan COALESCE untuk handle case jika tidak ada wallet.
@@ -248,3 +379,85 @@ func (r *walletRepository) GetTotalBalance(ctx context.Context) (decimal.Decimal
 
 	return total, nil
 }
+
+// ListWithLastTransaction mengambil wallets beserta tanggal transaksi terakhir.
+//
+// Tanggal diambil dari subquery MAX(transaction_date) per wallet,
+// di-LEFT JOIN supaya wallet tanpa transaksi tetap muncul.
+func (r *walletRepository) ListWithLastTransaction(
+	ctx context.Context,
+	filter repository.WalletFilter,
+) ([]*repository.WalletActivity, error) {
+	query := `
+		SELECT w.id, w.name, w.type, w.balance, w.currency, w.color, w.icon, w.is_active, w.opening_date,
+		       w.low_balance_threshold, w.version, w.created_at, w.updated_at, t.last_transaction_date
+		FROM wallets w
+		LEFT JOIN (
+			SELECT wallet_id, MAX(transaction_date) as last_transaction_date
+			FROM transactions
+			GROUP BY wallet_id
+		) t ON t.wallet_id = w.id
+	`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.IsActive != nil {
+		conditions = append(conditions, fmt.Sprintf("w.is_active = $%d", argIndex))
+		args = append(args, *filter.IsActive)
+		argIndex++
+	}
+
+	if filter.Type != nil {
+		conditions = append(conditions, fmt.Sprintf("w.type = $%d", argIndex))
+		args = append(args, string(*filter.Type))
+		argIndex++
+	}
+
+	if filter.Currency != nil {
+		conditions = append(conditions, fmt.Sprintf("w.currency = $%d", argIndex))
+		args = append(args, *filter.Currency)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY t.last_transaction_date DESC NULLS LAST, w.created_at DESC"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+
+	var result []*repository.WalletActivity
+	for rows.Next() {
+		wallet := &models.Wallet{}
+		activity := &repository.WalletActivity{Wallet: wallet}
+		err := rows.Scan(
+			&wallet.ID,
+			&wallet.Name,
+			&wallet.Type,
+			&wallet.Balance,
+			&wallet.Currency,
+			&wallet.Color,
+			&wallet.Icon,
+			&wallet.IsActive,
+			&wallet.OpeningDate,
+			&wallet.LowBalanceThreshold,
+			&wallet.Version,
+			&wallet.CreatedAt,
+			&wallet.UpdatedAt,
+			&activity.LastTransactionDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, activity)
+	}
+
+	return result, rows.Err()
+}
@@ -16,13 +16,10 @@ type RecurringRepository interface {
 	// GetByID mengambil recurring berdasarkan ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*models.RecurringTransaction, error)
 
-	// List mengambil semua recurring transactions dengan filter.
+	// List mengambil semua recurring transactions dengan filter,
+	// diurutkan next_due ASC.
 	List(ctx context.Context, filter RecurringFilter) ([]*models.RecurringTransaction, error)
 
-	// GetDue mengambil recurring yang sudah jatuh tempo (next_due <= today).
-	// Digunakan oleh scheduler untuk generate transactions.
-	GetDue(ctx context.Context) ([]*models.RecurringTransaction, error)
-
 	// Update memperbarui recurring.
 	Update(ctx context.Context, recurring *models.RecurringTransaction) error
 
@@ -34,6 +31,13 @@ type RecurringRepository interface {
 }
 
 // RecurringFilter adalah filter untuk query recurring transactions.
+//
+// Contoh:
+//
+//	// Recurring aktif yang jatuh tempo dalam 7 hari ke depan
+//	now := time.Now()
+//	week := now.AddDate(0, 0, 7)
+//	filter := RecurringFilter{IsActive: ptr(true), NextDueAfter: &now, NextDueBefore: &week}
 type RecurringFilter struct {
 	// WalletID filter berdasarkan wallet.
 	WalletID *uuid.UUID
@@ -46,4 +50,11 @@ type RecurringFilter struct {
 
 	// Frequency filter berdasarkan frekuensi.
 	Frequency *models.RecurringFrequency
+
+	// NextDueBefore filter recurring dengan next_due <= tanggal ini.
+	// Dengan waktu sekarang, hasilnya recurring yang sudah jatuh tempo.
+	NextDueBefore *time.Time
+
+	// NextDueAfter filter recurring dengan next_due >= tanggal ini.
+	NextDueAfter *time.Time
 }
@@ -18,7 +18,7 @@
 //	}
 //
 //	// Di production, inject PostgreSQL implementation
-//	walletRepo := postgres.NewWalletRepository(db.Pool)
+//	walletRepo := postgres.NewWalletRepository(db)
 //	service := &WalletService{repo: walletRepo}
 //
 //	// Di test, inject mock
@@ -29,6 +29,8 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 )
 
 // Common errors yang bisa terjadi di semua repositories.
@@ -46,23 +48,52 @@ var (
 
 	// ErrForeignKeyViolation dikembalikan ketika foreign key tidak valid.
 	ErrForeignKeyViolation = errors.New("foreign key violation")
+
+	// ErrConflict dikembalikan Update jika version row sudah berubah sejak
+	// dibaca (diubah proses lain). Baca ulang lalu ulangi perubahan.
+	ErrConflict = errors.New("record was modified concurrently")
 )
 
+// ErrQueryTimeout adalah sentinel untuk QueryTimeoutError, dipakai dengan
+// errors.Is.
+var ErrQueryTimeout = errors.New("database query timed out")
+
+// QueryTimeoutError dikembalikan jika query melebihi batas waktu per query
+// (database.query_timeout). Berbeda dengan context yang dibatalkan caller
+// (Ctrl+C, refresh dashboard) yang tetap mengembalikan context.Canceled.
+//
+//	if errors.Is(err, repository.ErrQueryTimeout) {
+//	    // Database lambat atau koneksi menggantung
+//	}
+type QueryTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *QueryTimeoutError) Error() string {
+	return fmt.Sprintf("database query timed out after %s", e.Timeout)
+}
+
+// Is membuat errors.Is(err, ErrQueryTimeout) dan
+// errors.Is(err, context.DeadlineExceeded) bernilai true.
+func (e *QueryTimeoutError) Is(target error) bool {
+	return target == ErrQueryTimeout || target == context.DeadlineExceeded
+}
+
 // Querier adalah interface untuk database operations.
 // Ini memungkinkan repository methods bekerja dengan:
-// - *pgxpool.Pool (untuk operasi normal)
+// - *database.PostgresDB (untuk operasi normal)
 // - pgx.Tx (untuk operasi dalam transaction)
 //
 // Pattern ini penting untuk atomic operations:
 //
-//	tx, err := db.Pool.Begin(ctx)
+//	tx, err := db.Begin(ctx)
 //	if err != nil {
 //	    return err
 //	}
 //	defer tx.Rollback(ctx)
 //
 //	// Semua operasi dalam transaction yang sama
-//	err = walletRepo.UpdateBalance(ctx, tx, walletID, newBalance)
+//	err = walletRepo.UpdateBalance(ctx, tx, walletID, newBalance, change)
 //	err = transferRepo.Create(ctx, tx, transfer)
 //
 //	return tx.Commit(ctx)
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/google/uuid"
+)
+
+// RuleRepository mendefinisikan operasi data access untuk
+// CategorizationRule.
+type RuleRepository interface {
+	// Create menyimpan rule baru.
+	Create(ctx context.Context, rule *models.CategorizationRule) error
+
+	// GetByID mengambil rule berdasarkan ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.CategorizationRule, error)
+
+	// List mengambil semua rule urut priority lalu created_at (urutan
+	// pengecekan).
+	List(ctx context.Context) ([]*models.CategorizationRule, error)
+
+	// Delete menghapus rule.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -16,27 +16,100 @@ import (
 type TransactionRepository interface {
 	// Create menyimpan transaction baru.
 	// TIDAK otomatis update wallet balance - harus dilakukan terpisah.
+	// Return ErrDuplicateKey jika IdempotencyKey sudah dipakai, atau
+	// ExternalReference sudah dipakai di wallet yang sama.
 	Create(ctx context.Context, tx *models.Transaction) error
 
+	// CreateBatch menyimpan banyak transaction sekaligus (multi-row
+	// insert), dengan aturan yang sama seperti Create. Jika satu gagal,
+	// tidak ada yang tersimpan.
+	CreateBatch(ctx context.Context, txs []*models.Transaction) error
+
 	// GetByID mengambil transaction berdasarkan ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error)
 
+	// GetByIdempotencyKey mengambil transaction dengan idempotency key
+	// tertentu. Return ErrNotFound jika belum ada.
+	GetByIdempotencyKey(ctx context.Context, key string) (*models.Transaction, error)
+
+	// ExistingExternalReferences mengembalikan refs yang sudah dipakai
+	// transaksi di wallet (lihat Transaction.ExternalReference).
+	ExistingExternalReferences(ctx context.Context, walletID uuid.UUID, refs []string) ([]string, error)
+
 	// List mengambil transactions dengan filter.
 	List(ctx context.Context, filter TransactionFilter, params ListParams) ([]*models.Transaction, error)
 
 	// Update memperbarui transaction.
 	Update(ctx context.Context, tx *models.Transaction) error
 
+	// UpdateCategoryAndTags hanya mengubah category_id dan tags. Saldo
+	// wallet tidak terpengaruh. Return ErrNotFound jika tidak ada.
+	UpdateCategoryAndTags(ctx context.Context, id uuid.UUID, categoryID *uuid.UUID, tags []string) error
+
 	// Delete menghapus transaction.
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// DeleteByWallet menghapus semua transaction milik wallet dan
+	// mengembalikan jumlah row yang dihapus (0 jika tidak ada, bukan error).
+	// TIDAK update wallet balance - hanya untuk dipanggil di dalam
+	// DB transaction hard delete wallet.
+	DeleteByWallet(ctx context.Context, walletID uuid.UUID) (int64, error)
+
+	// CountByCategory menghitung transaksi dengan category_id tersebut
+	// (sub-kategori tidak ikut dihitung).
+	CountByCategory(ctx context.Context, categoryID uuid.UUID) (int, error)
+
+	// ReassignCategory memindahkan semua transaksi dari kategori from ke
+	// kategori to dan mengembalikan jumlah row yang diubah. Saldo wallet
+	// tidak berubah karena kategori tidak mempengaruhi amount.
+	ReassignCategory(ctx context.Context, from, to uuid.UUID) (int64, error)
+
 	// GetSummary menghitung total income dan expense untuk periode tertentu.
 	// Berguna untuk dashboard dan reports.
+	//
+	// Hanya membaca tabel transactions: transfer antar wallet (tabel
+	// transfers) memindahkan uang, bukan income/expense, jadi tidak boleh
+	// ikut dihitung di sini.
 	GetSummary(ctx context.Context, filter TransactionFilter) (*TransactionSummary, error)
 
 	// GetByCategory menghitung total per kategori.
 	// Berguna untuk pie chart breakdown.
 	GetByCategory(ctx context.Context, filter TransactionFilter) ([]*CategorySummary, error)
+
+	// GetLargest mengambil maksimal limit transaksi dengan amount terbesar
+	// (amount DESC, lalu tanggal terbaru). Berguna untuk "top expenses".
+	GetLargest(ctx context.Context, filter TransactionFilter, limit int) ([]*models.Transaction, error)
+
+	// GetAmountStats menghitung rata-rata dan maksimum amount transaksi
+	// untuk kategori + tipe tertentu sejak tanggal since.
+	// Berguna untuk deteksi transaksi yang nilainya tidak wajar.
+	// Transaksi koreksi saldo (models.TagAdjustment) tidak dihitung,
+	// sama seperti reports dengan ExcludeAdjustments.
+	GetAmountStats(ctx context.Context, categoryID uuid.UUID, txType models.TransactionType, since time.Time) (*AmountStats, error)
+
+	// GetMonthlyCategoryTotals menghitung total dan jumlah transaksi per
+	// kategori per bulan dalam window [start, end), dalam satu query grouped.
+	// Transaksi tanpa kategori dan adjustment tidak dihitung.
+	// Bulan tanpa transaksi tidak muncul di hasil.
+	GetMonthlyCategoryTotals(ctx context.Context, start, end time.Time) ([]*MonthlyCategoryTotal, error)
+
+	// GetWeekdayHourTotals menghitung total dan jumlah transaksi per hari
+	// dalam minggu (dari transaction_date) dan jam, dalam satu query grouped.
+	//
+	// transaction_date tidak menyimpan jam, jadi jam diambil dari
+	// created_at - hanya untuk transaksi yang dicatat di hari yang sama.
+	// Transaksi yang dicatat belakangan (backdate, import) punya Hour -1.
+	GetWeekdayHourTotals(ctx context.Context, filter TransactionFilter) ([]*WeekdayHourTotal, error)
+
+	// GetCashFlowByWalletType menjumlahkan income (Inflow) dan expense
+	// (Outflow) per tipe wallet, dengan join ke tabel wallets.
+	// Tipe wallet tanpa transaksi tidak muncul di hasil.
+	GetCashFlowByWalletType(ctx context.Context, filter TransactionFilter) ([]*WalletTypeCashFlow, error)
+
+	// GetDescriptionTotals menjumlahkan transaksi per description yang
+	// di-lowercase dan di-trim, urut total DESC. Description kosong tidak
+	// dihitung. Berguna untuk "top merchants".
+	GetDescriptionTotals(ctx context.Context, filter TransactionFilter) ([]*DescriptionTotal, error)
 }
 
 // TransactionFilter adalah filter untuk query transactions.
@@ -56,9 +129,18 @@ type TransactionFilter struct {
 	// WalletID filter berdasarkan wallet.
 	WalletID *uuid.UUID
 
+	// WalletIDs filter transaksi dari salah satu wallet ini, mis. total
+	// pengeluaran dua kartu kredit. Jika WalletID juga diset, wallet itu
+	// ikut dihitung (lihat AllWalletIDs).
+	WalletIDs []uuid.UUID
+
 	// CategoryID filter berdasarkan category.
 	CategoryID *uuid.UUID
 
+	// Uncategorized filter transaksi tanpa category (diabaikan jika
+	// CategoryID diset).
+	Uncategorized bool
+
 	// Type filter berdasarkan tipe (income/expense).
 	Type *models.TransactionType
 
@@ -71,8 +153,42 @@ type TransactionFilter struct {
 	// Search untuk full-text search di description.
 	Search *string
 
+	// SearchNotes membuat Search juga mencari di note, bukan hanya
+	// description.
+	SearchNotes bool
+
 	// Tags filter berdasarkan tags (ANY match).
 	Tags []string
+
+	// ExcludeAdjustments mengecualikan transaksi koreksi saldo
+	// (yang punya tag models.TagAdjustment). Dipakai oleh reports.
+	ExcludeAdjustments bool
+
+	// ConvertToBase menjumlahkan amount * rate_to_base (kurs saat
+	// transaksi dibuat) di GetSummary dan GetByCategory, untuk total
+	// lintas currency. Transaksi tanpa rate_to_base dihitung apa adanya.
+	ConvertToBase bool
+}
+
+// AllWalletIDs menggabungkan WalletID dan WalletIDs tanpa duplikat.
+// Return nil jika filter tidak membatasi wallet.
+func (f TransactionFilter) AllWalletIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	add := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if f.WalletID != nil {
+		add(*f.WalletID)
+	}
+	for _, id := range f.WalletIDs {
+		add(id)
+	}
+	return ids
 }
 
 // TransactionSummary adalah ringkasan transaksi.
@@ -107,3 +223,78 @@ type CategorySummary struct {
 	// Percentage adalah persentase dari total.
 	Percentage float64
 }
+
+// AmountStats adalah statistik amount transaksi dalam suatu window.
+type AmountStats struct {
+	// Average adalah rata-rata amount.
+	Average decimal.Decimal
+
+	// Max adalah amount terbesar.
+	Max decimal.Decimal
+
+	// Count adalah jumlah transaksi (sample size).
+	Count int
+}
+
+// MonthlyCategoryTotal adalah total transaksi satu kategori dalam satu bulan.
+type MonthlyCategoryTotal struct {
+	// CategoryID adalah ID kategori.
+	CategoryID uuid.UUID
+
+	// Month adalah tanggal 1 bulan tersebut.
+	Month time.Time
+
+	// Total adalah jumlah amount di bulan tersebut.
+	Total decimal.Decimal
+
+	// Count adalah jumlah transaksi di bulan tersebut.
+	Count int
+}
+
+// UnknownHour adalah WeekdayHourTotal.Hour untuk transaksi yang jamnya
+// tidak diketahui (dicatat setelah tanggal transaksinya).
+const UnknownHour = -1
+
+// WeekdayHourTotal adalah total transaksi di satu hari dalam minggu dan jam.
+type WeekdayHourTotal struct {
+	// Weekday adalah hari transaksi (Sunday = 0).
+	Weekday time.Weekday
+
+	// Hour adalah jam 0-23, atau UnknownHour.
+	Hour int
+
+	// Total adalah jumlah amount.
+	Total decimal.Decimal
+
+	// Count adalah jumlah transaksi.
+	Count int
+}
+
+// WalletTypeCashFlow adalah uang yang masuk dan keluar lewat satu tipe
+// wallet (mis. berapa yang lewat e-wallet vs bank vs cash).
+type WalletTypeCashFlow struct {
+	// WalletType adalah tipe wallet.
+	WalletType models.WalletType
+
+	// Inflow adalah total income.
+	Inflow decimal.Decimal
+
+	// Outflow adalah total expense.
+	Outflow decimal.Decimal
+
+	// Count adalah jumlah transaksi.
+	Count int
+}
+
+// DescriptionTotal adalah total transaksi dengan description yang sama
+// (lowercase, tanpa spasi di awal/akhir).
+type DescriptionTotal struct {
+	// Description adalah description yang sudah di-lowercase dan di-trim.
+	Description string
+
+	// Total adalah jumlah amount.
+	Total decimal.Decimal
+
+	// Count adalah jumlah transaksi.
+	Count int
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/google/uuid"
@@ -39,13 +40,19 @@ type WalletRepository interface {
 	// Return ErrNotFound jika wallet tidak ditemukan.
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Wallet, error)
 
+	// Exists mengecek apakah wallet dengan ID tersebut ada.
+	// Lebih ringan dari GetByID jika hanya butuh validasi referensi.
+	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+
 	// List mengambil semua wallets dengan filter opsional.
 	// Wallets diurutkan berdasarkan created_at DESC.
 	List(ctx context.Context, filter WalletFilter) ([]*models.Wallet, error)
 
 	// Update memperbarui wallet yang sudah ada.
 	// Hanya field yang berubah yang di-update.
-	// Return ErrNotFound jika wallet tidak ditemukan.
+	// Return ErrNotFound jika wallet tidak ditemukan, atau ErrConflict
+	// jika wallet.Version sudah tidak sama dengan yang tersimpan.
+	// Jika berhasil, wallet.Version dinaikkan.
 	Update(ctx context.Context, wallet *models.Wallet) error
 
 	// Delete menghapus wallet (soft delete - set is_active = false).
@@ -55,11 +62,29 @@ type WalletRepository interface {
 	// UpdateBalance mengupdate saldo wallet.
 	// Ini adalah atomic operation - aman untuk concurrent access.
 	// Digunakan saat ada transaksi income/expense.
-	UpdateBalance(ctx context.Context, id uuid.UUID, newBalance decimal.Decimal) error
+	// Version wallet ikut naik, dan perubahannya dicatat ke balance audit
+	// bersama change (alasan dan entity penyebab) dalam operasi yang sama.
+	UpdateBalance(ctx context.Context, id uuid.UUID, newBalance decimal.Decimal, change models.BalanceChange) error
+
+	// AdjustBalance menambahkan delta ke saldo wallet (negatif untuk
+	// mengurangi) relatif terhadap saldo di database, bukan saldo yang
+	// dibaca sebelumnya, jadi perubahan concurrent tidak tertimpa.
+	// Dicatat ke balance audit seperti UpdateBalance. Return saldo baru;
+	// caller yang memutuskan apakah saldo negatif ditolak.
+	AdjustBalance(ctx context.Context, id uuid.UUID, delta decimal.Decimal, change models.BalanceChange) (decimal.Decimal, error)
+
+	// ListBalanceAudit mengambil riwayat perubahan saldo wallet,
+	// terbaru dulu.
+	ListBalanceAudit(ctx context.Context, walletID uuid.UUID, params ListParams) ([]*models.BalanceAudit, error)
 
 	// GetTotalBalance menghitung total saldo semua wallet aktif.
 	// Berguna untuk dashboard summary.
 	GetTotalBalance(ctx context.Context) (decimal.Decimal, error)
+
+	// ListWithLastTransaction sama seperti List, tapi setiap wallet
+	// disertai tanggal transaksi terakhirnya (nil jika belum pernah ada).
+	// Berguna untuk audit wallet yang sudah tidak dipakai.
+	ListWithLastTransaction(ctx context.Context, filter WalletFilter) ([]*WalletActivity, error)
 }
 
 // WalletFilter adalah filter untuk query wallets.
@@ -80,4 +105,31 @@ type WalletFilter struct {
 
 	// Currency filter berdasarkan mata uang.
 	Currency *string
+
+	// SortBy menentukan urutan hasil. Default WalletSortCreatedAt.
+	SortBy WalletSortOrder
+}
+
+// WalletSortOrder adalah urutan hasil WalletRepository.List.
+type WalletSortOrder string
+
+const (
+	// WalletSortCreatedAt: wallet terbaru dulu (default).
+	WalletSortCreatedAt WalletSortOrder = ""
+
+	// WalletSortName: nama A-Z, tidak case-sensitive.
+	WalletSortName WalletSortOrder = "name"
+
+	// WalletSortBalance: saldo terbesar dulu. Currency tidak dikonversi.
+	WalletSortBalance WalletSortOrder = "balance"
+)
+
+// WalletActivity adalah wallet beserta waktu terakhir dipakai.
+type WalletActivity struct {
+	// Wallet adalah data wallet.
+	Wallet *models.Wallet
+
+	// LastTransactionDate adalah tanggal transaksi terakhir.
+	// nil jika wallet belum pernah punya transaksi.
+	LastTransactionDate *time.Time
 }
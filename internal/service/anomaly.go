@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+)
+
+// Anomaly check defaults.
+const (
+	// AnomalyWindow adalah window histori yang dipakai untuk rata-rata.
+	AnomalyWindow = 90 * 24 * time.Hour
+
+	// AnomalyMinSamples adalah jumlah minimal transaksi historis
+	// sebelum pengecekan multiplier dilakukan. Di bawah ini rata-rata
+	// belum bisa dipercaya, jadi check di-skip.
+	AnomalyMinSamples = 3
+)
+
+// AnomalyThresholds adalah batas untuk deteksi transaksi tidak wajar.
+//
+// Nilai 0 pada salah satu field berarti check tersebut nonaktif.
+type AnomalyThresholds struct {
+	// Multiplier: warning jika amount > Multiplier × rata-rata kategori.
+	Multiplier float64
+
+	// Absolute: warning jika amount > nilai ini, tanpa melihat histori.
+	Absolute decimal.Decimal
+}
+
+// AnomalyWarning adalah soft warning untuk transaksi yang nilainya
+// jauh di atas kebiasaan. Ini BUKAN error - caller yang memutuskan
+// apakah perlu konfirmasi user.
+type AnomalyWarning struct {
+	// Amount adalah amount transaksi yang dicek.
+	Amount decimal.Decimal
+
+	// Average adalah rata-rata historis kategori (0 jika tidak dipakai).
+	Average decimal.Decimal
+
+	// Ratio adalah Amount / Average (0 jika tidak dipakai).
+	Ratio float64
+
+	// Absolute adalah threshold absolut yang terlampaui (0 jika tidak).
+	Absolute decimal.Decimal
+}
+
+// Message membuat pesan warning yang bisa ditampilkan ke user.
+// label biasanya nama kategori, misal "Food".
+//
+//	"This is 12× your usual Food expense"
+func (w *AnomalyWarning) Message(label string, txType models.TransactionType) string {
+	if w.Ratio > 0 {
+		return fmt.Sprintf("This is %.0f× your usual %s %s", w.Ratio, label, txType)
+	}
+	return fmt.Sprintf("This is above the large transaction threshold of %s", w.Absolute.String())
+}
+
+// CheckAnomaly mengecek apakah transaksi nilainya tidak wajar
+// dibanding rata-rata kategori dalam 90 hari terakhir.
+//
+// Return nil jika tidak ada yang aneh. Transaksi tanpa kategori
+// hanya dicek terhadap threshold absolut. Jika OriginalAmount diisi,
+// yang dicek adalah nominal setelah dikonversi ke currency wallet, sama
+// dengan yang akan disimpan Create.
+//
+//	warning, err := txService.CheckAnomaly(ctx, input, thresholds)
+//	if warning != nil {
+//	    // minta konfirmasi user
+//	}
+func (s *TransactionService) CheckAnomaly(
+	ctx context.Context,
+	input CreateTransactionInput,
+	thresholds AnomalyThresholds,
+) (*AnomalyWarning, error) {
+	if input.OriginalAmount != nil {
+		walletID, err := s.walletOrDefault(input.WalletID)
+		if err != nil {
+			return nil, err
+		}
+		wallet, err := s.walletRepo.GetByID(ctx, walletID)
+		if err != nil {
+			return nil, fmt.Errorf("wallet not found: %w", err)
+		}
+		if err := s.applyOriginalAmount(ctx, &input, wallet); err != nil {
+			return nil, err
+		}
+	}
+
+	var average decimal.Decimal
+	samples := 0
+
+	if input.CategoryID != nil && thresholds.Multiplier > 0 {
+		since := time.Now().Add(-AnomalyWindow)
+		stats, err := s.txRepo.GetAmountStats(ctx, *input.CategoryID, input.Type, since)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get amount stats: %w", err)
+		}
+		average = stats.Average
+		samples = stats.Count
+	}
+
+	return evaluateAnomaly(input.Amount, average, samples, thresholds), nil
+}
+
+// evaluateAnomaly adalah inti perhitungan CheckAnomaly (pure function).
+func evaluateAnomaly(amount, average decimal.Decimal, samples int, thresholds AnomalyThresholds) *AnomalyWarning {
+	if thresholds.Multiplier > 0 && samples >= AnomalyMinSamples && average.IsPositive() {
+		limit := average.Mul(decimal.NewFromFloat(thresholds.Multiplier))
+		if amount.GreaterThan(limit) {
+			ratio, _ := amount.Div(average).Float64()
+			return &AnomalyWarning{
+				Amount:  amount,
+				Average: average,
+				Ratio:   ratio,
+			}
+		}
+	}
+
+	if thresholds.Absolute.IsPositive() && amount.GreaterThan(thresholds.Absolute) {
+		return &AnomalyWarning{
+			Amount:   amount,
+			Absolute: thresholds.Absolute,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+)
+
+func TestEvaluateAnomaly(t *testing.T) {
+	thresholds := AnomalyThresholds{Multiplier: 5}
+
+	tests := []struct {
+		name      string
+		amount    int64
+		average   int64
+		samples   int
+		absolute  int64
+		wantWarn  bool
+		wantRatio float64
+	}{
+		{"normal amount", 60000, 50000, 10, 0, false, 0},
+		{"exactly at multiplier", 250000, 50000, 10, 0, false, 0},
+		{"above multiplier", 600000, 50000, 10, 0, true, 12},
+		{"fewer than 3 samples skips check", 600000, 50000, 2, 0, false, 0},
+		{"zero average skips check", 600000, 0, 10, 0, false, 0},
+		{"absolute threshold without history", 2000000, 0, 0, 1000000, true, 0},
+		{"below absolute threshold", 500000, 0, 0, 1000000, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := thresholds
+			th.Absolute = decimal.NewFromInt(tt.absolute)
+
+			got := evaluateAnomaly(decimal.NewFromInt(tt.amount), decimal.NewFromInt(tt.average), tt.samples, th)
+			if (got != nil) != tt.wantWarn {
+				t.Fatalf("evaluateAnomaly() = %+v, wantWarn %v", got, tt.wantWarn)
+			}
+			if got != nil && got.Ratio != tt.wantRatio {
+				t.Errorf("Ratio = %v, want %v", got.Ratio, tt.wantRatio)
+			}
+		})
+	}
+}
+
+func TestTransactionService_CheckAnomaly_IgnoresAdjustments(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 0)
+	food := repos.createCategory(t, "Food", models.CategoryTypeExpense)
+
+	// Tiga expense biasa 100, plus koreksi saldo besar di kategori yang sama
+	for _, amount := range []int64{100, 100, 100, 50000} {
+		tx := models.NewTransaction(wallet.ID, models.TransactionTypeExpense, decimal.NewFromInt(amount))
+		tx.CategoryID = &food.ID
+		tx.TransactionDate = time.Now().AddDate(0, 0, -1)
+		if amount == 50000 {
+			tx.AddTag(models.TagAdjustment)
+		}
+		if err := repos.transaction.Create(ctx, tx); err != nil {
+			t.Fatalf("create transaction: %v", err)
+		}
+	}
+
+	warning, err := svc.CheckAnomaly(ctx, CreateTransactionInput{
+		WalletID:   wallet.ID,
+		CategoryID: &food.ID,
+		Type:       models.TransactionTypeExpense,
+		Amount:     decimal.NewFromInt(1000),
+	}, AnomalyThresholds{Multiplier: 5})
+	if err != nil {
+		t.Fatalf("CheckAnomaly() error = %v", err)
+	}
+	if warning == nil || !warning.Average.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("CheckAnomaly() = %+v, want a warning against the 100 average", warning)
+	}
+}
+
+func TestTransactionService_CheckAnomaly_OriginalAmount(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	svc.SetRateProvider(NewStaticRates("IDR", map[string]decimal.Decimal{"USD": decimal.NewFromInt(16000)}), "IDR")
+	wallet := repos.createWallet(t, "BCA", 5000000)
+
+	// 100 USD = 1.600.000 IDR, di atas threshold 1.000.000 walaupun
+	// Amount belum diisi
+	original := decimal.NewFromInt(100)
+	warning, err := svc.CheckAnomaly(ctx, CreateTransactionInput{
+		WalletID:         wallet.ID,
+		Type:             models.TransactionTypeExpense,
+		OriginalAmount:   &original,
+		OriginalCurrency: "USD",
+	}, AnomalyThresholds{Absolute: decimal.NewFromInt(1000000)})
+	if err != nil {
+		t.Fatalf("CheckAnomaly() error = %v", err)
+	}
+	if warning == nil || !warning.Amount.Equal(decimal.NewFromInt(1600000)) {
+		t.Errorf("CheckAnomaly() = %+v, want a warning for the converted 1.600.000", warning)
+	}
+}
+
+func TestAnomalyWarning_Message(t *testing.T) {
+	w := &AnomalyWarning{Ratio: 12}
+	want := "This is 12× your usual Food expense"
+	if got := w.Message("Food", models.TransactionTypeExpense); got != want {
+		t.Errorf("Message() = %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
 // BudgetService menangani business logic untuk budget operations.
@@ -19,6 +21,9 @@ import (
 type BudgetService struct {
 	budgetRepo repository.BudgetRepository
 	txRepo     repository.TransactionRepository
+
+	// monthStartDay adalah tanggal awal periode monthly (1 = kalender).
+	monthStartDay int
 }
 
 // NewBudgetService membuat BudgetService baru.
@@ -32,23 +37,47 @@ func NewBudgetService(
 	}
 }
 
-// Create membuat budget baru.
+// SetMonthStartDay mengatur tanggal awal periode monthly (config
+// app.month_start_day) untuk status budget, Simulate dan PeriodStart.
+// Default 1.
+func (s *BudgetService) SetMonthStartDay(day int) {
+	s.monthStartDay = day
+}
+
+// PeriodStart mengembalikan awal periode budget yang memuat now. Dipakai
+// sebagai StartDate budget baru supaya window budget monthly sejajar
+// dengan app.month_start_day.
+//
+//	start := budgetService.PeriodStart(models.BudgetPeriodMonthly, time.Now())
+func (s *BudgetService) PeriodStart(period models.BudgetPeriod, now time.Time) time.Time {
+	return periodStart(period, now, s.monthStartDay)
+}
+
+// Create membuat budget baru. StartDate lebih dari satu periode dari
+// sekarang ditolak dengan ErrBudgetStartTooFar.
 func (s *BudgetService) Create(ctx context.Context, input CreateBudgetInput) (*models.Budget, error) {
 	budget := &models.Budget{
-		ID:         models.NewID(),
-		CategoryID: input.CategoryID,
-		Amount:     input.Amount,
-		Period:     input.Period,
-		StartDate:  input.StartDate,
-		EndDate:    input.EndDate,
-		IsActive:   true,
-		CreatedAt:  time.Now(),
+		ID:            models.NewID(),
+		CategoryID:    input.CategoryID,
+		Amount:        input.Amount,
+		Period:        input.Period,
+		StartDate:     input.StartDate,
+		EndDate:       input.EndDate,
+		IsActive:      true,
+		WarnThreshold: input.WarnThreshold,
+		CreatedAt:     time.Now(),
 	}
 
 	if err := budget.Validate(); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	// Budget yang baru mulai jauh di depan hampir selalu salah ketik tahun
+	if latest := budget.Period.AddTo(time.Now()); budget.StartDate.After(latest) {
+		return nil, fmt.Errorf("%w: %s is after %s", ErrBudgetStartTooFar,
+			budget.StartDate.Format("2006-01-02"), latest.Format("2006-01-02"))
+	}
+
 	if err := s.budgetRepo.Create(ctx, budget); err != nil {
 		return nil, fmt.Errorf("failed to create budget: %w", err)
 	}
@@ -89,30 +118,35 @@ func (s *BudgetService) ListActive(ctx context.Context) ([]*models.Budget, error
 	return s.List(ctx, repository.BudgetFilter{IsActive: &isActive})
 }
 
-// GetAllStatus menghitung status semua budget aktif.
-// Ini yang ditampilkan di dashboard.
+// GetAllStatus menghitung status semua budget aktif di periode berjalan
+// (Budget.PeriodWindow). Ini yang ditampilkan di dashboard.
 func (s *BudgetService) GetAllStatus(ctx context.Context) ([]*repository.BudgetStatus, error) {
-	statuses, err := s.budgetRepo.GetBudgetStatus(ctx)
+	statuses, err := s.budgetRepo.GetBudgetStatus(ctx, time.Now(), s.monthStartDay)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get budget status: %w", err)
 	}
 	return statuses, nil
 }
 
-// GetStatus menghitung status budget tertentu.
+// GetStatus menghitung status budget tertentu di periode berjalan
+// (Budget.PeriodWindow).
 func (s *BudgetService) GetStatus(ctx context.Context, id uuid.UUID) (*repository.BudgetStatus, error) {
 	budget, err := s.budgetRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get budget: %w", err)
 	}
 
-	// Calculate spent amount
+	// Calculate spent amount; EndDate filter inklusif, jadi berhenti tepat
+	// sebelum periode berikutnya
+	start, end := budget.PeriodWindow(time.Now(), s.monthStartDay)
+	end = end.Add(-time.Nanosecond)
+	if budget.EndDate != nil && budget.EndDate.Before(end) {
+		end = *budget.EndDate
+	}
 	filter := repository.TransactionFilter{
 		CategoryID: &budget.CategoryID,
-		StartDate:  &budget.StartDate,
-	}
-	if budget.EndDate != nil {
-		filter.EndDate = budget.EndDate
+		StartDate:  &start,
+		EndDate:    &end,
 	}
 
 	expenseType := models.TransactionTypeExpense
@@ -144,6 +178,86 @@ func (s *BudgetService) GetStatus(ctx context.Context, id uuid.UUID) (*repositor
 	}, nil
 }
 
+// CheckAlerts mengembalikan budget aktif yang sudah melewati threshold:
+//   - BudgetAlertWarning: progress >= budget.WarnThreshold (default 80%)
+//   - BudgetAlertOver: progress >= 100%
+//
+// Budget di bawah WarnThreshold tidak ikut. Urutan sama dengan GetAllStatus.
+//
+//	alerts, err := budgetService.CheckAlerts(ctx)
+//	for _, a := range alerts {
+//	    fmt.Printf("%s: %.0f%% (%s)\n", a.Status.CategoryName, a.Status.Progress, a.Level)
+//	}
+func (s *BudgetService) CheckAlerts(ctx context.Context) ([]*BudgetAlert, error) {
+	statuses, err := s.GetAllStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []*BudgetAlert
+	for _, status := range statuses {
+		if level, ok := budgetAlertLevel(status); ok {
+			alerts = append(alerts, &BudgetAlert{Status: status, Level: level})
+		}
+	}
+	return alerts, nil
+}
+
+// GetOverBudgetAlerts mengembalikan budget aktif yang pengeluarannya
+// melebihi amount (IsOverBudget), dalam bentuk siap tampil untuk banner
+// TUI. Urutan sama dengan GetAllStatus.
+//
+//	alerts, err := budgetService.GetOverBudgetAlerts(ctx)
+//	for _, a := range alerts {
+//	    fmt.Printf("%s over by %s (%.0f%%)\n", a.CategoryName, a.OverBy, a.OverByPercent)
+//	}
+func (s *BudgetService) GetOverBudgetAlerts(ctx context.Context) ([]OverBudgetAlert, error) {
+	statuses, err := s.GetAllStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []OverBudgetAlert
+	for _, status := range statuses {
+		if !status.IsOverBudget {
+			continue
+		}
+
+		overBy := status.Spent.Sub(status.Budget.Amount)
+		var overByPercent float64
+		if !status.Budget.Amount.IsZero() {
+			overByPercent, _ = overBy.Div(status.Budget.Amount).Mul(decimal.NewFromInt(100)).Float64()
+		}
+
+		alerts = append(alerts, OverBudgetAlert{
+			CategoryName:  status.CategoryName,
+			CategoryIcon:  status.CategoryIcon,
+			Spent:         status.Spent,
+			Budget:        status.Budget.Amount,
+			OverBy:        overBy,
+			OverByPercent: overByPercent,
+		})
+	}
+	return alerts, nil
+}
+
+// budgetAlertLevel menentukan level alert untuk satu status budget.
+// ok false jika progress masih di bawah WarnThreshold.
+func budgetAlertLevel(status *repository.BudgetStatus) (BudgetAlertLevel, bool) {
+	warnAt := status.Budget.WarnThreshold
+	if warnAt <= 0 {
+		warnAt = models.DefaultWarnThreshold
+	}
+
+	switch {
+	case status.Progress >= 100:
+		return BudgetAlertOver, true
+	case status.Progress >= warnAt:
+		return BudgetAlertWarning, true
+	}
+	return "", false
+}
+
 // Update memperbarui budget.
 func (s *BudgetService) Update(ctx context.Context, input UpdateBudgetInput) (*models.Budget, error) {
 	budget, err := s.budgetRepo.GetByID(ctx, input.ID)
@@ -160,6 +274,9 @@ func (s *BudgetService) Update(ctx context.Context, input UpdateBudgetInput) (*m
 	if input.IsActive != nil {
 		budget.IsActive = *input.IsActive
 	}
+	if input.WarnThreshold != nil {
+		budget.WarnThreshold = *input.WarnThreshold
+	}
 
 	if err := budget.Validate(); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -172,6 +289,158 @@ func (s *BudgetService) Update(ctx context.Context, input UpdateBudgetInput) (*m
 	return budget, nil
 }
 
+// Rollover memajukan PeriodStart budget aktif ke awal periode berjalan
+// (Budget.PeriodWindow). StartDate tidak diubah supaya tanggal mulai asli
+// budget tetap tersimpan, dan weekly/yearly tetap ter-anchor padanya.
+// Budget yang sudah lewat EndDate di-skip.
+//
+// Spending di status budget selalu dihitung dari window periode, jadi
+// tidak bergantung pada rollover sudah jalan atau belum.
+//
+// Return jumlah budget yang di-roll over.
+func (s *BudgetService) Rollover(ctx context.Context, now time.Time) (int, error) {
+	budgets, err := s.ListActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rolled := 0
+	for _, budget := range budgets {
+		if budget.EndDate != nil && now.After(*budget.EndDate) {
+			continue
+		}
+
+		start, _ := budget.PeriodWindow(now, s.monthStartDay)
+		if !start.After(budget.PeriodStart) {
+			continue
+		}
+
+		budget.PeriodStart = start
+		if err := s.budgetRepo.Update(ctx, budget); err != nil {
+			return rolled, fmt.Errorf("failed to roll over budget %s: %w", budget.ID, err)
+		}
+		rolled++
+	}
+
+	return rolled, nil
+}
+
+// Simulate menghitung bagaimana budget usulan akan berjalan di masa lalu.
+//
+// Untuk setiap periode yang sudah selesai dalam `months` bulan terakhir
+// (minggu mulai Senin, bulan mulai app.month_start_day, tahun mulai 1 Jan),
+// pengeluaran aktual kategori dibandingkan dengan amount. Periode berjalan
+// tidak dihitung karena belum lengkap.
+//
+// Berguna untuk menentukan budget yang realistis sebelum membuatnya:
+//
+//	sim, err := budgetService.Simulate(ctx, foodID, decimal.NewFromInt(1500000), models.BudgetPeriodMonthly, 6)
+//	fmt.Printf("over budget %d of %d months\n", sim.OverCount, len(sim.Periods))
+func (s *BudgetService) Simulate(
+	ctx context.Context,
+	categoryID uuid.UUID,
+	amount decimal.Decimal,
+	period models.BudgetPeriod,
+	months int,
+) (*BudgetSimulation, error) {
+	return s.simulate(ctx, categoryID, amount, period, months, time.Now())
+}
+
+// simulate adalah Simulate dengan waktu sekarang yang bisa di-set (untuk test).
+func (s *BudgetService) simulate(
+	ctx context.Context,
+	categoryID uuid.UUID,
+	amount decimal.Decimal,
+	period models.BudgetPeriod,
+	months int,
+	now time.Time,
+) (*BudgetSimulation, error) {
+	if !amount.IsPositive() {
+		return nil, models.ErrBudgetInvalidAmount
+	}
+	if !period.IsValid() {
+		return nil, models.ErrBudgetInvalidPeriod
+	}
+	if months < 1 {
+		return nil, ErrInvalidSimulationMonths
+	}
+
+	// Periode terakhir yang disimulasikan berakhir sebelum periode berjalan
+	current := periodStart(period, now, s.monthStartDay)
+	windowStart := current.AddDate(0, -months, 0)
+	start := periodStart(period, windowStart, s.monthStartDay)
+	if start.Before(windowStart) {
+		start = period.AddTo(start)
+	}
+
+	sim := &BudgetSimulation{
+		CategoryID: categoryID,
+		Amount:     amount,
+		Period:     period,
+	}
+
+	expenseType := models.TransactionTypeExpense
+	total := decimal.Zero
+	for start.Before(current) {
+		next := period.AddTo(start)
+		end := next.Add(-time.Nanosecond)
+
+		summary, err := s.txRepo.GetSummary(ctx, repository.TransactionFilter{
+			CategoryID:         &categoryID,
+			Type:               &expenseType,
+			StartDate:          &start,
+			EndDate:            &end,
+			ExcludeAdjustments: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get spending: %w", err)
+		}
+
+		spent := summary.TotalExpense
+		result := SimulatedPeriod{
+			Start:      start,
+			End:        end,
+			Spent:      spent,
+			Difference: amount.Sub(spent),
+			IsOver:     spent.GreaterThan(amount),
+		}
+		sim.Periods = append(sim.Periods, result)
+
+		if result.IsOver {
+			sim.OverCount++
+		}
+		if spent.GreaterThan(sim.MaxSpent) {
+			sim.MaxSpent = spent
+		}
+		total = total.Add(spent)
+		start = next
+	}
+
+	if len(sim.Periods) > 0 {
+		sim.AverageSpent = total.Div(decimal.NewFromInt(int64(len(sim.Periods))))
+	}
+
+	return sim, nil
+}
+
+// periodStart mengembalikan awal periode yang memuat t. Periode monthly
+// dimulai di monthStartDay (lihat utils.PeriodBounds).
+func periodStart(period models.BudgetPeriod, t time.Time, monthStartDay int) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case models.BudgetPeriodWeekly:
+		// time.Sunday = 0, jadi geser supaya minggu mulai Senin
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case models.BudgetPeriodYearly:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		year, month := utils.PeriodContaining(monthStartDay, t)
+		start, _ := utils.PeriodBounds(monthStartDay, year, month, t.Location())
+		return start
+	}
+}
+
 // Delete menghapus budget.
 func (s *BudgetService) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := s.budgetRepo.Delete(ctx, id); err != nil {
@@ -180,6 +449,72 @@ func (s *BudgetService) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// ErrInvalidSimulationMonths dikembalikan Simulate jika months < 1.
+var ErrInvalidSimulationMonths = errors.New("months must be at least 1")
+
+// ErrBudgetStartTooFar dikembalikan Create jika StartDate lebih dari satu
+// periode budget di masa depan.
+var ErrBudgetStartTooFar = errors.New("budget start date is more than one period in the future")
+
+// BudgetSimulation adalah hasil Simulate.
+type BudgetSimulation struct {
+	CategoryID uuid.UUID
+	Amount     decimal.Decimal
+	Period     models.BudgetPeriod
+
+	// Periods berisi setiap periode yang disimulasikan, terlama dulu.
+	Periods []SimulatedPeriod
+
+	// OverCount adalah jumlah periode yang pengeluarannya melebihi Amount.
+	OverCount int
+
+	// AverageSpent dan MaxSpent dihitung dari semua Periods.
+	AverageSpent decimal.Decimal
+	MaxSpent     decimal.Decimal
+}
+
+// SimulatedPeriod adalah hasil simulasi budget untuk satu periode.
+type SimulatedPeriod struct {
+	Start time.Time
+	End   time.Time
+	Spent decimal.Decimal
+
+	// Difference adalah Amount - Spent (negatif jika over budget).
+	Difference decimal.Decimal
+	IsOver     bool
+}
+
+// BudgetAlertLevel adalah tingkat alert budget.
+type BudgetAlertLevel string
+
+const (
+	// BudgetAlertWarning: progress sudah melewati WarnThreshold budget.
+	BudgetAlertWarning BudgetAlertLevel = "warning"
+
+	// BudgetAlertOver: progress 100% atau lebih.
+	BudgetAlertOver BudgetAlertLevel = "over"
+)
+
+// BudgetAlert adalah hasil CheckAlerts untuk satu budget.
+type BudgetAlert struct {
+	Status *repository.BudgetStatus
+	Level  BudgetAlertLevel
+}
+
+// OverBudgetAlert adalah hasil GetOverBudgetAlerts untuk satu budget.
+type OverBudgetAlert struct {
+	CategoryName string
+	CategoryIcon string
+
+	Spent  decimal.Decimal
+	Budget decimal.Decimal
+
+	// OverBy adalah Spent - Budget; OverByPercent adalah OverBy dalam
+	// persen dari Budget (0 jika Budget nol).
+	OverBy        decimal.Decimal
+	OverByPercent float64
+}
+
 // CreateBudgetInput adalah input untuk membuat budget.
 type CreateBudgetInput struct {
 	CategoryID uuid.UUID
@@ -187,12 +522,16 @@ type CreateBudgetInput struct {
 	Period     models.BudgetPeriod
 	StartDate  time.Time
 	EndDate    *time.Time
+
+	// WarnThreshold adalah persentase alert pertama (0 = default 80).
+	WarnThreshold float64
 }
 
 // UpdateBudgetInput adalah input untuk update budget.
 type UpdateBudgetInput struct {
-	ID       uuid.UUID
-	Amount   *decimal.Decimal
-	EndDate  *time.Time
-	IsActive *bool
+	ID            uuid.UUID
+	Amount        *decimal.Decimal
+	EndDate       *time.Time
+	IsActive      *bool
+	WarnThreshold *float64
 }
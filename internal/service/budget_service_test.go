@@ -0,0 +1,491 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+)
+
+func TestBudgetService_GetStatus(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	txSvc := newTestTransactionService(repos)
+	budgetSvc := NewBudgetService(repos.budget, repos.transaction)
+	wallet := repos.createWallet(t, "BCA", 1000000)
+
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	if err := repos.category.Create(ctx, food); err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+
+	budget, err := budgetSvc.Create(ctx, CreateBudgetInput{
+		CategoryID: food.ID,
+		Amount:     decimal.NewFromInt(100000),
+		Period:     models.BudgetPeriodMonthly,
+		StartDate:  time.Now().AddDate(0, 0, -1),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	for _, amount := range []int64{70000, 50000} {
+		_, err := txSvc.Create(ctx, CreateTransactionInput{
+			WalletID:   wallet.ID,
+			CategoryID: &food.ID,
+			Type:       models.TransactionTypeExpense,
+			Amount:     decimal.NewFromInt(amount),
+		})
+		if err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+	}
+
+	status, err := budgetSvc.GetStatus(ctx, budget.ID)
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+
+	if !status.Spent.Equal(decimal.NewFromInt(120000)) {
+		t.Errorf("Spent = %s, want 120000", status.Spent)
+	}
+	if !status.Remaining.IsZero() {
+		t.Errorf("Remaining = %s, want 0", status.Remaining)
+	}
+	if status.Progress != 120 {
+		t.Errorf("Progress = %v, want 120", status.Progress)
+	}
+	if !status.IsOverBudget {
+		t.Error("IsOverBudget = false, want true")
+	}
+}
+
+func TestBudgetService_Create_UnknownCategory(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := NewBudgetService(repos.budget, repos.transaction)
+
+	_, err := svc.Create(context.Background(), CreateBudgetInput{
+		CategoryID: models.NewID(),
+		Amount:     decimal.NewFromInt(100000),
+		Period:     models.BudgetPeriodMonthly,
+		StartDate:  time.Now(),
+	})
+	if err == nil {
+		t.Error("Create() with unknown category should return error")
+	}
+}
+
+func TestBudgetService_Create_StartTooFar(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewBudgetService(repos.budget, repos.transaction)
+
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	if err := repos.category.Create(ctx, food); err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+
+	now := time.Now()
+	tests := []struct {
+		name    string
+		period  models.BudgetPeriod
+		start   time.Time
+		wantErr bool
+	}{
+		{"started last month", models.BudgetPeriodMonthly, now.AddDate(0, -1, 0), false},
+		{"starts next month", models.BudgetPeriodMonthly, now.AddDate(0, 0, 25), false},
+		{"starts in two months", models.BudgetPeriodMonthly, now.AddDate(0, 2, 0), true},
+		{"weekly in ten days", models.BudgetPeriodWeekly, now.AddDate(0, 0, 10), true},
+		{"yearly in six months", models.BudgetPeriodYearly, now.AddDate(0, 6, 0), false},
+		{"typo in the year", models.BudgetPeriodYearly, now.AddDate(10, 0, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Create(ctx, CreateBudgetInput{
+				CategoryID: food.ID,
+				Amount:     decimal.NewFromInt(100000),
+				Period:     tt.period,
+				StartDate:  tt.start,
+			})
+			if got := errors.Is(err, ErrBudgetStartTooFar); got != tt.wantErr {
+				t.Errorf("Create() error = %v, want ErrBudgetStartTooFar: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Create() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestBudgetService_Simulate(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	txSvc := newTestTransactionService(repos)
+	budgetSvc := NewBudgetService(repos.budget, repos.transaction)
+	wallet := repos.createWallet(t, "BCA", 1000000)
+
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	if err := repos.category.Create(ctx, food); err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+
+	spend := []struct {
+		date   time.Time
+		amount int64
+	}{
+		{time.Date(2025, 3, 31, 12, 0, 0, 0, time.Local), 500000}, // sebelum window
+		{time.Date(2025, 4, 10, 12, 0, 0, 0, time.Local), 80000},
+		{time.Date(2025, 5, 1, 0, 0, 0, 0, time.Local), 70000},
+		{time.Date(2025, 5, 31, 23, 30, 0, 0, time.Local), 50000},
+		{time.Date(2025, 7, 2, 12, 0, 0, 0, time.Local), 10000}, // periode berjalan
+	}
+	for _, s := range spend {
+		_, err := txSvc.Create(ctx, CreateTransactionInput{
+			WalletID:   wallet.ID,
+			CategoryID: &food.ID,
+			Type:       models.TransactionTypeExpense,
+			Amount:     decimal.NewFromInt(s.amount),
+			Date:       s.date,
+		})
+		if err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+	}
+
+	now := time.Date(2025, 7, 15, 9, 0, 0, 0, time.Local)
+	sim, err := budgetSvc.simulate(ctx, food.ID, decimal.NewFromInt(100000), models.BudgetPeriodMonthly, 3, now)
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+
+	wantSpent := []int64{80000, 120000, 0}
+	if len(sim.Periods) != len(wantSpent) {
+		t.Fatalf("len(Periods) = %d, want %d", len(sim.Periods), len(wantSpent))
+	}
+	for i, want := range wantSpent {
+		p := sim.Periods[i]
+		if wantStart := time.Date(2025, time.Month(4+i), 1, 0, 0, 0, 0, time.Local); !p.Start.Equal(wantStart) {
+			t.Errorf("Periods[%d].Start = %s, want %s", i, p.Start, wantStart)
+		}
+		if !p.Spent.Equal(decimal.NewFromInt(want)) {
+			t.Errorf("Periods[%d].Spent = %s, want %d", i, p.Spent, want)
+		}
+		if p.IsOver != (want > 100000) {
+			t.Errorf("Periods[%d].IsOver = %v", i, p.IsOver)
+		}
+	}
+
+	if sim.OverCount != 1 {
+		t.Errorf("OverCount = %d, want 1", sim.OverCount)
+	}
+	if !sim.MaxSpent.Equal(decimal.NewFromInt(120000)) {
+		t.Errorf("MaxSpent = %s, want 120000", sim.MaxSpent)
+	}
+	if !sim.Periods[1].Difference.Equal(decimal.NewFromInt(-20000)) {
+		t.Errorf("Difference = %s, want -20000", sim.Periods[1].Difference)
+	}
+	if want := decimal.NewFromInt(200000).Div(decimal.NewFromInt(3)); !sim.AverageSpent.Equal(want) {
+		t.Errorf("AverageSpent = %s, want %s", sim.AverageSpent, want)
+	}
+}
+
+func TestBudgetService_Simulate_Weekly(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := NewBudgetService(repos.budget, repos.transaction)
+
+	// Rabu 16 Jul 2025; minggu berjalan mulai Senin 14 Jul
+	now := time.Date(2025, 7, 16, 9, 0, 0, 0, time.Local)
+	sim, err := svc.simulate(context.Background(), models.NewID(), decimal.NewFromInt(100), models.BudgetPeriodWeekly, 1, now)
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+
+	if len(sim.Periods) != 4 {
+		t.Fatalf("len(Periods) = %d, want 4", len(sim.Periods))
+	}
+	if first := time.Date(2025, 6, 16, 0, 0, 0, 0, time.Local); !sim.Periods[0].Start.Equal(first) {
+		t.Errorf("first period starts %s, want %s", sim.Periods[0].Start, first)
+	}
+	for _, p := range sim.Periods {
+		if p.Start.Weekday() != time.Monday {
+			t.Errorf("period starts on %s, want Monday", p.Start.Weekday())
+		}
+	}
+}
+
+func TestBudgetService_Rollover(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewBudgetService(repos.budget, repos.transaction)
+	food := repos.createCategory(t, "Food", models.CategoryTypeExpense)
+
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 0, 0, 0, 0, time.Local) }
+	monthly := &models.Budget{ID: models.NewID(), CategoryID: food.ID, Amount: decimal.NewFromInt(100),
+		Period: models.BudgetPeriodMonthly, StartDate: day(time.January, 31), IsActive: true}
+	weekly := &models.Budget{ID: models.NewID(), CategoryID: food.ID, Amount: decimal.NewFromInt(100),
+		Period: models.BudgetPeriodWeekly, StartDate: day(time.January, 1), IsActive: true}
+	for _, b := range []*models.Budget{monthly, weekly} {
+		if err := repos.budget.Create(ctx, b); err != nil {
+			t.Fatalf("create budget: %v", err)
+		}
+	}
+
+	// Monthly mengikuti month_start_day, tidak ikut bergeser dari 31 Jan;
+	// StartDate tidak pernah diubah
+	tests := []struct {
+		now                   time.Time
+		wantMonthly, wantWeek time.Time
+	}{
+		{day(time.March, 3), day(time.March, 1), day(time.February, 26)},
+		{day(time.April, 15), day(time.April, 1), day(time.April, 9)},
+	}
+	for _, tt := range tests {
+		if rolled, err := svc.Rollover(ctx, tt.now); err != nil || rolled != 2 {
+			t.Fatalf("Rollover(%s) = %d, %v, want 2, nil", tt.now.Format("2006-01-02"), rolled, err)
+		}
+		gotMonthly, _ := repos.budget.GetByID(ctx, monthly.ID)
+		gotWeekly, _ := repos.budget.GetByID(ctx, weekly.ID)
+		if !gotMonthly.PeriodStart.Equal(tt.wantMonthly) || !gotWeekly.PeriodStart.Equal(tt.wantWeek) {
+			t.Errorf("Rollover(%s) period start = %s (monthly), %s (weekly), want %s, %s", tt.now.Format("2006-01-02"),
+				gotMonthly.PeriodStart.Format("2006-01-02"), gotWeekly.PeriodStart.Format("2006-01-02"),
+				tt.wantMonthly.Format("2006-01-02"), tt.wantWeek.Format("2006-01-02"))
+		}
+		if !gotMonthly.StartDate.Equal(day(time.January, 31)) || !gotWeekly.StartDate.Equal(day(time.January, 1)) {
+			t.Errorf("Rollover(%s) changed StartDate to %s (monthly), %s (weekly)", tt.now.Format("2006-01-02"),
+				gotMonthly.StartDate.Format("2006-01-02"), gotWeekly.StartDate.Format("2006-01-02"))
+		}
+	}
+
+	// Periode yang sama tidak di-roll over dua kali
+	if rolled, err := svc.Rollover(ctx, day(time.April, 15)); err != nil || rolled != 0 {
+		t.Errorf("Rollover() again in the same period = %d, %v, want 0, nil", rolled, err)
+	}
+}
+
+func TestBudgetService_PeriodStart_MonthStartDay(t *testing.T) {
+	svc := NewBudgetService(nil, nil)
+	svc.SetMonthStartDay(25)
+
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{time.Date(2026, 1, 10, 9, 0, 0, 0, time.Local), time.Date(2025, 12, 25, 0, 0, 0, 0, time.Local)},
+		{time.Date(2026, 1, 24, 23, 0, 0, 0, time.Local), time.Date(2025, 12, 25, 0, 0, 0, 0, time.Local)},
+		{time.Date(2026, 1, 25, 0, 0, 0, 0, time.Local), time.Date(2026, 1, 25, 0, 0, 0, 0, time.Local)},
+		{time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local), time.Date(2026, 2, 25, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		if got := svc.PeriodStart(models.BudgetPeriodMonthly, tt.now); !got.Equal(tt.want) {
+			t.Errorf("PeriodStart(%s) = %s, want %s", tt.now.Format("2006-01-02 15:04"), got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestBudgetService_Simulate_MonthStartDay(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := NewBudgetService(repos.budget, repos.transaction)
+	svc.SetMonthStartDay(25)
+
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.Local)
+	sim, err := svc.simulate(context.Background(), models.NewID(), decimal.NewFromInt(100), models.BudgetPeriodMonthly, 2, now)
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+
+	if len(sim.Periods) != 2 {
+		t.Fatalf("len(Periods) = %d, want 2", len(sim.Periods))
+	}
+	if first := time.Date(2025, 10, 25, 0, 0, 0, 0, time.Local); !sim.Periods[0].Start.Equal(first) {
+		t.Errorf("first period starts %s, want %s", sim.Periods[0].Start, first)
+	}
+	for _, p := range sim.Periods {
+		if p.Start.Day() != 25 {
+			t.Errorf("period starts on day %d, want 25", p.Start.Day())
+		}
+	}
+}
+
+func TestBudgetService_Simulate_InvalidInput(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := NewBudgetService(repos.budget, repos.transaction)
+	ctx := context.Background()
+
+	if _, err := svc.Simulate(ctx, models.NewID(), decimal.Zero, models.BudgetPeriodMonthly, 6); err != models.ErrBudgetInvalidAmount {
+		t.Errorf("zero amount error = %v, want ErrBudgetInvalidAmount", err)
+	}
+	if _, err := svc.Simulate(ctx, models.NewID(), decimal.NewFromInt(1), "daily", 6); err != models.ErrBudgetInvalidPeriod {
+		t.Errorf("bad period error = %v, want ErrBudgetInvalidPeriod", err)
+	}
+	if _, err := svc.Simulate(ctx, models.NewID(), decimal.NewFromInt(1), models.BudgetPeriodMonthly, 0); err != ErrInvalidSimulationMonths {
+		t.Errorf("zero months error = %v, want ErrInvalidSimulationMonths", err)
+	}
+}
+
+func TestBudgetService_CheckAlerts(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	txSvc := newTestTransactionService(repos)
+	budgetSvc := NewBudgetService(repos.budget, repos.transaction)
+	wallet := repos.createWallet(t, "BCA", 1000000)
+
+	// spent 75.000 dari budget 100.000 → 75%
+	tests := []struct {
+		name      string
+		warnAt    float64
+		spent     int64
+		wantLevel BudgetAlertLevel
+	}{
+		{"below default threshold", 0, 75000, ""},
+		{"custom threshold reached", 70, 75000, BudgetAlertWarning},
+		{"exactly at threshold", 90, 90000, BudgetAlertWarning},
+		{"over budget", 70, 100000, BudgetAlertOver},
+	}
+
+	want := map[string]BudgetAlertLevel{}
+	for _, tt := range tests {
+		category := models.NewCategory(tt.name, models.CategoryTypeExpense)
+		if err := repos.category.Create(ctx, category); err != nil {
+			t.Fatalf("failed to create category: %v", err)
+		}
+		if _, err := budgetSvc.Create(ctx, CreateBudgetInput{
+			CategoryID:    category.ID,
+			Amount:        decimal.NewFromInt(100000),
+			Period:        models.BudgetPeriodMonthly,
+			StartDate:     time.Now().AddDate(0, 0, -1),
+			WarnThreshold: tt.warnAt,
+		}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if _, err := txSvc.Create(ctx, CreateTransactionInput{
+			WalletID:   wallet.ID,
+			CategoryID: &category.ID,
+			Type:       models.TransactionTypeExpense,
+			Amount:     decimal.NewFromInt(tt.spent),
+		}); err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+		if tt.wantLevel != "" {
+			want[tt.name] = tt.wantLevel
+		}
+	}
+
+	alerts, err := budgetSvc.CheckAlerts(ctx)
+	if err != nil {
+		t.Fatalf("CheckAlerts() error = %v", err)
+	}
+
+	got := map[string]BudgetAlertLevel{}
+	for _, a := range alerts {
+		got[a.Status.CategoryName] = a.Level
+	}
+	if len(got) != len(want) {
+		t.Errorf("CheckAlerts() = %v, want %v", got, want)
+	}
+	for name, level := range want {
+		if got[name] != level {
+			t.Errorf("alert %q = %q, want %q", name, got[name], level)
+		}
+	}
+}
+
+func TestBudgetService_GetOverBudgetAlerts(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	txSvc := newTestTransactionService(repos)
+	budgetSvc := NewBudgetService(repos.budget, repos.transaction)
+	wallet := repos.createWallet(t, "BCA", 1000000)
+
+	// Budget 200.000; tepat di budget belum dihitung over
+	for _, tt := range []struct {
+		name  string
+		spent int64
+	}{
+		{"Food", 250000},
+		{"Transport", 200000},
+		{"Fun", 120000},
+	} {
+		category := models.NewCategory(tt.name, models.CategoryTypeExpense)
+		category.Icon = "🍔"
+		if err := repos.category.Create(ctx, category); err != nil {
+			t.Fatalf("failed to create category: %v", err)
+		}
+		if _, err := budgetSvc.Create(ctx, CreateBudgetInput{
+			CategoryID: category.ID,
+			Amount:     decimal.NewFromInt(200000),
+			Period:     models.BudgetPeriodMonthly,
+			StartDate:  time.Now().AddDate(0, 0, -1),
+		}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if _, err := txSvc.Create(ctx, CreateTransactionInput{
+			WalletID:   wallet.ID,
+			CategoryID: &category.ID,
+			Type:       models.TransactionTypeExpense,
+			Amount:     decimal.NewFromInt(tt.spent),
+		}); err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+	}
+
+	alerts, err := budgetSvc.GetOverBudgetAlerts(ctx)
+	if err != nil {
+		t.Fatalf("GetOverBudgetAlerts() error = %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("GetOverBudgetAlerts() = %d alerts, want 1", len(alerts))
+	}
+
+	a := alerts[0]
+	if a.CategoryName != "Food" || a.CategoryIcon != "🍔" {
+		t.Errorf("alert category = %s %q, want 🍔 Food", a.CategoryIcon, a.CategoryName)
+	}
+	if !a.Spent.Equal(decimal.NewFromInt(250000)) || !a.Budget.Equal(decimal.NewFromInt(200000)) {
+		t.Errorf("alert = spent %s of %s, want 250000 of 200000", a.Spent, a.Budget)
+	}
+	if !a.OverBy.Equal(decimal.NewFromInt(50000)) || a.OverByPercent != 25 {
+		t.Errorf("alert over by %s (%v%%), want 50000 (25%%)", a.OverBy, a.OverByPercent)
+	}
+}
+
+func TestBudgetService_Update_WarnThreshold(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	budgetSvc := NewBudgetService(repos.budget, repos.transaction)
+
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	if err := repos.category.Create(ctx, food); err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	budget, err := budgetSvc.Create(ctx, CreateBudgetInput{
+		CategoryID: food.ID,
+		Amount:     decimal.NewFromInt(100000),
+		Period:     models.BudgetPeriodMonthly,
+		StartDate:  time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if budget.WarnThreshold != models.DefaultWarnThreshold {
+		t.Errorf("default WarnThreshold = %v, want %v", budget.WarnThreshold, models.DefaultWarnThreshold)
+	}
+
+	warnAt := 90.0
+	updated, err := budgetSvc.Update(ctx, UpdateBudgetInput{ID: budget.ID, WarnThreshold: &warnAt})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.WarnThreshold != 90 {
+		t.Errorf("WarnThreshold = %v, want 90", updated.WarnThreshold)
+	}
+
+	invalid := 100.0
+	if _, err := budgetSvc.Update(ctx, UpdateBudgetInput{ID: budget.ID, WarnThreshold: &invalid}); !errors.Is(err, models.ErrBudgetInvalidWarn) {
+		t.Errorf("Update() with 100%% error = %v, want ErrBudgetInvalidWarn", err)
+	}
+}
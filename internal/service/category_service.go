@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -10,14 +11,38 @@ import (
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
 )
 
+// Category delete errors.
+var (
+	// ErrCategoryInUse dikembalikan Delete jika kategori masih dipakai
+	// transaksi atau budget. Hapus dengan DeleteAndReassign.
+	ErrCategoryInUse = errors.New("category is still in use")
+
+	// ErrInvalidReassign dikembalikan DeleteAndReassign jika kategori
+	// tujuan sama dengan kategori yang dihapus atau beda tipe.
+	ErrInvalidReassign = errors.New("invalid reassign category")
+)
+
 // CategoryService menangani business logic untuk category operations.
 type CategoryService struct {
-	repo repository.CategoryRepository
+	repo       repository.CategoryRepository
+	txRepo     repository.TransactionRepository
+	budgetRepo repository.BudgetRepository
+	txManager  repository.TransactionManager
 }
 
 // NewCategoryService membuat CategoryService baru.
-func NewCategoryService(repo repository.CategoryRepository) *CategoryService {
-	return &CategoryService{repo: repo}
+func NewCategoryService(
+	repo repository.CategoryRepository,
+	txRepo repository.TransactionRepository,
+	budgetRepo repository.BudgetRepository,
+	txManager repository.TransactionManager,
+) *CategoryService {
+	return &CategoryService{
+		repo:       repo,
+		txRepo:     txRepo,
+		budgetRepo: budgetRepo,
+		txManager:  txManager,
+	}
 }
 
 // Create membuat category baru.
@@ -64,6 +89,16 @@ func (s *CategoryService) GetByID(ctx context.Context, id uuid.UUID) (*models.Ca
 	return category, nil
 }
 
+// GetByName mengambil category berdasarkan nama (case-insensitive).
+// Error membungkus repository.ErrNotFound jika tidak ada.
+func (s *CategoryService) GetByName(ctx context.Context, name string) (*models.Category, error) {
+	category, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category %q: %w", name, err)
+	}
+	return category, nil
+}
+
 // GetByType mengambil kategori berdasarkan tipe.
 func (s *CategoryService) GetByType(ctx context.Context, catType models.CategoryType) ([]*models.Category, error) {
 	categories, err := s.repo.GetByType(ctx, catType)
@@ -137,12 +172,127 @@ func (s *CategoryService) Update(ctx context.Context, input UpdateCategoryInput)
 	return category, nil
 }
 
-// Delete menghapus category.
+// GetAdjustmentCategory mengambil kategori "Adjustment" untuk tipe tertentu.
+//
+// Kategori ini di-seed oleh migration 000009; jika sudah dihapus user,
+// kategori dibuat ulang agar koreksi saldo tetap terkategori.
+func (s *CategoryService) GetAdjustmentCategory(ctx context.Context, catType models.CategoryType) (*models.Category, error) {
+	categories, err := s.GetByType(ctx, catType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range categories {
+		if c.Name == models.AdjustmentCategoryName && !c.IsSubCategory() {
+			return c, nil
+		}
+	}
+
+	return s.Create(ctx, CreateCategoryInput{
+		Name:      models.AdjustmentCategoryName,
+		Type:      catType,
+		Icon:      "⚖️",
+		Color:     "#94A3B8",
+		SortOrder: 100,
+	})
+}
+
+// Usage menghitung transaksi dan budget yang memakai kategori.
+func (s *CategoryService) Usage(ctx context.Context, id uuid.UUID) (*CategoryUsage, error) {
+	transactions, err := s.txRepo.CountByCategory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	budgets, err := s.budgetRepo.List(ctx, repository.BudgetFilter{CategoryID: &id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	return &CategoryUsage{Transactions: transactions, Budgets: len(budgets)}, nil
+}
+
+// Delete menghapus category yang tidak dipakai transaksi maupun budget.
+//
+// Category di-hard delete: FK di database akan menghapus budget-nya
+// (ON DELETE CASCADE) dan mengosongkan kategori transaksinya, jadi
+// kategori yang masih dipakai ditolak dengan ErrCategoryInUse.
+// Pindahkan dulu lewat DeleteAndReassign.
 func (s *CategoryService) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("failed to delete category: %w", err)
+	return s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.GetByID(ctx, id); err != nil {
+			return fmt.Errorf("failed to get category: %w", err)
+		}
+
+		usage, err := s.Usage(ctx, id)
+		if err != nil {
+			return err
+		}
+		if usage.InUse() {
+			return fmt.Errorf("%w: %d transactions, %d budgets (reassign them to another category first)",
+				ErrCategoryInUse, usage.Transactions, usage.Budgets)
+		}
+
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete category: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteAndReassign memindahkan transaksi dan budget kategori id ke
+// kategori to, lalu menghapus kategori id. Semua dalam satu DB
+// transaction. Kategori to harus bertipe sama.
+//
+//	usage, err := categoryService.DeleteAndReassign(ctx, oldID, newID)
+//	// usage berisi jumlah transaksi dan budget yang dipindahkan
+func (s *CategoryService) DeleteAndReassign(ctx context.Context, id, to uuid.UUID) (*CategoryUsage, error) {
+	if id == to {
+		return nil, fmt.Errorf("%w: cannot reassign a category to itself", ErrInvalidReassign)
 	}
-	return nil
+
+	usage := &CategoryUsage{}
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		category, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get category: %w", err)
+		}
+		target, err := s.repo.GetByID(ctx, to)
+		if err != nil {
+			return fmt.Errorf("failed to get reassign category: %w", err)
+		}
+		if target.Type != category.Type {
+			return fmt.Errorf("%w: %s is %s, %s is %s",
+				ErrInvalidReassign, target.Name, target.Type, category.Name, category.Type)
+		}
+
+		moved, err := s.txRepo.ReassignCategory(ctx, id, to)
+		if err != nil {
+			return fmt.Errorf("failed to reassign transactions: %w", err)
+		}
+		usage.Transactions = int(moved)
+
+		budgets, err := s.budgetRepo.List(ctx, repository.BudgetFilter{CategoryID: &id})
+		if err != nil {
+			return fmt.Errorf("failed to list budgets: %w", err)
+		}
+		for _, b := range budgets {
+			b.CategoryID = to
+			if err := s.budgetRepo.Update(ctx, b); err != nil {
+				return fmt.Errorf("failed to reassign budget: %w", err)
+			}
+		}
+		usage.Budgets = len(budgets)
+
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete category: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
 }
 
 // CreateCategoryInput adalah input untuk membuat category.
@@ -164,6 +314,17 @@ type UpdateCategoryInput struct {
 	SortOrder *int
 }
 
+// CategoryUsage adalah jumlah data yang memakai satu kategori.
+type CategoryUsage struct {
+	Transactions int
+	Budgets      int
+}
+
+// InUse true jika kategori masih dipakai transaksi atau budget.
+func (u CategoryUsage) InUse() bool {
+	return u.Transactions > 0 || u.Budgets > 0
+}
+
 // CategoryWithChildren adalah category dengan sub-categories.
 type CategoryWithChildren struct {
 	Category *models.Category
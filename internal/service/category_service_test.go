@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func newTestCategoryService(repos *memoryRepos) *CategoryService {
+	return NewCategoryService(repos.category, repos.transaction, repos.budget, repos.txManager)
+}
+
+// createUsedCategory membuat kategori expense dengan satu transaksi dan satu
+// budget yang memakainya.
+func createUsedCategory(t *testing.T, repos *memoryRepos, name string) *models.Category {
+	t.Helper()
+	ctx := context.Background()
+
+	category := models.NewCategory(name, models.CategoryTypeExpense)
+	if err := repos.category.Create(ctx, category); err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+
+	wallet := repos.createWallet(t, "Cash "+name, 1000)
+	tx := models.NewTransaction(wallet.ID, models.TransactionTypeExpense, decimal.NewFromInt(100))
+	tx.CategoryID = &category.ID
+	if err := repos.transaction.Create(ctx, tx); err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if err := repos.budget.Create(ctx, models.NewBudget(category.ID, decimal.NewFromInt(500))); err != nil {
+		t.Fatalf("failed to create budget: %v", err)
+	}
+	return category
+}
+
+func TestCategoryService_Delete(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestCategoryService(repos)
+
+	used := createUsedCategory(t, repos, "Food")
+	err := svc.Delete(ctx, used.ID)
+	if !errors.Is(err, ErrCategoryInUse) {
+		t.Fatalf("Delete() used category error = %v, want ErrCategoryInUse", err)
+	}
+	if _, err := repos.category.GetByID(ctx, used.ID); err != nil {
+		t.Errorf("category removed after rejected delete: %v", err)
+	}
+
+	unused := models.NewCategory("Unused", models.CategoryTypeExpense)
+	if err := repos.category.Create(ctx, unused); err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	if err := svc.Delete(ctx, unused.ID); err != nil {
+		t.Fatalf("Delete() unused category error = %v", err)
+	}
+	if _, err := repos.category.GetByID(ctx, unused.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetByID() after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCategoryService_DeleteAndReassign(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestCategoryService(repos)
+
+	old := createUsedCategory(t, repos, "Eating Out")
+	target := models.NewCategory("Food", models.CategoryTypeExpense)
+	income := models.NewCategory("Salary", models.CategoryTypeIncome)
+	for _, c := range []*models.Category{target, income} {
+		if err := repos.category.Create(ctx, c); err != nil {
+			t.Fatalf("failed to create category: %v", err)
+		}
+	}
+
+	if _, err := svc.DeleteAndReassign(ctx, old.ID, old.ID); !errors.Is(err, ErrInvalidReassign) {
+		t.Errorf("DeleteAndReassign() to itself error = %v, want ErrInvalidReassign", err)
+	}
+	if _, err := svc.DeleteAndReassign(ctx, old.ID, income.ID); !errors.Is(err, ErrInvalidReassign) {
+		t.Errorf("DeleteAndReassign() to other type error = %v, want ErrInvalidReassign", err)
+	}
+
+	usage, err := svc.DeleteAndReassign(ctx, old.ID, target.ID)
+	if err != nil {
+		t.Fatalf("DeleteAndReassign() error = %v", err)
+	}
+	if usage.Transactions != 1 || usage.Budgets != 1 {
+		t.Errorf("usage = %+v, want 1 transaction and 1 budget moved", usage)
+	}
+
+	if _, err := repos.category.GetByID(ctx, old.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetByID() after delete error = %v, want ErrNotFound", err)
+	}
+	after, err := svc.Usage(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+	if after.Transactions != 1 || after.Budgets != 1 {
+		t.Errorf("target usage = %+v, want 1 transaction and 1 budget", after)
+	}
+}
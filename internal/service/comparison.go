@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// ComparisonService membandingkan income, expense, dan spending per
+// kategori antara dua periode sembarang. Hasilnya terstruktur supaya
+// bisa dirender oleh CLI maupun TUI.
+type ComparisonService struct {
+	txService *TransactionService
+}
+
+// NewComparisonService membuat instance baru ComparisonService.
+func NewComparisonService(txService *TransactionService) *ComparisonService {
+	return &ComparisonService{txService: txService}
+}
+
+// Compare menghitung perubahan dari periode a ke periode b. Filter
+// biasanya hanya berisi StartDate/EndDate; transaksi adjustment tidak
+// dihitung karena bukan income/expense sungguhan.
+//
+//	result, err := comparisonService.Compare(ctx, lastMonth, thisMonth)
+//	for _, c := range result.Categories {
+//	    fmt.Println(c.Name, c.Delta())
+//	}
+func (s *ComparisonService) Compare(ctx context.Context, a, b repository.TransactionFilter) (*PeriodComparison, error) {
+	a.ExcludeAdjustments = true
+	b.ExcludeAdjustments = true
+
+	summaryA, err := s.txService.GetSummary(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	summaryB, err := s.txService.GetSummary(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	expense := models.TransactionTypeExpense
+	a.Type, b.Type = &expense, &expense
+
+	categoriesA, err := s.txService.GetCategorySummary(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	categoriesB, err := s.txService.GetCategorySummary(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	netA := summaryA.TotalIncome.Sub(summaryA.TotalExpense)
+	netB := summaryB.TotalIncome.Sub(summaryB.TotalExpense)
+	return &PeriodComparison{
+		Income:     AmountChange{A: summaryA.TotalIncome, B: summaryB.TotalIncome},
+		Expense:    AmountChange{A: summaryA.TotalExpense, B: summaryB.TotalExpense},
+		Net:        AmountChange{A: netA, B: netB},
+		Categories: CompareCategories(categoriesA, categoriesB),
+	}, nil
+}
+
+// CompareCategories menggabungkan total per kategori dua periode.
+// Kategori yang nol di kedua periode dibuang; sisanya diurutkan dari
+// perubahan absolut terbesar, lalu nama.
+func CompareCategories(a, b []*repository.CategorySummary) []*CategoryChange {
+	byID := make(map[uuid.UUID]*CategoryChange)
+	var changes []*CategoryChange
+
+	get := func(s *repository.CategorySummary) *CategoryChange {
+		c, ok := byID[s.CategoryID]
+		if !ok {
+			c = &CategoryChange{CategoryID: s.CategoryID, Name: s.CategoryName}
+			byID[s.CategoryID] = c
+			changes = append(changes, c)
+		}
+		return c
+	}
+	for _, s := range a {
+		c := get(s)
+		c.A = c.A.Add(s.Total)
+	}
+	for _, s := range b {
+		c := get(s)
+		c.B = c.B.Add(s.Total)
+	}
+
+	kept := changes[:0]
+	for _, c := range changes {
+		if !c.A.IsZero() || !c.B.IsZero() {
+			kept = append(kept, c)
+		}
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		di, dj := kept[i].Delta().Abs(), kept[j].Delta().Abs()
+		if !di.Equal(dj) {
+			return di.GreaterThan(dj)
+		}
+		return kept[i].Name < kept[j].Name
+	})
+	return kept
+}
+
+// AmountChange adalah satu nilai di periode A dan periode B.
+type AmountChange struct {
+	A decimal.Decimal
+	B decimal.Decimal
+}
+
+// Delta adalah perubahan absolut dari A ke B (B - A).
+func (c AmountChange) Delta() decimal.Decimal {
+	return c.B.Sub(c.A)
+}
+
+// Percent adalah perubahan dalam persen terhadap A, dibulatkan 1
+// desimal. ok false jika A nol karena persentase tidak terdefinisi.
+// Pembagi memakai |A| supaya kenaikan dari nilai negatif (mis. net
+// defisit) tetap bertanda positif.
+func (c AmountChange) Percent() (pct decimal.Decimal, ok bool) {
+	if c.A.IsZero() {
+		return decimal.Zero, false
+	}
+	return c.Delta().Div(c.A.Abs()).Mul(decimal.NewFromInt(100)).Round(1), true
+}
+
+// PeriodComparison adalah hasil ComparisonService.Compare.
+type PeriodComparison struct {
+	Income  AmountChange
+	Expense AmountChange
+	Net     AmountChange
+
+	// Categories adalah spending per kategori expense, urut dari
+	// perubahan absolut terbesar.
+	Categories []*CategoryChange
+}
+
+// CategoryChange adalah spending satu kategori di kedua periode.
+type CategoryChange struct {
+	CategoryID uuid.UUID
+	Name       string
+	AmountChange
+}
+
+// IsNew mengecek apakah kategori hanya ada spending di periode B.
+func (c *CategoryChange) IsNew() bool {
+	return c.A.IsZero() && !c.B.IsZero()
+}
+
+// IsGone mengecek apakah kategori hanya ada spending di periode A.
+func (c *CategoryChange) IsGone() bool {
+	return !c.A.IsZero() && c.B.IsZero()
+}
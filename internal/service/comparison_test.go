@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestAmountChange_Percent(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    int64
+		want    string
+		wantOK  bool
+		wantDel int64
+	}{
+		{"increase", 200, 250, "25", true, 50},
+		{"decrease", 300, 100, "-66.7", true, -200},
+		{"unchanged", 100, 100, "0", true, 0},
+		{"zero in A", 0, 500, "0", false, 500},
+		{"both zero", 0, 0, "0", false, 0},
+		{"negative A improves", -100, 50, "150", true, 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := AmountChange{A: decimal.NewFromInt(tt.a), B: decimal.NewFromInt(tt.b)}
+			if got := c.Delta(); !got.Equal(decimal.NewFromInt(tt.wantDel)) {
+				t.Errorf("Delta() = %s, want %d", got, tt.wantDel)
+			}
+			pct, ok := c.Percent()
+			if ok != tt.wantOK || pct.String() != tt.want {
+				t.Errorf("Percent() = %s, %v, want %s, %v", pct, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCompareCategories(t *testing.T) {
+	food, bills, travel, gym, unused := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	summary := func(id uuid.UUID, name string, total int64) *repository.CategorySummary {
+		return &repository.CategorySummary{CategoryID: id, CategoryName: name, Total: decimal.NewFromInt(total)}
+	}
+
+	a := []*repository.CategorySummary{
+		summary(food, "Food", 500),
+		summary(bills, "Bills", 300),
+		summary(gym, "Gym", 100),
+		summary(unused, "Unused", 0),
+	}
+	b := []*repository.CategorySummary{
+		summary(food, "Food", 450),
+		summary(bills, "Bills", 400),
+		summary(travel, "Travel", 100),
+		summary(gym, "Gym", 0),
+		summary(unused, "Unused", 0),
+	}
+
+	changes := CompareCategories(a, b)
+
+	want := []struct {
+		name      string
+		delta     int64
+		new, gone bool
+	}{
+		{"Bills", 100, false, false},
+		{"Gym", -100, false, true},
+		{"Travel", 100, true, false},
+		{"Food", -50, false, false},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d categories, want %d (zero in both periods must be dropped)", len(changes), len(want))
+	}
+	for i, w := range want {
+		c := changes[i]
+		if c.Name != w.name || !c.Delta().Equal(decimal.NewFromInt(w.delta)) {
+			t.Errorf("changes[%d] = %s %s, want %s %d", i, c.Name, c.Delta(), w.name, w.delta)
+		}
+		if c.IsNew() != w.new || c.IsGone() != w.gone {
+			t.Errorf("%s: IsNew() = %v, IsGone() = %v, want %v, %v", c.Name, c.IsNew(), c.IsGone(), w.new, w.gone)
+		}
+	}
+}
+
+func TestComparisonService_Compare(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	txService := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 10000)
+
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	salary := models.NewCategory("Salary", models.CategoryTypeIncome)
+	for _, c := range []*models.Category{food, salary} {
+		if err := repos.category.Create(ctx, c); err != nil {
+			t.Fatalf("failed to create category: %v", err)
+		}
+	}
+
+	add := func(category *models.Category, txType models.TransactionType, amount int64, date time.Time) {
+		t.Helper()
+		if _, err := txService.Create(ctx, CreateTransactionInput{
+			WalletID:   wallet.ID,
+			CategoryID: &category.ID,
+			Type:       txType,
+			Amount:     decimal.NewFromInt(amount),
+			Date:       date,
+		}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	add(salary, models.TransactionTypeIncome, 1000, time.Date(2025, 12, 1, 9, 0, 0, 0, time.Local))
+	add(food, models.TransactionTypeExpense, 200, time.Date(2025, 12, 10, 9, 0, 0, 0, time.Local))
+	add(salary, models.TransactionTypeIncome, 1000, time.Date(2026, 1, 1, 9, 0, 0, 0, time.Local))
+	add(food, models.TransactionTypeExpense, 300, time.Date(2026, 1, 10, 9, 0, 0, 0, time.Local))
+
+	result, err := NewComparisonService(txService).Compare(ctx, txService.MonthFilter(2025, time.December), txService.MonthFilter(2026, time.January))
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	checks := []struct {
+		name string
+		got  AmountChange
+		a, b int64
+	}{
+		{"Income", result.Income, 1000, 1000},
+		{"Expense", result.Expense, 200, 300},
+		{"Net", result.Net, 800, 700},
+	}
+	for _, c := range checks {
+		if !c.got.A.Equal(decimal.NewFromInt(c.a)) || !c.got.B.Equal(decimal.NewFromInt(c.b)) {
+			t.Errorf("%s = %s -> %s, want %d -> %d", c.name, c.got.A, c.got.B, c.a, c.b)
+		}
+	}
+
+	if len(result.Categories) != 1 || result.Categories[0].Name != "Food" {
+		t.Fatalf("Categories = %+v, want only Food (income categories are not spending)", result.Categories)
+	}
+	if pct, ok := result.Categories[0].Percent(); !ok || pct.String() != "50" {
+		t.Errorf("Food Percent() = %s, %v, want 50, true", pct, ok)
+	}
+}
@@ -17,9 +17,18 @@
 // Contoh:
 //
 //	walletRepo := postgres.NewWalletRepository(pool)
-//	walletService := service.NewWalletService(walletRepo)
+//	walletService := service.NewWalletService(walletRepo, transferRepo, txManager)
 //
 //	// Dalam test
 //	mockRepo := &MockWalletRepository{}
-//	walletService := service.NewWalletService(mockRepo)
+//	walletService := service.NewWalletService(mockRepo, nil, nil)
+//
+// Transfer vs transaksi:
+//
+// Transfer (TransferService) memindahkan uang antar wallet milik user sendiri,
+// jadi bukan income maupun expense. Transfer disimpan di tabel transfers dan
+// TIDAK BOLEH muncul di ringkasan income/expense (GetSummary, GetCategoryStats,
+// budget). Hanya fee transfer yang mengurangi total saldo.
+// TransactionService menolak transaksi bertag "transfer" untuk mencegah
+// transfer dicatat sebagai sepasang income + expense.
 package service
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// Nama-nama check yang dijalankan DoctorService.
+const (
+	CheckOrphanContributions = "Orphaned goal contributions"
+	CheckMissingCategories   = "References to missing categories"
+	CheckMissingWallets      = "Transactions with missing wallets"
+	CheckGoalAmounts         = "Goal amounts out of sync"
+	CheckImportedCurrencies  = "Imports in another currency"
+)
+
+// DoctorService menjalankan pemeriksaan integritas data.
+//
+// Setiap check memakai query khusus di IntegrityRepository dan
+// menghasilkan daftar row bermasalah. Sebagian bisa diperbaiki otomatis
+// lewat Fix (lihat DoctorCheck.Fixable).
+type DoctorService struct {
+	integrityRepo repository.IntegrityRepository
+}
+
+// NewDoctorService membuat DoctorService baru.
+func NewDoctorService(integrityRepo repository.IntegrityRepository) *DoctorService {
+	return &DoctorService{integrityRepo: integrityRepo}
+}
+
+// Check menjalankan semua pemeriksaan integritas.
+//
+//	report, err := doctorService.Check(ctx)
+//	if report.TotalIssues() > 0 {
+//	    // tampilkan laporan
+//	}
+func (s *DoctorService) Check(ctx context.Context) (*DoctorReport, error) {
+	report := &DoctorReport{}
+
+	issueChecks := []struct {
+		name        string
+		description string
+		fixable     bool
+		find        func(context.Context) ([]*repository.IntegrityIssue, error)
+	}{
+		{
+			CheckOrphanContributions,
+			"Contributions whose goal no longer exists",
+			false,
+			s.integrityRepo.FindOrphanContributions,
+		},
+		{
+			CheckMissingCategories,
+			"Transactions, recurring and budgets pointing at a deleted category",
+			true,
+			s.integrityRepo.FindMissingCategoryRefs,
+		},
+		{
+			CheckMissingWallets,
+			"Transactions whose wallet no longer exists",
+			false,
+			s.integrityRepo.FindMissingWalletRefs,
+		},
+		{
+			CheckImportedCurrencies,
+			"Wallets holding imported transactions converted from another currency",
+			false,
+			s.integrityRepo.FindImportedCurrencyMismatches,
+		},
+	}
+
+	for _, c := range issueChecks {
+		issues, err := c.find(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", c.name, err)
+		}
+		report.Checks = append(report.Checks, &DoctorCheck{
+			Name:        c.name,
+			Description: c.description,
+			Fixable:     c.fixable,
+			Issues:      issues,
+		})
+	}
+
+	mismatches, err := s.integrityRepo.FindGoalAmountMismatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s: %w", CheckGoalAmounts, err)
+	}
+	report.Checks = append(report.Checks, &DoctorCheck{
+		Name:        CheckGoalAmounts,
+		Description: "Goals whose current amount differs from the sum of contributions",
+		Fixable:     true,
+		Mismatches:  mismatches,
+	})
+
+	return report, nil
+}
+
+// Fix menjalankan perbaikan yang aman:
+//   - category_id yang tidak valid di transactions/recurring di-set NULL
+//   - current_amount goal dihitung ulang dari kontribusi
+//
+// Orphan contributions, budgets tanpa kategori, dan transaksi tanpa wallet
+// tidak diperbaiki otomatis karena butuh keputusan user (hapus atau pindahkan).
+func (s *DoctorService) Fix(ctx context.Context) (*DoctorFixResult, error) {
+	result := &DoctorFixResult{}
+
+	cleared, err := s.integrityRepo.ClearMissingCategoryRefs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear missing categories: %w", err)
+	}
+	result.CategoryRefsCleared = cleared
+
+	recomputed, err := s.integrityRepo.RecomputeGoalAmounts(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to recompute goal amounts: %w", err)
+	}
+	result.GoalsRecomputed = recomputed
+
+	return result, nil
+}
+
+// DoctorReport adalah hasil semua pemeriksaan.
+type DoctorReport struct {
+	Checks []*DoctorCheck
+}
+
+// TotalIssues menghitung jumlah semua masalah yang ditemukan.
+func (r *DoctorReport) TotalIssues() int {
+	total := 0
+	for _, c := range r.Checks {
+		total += c.Count()
+	}
+	return total
+}
+
+// DoctorCheck adalah hasil satu pemeriksaan.
+type DoctorCheck struct {
+	Name        string
+	Description string
+
+	// Fixable true jika masalah bisa diperbaiki dengan `wallet doctor --fix`.
+	Fixable bool
+
+	// Issues diisi oleh check berbasis foreign key.
+	Issues []*repository.IntegrityIssue
+
+	// Mismatches diisi oleh check goal amount.
+	Mismatches []*repository.GoalAmountMismatch
+}
+
+// Count mengembalikan jumlah masalah di check ini.
+func (c *DoctorCheck) Count() int {
+	return len(c.Issues) + len(c.Mismatches)
+}
+
+// DoctorFixResult adalah ringkasan perbaikan yang dilakukan Fix.
+type DoctorFixResult struct {
+	CategoryRefsCleared int
+	GoalsRecomputed     int
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+)
+
+func TestDoctorService_CheckAndFix(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	goalRepo := memory.NewGoalRepository(store)
+	svc := NewDoctorService(memory.NewIntegrityRepository(store))
+	goalSvc := NewGoalService(goalRepo, memory.NewWalletRepository(store), memory.NewTransactionManager(store))
+
+	goal, _ := goalSvc.Create(ctx, CreateGoalInput{Name: "Laptop", TargetAmount: decimal.NewFromInt(1000)})
+	_ = goalSvc.AddContribution(ctx, goal.ID, AddContributionInput{Amount: decimal.NewFromInt(200)})
+
+	report, err := svc.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if report.TotalIssues() != 0 {
+		t.Fatalf("TotalIssues() on clean data = %d, want 0", report.TotalIssues())
+	}
+
+	// Simulasikan current_amount yang tidak sinkron
+	_ = goalRepo.UpdateCurrentAmount(ctx, goal.ID, decimal.NewFromInt(500))
+
+	report, _ = svc.Check(ctx)
+	if report.TotalIssues() != 1 {
+		t.Fatalf("TotalIssues() = %d, want 1", report.TotalIssues())
+	}
+	for _, check := range report.Checks {
+		if check.Count() > 0 && check.Name != CheckGoalAmounts {
+			t.Errorf("unexpected issues in %q", check.Name)
+		}
+	}
+
+	result, err := svc.Fix(ctx)
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if result.GoalsRecomputed != 1 || result.CategoryRefsCleared != 0 {
+		t.Errorf("Fix() = %+v, want 1 goal recomputed", result)
+	}
+
+	report, _ = svc.Check(ctx)
+	if report.TotalIssues() != 0 {
+		t.Errorf("TotalIssues() after fix = %d, want 0", report.TotalIssues())
+	}
+}
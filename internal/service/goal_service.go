@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -19,15 +20,46 @@ import (
 // - CRUD goals
 // - Add contributions
 // - Track progress
+// - Goal net worth: progress total saldo wallet menuju target
+// - Sweep surplus saldo wallet ke goal (AutoContributeFromSurplus)
 type GoalService struct {
-	goalRepo repository.GoalRepository
+	goalRepo   repository.GoalRepository
+	walletRepo repository.WalletRepository
+	txManager  repository.TransactionManager
 }
 
 // NewGoalService membuat GoalService baru.
-func NewGoalService(goalRepo repository.GoalRepository) *GoalService {
-	return &GoalService{goalRepo: goalRepo}
+func NewGoalService(
+	goalRepo repository.GoalRepository,
+	walletRepo repository.WalletRepository,
+	txManager repository.TransactionManager,
+) *GoalService {
+	return &GoalService{
+		goalRepo:   goalRepo,
+		walletRepo: walletRepo,
+		txManager:  txManager,
+	}
 }
 
+// Goal errors
+var (
+	// ErrNoActiveGoal dikembalikan jika tidak ada goal aktif yang bisa
+	// menerima kontribusi.
+	ErrNoActiveGoal = errors.New("no active goal to contribute to")
+
+	// ErrDuplicateGoalOrder dikembalikan jika goal yang sama muncul lebih
+	// dari sekali di Reorder.
+	ErrDuplicateGoalOrder = errors.New("goal listed more than once")
+
+	// ErrGoalModified dikembalikan Update jika goal masih diubah proses
+	// lain setelah update dicoba ulang.
+	ErrGoalModified = errors.New("the goal was modified by another process, please retry")
+
+	// ErrNetWorthContribution dikembalikan AddContribution untuk goal net
+	// worth, yang progress-nya mengikuti total saldo wallet.
+	ErrNetWorthContribution = errors.New("net worth goals follow your wallet balances and do not accept contributions")
+)
+
 // Create membuat goal baru.
 func (s *GoalService) Create(ctx context.Context, input CreateGoalInput) (*models.Goal, error) {
 	goal := models.NewGoal(input.Name, input.TargetAmount)
@@ -35,6 +67,9 @@ func (s *GoalService) Create(ctx context.Context, input CreateGoalInput) (*model
 	goal.Deadline = input.Deadline
 	goal.Color = input.Color
 	goal.Icon = input.Icon
+	if input.Kind != "" {
+		goal.Kind = input.Kind
+	}
 
 	if err := goal.Validate(); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -44,6 +79,9 @@ func (s *GoalService) Create(ctx context.Context, input CreateGoalInput) (*model
 		return nil, fmt.Errorf("failed to create goal: %w", err)
 	}
 
+	if err := s.fillNetWorth(ctx, goal); err != nil {
+		return nil, err
+	}
 	return goal, nil
 }
 
@@ -53,6 +91,9 @@ func (s *GoalService) GetByID(ctx context.Context, id uuid.UUID) (*models.Goal,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get goal: %w", err)
 	}
+	if err := s.fillNetWorth(ctx, goal); err != nil {
+		return nil, err
+	}
 	return goal, nil
 }
 
@@ -62,16 +103,60 @@ func (s *GoalService) List(ctx context.Context, filter repository.GoalFilter) ([
 	if err != nil {
 		return nil, fmt.Errorf("failed to list goals: %w", err)
 	}
+	if err := s.fillNetWorth(ctx, goals...); err != nil {
+		return nil, err
+	}
 	return goals, nil
 }
 
+// fillNetWorth mengisi CurrentAmount goal net worth dengan total saldo
+// wallet saat ini. Total hanya diambil jika ada goal net worth. Nilai ini
+// tidak disimpan: current_amount goal net worth di database tetap 0.
+func (s *GoalService) fillNetWorth(ctx context.Context, goals ...*models.Goal) error {
+	var total *decimal.Decimal
+	for _, g := range goals {
+		if !g.IsNetWorth() {
+			continue
+		}
+		if total == nil {
+			balance, err := s.walletRepo.GetTotalBalance(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get total balance: %w", err)
+			}
+			total = &balance
+		}
+		g.CurrentAmount = *total
+	}
+	return nil
+}
+
 // ListActive mengambil goal aktif.
 func (s *GoalService) ListActive(ctx context.Context) ([]*models.Goal, error) {
 	status := models.GoalStatusActive
 	return s.List(ctx, repository.GoalFilter{Status: &status})
 }
 
-// AddContribution menambahkan kontribusi ke goal.
+// GetCompletedGoals mengambil goal yang selesai (status completed) di
+// tahun year waktu lokal, terbaru dulu. Tanggal selesai adalah updated_at
+// goal.
+//
+//	goals, err := goalService.GetCompletedGoals(ctx, 2025)
+func (s *GoalService) GetCompletedGoals(ctx context.Context, year int) ([]*models.Goal, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(1, 0, 0).Add(-time.Nanosecond)
+
+	goals, err := s.goalRepo.ListCompletedInPeriod(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list completed goals: %w", err)
+	}
+	if err := s.fillNetWorth(ctx, goals...); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+// AddContribution menambahkan kontribusi ke goal. Goal net worth
+// ditolak dengan ErrNetWorthContribution.
 //
 // Contoh:
 //
@@ -87,6 +172,14 @@ func (s *GoalService) AddContribution(ctx context.Context, goalID uuid.UUID, inp
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	target, err := s.goalRepo.GetByID(ctx, goalID)
+	if err != nil {
+		return fmt.Errorf("failed to get goal: %w", err)
+	}
+	if target.IsNetWorth() {
+		return ErrNetWorthContribution
+	}
+
 	// AddContribution in repo also updates goal.current_amount
 	if err := s.goalRepo.AddContribution(ctx, contribution); err != nil {
 		return fmt.Errorf("failed to add contribution: %w", err)
@@ -99,13 +192,111 @@ func (s *GoalService) AddContribution(ctx context.Context, goalID uuid.UUID, inp
 	}
 
 	if goal.IsCompleted() && goal.Status == models.GoalStatusActive {
-		goal.Status = models.GoalStatusCompleted
-		_ = s.goalRepo.Update(ctx, goal)
+		_ = s.MarkCompleted(ctx, goalID)
 	}
 
 	return nil
 }
 
+// AutoContributeFromSurplus menyapu saldo wallet di atas keepBalance ke
+// goal aktif dengan prioritas tertinggi, lalu mengembalikan jumlah yang
+// dikontribusikan (0 jika tidak ada surplus).
+//
+// Prioritas: goal yang paling dekat selesai (progress tertinggi), lalu
+// deadline terdekat. Kontribusi dibatasi sisa target goal, jadi goal tidak
+// pernah lebih dari 100%.
+//
+// Uang benar-benar dipindahkan: saldo wallet dikurangi sebesar kontribusi
+// dalam satu database transaction, sehingga menjalankan ulang di bulan
+// yang sama tidak menyapu surplus dua kali.
+//
+//	amount, err := goalService.AutoContributeFromSurplus(ctx, walletID, decimal.NewFromInt(1000000))
+func (s *GoalService) AutoContributeFromSurplus(
+	ctx context.Context,
+	walletID uuid.UUID,
+	keepBalance decimal.Decimal,
+) (decimal.Decimal, error) {
+	if keepBalance.IsNegative() {
+		return decimal.Zero, errors.New("keep balance cannot be negative")
+	}
+
+	wallet, err := s.walletRepo.GetByID(ctx, walletID)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("wallet not found: %w", err)
+	}
+
+	surplus := wallet.Balance.Sub(keepBalance)
+	if !surplus.IsPositive() {
+		return decimal.Zero, nil
+	}
+
+	goals, err := s.ListActive(ctx)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	goal := priorityGoal(goals)
+	if goal == nil {
+		return decimal.Zero, ErrNoActiveGoal
+	}
+
+	amount := decimal.Min(surplus, goal.GetRemaining())
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.AddContribution(ctx, goal.ID, AddContributionInput{
+			Amount: amount,
+			Note:   fmt.Sprintf("Auto-contribute surplus from %s", wallet.Name),
+		}); err != nil {
+			return err
+		}
+
+		change := models.BalanceChange{Reason: models.BalanceReasonGoalContribution, ReferenceID: &goal.ID}
+		if err := s.walletRepo.UpdateBalance(ctx, wallet.ID, wallet.Balance.Sub(amount), change); err != nil {
+			return fmt.Errorf("failed to update balance: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return amount, nil
+}
+
+// priorityGoal memilih goal dengan prioritas tertinggi untuk auto-contribute:
+// progress tertinggi, lalu deadline terdekat (goal tanpa deadline terakhir).
+// Goal yang sudah mencapai target dan goal net worth dilewati. Return nil
+// jika tidak ada.
+func priorityGoal(goals []*models.Goal) *models.Goal {
+	var best *models.Goal
+	for _, g := range goals {
+		if g.IsCompleted() || g.IsNetWorth() {
+			continue
+		}
+		if best == nil || higherPriority(g, best) {
+			best = g
+		}
+	}
+	return best
+}
+
+// higherPriority mengecek apakah goal a lebih prioritas dari b.
+func higherPriority(a, b *models.Goal) bool {
+	if pa, pb := a.GetProgress(), b.GetProgress(); pa != pb {
+		return pa > pb
+	}
+	switch {
+	case a.Deadline == nil:
+		return false
+	case b.Deadline == nil:
+		return true
+	default:
+		return a.Deadline.Before(*b.Deadline)
+	}
+}
+
 // GetContributions mengambil history kontribusi.
 func (s *GoalService) GetContributions(
 	ctx context.Context,
@@ -121,22 +312,118 @@ func (s *GoalService) GetContributions(
 
 // GetProgress menghitung progress goal.
 func (s *GoalService) GetProgress(ctx context.Context, id uuid.UUID) (*GoalProgress, error) {
-	goal, err := s.goalRepo.GetByID(ctx, id)
+	goal, err := s.GetByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get goal: %w", err)
+		return nil, err
 	}
 
+	return NewGoalProgress(goal, time.Now()), nil
+}
+
+// NewGoalProgress menghitung progress dan proyeksi goal per waktu now.
+func NewGoalProgress(goal *models.Goal, now time.Time) *GoalProgress {
 	return &GoalProgress{
 		Goal:              goal,
 		Progress:          goal.GetProgress(),
 		Remaining:         goal.GetRemaining(),
 		IsCompleted:       goal.IsCompleted(),
 		DaysUntilDeadline: goal.DaysUntilDeadline(),
-	}, nil
+		OnTrack:           goal.IsOnTrack(now),
+		RequiredPerMonth:  goal.RequiredPerMonth(now),
+	}
+}
+
+// GoalForecastMonths adalah panjang maksimal proyeksi ForecastGoal.
+const GoalForecastMonths = 36
+
+// AverageMonthlyContribution menghitung rata-rata kontribusi per bulan:
+// total kontribusi dibagi jumlah bulan sejak bulan kontribusi pertama
+// sampai bulan now (termasuk keduanya). Nol jika belum ada kontribusi.
+func (s *GoalService) AverageMonthlyContribution(ctx context.Context, goalID uuid.UUID, now time.Time) (decimal.Decimal, error) {
+	total := decimal.Zero
+	var first time.Time
+	for offset := 0; ; offset += groupPageSize {
+		page, err := s.GetContributions(ctx, goalID, repository.ListParams{Limit: groupPageSize, Offset: offset})
+		if err != nil {
+			return decimal.Zero, err
+		}
+		for _, c := range page {
+			total = total.Add(c.Amount)
+			if first.IsZero() || c.CreatedAt.Before(first) {
+				first = c.CreatedAt
+			}
+		}
+		if len(page) < groupPageSize {
+			break
+		}
+	}
+	if first.IsZero() {
+		return decimal.Zero, nil
+	}
+
+	months := (now.Year()-first.Year())*12 + int(now.Month()-first.Month()) + 1
+	if months < 1 {
+		months = 1
+	}
+	return total.Div(decimal.NewFromInt(int64(months))).Round(2), nil
+}
+
+// ForecastGoal memproyeksikan saldo goal bulan demi bulan dengan
+// kontribusi tetap monthly, mulai bulan setelah now, sampai target
+// tercapai atau GoalForecastMonths bulan. Kosong jika goal sudah tercapai
+// atau monthly tidak positif.
+//
+//	for _, m := range service.ForecastGoal(goal, decimal.NewFromInt(500000), time.Now()) {
+//	    fmt.Println(m.Month.Format("Jan 2006"), m.Balance, m.Reached)
+//	}
+func ForecastGoal(goal *models.Goal, monthly decimal.Decimal, now time.Time) []*GoalForecastMonth {
+	if goal.IsCompleted() || !monthly.IsPositive() {
+		return nil
+	}
+
+	var months []*GoalForecastMonth
+	balance := goal.CurrentAmount
+	for i := 1; i <= GoalForecastMonths; i++ {
+		balance = balance.Add(monthly)
+		reached := balance.GreaterThanOrEqual(goal.TargetAmount)
+
+		progress := 100.0
+		if !reached && goal.TargetAmount.IsPositive() {
+			progress, _ = balance.Div(goal.TargetAmount).Mul(decimal.NewFromInt(100)).Float64()
+		}
+
+		months = append(months, &GoalForecastMonth{
+			Month:    time.Date(now.Year(), now.Month()+time.Month(i), 1, 0, 0, 0, 0, now.Location()),
+			Balance:  balance,
+			Progress: progress,
+			Reached:  reached,
+		})
+		if reached {
+			break
+		}
+	}
+	return months
 }
 
 // Update memperbarui goal.
+//
+// Sama seperti WalletService.Update, konflik version (misalnya kontribusi
+// masuk di tengah update) dicoba ulang sekali dengan goal yang dibaca
+// ulang; jika masih konflik, return ErrGoalModified.
 func (s *GoalService) Update(ctx context.Context, input UpdateGoalInput) (*models.Goal, error) {
+	for attempt := 0; ; attempt++ {
+		goal, err := s.update(ctx, input)
+		if !errors.Is(err, repository.ErrConflict) {
+			return goal, err
+		}
+		if attempt == conflictRetries {
+			return nil, ErrGoalModified
+		}
+	}
+}
+
+// update membaca goal, menerapkan input, lalu menyimpannya satu kali.
+func (s *GoalService) update(ctx context.Context, input UpdateGoalInput) (*models.Goal, error) {
 	goal, err := s.goalRepo.GetByID(ctx, input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get goal: %w", err)
@@ -203,6 +490,35 @@ func (s *GoalService) Cancel(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+// Reorder mengatur prioritas goal: orderedIDs[0] mendapat sort_order 1,
+// orderedIDs[1] sort_order 2, dan seterusnya. Semua update dilakukan dalam
+// satu database transaction; jika salah satu goal tidak ditemukan, tidak
+// ada yang berubah. Goal yang tidak ada di orderedIDs tidak diubah.
+//
+//	err := goalService.Reorder(ctx, []uuid.UUID{houseID, laptopID, holidayID})
+func (s *GoalService) Reorder(ctx context.Context, orderedIDs []uuid.UUID) error {
+	if len(orderedIDs) == 0 {
+		return errors.New("no goals to reorder")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(orderedIDs))
+	for _, id := range orderedIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: %s", ErrDuplicateGoalOrder, id)
+		}
+		seen[id] = true
+	}
+
+	return s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		for i, id := range orderedIDs {
+			if err := s.goalRepo.UpdateSortOrder(ctx, id, i+1); err != nil {
+				return fmt.Errorf("failed to reorder goal %s: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
 // CreateGoalInput adalah input untuk membuat goal.
 type CreateGoalInput struct {
 	Name         string
@@ -211,6 +527,9 @@ type CreateGoalInput struct {
 	Deadline     *time.Time
 	Color        string
 	Icon         string
+
+	// Kind kosong berarti models.GoalKindSavings.
+	Kind models.GoalKind
 }
 
 // UpdateGoalInput adalah input untuk update goal.
@@ -237,5 +556,15 @@ type GoalProgress struct {
 	Progress          float64         // Percentage (0-100)
 	Remaining         decimal.Decimal // Amount remaining
 	IsCompleted       bool
-	DaysUntilDeadline int // -1 if no deadline or past
+	DaysUntilDeadline int             // -1 if no deadline or past
+	OnTrack           bool            // See models.Goal.IsOnTrack
+	RequiredPerMonth  decimal.Decimal // Zero if no deadline or completed
+}
+
+// GoalForecastMonth adalah satu baris proyeksi ForecastGoal.
+type GoalForecastMonth struct {
+	Month    time.Time       // Tanggal 1 bulan proyeksi
+	Balance  decimal.Decimal // Saldo goal di akhir bulan
+	Progress float64         // Percentage (0-100)
+	Reached  bool            // Target tercapai di bulan ini
 }
@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestGoalService_AddContribution_CompletesGoal(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewGoalService(repos.goal, repos.wallet, repos.txManager)
+
+	goal, err := svc.Create(ctx, CreateGoalInput{
+		Name:         "Laptop",
+		TargetAmount: decimal.NewFromInt(1000),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := svc.AddContribution(ctx, goal.ID, AddContributionInput{Amount: decimal.NewFromInt(600)}); err != nil {
+		t.Fatalf("AddContribution() error = %v", err)
+	}
+
+	got, _ := svc.GetByID(ctx, goal.ID)
+	if got.Status != models.GoalStatusActive {
+		t.Errorf("Status after partial contribution = %s, want active", got.Status)
+	}
+
+	if err := svc.AddContribution(ctx, goal.ID, AddContributionInput{Amount: decimal.NewFromInt(400)}); err != nil {
+		t.Fatalf("AddContribution() error = %v", err)
+	}
+
+	got, _ = svc.GetByID(ctx, goal.ID)
+	if !got.CurrentAmount.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("CurrentAmount = %s, want 1000", got.CurrentAmount)
+	}
+	if got.Status != models.GoalStatusCompleted {
+		t.Errorf("Status = %s, want completed", got.Status)
+	}
+
+	contributions, err := svc.GetContributions(ctx, goal.ID, repository.ListParams{})
+	if err != nil {
+		t.Fatalf("GetContributions() error = %v", err)
+	}
+	if len(contributions) != 2 {
+		t.Errorf("GetContributions() returned %d, want 2", len(contributions))
+	}
+}
+
+func TestGoalService_AddContribution_Invalid(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewGoalService(repos.goal, repos.wallet, repos.txManager)
+
+	goal, _ := svc.Create(ctx, CreateGoalInput{Name: "Laptop", TargetAmount: decimal.NewFromInt(1000)})
+
+	if err := svc.AddContribution(ctx, goal.ID, AddContributionInput{Amount: decimal.Zero}); err == nil {
+		t.Error("AddContribution(0) should return error")
+	}
+	if err := svc.AddContribution(ctx, models.NewID(), AddContributionInput{Amount: decimal.NewFromInt(1)}); err == nil {
+		t.Error("AddContribution(unknown goal) should return error")
+	}
+}
+
+func TestGoalService_ListActive(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewGoalService(repos.goal, repos.wallet, repos.txManager)
+
+	active, _ := svc.Create(ctx, CreateGoalInput{Name: "Laptop", TargetAmount: decimal.NewFromInt(1000)})
+	cancelled, _ := svc.Create(ctx, CreateGoalInput{Name: "Car", TargetAmount: decimal.NewFromInt(5000)})
+	if err := svc.Cancel(ctx, cancelled.ID); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	goals, err := svc.ListActive(ctx)
+	if err != nil {
+		t.Fatalf("ListActive() error = %v", err)
+	}
+	if len(goals) != 1 || goals[0].ID != active.ID {
+		t.Errorf("ListActive() = %d goals, want only the active goal", len(goals))
+	}
+}
+
+func TestGoalService_Reorder(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewGoalService(repos.goal, repos.wallet, repos.txManager)
+
+	var ids []uuid.UUID
+	for _, name := range []string{"Laptop", "House", "Holiday"} {
+		g, err := svc.Create(ctx, CreateGoalInput{Name: name, TargetAmount: decimal.NewFromInt(1000)})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		ids = append(ids, g.ID)
+	}
+
+	// House, Holiday, Laptop
+	if err := svc.Reorder(ctx, []uuid.UUID{ids[1], ids[2], ids[0]}); err != nil {
+		t.Fatalf("Reorder() error = %v", err)
+	}
+
+	goals, err := svc.ListActive(ctx)
+	if err != nil {
+		t.Fatalf("ListActive() error = %v", err)
+	}
+	var names []string
+	for _, g := range goals {
+		names = append(names, g.Name)
+	}
+	if got := strings.Join(names, ","); got != "House,Holiday,Laptop" {
+		t.Errorf("ListActive() order = %s, want House,Holiday,Laptop", got)
+	}
+
+	t.Run("duplicate", func(t *testing.T) {
+		err := svc.Reorder(ctx, []uuid.UUID{ids[0], ids[0]})
+		if !errors.Is(err, ErrDuplicateGoalOrder) {
+			t.Errorf("Reorder() error = %v, want ErrDuplicateGoalOrder", err)
+		}
+	})
+
+	t.Run("unknown goal rolls back", func(t *testing.T) {
+		err := svc.Reorder(ctx, []uuid.UUID{ids[0], models.NewID()})
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Fatalf("Reorder() error = %v, want ErrNotFound", err)
+		}
+		laptop, _ := svc.GetByID(ctx, ids[0])
+		if laptop.SortOrder != 3 {
+			t.Errorf("Laptop sort_order = %d after failed reorder, want 3", laptop.SortOrder)
+		}
+	})
+}
+
+func TestGoalService_AutoContributeFromSurplus(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewGoalService(repos.goal, repos.wallet, repos.txManager)
+	wallet := repos.createWallet(t, "BCA", 1500)
+
+	laptop, _ := svc.Create(ctx, CreateGoalInput{Name: "Laptop", TargetAmount: decimal.NewFromInt(1000)})
+	trip, _ := svc.Create(ctx, CreateGoalInput{Name: "Trip", TargetAmount: decimal.NewFromInt(10000)})
+	if err := svc.AddContribution(ctx, laptop.ID, AddContributionInput{Amount: decimal.NewFromInt(800)}); err != nil {
+		t.Fatalf("AddContribution() error = %v", err)
+	}
+
+	// Surplus 500, tapi Laptop (80%) hanya butuh 200
+	amount, err := svc.AutoContributeFromSurplus(ctx, wallet.ID, decimal.NewFromInt(1000))
+	if err != nil {
+		t.Fatalf("AutoContributeFromSurplus() error = %v", err)
+	}
+	if !amount.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("amount = %s, want 200", amount)
+	}
+	got, _ := svc.GetByID(ctx, laptop.ID)
+	if got.Status != models.GoalStatusCompleted {
+		t.Errorf("Laptop status = %s, want completed", got.Status)
+	}
+	if balance := repos.balanceOf(t, wallet); !balance.Equal(decimal.NewFromInt(1300)) {
+		t.Errorf("wallet balance = %s, want 1300", balance)
+	}
+
+	// Sisa surplus 300 masuk ke goal berikutnya
+	amount, err = svc.AutoContributeFromSurplus(ctx, wallet.ID, decimal.NewFromInt(1000))
+	if err != nil {
+		t.Fatalf("AutoContributeFromSurplus() error = %v", err)
+	}
+	if !amount.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("amount = %s, want 300", amount)
+	}
+	got, _ = svc.GetByID(ctx, trip.ID)
+	if !got.CurrentAmount.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("Trip CurrentAmount = %s, want 300", got.CurrentAmount)
+	}
+
+	// Tidak ada surplus lagi
+	amount, err = svc.AutoContributeFromSurplus(ctx, wallet.ID, decimal.NewFromInt(1000))
+	if err != nil || !amount.IsZero() {
+		t.Errorf("AutoContributeFromSurplus() = %s, %v, want 0, nil", amount, err)
+	}
+}
+
+func TestGoalService_AutoContributeFromSurplus_NoGoal(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := NewGoalService(repos.goal, repos.wallet, repos.txManager)
+	wallet := repos.createWallet(t, "BCA", 1500)
+
+	_, err := svc.AutoContributeFromSurplus(context.Background(), wallet.ID, decimal.NewFromInt(1000))
+	if !errors.Is(err, ErrNoActiveGoal) {
+		t.Errorf("AutoContributeFromSurplus() error = %v, want ErrNoActiveGoal", err)
+	}
+	if balance := repos.balanceOf(t, wallet); !balance.Equal(decimal.NewFromInt(1500)) {
+		t.Errorf("wallet balance = %s, want unchanged 1500", balance)
+	}
+}
+
+// racingGoalRepo adalah racingWalletRepo untuk goal.
+type racingGoalRepo struct {
+	repository.GoalRepository
+	races      int
+	concurrent func()
+}
+
+func (r *racingGoalRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Goal, error) {
+	g, err := r.GoalRepository.GetByID(ctx, id)
+	if err == nil && r.races > 0 {
+		r.races--
+		r.concurrent()
+	}
+	return g, err
+}
+
+func TestGoalService_Update_Conflict(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		races   int
+		wantErr error
+	}{
+		{"concurrent contribution is kept", 1, nil},
+		{"conflict after retry", 2, ErrGoalModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newMemoryRepos()
+			goal := models.NewGoal("Laptop", decimal.NewFromInt(1000))
+			if err := repos.goal.Create(ctx, goal); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			racing := &racingGoalRepo{GoalRepository: repos.goal, races: tt.races}
+			racing.concurrent = func() {
+				c := models.NewContribution(goal.ID, decimal.NewFromInt(100))
+				if err := repos.goal.AddContribution(ctx, c); err != nil {
+					t.Fatalf("AddContribution() error = %v", err)
+				}
+			}
+			svc := NewGoalService(racing, repos.wallet, repos.txManager)
+
+			target := decimal.NewFromInt(2000)
+			_, err := svc.Update(ctx, UpdateGoalInput{ID: goal.ID, TargetAmount: &target})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+
+			got, _ := repos.goal.GetByID(ctx, goal.ID)
+			wantAmount := decimal.NewFromInt(int64(100 * tt.races))
+			if !got.CurrentAmount.Equal(wantAmount) {
+				t.Errorf("CurrentAmount = %s, want %s", got.CurrentAmount, wantAmount)
+			}
+			if updated := got.TargetAmount.Equal(target); updated != (tt.wantErr == nil) {
+				t.Errorf("TargetAmount = %s, updated = %v", got.TargetAmount, updated)
+			}
+		})
+	}
+}
+
+func TestGoalService_GetCompletedGoals(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewGoalService(repos.goal, repos.wallet, repos.txManager)
+
+	var completed *models.Goal
+	for _, name := range []string{"Laptop", "Holiday"} {
+		goal, err := svc.Create(ctx, CreateGoalInput{Name: name, TargetAmount: decimal.NewFromInt(1000)})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		completed = goal
+	}
+	if err := svc.MarkCompleted(ctx, completed.ID); err != nil {
+		t.Fatalf("MarkCompleted() error = %v", err)
+	}
+
+	year := time.Now().Year()
+	goals, err := svc.GetCompletedGoals(ctx, year)
+	if err != nil {
+		t.Fatalf("GetCompletedGoals() error = %v", err)
+	}
+	if len(goals) != 1 || goals[0].ID != completed.ID {
+		t.Errorf("GetCompletedGoals(%d) = %v, want only Holiday", year, goals)
+	}
+
+	goals, err = svc.GetCompletedGoals(ctx, year-1)
+	if err != nil || len(goals) != 0 {
+		t.Errorf("GetCompletedGoals(%d) = %v, %v; want none", year-1, goals, err)
+	}
+}
+
+func TestGoalService_AverageMonthlyContribution(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewGoalService(repos.goal, repos.wallet, repos.txManager)
+
+	goal, err := svc.Create(ctx, CreateGoalInput{Name: "Laptop", TargetAmount: decimal.NewFromInt(10000)})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	now := time.Date(2025, 3, 20, 0, 0, 0, 0, time.Local)
+	if avg, err := svc.AverageMonthlyContribution(ctx, goal.ID, now); err != nil || !avg.IsZero() {
+		t.Errorf("AverageMonthlyContribution() without contributions = %s, %v; want 0", avg, err)
+	}
+
+	// Januari sampai Maret: 900 dalam 3 bulan
+	for _, c := range []struct {
+		amount int64
+		date   time.Time
+	}{
+		{500, time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local)},
+		{400, time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)},
+	} {
+		contribution := models.NewContribution(goal.ID, decimal.NewFromInt(c.amount))
+		contribution.CreatedAt = c.date
+		if err := repos.goal.AddContribution(ctx, contribution); err != nil {
+			t.Fatalf("AddContribution() error = %v", err)
+		}
+	}
+
+	avg, err := svc.AverageMonthlyContribution(ctx, goal.ID, now)
+	if err != nil {
+		t.Fatalf("AverageMonthlyContribution() error = %v", err)
+	}
+	if !avg.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("AverageMonthlyContribution() = %s, want 300", avg)
+	}
+}
+
+func TestForecastGoal(t *testing.T) {
+	now := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+	goal := models.NewGoal("Laptop", decimal.NewFromInt(1000))
+	goal.CurrentAmount = decimal.NewFromInt(250)
+
+	months := ForecastGoal(goal, decimal.NewFromInt(300), now)
+	if len(months) != 3 {
+		t.Fatalf("got %d months, want 3 (250 + 3×300 >= 1000)", len(months))
+	}
+	if got := months[0]; !got.Month.Equal(time.Date(2025, 12, 1, 0, 0, 0, 0, time.Local)) || !got.Balance.Equal(decimal.NewFromInt(550)) || got.Progress != 55 || got.Reached {
+		t.Errorf("first month = %+v, want Dec 2025, 550, 55%%, not reached", got)
+	}
+	if last := months[2]; last.Month.Month() != time.February || !last.Reached || last.Progress != 100 {
+		t.Errorf("last month = %+v, want Feb 2026 reached at 100%%", last)
+	}
+
+	if months := ForecastGoal(goal, decimal.NewFromInt(1), now); len(months) != GoalForecastMonths || months[len(months)-1].Reached {
+		t.Errorf("slow forecast has %d months, want capped at %d without reaching the target", len(months), GoalForecastMonths)
+	}
+	if months := ForecastGoal(goal, decimal.Zero, now); months != nil {
+		t.Errorf("ForecastGoal() with no contribution = %v, want nil", months)
+	}
+}
+
+func TestGoalService_NetWorthGoal(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewGoalService(repos.goal, repos.wallet, repos.txManager)
+
+	bca := repos.createWallet(t, "BCA", 3000)
+	repos.createWallet(t, "Cash", 1000)
+
+	goal, err := svc.Create(ctx, CreateGoalInput{
+		Name:         "First 10k",
+		TargetAmount: decimal.NewFromInt(10000),
+		Kind:         models.GoalKindNetWorth,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !goal.CurrentAmount.Equal(decimal.NewFromInt(4000)) {
+		t.Errorf("CurrentAmount = %s, want the total balance 4000", goal.CurrentAmount)
+	}
+
+	// Saldo berubah: progress ikut berubah tanpa kontribusi
+	if err := repos.wallet.UpdateBalance(ctx, bca.ID, decimal.NewFromInt(4000), models.BalanceChange{Reason: models.BalanceReasonAdjustment}); err != nil {
+		t.Fatalf("UpdateBalance() error = %v", err)
+	}
+	progress, err := svc.GetProgress(ctx, goal.ID)
+	if err != nil {
+		t.Fatalf("GetProgress() error = %v", err)
+	}
+	if progress.Progress != 50 {
+		t.Errorf("Progress = %v, want 50", progress.Progress)
+	}
+
+	err = svc.AddContribution(ctx, goal.ID, AddContributionInput{Amount: decimal.NewFromInt(100)})
+	if !errors.Is(err, ErrNetWorthContribution) {
+		t.Errorf("AddContribution() error = %v, want ErrNetWorthContribution", err)
+	}
+
+	// Nilai live tidak disimpan
+	stored, err := repos.goal.GetByID(ctx, goal.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !stored.CurrentAmount.IsZero() {
+		t.Errorf("stored current_amount = %s, want 0", stored.CurrentAmount)
+	}
+
+	// Auto-contribute tidak memilih goal net worth
+	if _, err := svc.AutoContributeFromSurplus(ctx, bca.ID, decimal.Zero); !errors.Is(err, ErrNoActiveGoal) {
+		t.Errorf("AutoContributeFromSurplus() error = %v, want ErrNoActiveGoal", err)
+	}
+}
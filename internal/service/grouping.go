@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// GroupKey menentukan cara transaksi dikelompokkan di GroupTransactions.
+type GroupKey string
+
+const (
+	GroupByCategory GroupKey = "category"
+	GroupByWallet   GroupKey = "wallet"
+	GroupByDay      GroupKey = "day"
+)
+
+// groupPageSize adalah ukuran halaman saat ListGrouped membaca transaksi
+// (batas maksimal ListParams).
+const groupPageSize = 100
+
+// ErrInvalidGroupKey dikembalikan ParseGroupKey untuk key yang tidak dikenal.
+var ErrInvalidGroupKey = errors.New("group by must be category, wallet or day")
+
+// ParseGroupKey mem-parse nilai flag --group-by.
+func ParseGroupKey(s string) (GroupKey, error) {
+	switch key := GroupKey(s); key {
+	case GroupByCategory, GroupByWallet, GroupByDay:
+		return key, nil
+	}
+	return "", fmt.Errorf("%w, got %q", ErrInvalidGroupKey, s)
+}
+
+// TransactionGroup adalah transaksi dengan key yang sama.
+type TransactionGroup struct {
+	// Label adalah nama kategori/wallet, atau tanggal (YYYY-MM-DD)
+	// untuk GroupByDay.
+	Label string
+
+	// Day adalah tanggal group untuk GroupByDay; zero untuk key lain.
+	Day time.Time
+
+	// Transactions urut seperti input.
+	Transactions []*models.Transaction
+
+	// Income dan Expense adalah total per tipe.
+	Income  decimal.Decimal
+	Expense decimal.Decimal
+}
+
+// Subtotal adalah total amount group (income + expense).
+func (g *TransactionGroup) Subtotal() decimal.Decimal {
+	return g.Income.Add(g.Expense)
+}
+
+// GroupTransactions mengelompokkan transaksi per kategori, wallet, atau
+// hari. Hanya group yang punya transaksi yang dikembalikan.
+//
+// Group kategori dan wallet diurutkan dari Subtotal terbesar; group hari
+// dari tanggal terbaru, sama seperti `wallet tx list`. Jika toBase,
+// amount dijumlah dalam currency default (Transaction.AmountInBase),
+// sama seperti GetSummary untuk filter lintas wallet.
+//
+//	groups := service.GroupTransactions(txs, service.GroupByCategory, refs, false)
+//	for _, g := range groups {
+//	    fmt.Println(g.Label, g.Subtotal())
+//	}
+func GroupTransactions(txs []*models.Transaction, key GroupKey, refs *RefData, toBase bool) []*TransactionGroup {
+	byKey := make(map[string]*TransactionGroup)
+	var groups []*TransactionGroup
+
+	for _, tx := range txs {
+		id, label, day := groupOf(tx, key, refs)
+
+		g, ok := byKey[id]
+		if !ok {
+			g = &TransactionGroup{Label: label, Day: day}
+			byKey[id] = g
+			groups = append(groups, g)
+		}
+		g.Transactions = append(g.Transactions, tx)
+
+		amount := tx.Amount
+		if toBase {
+			amount = tx.AmountInBase()
+		}
+		switch tx.Type {
+		case models.TransactionTypeIncome:
+			g.Income = g.Income.Add(amount)
+		case models.TransactionTypeExpense:
+			g.Expense = g.Expense.Add(amount)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if key == GroupByDay {
+			return groups[i].Day.After(groups[j].Day)
+		}
+		if si, sj := groups[i].Subtotal(), groups[j].Subtotal(); !si.Equal(sj) {
+			return si.GreaterThan(sj)
+		}
+		return groups[i].Label < groups[j].Label
+	})
+	return groups
+}
+
+// groupOf mengembalikan id group (unik per key), label, dan tanggal
+// group untuk transaksi.
+func groupOf(tx *models.Transaction, key GroupKey, refs *RefData) (string, string, time.Time) {
+	switch key {
+	case GroupByWallet:
+		return tx.WalletID.String(), refs.WalletName(tx.WalletID), time.Time{}
+	case GroupByDay:
+		d := tx.TransactionDate
+		day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
+		label := day.Format("2006-01-02")
+		return label, label, day
+	default:
+		id := ""
+		if tx.CategoryID != nil {
+			id = tx.CategoryID.String()
+		}
+		return id, refs.CategoryName(tx.CategoryID), time.Time{}
+	}
+}
+
+// ListGrouped mengambil semua transaksi yang cocok dengan filter (tanpa
+// limit), mengelompokkannya dengan GroupTransactions, lalu menghitung
+// GetSummary untuk filter yang sama sebagai pembanding grand total.
+func (s *TransactionService) ListGrouped(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+	key GroupKey,
+	refs *RefData,
+) (*GroupedTransactions, error) {
+	var txs []*models.Transaction
+	for offset := 0; ; offset += groupPageSize {
+		page, err := s.List(ctx, filter, repository.ListParams{Limit: groupPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, page...)
+		if len(page) < groupPageSize {
+			break
+		}
+	}
+
+	summary, err := s.GetSummary(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GroupedTransactions{
+		Groups:  GroupTransactions(txs, key, refs, crossesWallets(filter)),
+		Count:   len(txs),
+		Summary: summary,
+	}
+	for _, g := range result.Groups {
+		result.Income = result.Income.Add(g.Income)
+		result.Expense = result.Expense.Add(g.Expense)
+	}
+	return result, nil
+}
+
+// GroupedTransactions adalah hasil ListGrouped.
+type GroupedTransactions struct {
+	Groups []*TransactionGroup
+
+	// Income, Expense, dan Count adalah grand total dari semua group.
+	Income  decimal.Decimal
+	Expense decimal.Decimal
+	Count   int
+
+	// Summary adalah GetSummary untuk filter yang sama.
+	Summary *repository.TransactionSummary
+}
+
+// MatchesSummary mengecek grand total terhadap Summary. Jika berbeda,
+// List dan GetSummary menerapkan filter secara berbeda.
+func (g *GroupedTransactions) MatchesSummary() bool {
+	return g.Count == g.Summary.Count &&
+		g.Income.Equal(g.Summary.TotalIncome) &&
+		g.Expense.Equal(g.Summary.TotalExpense)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestParseGroupKey(t *testing.T) {
+	for _, s := range []string{"category", "wallet", "day"} {
+		if key, err := ParseGroupKey(s); err != nil || string(key) != s {
+			t.Errorf("ParseGroupKey(%q) = %q, %v", s, key, err)
+		}
+	}
+	if _, err := ParseGroupKey("month"); !errors.Is(err, ErrInvalidGroupKey) {
+		t.Errorf("ParseGroupKey(month) error = %v, want ErrInvalidGroupKey", err)
+	}
+}
+
+func TestGroupTransactions(t *testing.T) {
+	bca := models.NewWallet("BCA", models.WalletTypeBank)
+	cash := models.NewWallet("Cash", models.WalletTypeCash)
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	bills := models.NewCategory("Bills", models.CategoryTypeExpense)
+	unused := models.NewCategory("Travel", models.CategoryTypeExpense)
+	refs := NewRefData([]*models.Wallet{bca, cash}, []*models.Category{food, bills, unused})
+
+	jan := func(day int) time.Time { return time.Date(2026, 1, day, 0, 0, 0, 0, time.Local) }
+	newTx := func(wallet *models.Wallet, category *models.Category, txType models.TransactionType, amount int64, date time.Time) *models.Transaction {
+		tx := models.NewTransaction(wallet.ID, txType, decimal.NewFromInt(amount))
+		if category != nil {
+			tx.CategoryID = &category.ID
+		}
+		tx.TransactionDate = date
+		return tx
+	}
+	txs := []*models.Transaction{
+		newTx(cash, food, models.TransactionTypeExpense, 50, jan(3)),
+		newTx(bca, bills, models.TransactionTypeExpense, 300, jan(2)),
+		newTx(cash, food, models.TransactionTypeExpense, 70, jan(2)),
+		newTx(bca, nil, models.TransactionTypeIncome, 1000, jan(1)),
+	}
+
+	type want struct {
+		label   string
+		count   int
+		income  int64
+		expense int64
+	}
+	tests := []struct {
+		key  GroupKey
+		want []want
+	}{
+		{GroupByCategory, []want{
+			{UncategorizedName, 1, 1000, 0},
+			{"Bills", 1, 0, 300},
+			{"Food", 2, 0, 120},
+		}},
+		{GroupByWallet, []want{
+			{"BCA", 2, 1000, 300},
+			{"Cash", 2, 0, 120},
+		}},
+		{GroupByDay, []want{
+			{"2026-01-03", 1, 0, 50},
+			{"2026-01-02", 2, 0, 370},
+			{"2026-01-01", 1, 1000, 0},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.key), func(t *testing.T) {
+			groups := GroupTransactions(txs, tt.key, refs, false)
+			if len(groups) != len(tt.want) {
+				t.Fatalf("got %d groups, want %d (empty groups must not appear)", len(groups), len(tt.want))
+			}
+			for i, w := range tt.want {
+				g := groups[i]
+				if g.Label != w.label || len(g.Transactions) != w.count ||
+					!g.Income.Equal(decimal.NewFromInt(w.income)) || !g.Expense.Equal(decimal.NewFromInt(w.expense)) {
+					t.Errorf("groups[%d] = %s: %d tx, income %s, expense %s; want %+v",
+						i, g.Label, len(g.Transactions), g.Income, g.Expense, w)
+				}
+			}
+		})
+	}
+
+	if groups := GroupTransactions(nil, GroupByCategory, refs, false); len(groups) != 0 {
+		t.Errorf("GroupTransactions(nil) = %d groups, want none", len(groups))
+	}
+}
+
+func TestTransactionService_ListGrouped(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 0)
+
+	// Lebih dari satu halaman List
+	for i := 0; i < groupPageSize+5; i++ {
+		if _, err := svc.Create(ctx, CreateTransactionInput{
+			WalletID: wallet.ID,
+			Type:     models.TransactionTypeIncome,
+			Amount:   decimal.NewFromInt(10),
+		}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	refs, err := LoadRefData(ctx, repos.wallet, repos.category)
+	if err != nil {
+		t.Fatalf("LoadRefData() error = %v", err)
+	}
+	grouped, err := svc.ListGrouped(ctx, repository.TransactionFilter{}, GroupByWallet, refs)
+	if err != nil {
+		t.Fatalf("ListGrouped() error = %v", err)
+	}
+
+	if grouped.Count != groupPageSize+5 || len(grouped.Groups) != 1 {
+		t.Errorf("ListGrouped() = %d tx in %d groups, want %d in 1", grouped.Count, len(grouped.Groups), groupPageSize+5)
+	}
+	if !grouped.MatchesSummary() {
+		t.Errorf("grand total (%s, %d tx) does not match summary %+v", grouped.Income, grouped.Count, grouped.Summary)
+	}
+
+	grouped.Summary.Count++
+	if grouped.MatchesSummary() {
+		t.Error("MatchesSummary() = true after the summary count changed")
+	}
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// InvestmentService menangani wallet bertipe investment.
+//
+// Saldo wallet investment adalah nilai pasar. Perubahan nilai pasar
+// dicatat sebagai transaksi revaluation (tag models.TagRevaluation +
+// models.TagAdjustment) sehingga:
+//   - saldo wallet selalu sama dengan nilai terakhir
+//   - laporan income/expense tidak terpengaruh
+//   - modal yang disetor bisa dipisahkan dari gain/loss
+type InvestmentService struct {
+	walletRepo   repository.WalletRepository
+	txRepo       repository.TransactionRepository
+	transferRepo repository.TransferRepository
+	txManager    repository.TransactionManager
+}
+
+// NewInvestmentService membuat InvestmentService baru.
+func NewInvestmentService(
+	walletRepo repository.WalletRepository,
+	txRepo repository.TransactionRepository,
+	transferRepo repository.TransferRepository,
+	txManager repository.TransactionManager,
+) *InvestmentService {
+	return &InvestmentService{
+		walletRepo:   walletRepo,
+		txRepo:       txRepo,
+		transferRepo: transferRepo,
+		txManager:    txManager,
+	}
+}
+
+var (
+	// ErrNotInvestmentWallet dikembalikan jika wallet bukan tipe investment.
+	ErrNotInvestmentWallet = errors.New("wallet is not an investment wallet")
+
+	// ErrValueUnchanged dikembalikan Revalue jika nilai baru sama dengan saldo.
+	ErrValueUnchanged = errors.New("wallet value already matches")
+)
+
+// Revalue mencatat nilai pasar terbaru wallet investment.
+//
+// Selisih terhadap saldo sekarang dicatat sebagai transaksi revaluation
+// (income jika naik, expense jika turun) lalu saldo di-set ke Value,
+// dalam satu DB transaction.
+//
+//	tx, err := investmentService.Revalue(ctx, service.RevalueInput{
+//	    WalletID: fundID,
+//	    Value:    decimal.NewFromInt(15250000),
+//	    Note:     "NAV update",
+//	})
+func (s *InvestmentService) Revalue(ctx context.Context, input RevalueInput) (*models.Transaction, error) {
+	if input.Value.IsNegative() {
+		return nil, errors.New("value cannot be negative")
+	}
+
+	wallet, err := s.investmentWallet(ctx, input.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !wallet.IsActive {
+		return nil, errors.New("cannot revalue inactive wallet")
+	}
+
+	diff := input.Value.Sub(wallet.Balance)
+	if diff.IsZero() {
+		return nil, ErrValueUnchanged
+	}
+
+	txType := models.TransactionTypeIncome
+	if diff.IsNegative() {
+		txType = models.TransactionTypeExpense
+	}
+
+	transaction := models.NewTransaction(wallet.ID, txType, diff.Abs())
+	transaction.Description = input.Note
+	if transaction.Description == "" {
+		transaction.Description = fmt.Sprintf("Revaluation: %s -> %s",
+			wallet.Balance.StringFixed(2), input.Value.StringFixed(2))
+	}
+	transaction.AddTag(models.TagRevaluation)
+	transaction.AddTag(models.TagAdjustment)
+	if !input.Date.IsZero() {
+		transaction.TransactionDate = input.Date
+	}
+
+	if err := transaction.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.txRepo.Create(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to create revaluation: %w", err)
+		}
+
+		change := models.BalanceChange{Reason: models.BalanceReasonRevaluation, ReferenceID: &transaction.ID}
+		if err := s.walletRepo.UpdateBalance(ctx, wallet.ID, input.Value, change); err != nil {
+			return fmt.Errorf("failed to update balance: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// GetPerformance menghitung modal yang disetor dan gain/loss wallet investment
+// dari seluruh history transaksi dan transfer wallet.
+//
+// Lihat computeInvestmentPerformance untuk aturan perhitungannya.
+func (s *InvestmentService) GetPerformance(ctx context.Context, walletID uuid.UUID) (*InvestmentPerformance, error) {
+	wallet, err := s.investmentWallet(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.entries(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	perf := computeInvestmentPerformance(wallet.Balance, entries)
+	perf.WalletID = walletID
+	return perf, nil
+}
+
+// investmentWallet mengambil wallet dan memastikan tipenya investment.
+func (s *InvestmentService) investmentWallet(ctx context.Context, id uuid.UUID) (*models.Wallet, error) {
+	wallet, err := s.walletRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("wallet not found: %w", err)
+	}
+	if wallet.Type != models.WalletTypeInvestment {
+		return nil, ErrNotInvestmentWallet
+	}
+	return wallet, nil
+}
+
+// investmentListPageSize adalah ukuran halaman saat membaca seluruh history
+// (maksimal yang diizinkan ListParams).
+const investmentListPageSize = 100
+
+// entries membaca semua transaksi dan transfer wallet sebagai investmentEntry.
+func (s *InvestmentService) entries(ctx context.Context, walletID uuid.UUID) ([]investmentEntry, error) {
+	var entries []investmentEntry
+
+	for offset := 0; ; offset += investmentListPageSize {
+		txs, err := s.txRepo.List(ctx, repository.TransactionFilter{WalletID: &walletID},
+			repository.ListParams{Limit: investmentListPageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list transactions: %w", err)
+		}
+
+		for _, tx := range txs {
+			entry := investmentEntry{date: tx.TransactionDate, createdAt: tx.CreatedAt, amount: tx.Amount}
+			if tx.Type == models.TransactionTypeExpense {
+				entry.amount = entry.amount.Neg()
+			}
+			entry.revaluation = tx.IsRevaluation()
+			entries = append(entries, entry)
+		}
+
+		if len(txs) < investmentListPageSize {
+			break
+		}
+	}
+
+	if s.transferRepo == nil {
+		return entries, nil
+	}
+
+	for offset := 0; ; offset += investmentListPageSize {
+		transfers, err := s.transferRepo.List(ctx, repository.TransferFilter{WalletID: &walletID},
+			repository.ListParams{Limit: investmentListPageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list transfers: %w", err)
+		}
+
+		for _, t := range transfers {
+			entry := investmentEntry{date: t.CreatedAt, createdAt: t.CreatedAt}
+			if t.ToWalletID == walletID {
+				entry.amount = t.ReceivedAmount()
+			} else {
+				entry.amount = t.Amount.Add(t.Fee).Neg()
+			}
+			entries = append(entries, entry)
+		}
+
+		if len(transfers) < investmentListPageSize {
+			break
+		}
+	}
+
+	return entries, nil
+}
+
+// investmentEntry adalah satu perubahan saldo wallet investment.
+type investmentEntry struct {
+	// date adalah tanggal efektif (TransactionDate untuk transaksi,
+	// CreatedAt untuk transfer); createdAt dipakai sebagai tie-breaker.
+	date      time.Time
+	createdAt time.Time
+
+	// amount bertanda: positif menambah saldo, negatif mengurangi.
+	amount decimal.Decimal
+
+	// revaluation true untuk perubahan nilai pasar.
+	revaluation bool
+}
+
+// computeInvestmentPerformance me-replay history wallet investment.
+//
+// Saldo awal (saldo saat wallet dibuat) dihitung mundur dari saldo sekarang
+// dan dianggap setoran modal. Lalu entry diproses urut tanggal:
+//   - setoran (income/transfer masuk): modal dan nilai bertambah
+//   - revaluation: hanya nilai yang berubah
+//   - penarikan (expense/transfer keluar): modal berkurang proporsional
+//     terhadap porsi nilai yang ditarik (average cost). Selisih antara
+//     jumlah ditarik dan modal yang ikut keluar menjadi RealizedGain.
+//
+// Contoh: setor 1.000, naik ke 1.500, tarik 300 (20% nilai)
+// → modal 800, nilai 1.200, unrealized gain 400, realized gain 100.
+func computeInvestmentPerformance(balance decimal.Decimal, entries []investmentEntry) *InvestmentPerformance {
+	sorted := make([]investmentEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		di, dj := truncateDay(sorted[i].date), truncateDay(sorted[j].date)
+		if !di.Equal(dj) {
+			return di.Before(dj)
+		}
+		return sorted[i].createdAt.Before(sorted[j].createdAt)
+	})
+
+	opening := balance
+	for _, e := range sorted {
+		opening = opening.Sub(e.amount)
+	}
+
+	perf := &InvestmentPerformance{}
+	contributed := opening
+	value := opening
+
+	for _, e := range sorted {
+		switch {
+		case e.revaluation:
+			value = value.Add(e.amount)
+			perf.Revaluations++
+			date := e.date
+			perf.LastRevaluation = &date
+
+		case e.amount.IsPositive():
+			contributed = contributed.Add(e.amount)
+			value = value.Add(e.amount)
+
+		default:
+			withdrawn := e.amount.Neg()
+			cost := contributed
+			if value.GreaterThan(withdrawn) {
+				cost = contributed.Mul(withdrawn).Div(value)
+			}
+			contributed = contributed.Sub(cost)
+			value = value.Sub(withdrawn)
+			perf.RealizedGain = perf.RealizedGain.Add(withdrawn.Sub(cost))
+		}
+	}
+
+	perf.Contributed = contributed.Round(2)
+	perf.CurrentValue = balance
+	perf.UnrealizedGain = balance.Sub(perf.Contributed)
+	perf.RealizedGain = perf.RealizedGain.Round(2)
+	if perf.Contributed.IsPositive() {
+		perf.GainPercent, _ = perf.UnrealizedGain.Div(perf.Contributed).Mul(decimal.NewFromInt(100)).Float64()
+	}
+
+	return perf
+}
+
+// truncateDay memotong t ke awal hari (timezone t).
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// InvestmentPerformance adalah ringkasan modal vs gain/loss wallet investment.
+type InvestmentPerformance struct {
+	WalletID uuid.UUID
+
+	// Contributed adalah modal yang masih tertanam (setoran dikurangi
+	// porsi modal yang sudah ditarik).
+	Contributed decimal.Decimal
+
+	// CurrentValue adalah nilai pasar terakhir (= saldo wallet).
+	CurrentValue decimal.Decimal
+
+	// UnrealizedGain = CurrentValue - Contributed (negatif jika rugi).
+	UnrealizedGain decimal.Decimal
+
+	// GainPercent adalah UnrealizedGain dalam persen dari Contributed.
+	GainPercent float64
+
+	// RealizedGain adalah gain/loss yang sudah ditarik keluar wallet.
+	RealizedGain decimal.Decimal
+
+	// Revaluations adalah jumlah revaluation yang tercatat.
+	Revaluations int
+
+	// LastRevaluation adalah tanggal revaluation terakhir (nil jika belum ada).
+	LastRevaluation *time.Time
+}
+
+// RevalueInput adalah input untuk Revalue.
+type RevalueInput struct {
+	WalletID uuid.UUID
+
+	// Value adalah nilai pasar terbaru wallet.
+	Value decimal.Decimal
+
+	// Note adalah keterangan, misalnya "NAV update".
+	Note string
+
+	// Date adalah tanggal valuasi (default: sekarang).
+	Date time.Time
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestComputeInvestmentPerformance(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.Local) }
+	dec := decimal.NewFromInt
+
+	tests := []struct {
+		name           string
+		balance        int64
+		entries        []investmentEntry
+		wantContrib    int64
+		wantGain       int64
+		wantRealized   int64
+		wantPercent    float64
+		wantRevaluates int
+	}{
+		{
+			name:        "no history",
+			balance:     1000,
+			wantContrib: 1000,
+		},
+		{
+			name:    "multiple revaluations",
+			balance: 1150,
+			entries: []investmentEntry{
+				{date: day(2), amount: dec(300), revaluation: true},
+				{date: day(3), amount: dec(-150), revaluation: true},
+			},
+			wantContrib:    1000,
+			wantGain:       150,
+			wantPercent:    15,
+			wantRevaluates: 2,
+		},
+		{
+			name:    "withdrawal takes cost proportionally",
+			balance: 1200,
+			entries: []investmentEntry{
+				{date: day(2), amount: dec(500), revaluation: true},
+				{date: day(3), amount: dec(-300)},
+			},
+			wantContrib:    800,
+			wantGain:       400,
+			wantRealized:   100,
+			wantPercent:    50,
+			wantRevaluates: 1,
+		},
+		{
+			name:    "contributions, loss, withdrawal, recovery",
+			balance: 1950,
+			entries: []investmentEntry{
+				// Diproses urut tanggal, bukan urutan slice
+				{date: day(5), amount: dec(750), revaluation: true},
+				{date: day(2), amount: dec(1000)},
+				{date: day(3), amount: dec(-400), revaluation: true},
+				{date: day(4), amount: dec(-400)},
+			},
+			// opening 1000, +1000 → 2000/2000, -400 → 2000/1600,
+			// tarik 400 (25%) → modal 1500, nilai 1200, realized -100,
+			// +750 → nilai 1950
+			wantContrib:    1500,
+			wantGain:       450,
+			wantRealized:   -100,
+			wantPercent:    30,
+			wantRevaluates: 2,
+		},
+		{
+			name:    "full withdrawal",
+			balance: 0,
+			entries: []investmentEntry{
+				{date: day(2), amount: dec(200), revaluation: true},
+				{date: day(3), amount: dec(-1200)},
+			},
+			wantContrib:    0,
+			wantGain:       0,
+			wantRealized:   200,
+			wantRevaluates: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			perf := computeInvestmentPerformance(dec(tt.balance), tt.entries)
+
+			if !perf.Contributed.Equal(dec(tt.wantContrib)) {
+				t.Errorf("Contributed = %s, want %d", perf.Contributed, tt.wantContrib)
+			}
+			if !perf.CurrentValue.Equal(dec(tt.balance)) {
+				t.Errorf("CurrentValue = %s, want %d", perf.CurrentValue, tt.balance)
+			}
+			if !perf.UnrealizedGain.Equal(dec(tt.wantGain)) {
+				t.Errorf("UnrealizedGain = %s, want %d", perf.UnrealizedGain, tt.wantGain)
+			}
+			if !perf.RealizedGain.Equal(dec(tt.wantRealized)) {
+				t.Errorf("RealizedGain = %s, want %d", perf.RealizedGain, tt.wantRealized)
+			}
+			if perf.GainPercent != tt.wantPercent {
+				t.Errorf("GainPercent = %v, want %v", perf.GainPercent, tt.wantPercent)
+			}
+			if perf.Revaluations != tt.wantRevaluates {
+				t.Errorf("Revaluations = %d, want %d", perf.Revaluations, tt.wantRevaluates)
+			}
+		})
+	}
+}
+
+func TestInvestmentService_Revalue(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewInvestmentService(repos.wallet, repos.transaction, repos.transfer, repos.txManager)
+	txSvc := newTestTransactionService(repos)
+	transferSvc := NewTransferService(repos.transfer, repos.wallet, repos.txManager)
+
+	fund := models.NewWallet("Reksa Dana", models.WalletTypeInvestment)
+	fund.Balance = decimal.NewFromInt(10000000)
+	if err := repos.wallet.Create(ctx, fund); err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	bank := repos.createWallet(t, "BCA", 5000000)
+
+	// Setor 2jt lewat transfer, lalu dua kali update NAV
+	if _, err := transferSvc.Create(ctx, CreateTransferInput{
+		FromWalletID: bank.ID,
+		ToWalletID:   fund.ID,
+		Amount:       decimal.NewFromInt(2000000),
+	}); err != nil {
+		t.Fatalf("transfer error = %v", err)
+	}
+	for _, value := range []int64{12500000, 13200000} {
+		if _, err := svc.Revalue(ctx, RevalueInput{WalletID: fund.ID, Value: decimal.NewFromInt(value), Note: "NAV update"}); err != nil {
+			t.Fatalf("Revalue(%d) error = %v", value, err)
+		}
+	}
+
+	if got := repos.balanceOf(t, fund); !got.Equal(decimal.NewFromInt(13200000)) {
+		t.Errorf("balance = %s, want 13200000", got)
+	}
+
+	perf, err := svc.GetPerformance(ctx, fund.ID)
+	if err != nil {
+		t.Fatalf("GetPerformance() error = %v", err)
+	}
+	if !perf.Contributed.Equal(decimal.NewFromInt(12000000)) {
+		t.Errorf("Contributed = %s, want 12000000", perf.Contributed)
+	}
+	if !perf.UnrealizedGain.Equal(decimal.NewFromInt(1200000)) {
+		t.Errorf("UnrealizedGain = %s, want 1200000", perf.UnrealizedGain)
+	}
+	if perf.GainPercent != 10 {
+		t.Errorf("GainPercent = %v, want 10", perf.GainPercent)
+	}
+
+	// Revaluation tidak boleh muncul di laporan income/expense
+	summary, err := txSvc.GetSummary(ctx, repository.TransactionFilter{ExcludeAdjustments: true})
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if !summary.TotalIncome.IsZero() || !summary.TotalExpense.IsZero() {
+		t.Errorf("summary = income %s, expense %s; want revaluations excluded", summary.TotalIncome, summary.TotalExpense)
+	}
+
+	if _, err := svc.Revalue(ctx, RevalueInput{WalletID: fund.ID, Value: decimal.NewFromInt(13200000)}); !errors.Is(err, ErrValueUnchanged) {
+		t.Errorf("Revalue() with same value error = %v, want ErrValueUnchanged", err)
+	}
+	if _, err := svc.Revalue(ctx, RevalueInput{WalletID: bank.ID, Value: decimal.NewFromInt(1)}); !errors.Is(err, ErrNotInvestmentWallet) {
+		t.Errorf("Revalue() on bank wallet error = %v, want ErrNotInvestmentWallet", err)
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// LegacyTransferService mengubah transfer lama, yang dulu dicatat
+// sebagai sepasang transaksi expense/income bertag models.TagTransfer
+// (sebelum ada model Transfer), menjadi record Transfer.
+//
+// Saldo wallet tidak berubah: expense di wallet sumber dan income di
+// wallet tujuan sudah memindahkan uang yang sama seperti transfer tanpa
+// fee.
+type LegacyTransferService struct {
+	txRepo       repository.TransactionRepository
+	transferRepo repository.TransferRepository
+	walletRepo   repository.WalletRepository
+	txManager    repository.TransactionManager
+}
+
+// NewLegacyTransferService membuat instance baru LegacyTransferService.
+func NewLegacyTransferService(
+	txRepo repository.TransactionRepository,
+	transferRepo repository.TransferRepository,
+	walletRepo repository.WalletRepository,
+	txManager repository.TransactionManager,
+) *LegacyTransferService {
+	return &LegacyTransferService{
+		txRepo:       txRepo,
+		transferRepo: transferRepo,
+		walletRepo:   walletRepo,
+		txManager:    txManager,
+	}
+}
+
+// Find mencari pasangan transaksi transfer lama. Expense dan income
+// berpasangan jika keduanya bertag models.TagTransfer, amount sama,
+// tanggal (hari) sama, dan wallet-nya berbeda dengan currency yang sama.
+// Setiap transaksi hanya dipakai satu kali; yang lebih awal dipasangkan
+// dulu. Transaksi bertag transfer yang tidak punya pasangan dikembalikan
+// di Unmatched dan tidak disentuh Convert.
+func (s *LegacyTransferService) Find(ctx context.Context) (*LegacyTransferScan, error) {
+	filter := repository.TransactionFilter{Tags: []string{models.TagTransfer}}
+
+	var txs []*models.Transaction
+	for offset := 0; ; offset += groupPageSize {
+		page, err := s.txRepo.List(ctx, filter, repository.ListParams{Limit: groupPageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list transfer transactions: %w", err)
+		}
+		txs = append(txs, page...)
+		if len(page) < groupPageSize {
+			break
+		}
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].TransactionDate.Before(txs[j].TransactionDate)
+	})
+
+	currencies := make(map[uuid.UUID]string)
+	currencyOf := func(id uuid.UUID) (string, error) {
+		if c, ok := currencies[id]; ok {
+			return c, nil
+		}
+		w, err := s.walletRepo.GetByID(ctx, id)
+		if err != nil {
+			return "", fmt.Errorf("failed to get wallet: %w", err)
+		}
+		currencies[id] = w.Currency
+		return w.Currency, nil
+	}
+
+	scan := &LegacyTransferScan{}
+	used := make(map[uuid.UUID]bool)
+	for _, expense := range txs {
+		if expense.Type != models.TransactionTypeExpense {
+			continue
+		}
+		for _, income := range txs {
+			if used[income.ID] || !isLegacyTransferPair(expense, income) {
+				continue
+			}
+			from, err := currencyOf(expense.WalletID)
+			if err != nil {
+				return nil, err
+			}
+			to, err := currencyOf(income.WalletID)
+			if err != nil {
+				return nil, err
+			}
+			if !strings.EqualFold(from, to) {
+				continue
+			}
+
+			used[expense.ID], used[income.ID] = true, true
+			scan.Pairs = append(scan.Pairs, &LegacyTransferPair{Expense: expense, Income: income})
+			break
+		}
+	}
+
+	for _, tx := range txs {
+		if !used[tx.ID] {
+			scan.Unmatched = append(scan.Unmatched, tx)
+		}
+	}
+	return scan, nil
+}
+
+// isLegacyTransferPair mengecek syarat pasangan selain currency wallet.
+func isLegacyTransferPair(expense, income *models.Transaction) bool {
+	if income.Type != models.TransactionTypeIncome || income.WalletID == expense.WalletID {
+		return false
+	}
+	if !income.Amount.Equal(expense.Amount) {
+		return false
+	}
+	ey, em, ed := expense.TransactionDate.Date()
+	iy, im, id := income.TransactionDate.Date()
+	return ey == iy && em == im && ed == id
+}
+
+// Convert membuat satu Transfer per pasangan lalu menghapus kedua
+// transaksinya, semua dalam satu database transaction. Transfer memakai
+// tanggal dan description transaksi expense sebagai CreatedAt dan Note.
+//
+//	scan, err := legacyService.Find(ctx)
+//	transfers, err := legacyService.Convert(ctx, scan.Pairs)
+func (s *LegacyTransferService) Convert(ctx context.Context, pairs []*LegacyTransferPair) ([]*models.Transfer, error) {
+	transfers := make([]*models.Transfer, 0, len(pairs))
+	for _, p := range pairs {
+		transfer := models.NewTransfer(p.Expense.WalletID, p.Income.WalletID, p.Expense.Amount)
+		transfer.CreatedAt = p.Expense.TransactionDate
+		transfer.Note = p.Expense.Description
+		if transfer.Note == "" {
+			transfer.Note = p.Income.Description
+		}
+		if err := transfer.Validate(); err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		for i, p := range pairs {
+			if err := s.transferRepo.Create(ctx, transfers[i]); err != nil {
+				return fmt.Errorf("failed to create transfer: %w", err)
+			}
+			if err := s.txRepo.Delete(ctx, p.Expense.ID); err != nil {
+				return fmt.Errorf("failed to delete transaction %s: %w", p.Expense.ID, err)
+			}
+			if err := s.txRepo.Delete(ctx, p.Income.ID); err != nil {
+				return fmt.Errorf("failed to delete transaction %s: %w", p.Income.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// LegacyTransferPair adalah expense di wallet sumber dan income di
+// wallet tujuan yang bersama-sama mencatat satu transfer.
+type LegacyTransferPair struct {
+	Expense *models.Transaction
+	Income  *models.Transaction
+}
+
+// LegacyTransferScan adalah hasil LegacyTransferService.Find.
+type LegacyTransferScan struct {
+	Pairs []*LegacyTransferPair
+
+	// Unmatched adalah transaksi bertag transfer tanpa pasangan.
+	Unmatched []*models.Transaction
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestLegacyTransferService_FindAndConvert(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	bca := repos.createWallet(t, "BCA", 1000)
+	cash := repos.createWallet(t, "Cash", 1000)
+
+	day := time.Date(2023, 5, 10, 9, 0, 0, 0, time.Local)
+	legacy := func(wallet *models.Wallet, txType models.TransactionType, amount int64, date time.Time, tags ...string) *models.Transaction {
+		t.Helper()
+		tx := models.NewTransaction(wallet.ID, txType, decimal.NewFromInt(amount))
+		tx.Description = "ATM withdrawal"
+		tx.TransactionDate = date
+		tx.Tags = tags
+		if err := repos.transaction.Create(ctx, tx); err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+		return tx
+	}
+
+	out := legacy(bca, models.TransactionTypeExpense, 200, day, models.TagTransfer)
+	in := legacy(cash, models.TransactionTypeIncome, 200, day.Add(3*time.Hour), models.TagTransfer)
+	// Beda hari: tidak dipasangkan
+	lonely := legacy(bca, models.TransactionTypeExpense, 50, day, models.TagTransfer)
+	legacy(cash, models.TransactionTypeIncome, 50, day.AddDate(0, 0, 1), models.TagTransfer)
+	// Tanpa tag: bukan transfer lama
+	untagged := legacy(bca, models.TransactionTypeExpense, 200, day)
+
+	svc := NewLegacyTransferService(repos.transaction, repos.transfer, repos.wallet, repos.txManager)
+	scan, err := svc.Find(ctx)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(scan.Pairs) != 1 || scan.Pairs[0].Expense.ID != out.ID || scan.Pairs[0].Income.ID != in.ID {
+		t.Fatalf("Pairs = %+v, want the BCA -> Cash pair", scan.Pairs)
+	}
+	if len(scan.Unmatched) != 2 {
+		t.Errorf("len(Unmatched) = %d, want 2 (different days)", len(scan.Unmatched))
+	}
+
+	transfers, err := svc.Convert(ctx, scan.Pairs)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if len(transfers) != 1 {
+		t.Fatalf("got %d transfers, want 1", len(transfers))
+	}
+
+	saved, err := repos.transfer.GetByID(ctx, transfers[0].ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if saved.FromWalletID != bca.ID || saved.ToWalletID != cash.ID || !saved.Amount.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("transfer = %s -> %s %s, want BCA -> Cash 200", saved.FromWalletID, saved.ToWalletID, saved.Amount)
+	}
+	if !saved.CreatedAt.Equal(day) || saved.Note != "ATM withdrawal" {
+		t.Errorf("transfer CreatedAt = %v, Note = %q; want the legacy date and description", saved.CreatedAt, saved.Note)
+	}
+
+	for _, tx := range []*models.Transaction{out, in} {
+		if _, err := repos.transaction.GetByID(ctx, tx.ID); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("legacy transaction %s still exists (err = %v)", tx.ID, err)
+		}
+	}
+	for _, tx := range []*models.Transaction{lonely, untagged} {
+		if _, err := repos.transaction.GetByID(ctx, tx.ID); err != nil {
+			t.Errorf("unrelated transaction %s removed: %v", tx.ID, err)
+		}
+	}
+
+	// Saldo tidak berubah: uangnya sudah dipindahkan oleh transaksi lama
+	if got := repos.balanceOf(t, bca); !got.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("BCA balance = %s, want unchanged 1000", got)
+	}
+}
+
+func TestLegacyTransferService_Find_SkipsDifferentCurrencies(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	idr := repos.createWallet(t, "BCA", 1000)
+
+	usd := models.NewWallet("Wise", models.WalletTypeBank)
+	usd.Currency = "USD"
+	if err := repos.wallet.Create(ctx, usd); err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	day := time.Date(2023, 5, 10, 9, 0, 0, 0, time.Local)
+	for _, tx := range []*models.Transaction{
+		models.NewTransaction(idr.ID, models.TransactionTypeExpense, decimal.NewFromInt(100)),
+		models.NewTransaction(usd.ID, models.TransactionTypeIncome, decimal.NewFromInt(100)),
+	} {
+		tx.TransactionDate = day
+		tx.Tags = []string{models.TagTransfer}
+		if err := repos.transaction.Create(ctx, tx); err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+	}
+
+	scan, err := NewLegacyTransferService(repos.transaction, repos.transfer, repos.wallet, repos.txManager).Find(ctx)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(scan.Pairs) != 0 || len(scan.Unmatched) != 2 {
+		t.Errorf("Pairs = %d, Unmatched = %d; want 0 and 2 (a transfer across currencies needs a rate)", len(scan.Pairs), len(scan.Unmatched))
+	}
+}
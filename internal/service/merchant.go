@@ -0,0 +1,89 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// TopMerchantsLimit adalah jumlah merchant di laporan bulanan.
+const TopMerchantsLimit = 10
+
+// MerchantSummary adalah total pengeluaran di satu merchant.
+type MerchantSummary struct {
+	// Name adalah description yang sudah dinormalisasi (NormalizeMerchant).
+	Name string
+
+	// Total adalah jumlah expense.
+	Total decimal.Decimal
+
+	// Count adalah jumlah transaksi.
+	Count int
+}
+
+// merchantSuffixPattern mencocokkan kata di akhir description yang biasa
+// ditambahkan bank: nomor referensi dan tanggal/jam ("0423", "#1234",
+// "04/23", "2024-01-05", "12:30").
+var merchantSuffixPattern = regexp.MustCompile(`^[#*]?\d[\d/.:-]*$`)
+
+// NormalizeMerchant mengubah description transaksi menjadi nama merchant:
+// lowercase, spasi dirapikan, dan nomor/tanggal di akhir dibuang supaya
+// mutasi bank seperti "INDOMARET 0423" dan "INDOMARET 0817" tergabung.
+// Kata pertama tidak pernah dibuang ("7-Eleven", "711" tetap utuh).
+//
+//	NormalizeMerchant("INDOMARET 0423")        // "indomaret"
+//	NormalizeMerchant("Grab* 12345 05/01/24")  // "grab"
+//	NormalizeMerchant("Alfamart 24 Jam")       // "alfamart 24 jam"
+func NormalizeMerchant(description string) string {
+	words := strings.Fields(strings.ToLower(description))
+	for len(words) > 1 {
+		last := strings.Trim(words[len(words)-1], "-")
+		if last != "" && !merchantSuffixPattern.MatchString(last) {
+			break
+		}
+		words = words[:len(words)-1]
+	}
+
+	// Pemisah yang tersisa setelah nomor dibuang ("grab*", "shell -")
+	return strings.TrimRight(strings.Join(words, " "), " -*#:")
+}
+
+// TopMerchants menggabungkan totals per merchant (NormalizeMerchant) dan
+// mengembalikan maksimal limit merchant dengan total terbesar.
+// Description yang kosong setelah dinormalisasi tidak dihitung.
+func TopMerchants(totals []*repository.DescriptionTotal, limit int) []*MerchantSummary {
+	byName := make(map[string]*MerchantSummary)
+	var merchants []*MerchantSummary
+
+	for _, t := range totals {
+		name := NormalizeMerchant(t.Description)
+		if name == "" {
+			continue
+		}
+
+		m, ok := byName[name]
+		if !ok {
+			m = &MerchantSummary{Name: name}
+			byName[name] = m
+			merchants = append(merchants, m)
+		}
+		m.Total = m.Total.Add(t.Total)
+		m.Count += t.Count
+	}
+
+	sort.SliceStable(merchants, func(i, j int) bool {
+		if !merchants[i].Total.Equal(merchants[j].Total) {
+			return merchants[i].Total.GreaterThan(merchants[j].Total)
+		}
+		return merchants[i].Name < merchants[j].Name
+	})
+
+	if limit > 0 && len(merchants) > limit {
+		merchants = merchants[:limit]
+	}
+	return merchants
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestNormalizeMerchant(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"INDOMARET 0423", "indomaret"},
+		{"INDOMARET 0817", "indomaret"},
+		{"  Indomaret   Raya  0423 ", "indomaret raya"},
+		{"Grab* 12345 05/01/24", "grab"},
+		{"SHELL 2024-01-05 12:30", "shell"},
+		{"Tokopedia #88123", "tokopedia"},
+		{"Alfamart 24 Jam", "alfamart 24 jam"},
+		{"Starbucks - 0412", "starbucks"},
+		{"7-Eleven", "7-eleven"},
+		{"711", "711"},
+		{"", ""},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeMerchant(tt.description); got != tt.want {
+			t.Errorf("NormalizeMerchant(%q) = %q, want %q", tt.description, got, tt.want)
+		}
+	}
+}
+
+func TestTopMerchants(t *testing.T) {
+	total := func(description string, amount int64, count int) *repository.DescriptionTotal {
+		return &repository.DescriptionTotal{Description: description, Total: decimal.NewFromInt(amount), Count: count}
+	}
+
+	merchants := TopMerchants([]*repository.DescriptionTotal{
+		total("shell 0101", 300, 1),
+		total("indomaret 0423", 200, 2),
+		total("indomaret 0817", 150, 1),
+		total("0423", 50, 1),
+		total("cafe", 10, 1),
+	}, 2)
+
+	if len(merchants) != 2 {
+		t.Fatalf("got %d merchants, want 2 (limit)", len(merchants))
+	}
+	if m := merchants[0]; m.Name != "indomaret" || !m.Total.Equal(decimal.NewFromInt(350)) || m.Count != 3 {
+		t.Errorf("merchants[0] = %s %s (%d), want indomaret 350 (3)", m.Name, m.Total, m.Count)
+	}
+	if merchants[1].Name != "shell" {
+		t.Errorf("merchants[1] = %s, want shell", merchants[1].Name)
+	}
+
+	if got := TopMerchants(nil, TopMerchantsLimit); len(got) != 0 {
+		t.Errorf("TopMerchants(nil) = %v, want empty", got)
+	}
+}
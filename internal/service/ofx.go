@@ -0,0 +1,193 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrUnsupportedOFX dikembalikan untuk file OFX 1.x (SGML, diawali
+// "OFXHEADER:"), yang tidak bisa dibaca sebagai XML.
+var ErrUnsupportedOFX = errors.New("OFX 1.x (SGML) files are not supported; download the statement as OFX 2.x (XML)")
+
+// OFXStatement adalah isi file OFX: currency rekening dan transaksinya
+// (STMTTRN dari rekening bank maupun kartu kredit).
+type OFXStatement struct {
+	// Currency dari CURDEF, kosong jika tidak ada.
+	Currency     string
+	Transactions []OFXTransaction
+}
+
+// OFXTransaction adalah satu STMTTRN.
+type OFXTransaction struct {
+	// FITID adalah ID transaksi dari bank, unik per rekening.
+	FITID string
+
+	// Type adalah TRNTYPE apa adanya (DEBIT, CREDIT, FEE, ...).
+	Type string
+
+	// Posted dari DTPOSTED.
+	Posted time.Time
+
+	// Amount dari TRNAMT: positif uang masuk, negatif uang keluar.
+	Amount decimal.Decimal
+
+	Name string
+	Memo string
+}
+
+// Description mengembalikan NAME, atau MEMO jika NAME kosong.
+func (t OFXTransaction) Description() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return t.Memo
+}
+
+// ofxStmtTrn adalah element STMTTRN di XML.
+type ofxStmtTrn struct {
+	TrnType  string `xml:"TRNTYPE"`
+	DtPosted string `xml:"DTPOSTED"`
+	TrnAmt   string `xml:"TRNAMT"`
+	FITID    string `xml:"FITID"`
+	Name     string `xml:"NAME"`
+	Memo     string `xml:"MEMO"`
+}
+
+// ParseOFX membaca file OFX 2.x (XML). Struktur di atas STMTTRN tidak
+// dicek, jadi statement bank (BANKMSGSRSV1) dan kartu kredit
+// (CREDITCARDMSGSRSV1) sama-sama bisa dibaca. Transaksi yang FITID,
+// DTPOSTED atau TRNAMT-nya tidak valid membuat seluruh file ditolak.
+//
+//	statement, err := service.ParseOFX(file)
+func ParseOFX(r io.Reader) (*OFXStatement, error) {
+	br := bufio.NewReader(r)
+	if head, _ := br.Peek(64); bytes.HasPrefix(bytes.TrimLeft(head, "\ufeff \t\r\n"), []byte("OFXHEADER:")) {
+		return nil, ErrUnsupportedOFX
+	}
+
+	decoder := xml.NewDecoder(br)
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// ASCII adalah subset UTF-8; charset lain tidak didukung
+		if strings.EqualFold(charset, "us-ascii") || strings.EqualFold(charset, "ascii") {
+			return input, nil
+		}
+		return nil, fmt.Errorf("unsupported OFX encoding %q", charset)
+	}
+
+	statement := &OFXStatement{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid OFX file: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "CURDEF":
+			var currency string
+			if err := decoder.DecodeElement(&currency, &start); err != nil {
+				return nil, fmt.Errorf("invalid OFX file: %w", err)
+			}
+			statement.Currency = strings.ToUpper(strings.TrimSpace(currency))
+
+		case "STMTTRN":
+			var raw ofxStmtTrn
+			if err := decoder.DecodeElement(&raw, &start); err != nil {
+				return nil, fmt.Errorf("invalid OFX file: %w", err)
+			}
+			tx, err := raw.parse()
+			if err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", len(statement.Transactions)+1, err)
+			}
+			statement.Transactions = append(statement.Transactions, tx)
+		}
+	}
+
+	return statement, nil
+}
+
+// parse mengubah STMTTRN mentah menjadi OFXTransaction.
+func (raw ofxStmtTrn) parse() (OFXTransaction, error) {
+	tx := OFXTransaction{
+		FITID: strings.TrimSpace(raw.FITID),
+		Type:  strings.TrimSpace(raw.TrnType),
+		Name:  strings.TrimSpace(raw.Name),
+		Memo:  strings.TrimSpace(raw.Memo),
+	}
+	if tx.FITID == "" {
+		return tx, errors.New("missing FITID")
+	}
+
+	posted, err := parseOFXDate(raw.DtPosted)
+	if err != nil {
+		return tx, fmt.Errorf("FITID %s: %w", tx.FITID, err)
+	}
+	tx.Posted = posted
+
+	amount, err := decimal.NewFromString(strings.TrimSpace(raw.TrnAmt))
+	if err != nil {
+		return tx, fmt.Errorf("FITID %s: invalid TRNAMT %q", tx.FITID, raw.TrnAmt)
+	}
+	tx.Amount = amount
+
+	return tx, nil
+}
+
+// parseOFXDate mem-parse tanggal OFX: YYYYMMDD, opsional diikuti
+// HHMMSS, .XXX (milidetik) dan [offset:nama zona]. Tanpa zona,
+// tanggal dianggap waktu lokal.
+//
+//	parseOFXDate("20240115")                       // 15 Jan 2024 00:00 lokal
+//	parseOFXDate("20240115093000.000[+7:WIB]")     // 15 Jan 2024 09:30 +07:00
+func parseOFXDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	value, zone, hasZone := strings.Cut(s, "[")
+
+	loc := time.Local
+	if hasZone {
+		offsetStr, name, _ := strings.Cut(strings.TrimSuffix(zone, "]"), ":")
+		offset, err := strconv.ParseFloat(offsetStr, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid DTPOSTED %q", s)
+		}
+		if name == "" {
+			name = "UTC" + offsetStr
+		}
+		loc = time.FixedZone(name, int(offset*3600))
+	}
+
+	value, _, _ = strings.Cut(value, ".")
+	var layout string
+	switch len(value) {
+	case 8:
+		layout = "20060102"
+	case 12:
+		layout = "200601021504"
+	case 14:
+		layout = "20060102150405"
+	default:
+		return time.Time{}, fmt.Errorf("invalid DTPOSTED %q", s)
+	}
+
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid DTPOSTED %q", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const testOFX = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <BANKMSGSRSV1>
+    <STMTTRNRS>
+      <STMTRS>
+        <CURDEF>idr</CURDEF>
+        <BANKTRANLIST>
+          <DTSTART>20240101</DTSTART>
+          <DTEND>20240131</DTEND>
+          <STMTTRN>
+            <TRNTYPE>CREDIT</TRNTYPE>
+            <DTPOSTED>20240105</DTPOSTED>
+            <TRNAMT>5000000.00</TRNAMT>
+            <FITID>TX-001</FITID>
+            <NAME>GAJI JANUARI</NAME>
+          </STMTTRN>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20240107093000.000[+7:WIB]</DTPOSTED>
+            <TRNAMT>-45000.50</TRNAMT>
+            <FITID>TX-002</FITID>
+            <MEMO>INDOMARET 0423</MEMO>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </STMTRS>
+    </STMTTRNRS>
+  </BANKMSGSRSV1>
+</OFX>
+`
+
+func TestParseOFX(t *testing.T) {
+	statement, err := ParseOFX(strings.NewReader(testOFX))
+	if err != nil {
+		t.Fatalf("ParseOFX() error = %v", err)
+	}
+
+	if statement.Currency != "IDR" {
+		t.Errorf("Currency = %q, want IDR", statement.Currency)
+	}
+	if len(statement.Transactions) != 2 {
+		t.Fatalf("got %d transactions, want 2", len(statement.Transactions))
+	}
+
+	salary := statement.Transactions[0]
+	if salary.FITID != "TX-001" || salary.Type != "CREDIT" || salary.Description() != "GAJI JANUARI" {
+		t.Errorf("transactions[0] = %+v", salary)
+	}
+	if !salary.Amount.Equal(decimal.NewFromInt(5000000)) {
+		t.Errorf("transactions[0].Amount = %s, want 5000000", salary.Amount)
+	}
+
+	shop := statement.Transactions[1]
+	if !shop.Amount.Equal(decimal.RequireFromString("-45000.50")) {
+		t.Errorf("transactions[1].Amount = %s, want -45000.50", shop.Amount)
+	}
+	if shop.Description() != "INDOMARET 0423" {
+		t.Errorf("transactions[1].Description() = %q, want MEMO when NAME is empty", shop.Description())
+	}
+	want := time.Date(2024, 1, 7, 2, 30, 0, 0, time.UTC)
+	if !shop.Posted.Equal(want) {
+		t.Errorf("transactions[1].Posted = %s, want %s", shop.Posted, want)
+	}
+}
+
+func TestParseOFX_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"SGML", "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\n\n<OFX>", "not supported"},
+		{"missing FITID", "<OFX><STMTTRN><DTPOSTED>20240101</DTPOSTED><TRNAMT>1</TRNAMT></STMTTRN></OFX>", "missing FITID"},
+		{"bad amount", "<OFX><STMTTRN><FITID>1</FITID><DTPOSTED>20240101</DTPOSTED><TRNAMT>1,5</TRNAMT></STMTTRN></OFX>", "invalid TRNAMT"},
+		{"bad date", "<OFX><STMTTRN><FITID>1</FITID><DTPOSTED>2024-01-01</DTPOSTED><TRNAMT>1</TRNAMT></STMTTRN></OFX>", "invalid DTPOSTED"},
+		{"broken XML", "<OFX><STMTTRN><FITID>1</STMTTRN>", "invalid OFX file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseOFX(strings.NewReader(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseOFX() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ParseOFX(strings.NewReader("OFXHEADER:100\n")); !errors.Is(err, ErrUnsupportedOFX) {
+		t.Errorf("ParseOFX(SGML) error = %v, want ErrUnsupportedOFX", err)
+	}
+}
+
+func TestParseOFXDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"20240115", time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)},
+		{"202401151230", time.Date(2024, 1, 15, 12, 30, 0, 0, time.Local)},
+		{"20240115123045.123", time.Date(2024, 1, 15, 12, 30, 45, 0, time.Local)},
+		{"20240115000000[-5:EST]", time.Date(2024, 1, 15, 5, 0, 0, 0, time.UTC)},
+		{"20240115000000[5.5:IST]", time.Date(2024, 1, 14, 18, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseOFXDate(tt.value)
+		if err != nil {
+			t.Errorf("parseOFXDate(%q) error = %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseOFXDate(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoRate dikembalikan RateProvider jika kurs untuk pasangan currency
+// tidak tersedia.
+var ErrNoRate = errors.New("no exchange rate available")
+
+// RateProvider memberikan kurs saat ini: 1 unit from = rate unit to.
+type RateProvider interface {
+	Rate(ctx context.Context, from, to string) (decimal.Decimal, error)
+}
+
+// StaticRates adalah RateProvider dengan kurs tetap dari config
+// (app.rates): Rates[X] adalah nilai 1 unit X dalam Base.
+//
+//	rates := service.NewStaticRates("IDR", map[string]decimal.Decimal{
+//	    "USD": decimal.NewFromInt(16250),
+//	})
+//	rate, _ := rates.Rate(ctx, "USD", "IDR") // 16250
+type StaticRates struct {
+	Base  string
+	Rates map[string]decimal.Decimal
+}
+
+// NewStaticRates membuat StaticRates. Kode currency di-uppercase.
+func NewStaticRates(base string, rates map[string]decimal.Decimal) *StaticRates {
+	normalized := make(map[string]decimal.Decimal, len(rates))
+	for currency, rate := range rates {
+		normalized[strings.ToUpper(currency)] = rate
+	}
+	return &StaticRates{Base: strings.ToUpper(base), Rates: normalized}
+}
+
+// Rate mengembalikan kurs from → to lewat Base. ErrNoRate jika salah
+// satu currency tidak ada di Rates.
+func (s *StaticRates) Rate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+
+	fromRate, ok := s.toBase(from)
+	if !ok {
+		return decimal.Zero, ErrNoRate
+	}
+	toRate, ok := s.toBase(to)
+	if !ok {
+		return decimal.Zero, ErrNoRate
+	}
+	return fromRate.DivRound(toRate, 8), nil
+}
+
+// toBase mengembalikan nilai 1 unit currency dalam Base.
+func (s *StaticRates) toBase(currency string) (decimal.Decimal, bool) {
+	if currency == s.Base {
+		return decimal.NewFromInt(1), true
+	}
+	rate, ok := s.Rates[currency]
+	return rate, ok && rate.IsPositive()
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestStaticRates_Rate(t *testing.T) {
+	rates := NewStaticRates("idr", map[string]decimal.Decimal{
+		"usd": decimal.NewFromInt(16000),
+		"SGD": decimal.NewFromInt(12000),
+	})
+
+	tests := []struct {
+		from, to string
+		want     string
+		wantErr  error
+	}{
+		{"USD", "IDR", "16000", nil},
+		{"IDR", "USD", "0.0000625", nil},
+		{"USD", "SGD", "1.33333333", nil},
+		{"EUR", "EUR", "1", nil},
+		{"EUR", "IDR", "", ErrNoRate},
+	}
+
+	for _, tt := range tests {
+		got, err := rates.Rate(context.Background(), tt.from, tt.to)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("Rate(%s, %s) error = %v, want %v", tt.from, tt.to, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("Rate(%s, %s) = %s, want %s", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionService_RateSnapshot(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	idr := repos.createWallet(t, "BCA", 0)
+
+	usd := models.NewWallet("Wise", models.WalletTypeBank)
+	usd.Currency = "USD"
+	if err := repos.wallet.Create(ctx, usd); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := newTestTransactionService(repos)
+	rates := NewStaticRates("IDR", map[string]decimal.Decimal{"USD": decimal.NewFromInt(15000)})
+	svc.SetRateProvider(rates, "IDR")
+
+	jan := time.Date(2025, 1, 10, 12, 0, 0, 0, time.Local)
+	income := func(wallet *models.Wallet, amount int64) *models.Transaction {
+		t.Helper()
+		tx, err := svc.Create(ctx, CreateTransactionInput{
+			WalletID: wallet.ID,
+			Type:     models.TransactionTypeIncome,
+			Amount:   decimal.NewFromInt(amount),
+			Date:     jan,
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		return tx
+	}
+
+	usdTx := income(usd, 100)
+	if usdTx.RateToBase == nil || !usdTx.RateToBase.Equal(decimal.NewFromInt(15000)) {
+		t.Fatalf("RateToBase = %v, want 15000", usdTx.RateToBase)
+	}
+	if idrTx := income(idr, 500000); idrTx.RateToBase != nil {
+		t.Errorf("RateToBase for a base-currency wallet = %v, want nil", idrTx.RateToBase)
+	}
+
+	// Kurs berubah setelah transaksi dibuat; laporan Januari tetap
+	// memakai kurs saat itu.
+	rates.Rates["USD"] = decimal.NewFromInt(17000)
+
+	summary, err := svc.GetMonthlySummary(ctx, 2025, time.January)
+	if err != nil {
+		t.Fatalf("GetMonthlySummary() error = %v", err)
+	}
+	if want := decimal.NewFromInt(2000000); !summary.TotalIncome.Equal(want) {
+		t.Errorf("TotalIncome = %s, want %s (100 USD at 15000 + 500000 IDR)", summary.TotalIncome, want)
+	}
+
+	// Satu wallet: tetap dalam currency wallet itu
+	summary, err = svc.GetSummary(ctx, repository.TransactionFilter{WalletID: &usd.ID})
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if want := decimal.NewFromInt(100); !summary.TotalIncome.Equal(want) {
+		t.Errorf("TotalIncome for the USD wallet = %s, want %s", summary.TotalIncome, want)
+	}
+}
+
+func TestTransactionService_RateSnapshot_NoRate(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+
+	eur := models.NewWallet("Revolut", models.WalletTypeBank)
+	eur.Currency = "EUR"
+	eur.Balance = decimal.NewFromInt(100)
+	if err := repos.wallet.Create(ctx, eur); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := newTestTransactionService(repos)
+	svc.SetRateProvider(NewStaticRates("IDR", nil), "IDR")
+
+	tx, err := svc.Create(ctx, CreateTransactionInput{
+		WalletID: eur.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(10),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if tx.RateToBase != nil {
+		t.Errorf("RateToBase = %v, want nil when no rate is configured", tx.RateToBase)
+	}
+}
+
+func TestTransactionService_Create_OriginalAmount(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	bca := repos.createWallet(t, "BCA", 1000000)
+
+	svc := newTestTransactionService(repos)
+	svc.SetRateProvider(NewStaticRates("IDR", map[string]decimal.Decimal{"USD": decimal.NewFromInt(16250)}), "IDR")
+
+	original := decimal.RequireFromString("12.50")
+	tx, err := svc.Create(ctx, CreateTransactionInput{
+		WalletID:         bca.ID,
+		Type:             models.TransactionTypeExpense,
+		OriginalAmount:   &original,
+		OriginalCurrency: "usd",
+		Description:      "Coffee in Singapore",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if want := decimal.NewFromInt(203125); !tx.Amount.Equal(want) {
+		t.Errorf("Amount = %s, want %s (12.50 USD at 16250)", tx.Amount, want)
+	}
+	saved, err := repos.transaction.GetByID(ctx, tx.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if saved.OriginalAmount == nil || !saved.OriginalAmount.Equal(original) || saved.OriginalCurrency != "USD" {
+		t.Errorf("saved original = %v %q, want 12.50 USD", saved.OriginalAmount, saved.OriginalCurrency)
+	}
+	if got := repos.balanceOf(t, bca); !got.Equal(decimal.NewFromInt(796875)) {
+		t.Errorf("balance = %s, want 796875 (in the wallet's currency)", got)
+	}
+}
+
+func TestTransactionService_Create_OriginalAmountErrors(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	bca := repos.createWallet(t, "BCA", 1000000)
+	original := decimal.NewFromInt(10)
+
+	svc := newTestTransactionService(repos)
+	input := CreateTransactionInput{
+		WalletID:         bca.ID,
+		Type:             models.TransactionTypeExpense,
+		OriginalAmount:   &original,
+		OriginalCurrency: "EUR",
+	}
+
+	if _, err := svc.Create(ctx, input); !errors.Is(err, ErrNoRate) {
+		t.Errorf("Create() without a rate provider error = %v, want ErrNoRate", err)
+	}
+
+	svc.SetRateProvider(NewStaticRates("IDR", map[string]decimal.Decimal{"USD": decimal.NewFromInt(16250)}), "IDR")
+	if _, err := svc.Create(ctx, input); !errors.Is(err, ErrNoRate) {
+		t.Errorf("Create() with an unknown currency error = %v, want ErrNoRate", err)
+	}
+
+	input.OriginalCurrency = ""
+	if _, err := svc.Create(ctx, input); !errors.Is(err, ErrOriginalCurrencyRequired) {
+		t.Errorf("Create() without a currency error = %v, want ErrOriginalCurrencyRequired", err)
+	}
+
+	if got := repos.balanceOf(t, bca); !got.Equal(decimal.NewFromInt(1000000)) {
+		t.Errorf("balance = %s, want unchanged 1000000", got)
+	}
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +14,41 @@ import (
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
 )
 
+// ErrRecurringNotDue dikembalikan ProcessOne jika recurring tidak aktif atau
+// belum jatuh tempo.
+var ErrRecurringNotDue = errors.New("recurring is not due")
+
+// ErrInvalidScheduleRange dikembalikan GenerateSchedule jika to sebelum from.
+var ErrInvalidScheduleRange = errors.New("schedule end is before start")
+
+// ErrRecurringNextDueInPast adalah sentinel untuk
+// RecurringNextDueInPastError, dipakai dengan errors.Is.
+var ErrRecurringNextDueInPast = errors.New("next due is more than one period in the past")
+
+// RecurringNextDueInPastError dikembalikan Create jika NextDue lebih dari
+// satu periode di masa lalu: ProcessDue akan langsung membuat banyak
+// transaksi sekaligus. Suggested adalah jatuh tempo pertama mulai hari
+// ini dengan jadwal yang sama.
+//
+//	var dueErr *service.RecurringNextDueInPastError
+//	if errors.As(err, &dueErr) {
+//	    input.NextDue = dueErr.Suggested
+//	}
+type RecurringNextDueInPastError struct {
+	NextDue   time.Time
+	Suggested time.Time
+}
+
+func (e *RecurringNextDueInPastError) Error() string {
+	return fmt.Sprintf("next due %s is more than one period in the past (next occurrence: %s)",
+		e.NextDue.Format("2006-01-02"), e.Suggested.Format("2006-01-02"))
+}
+
+// Is membuat errors.Is(err, ErrRecurringNextDueInPast) bernilai true.
+func (e *RecurringNextDueInPastError) Is(target error) bool {
+	return target == ErrRecurringNextDueInPast
+}
+
 // RecurringService menangani business logic untuk recurring transactions.
 //
 // Recurring transaction adalah transaksi yang terjadi secara berkala.
@@ -35,7 +72,17 @@ func NewRecurringService(
 }
 
 // Create membuat recurring transaction baru.
+//
+// Jika WalletID kosong, default wallet dari TransactionService yang dipakai.
+// NextDue lebih dari satu periode di masa lalu ditolak dengan
+// *RecurringNextDueInPastError.
 func (s *RecurringService) Create(ctx context.Context, input CreateRecurringInput) (*models.RecurringTransaction, error) {
+	walletID, err := s.txService.walletOrDefault(input.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	input.WalletID = walletID
+
 	recurring := &models.RecurringTransaction{
 		ID:          models.NewID(),
 		WalletID:    input.WalletID,
@@ -44,16 +91,20 @@ func (s *RecurringService) Create(ctx context.Context, input CreateRecurringInpu
 		Amount:      input.Amount,
 		Description: input.Description,
 		Frequency:   input.Frequency,
-		NextDue:     input.NextDue,
 		EndDate:     input.EndDate,
 		IsActive:    true,
 		CreatedAt:   time.Now(),
 	}
+	recurring.SetNextDue(input.NextDue)
 
 	if err := recurring.Validate(); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	if err := checkNextDue(recurring, time.Now()); err != nil {
+		return nil, err
+	}
+
 	if err := s.recurringRepo.Create(ctx, recurring); err != nil {
 		return nil, fmt.Errorf("failed to create recurring: %w", err)
 	}
@@ -61,6 +112,24 @@ func (s *RecurringService) Create(ctx context.Context, input CreateRecurringInpu
 	return recurring, nil
 }
 
+// checkNextDue mengembalikan *RecurringNextDueInPastError jika NextDue
+// masih sebelum hari ini setelah dimajukan satu periode. Satu periode
+// yang terlewat (misalnya tagihan kemarin) tetap diterima.
+func checkNextDue(recurring *models.RecurringTransaction, now time.Time) error {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	next := *recurring
+	next.AdvanceNextDue()
+	if !next.NextDue.Before(today) {
+		return nil
+	}
+
+	for next.NextDue.Before(today) {
+		next.AdvanceNextDue()
+	}
+	return &RecurringNextDueInPastError{NextDue: recurring.NextDue, Suggested: next.NextDue}
+}
+
 // GetByID mengambil recurring berdasarkan ID.
 func (s *RecurringService) GetByID(ctx context.Context, id uuid.UUID) (*models.RecurringTransaction, error) {
 	recurring, err := s.recurringRepo.GetByID(ctx, id)
@@ -85,15 +154,68 @@ func (s *RecurringService) ListActive(ctx context.Context) ([]*models.RecurringT
 	return s.List(ctx, repository.RecurringFilter{IsActive: &isActive})
 }
 
-// GetDue mengambil recurring yang jatuh tempo.
+// GetDue mengambil recurring aktif yang jatuh tempo (next_due <= sekarang).
 func (s *RecurringService) GetDue(ctx context.Context) ([]*models.RecurringTransaction, error) {
-	recurrings, err := s.recurringRepo.GetDue(ctx)
+	isActive := true
+	now := time.Now()
+	recurrings, err := s.recurringRepo.List(ctx, repository.RecurringFilter{IsActive: &isActive, NextDueBefore: &now})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get due recurring: %w", err)
 	}
 	return recurrings, nil
 }
 
+// GenerateSchedule mensimulasikan AdvanceNextDue untuk semua recurring
+// aktif dan mengembalikan setiap jatuh tempo dalam window [from, to],
+// urut tanggal. Recurring tidak diubah; berguna untuk kalender dan
+// forecast cash flow.
+//
+// Jatuh tempo yang sudah lewat tapi belum diproses (sebelum from) tidak
+// ikut. EndDate recurring tetap dihormati.
+//
+//	from := time.Now()
+//	schedule, err := recurringService.GenerateSchedule(ctx, from, from.AddDate(0, 3, 0))
+func (s *RecurringService) GenerateSchedule(ctx context.Context, from, to time.Time) ([]*ScheduledOccurrence, error) {
+	if to.Before(from) {
+		return nil, ErrInvalidScheduleRange
+	}
+
+	isActive := true
+	recurrings, err := s.List(ctx, repository.RecurringFilter{IsActive: &isActive, NextDueBefore: &to})
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule []*ScheduledOccurrence
+	for _, r := range recurrings {
+		sim := *r
+		for sim.IsActive && !sim.NextDue.After(to) {
+			if !sim.NextDue.Before(from) {
+				schedule = append(schedule, &ScheduledOccurrence{
+					RecurringID:   r.ID,
+					WalletID:      r.WalletID,
+					Description:   r.Description,
+					Type:          r.Type,
+					Amount:        r.Amount,
+					ScheduledDate: sim.NextDue,
+				})
+			}
+
+			// Frequency yang tidak dikenal tidak memajukan NextDue
+			prev := sim.NextDue
+			sim.AdvanceNextDue()
+			if !sim.NextDue.After(prev) {
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].ScheduledDate.Before(schedule[j].ScheduledDate)
+	})
+	return schedule, nil
+}
+
 // ProcessDue memproses semua recurring yang jatuh tempo.
 //
 // Ini adalah method utama yang dipanggil oleh scheduler.
@@ -101,6 +223,12 @@ func (s *RecurringService) GetDue(ctx context.Context) ([]*models.RecurringTrans
 // 1. Generate transaction
 // 2. Advance next_due ke periode berikutnya
 //
+// Semua recurring diproses sekaligus lewat processBatch. Jika batch itu
+// ditolak sebelum menulis apa pun (ErrBulkInputInvalid, misalnya saldo
+// satu wallet tidak cukup), semuanya diproses satu per satu supaya satu
+// recurring yang gagal tidak menahan yang lain. Error database dari
+// batch dikembalikan apa adanya.
+//
 // Return jumlah transaksi yang berhasil di-generate.
 func (s *RecurringService) ProcessDue(ctx context.Context) (int, error) {
 	recurrings, err := s.GetDue(ctx)
@@ -108,36 +236,136 @@ func (s *RecurringService) ProcessDue(ctx context.Context) (int, error) {
 		return 0, err
 	}
 
-	processed := 0
-	for _, recurring := range recurrings {
-		// Generate transaction
-		input := CreateTransactionInput{
-			WalletID:    recurring.WalletID,
-			CategoryID:  recurring.CategoryID,
-			Type:        recurring.Type,
-			Amount:      recurring.Amount,
-			Description: recurring.Description,
-			Date:        recurring.NextDue,
+	if len(recurrings) > 1 {
+		err := s.processBatch(ctx, recurrings)
+		if err == nil {
+			return len(recurrings), nil
 		}
+		if !errors.Is(err, ErrBulkInputInvalid) {
+			return 0, err
+		}
+	}
 
-		_, err := s.txService.Create(ctx, input)
-		if err != nil {
+	processed := 0
+	for _, recurring := range recurrings {
+		if _, err := s.process(ctx, recurring); err != nil {
 			// Log error but continue with others
 			fmt.Printf("Failed to process recurring %s: %v\n", recurring.ID, err)
 			continue
 		}
 
-		// Advance next due
+		processed++
+	}
+
+	return processed, nil
+}
+
+// ProcessOne memproses satu recurring yang jatuh tempo, untuk user yang
+// ingin memilih item mana yang di-generate daripada ProcessDue sekaligus.
+//
+// Return ErrRecurringNotDue jika recurring tidak aktif atau belum jatuh tempo.
+//
+//	tx, err := recurringService.ProcessOne(ctx, recurringID)
+func (s *RecurringService) ProcessOne(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
+	recurring, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !recurring.IsDue() {
+		return nil, ErrRecurringNotDue
+	}
+
+	return s.process(ctx, recurring)
+}
+
+// process men-generate transaksi dari recurring lalu memajukan next_due.
+// Pembuatan transaksi, update saldo, dan update recurring berjalan dalam
+// satu DB transaction sehingga recurring tidak ter-generate dua kali.
+//
+// Transaksi diberi idempotency key per recurring + tanggal jatuh tempo
+// (recurringIdempotencyKey), jadi run daemon yang diulang untuk due date
+// yang sama tidak membuat transaksi dobel.
+func (s *RecurringService) process(ctx context.Context, recurring *models.RecurringTransaction) (*models.Transaction, error) {
+	var transaction *models.Transaction
+
+	err := s.txService.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		transaction, err = s.txService.Create(ctx, recurringInput(recurring))
+		if err != nil {
+			return err
+		}
+
 		recurring.AdvanceNextDue()
 		if err := s.recurringRepo.Update(ctx, recurring); err != nil {
-			fmt.Printf("Failed to update recurring %s: %v\n", recurring.ID, err)
-			continue
+			return fmt.Errorf("failed to update recurring: %w", err)
 		}
 
-		processed++
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return processed, nil
+	return transaction, nil
+}
+
+// processBatch men-generate transaksi semua recurring sekaligus dengan
+// TransactionService.BulkCreate, lalu memajukan next_due masing-masing,
+// dalam satu DB transaction. recurrings baru diubah jika semuanya
+// berhasil, jadi setelah ErrBulkInputInvalid bisa diproses ulang satu
+// per satu.
+func (s *RecurringService) processBatch(ctx context.Context, recurrings []*models.RecurringTransaction) error {
+	inputs := make([]CreateTransactionInput, len(recurrings))
+	advanced := make([]*models.RecurringTransaction, len(recurrings))
+	for i, recurring := range recurrings {
+		inputs[i] = recurringInput(recurring)
+		r := *recurring
+		r.AdvanceNextDue()
+		advanced[i] = &r
+	}
+
+	err := s.txService.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.txService.bulkCreate(ctx, inputs, models.BalanceReasonRecurring); err != nil {
+			return err
+		}
+
+		for _, recurring := range advanced {
+			if err := s.recurringRepo.Update(ctx, recurring); err != nil {
+				return fmt.Errorf("failed to update recurring: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, recurring := range recurrings {
+		*recurring = *advanced[i]
+	}
+	return nil
+}
+
+// recurringInput membuat input transaksi dari recurring pada due date
+// saat ini.
+func recurringInput(recurring *models.RecurringTransaction) CreateTransactionInput {
+	return CreateTransactionInput{
+		WalletID:       recurring.WalletID,
+		CategoryID:     recurring.CategoryID,
+		Type:           recurring.Type,
+		Amount:         recurring.Amount,
+		Description:    recurring.Description,
+		Date:           recurring.NextDue,
+		IdempotencyKey: recurringIdempotencyKey(recurring),
+	}
+}
+
+// recurringIdempotencyKey membuat idempotency key untuk transaksi dari
+// recurring pada due date saat ini, contoh "recurring:<id>:2025-01-05".
+func recurringIdempotencyKey(recurring *models.RecurringTransaction) string {
+	return fmt.Sprintf("recurring:%s:%s", recurring.ID, recurring.NextDue.Format("2006-01-02"))
 }
 
 // Update memperbarui recurring.
@@ -154,7 +382,7 @@ func (s *RecurringService) Update(ctx context.Context, input UpdateRecurringInpu
 		recurring.Description = *input.Description
 	}
 	if input.NextDue != nil {
-		recurring.NextDue = *input.NextDue
+		recurring.SetNextDue(*input.NextDue)
 	}
 	if input.EndDate != nil {
 		recurring.EndDate = input.EndDate
@@ -204,6 +432,17 @@ type CreateRecurringInput struct {
 	EndDate     *time.Time
 }
 
+// ScheduledOccurrence adalah satu jatuh tempo recurring di masa depan,
+// hasil GenerateSchedule.
+type ScheduledOccurrence struct {
+	RecurringID   uuid.UUID
+	WalletID      uuid.UUID
+	Description   string
+	Type          models.TransactionType
+	Amount        decimal.Decimal
+	ScheduledDate time.Time
+}
+
 // UpdateRecurringInput adalah input untuk update recurring.
 type UpdateRecurringInput struct {
 	ID          uuid.UUID
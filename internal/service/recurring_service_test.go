@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestRecurringService_ProcessOne(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewRecurringService(repos.recurring, newTestTransactionService(repos))
+	wallet := repos.createWallet(t, "BCA", 1000000)
+
+	today := time.Now()
+	yesterday := today.AddDate(0, 0, -1)
+
+	newRecurring := func(amount int64, nextDue time.Time) *models.RecurringTransaction {
+		t.Helper()
+		rec, err := svc.Create(ctx, CreateRecurringInput{
+			WalletID:  wallet.ID,
+			Type:      models.TransactionTypeExpense,
+			Amount:    decimal.NewFromInt(amount),
+			Frequency: models.RecurringMonthly,
+			NextDue:   nextDue,
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		return rec
+	}
+
+	netflix := newRecurring(150000, yesterday)
+	gym := newRecurring(300000, yesterday)
+	future := newRecurring(50000, today.AddDate(0, 0, 7))
+
+	tx, err := svc.ProcessOne(ctx, netflix.ID)
+	if err != nil {
+		t.Fatalf("ProcessOne() error = %v", err)
+	}
+	if !tx.Amount.Equal(decimal.NewFromInt(150000)) {
+		t.Errorf("transaction amount = %s, want 150000", tx.Amount)
+	}
+	if want := "recurring:" + netflix.ID.String() + ":" + yesterday.Format("2006-01-02"); tx.IdempotencyKey != want {
+		t.Errorf("IdempotencyKey = %q, want %q", tx.IdempotencyKey, want)
+	}
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(850000)) {
+		t.Errorf("balance = %s, want 850000", got)
+	}
+
+	// Hanya netflix yang maju; gym tetap due
+	updated, err := svc.GetByID(ctx, netflix.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if want := yesterday.AddDate(0, 1, 0); !updated.NextDue.Equal(want) {
+		t.Errorf("NextDue = %v, want %v", updated.NextDue, want)
+	}
+
+	due, err := svc.GetDue(ctx)
+	if err != nil {
+		t.Fatalf("GetDue() error = %v", err)
+	}
+	if len(due) != 1 || due[0].ID != gym.ID {
+		t.Errorf("GetDue() = %d items, want only the unprocessed one", len(due))
+	}
+
+	if _, err := svc.ProcessOne(ctx, future.ID); !errors.Is(err, ErrRecurringNotDue) {
+		t.Errorf("ProcessOne(future) error = %v, want ErrRecurringNotDue", err)
+	}
+}
+
+func TestRecurringService_ProcessOne_RollsBack(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewRecurringService(repos.recurring, newTestTransactionService(repos))
+	wallet := repos.createWallet(t, "BCA", 100)
+
+	rec, err := svc.Create(ctx, CreateRecurringInput{
+		WalletID:  wallet.ID,
+		Type:      models.TransactionTypeExpense,
+		Amount:    decimal.NewFromInt(500),
+		Frequency: models.RecurringWeekly,
+		NextDue:   time.Now().AddDate(0, 0, -1),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := svc.ProcessOne(ctx, rec.ID); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("ProcessOne() error = %v, want ErrInsufficientBalance", err)
+	}
+
+	got, err := svc.GetByID(ctx, rec.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !got.NextDue.Equal(rec.NextDue) {
+		t.Errorf("NextDue = %v, want unchanged %v", got.NextDue, rec.NextDue)
+	}
+}
+
+func TestRecurringService_ProcessDue(t *testing.T) {
+	ctx := context.Background()
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	setup := func(t *testing.T, gopayBalance int64) (*memoryRepos, *RecurringService, *models.Wallet, *models.Wallet) {
+		t.Helper()
+		repos := newMemoryRepos()
+		svc := NewRecurringService(repos.recurring, newTestTransactionService(repos))
+		bca := repos.createWallet(t, "BCA", 1000000)
+		gopay := repos.createWallet(t, "GoPay", gopayBalance)
+		bills := models.NewCategory("Bills", models.CategoryTypeExpense)
+		if err := repos.category.Create(ctx, bills); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, spec := range []struct {
+			wallet   *models.Wallet
+			category *uuid.UUID
+			amount   int64
+		}{
+			{bca, &bills.ID, 150000},
+			{bca, &bills.ID, 300000},
+			{gopay, &bills.ID, 50000},
+			{bca, nil, 20000},
+		} {
+			if _, err := svc.Create(ctx, CreateRecurringInput{
+				WalletID:   spec.wallet.ID,
+				CategoryID: spec.category,
+				Type:       models.TransactionTypeExpense,
+				Amount:     decimal.NewFromInt(spec.amount),
+				Frequency:  models.RecurringMonthly,
+				NextDue:    yesterday,
+			}); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+		return repos, svc, bca, gopay
+	}
+
+	t.Run("batch", func(t *testing.T) {
+		repos, svc, bca, gopay := setup(t, 100000)
+
+		processed, err := svc.ProcessDue(ctx)
+		if err != nil || processed != 4 {
+			t.Fatalf("ProcessDue() = %d, %v, want 4, nil", processed, err)
+		}
+		if got := repos.balanceOf(t, bca); !got.Equal(decimal.NewFromInt(530000)) {
+			t.Errorf("BCA balance = %s, want 530000", got)
+		}
+		if got := repos.balanceOf(t, gopay); !got.Equal(decimal.NewFromInt(50000)) {
+			t.Errorf("GoPay balance = %s, want 50000", got)
+		}
+
+		// Lewat batch: satu update saldo per wallet
+		audit, _ := repos.wallet.ListBalanceAudit(ctx, bca.ID, repository.ListParams{})
+		if len(audit) != 1 || audit[0].Reason != models.BalanceReasonRecurring {
+			t.Errorf("BCA has %d balance changes, want one recurring update", len(audit))
+		}
+		if due, _ := svc.GetDue(ctx); len(due) != 0 {
+			t.Errorf("GetDue() = %d items after ProcessDue, want 0", len(due))
+		}
+
+		// Run ulang tidak membuat transaksi dobel
+		if processed, _ := svc.ProcessDue(ctx); processed != 0 {
+			t.Errorf("second ProcessDue() = %d, want 0", processed)
+		}
+	})
+
+	t.Run("one fails", func(t *testing.T) {
+		repos, svc, bca, gopay := setup(t, 10000)
+
+		processed, err := svc.ProcessDue(ctx)
+		if err != nil || processed != 3 {
+			t.Fatalf("ProcessDue() = %d, %v, want 3, nil", processed, err)
+		}
+		if got := repos.balanceOf(t, bca); !got.Equal(decimal.NewFromInt(530000)) {
+			t.Errorf("BCA balance = %s, want 530000", got)
+		}
+		due, _ := svc.GetDue(ctx)
+		if len(due) != 1 || due[0].WalletID != gopay.ID {
+			t.Errorf("GetDue() = %d items, want only the GoPay recurring", len(due))
+		}
+	})
+
+	t.Run("database error is not retried", func(t *testing.T) {
+		repos, svc, bca, _ := setup(t, 100000)
+		errDB := errors.New("connection reset")
+		svc.txService.txRepo = failingBatchTxRepo{svc.txService.txRepo, errDB}
+
+		processed, err := svc.ProcessDue(ctx)
+		if !errors.Is(err, errDB) || processed != 0 {
+			t.Fatalf("ProcessDue() = %d, %v, want 0, %v", processed, err, errDB)
+		}
+		all, _ := repos.transaction.List(ctx, repository.TransactionFilter{}, repository.ListParams{Limit: 10})
+		if len(all) != 0 {
+			t.Errorf("got %d transactions, want none created one by one", len(all))
+		}
+		if got := repos.balanceOf(t, bca); !got.Equal(decimal.NewFromInt(1000000)) {
+			t.Errorf("BCA balance = %s, want unchanged 1000000", got)
+		}
+	})
+}
+
+// failingBatchTxRepo menggagalkan CreateBatch seperti error database.
+type failingBatchTxRepo struct {
+	repository.TransactionRepository
+	err error
+}
+
+func (f failingBatchTxRepo) CreateBatch(ctx context.Context, txs []*models.Transaction) error {
+	return f.err
+}
+
+func TestRecurringService_Create_NextDueInPast(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewRecurringService(repos.recurring, newTestTransactionService(repos))
+	wallet := repos.createWallet(t, "BCA", 0)
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name          string
+		freq          models.RecurringFrequency
+		nextDue       time.Time
+		wantSuggested time.Time
+	}{
+		{"missed one month", models.RecurringMonthly, today.AddDate(0, -1, 0), time.Time{}},
+		{"missed one week", models.RecurringWeekly, today.AddDate(0, 0, -7), time.Time{}},
+		{"missed two weeks", models.RecurringWeekly, today.AddDate(0, 0, -15), today.AddDate(0, 0, 6)},
+		{"missed a year", models.RecurringWeekly, today.AddDate(0, 0, -365), today.AddDate(0, 0, 6)},
+		{"missed two days", models.RecurringDaily, today.AddDate(0, 0, -2), today},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Create(ctx, CreateRecurringInput{
+				WalletID:  wallet.ID,
+				Type:      models.TransactionTypeExpense,
+				Amount:    decimal.NewFromInt(100),
+				Frequency: tt.freq,
+				NextDue:   tt.nextDue,
+			})
+			if tt.wantSuggested.IsZero() {
+				if err != nil {
+					t.Errorf("Create() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var dueErr *RecurringNextDueInPastError
+			if !errors.Is(err, ErrRecurringNextDueInPast) || !errors.As(err, &dueErr) {
+				t.Fatalf("Create() error = %v, want *RecurringNextDueInPastError", err)
+			}
+			if !dueErr.Suggested.Equal(tt.wantSuggested) {
+				t.Errorf("Suggested = %s, want %s", dueErr.Suggested.Format("2006-01-02"), tt.wantSuggested.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestRecurringService_GenerateSchedule(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewRecurringService(repos.recurring, newTestTransactionService(repos))
+	wallet := repos.createWallet(t, "BCA", 0)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local)
+
+	// Lewat repository: tanggal tetap di Maret 2026 bisa sudah lebih dari
+	// satu periode lalu, yang ditolak RecurringService.Create
+	create := func(desc string, freq models.RecurringFrequency, nextDue time.Time, endDate *time.Time) *models.RecurringTransaction {
+		t.Helper()
+		rec := models.NewRecurringTransaction(wallet.ID, models.TransactionTypeExpense, decimal.NewFromInt(100), freq, nextDue)
+		rec.Description = desc
+		rec.EndDate = endDate
+		if err := repos.recurring.Create(ctx, rec); err != nil {
+			t.Fatalf("failed to create recurring: %v", err)
+		}
+		return rec
+	}
+
+	weeklyEnd := time.Date(2026, 3, 20, 0, 0, 0, 0, time.Local)
+	create("Rent", models.RecurringMonthly, time.Date(2026, 2, 25, 0, 0, 0, 0, time.Local), nil)
+	create("Groceries", models.RecurringWeekly, time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local), &weeklyEnd)
+	create("Insurance", models.RecurringYearly, time.Date(2026, 6, 1, 0, 0, 0, 0, time.Local), nil)
+	stopped := create("Gym", models.RecurringMonthly, time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local), nil)
+	if err := svc.Deactivate(ctx, stopped.ID); err != nil {
+		t.Fatalf("Deactivate() error = %v", err)
+	}
+
+	schedule, err := svc.GenerateSchedule(ctx, from, to)
+	if err != nil {
+		t.Fatalf("GenerateSchedule() error = %v", err)
+	}
+
+	want := []struct {
+		desc string
+		day  int
+	}{
+		{"Groceries", 2}, {"Groceries", 9}, {"Groceries", 16}, {"Rent", 25},
+	}
+	if len(schedule) != len(want) {
+		t.Fatalf("GenerateSchedule() = %d occurrences, want %d", len(schedule), len(want))
+	}
+	for i, w := range want {
+		got := schedule[i]
+		if got.Description != w.desc || got.ScheduledDate.Day() != w.day || got.ScheduledDate.Month() != time.March {
+			t.Errorf("schedule[%d] = %s on %s, want %s on 2026-03-%02d",
+				i, got.Description, got.ScheduledDate.Format("2006-01-02"), w.desc, w.day)
+		}
+	}
+
+	// Simulasi tidak mengubah recurring yang tersimpan
+	due, err := svc.ListActive(ctx)
+	if err != nil {
+		t.Fatalf("ListActive() error = %v", err)
+	}
+	for _, r := range due {
+		if r.Description == "Rent" && r.NextDue.Month() != time.February {
+			t.Errorf("Rent NextDue = %s, want unchanged", r.NextDue.Format("2006-01-02"))
+		}
+	}
+
+	if _, err := svc.GenerateSchedule(ctx, to, from); !errors.Is(err, ErrInvalidScheduleRange) {
+		t.Errorf("GenerateSchedule(to, from) error = %v, want ErrInvalidScheduleRange", err)
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// Label pengganti untuk referensi yang tidak ditemukan di RefData.
+const (
+	UnknownWalletName = "Unknown"
+	UncategorizedName = "Uncategorized"
+)
+
+// RefData adalah wallet dan category yang di-index per ID, untuk
+// menampilkan nama di export, laporan, dan dashboard.
+//
+// RefData di-load sekali (satu List per tabel) lalu dipakai bersama,
+// bukan setiap export/laporan me-load daftar wallet dan category sendiri.
+// Isinya tidak diubah setelah dibuat, jadi aman dipakai concurrent.
+//
+//	refs, err := service.LoadRefData(ctx, walletRepo, categoryRepo)
+//	refs.WalletName(tx.WalletID)     // "BCA" atau "Unknown"
+//	refs.CategoryName(tx.CategoryID) // "Food" atau "Uncategorized"
+type RefData struct {
+	WalletsByID    map[uuid.UUID]*models.Wallet
+	CategoriesByID map[uuid.UUID]*models.Category
+
+	// wallets menyimpan urutan dari repository (untuk ActiveWallets).
+	wallets []*models.Wallet
+}
+
+// NewRefData membuat RefData dari daftar wallet dan category.
+func NewRefData(wallets []*models.Wallet, categories []*models.Category) *RefData {
+	refs := &RefData{
+		WalletsByID:    make(map[uuid.UUID]*models.Wallet, len(wallets)),
+		CategoriesByID: make(map[uuid.UUID]*models.Category, len(categories)),
+		wallets:        wallets,
+	}
+	for _, w := range wallets {
+		refs.WalletsByID[w.ID] = w
+	}
+	for _, c := range categories {
+		refs.CategoriesByID[c.ID] = c
+	}
+	return refs
+}
+
+// LoadRefData me-load semua wallet (termasuk yang diarsipkan, karena
+// transaksi lama tetap menunjuk ke sana) dan semua category.
+// categoryRepo boleh nil jika caller hanya butuh wallet.
+func LoadRefData(
+	ctx context.Context,
+	walletRepo repository.WalletRepository,
+	categoryRepo repository.CategoryRepository,
+) (*RefData, error) {
+	wallets, err := walletRepo.List(ctx, repository.WalletFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallets: %w", err)
+	}
+
+	var categories []*models.Category
+	if categoryRepo != nil {
+		categories, err = categoryRepo.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get categories: %w", err)
+		}
+	}
+
+	return NewRefData(wallets, categories), nil
+}
+
+// Wallet mengambil wallet dengan ID tertentu. RefData nil dianggap kosong.
+func (r *RefData) Wallet(id uuid.UUID) (*models.Wallet, bool) {
+	if r == nil {
+		return nil, false
+	}
+	w, ok := r.WalletsByID[id]
+	return w, ok
+}
+
+// WalletName mengembalikan nama wallet, atau UnknownWalletName.
+func (r *RefData) WalletName(id uuid.UUID) string {
+	if w, ok := r.Wallet(id); ok {
+		return w.Name
+	}
+	return UnknownWalletName
+}
+
+// WalletCurrency mengembalikan currency wallet, atau fallback jika
+// wallet tidak ditemukan.
+func (r *RefData) WalletCurrency(id uuid.UUID, fallback string) string {
+	if w, ok := r.Wallet(id); ok {
+		return w.Currency
+	}
+	return fallback
+}
+
+// CategoryName mengembalikan nama category, atau UncategorizedName jika
+// id nil atau category tidak ditemukan (misalnya sudah dihapus).
+func (r *RefData) CategoryName(id *uuid.UUID) string {
+	if r == nil || id == nil {
+		return UncategorizedName
+	}
+	if c, ok := r.CategoriesByID[*id]; ok {
+		return c.Name
+	}
+	return UncategorizedName
+}
+
+// ActiveWallets mengembalikan wallet yang aktif, dengan urutan dari
+// repository.
+func (r *RefData) ActiveWallets() []*models.Wallet {
+	if r == nil {
+		return nil
+	}
+	var active []*models.Wallet
+	for _, w := range r.wallets {
+		if w.IsActive {
+			active = append(active, w)
+		}
+	}
+	return active
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+)
+
+func TestRefData_Labels(t *testing.T) {
+	bca := models.NewWallet("BCA", models.WalletTypeBank)
+	wise := models.NewWallet("Wise", models.WalletTypeBank)
+	wise.Currency = "USD"
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	refs := NewRefData([]*models.Wallet{bca, wise}, []*models.Category{food})
+
+	missing := uuid.New()
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"wallet name", refs.WalletName(bca.ID), "BCA"},
+		{"unknown wallet", refs.WalletName(missing), UnknownWalletName},
+		{"wallet currency", refs.WalletCurrency(wise.ID, "IDR"), "USD"},
+		{"unknown wallet currency", refs.WalletCurrency(missing, "IDR"), "IDR"},
+		{"category name", refs.CategoryName(&food.ID), "Food"},
+		{"no category", refs.CategoryName(nil), UncategorizedName},
+		{"deleted category", refs.CategoryName(&missing), UncategorizedName},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRefData_Nil(t *testing.T) {
+	var refs *RefData
+	id := uuid.New()
+
+	if got := refs.WalletName(id); got != UnknownWalletName {
+		t.Errorf("WalletName() = %q, want %q", got, UnknownWalletName)
+	}
+	if got := refs.CategoryName(&id); got != UncategorizedName {
+		t.Errorf("CategoryName() = %q, want %q", got, UncategorizedName)
+	}
+	if got := refs.WalletCurrency(id, "IDR"); got != "IDR" {
+		t.Errorf("WalletCurrency() = %q, want IDR", got)
+	}
+	if got := refs.ActiveWallets(); got != nil {
+		t.Errorf("ActiveWallets() = %v, want nil", got)
+	}
+}
+
+func TestLoadRefData(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	active := repos.createWallet(t, "BCA", 0)
+	archived := repos.createWallet(t, "Old card", 0)
+	archived.IsActive = false
+	if err := repos.wallet.Update(ctx, archived); err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err := LoadRefData(ctx, repos.wallet, repos.category)
+	if err != nil {
+		t.Fatalf("LoadRefData() error = %v", err)
+	}
+
+	// Wallet yang diarsipkan tetap punya nama (transaksi lama menunjuk ke sana)
+	if got := refs.WalletName(archived.ID); got != "Old card" {
+		t.Errorf("WalletName(archived) = %q, want Old card", got)
+	}
+	if got := refs.ActiveWallets(); len(got) != 1 || got[0].ID != active.ID {
+		t.Errorf("ActiveWallets() = %v, want only BCA", got)
+	}
+}
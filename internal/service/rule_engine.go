@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// RuleEngine mencocokkan transaksi dengan categorization rule. Rule dicek
+// urut Priority (kecil dulu) lalu CreatedAt; rule pertama yang cocok dan
+// kategorinya bertipe sama dengan transaksi yang menang.
+//
+//	engine, err := service.NewRuleEngine(rules, categories)
+//	if rule := engine.Match(tx); rule != nil {
+//	    tx.CategoryID = &rule.CategoryID
+//	}
+type RuleEngine struct {
+	rules         []compiledRule
+	categoryTypes map[uuid.UUID]models.CategoryType
+}
+
+// compiledRule adalah rule beserta regex-nya yang sudah dikompilasi.
+type compiledRule struct {
+	rule *models.CategorizationRule
+	re   *regexp.Regexp
+}
+
+// NewRuleEngine membuat RuleEngine dari rules. categories dipakai untuk
+// mengecek tipe kategori; rule yang kategorinya tidak ada di categories
+// tidak pernah cocok. Regex dikompilasi sekali di sini.
+func NewRuleEngine(rules []*models.CategorizationRule, categories []*models.Category) (*RuleEngine, error) {
+	sorted := append([]*models.CategorizationRule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority < sorted[j].Priority
+		}
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	e := &RuleEngine{categoryTypes: make(map[uuid.UUID]models.CategoryType, len(categories))}
+	for _, c := range categories {
+		e.categoryTypes[c.ID] = c.Type
+	}
+
+	for _, r := range sorted {
+		cr := compiledRule{rule: r}
+		if r.MatchType == models.RuleMatchDescriptionRegex {
+			re, err := r.CompileRegex()
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: %w", r.ID, err)
+			}
+			cr.re = re
+		}
+		e.rules = append(e.rules, cr)
+	}
+	return e, nil
+}
+
+// Len mengembalikan jumlah rule.
+func (e *RuleEngine) Len() int {
+	return len(e.rules)
+}
+
+// Match mengembalikan rule pertama yang cocok dengan tx, atau nil.
+// Rule yang kategorinya bertipe lain (kategori income untuk transaksi
+// expense) dilewati supaya rule berikutnya tetap bisa cocok.
+func (e *RuleEngine) Match(tx *models.Transaction) *models.CategorizationRule {
+	for _, cr := range e.rules {
+		catType, ok := e.categoryTypes[cr.rule.CategoryID]
+		if !ok || string(catType) != string(tx.Type) {
+			continue
+		}
+		if cr.matches(tx) {
+			return cr.rule
+		}
+	}
+	return nil
+}
+
+func (cr compiledRule) matches(tx *models.Transaction) bool {
+	switch cr.rule.MatchType {
+	case models.RuleMatchWallet:
+		return cr.rule.WalletID != nil && *cr.rule.WalletID == tx.WalletID
+	case models.RuleMatchDescriptionContains:
+		return strings.Contains(strings.ToLower(tx.Description), strings.ToLower(cr.rule.Pattern))
+	case models.RuleMatchDescriptionRegex:
+		return cr.re != nil && cr.re.MatchString(tx.Description)
+	case models.RuleMatchTag:
+		for _, tag := range tx.Tags {
+			if strings.EqualFold(strings.TrimSpace(tag), cr.rule.Pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadRuleEngine membuat RuleEngine dari semua rule di ruleRepo.
+// Mengembalikan nil (tanpa error) jika belum ada rule, supaya caller
+// tidak perlu me-load category.
+func loadRuleEngine(ctx context.Context, ruleRepo repository.RuleRepository, categoryRepo repository.CategoryRepository) (*RuleEngine, error) {
+	rules, err := ruleRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categorization rules: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	categories, err := categoryRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return NewRuleEngine(rules, categories)
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// RuleService mengelola categorization rule dan menerapkannya ke
+// transaksi lama yang belum punya kategori (autocategorize).
+// Transaksi baru dikategorikan di TransactionService.Create (lihat
+// TransactionService.SetRuleRepository).
+type RuleService struct {
+	ruleRepo     repository.RuleRepository
+	txRepo       repository.TransactionRepository
+	walletRepo   repository.WalletRepository
+	categoryRepo repository.CategoryRepository
+	txManager    repository.TransactionManager
+}
+
+// NewRuleService membuat instance baru RuleService.
+func NewRuleService(
+	ruleRepo repository.RuleRepository,
+	txRepo repository.TransactionRepository,
+	walletRepo repository.WalletRepository,
+	categoryRepo repository.CategoryRepository,
+	txManager repository.TransactionManager,
+) *RuleService {
+	return &RuleService{
+		ruleRepo:     ruleRepo,
+		txRepo:       txRepo,
+		walletRepo:   walletRepo,
+		categoryRepo: categoryRepo,
+		txManager:    txManager,
+	}
+}
+
+// Create membuat rule baru. Regex yang tidak valid ditolak di sini
+// (models.ErrRuleInvalidRegex), begitu juga kategori atau wallet yang
+// tidak ada.
+//
+//	rule, err := ruleService.Create(ctx, service.CreateRuleInput{
+//	    MatchType:  models.RuleMatchDescriptionContains,
+//	    Pattern:    "gojek",
+//	    CategoryID: transportID,
+//	})
+func (s *RuleService) Create(ctx context.Context, input CreateRuleInput) (*models.CategorizationRule, error) {
+	rule := models.NewCategorizationRule(input.MatchType, input.CategoryID)
+	rule.Priority = input.Priority
+	rule.Pattern = input.Pattern
+	rule.WalletID = input.WalletID
+
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if _, err := s.categoryRepo.GetByID(ctx, rule.CategoryID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrCategoryNotFound, rule.CategoryID)
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if rule.WalletID != nil {
+		if _, err := s.walletRepo.GetByID(ctx, *rule.WalletID); err != nil {
+			return nil, fmt.Errorf("wallet not found: %w", err)
+		}
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create rule: %w", err)
+	}
+	return rule, nil
+}
+
+// List mengambil semua rule urut pengecekan.
+func (s *RuleService) List(ctx context.Context) ([]*models.CategorizationRule, error) {
+	return s.ruleRepo.List(ctx)
+}
+
+// GetByID mengambil rule berdasarkan ID.
+func (s *RuleService) GetByID(ctx context.Context, id uuid.UUID) (*models.CategorizationRule, error) {
+	return s.ruleRepo.GetByID(ctx, id)
+}
+
+// Delete menghapus rule. Transaksi yang sudah dikategorikan rule ini
+// tidak berubah.
+func (s *RuleService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+	return nil
+}
+
+// Test mengembalikan rule yang akan mengkategorikan tx, atau nil jika
+// tidak ada yang cocok. tx tidak disimpan.
+//
+//	rule, err := ruleService.Test(ctx, &models.Transaction{
+//	    Type:        models.TransactionTypeExpense,
+//	    Description: "GOJEK ride",
+//	})
+func (s *RuleService) Test(ctx context.Context, tx *models.Transaction) (*models.CategorizationRule, error) {
+	engine, err := loadRuleEngine(ctx, s.ruleRepo, s.categoryRepo)
+	if err != nil || engine == nil {
+		return nil, err
+	}
+	return engine.Match(tx), nil
+}
+
+// PreviewAutocategorize mencari transaksi tanpa kategori yang cocok
+// dengan rule, tanpa mengubah apa pun. Transaksi bertag sistem
+// (adjustment, revaluation, transfer) dilewati.
+func (s *RuleService) PreviewAutocategorize(ctx context.Context) (*AutocategorizePreview, error) {
+	preview := &AutocategorizePreview{}
+
+	engine, err := loadRuleEngine(ctx, s.ruleRepo, s.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.TransactionFilter{Uncategorized: true}
+	for offset := 0; ; offset += groupPageSize {
+		page, err := s.txRepo.List(ctx, filter, repository.ListParams{Limit: groupPageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list uncategorized transactions: %w", err)
+		}
+		for _, tx := range page {
+			if hasSystemTag(tx) {
+				continue
+			}
+			var rule *models.CategorizationRule
+			if engine != nil {
+				rule = engine.Match(tx)
+			}
+			if rule == nil {
+				preview.Unmatched++
+				continue
+			}
+			preview.Matches = append(preview.Matches, &RuleMatch{Transaction: tx, Rule: rule})
+		}
+		if len(page) < groupPageSize {
+			break
+		}
+	}
+	return preview, nil
+}
+
+// ApplyAutocategorize mengisi kategori setiap match dalam satu database
+// transaction. Hanya category_id yang di-update, jadi saldo wallet tidak
+// berubah.
+//
+//	preview, err := ruleService.PreviewAutocategorize(ctx)
+//	n, err := ruleService.ApplyAutocategorize(ctx, preview.Matches)
+func (s *RuleService) ApplyAutocategorize(ctx context.Context, matches []*RuleMatch) (int, error) {
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, m := range matches {
+			categoryID := m.Rule.CategoryID
+			if err := s.txRepo.UpdateCategoryAndTags(ctx, m.Transaction.ID, &categoryID, m.Transaction.Tags); err != nil {
+				return fmt.Errorf("failed to update transaction %s: %w", m.Transaction.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range matches {
+		categoryID := m.Rule.CategoryID
+		m.Transaction.CategoryID = &categoryID
+	}
+	return len(matches), nil
+}
+
+// hasSystemTag true jika tx punya tag yang dikelola aplikasi.
+func hasSystemTag(tx *models.Transaction) bool {
+	for _, tag := range tx.Tags {
+		if isSystemTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateRuleInput adalah input untuk membuat categorization rule.
+type CreateRuleInput struct {
+	Priority   int
+	MatchType  models.RuleMatchType
+	Pattern    string
+	WalletID   *uuid.UUID // Wajib untuk models.RuleMatchWallet
+	CategoryID uuid.UUID
+}
+
+// RuleMatch adalah transaksi beserta rule yang mengkategorikannya.
+type RuleMatch struct {
+	Transaction *models.Transaction
+	Rule        *models.CategorizationRule
+}
+
+// AutocategorizePreview adalah hasil RuleService.PreviewAutocategorize.
+type AutocategorizePreview struct {
+	Matches []*RuleMatch
+
+	// Unmatched adalah jumlah transaksi tanpa kategori yang tidak cocok
+	// dengan rule mana pun.
+	Unmatched int
+}
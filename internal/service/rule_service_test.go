@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+)
+
+// createCategory membuat kategori di repository.
+func (r *memoryRepos) createCategory(t *testing.T, name string, catType models.CategoryType) *models.Category {
+	t.Helper()
+
+	c := models.NewCategory(name, catType)
+	if err := r.category.Create(context.Background(), c); err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	return c
+}
+
+func newTestRuleService(repos *memoryRepos) *RuleService {
+	return NewRuleService(repos.rule, repos.transaction, repos.wallet, repos.category, repos.txManager)
+}
+
+func TestRuleEngine_Precedence(t *testing.T) {
+	walletID := uuid.New()
+	transport := models.NewCategory("Transport", models.CategoryTypeExpense)
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	refund := models.NewCategory("Refund", models.CategoryTypeIncome)
+
+	now := time.Now()
+	rule := func(priority int, created time.Time, matchType models.RuleMatchType, pattern string, category *models.Category) *models.CategorizationRule {
+		r := models.NewCategorizationRule(matchType, category.ID)
+		r.Priority, r.CreatedAt, r.Pattern = priority, created, pattern
+		if matchType == models.RuleMatchWallet {
+			r.WalletID = &walletID
+		}
+		return r
+	}
+
+	walletRule := rule(10, now, models.RuleMatchWallet, "", transport)
+	foodRule := rule(5, now, models.RuleMatchDescriptionContains, "GoFood", food)
+	refundRule := rule(0, now, models.RuleMatchDescriptionContains, "gofood", refund)
+	olderTag := rule(20, now.Add(-time.Hour), models.RuleMatchTag, "work", food)
+	newerTag := rule(20, now, models.RuleMatchTag, "work", transport)
+	regexRule := rule(30, now, models.RuleMatchDescriptionRegex, `^(indomaret|alfamart)\b`, food)
+
+	engine, err := NewRuleEngine(
+		[]*models.CategorizationRule{regexRule, newerTag, walletRule, olderTag, foodRule, refundRule},
+		[]*models.Category{transport, food, refund},
+	)
+	if err != nil {
+		t.Fatalf("NewRuleEngine() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		tx   *models.Transaction
+		want *models.CategorizationRule
+	}{
+		{
+			name: "lower priority wins over wallet rule",
+			tx:   &models.Transaction{Type: models.TransactionTypeExpense, WalletID: walletID, Description: "gofood lunch"},
+			want: foodRule,
+		},
+		{
+			name: "rule with other category type is skipped",
+			tx:   &models.Transaction{Type: models.TransactionTypeIncome, Description: "GoFood refund"},
+			want: refundRule,
+		},
+		{
+			name: "wallet rule",
+			tx:   &models.Transaction{Type: models.TransactionTypeExpense, WalletID: walletID, Description: "ride"},
+			want: walletRule,
+		},
+		{
+			name: "same priority checks older rule first",
+			tx:   &models.Transaction{Type: models.TransactionTypeExpense, Tags: []string{"Work"}},
+			want: olderTag,
+		},
+		{
+			name: "regex is case-insensitive",
+			tx:   &models.Transaction{Type: models.TransactionTypeExpense, Description: "INDOMARET Sudirman"},
+			want: regexRule,
+		},
+		{
+			name: "no match",
+			tx:   &models.Transaction{Type: models.TransactionTypeExpense, Description: "rent"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.Match(tt.tx); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleService_Create(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := newTestRuleService(repos)
+	ctx := context.Background()
+	food := repos.createCategory(t, "Food", models.CategoryTypeExpense)
+
+	tests := []struct {
+		name    string
+		input   CreateRuleInput
+		wantErr error
+	}{
+		{
+			name:    "invalid regex",
+			input:   CreateRuleInput{MatchType: models.RuleMatchDescriptionRegex, Pattern: "(unclosed", CategoryID: food.ID},
+			wantErr: models.ErrRuleInvalidRegex,
+		},
+		{
+			name:    "pattern too long",
+			input:   CreateRuleInput{MatchType: models.RuleMatchDescriptionContains, Pattern: strings.Repeat("a", models.MaxRulePatternLength+1), CategoryID: food.ID},
+			wantErr: models.ErrRulePatternTooLong,
+		},
+		{
+			name:    "wallet rule without wallet",
+			input:   CreateRuleInput{MatchType: models.RuleMatchWallet, CategoryID: food.ID},
+			wantErr: models.ErrRuleWalletRequired,
+		},
+		{
+			name:    "unknown category",
+			input:   CreateRuleInput{MatchType: models.RuleMatchTag, Pattern: "work", CategoryID: uuid.New()},
+			wantErr: ErrCategoryNotFound,
+		},
+		{
+			name:  "valid regex",
+			input: CreateRuleInput{MatchType: models.RuleMatchDescriptionRegex, Pattern: "^warung", CategoryID: food.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Create(ctx, tt.input)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleService_Autocategorize(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := newTestRuleService(repos)
+	txService := newTestTransactionService(repos)
+	ctx := context.Background()
+
+	wallet := repos.createWallet(t, "BCA", 1000000)
+	food := repos.createCategory(t, "Food", models.CategoryTypeExpense)
+
+	add := func(desc string, tags ...string) *models.Transaction {
+		tx, err := txService.Create(ctx, CreateTransactionInput{
+			WalletID:    wallet.ID,
+			Type:        models.TransactionTypeExpense,
+			Amount:      decimal.NewFromInt(10000),
+			Description: desc,
+			Tags:        tags,
+		})
+		if err != nil {
+			t.Fatalf("Create(%q) error = %v", desc, err)
+		}
+		return tx
+	}
+	add("Warung Padang")
+	add("warung kopi")
+	add("Warung (adjusted)", models.TagAdjustment)
+	add("Rent")
+
+	if _, err := svc.Create(ctx, CreateRuleInput{MatchType: models.RuleMatchDescriptionContains, Pattern: "warung", CategoryID: food.ID}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	preview, err := svc.PreviewAutocategorize(ctx)
+	if err != nil {
+		t.Fatalf("PreviewAutocategorize() error = %v", err)
+	}
+	if len(preview.Matches) != 2 || preview.Unmatched != 1 {
+		t.Fatalf("preview = %d matches, %d unmatched; want 2, 1", len(preview.Matches), preview.Unmatched)
+	}
+
+	n, err := svc.ApplyAutocategorize(ctx, preview.Matches)
+	if err != nil || n != 2 {
+		t.Fatalf("ApplyAutocategorize() = %d, %v; want 2", n, err)
+	}
+	for _, m := range preview.Matches {
+		tx, _ := repos.transaction.GetByID(ctx, m.Transaction.ID)
+		if tx.CategoryID == nil || *tx.CategoryID != food.ID {
+			t.Errorf("%q category = %v, want Food", tx.Description, tx.CategoryID)
+		}
+	}
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(960000)) {
+		t.Errorf("balance = %s, want unchanged 960000", got)
+	}
+
+	preview, err = svc.PreviewAutocategorize(ctx)
+	if err != nil || len(preview.Matches) != 0 {
+		t.Errorf("second preview = %v, %v; want no matches", preview, err)
+	}
+}
+
+func TestTransactionService_CreateAppliesRule(t *testing.T) {
+	repos := newMemoryRepos()
+	ctx := context.Background()
+	txService := newTestTransactionService(repos)
+	txService.SetRuleRepository(repos.rule)
+
+	wallet := repos.createWallet(t, "GoPay", 100000)
+	transport := repos.createCategory(t, "Transport", models.CategoryTypeExpense)
+	food := repos.createCategory(t, "Food", models.CategoryTypeExpense)
+
+	if _, err := newTestRuleService(repos).Create(ctx, CreateRuleInput{
+		MatchType: models.RuleMatchWallet, WalletID: &wallet.ID, CategoryID: transport.ID,
+	}); err != nil {
+		t.Fatalf("Create rule error = %v", err)
+	}
+
+	input := CreateTransactionInput{
+		WalletID: wallet.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(15000),
+	}
+	tx, err := txService.Create(ctx, input)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if tx.CategoryID == nil || *tx.CategoryID != transport.ID {
+		t.Errorf("CategoryID = %v, want Transport from the wallet rule", tx.CategoryID)
+	}
+
+	// Kategori eksplisit tidak ditimpa rule
+	input.CategoryID = &food.ID
+	tx, err = txService.Create(ctx, input)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if *tx.CategoryID != food.ID {
+		t.Errorf("CategoryID = %v, want the explicit Food category", tx.CategoryID)
+	}
+
+	// BulkCreate memakai rule yang sama
+	txs, err := txService.BulkCreate(ctx, []CreateTransactionInput{{
+		WalletID: wallet.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(5000),
+	}, input})
+	if err != nil {
+		t.Fatalf("BulkCreate() error = %v", err)
+	}
+	if *txs[0].CategoryID != transport.ID || *txs[1].CategoryID != food.ID {
+		t.Errorf("BulkCreate categories = %v, %v, want Transport and Food", txs[0].CategoryID, txs[1].CategoryID)
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Scheduler menjalankan background jobs secara berkala.
+//
+// Job yang dijalankan setiap putaran:
+// 1. Process recurring transactions yang jatuh tempo
+// 2. Roll over budget ke periode berjalan
+//
+// Setiap putaran ditambah jitter random supaya beberapa instance
+// yang jalan bersamaan tidak bangun di detik yang sama.
+//
+//	scheduler := service.NewScheduler(recurringService, budgetService, service.SchedulerOptions{
+//	    Interval: time.Hour,
+//	    Jitter:   time.Minute,
+//	})
+//	err := scheduler.Run(ctx) // blocking sampai ctx di-cancel
+type Scheduler struct {
+	jobs     []schedulerJob
+	interval time.Duration
+	jitter   time.Duration
+	logf     func(format string, args ...interface{})
+}
+
+// schedulerJob adalah satu unit kerja scheduler.
+// run mengembalikan jumlah item yang diproses.
+type schedulerJob struct {
+	name string
+	run  func(ctx context.Context) (int, error)
+}
+
+// NewScheduler membuat Scheduler baru.
+func NewScheduler(
+	recurringService *RecurringService,
+	budgetService *BudgetService,
+	opts SchedulerOptions,
+) *Scheduler {
+	s := &Scheduler{
+		interval: opts.Interval,
+		jitter:   opts.Jitter,
+		logf:     opts.Logf,
+	}
+	if s.interval <= 0 {
+		s.interval = DefaultSchedulerInterval
+	}
+	if s.logf == nil {
+		s.logf = func(string, ...interface{}) {}
+	}
+
+	s.jobs = []schedulerJob{
+		{name: "recurring", run: recurringService.ProcessDue},
+		{name: "budget rollover", run: func(ctx context.Context) (int, error) {
+			return budgetService.Rollover(ctx, time.Now())
+		}},
+	}
+
+	return s
+}
+
+// RunOnce menjalankan semua job satu kali.
+//
+// Error di satu job tidak menghentikan job berikutnya;
+// error pertama yang terjadi dikembalikan setelah semua job selesai.
+func (s *Scheduler) RunOnce(ctx context.Context) error {
+	var firstErr error
+	for _, job := range s.jobs {
+		n, err := job.run(ctx)
+		if err != nil {
+			s.logf("%s failed: %v", job.name, err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", job.name, err)
+			}
+			continue
+		}
+		s.logf("%s: %d processed", job.name, n)
+	}
+	return firstErr
+}
+
+// Run menjalankan job secara berkala sampai ctx di-cancel.
+//
+// Job langsung dijalankan sekali saat start, lalu setiap interval + jitter.
+// Error dari job hanya di-log supaya daemon tetap hidup.
+// Return nil saat ctx di-cancel (shutdown normal).
+func (s *Scheduler) Run(ctx context.Context) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		_ = s.RunOnce(ctx)
+
+		delay := s.nextDelay()
+		s.logf("next run at %s", time.Now().Add(delay).Format(time.RFC3339))
+		timer.Reset(delay)
+	}
+}
+
+// nextDelay menghitung jeda ke putaran berikutnya: interval + [0, jitter).
+func (s *Scheduler) nextDelay() time.Duration {
+	if s.jitter <= 0 {
+		return s.interval
+	}
+	return s.interval + time.Duration(rand.Int64N(int64(s.jitter)))
+}
+
+// DefaultSchedulerInterval adalah interval default jika tidak di-set.
+const DefaultSchedulerInterval = time.Hour
+
+// SchedulerOptions adalah konfigurasi Scheduler.
+type SchedulerOptions struct {
+	// Interval antar putaran. Default: 1 jam.
+	Interval time.Duration
+
+	// Jitter maksimal yang ditambahkan ke interval. 0 = tanpa jitter.
+	Jitter time.Duration
+
+	// Logf dipanggil untuk setiap log line. nil = silent.
+	Logf func(format string, args ...interface{})
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduler_NextDelay(t *testing.T) {
+	s := &Scheduler{interval: time.Minute, jitter: 10 * time.Second}
+
+	for i := 0; i < 100; i++ {
+		d := s.nextDelay()
+		if d < time.Minute || d >= time.Minute+10*time.Second {
+			t.Fatalf("nextDelay() = %v, want in [1m, 1m10s)", d)
+		}
+	}
+
+	s.jitter = 0
+	if d := s.nextDelay(); d != time.Minute {
+		t.Errorf("nextDelay() without jitter = %v, want 1m", d)
+	}
+}
+
+func TestScheduler_RunOnce(t *testing.T) {
+	var ran []string
+	s := &Scheduler{
+		logf: func(string, ...interface{}) {},
+		jobs: []schedulerJob{
+			{name: "failing", run: func(ctx context.Context) (int, error) {
+				ran = append(ran, "failing")
+				return 0, errors.New("boom")
+			}},
+			{name: "ok", run: func(ctx context.Context) (int, error) {
+				ran = append(ran, "ok")
+				return 1, nil
+			}},
+		},
+	}
+
+	err := s.RunOnce(context.Background())
+	if err == nil {
+		t.Error("expected error from failing job")
+	}
+	if len(ran) != 2 {
+		t.Errorf("ran = %v, want both jobs to run", ran)
+	}
+}
+
+func TestScheduler_RunStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runs := 0
+	s := &Scheduler{
+		interval: time.Hour,
+		logf:     func(string, ...interface{}) {},
+		jobs: []schedulerJob{
+			{name: "count", run: func(ctx context.Context) (int, error) {
+				runs++
+				cancel()
+				return 0, nil
+			}},
+		},
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error = %v, want nil on cancel", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not return after context cancel")
+	}
+
+	if runs != 1 {
+		t.Errorf("runs = %d, want 1", runs)
+	}
+}
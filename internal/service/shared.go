@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// Default tag untuk laporan pengeluaran bersama.
+const (
+	DefaultSharedTag     = "shared"
+	DefaultReimbursedTag = "reimbursed"
+)
+
+var (
+	// ErrSharedTagRequired dikembalikan jika tag pengeluaran bersama kosong.
+	ErrSharedTagRequired = errors.New("shared tag is required")
+
+	// ErrInvalidSplit dikembalikan untuk split di luar 0-100.
+	ErrInvalidSplit = errors.New("split must be between 0 and 100")
+
+	// ErrSharedTagConflict dikembalikan jika tag reimbursed atau exclude
+	// sama dengan tag pengeluaran bersama.
+	ErrSharedTagConflict = errors.New("reimbursed and exclude tags must differ from the shared tag")
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// SharedExpenseService menghitung settle-up pengeluaran bersama: transaksi
+// dengan tag tertentu (misalnya #shared) yang dibayar user lalu dibagi
+// dengan satu orang lain (partner).
+type SharedExpenseService struct {
+	txService *TransactionService
+}
+
+// NewSharedExpenseService membuat instance baru SharedExpenseService.
+func NewSharedExpenseService(txService *TransactionService) *SharedExpenseService {
+	return &SharedExpenseService{txService: txService}
+}
+
+// Report mengambil semua transaksi dengan opts.Tag dalam filter (biasanya
+// hanya StartDate/EndDate) lalu menghitung SettleShared. Transaksi
+// adjustment tidak dihitung.
+//
+//	report, err := sharedService.Report(ctx, thisMonth, service.SharedExpenseOptions{
+//	    Tag:   "shared",
+//	    Split: decimal.NewFromInt(60),
+//	}, refs)
+//	fmt.Println(report.Balance()) // positif: partner berutang ke user
+func (s *SharedExpenseService) Report(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+	opts SharedExpenseOptions,
+	refs *RefData,
+) (*SharedExpenseReport, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	filter.Tags = []string{opts.Tag}
+	filter.ExcludeAdjustments = true
+
+	var txs []*models.Transaction
+	for offset := 0; ; offset += groupPageSize {
+		page, err := s.txService.List(ctx, filter, repository.ListParams{Limit: groupPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, page...)
+		if len(page) < groupPageSize {
+			break
+		}
+	}
+
+	return SettleShared(txs, opts, refs)
+}
+
+// SettleShared membagi transaksi bertag opts.Tag antara user dan partner.
+//
+// Aturan per transaksi:
+//   - punya opts.ExcludeTag → diabaikan
+//   - punya opts.ReimbursedTag → reimbursement, bukan pengeluaran:
+//     income adalah uang dari partner, expense adalah uang ke partner
+//   - expense lain → pengeluaran bersama yang dibayar user
+//   - income lain → refund pengeluaran bersama (mengurangi total)
+//
+// Bagian user adalah opts.Split persen dari total (dibulatkan 2 desimal),
+// bagian partner sisanya. Amount dihitung dalam currency default
+// (Transaction.AmountInBase) karena transaksi bisa dari banyak wallet.
+func SettleShared(txs []*models.Transaction, opts SharedExpenseOptions, refs *RefData) (*SharedExpenseReport, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	report := &SharedExpenseReport{Split: opts.Split}
+	byCategory := make(map[uuid.UUID]*SharedCategory)
+
+	for _, tx := range txs {
+		if !tx.HasTag(opts.Tag) || (opts.ExcludeTag != "" && tx.HasTag(opts.ExcludeTag)) {
+			continue
+		}
+
+		amount := tx.AmountInBase()
+		if tx.HasTag(opts.ReimbursedTag) {
+			report.Reimbursements = append(report.Reimbursements, tx)
+			if tx.Type == models.TransactionTypeIncome {
+				report.Reimbursed = report.Reimbursed.Add(amount)
+			} else {
+				report.Reimbursed = report.Reimbursed.Sub(amount)
+			}
+			continue
+		}
+
+		if tx.Type == models.TransactionTypeIncome {
+			amount = amount.Neg()
+		}
+		report.Expenses = append(report.Expenses, tx)
+		report.Total = report.Total.Add(amount)
+
+		var key uuid.UUID
+		if tx.CategoryID != nil {
+			key = *tx.CategoryID
+		}
+		c, ok := byCategory[key]
+		if !ok {
+			c = &SharedCategory{CategoryID: tx.CategoryID, Name: refs.CategoryName(tx.CategoryID)}
+			byCategory[key] = c
+			report.Categories = append(report.Categories, c)
+		}
+		c.Total = c.Total.Add(amount)
+	}
+
+	report.YourShare = shareOf(report.Total, opts.Split)
+	report.PartnerShare = report.Total.Sub(report.YourShare)
+	for _, c := range report.Categories {
+		c.YourShare = shareOf(c.Total, opts.Split)
+		c.PartnerShare = c.Total.Sub(c.YourShare)
+	}
+
+	sort.SliceStable(report.Categories, func(i, j int) bool {
+		a, b := report.Categories[i], report.Categories[j]
+		if !a.Total.Equal(b.Total) {
+			return a.Total.GreaterThan(b.Total)
+		}
+		return a.Name < b.Name
+	})
+	return report, nil
+}
+
+// shareOf menghitung split persen dari amount, dibulatkan 2 desimal.
+func shareOf(amount, split decimal.Decimal) decimal.Decimal {
+	return amount.Mul(split).Div(hundred).Round(2)
+}
+
+// SharedExpenseOptions adalah parameter laporan pengeluaran bersama.
+type SharedExpenseOptions struct {
+	// Tag menandai pengeluaran bersama (tanpa #). Default DefaultSharedTag.
+	Tag string
+
+	// ReimbursedTag menandai reimbursement antara user dan partner, di
+	// transaksi yang juga punya Tag. Default DefaultReimbursedTag.
+	ReimbursedTag string
+
+	// ExcludeTag (opsional) mengecualikan transaksi dari laporan.
+	ExcludeTag string
+
+	// Split adalah bagian user dalam persen (0-100); 50 berarti dibagi rata.
+	Split decimal.Decimal
+}
+
+// normalize mengisi default, menyamakan format tag dengan
+// Transaction.AddTag, dan memvalidasi opsi.
+func (o *SharedExpenseOptions) normalize() error {
+	clean := func(tag string) string {
+		return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	}
+	o.Tag, o.ReimbursedTag, o.ExcludeTag = clean(o.Tag), clean(o.ReimbursedTag), clean(o.ExcludeTag)
+	if o.ReimbursedTag == "" {
+		o.ReimbursedTag = DefaultReimbursedTag
+	}
+
+	switch {
+	case o.Tag == "":
+		return ErrSharedTagRequired
+	case o.ReimbursedTag == o.Tag || o.ExcludeTag == o.Tag:
+		return ErrSharedTagConflict
+	case o.Split.IsNegative() || o.Split.GreaterThan(hundred):
+		return fmt.Errorf("%w, got %s", ErrInvalidSplit, o.Split)
+	}
+	return nil
+}
+
+// SharedExpenseReport adalah hasil SettleShared.
+type SharedExpenseReport struct {
+	// Split adalah bagian user dalam persen.
+	Split decimal.Decimal
+
+	// Expenses adalah pengeluaran bersama (dan refund-nya), urut seperti
+	// input.
+	Expenses []*models.Transaction
+
+	// Reimbursements adalah transaksi dengan tag reimbursed.
+	Reimbursements []*models.Transaction
+
+	// Total adalah pengeluaran bersama dikurangi refund.
+	Total decimal.Decimal
+
+	// YourShare + PartnerShare = Total.
+	YourShare    decimal.Decimal
+	PartnerShare decimal.Decimal
+
+	// Reimbursed adalah uang yang sudah diterima dari partner dikurangi
+	// uang yang sudah dikirim ke partner.
+	Reimbursed decimal.Decimal
+
+	// Categories adalah Total per kategori, terbesar dulu.
+	Categories []*SharedCategory
+}
+
+// Balance adalah sisa yang harus dibayar partner ke user. Negatif berarti
+// user yang berutang ke partner (reimbursement melebihi bagian partner).
+func (r *SharedExpenseReport) Balance() decimal.Decimal {
+	return r.PartnerShare.Sub(r.Reimbursed)
+}
+
+// PartnerShareOf adalah bagian partner dari satu pengeluaran bersama,
+// dengan pembulatan yang sama seperti PartnerShare.
+func (r *SharedExpenseReport) PartnerShareOf(tx *models.Transaction) decimal.Decimal {
+	amount := tx.AmountInBase()
+	if tx.Type == models.TransactionTypeIncome {
+		amount = amount.Neg()
+	}
+	return amount.Sub(shareOf(amount, r.Split))
+}
+
+// SharedCategory adalah pengeluaran bersama satu kategori.
+type SharedCategory struct {
+	// CategoryID nil untuk transaksi tanpa kategori.
+	CategoryID *uuid.UUID
+	Name       string
+
+	Total        decimal.Decimal
+	YourShare    decimal.Decimal
+	PartnerShare decimal.Decimal
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// sharedTx membuat transaksi dengan tags untuk test settle-up.
+func sharedTx(txType models.TransactionType, amount string, tags ...string) *models.Transaction {
+	tx := models.NewTransaction(models.NewID(), txType, decimal.RequireFromString(amount))
+	for _, tag := range tags {
+		tx.AddTag(tag)
+	}
+	return tx
+}
+
+func TestSettleShared(t *testing.T) {
+	expense, income := models.TransactionTypeExpense, models.TransactionTypeIncome
+
+	tests := []struct {
+		name        string
+		split       int64
+		txs         []*models.Transaction
+		wantTotal   string
+		wantPartner string
+		wantBalance string
+	}{
+		{
+			name:        "even split",
+			split:       50,
+			txs:         []*models.Transaction{sharedTx(expense, "300000", "shared"), sharedTx(expense, "100000", "shared")},
+			wantTotal:   "400000",
+			wantPartner: "200000",
+			wantBalance: "200000",
+		},
+		{
+			name:        "uneven split",
+			split:       60,
+			txs:         []*models.Transaction{sharedTx(expense, "100000", "shared")},
+			wantTotal:   "100000",
+			wantPartner: "40000",
+			wantBalance: "40000",
+		},
+		{
+			name:  "partial reimbursement",
+			split: 50,
+			txs: []*models.Transaction{
+				sharedTx(expense, "400000", "shared"),
+				sharedTx(income, "150000", "shared", "reimbursed"),
+			},
+			wantTotal:   "400000",
+			wantPartner: "200000",
+			wantBalance: "50000",
+		},
+		{
+			name:  "reimbursement exceeds the owed share",
+			split: 50,
+			txs: []*models.Transaction{
+				sharedTx(expense, "100000", "shared"),
+				sharedTx(income, "80000", "shared", "reimbursed"),
+			},
+			wantTotal:   "100000",
+			wantPartner: "50000",
+			wantBalance: "-30000",
+		},
+		{
+			name:  "money sent to the partner",
+			split: 50,
+			txs: []*models.Transaction{
+				sharedTx(expense, "100000", "shared"),
+				sharedTx(income, "80000", "shared", "reimbursed"),
+				sharedTx(expense, "30000", "shared", "reimbursed"),
+			},
+			wantTotal:   "100000",
+			wantPartner: "50000",
+			wantBalance: "0",
+		},
+		{
+			name:  "refund reduces the shared spend",
+			split: 50,
+			txs: []*models.Transaction{
+				sharedTx(expense, "100000", "shared"),
+				sharedTx(income, "20000", "shared"),
+			},
+			wantTotal:   "80000",
+			wantPartner: "40000",
+			wantBalance: "40000",
+		},
+		{
+			name:  "excluded and untagged",
+			split: 50,
+			txs: []*models.Transaction{
+				sharedTx(expense, "100000", "shared"),
+				sharedTx(expense, "999999", "shared", "personal"),
+				sharedTx(expense, "555555"),
+			},
+			wantTotal:   "100000",
+			wantPartner: "50000",
+			wantBalance: "50000",
+		},
+		{
+			name:        "rounding goes to the partner share",
+			split:       50,
+			txs:         []*models.Transaction{sharedTx(expense, "0.03", "shared")},
+			wantTotal:   "0.03",
+			wantPartner: "0.01",
+			wantBalance: "0.01",
+		},
+		{
+			name:        "user pays everything",
+			split:       100,
+			txs:         []*models.Transaction{sharedTx(expense, "100000", "shared")},
+			wantTotal:   "100000",
+			wantPartner: "0",
+			wantBalance: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := SettleShared(tt.txs, SharedExpenseOptions{
+				Tag:        "shared",
+				ExcludeTag: "personal",
+				Split:      decimal.NewFromInt(tt.split),
+			}, NewRefData(nil, nil))
+			if err != nil {
+				t.Fatalf("SettleShared() error = %v", err)
+			}
+
+			if !report.Total.Equal(decimal.RequireFromString(tt.wantTotal)) {
+				t.Errorf("Total = %s, want %s", report.Total, tt.wantTotal)
+			}
+			if !report.PartnerShare.Equal(decimal.RequireFromString(tt.wantPartner)) {
+				t.Errorf("PartnerShare = %s, want %s", report.PartnerShare, tt.wantPartner)
+			}
+			if !report.YourShare.Add(report.PartnerShare).Equal(report.Total) {
+				t.Errorf("YourShare %s + PartnerShare %s != Total %s", report.YourShare, report.PartnerShare, report.Total)
+			}
+			if !report.Balance().Equal(decimal.RequireFromString(tt.wantBalance)) {
+				t.Errorf("Balance() = %s, want %s", report.Balance(), tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestSettleShared_Categories(t *testing.T) {
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	rent := models.NewCategory("Rent", models.CategoryTypeExpense)
+	refs := NewRefData(nil, []*models.Category{food, rent})
+
+	groceries := sharedTx(models.TransactionTypeExpense, "150000", "Shared")
+	groceries.SetCategory(food.ID)
+	dinner := sharedTx(models.TransactionTypeExpense, "50000", "shared")
+	dinner.SetCategory(food.ID)
+	flat := sharedTx(models.TransactionTypeExpense, "3000000", "shared")
+	flat.SetCategory(rent.ID)
+	misc := sharedTx(models.TransactionTypeExpense, "10000", "shared")
+
+	report, err := SettleShared([]*models.Transaction{groceries, dinner, flat, misc},
+		SharedExpenseOptions{Tag: "#Shared", Split: decimal.NewFromInt(60)}, refs)
+	if err != nil {
+		t.Fatalf("SettleShared() error = %v", err)
+	}
+
+	want := []struct {
+		name, total, partner string
+	}{
+		{"Rent", "3000000", "1200000"},
+		{"Food", "200000", "80000"},
+		{UncategorizedName, "10000", "4000"},
+	}
+	if len(report.Categories) != len(want) {
+		t.Fatalf("got %d categories, want %d", len(report.Categories), len(want))
+	}
+	for i, w := range want {
+		c := report.Categories[i]
+		if c.Name != w.name || !c.Total.Equal(decimal.RequireFromString(w.total)) || !c.PartnerShare.Equal(decimal.RequireFromString(w.partner)) {
+			t.Errorf("category %d = %s %s (partner %s), want %s %s (partner %s)",
+				i, c.Name, c.Total, c.PartnerShare, w.name, w.total, w.partner)
+		}
+	}
+}
+
+func TestSettleShared_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SharedExpenseOptions
+		want error
+	}{
+		{"no tag", SharedExpenseOptions{Split: decimal.NewFromInt(50)}, ErrSharedTagRequired},
+		{"split above 100", SharedExpenseOptions{Tag: "shared", Split: decimal.NewFromInt(101)}, ErrInvalidSplit},
+		{"negative split", SharedExpenseOptions{Tag: "shared", Split: decimal.NewFromInt(-1)}, ErrInvalidSplit},
+		{"reimbursed equals tag", SharedExpenseOptions{Tag: "shared", ReimbursedTag: "#shared", Split: decimal.NewFromInt(50)}, ErrSharedTagConflict},
+		{"exclude equals tag", SharedExpenseOptions{Tag: "shared", ExcludeTag: "SHARED", Split: decimal.NewFromInt(50)}, ErrSharedTagConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SettleShared(nil, tt.opts, NewRefData(nil, nil)); !errors.Is(err, tt.want) {
+				t.Errorf("SettleShared() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSharedExpenseService_Report(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	wallet := repos.createWallet(t, "BCA", 0)
+
+	now := time.Now()
+	for _, tx := range []*models.Transaction{
+		sharedTx(models.TransactionTypeExpense, "100000", "shared"),
+		sharedTx(models.TransactionTypeExpense, "40000"),
+		sharedTx(models.TransactionTypeIncome, "20000", "shared", "reimbursed"),
+	} {
+		tx.WalletID = wallet.ID
+		tx.TransactionDate = now
+		if err := repos.transaction.Create(ctx, tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Di luar periode
+	old := sharedTx(models.TransactionTypeExpense, "900000", "shared")
+	old.WalletID = wallet.ID
+	old.TransactionDate = now.AddDate(-1, 0, 0)
+	if err := repos.transaction.Create(ctx, old); err != nil {
+		t.Fatal(err)
+	}
+
+	start := now.AddDate(0, 0, -1)
+	end := now.AddDate(0, 0, 1)
+	report, err := NewSharedExpenseService(newTestTransactionService(repos)).Report(ctx,
+		repository.TransactionFilter{StartDate: &start, EndDate: &end},
+		SharedExpenseOptions{Tag: DefaultSharedTag, Split: decimal.NewFromInt(50)}, NewRefData(nil, nil))
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	if len(report.Expenses) != 1 || len(report.Reimbursements) != 1 {
+		t.Errorf("got %d expenses and %d reimbursements, want 1 and 1", len(report.Expenses), len(report.Reimbursements))
+	}
+	if !report.Balance().Equal(decimal.NewFromInt(30000)) {
+		t.Errorf("Balance() = %s, want 30000", report.Balance())
+	}
+}
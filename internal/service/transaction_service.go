@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
 )
 
 // TransactionService menangani business logic untuk transaction operations.
@@ -27,34 +29,409 @@ import (
 //
 // Jika langkah manapun gagal, semua di-rollback.
 type TransactionService struct {
-	txRepo     repository.TransactionRepository
-	walletRepo repository.WalletRepository
-	txManager  repository.TransactionManager
+	txRepo       repository.TransactionRepository
+	walletRepo   repository.WalletRepository
+	categoryRepo repository.CategoryRepository
+	txManager    repository.TransactionManager
+
+	// defaultWalletID dipakai Create jika input.WalletID kosong.
+	defaultWalletID uuid.UUID
+
+	// rejectBeforeWalletDate menolak transaksi sebelum wallet.StartDate().
+	// Jika nonaktif, transaksi itu diterima dan dilaporkan ke warn.
+	rejectBeforeWalletDate bool
+	warn                   func(warning error)
+
+	// monthStartDay adalah tanggal awal periode bulanan (1 = kalender).
+	monthStartDay int
+
+	// fiscalYearStartMonth adalah bulan awal tahun fiskal (1 = kalender).
+	fiscalYearStartMonth int
+
+	// rates dan baseCurrency dipakai untuk menyimpan kurs saat transaksi
+	// dibuat (Transaction.RateToBase). rates nil berarti tidak disimpan.
+	rates        RateProvider
+	baseCurrency string
+
+	// ruleRepo mengisi kategori transaksi tanpa kategori. nil berarti
+	// categorization rule tidak dipakai.
+	ruleRepo repository.RuleRepository
+
+	// maxAmount adalah amount maksimal satu transaksi. 0 = tanpa batas.
+	maxAmount decimal.Decimal
 }
 
 // NewTransactionService membuat TransactionService baru.
 func NewTransactionService(
 	txRepo repository.TransactionRepository,
 	walletRepo repository.WalletRepository,
+	categoryRepo repository.CategoryRepository,
 	txManager repository.TransactionManager,
 ) *TransactionService {
 	return &TransactionService{
-		txRepo:     txRepo,
-		walletRepo: walletRepo,
-		txManager:  txManager,
+		txRepo:       txRepo,
+		walletRepo:   walletRepo,
+		categoryRepo: categoryRepo,
+		txManager:    txManager,
+	}
+}
+
+// SetDefaultWallet mengatur wallet fallback untuk Create (biasanya dari
+// config app.default_wallet). uuid.Nil berarti tidak ada fallback.
+func (s *TransactionService) SetDefaultWallet(id uuid.UUID) {
+	s.defaultWalletID = id
+}
+
+// SetRejectBeforeWalletDate mengaktifkan penolakan transaksi yang tanggalnya
+// sebelum wallet dimulai (config validation.reject_before_wallet_date).
+// Default nonaktif.
+func (s *TransactionService) SetRejectBeforeWalletDate(enabled bool) {
+	s.rejectBeforeWalletDate = enabled
+}
+
+// SetWarningHandler mengatur penerima warning sanity check yang tidak
+// menolak transaksi. Saat ini satu-satunya warning adalah
+// *TransactionBeforeWalletError jika SetRejectBeforeWalletDate nonaktif;
+// warning dikirim sebelum transaksi disimpan. nil berarti warning dibuang.
+//
+//	txService.SetWarningHandler(func(w error) {
+//	    fmt.Fprintln(os.Stderr, "warning:", w)
+//	})
+func (s *TransactionService) SetWarningHandler(fn func(warning error)) {
+	s.warn = fn
+}
+
+// SetMonthStartDay mengatur tanggal awal periode bulanan (config
+// app.month_start_day) untuk GetMonthlySummary, GetTopExpenses dan
+// GetTopExpenseCategories. Default 1 (bulan kalender).
+func (s *TransactionService) SetMonthStartDay(day int) {
+	s.monthStartDay = day
+}
+
+// SetFiscalYearStartMonth mengatur bulan awal tahun fiskal (config
+// app.fiscal_year_start_month) untuk YearFilter dan YearToDateFilter.
+// Default 1 (tahun kalender).
+func (s *TransactionService) SetFiscalYearStartMonth(month int) {
+	s.fiscalYearStartMonth = month
+}
+
+// SetRateProvider mengaktifkan snapshot kurs: transaksi di wallet yang
+// currency-nya bukan base (app.currency) menyimpan kurs saat dibuat,
+// supaya laporan lintas currency tidak memakai kurs hari ini untuk
+// transaksi lama.
+//
+//	rates, _ := cfg.App.ExchangeRates()
+//	txService.SetRateProvider(service.NewStaticRates(cfg.App.Currency, rates), cfg.App.Currency)
+func (s *TransactionService) SetRateProvider(provider RateProvider, baseCurrency string) {
+	s.rates = provider
+	s.baseCurrency = strings.ToUpper(baseCurrency)
+}
+
+// SetMaxAmount mengatur amount maksimal satu transaksi (config
+// app.max_transaction_amount), dalam currency wallet. Create dan
+// BulkCreate menolak amount di atasnya dengan ErrTransactionExceedsLimit.
+// 0 berarti tanpa batas.
+func (s *TransactionService) SetMaxAmount(limit decimal.Decimal) {
+	s.maxAmount = limit
+}
+
+// checkMaxAmount mengembalikan ErrTransactionExceedsLimit jika batas
+// aktif dan amount melebihinya.
+func (s *TransactionService) checkMaxAmount(amount decimal.Decimal) error {
+	if s.maxAmount.IsPositive() && amount.GreaterThan(s.maxAmount) {
+		return fmt.Errorf("%w: %s is above %s", ErrTransactionExceedsLimit, amount, s.maxAmount)
+	}
+	return nil
+}
+
+// SetRuleRepository mengaktifkan categorization rule: Create mengisi
+// kategori transaksi yang dibuat tanpa kategori dari rule pertama yang
+// cocok (lihat RuleEngine).
+func (s *TransactionService) SetRuleRepository(repo repository.RuleRepository) {
+	s.ruleRepo = repo
+}
+
+// ruleCategory mengembalikan kategori dari rule pertama yang cocok
+// dengan tx, atau nil. Transaksi bertag sistem tidak dikategorikan.
+func (s *TransactionService) ruleCategory(ctx context.Context, tx *models.Transaction) (*uuid.UUID, error) {
+	if s.ruleRepo == nil || hasSystemTag(tx) {
+		return nil, nil
+	}
+
+	engine, err := loadRuleEngine(ctx, s.ruleRepo, s.categoryRepo)
+	if err != nil || engine == nil {
+		return nil, err
+	}
+	rule := engine.Match(tx)
+	if rule == nil {
+		return nil, nil
+	}
+	categoryID := rule.CategoryID
+	return &categoryID, nil
+}
+
+// applyRuleCategories mengisi kategori transaksi tanpa kategori dari
+// rule pertama yang cocok, seperti ruleCategory, tapi rule hanya dimuat
+// sekali untuk semua txs.
+func (s *TransactionService) applyRuleCategories(ctx context.Context, txs []*models.Transaction) error {
+	if s.ruleRepo == nil {
+		return nil
+	}
+
+	var engine *RuleEngine
+	loaded := false
+	for _, tx := range txs {
+		if tx.CategoryID != nil || hasSystemTag(tx) {
+			continue
+		}
+		if !loaded {
+			var err error
+			if engine, err = loadRuleEngine(ctx, s.ruleRepo, s.categoryRepo); err != nil {
+				return err
+			}
+			loaded = true
+		}
+		if engine == nil {
+			return nil
+		}
+		if rule := engine.Match(tx); rule != nil {
+			categoryID := rule.CategoryID
+			tx.CategoryID = &categoryID
+		}
+	}
+	return nil
+}
+
+// rateToBase mengembalikan kurs currency ke baseCurrency saat ini, atau
+// nil jika rate provider tidak di-set, currency sama, atau kurs untuk
+// currency itu tidak dikonfigurasi.
+func (s *TransactionService) rateToBase(ctx context.Context, currency string) (*decimal.Decimal, error) {
+	if s.rates == nil || currency == "" || strings.EqualFold(currency, s.baseCurrency) {
+		return nil, nil
+	}
+
+	rate, err := s.rates.Rate(ctx, currency, s.baseCurrency)
+	if errors.Is(err, ErrNoRate) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rate %s/%s: %w", currency, s.baseCurrency, err)
+	}
+	return &rate, nil
+}
+
+// ApplyExchangeRate mengonversi amount dari currency from ke currency to
+// dengan kurs dari rate provider (SetRateProvider), dibulatkan 2 desimal
+// seperti kolom amount. Error membungkus ErrNoRate jika rate provider
+// tidak di-set atau kurs pasangan currency itu tidak dikonfigurasi.
+//
+//	idr, err := txService.ApplyExchangeRate(ctx, decimal.NewFromInt(12), "USD", "IDR")
+func (s *TransactionService) ApplyExchangeRate(ctx context.Context, amount decimal.Decimal, from, to string) (decimal.Decimal, error) {
+	if s.rates == nil {
+		return decimal.Zero, fmt.Errorf("%w: %s/%s", ErrNoRate, from, to)
+	}
+
+	rate, err := s.rates.Rate(ctx, from, to)
+	if errors.Is(err, ErrNoRate) {
+		return decimal.Zero, fmt.Errorf("%w: %s/%s", ErrNoRate, from, to)
+	}
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get exchange rate %s/%s: %w", from, to, err)
+	}
+	return amount.Mul(rate).Round(2), nil
+}
+
+// applyOriginalAmount mengisi input.Amount dari input.OriginalAmount,
+// dikonversi ke currency wallet. Tidak melakukan apa-apa jika
+// OriginalAmount nil.
+func (s *TransactionService) applyOriginalAmount(ctx context.Context, input *CreateTransactionInput, wallet *models.Wallet) error {
+	if input.OriginalAmount == nil {
+		return nil
+	}
+	if !input.OriginalAmount.IsPositive() {
+		return models.ErrTransactionInvalidAmount
+	}
+
+	input.OriginalCurrency = strings.ToUpper(strings.TrimSpace(input.OriginalCurrency))
+	if input.OriginalCurrency == "" {
+		return ErrOriginalCurrencyRequired
+	}
+
+	amount, err := s.ApplyExchangeRate(ctx, *input.OriginalAmount, input.OriginalCurrency, wallet.Currency)
+	if err != nil {
+		return err
+	}
+	input.Amount = amount
+	return nil
+}
+
+// checkWalletDate mengecek apakah date jatuh sebelum hari wallet dimulai.
+// Jika pengecekan aktif, return *TransactionBeforeWalletError; jika
+// tidak, error yang sama dikirim ke warning handler dan return nil.
+// Dibandingkan per hari kalender, jadi transaksi di hari yang sama
+// dengan wallet dibuat tetap diterima walaupun jamnya lebih awal.
+func (s *TransactionService) checkWalletDate(wallet *models.Wallet, date time.Time) error {
+	start := wallet.StartDate()
+	if !calendarDay(date).Before(calendarDay(start)) {
+		return nil
+	}
+
+	err := &TransactionBeforeWalletError{
+		WalletName: wallet.Name,
+		Date:       date,
+		StartDate:  start,
+	}
+	if s.rejectBeforeWalletDate {
+		return err
+	}
+	if s.warn != nil {
+		s.warn(err)
+	}
+	return nil
+}
+
+// calendarDay mengembalikan tanggal kalender t (di timezone t sendiri)
+// sebagai midnight UTC, supaya opening_date (DATE) dan created_at
+// (TIMESTAMPTZ) bisa dibandingkan per hari.
+func calendarDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// walletOrDefault mengembalikan id, atau default wallet jika id kosong.
+func (s *TransactionService) walletOrDefault(id uuid.UUID) (uuid.UUID, error) {
+	if id != uuid.Nil {
+		return id, nil
+	}
+	if s.defaultWalletID == uuid.Nil {
+		return uuid.Nil, ErrWalletRequired
 	}
+	return s.defaultWalletID, nil
 }
 
 // Common errors
 var (
 	ErrInsufficientBalance = errors.New("insufficient wallet balance")
+	ErrNoAdjustmentNeeded  = errors.New("wallet balance already matches target")
+	ErrCategoryNotFound    = errors.New("category not found")
+
+	// ErrWalletRequired dikembalikan jika WalletID kosong dan
+	// tidak ada default wallet (lihat SetDefaultWallet).
+	ErrWalletRequired = errors.New("wallet is required (use --wallet or set app.default_wallet)")
+
+	// ErrTransferAsTransaction dikembalikan jika transfer dicoba dicatat
+	// sebagai transaksi income/expense. Gunakan TransferService.
+	ErrTransferAsTransaction = errors.New("transfers must be recorded with the transfer command, not as income/expense")
+
+	// ErrTransactionBeforeWallet adalah sentinel untuk
+	// TransactionBeforeWalletError, dipakai dengan errors.Is.
+	ErrTransactionBeforeWallet = errors.New("transaction date is before the wallet start date")
+
+	// ErrIdempotencyKeyReused dikembalikan jika idempotency key sudah
+	// dipakai transaksi lain dengan wallet, tipe atau amount berbeda.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different transaction")
+
+	// ErrOriginalCurrencyRequired dikembalikan jika OriginalAmount diisi
+	// tanpa OriginalCurrency.
+	ErrOriginalCurrencyRequired = errors.New("original currency is required with an original amount")
+
+	// ErrCategoryTypeMismatch dikembalikan jika kategori income dipakai
+	// untuk transaksi expense, atau sebaliknya.
+	ErrCategoryTypeMismatch = errors.New("category type does not match transaction type")
+
+	// ErrSystemTag dikembalikan jika user mencoba menambah atau menghapus
+	// tag yang dikelola aplikasi (adjustment, revaluation, transfer).
+	ErrSystemTag = errors.New("system tags cannot be changed")
+
+	// ErrTransactionExceedsLimit dikembalikan jika amount melebihi
+	// app.max_transaction_amount (lihat SetMaxAmount).
+	ErrTransactionExceedsLimit = errors.New("transaction amount exceeds app.max_transaction_amount")
+
+	// ErrBulkInputInvalid adalah sentinel untuk BulkInputError, dipakai
+	// dengan errors.Is.
+	ErrBulkInputInvalid = errors.New("bulk input rejected")
 )
 
+// BulkInputError dikembalikan BulkCreate jika input ditolak sebelum
+// apa pun ditulis ke database. Caller boleh memproses ulang input satu
+// per satu; error lain (dari database) tidak aman diulang.
+//
+//	if errors.Is(err, service.ErrBulkInputInvalid) {
+//	    // fallback ke Create per input
+//	}
+type BulkInputError struct {
+	// Row adalah nomor input (mulai dari 1), atau 0 jika error tidak
+	// berasal dari satu input (misalnya saldo akhir wallet tidak cukup).
+	Row int
+	Err error
+}
+
+func (e *BulkInputError) Error() string {
+	if e.Row == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("transaction %d: %v", e.Row, e.Err)
+}
+
+func (e *BulkInputError) Unwrap() error {
+	return e.Err
+}
+
+// Is membuat errors.Is(err, ErrBulkInputInvalid) bernilai true.
+func (e *BulkInputError) Is(target error) bool {
+	return target == ErrBulkInputInvalid
+}
+
+// TransactionBeforeWalletError dikembalikan Create jika
+// SetRejectBeforeWalletDate aktif dan tanggal transaksi sebelum
+// wallet.StartDate(). Jika nonaktif, nilai yang sama dikirim ke
+// SetWarningHandler.
+//
+//	var dateErr *service.TransactionBeforeWalletError
+//	if errors.As(err, &dateErr) {
+//	    fmt.Println("wallet starts at", dateErr.StartDate)
+//	}
+type TransactionBeforeWalletError struct {
+	WalletName string
+	Date       time.Time
+	StartDate  time.Time
+}
+
+func (e *TransactionBeforeWalletError) Error() string {
+	return fmt.Sprintf("transaction date %s is before wallet %q started on %s",
+		e.Date.Format("2006-01-02"), e.WalletName, e.StartDate.Format("2006-01-02"))
+}
+
+// Is membuat errors.Is(err, ErrTransactionBeforeWallet) bernilai true.
+func (e *TransactionBeforeWalletError) Is(target error) bool {
+	return target == ErrTransactionBeforeWallet
+}
+
 // Create membuat transaksi baru dan update wallet balance.
 //
 // Income: wallet.balance += amount
 // Expense: wallet.balance -= amount (error jika tidak cukup)
 //
+// Transaksi dengan tag models.TagTransfer ditolak (ErrTransferAsTransaction)
+// karena transfer harus lewat TransferService agar tidak terhitung
+// sebagai income/expense.
+//
+// Jika WalletID kosong, default wallet (SetDefaultWallet) yang dipakai.
+// Jika SetRejectBeforeWalletDate aktif, transaksi sebelum wallet dimulai
+// ditolak dengan *TransactionBeforeWalletError; jika tidak, error itu
+// dikirim ke SetWarningHandler sebagai warning.
+//
+// Amount di atas SetMaxAmount ditolak dengan ErrTransactionExceedsLimit,
+// sebelum saldo dicek.
+//
+// Jika CategoryID nil dan SetRuleRepository di-set, kategori diisi dari
+// categorization rule pertama yang cocok.
+//
+// Jika IdempotencyKey diisi dan sudah pernah dipakai, transaksi yang sudah
+// ada dikembalikan tanpa membuat transaksi baru atau mengubah saldo.
+// Key yang sama dengan wallet, tipe atau amount berbeda ditolak dengan
+// ErrIdempotencyKeyReused.
+//
 // Contoh:
 //
 //	tx, err := txService.Create(ctx, service.CreateTransactionInput{
@@ -65,58 +442,392 @@ var (
 //	    Description: "Makan siang",
 //	})
 func (s *TransactionService) Create(ctx context.Context, input CreateTransactionInput) (*models.Transaction, error) {
-	// Get wallet and validate
-	wallet, err := s.walletRepo.GetByID(ctx, input.WalletID)
+	tx, _, err := s.CreateIdempotent(ctx, input)
+	return tx, err
+}
+
+// CreateIdempotent sama dengan Create, ditambah replayed: true jika
+// IdempotencyKey sudah pernah dipakai dan tx adalah transaksi yang sudah
+// ada (tidak ada yang ditulis). Dipakai caller yang perlu membedakan
+// transaksi baru dari request ulang tanpa membandingkan waktu.
+//
+//	tx, replayed, err := txService.CreateIdempotent(ctx, input)
+//	if replayed {
+//	    fmt.Println("already recorded:", tx.ID)
+//	}
+func (s *TransactionService) CreateIdempotent(ctx context.Context, input CreateTransactionInput) (tx *models.Transaction, replayed bool, err error) {
+	walletID, err := s.walletOrDefault(input.WalletID)
 	if err != nil {
-		return nil, fmt.Errorf("wallet not found: %w", err)
+		return nil, false, err
 	}
+	input.WalletID = walletID
 
-	if !wallet.IsActive {
-		return nil, errors.New("cannot create transaction on inactive wallet")
+	// Request yang diulang: kembalikan transaksi yang sudah ada
+	input.IdempotencyKey = strings.TrimSpace(input.IdempotencyKey)
+	if existing, err := s.findByIdempotencyKey(ctx, input); existing != nil || err != nil {
+		return existing, existing != nil, err
+	}
+
+	transaction, wallet, err := s.buildTransaction(ctx, input, newCreateLookup())
+	if err != nil {
+		return nil, false, err
 	}
 
 	// Check balance for expense
 	if input.Type == models.TransactionTypeExpense {
-		if wallet.Balance.LessThan(input.Amount) {
-			return nil, ErrInsufficientBalance
+		if wallet.Balance.LessThan(transaction.Amount) {
+			return nil, false, ErrInsufficientBalance
 		}
 	}
 
-	// Create transaction model
-	transaction := &models.Transaction{
-		BaseModel:       models.BaseModel{ID: models.NewID()},
-		WalletID:        input.WalletID,
-		CategoryID:      input.CategoryID,
-		Type:            input.Type,
-		Amount:          input.Amount,
-		Description:     input.Description,
-		Tags:            input.Tags,
-		TransactionDate: input.Date,
+	if transaction.CategoryID == nil {
+		if transaction.CategoryID, err = s.ruleCategory(ctx, transaction); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// Calculate new balance
+	newBalance := wallet.Balance
+	if input.Type == models.TransactionTypeIncome {
+		newBalance = newBalance.Add(transaction.Amount)
+	} else {
+		newBalance = newBalance.Sub(transaction.Amount)
+	}
+
+	// Execute in transaction
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.txRepo.Create(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+
+		change := models.BalanceChange{Reason: models.BalanceReasonTransactionCreated, ReferenceID: &transaction.ID}
+		if err := s.walletRepo.UpdateBalance(ctx, wallet.ID, newBalance, change); err != nil {
+			return fmt.Errorf("failed to update balance: %w", err)
+		}
+
+		return nil
+	})
+
+	// Request lain dengan key yang sama menang duluan (unique constraint).
+	// Error lookup diabaikan supaya error asli yang dikembalikan.
+	if errors.Is(err, repository.ErrDuplicateKey) && input.IdempotencyKey != "" {
+		if existing, _ := s.findByIdempotencyKey(ctx, input); existing != nil {
+			return existing, true, nil
+		}
 	}
 
+	if err != nil {
+		return nil, false, err
+	}
+
+	return transaction, false, nil
+}
+
+// BulkCreate membuat banyak transaksi dalam satu database transaction:
+// semua tersimpan, atau tidak ada sama sekali jika satu saja gagal.
+// Validasinya sama dengan Create, kecuali saldo: yang dicek hanya saldo
+// akhir setiap wallet setelah semua input, supaya expense tidak ditolak
+// karena income di input lain belum masuk. Setiap wallet di-update sekali
+// dengan net perubahannya (WalletRepository.AdjustBalance).
+//
+// Input yang ditolak sebelum menulis apa pun dikembalikan sebagai
+// *BulkInputError dengan nomor input (mulai dari 1).
+//
+// Semua input divalidasi sebelum menulis apa pun; wallet, kategori dan
+// categorization rules hanya diambil sekali, dan transaksi disimpan
+// dengan TransactionRepository.CreateBatch. Jadi jumlah query ke database
+// mengikuti jumlah wallet dan kategori, bukan jumlah input.
+//
+// Transaksi dikembalikan sesuai urutan inputs.
+//
+//	txs, err := txService.BulkCreate(ctx, []service.CreateTransactionInput{...})
+func (s *TransactionService) BulkCreate(ctx context.Context, inputs []CreateTransactionInput) ([]*models.Transaction, error) {
+	return s.bulkCreate(ctx, inputs, models.BalanceReasonImport)
+}
+
+// bulkCreate adalah BulkCreate dengan alasan balance audit yang bisa
+// diatur, misalnya models.BalanceReasonRecurring untuk ProcessDue.
+func (s *TransactionService) bulkCreate(
+	ctx context.Context,
+	inputs []CreateTransactionInput,
+	reason models.BalanceChangeReason,
+) ([]*models.Transaction, error) {
+	transactions := make([]*models.Transaction, len(inputs))
+	lookup := newCreateLookup()
+
+	for i, input := range inputs {
+		if err := s.rejectUsedIdempotencyKey(ctx, input.IdempotencyKey); err != nil {
+			return nil, &BulkInputError{Row: i + 1, Err: err}
+		}
+		tx, _, err := s.buildTransaction(ctx, input, lookup)
+		if err != nil {
+			return nil, &BulkInputError{Row: i + 1, Err: err}
+		}
+		transactions[i] = tx
+	}
+	wallets := lookup.wallets
+
+	if err := s.applyRuleCategories(ctx, transactions); err != nil {
+		return nil, err
+	}
+
+	// Net perubahan saldo per wallet
+	deltas := make(map[uuid.UUID]decimal.Decimal, len(wallets))
+	for _, tx := range transactions {
+		if tx.Type == models.TransactionTypeIncome {
+			deltas[tx.WalletID] = deltas[tx.WalletID].Add(tx.Amount)
+		} else {
+			deltas[tx.WalletID] = deltas[tx.WalletID].Sub(tx.Amount)
+		}
+	}
+	for id, delta := range deltas {
+		if wallet := wallets[id]; wallet.Balance.Add(delta).IsNegative() {
+			return nil, &BulkInputError{Err: fmt.Errorf("wallet %s: %w", wallet.Name, ErrInsufficientBalance)}
+		}
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.txRepo.CreateBatch(ctx, transactions); err != nil {
+			return fmt.Errorf("failed to create transactions: %w", err)
+		}
+		for id, delta := range deltas {
+			balance, err := s.walletRepo.AdjustBalance(ctx, id, delta, models.BalanceChange{Reason: reason})
+			if err != nil {
+				return fmt.Errorf("failed to update balance: %w", err)
+			}
+			// Saldo bisa berubah sejak dibaca di atas; yang menentukan
+			// adalah saldo setelah delta diterapkan di database.
+			if balance.IsNegative() {
+				return fmt.Errorf("wallet %s: %w", wallets[id].Name, ErrInsufficientBalance)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// createLookup menyimpan wallet yang sudah diambil dan kategori yang
+// sudah dicek oleh buildTransaction, supaya batch tidak mengulang query
+// yang sama untuk setiap input.
+type createLookup struct {
+	wallets    map[uuid.UUID]*models.Wallet
+	categories map[uuid.UUID]bool
+}
+
+// newCreateLookup membuat createLookup kosong.
+func newCreateLookup() *createLookup {
+	return &createLookup{
+		wallets:    make(map[uuid.UUID]*models.Wallet),
+		categories: make(map[uuid.UUID]bool),
+	}
+}
+
+// buildTransaction memvalidasi satu input dan membuat model transaksinya
+// (belum disimpan), beserta wallet-nya. Dipakai Create dan BulkCreate
+// supaya validasi keduanya selalu sama: tag transfer, wallet aktif,
+// kategori, konversi currency, batas amount, kurs, dan tanggal wallet.
+//
+// Yang tidak dicek di sini karena berbeda antara keduanya: idempotency
+// key (Create mengembalikan transaksi lama, batch menolak input-nya),
+// saldo (Create per transaksi, batch per wallet setelah semua input), dan
+// categorization rules (batch sekaligus lewat applyRuleCategories).
+func (s *TransactionService) buildTransaction(
+	ctx context.Context,
+	input CreateTransactionInput,
+	lookup *createLookup,
+) (*models.Transaction, *models.Wallet, error) {
+	for _, tag := range input.Tags {
+		if strings.EqualFold(strings.TrimSpace(tag), models.TagTransfer) {
+			return nil, nil, ErrTransferAsTransaction
+		}
+	}
+
+	walletID, err := s.walletOrDefault(input.WalletID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wallet, ok := lookup.wallets[walletID]
+	if !ok {
+		wallet, err = s.walletRepo.GetByID(ctx, walletID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wallet not found: %w", err)
+		}
+		if !wallet.IsActive {
+			return nil, nil, errors.New("cannot create transaction on inactive wallet")
+		}
+		lookup.wallets[walletID] = wallet
+	}
+
+	if input.CategoryID != nil && !lookup.categories[*input.CategoryID] {
+		if err := s.checkCategory(ctx, *input.CategoryID); err != nil {
+			return nil, nil, err
+		}
+		lookup.categories[*input.CategoryID] = true
+	}
+
+	if err := s.applyOriginalAmount(ctx, &input, wallet); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.checkMaxAmount(input.Amount); err != nil {
+		return nil, nil, err
+	}
+
+	rate, err := s.rateToBase(ctx, wallet.Currency)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transaction := &models.Transaction{
+		BaseModel:         models.BaseModel{ID: models.NewID()},
+		WalletID:          walletID,
+		CategoryID:        input.CategoryID,
+		Type:              input.Type,
+		Amount:            input.Amount,
+		Description:       input.Description,
+		Note:              input.Note,
+		Tags:              input.Tags,
+		TransactionDate:   input.Date,
+		OriginalCurrency:  input.OriginalCurrency,
+		OriginalAmount:    input.OriginalAmount,
+		IdempotencyKey:    strings.TrimSpace(input.IdempotencyKey),
+		ExternalReference: input.ExternalReference,
+		RateToBase:        rate,
+	}
 	if transaction.TransactionDate.IsZero() {
 		transaction.TransactionDate = time.Now()
 	}
 
 	if err := transaction.Validate(); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
 	}
+	if err := s.checkWalletDate(wallet, transaction.TransactionDate); err != nil {
+		return nil, nil, err
+	}
+	return transaction, wallet, nil
+}
 
-	// Calculate new balance
-	newBalance := wallet.Balance
-	if input.Type == models.TransactionTypeIncome {
-		newBalance = newBalance.Add(input.Amount)
-	} else {
-		newBalance = newBalance.Sub(input.Amount)
+// rejectUsedIdempotencyKey menolak input batch dengan key yang sudah
+// dipakai. Create mengembalikan transaksi lama untuk key seperti itu, jadi
+// caller batch bisa fallback ke Create untuk input tersebut.
+func (s *TransactionService) rejectUsedIdempotencyKey(ctx context.Context, key string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil
+	}
+	_, err := s.txRepo.GetByIdempotencyKey(ctx, key)
+	if err == nil {
+		return fmt.Errorf("idempotency key %q was already used", key)
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to check idempotency key: %w", err)
+	}
+	return nil
+}
+
+// findByIdempotencyKey mengambil transaksi yang sudah dibuat dengan
+// input.IdempotencyKey. Return nil, nil jika key kosong atau belum dipakai.
+func (s *TransactionService) findByIdempotencyKey(ctx context.Context, input CreateTransactionInput) (*models.Transaction, error) {
+	if input.IdempotencyKey == "" {
+		return nil, nil
+	}
+
+	existing, err := s.txRepo.GetByIdempotencyKey(ctx, input.IdempotencyKey)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check idempotency key: %w", err)
+	}
+
+	// Amount hasil konversi bisa berubah jika kurs berubah, jadi request
+	// dengan OriginalAmount dibandingkan dengan nominal aslinya
+	sameAmount := existing.Amount.Equal(input.Amount)
+	if input.OriginalAmount != nil {
+		sameAmount = existing.OriginalAmount != nil && existing.OriginalAmount.Equal(*input.OriginalAmount)
+	}
+	if existing.WalletID != input.WalletID || existing.Type != input.Type || !sameAmount {
+		return nil, ErrIdempotencyKeyReused
+	}
+	return existing, nil
+}
+
+// checkCategory memastikan kategori ada. Cukup Exists, row-nya tidak dipakai.
+func (s *TransactionService) checkCategory(ctx context.Context, id uuid.UUID) error {
+	exists, err := s.categoryRepo.Exists(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check category: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrCategoryNotFound, id)
+	}
+	return nil
+}
+
+// Adjust membawa saldo wallet ke TargetBalance dengan mencatat transaksi
+// koreksi, bukan menimpa saldo diam-diam.
+//
+// Selisih positif dicatat sebagai income, selisih negatif sebagai expense.
+// Transaksi diberi tag models.TagAdjustment sehingga reports bisa
+// mengecualikannya (lihat TransactionFilter.ExcludeAdjustments).
+//
+//	tx, err := txService.Adjust(ctx, service.AdjustBalanceInput{
+//	    WalletID:      walletID,
+//	    TargetBalance: decimal.NewFromInt(1250000),
+//	    CategoryID:    &adjustmentCategoryID,
+//	})
+func (s *TransactionService) Adjust(ctx context.Context, input AdjustBalanceInput) (*models.Transaction, error) {
+	if input.TargetBalance.IsNegative() {
+		return nil, errors.New("target balance cannot be negative")
+	}
+
+	wallet, err := s.walletRepo.GetByID(ctx, input.WalletID)
+	if err != nil {
+		return nil, fmt.Errorf("wallet not found: %w", err)
+	}
+
+	if !wallet.IsActive {
+		return nil, errors.New("cannot adjust balance of inactive wallet")
+	}
+
+	diff := input.TargetBalance.Sub(wallet.Balance)
+	if diff.IsZero() {
+		return nil, ErrNoAdjustmentNeeded
+	}
+
+	txType := models.TransactionTypeIncome
+	if diff.IsNegative() {
+		txType = models.TransactionTypeExpense
+	}
+
+	transaction := models.NewTransaction(wallet.ID, txType, diff.Abs())
+	transaction.CategoryID = input.CategoryID
+	transaction.Description = input.Reason
+	if transaction.Description == "" {
+		transaction.Description = fmt.Sprintf("Balance adjustment: %s -> %s",
+			wallet.Balance.StringFixed(2), input.TargetBalance.StringFixed(2))
+	}
+	transaction.AddTag(models.TagAdjustment)
+	if !input.Date.IsZero() {
+		transaction.TransactionDate = input.Date
+	}
+
+	if err := transaction.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	// Execute in transaction
 	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
 		if err := s.txRepo.Create(ctx, transaction); err != nil {
-			return fmt.Errorf("failed to create transaction: %w", err)
+			return fmt.Errorf("failed to create adjustment: %w", err)
 		}
 
-		if err := s.walletRepo.UpdateBalance(ctx, wallet.ID, newBalance); err != nil {
+		change := models.BalanceChange{Reason: models.BalanceReasonAdjustment, ReferenceID: &transaction.ID}
+		if err := s.walletRepo.UpdateBalance(ctx, wallet.ID, input.TargetBalance, change); err != nil {
 			return fmt.Errorf("failed to update balance: %w", err)
 		}
 
@@ -162,6 +873,126 @@ func (s *TransactionService) GetByWallet(
 	return s.List(ctx, filter, params)
 }
 
+// AttachReceipt menyimpan referensi bukti transaksi (path file atau URL).
+//
+// Path divalidasi oleh Transaction.Validate (maksimal 500 karakter,
+// tidak boleh mengandung ".."). Path kosong menghapus attachment.
+// Saldo wallet tidak berubah.
+//
+//	err := txService.AttachReceipt(ctx, txID, "/home/user/receipts/lunch.jpg")
+func (s *TransactionService) AttachReceipt(ctx context.Context, txID uuid.UUID, path string) error {
+	tx, err := s.txRepo.GetByID(ctx, txID)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	tx.Attachment = path
+	if err := tx.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.txRepo.Update(ctx, tx); err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateNote mengganti note transaksi. Note kosong menghapus note.
+// Panjangnya divalidasi oleh Transaction.Validate (maksimal
+// models.MaxNoteLength byte). Saldo wallet tidak berubah.
+//
+//	err := txService.UpdateNote(ctx, txID, "Garansi 2 tahun, nota di laci")
+func (s *TransactionService) UpdateNote(ctx context.Context, txID uuid.UUID, note string) (*models.Transaction, error) {
+	tx, err := s.txRepo.GetByID(ctx, txID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	tx.Note = note
+	if err := tx.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.txRepo.Update(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to update transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// Recategorize mengganti kategori transaksi; categoryID nil menjadikannya
+// uncategorized. Tipe kategori harus sama dengan tipe transaksi
+// (ErrCategoryTypeMismatch). Hanya category_id yang di-update, jadi saldo
+// wallet tidak berubah.
+//
+//	tx, err := txService.Recategorize(ctx, txID, &groceriesID)
+func (s *TransactionService) Recategorize(ctx context.Context, txID uuid.UUID, categoryID *uuid.UUID) (*models.Transaction, error) {
+	tx, err := s.txRepo.GetByID(ctx, txID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if categoryID != nil {
+		category, err := s.categoryRepo.GetByID(ctx, *categoryID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrCategoryNotFound, *categoryID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+		if string(category.Type) != string(tx.Type) {
+			return nil, fmt.Errorf("%w: %s is an %s category, transaction is %s",
+				ErrCategoryTypeMismatch, category.Name, category.Type, tx.Type)
+		}
+	}
+
+	if err := s.txRepo.UpdateCategoryAndTags(ctx, tx.ID, categoryID, tx.Tags); err != nil {
+		return nil, fmt.Errorf("failed to update transaction: %w", err)
+	}
+	tx.CategoryID = categoryID
+	return tx, nil
+}
+
+// UpdateTags menambah dan menghapus tag transaksi (lihat
+// Transaction.AddTag). Tag sistem (adjustment, revaluation, transfer)
+// tidak bisa diubah lewat sini karena menentukan apakah transaksi ikut
+// laporan income/expense (ErrSystemTag). Hanya tags yang di-update, jadi
+// saldo wallet tidak berubah.
+//
+//	tx, err := txService.UpdateTags(ctx, txID, []string{"work"}, []string{"lunch"})
+func (s *TransactionService) UpdateTags(ctx context.Context, txID uuid.UUID, add, remove []string) (*models.Transaction, error) {
+	for _, tag := range append(append([]string(nil), add...), remove...) {
+		if isSystemTag(tag) {
+			return nil, fmt.Errorf("%w: %s", ErrSystemTag, strings.ToLower(strings.TrimSpace(tag)))
+		}
+	}
+
+	tx, err := s.txRepo.GetByID(ctx, txID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	for _, tag := range remove {
+		tx.RemoveTag(tag)
+	}
+	for _, tag := range add {
+		tx.AddTag(tag)
+	}
+
+	if err := s.txRepo.UpdateCategoryAndTags(ctx, tx.ID, tx.CategoryID, tx.Tags); err != nil {
+		return nil, fmt.Errorf("failed to update transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// isSystemTag mengecek tag yang dikelola aplikasi, bukan user.
+func isSystemTag(tag string) bool {
+	switch strings.ToLower(strings.TrimSpace(tag)) {
+	case models.TagAdjustment, models.TagRevaluation, models.TagTransfer:
+		return true
+	}
+	return false
+}
+
 // GetRecent mengambil transaksi terbaru.
 func (s *TransactionService) GetRecent(ctx context.Context, limit int) ([]*models.Transaction, error) {
 	params := repository.ListParams{Limit: limit, Offset: 0}
@@ -198,7 +1029,8 @@ func (s *TransactionService) Delete(ctx context.Context, id uuid.UUID) error {
 			return fmt.Errorf("failed to delete transaction: %w", err)
 		}
 
-		if err := s.walletRepo.UpdateBalance(ctx, wallet.ID, newBalance); err != nil {
+		change := models.BalanceChange{Reason: models.BalanceReasonTransactionDeleted, ReferenceID: &tx.ID}
+		if err := s.walletRepo.UpdateBalance(ctx, wallet.ID, newBalance, change); err != nil {
 			return fmt.Errorf("failed to update balance: %w", err)
 		}
 
@@ -206,11 +1038,14 @@ func (s *TransactionService) Delete(ctx context.Context, id uuid.UUID) error {
 	})
 }
 
-// GetSummary menghitung ringkasan transaksi.
+// GetSummary menghitung ringkasan transaksi. Jika filter tidak dibatasi
+// ke satu wallet, amount dikonversi ke currency default dengan kurs yang
+// disimpan di transaksi (lihat SetRateProvider).
 func (s *TransactionService) GetSummary(
 	ctx context.Context,
 	filter repository.TransactionFilter,
 ) (*repository.TransactionSummary, error) {
+	filter.ConvertToBase = crossesWallets(filter)
 	summary, err := s.txRepo.GetSummary(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get summary: %w", err)
@@ -218,28 +1053,126 @@ func (s *TransactionService) GetSummary(
 	return summary, nil
 }
 
-// GetMonthlySummary menghitung ringkasan untuk bulan tertentu.
+// crossesWallets mengecek apakah filter bisa mencakup lebih dari satu
+// wallet (dan berarti lebih dari satu currency).
+func crossesWallets(filter repository.TransactionFilter) bool {
+	return len(filter.AllWalletIDs()) != 1
+}
+
+// GetMonthlySummary menghitung ringkasan untuk periode bulan tertentu
+// (lihat SetMonthStartDay dan utils.PeriodBounds).
+// Transaksi adjustment tidak dihitung karena bukan income/expense sungguhan.
+// Jika walletIDs diisi, hanya transaksi dari wallet-wallet itu yang dihitung.
+//
+//	summary, err := txService.GetMonthlySummary(ctx, 2026, time.January, cardA, cardB)
 func (s *TransactionService) GetMonthlySummary(
 	ctx context.Context,
 	year int,
 	month time.Month,
+	walletIDs ...uuid.UUID,
 ) (*repository.TransactionSummary, error) {
-	startDate := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
-	endDate := startDate.AddDate(0, 1, -1) // Last day of month
+	filter := s.MonthFilter(year, month)
+	filter.WalletIDs = walletIDs
+	return s.GetSummary(ctx, filter)
+}
+
+// GetTopExpenses mengambil limit transaksi expense terbesar di bulan tertentu.
+// Transaksi adjustment tidak dihitung.
+//
+//	now := time.Now()
+//	top, err := txService.GetTopExpenses(ctx, now.Year(), now.Month(), 5)
+func (s *TransactionService) GetTopExpenses(
+	ctx context.Context,
+	year int,
+	month time.Month,
+	limit int,
+) ([]*models.Transaction, error) {
+	filter := s.MonthFilter(year, month)
+	expense := models.TransactionTypeExpense
+	filter.Type = &expense
 
-	filter := repository.TransactionFilter{
-		StartDate: &startDate,
-		EndDate:   &endDate,
+	transactions, err := s.txRepo.GetLargest(ctx, filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top expenses: %w", err)
 	}
+	return transactions, nil
+}
 
-	return s.GetSummary(ctx, filter)
+// GetTopExpenseCategories mengambil limit kategori expense dengan total
+// terbesar di bulan tertentu. Kategori tanpa pengeluaran tidak ikut.
+// Percentage adalah porsi kategori dari total expense berkategori bulan itu.
+func (s *TransactionService) GetTopExpenseCategories(
+	ctx context.Context,
+	year int,
+	month time.Month,
+	limit int,
+) ([]*repository.CategorySummary, error) {
+	filter := s.MonthFilter(year, month)
+	expense := models.TransactionTypeExpense
+	filter.Type = &expense
+
+	summaries, err := s.GetCategorySummary(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hasil GetByCategory sudah urut total DESC
+	var top []*repository.CategorySummary
+	for _, summary := range summaries {
+		if len(top) >= limit || !summary.Total.IsPositive() {
+			break
+		}
+		top = append(top, summary)
+	}
+	return top, nil
+}
+
+// MonthFilter membuat filter untuk satu periode bulan, tanpa adjustment.
+// Batas periode mengikuti monthStartDay; EndDate inklusif sampai tepat
+// sebelum periode berikutnya.
+//
+//	filter := txService.MonthFilter(2026, time.January)
+//	merchants, err := txService.GetTopMerchants(ctx, filter, service.TopMerchantsLimit)
+func (s *TransactionService) MonthFilter(year int, month time.Month) repository.TransactionFilter {
+	start, next := utils.PeriodBounds(s.monthStartDay, year, month, time.Local)
+	return periodFilter(start, next)
 }
 
-// GetCategorySummary menghitung ringkasan per kategori.
+// YearFilter membuat filter untuk satu tahun fiskal, tanpa adjustment.
+// Batasnya mengikuti fiscalYearStartMonth (lihat utils.FiscalYearBounds).
+//
+//	filter := txService.YearFilter(2025) // FY2025
+//	summary, err := txService.GetSummary(ctx, filter)
+func (s *TransactionService) YearFilter(fy int) repository.TransactionFilter {
+	start, next := utils.FiscalYearBounds(s.fiscalYearStartMonth, fy, time.Local)
+	return periodFilter(start, next)
+}
+
+// YearToDateFilter membuat filter dari awal tahun fiskal yang memuat now
+// sampai akhir hari now, tanpa adjustment.
+func (s *TransactionService) YearToDateFilter(now time.Time) repository.TransactionFilter {
+	start, next := utils.FiscalYearToDate(s.fiscalYearStartMonth, now)
+	return periodFilter(start, next)
+}
+
+// periodFilter membuat filter [start, next) tanpa adjustment; EndDate
+// inklusif sampai tepat sebelum next.
+func periodFilter(start, next time.Time) repository.TransactionFilter {
+	end := next.Add(-time.Nanosecond)
+	return repository.TransactionFilter{
+		StartDate:          &start,
+		EndDate:            &end,
+		ExcludeAdjustments: true,
+	}
+}
+
+// GetCategorySummary menghitung ringkasan per kategori, dengan konversi
+// currency yang sama seperti GetSummary.
 func (s *TransactionService) GetCategorySummary(
 	ctx context.Context,
 	filter repository.TransactionFilter,
 ) ([]*repository.CategorySummary, error) {
+	filter.ConvertToBase = crossesWallets(filter)
 	summaries, err := s.txRepo.GetByCategory(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get category summary: %w", err)
@@ -247,6 +1180,199 @@ func (s *TransactionService) GetCategorySummary(
 	return summaries, nil
 }
 
+// CategoryStatsMonths adalah jumlah bulan penuh yang dipakai untuk
+// menghitung rata-rata bulanan per kategori.
+const CategoryStatsMonths = 3
+
+// GetCategoryStats menghitung statistik per kategori untuk
+// CategoryStatsMonths bulan penuh sebelum bulan berjalan.
+//
+// Rata-rata selalu dibagi CategoryStatsMonths, jadi bulan tanpa transaksi
+// dihitung sebagai 0 (kategori yang hanya aktif satu bulan punya rata-rata
+// sepertiga dari total bulan itu). Kategori tanpa aktivitas tidak ada di map.
+//
+//	stats, err := txService.GetCategoryStats(ctx, time.Now())
+//	if s, ok := stats[categoryID]; ok {
+//	    fmt.Println("Average:", s.AverageMonthly)
+//	}
+func (s *TransactionService) GetCategoryStats(ctx context.Context, now time.Time) (map[uuid.UUID]*CategoryStats, error) {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	start := monthStart.AddDate(0, -CategoryStatsMonths, 0)
+	lastMonth := monthStart.AddDate(0, -1, 0)
+
+	totals, err := s.txRepo.GetMonthlyCategoryTotals(ctx, start, monthStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category stats: %w", err)
+	}
+
+	stats := make(map[uuid.UUID]*CategoryStats)
+	for _, t := range totals {
+		st, ok := stats[t.CategoryID]
+		if !ok {
+			st = &CategoryStats{CategoryID: t.CategoryID}
+			stats[t.CategoryID] = st
+		}
+		st.Total = st.Total.Add(t.Total)
+		st.Count += t.Count
+		if t.Month.Year() == lastMonth.Year() && t.Month.Month() == lastMonth.Month() {
+			st.LastMonth = st.LastMonth.Add(t.Total)
+		}
+	}
+
+	months := decimal.NewFromInt(CategoryStatsMonths)
+	for _, st := range stats {
+		st.AverageMonthly = st.Total.Div(months).Round(2)
+	}
+
+	return stats, nil
+}
+
+// GetHeatmap menghitung pola pengeluaran per hari dalam minggu × jam
+// untuk transaksi yang cocok dengan filter. Hanya expense yang dihitung
+// (filter.Type diabaikan) dan adjustment tidak ikut.
+//
+//	heatmap, err := txService.GetHeatmap(ctx, repository.TransactionFilter{StartDate: &ninetyDaysAgo})
+//	fmt.Println(heatmap.Data[time.Saturday][21]) // total expense Sabtu jam 21:00-21:59
+func (s *TransactionService) GetHeatmap(ctx context.Context, filter repository.TransactionFilter) (*SpendingHeatmap, error) {
+	expense := models.TransactionTypeExpense
+	filter.Type = &expense
+	filter.ExcludeAdjustments = true
+
+	totals, err := s.txRepo.GetWeekdayHourTotals(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spending heatmap: %w", err)
+	}
+
+	heatmap := &SpendingHeatmap{}
+	for _, t := range totals {
+		if t.Weekday < time.Sunday || t.Weekday > time.Saturday {
+			continue
+		}
+		heatmap.Total = heatmap.Total.Add(t.Total)
+
+		if t.Hour < 0 || t.Hour > 23 {
+			heatmap.UnknownHour[t.Weekday] = heatmap.UnknownHour[t.Weekday].Add(t.Total)
+			continue
+		}
+		cell := heatmap.Data[t.Weekday][t.Hour].Add(t.Total)
+		heatmap.Data[t.Weekday][t.Hour] = cell
+		if cell.GreaterThan(heatmap.Max) {
+			heatmap.Max = cell
+		}
+	}
+
+	return heatmap, nil
+}
+
+// walletTypeOrder adalah urutan tipe wallet di GetCashFlowByWalletType.
+var walletTypeOrder = []models.WalletType{
+	models.WalletTypeCash,
+	models.WalletTypeBank,
+	models.WalletTypeEWallet,
+	models.WalletTypeInvestment,
+}
+
+// GetCashFlowByWalletType menghitung uang masuk (income) dan keluar
+// (expense) per tipe wallet untuk transaksi yang cocok dengan filter.
+// Adjustment tidak ikut. Semua tipe wallet selalu ada di hasil (urut
+// cash, bank, ewallet, investment), yang tanpa transaksi bernilai 0,
+// supaya laporan antar bulan mudah dibandingkan.
+func (s *TransactionService) GetCashFlowByWalletType(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+) ([]*repository.WalletTypeCashFlow, error) {
+	filter.Type = nil
+	filter.ExcludeAdjustments = true
+
+	flows, err := s.txRepo.GetCashFlowByWalletType(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cash flow by wallet type: %w", err)
+	}
+	return CompleteWalletTypes(flows), nil
+}
+
+// CompleteWalletTypes mengurutkan flows per tipe wallet (cash, bank,
+// ewallet, investment) dan menambahkan tipe yang tidak ada dengan nilai 0.
+// Tipe yang tidak dikenal ditaruh di akhir.
+func CompleteWalletTypes(flows []*repository.WalletTypeCashFlow) []*repository.WalletTypeCashFlow {
+	byType := make(map[models.WalletType]*repository.WalletTypeCashFlow, len(flows))
+	for _, f := range flows {
+		byType[f.WalletType] = f
+	}
+
+	result := make([]*repository.WalletTypeCashFlow, 0, len(walletTypeOrder))
+	for _, t := range walletTypeOrder {
+		flow, ok := byType[t]
+		if !ok {
+			flow = &repository.WalletTypeCashFlow{WalletType: t}
+		}
+		result = append(result, flow)
+		delete(byType, t)
+	}
+	// Tipe yang tidak dikenal (data lama) tetap ditampilkan di akhir
+	for _, f := range flows {
+		if _, ok := byType[f.WalletType]; ok {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// GetTopMerchants mengambil maksimal limit merchant dengan total expense
+// terbesar untuk transaksi yang cocok dengan filter. Merchant diambil dari
+// description yang dinormalisasi (lihat NormalizeMerchant), jadi
+// "INDOMARET 0423" dan "Indomaret 0817" dihitung sebagai satu merchant.
+// Adjustment dan transaksi tanpa description tidak ikut.
+func (s *TransactionService) GetTopMerchants(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+	limit int,
+) ([]*MerchantSummary, error) {
+	expense := models.TransactionTypeExpense
+	filter.Type = &expense
+	filter.ExcludeAdjustments = true
+
+	totals, err := s.txRepo.GetDescriptionTotals(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top merchants: %w", err)
+	}
+	return TopMerchants(totals, limit), nil
+}
+
+// SpendingHeatmap adalah total pengeluaran per hari dalam minggu × jam.
+type SpendingHeatmap struct {
+	// Data di-index [weekday][hour], weekday mengikuti time.Weekday
+	// (Sunday = 0) dan hour 0-23.
+	Data [7][24]decimal.Decimal
+
+	// UnknownHour adalah total per weekday yang jamnya tidak diketahui
+	// (transaksi yang dicatat setelah tanggalnya, misalnya import).
+	UnknownHour [7]decimal.Decimal
+
+	// Max adalah nilai cell Data terbesar, untuk skala warna.
+	Max decimal.Decimal
+
+	// Total adalah total seluruh pengeluaran, termasuk UnknownHour.
+	Total decimal.Decimal
+}
+
+// CategoryStats adalah statistik pengeluaran/pemasukan satu kategori.
+type CategoryStats struct {
+	CategoryID uuid.UUID
+
+	// Total adalah jumlah amount selama window.
+	Total decimal.Decimal
+
+	// AverageMonthly adalah Total / CategoryStatsMonths.
+	AverageMonthly decimal.Decimal
+
+	// LastMonth adalah total bulan lalu.
+	LastMonth decimal.Decimal
+
+	// Count adalah jumlah transaksi selama window.
+	Count int
+}
+
 // CreateTransactionInput adalah input untuk membuat transaction.
 type CreateTransactionInput struct {
 	WalletID    uuid.UUID
@@ -256,4 +1382,40 @@ type CreateTransactionInput struct {
 	Description string
 	Tags        []string
 	Date        time.Time
+
+	// Note opsional, catatan panjang (lihat models.Transaction.Note).
+	Note string
+
+	// OriginalCurrency diisi importer jika row CSV punya kolom Currency
+	// (lihat models.Transaction.OriginalCurrency), atau bersama
+	// OriginalAmount untuk transaksi dalam currency asing.
+	OriginalCurrency string
+
+	// OriginalAmount opsional, nominal dalam OriginalCurrency. Jika diisi,
+	// Amount diabaikan dan dihitung dari OriginalAmount dengan kurs ke
+	// currency wallet (lihat ApplyExchangeRate); saldo wallet selalu
+	// berubah dalam currency wallet.
+	OriginalAmount *decimal.Decimal
+
+	// IdempotencyKey opsional; request dengan key yang sama hanya membuat
+	// satu transaksi (lihat Create).
+	IdempotencyKey string
+
+	// ExternalReference opsional, ID transaksi dari bank (lihat
+	// models.Transaction.ExternalReference).
+	ExternalReference string
+}
+
+// AdjustBalanceInput adalah input untuk koreksi saldo wallet.
+type AdjustBalanceInput struct {
+	WalletID      uuid.UUID
+	TargetBalance decimal.Decimal
+
+	// CategoryID biasanya kategori "Adjustment" sesuai arah koreksi
+	// (lihat CategoryService.GetAdjustmentCategory).
+	CategoryID *uuid.UUID
+
+	// Reason opsional; default berisi saldo lama dan saldo baru.
+	Reason string
+	Date   time.Time
 }
@@ -0,0 +1,1293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+)
+
+// memoryRepos menyiapkan repositories in-memory yang berbagi satu store.
+type memoryRepos struct {
+	wallet      repository.WalletRepository
+	category    repository.CategoryRepository
+	transaction repository.TransactionRepository
+	transfer    repository.TransferRepository
+	budget      repository.BudgetRepository
+	goal        repository.GoalRepository
+	recurring   repository.RecurringRepository
+	rule        repository.RuleRepository
+	txManager   repository.TransactionManager
+}
+
+func newMemoryRepos() *memoryRepos {
+	store := memory.NewStore()
+	return &memoryRepos{
+		wallet:      memory.NewWalletRepository(store),
+		category:    memory.NewCategoryRepository(store),
+		transaction: memory.NewTransactionRepository(store),
+		transfer:    memory.NewTransferRepository(store),
+		budget:      memory.NewBudgetRepository(store),
+		goal:        memory.NewGoalRepository(store),
+		recurring:   memory.NewRecurringRepository(store),
+		rule:        memory.NewRuleRepository(store),
+		txManager:   memory.NewTransactionManager(store),
+	}
+}
+
+// createWallet membuat wallet aktif dengan saldo awal.
+func (r *memoryRepos) createWallet(t *testing.T, name string, balance int64) *models.Wallet {
+	t.Helper()
+
+	w := models.NewWallet(name, models.WalletTypeBank)
+	w.Balance = decimal.NewFromInt(balance)
+	if err := r.wallet.Create(context.Background(), w); err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	return w
+}
+
+// balanceOf mengambil saldo wallet terbaru dari repository.
+func (r *memoryRepos) balanceOf(t *testing.T, wallet *models.Wallet) decimal.Decimal {
+	t.Helper()
+
+	w, err := r.wallet.GetByID(context.Background(), wallet.ID)
+	if err != nil {
+		t.Fatalf("failed to get wallet: %v", err)
+	}
+	return w.Balance
+}
+
+func newTestTransactionService(repos *memoryRepos) *TransactionService {
+	return NewTransactionService(repos.transaction, repos.wallet, repos.category, repos.txManager)
+}
+
+func TestTransactionService_Create(t *testing.T) {
+	tests := []struct {
+		name        string
+		txType      models.TransactionType
+		amount      int64
+		wantBalance int64
+		wantErr     error
+	}{
+		{"income adds to balance", models.TransactionTypeIncome, 500, 1500, nil},
+		{"expense subtracts from balance", models.TransactionTypeExpense, 300, 700, nil},
+		{"expense exactly balance", models.TransactionTypeExpense, 1000, 0, nil},
+		{"insufficient balance", models.TransactionTypeExpense, 1001, 1000, ErrInsufficientBalance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newMemoryRepos()
+			svc := newTestTransactionService(repos)
+			wallet := repos.createWallet(t, "BCA", 1000)
+
+			_, err := svc.Create(context.Background(), CreateTransactionInput{
+				WalletID: wallet.ID,
+				Type:     tt.txType,
+				Amount:   decimal.NewFromInt(tt.amount),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(tt.wantBalance)) {
+				t.Errorf("balance = %s, want %d", got, tt.wantBalance)
+			}
+		})
+	}
+}
+
+// staleCategoryRepo selalu menganggap kategori ada, untuk mensimulasikan
+// kategori yang dihapus setelah dicek tapi sebelum transaksi disimpan.
+type staleCategoryRepo struct {
+	repository.CategoryRepository
+}
+
+func (staleCategoryRepo) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	return true, nil
+}
+
+func TestTransactionService_Create_RollsBackOnFailure(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := NewTransactionService(repos.transaction, repos.wallet, staleCategoryRepo{repos.category}, repos.txManager)
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	// Category lolos pengecekan tapi tidak ada → txRepo.Create gagal
+	// (foreign key), balance tidak boleh berubah.
+	missing := models.NewID()
+	_, err := svc.Create(context.Background(), CreateTransactionInput{
+		WalletID:   wallet.ID,
+		CategoryID: &missing,
+		Type:       models.TransactionTypeIncome,
+		Amount:     decimal.NewFromInt(500),
+	})
+	if !errors.Is(err, repository.ErrForeignKeyViolation) {
+		t.Fatalf("Create() error = %v, want ErrForeignKeyViolation", err)
+	}
+
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("balance = %s, want 1000 (unchanged)", got)
+	}
+}
+
+func TestTransactionService_Create_InactiveWallet(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+	_ = repos.wallet.Delete(context.Background(), wallet.ID)
+
+	_, err := svc.Create(context.Background(), CreateTransactionInput{
+		WalletID: wallet.ID,
+		Type:     models.TransactionTypeIncome,
+		Amount:   decimal.NewFromInt(500),
+	})
+	if err == nil {
+		t.Error("Create() on inactive wallet should return error")
+	}
+}
+
+func TestTransactionService_Create_DefaultWallet(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+	input := CreateTransactionInput{
+		Type:   models.TransactionTypeIncome,
+		Amount: decimal.NewFromInt(500),
+	}
+
+	if _, err := svc.Create(context.Background(), input); !errors.Is(err, ErrWalletRequired) {
+		t.Fatalf("Create() without wallet error = %v, want ErrWalletRequired", err)
+	}
+
+	svc.SetDefaultWallet(wallet.ID)
+	tx, err := svc.Create(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if tx.WalletID != wallet.ID {
+		t.Errorf("WalletID = %s, want default %s", tx.WalletID, wallet.ID)
+	}
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(1500)) {
+		t.Errorf("balance = %s, want 1500", got)
+	}
+}
+
+func TestTransactionService_Create_MaxAmount(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	svc.SetMaxAmount(decimal.NewFromInt(100000000))
+	ctx := context.Background()
+	wallet := repos.createWallet(t, "Petty Cash", 1000000)
+
+	tests := []struct {
+		name    string
+		txType  models.TransactionType
+		amount  int64
+		wantErr error
+	}{
+		{name: "income above limit", txType: models.TransactionTypeIncome, amount: 500000000, wantErr: ErrTransactionExceedsLimit},
+		{name: "expense above limit and balance", txType: models.TransactionTypeExpense, amount: 500000000, wantErr: ErrTransactionExceedsLimit},
+		{name: "expense within limit above balance", txType: models.TransactionTypeExpense, amount: 50000000, wantErr: ErrInsufficientBalance},
+		{name: "exactly the limit", txType: models.TransactionTypeIncome, amount: 100000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Create(ctx, CreateTransactionInput{
+				WalletID: wallet.ID,
+				Type:     tt.txType,
+				Amount:   decimal.NewFromInt(tt.amount),
+			})
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == ErrTransactionExceedsLimit && errors.Is(err, ErrInsufficientBalance) {
+				t.Error("limit error must not match ErrInsufficientBalance")
+			}
+		})
+	}
+
+	if _, err := svc.BulkCreate(ctx, []CreateTransactionInput{
+		{WalletID: wallet.ID, Type: models.TransactionTypeIncome, Amount: decimal.NewFromInt(100000001)},
+	}); !errors.Is(err, ErrTransactionExceedsLimit) {
+		t.Errorf("BulkCreate() error = %v, want ErrTransactionExceedsLimit", err)
+	}
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(101000000)) {
+		t.Errorf("balance = %s, want 101000000 (only the transaction at the limit)", got)
+	}
+}
+
+func TestTransactionService_Create_BeforeWalletDate(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+
+	created := time.Date(2024, 3, 10, 15, 30, 0, 0, time.Local)
+	opened := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	fresh := models.NewWallet("BCA", models.WalletTypeBank)
+	fresh.CreatedAt = created
+	old := models.NewWallet("Mandiri", models.WalletTypeBank)
+	old.CreatedAt = created
+	old.OpeningDate = &opened
+	for _, w := range []*models.Wallet{fresh, old} {
+		if err := repos.wallet.Create(ctx, w); err != nil {
+			t.Fatalf("failed to create wallet: %v", err)
+		}
+	}
+
+	create := func(w *models.Wallet, date time.Time) error {
+		_, err := svc.Create(ctx, CreateTransactionInput{
+			WalletID: w.ID,
+			Type:     models.TransactionTypeIncome,
+			Amount:   decimal.NewFromInt(100),
+			Date:     date,
+		})
+		return err
+	}
+
+	var warnings []error
+	svc.SetWarningHandler(func(w error) { warnings = append(warnings, w) })
+
+	// Default nonaktif: backdate tetap diterima, dengan warning
+	if err := create(fresh, created.AddDate(0, -6, 0)); err != nil {
+		t.Fatalf("Create() with check disabled error = %v", err)
+	}
+	if err := create(fresh, created); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	var warning *TransactionBeforeWalletError
+	if len(warnings) != 1 || !errors.As(warnings[0], &warning) || warning.WalletName != "BCA" {
+		t.Fatalf("warnings = %v, want one TransactionBeforeWalletError for BCA", warnings)
+	}
+
+	svc.SetRejectBeforeWalletDate(true)
+
+	tests := []struct {
+		name    string
+		wallet  *models.Wallet
+		date    time.Time
+		wantErr bool
+	}{
+		{"day before created", fresh, time.Date(2024, 3, 9, 23, 59, 0, 0, time.Local), true},
+		{"same day, earlier hour", fresh, time.Date(2024, 3, 10, 8, 0, 0, 0, time.Local), false},
+		{"after created", fresh, created.AddDate(0, 0, 1), false},
+		{"day before opening date", old, time.Date(2023, 12, 31, 12, 0, 0, 0, time.Local), true},
+		{"on opening date", old, time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), false},
+		{"between opening and created", old, time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := create(tt.wallet, tt.date)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Create() error = %v, want nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrTransactionBeforeWallet) {
+				t.Fatalf("Create() error = %v, want ErrTransactionBeforeWallet", err)
+			}
+			var dateErr *TransactionBeforeWalletError
+			if !errors.As(err, &dateErr) {
+				t.Fatalf("Create() error type = %T, want *TransactionBeforeWalletError", err)
+			}
+			if !dateErr.StartDate.Equal(tt.wallet.StartDate()) {
+				t.Errorf("StartDate = %v, want %v", dateErr.StartDate, tt.wallet.StartDate())
+			}
+		})
+	}
+
+	// Strict: ditolak, bukan warning
+	if len(warnings) != 1 {
+		t.Errorf("got %d warnings in strict mode, want none", len(warnings)-1)
+	}
+}
+
+func TestTransactionService_Delete_RestoresBalance(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	tx, err := svc.Create(ctx, CreateTransactionInput{
+		WalletID: wallet.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(400),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := svc.Delete(ctx, tx.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("balance after delete = %s, want 1000", got)
+	}
+	if _, err := svc.GetByID(ctx, tx.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetByID() after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTransactionService_Create_IdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	input := CreateTransactionInput{
+		WalletID:       wallet.ID,
+		Type:           models.TransactionTypeExpense,
+		Amount:         decimal.NewFromInt(200),
+		IdempotencyKey: "script-2025-03-01",
+	}
+	first, replayed, err := svc.CreateIdempotent(ctx, input)
+	if err != nil || replayed {
+		t.Fatalf("CreateIdempotent() = %v, %v, want a new transaction", replayed, err)
+	}
+
+	// Retry dengan key yang sama mengembalikan transaksi yang sama
+	second, replayed, err := svc.CreateIdempotent(ctx, input)
+	if err != nil {
+		t.Fatalf("CreateIdempotent() retry error = %v", err)
+	}
+	if !replayed {
+		t.Error("CreateIdempotent() retry replayed = false, want true")
+	}
+	if second.ID != first.ID {
+		t.Errorf("retry created transaction %s, want existing %s", second.ID, first.ID)
+	}
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(800)) {
+		t.Errorf("balance = %s, want 800 (charged once)", got)
+	}
+	txs, _ := repos.transaction.List(ctx, repository.TransactionFilter{}, repository.ListParams{Limit: 10})
+	if len(txs) != 1 {
+		t.Errorf("transactions = %d, want 1", len(txs))
+	}
+
+	// Key yang sama untuk transaksi lain ditolak
+	input.Amount = decimal.NewFromInt(300)
+	if _, err := svc.Create(ctx, input); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Errorf("Create() with reused key error = %v, want ErrIdempotencyKeyReused", err)
+	}
+
+	// Tanpa key tetap membuat transaksi baru setiap kali
+	input.IdempotencyKey = ""
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Create(ctx, input); err != nil {
+			t.Fatalf("Create() without key error = %v", err)
+		}
+	}
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("balance = %s, want 200", got)
+	}
+}
+
+func TestTransactionService_GetSummary(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	inputs := []CreateTransactionInput{
+		{WalletID: wallet.ID, Type: models.TransactionTypeIncome, Amount: decimal.NewFromInt(2000)},
+		{WalletID: wallet.ID, Type: models.TransactionTypeExpense, Amount: decimal.NewFromInt(500)},
+		{WalletID: wallet.ID, Type: models.TransactionTypeExpense, Amount: decimal.NewFromInt(250)},
+	}
+	for _, input := range inputs {
+		if _, err := svc.Create(ctx, input); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	summary, err := svc.GetSummary(ctx, repository.TransactionFilter{WalletID: &wallet.ID})
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+
+	if !summary.TotalIncome.Equal(decimal.NewFromInt(2000)) {
+		t.Errorf("TotalIncome = %s, want 2000", summary.TotalIncome)
+	}
+	if !summary.TotalExpense.Equal(decimal.NewFromInt(750)) {
+		t.Errorf("TotalExpense = %s, want 750", summary.TotalExpense)
+	}
+	if !summary.Net.Equal(decimal.NewFromInt(1250)) {
+		t.Errorf("Net = %s, want 1250", summary.Net)
+	}
+}
+
+func TestTransactionService_GetMonthlySummary_MonthStartDay(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000000)
+
+	day := func(m time.Month, d, hour int) time.Time {
+		year := 2026
+		if m == time.December {
+			year = 2025
+		}
+		return time.Date(year, m, d, hour, 0, 0, 0, time.Local)
+	}
+	// Periode January 2026 dengan anchor 25 = 25 Dec 2025 – 24 Jan 2026
+	inputs := []struct {
+		amount int64
+		date   time.Time
+	}{
+		{1, day(time.December, 24, 23)}, // periode sebelumnya
+		{10, day(time.December, 25, 0)},
+		{100, day(time.January, 1, 12)},
+		{1000, day(time.January, 24, 23)},
+		{10000, day(time.January, 25, 0)}, // periode berikutnya
+	}
+	for _, in := range inputs {
+		if _, err := svc.Create(ctx, CreateTransactionInput{
+			WalletID: wallet.ID,
+			Type:     models.TransactionTypeExpense,
+			Amount:   decimal.NewFromInt(in.amount),
+			Date:     in.date,
+		}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		anchor int
+		want   int64
+	}{
+		{anchor: 1, want: 11100},
+		{anchor: 25, want: 1110},
+	}
+	for _, tt := range tests {
+		svc.SetMonthStartDay(tt.anchor)
+		summary, err := svc.GetMonthlySummary(ctx, 2026, time.January)
+		if err != nil {
+			t.Fatalf("GetMonthlySummary() error = %v", err)
+		}
+		if !summary.TotalExpense.Equal(decimal.NewFromInt(tt.want)) {
+			t.Errorf("anchor %d: TotalExpense = %s, want %d", tt.anchor, summary.TotalExpense, tt.want)
+		}
+	}
+}
+
+func TestTransactionService_YearFilter_FiscalStart(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	svc.SetFiscalYearStartMonth(4)
+	wallet := repos.createWallet(t, "BCA", 1000000)
+
+	// FY2025 dengan start April = 1 Apr 2025 – 31 Mar 2026
+	inputs := []struct {
+		amount int64
+		date   time.Time
+	}{
+		{1, time.Date(2025, time.March, 31, 23, 0, 0, 0, time.Local)}, // FY2024
+		{10, time.Date(2025, time.April, 1, 0, 0, 0, 0, time.Local)},
+		{100, time.Date(2025, time.December, 31, 12, 0, 0, 0, time.Local)},
+		{1000, time.Date(2026, time.January, 15, 12, 0, 0, 0, time.Local)},
+		{10000, time.Date(2026, time.March, 31, 23, 0, 0, 0, time.Local)},
+		{100000, time.Date(2026, time.April, 1, 0, 0, 0, 0, time.Local)}, // FY2026
+	}
+	for _, in := range inputs {
+		if _, err := svc.Create(ctx, CreateTransactionInput{
+			WalletID: wallet.ID,
+			Type:     models.TransactionTypeExpense,
+			Amount:   decimal.NewFromInt(in.amount),
+			Date:     in.date,
+		}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter repository.TransactionFilter
+		want   int64
+	}{
+		{"FY2025", svc.YearFilter(2025), 11110},
+		{"FY2026", svc.YearFilter(2026), 100000},
+		{"YTD mid-January", svc.YearToDateFilter(time.Date(2026, time.January, 15, 8, 0, 0, 0, time.Local)), 1110},
+		{"YTD first day", svc.YearToDateFilter(time.Date(2025, time.April, 1, 8, 0, 0, 0, time.Local)), 10},
+	}
+	for _, tt := range tests {
+		summary, err := svc.GetSummary(ctx, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: GetSummary() error = %v", tt.name, err)
+		}
+		if !summary.TotalExpense.Equal(decimal.NewFromInt(tt.want)) {
+			t.Errorf("%s: TotalExpense = %s, want %d", tt.name, summary.TotalExpense, tt.want)
+		}
+	}
+}
+
+func TestTransactionService_AttachReceipt(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	tx, err := svc.Create(ctx, CreateTransactionInput{
+		WalletID: wallet.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(400),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := svc.AttachReceipt(ctx, tx.ID, "/home/user/receipts/lunch.jpg"); err != nil {
+		t.Fatalf("AttachReceipt() error = %v", err)
+	}
+	got, _ := svc.GetByID(ctx, tx.ID)
+	if got.Attachment != "/home/user/receipts/lunch.jpg" {
+		t.Errorf("Attachment = %q, want receipt path", got.Attachment)
+	}
+	if balance := repos.balanceOf(t, wallet); !balance.Equal(decimal.NewFromInt(600)) {
+		t.Errorf("balance after attach = %s, want 600 (unchanged)", balance)
+	}
+
+	err = svc.AttachReceipt(ctx, tx.ID, "../secret.jpg")
+	if !errors.Is(err, models.ErrAttachmentInvalidPath) {
+		t.Errorf("AttachReceipt() with '..' error = %v, want ErrAttachmentInvalidPath", err)
+	}
+	got, _ = svc.GetByID(ctx, tx.ID)
+	if got.Attachment != "/home/user/receipts/lunch.jpg" {
+		t.Errorf("Attachment after rejected path = %q, want previous path kept", got.Attachment)
+	}
+
+	if err := svc.AttachReceipt(ctx, models.NewID(), "/tmp/x.jpg"); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("AttachReceipt() unknown tx error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTransactionService_UpdateNote(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	tx, err := svc.Create(ctx, CreateTransactionInput{
+		WalletID: wallet.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(400),
+		Note:     "Split with Budi",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	note := "Split with Budi\nHe paid 200 back\n"
+	if _, err := svc.UpdateNote(ctx, tx.ID, note); err != nil {
+		t.Fatalf("UpdateNote() error = %v", err)
+	}
+	got, _ := svc.GetByID(ctx, tx.ID)
+	if got.Note != "Split with Budi\nHe paid 200 back" {
+		t.Errorf("Note = %q, want multi-line note without trailing newline", got.Note)
+	}
+	if balance := repos.balanceOf(t, wallet); !balance.Equal(decimal.NewFromInt(600)) {
+		t.Errorf("balance after note = %s, want 600 (unchanged)", balance)
+	}
+
+	_, err = svc.UpdateNote(ctx, tx.ID, strings.Repeat("x", models.MaxNoteLength+1))
+	if !errors.Is(err, models.ErrNoteTooLong) {
+		t.Errorf("UpdateNote() too long error = %v, want ErrNoteTooLong", err)
+	}
+	got, _ = svc.GetByID(ctx, tx.ID)
+	if got.Note != "Split with Budi\nHe paid 200 back" {
+		t.Errorf("Note after rejected update = %q, want previous note kept", got.Note)
+	}
+}
+
+func TestTransactionService_RecategorizeAndTags(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	groceries := models.NewCategory("Groceries", models.CategoryTypeExpense)
+	salary := models.NewCategory("Salary", models.CategoryTypeIncome)
+	for _, c := range []*models.Category{groceries, salary} {
+		if err := repos.category.Create(ctx, c); err != nil {
+			t.Fatalf("create category: %v", err)
+		}
+	}
+
+	tx, err := svc.Create(ctx, CreateTransactionInput{
+		WalletID: wallet.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(400),
+		Tags:     []string{"lunch"},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := svc.Recategorize(ctx, tx.ID, &groceries.ID); err != nil {
+		t.Fatalf("Recategorize() error = %v", err)
+	}
+	if _, err := svc.Recategorize(ctx, tx.ID, &salary.ID); !errors.Is(err, ErrCategoryTypeMismatch) {
+		t.Errorf("Recategorize() to an income category error = %v, want ErrCategoryTypeMismatch", err)
+	}
+
+	if _, err := svc.UpdateTags(ctx, tx.ID, []string{"Work", "work"}, []string{"lunch"}); err != nil {
+		t.Fatalf("UpdateTags() error = %v", err)
+	}
+	if _, err := svc.UpdateTags(ctx, tx.ID, []string{models.TagAdjustment}, nil); !errors.Is(err, ErrSystemTag) {
+		t.Errorf("UpdateTags() adding a system tag error = %v, want ErrSystemTag", err)
+	}
+
+	got, _ := svc.GetByID(ctx, tx.ID)
+	if got.CategoryID == nil || *got.CategoryID != groceries.ID {
+		t.Errorf("CategoryID = %v, want Groceries", got.CategoryID)
+	}
+	if len(got.Tags) != 1 || got.Tags[0] != "work" {
+		t.Errorf("Tags = %v, want [work]", got.Tags)
+	}
+	if balance := repos.balanceOf(t, wallet); !balance.Equal(decimal.NewFromInt(600)) {
+		t.Errorf("balance = %s, want 600 (unchanged)", balance)
+	}
+}
+
+func TestTransactionService_Adjust(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	categories := NewCategoryService(repos.category, repos.transaction, repos.budget, repos.txManager)
+	wallet := repos.createWallet(t, "Cash", 1000)
+
+	tests := []struct {
+		name     string
+		target   int64
+		wantType models.TransactionType
+		wantAmt  int64
+	}{
+		{"balance too low", 1300, models.TransactionTypeIncome, 300},
+		{"balance too high", 800, models.TransactionTypeExpense, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := categories.GetAdjustmentCategory(ctx, models.CategoryType(tt.wantType))
+			if err != nil {
+				t.Fatalf("GetAdjustmentCategory() error = %v", err)
+			}
+
+			tx, err := svc.Adjust(ctx, AdjustBalanceInput{
+				WalletID:      wallet.ID,
+				TargetBalance: decimal.NewFromInt(tt.target),
+				CategoryID:    &category.ID,
+			})
+			if err != nil {
+				t.Fatalf("Adjust() error = %v", err)
+			}
+
+			if tx.Type != tt.wantType || !tx.Amount.Equal(decimal.NewFromInt(tt.wantAmt)) {
+				t.Errorf("Adjust() = %s %s, want %s %d", tx.Type, tx.Amount, tt.wantType, tt.wantAmt)
+			}
+			if !tx.IsAdjustment() {
+				t.Error("adjustment transaction should carry the adjustment tag")
+			}
+			if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(tt.target)) {
+				t.Errorf("balance = %s, want %d", got, tt.target)
+			}
+		})
+	}
+
+	if _, err := svc.Adjust(ctx, AdjustBalanceInput{
+		WalletID:      wallet.ID,
+		TargetBalance: decimal.NewFromInt(800),
+	}); !errors.Is(err, ErrNoAdjustmentNeeded) {
+		t.Errorf("Adjust() to current balance error = %v, want ErrNoAdjustmentNeeded", err)
+	}
+
+	// Kategori adjustment tidak dibuat dobel
+	expense, _ := categories.GetExpenseCategories(ctx)
+	if len(expense) != 1 {
+		t.Errorf("expense categories = %d, want 1", len(expense))
+	}
+}
+
+func TestTransactionService_GetSummary_ExcludeAdjustments(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	if _, err := svc.Create(ctx, CreateTransactionInput{
+		WalletID: wallet.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(200),
+	}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := svc.Adjust(ctx, AdjustBalanceInput{
+		WalletID:      wallet.ID,
+		TargetBalance: decimal.NewFromInt(900),
+	}); err != nil {
+		t.Fatalf("Adjust() error = %v", err)
+	}
+
+	all, _ := svc.GetSummary(ctx, repository.TransactionFilter{})
+	if all.Count != 2 {
+		t.Errorf("Count = %d, want 2", all.Count)
+	}
+
+	reported, _ := svc.GetSummary(ctx, repository.TransactionFilter{ExcludeAdjustments: true})
+	if reported.Count != 1 || !reported.TotalIncome.IsZero() {
+		t.Errorf("summary without adjustments = %+v, want only the expense", reported)
+	}
+}
+
+func TestTransactionService_GetCategoryStats(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 100000)
+
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	travel := models.NewCategory("Travel", models.CategoryTypeExpense)
+	for _, c := range []*models.Category{food, travel} {
+		if err := repos.category.Create(ctx, c); err != nil {
+			t.Fatalf("create category: %v", err)
+		}
+	}
+
+	now := time.Date(2025, time.April, 10, 12, 0, 0, 0, time.Local)
+	date := func(m time.Month, d int) time.Time {
+		return time.Date(2025, m, d, 0, 0, 0, 0, time.Local)
+	}
+
+	inputs := []struct {
+		category *models.Category
+		amount   int64
+		date     time.Time
+	}{
+		// Food aktif setiap bulan
+		{food, 300, date(time.January, 5)},
+		{food, 200, date(time.February, 14)},
+		{food, 100, date(time.March, 1)},
+		{food, 400, date(time.March, 31)},
+		// Travel hanya aktif di Februari
+		{travel, 900, date(time.February, 20)},
+		// Di luar window: bulan berjalan dan sebelum Januari
+		{food, 5000, date(time.April, 2)},
+		{food, 5000, time.Date(2024, time.December, 31, 0, 0, 0, 0, time.Local)},
+	}
+	for _, in := range inputs {
+		categoryID := in.category.ID
+		if _, err := svc.Create(ctx, CreateTransactionInput{
+			WalletID:   wallet.ID,
+			CategoryID: &categoryID,
+			Type:       models.TransactionTypeExpense,
+			Amount:     decimal.NewFromInt(in.amount),
+			Date:       in.date,
+		}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	stats, err := svc.GetCategoryStats(ctx, now)
+	if err != nil {
+		t.Fatalf("GetCategoryStats() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		categoryID  uuid.UUID
+		wantAverage int64
+		wantLast    int64
+		wantCount   int
+	}{
+		{"every month", food.ID, 333, 500, 4},
+		{"one month only", travel.ID, 300, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := stats[tt.categoryID]
+			if !ok {
+				t.Fatal("category missing from stats")
+			}
+			if !s.AverageMonthly.Round(0).Equal(decimal.NewFromInt(tt.wantAverage)) {
+				t.Errorf("AverageMonthly = %s, want ~%d", s.AverageMonthly, tt.wantAverage)
+			}
+			if !s.LastMonth.Equal(decimal.NewFromInt(tt.wantLast)) {
+				t.Errorf("LastMonth = %s, want %d", s.LastMonth, tt.wantLast)
+			}
+			if s.Count != tt.wantCount {
+				t.Errorf("Count = %d, want %d", s.Count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestTransactionService_GetTopExpenses(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000000)
+
+	food := models.NewCategory("Food", models.CategoryTypeExpense)
+	rent := models.NewCategory("Rent", models.CategoryTypeExpense)
+	fun := models.NewCategory("Fun", models.CategoryTypeExpense)
+	health := models.NewCategory("Health", models.CategoryTypeExpense)
+	for _, c := range []*models.Category{food, rent, fun, health} {
+		if err := repos.category.Create(ctx, c); err != nil {
+			t.Fatalf("create category: %v", err)
+		}
+	}
+
+	march := func(d int) time.Time { return time.Date(2025, time.March, d, 0, 0, 0, 0, time.Local) }
+	inputs := []struct {
+		category *models.Category
+		txType   models.TransactionType
+		amount   int64
+		date     time.Time
+	}{
+		{rent, models.TransactionTypeExpense, 5000, march(1)},
+		{food, models.TransactionTypeExpense, 1000, march(3)},
+		{food, models.TransactionTypeExpense, 2000, march(4)},
+		{fun, models.TransactionTypeExpense, 1500, march(10)},
+		{health, models.TransactionTypeExpense, 500, march(11)},
+		{food, models.TransactionTypeExpense, 100, march(12)},
+		{nil, models.TransactionTypeIncome, 90000, march(5)},
+		// Bulan lain tidak ikut
+		{rent, models.TransactionTypeExpense, 9000, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.Local)},
+	}
+	for _, in := range inputs {
+		input := CreateTransactionInput{WalletID: wallet.ID, Type: in.txType, Amount: decimal.NewFromInt(in.amount), Date: in.date}
+		if in.category != nil {
+			categoryID := in.category.ID
+			input.CategoryID = &categoryID
+		}
+		if _, err := svc.Create(ctx, input); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	top, err := svc.GetTopExpenses(ctx, 2025, time.March, 5)
+	if err != nil {
+		t.Fatalf("GetTopExpenses() error = %v", err)
+	}
+	wantAmounts := []int64{5000, 2000, 1500, 1000, 500}
+	if len(top) != len(wantAmounts) {
+		t.Fatalf("GetTopExpenses() returned %d transactions, want %d", len(top), len(wantAmounts))
+	}
+	for i, want := range wantAmounts {
+		if !top[i].Amount.Equal(decimal.NewFromInt(want)) {
+			t.Errorf("GetTopExpenses()[%d] = %s, want %d", i, top[i].Amount, want)
+		}
+	}
+
+	categories, err := svc.GetTopExpenseCategories(ctx, 2025, time.March, 3)
+	if err != nil {
+		t.Fatalf("GetTopExpenseCategories() error = %v", err)
+	}
+	wantNames := []string{"Rent", "Food", "Fun"}
+	if len(categories) != len(wantNames) {
+		t.Fatalf("GetTopExpenseCategories() returned %d categories, want %d", len(categories), len(wantNames))
+	}
+	for i, want := range wantNames {
+		if categories[i].CategoryName != want {
+			t.Errorf("GetTopExpenseCategories()[%d] = %s, want %s", i, categories[i].CategoryName, want)
+		}
+	}
+	// Rent 5000 dari total 10100
+	if pct := categories[0].Percentage; pct < 49.5 || pct > 49.6 {
+		t.Errorf("Rent share = %.2f%%, want ~49.5%%", pct)
+	}
+}
+
+func TestTransactionService_GetHeatmap(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 100000)
+
+	at := func(d, hour int) time.Time { return time.Date(2025, time.March, d, hour, 15, 0, 0, time.Local) }
+	inputs := []struct {
+		txType     models.TransactionType
+		amount     int64
+		date       time.Time
+		createdAt  time.Time
+		adjustment bool
+	}{
+		// Sabtu malam
+		{models.TransactionTypeExpense, 100, at(8, 21), at(8, 21), false},
+		{models.TransactionTypeExpense, 50, at(8, 21), at(8, 21), false},
+		// Senin pagi
+		{models.TransactionTypeExpense, 30, at(10, 8), at(10, 8), false},
+		// Dicatat belakangan: jamnya tidak diketahui
+		{models.TransactionTypeExpense, 70, at(11, 0), at(20, 9), false},
+		// Income dan adjustment tidak ikut
+		{models.TransactionTypeIncome, 9000, at(8, 21), at(8, 21), false},
+		{models.TransactionTypeExpense, 500, at(8, 21), at(8, 21), true},
+	}
+	for _, in := range inputs {
+		tx := models.NewTransaction(wallet.ID, in.txType, decimal.NewFromInt(in.amount))
+		tx.TransactionDate = in.date
+		tx.CreatedAt = in.createdAt
+		if in.adjustment {
+			tx.AddTag(models.TagAdjustment)
+		}
+		if err := repos.transaction.Create(ctx, tx); err != nil {
+			t.Fatalf("create transaction: %v", err)
+		}
+	}
+
+	heatmap, err := svc.GetHeatmap(ctx, repository.TransactionFilter{})
+	if err != nil {
+		t.Fatalf("GetHeatmap() error = %v", err)
+	}
+
+	if got := heatmap.Data[time.Saturday][21]; !got.Equal(decimal.NewFromInt(150)) {
+		t.Errorf("Saturday 21:00 = %s, want 150", got)
+	}
+	if got := heatmap.Data[time.Monday][8]; !got.Equal(decimal.NewFromInt(30)) {
+		t.Errorf("Monday 08:00 = %s, want 30", got)
+	}
+	if got := heatmap.Data[time.Tuesday][0]; !got.IsZero() {
+		t.Errorf("Tuesday 00:00 = %s, want 0 (backdated entry has no hour)", got)
+	}
+	if got := heatmap.UnknownHour[time.Tuesday]; !got.Equal(decimal.NewFromInt(70)) {
+		t.Errorf("UnknownHour[Tuesday] = %s, want 70", got)
+	}
+	if !heatmap.Max.Equal(decimal.NewFromInt(150)) {
+		t.Errorf("Max = %s, want 150", heatmap.Max)
+	}
+	if !heatmap.Total.Equal(decimal.NewFromInt(250)) {
+		t.Errorf("Total = %s, want 250", heatmap.Total)
+	}
+}
+
+func TestTransactionService_BulkCreate(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 100)
+
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.Local) }
+
+	// Expense tanggal 3 hanya cukup setelah income tanggal 2 masuk,
+	// walaupun urutan input terbalik.
+	txs, err := svc.BulkCreate(ctx, []CreateTransactionInput{
+		{WalletID: wallet.ID, Type: models.TransactionTypeExpense, Amount: decimal.NewFromInt(300), Date: day(3)},
+		{WalletID: wallet.ID, Type: models.TransactionTypeIncome, Amount: decimal.NewFromInt(500), Date: day(2)},
+	})
+	if err != nil {
+		t.Fatalf("BulkCreate() error = %v", err)
+	}
+	if len(txs) != 2 || txs[0].Type != models.TransactionTypeExpense {
+		t.Errorf("BulkCreate() returned %d transactions, want 2 in input order", len(txs))
+	}
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("balance = %s, want 300", got)
+	}
+
+	// Saldo akhir tidak cukup: tidak ada yang tersimpan
+	_, err = svc.BulkCreate(ctx, []CreateTransactionInput{
+		{WalletID: wallet.ID, Type: models.TransactionTypeIncome, Amount: decimal.NewFromInt(50), Date: day(4)},
+		{WalletID: wallet.ID, Type: models.TransactionTypeExpense, Amount: decimal.NewFromInt(1000), Date: day(5)},
+	})
+	if !errors.Is(err, ErrInsufficientBalance) || !errors.Is(err, ErrBulkInputInvalid) {
+		t.Fatalf("BulkCreate() error = %v, want ErrInsufficientBalance as ErrBulkInputInvalid", err)
+	}
+
+	// Input tidak valid: error menyebut nomor input
+	_, err = svc.BulkCreate(ctx, []CreateTransactionInput{
+		{WalletID: wallet.ID, Type: models.TransactionTypeIncome, Amount: decimal.NewFromInt(50), Date: day(4)},
+		{WalletID: wallet.ID, Type: models.TransactionTypeIncome, Amount: decimal.NewFromInt(-5), Date: day(5)},
+	})
+	var inputErr *BulkInputError
+	if !errors.As(err, &inputErr) || inputErr.Row != 2 || !strings.Contains(err.Error(), "transaction 2") {
+		t.Fatalf("BulkCreate() error = %v, want BulkInputError for transaction 2", err)
+	}
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("balance after failed bulk = %s, want 300", got)
+	}
+	all, _ := repos.transaction.List(ctx, repository.TransactionFilter{}, repository.ListParams{Limit: 10})
+	if len(all) != 2 {
+		t.Errorf("got %d transactions after failed bulk, want 2", len(all))
+	}
+}
+
+func TestTransactionService_GetCashFlowByWalletType(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	bank := repos.createWallet(t, "BCA", 100000)
+
+	gopay := models.NewWallet("GoPay", models.WalletTypeEWallet)
+	if err := repos.wallet.Create(ctx, gopay); err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	inputs := []struct {
+		wallet     *models.Wallet
+		txType     models.TransactionType
+		amount     int64
+		adjustment bool
+	}{
+		{bank, models.TransactionTypeIncome, 5000, false},
+		{bank, models.TransactionTypeExpense, 1200, false},
+		{gopay, models.TransactionTypeExpense, 300, false},
+		{gopay, models.TransactionTypeExpense, 200, false},
+		// Adjustment tidak ikut
+		{bank, models.TransactionTypeExpense, 9999, true},
+	}
+	for _, in := range inputs {
+		tx := models.NewTransaction(in.wallet.ID, in.txType, decimal.NewFromInt(in.amount))
+		if in.adjustment {
+			tx.AddTag(models.TagAdjustment)
+		}
+		if err := repos.transaction.Create(ctx, tx); err != nil {
+			t.Fatalf("create transaction: %v", err)
+		}
+	}
+
+	flows, err := svc.GetCashFlowByWalletType(ctx, repository.TransactionFilter{})
+	if err != nil {
+		t.Fatalf("GetCashFlowByWalletType() error = %v", err)
+	}
+
+	want := []struct {
+		walletType      models.WalletType
+		inflow, outflow int64
+		count           int
+	}{
+		// Tipe tanpa transaksi tetap ada dengan nilai 0
+		{models.WalletTypeCash, 0, 0, 0},
+		{models.WalletTypeBank, 5000, 1200, 2},
+		{models.WalletTypeEWallet, 0, 500, 2},
+		{models.WalletTypeInvestment, 0, 0, 0},
+	}
+	if len(flows) != len(want) {
+		t.Fatalf("got %d wallet types, want %d", len(flows), len(want))
+	}
+	for i, w := range want {
+		f := flows[i]
+		if f.WalletType != w.walletType || !f.Inflow.Equal(decimal.NewFromInt(w.inflow)) ||
+			!f.Outflow.Equal(decimal.NewFromInt(w.outflow)) || f.Count != w.count {
+			t.Errorf("flows[%d] = %s in %s out %s (%d), want %s in %d out %d (%d)",
+				i, f.WalletType, f.Inflow, f.Outflow, f.Count, w.walletType, w.inflow, w.outflow, w.count)
+		}
+	}
+}
+
+func TestTransactionService_GetTopMerchants(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 100000)
+
+	// Bulan tanpa transaksi: hasil kosong, bukan error
+	merchants, err := svc.GetTopMerchants(ctx, repository.TransactionFilter{}, TopMerchantsLimit)
+	if err != nil || len(merchants) != 0 {
+		t.Fatalf("GetTopMerchants() on empty month = %v, %v; want no merchants", merchants, err)
+	}
+
+	inputs := []struct {
+		txType      models.TransactionType
+		amount      int64
+		description string
+	}{
+		{models.TransactionTypeExpense, 100, "INDOMARET 0423"},
+		{models.TransactionTypeExpense, 250, "Indomaret 0817"},
+		{models.TransactionTypeExpense, 300, "Shell 12/04"},
+		{models.TransactionTypeExpense, 999, ""},
+		{models.TransactionTypeIncome, 5000, "Indomaret refund"},
+	}
+	for _, in := range inputs {
+		tx := models.NewTransaction(wallet.ID, in.txType, decimal.NewFromInt(in.amount))
+		tx.Description = in.description
+		if err := repos.transaction.Create(ctx, tx); err != nil {
+			t.Fatalf("create transaction: %v", err)
+		}
+	}
+
+	merchants, err = svc.GetTopMerchants(ctx, repository.TransactionFilter{}, TopMerchantsLimit)
+	if err != nil {
+		t.Fatalf("GetTopMerchants() error = %v", err)
+	}
+	if len(merchants) != 2 {
+		t.Fatalf("got %d merchants, want 2 (indomaret, shell): %+v", len(merchants), merchants)
+	}
+	if m := merchants[0]; m.Name != "indomaret" || !m.Total.Equal(decimal.NewFromInt(350)) || m.Count != 2 {
+		t.Errorf("merchants[0] = %s %s (%d), want indomaret 350 (2)", m.Name, m.Total, m.Count)
+	}
+	if m := merchants[1]; m.Name != "shell" || !m.Total.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("merchants[1] = %s %s, want shell 300", m.Name, m.Total)
+	}
+}
+
+func TestTransactionService_Create_UnknownCategory(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	categoryID := models.NewID()
+	_, err := svc.Create(context.Background(), CreateTransactionInput{
+		WalletID:   wallet.ID,
+		CategoryID: &categoryID,
+		Type:       models.TransactionTypeExpense,
+		Amount:     decimal.NewFromInt(100),
+	})
+	if !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("Create() error = %v, want ErrCategoryNotFound", err)
+	}
+	if balance := repos.balanceOf(t, wallet); !balance.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("balance = %s, want unchanged 1000", balance)
+	}
+}
+
+// countingWalletRepo dan countingTxRepo menghitung panggilan ke
+// repository yang dipakai BulkCreate, sebagai ganti round trip ke
+// PostgreSQL.
+type countingWalletRepo struct {
+	repository.WalletRepository
+	calls *int
+}
+
+func (c countingWalletRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Wallet, error) {
+	*c.calls++
+	return c.WalletRepository.GetByID(ctx, id)
+}
+
+func (c countingWalletRepo) AdjustBalance(ctx context.Context, id uuid.UUID, delta decimal.Decimal, change models.BalanceChange) (decimal.Decimal, error) {
+	*c.calls++
+	return c.WalletRepository.AdjustBalance(ctx, id, delta, change)
+}
+
+type countingTxRepo struct {
+	repository.TransactionRepository
+	calls *int
+}
+
+func (c countingTxRepo) Create(ctx context.Context, tx *models.Transaction) error {
+	*c.calls++
+	return c.TransactionRepository.Create(ctx, tx)
+}
+
+func (c countingTxRepo) CreateBatch(ctx context.Context, txs []*models.Transaction) error {
+	*c.calls++
+	return c.TransactionRepository.CreateBatch(ctx, txs)
+}
+
+// bulkInputs membuat n income bergantian di wallets.
+func bulkInputs(n int, wallets ...*models.Wallet) []CreateTransactionInput {
+	inputs := make([]CreateTransactionInput, n)
+	for i := range inputs {
+		inputs[i] = CreateTransactionInput{
+			WalletID: wallets[i%len(wallets)].ID,
+			Type:     models.TransactionTypeIncome,
+			Amount:   decimal.NewFromInt(int64(i + 1)),
+		}
+	}
+	return inputs
+}
+
+func TestTransactionService_BulkCreate_RoundTrips(t *testing.T) {
+	repos := newMemoryRepos()
+	bca := repos.createWallet(t, "BCA", 0)
+	gopay := repos.createWallet(t, "GoPay", 0)
+
+	calls := 0
+	svc := NewTransactionService(countingTxRepo{repos.transaction, &calls}, countingWalletRepo{repos.wallet, &calls}, repos.category, repos.txManager)
+
+	if _, err := svc.BulkCreate(context.Background(), bulkInputs(1000, bca, gopay)); err != nil {
+		t.Fatalf("BulkCreate() error = %v", err)
+	}
+	// 2 GetByID + 1 CreateBatch + 2 UpdateBalance
+	if calls != 5 {
+		t.Errorf("BulkCreate of 1000 rows made %d repository calls, want 5", calls)
+	}
+	if got := repos.balanceOf(t, bca); !got.Equal(decimal.NewFromInt(250000)) {
+		t.Errorf("BCA balance = %s, want 250000", got)
+	}
+}
+
+// Create dan BulkCreate memakai validasi yang sama (buildTransaction),
+// jadi input yang ditolak Create juga ditolak batch dengan error yang sama.
+func TestTransactionService_CreateAndBulkCreate_SameValidation(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000000)
+	unknown := models.NewID()
+
+	tests := []struct {
+		name  string
+		input CreateTransactionInput
+		want  error
+	}{
+		{"transfer tag", CreateTransactionInput{WalletID: wallet.ID, Type: models.TransactionTypeExpense,
+			Amount: decimal.NewFromInt(100), Tags: []string{"Transfer"}}, ErrTransferAsTransaction},
+		{"zero amount", CreateTransactionInput{WalletID: wallet.ID, Type: models.TransactionTypeExpense,
+			Amount: decimal.Zero}, models.ErrTransactionInvalidAmount},
+		{"unknown category", CreateTransactionInput{WalletID: wallet.ID, Type: models.TransactionTypeExpense,
+			Amount: decimal.NewFromInt(100), CategoryID: &unknown}, ErrCategoryNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := svc.Create(ctx, tt.input); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Create() error = %v, want %v", tt.name, err, tt.want)
+		}
+		_, err := svc.BulkCreate(ctx, []CreateTransactionInput{tt.input})
+		var inputErr *BulkInputError
+		if !errors.As(err, &inputErr) || inputErr.Row != 1 || !errors.Is(err, tt.want) {
+			t.Errorf("%s: BulkCreate() error = %v, want row 1 with %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func BenchmarkTransactionService_Create1000(b *testing.B) {
+	for b.Loop() {
+		b.StopTimer()
+		repos := newMemoryRepos()
+		wallet := models.NewWallet("BCA", models.WalletTypeBank)
+		_ = repos.wallet.Create(context.Background(), wallet)
+		svc := newTestTransactionService(repos)
+		inputs := bulkInputs(1000, wallet)
+		b.StartTimer()
+
+		for _, input := range inputs {
+			if _, err := svc.Create(context.Background(), input); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkTransactionService_BulkCreate1000(b *testing.B) {
+	for b.Loop() {
+		b.StopTimer()
+		repos := newMemoryRepos()
+		wallet := models.NewWallet("BCA", models.WalletTypeBank)
+		_ = repos.wallet.Create(context.Background(), wallet)
+		svc := newTestTransactionService(repos)
+		inputs := bulkInputs(1000, wallet)
+		b.StartTimer()
+
+		if _, err := svc.BulkCreate(context.Background(), inputs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
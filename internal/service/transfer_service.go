@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -25,6 +26,10 @@ type TransferService struct {
 	txManager    repository.TransactionManager
 }
 
+// ErrCrossCurrencyTransfer dikembalikan jika wallet sumber dan tujuan
+// berbeda currency tapi ExchangeRate tidak diisi.
+var ErrCrossCurrencyTransfer = errors.New("wallets use different currencies; an exchange rate is required")
+
 // NewTransferService membuat TransferService baru.
 func NewTransferService(
 	transferRepo repository.TransferRepository,
@@ -75,6 +80,16 @@ func (s *TransferService) Create(ctx context.Context, input CreateTransferInput)
 		return nil, errors.New("destination wallet is inactive")
 	}
 
+	// Transfer beda currency wajib pakai kurs; kurs diabaikan jika currency sama
+	var exchangeRate *decimal.Decimal
+	if !strings.EqualFold(fromWallet.Currency, toWallet.Currency) {
+		if input.ExchangeRate.IsZero() {
+			return nil, ErrCrossCurrencyTransfer
+		}
+		rate := input.ExchangeRate
+		exchangeRate = &rate
+	}
+
 	// Calculate total deducted from source
 	totalDeducted := input.Amount.Add(input.Fee)
 
@@ -87,6 +102,7 @@ func (s *TransferService) Create(ctx context.Context, input CreateTransferInput)
 	transfer := models.NewTransfer(input.FromWalletID, input.ToWalletID, input.Amount)
 	transfer.Fee = input.Fee
 	transfer.Note = input.Note
+	transfer.ExchangeRate = exchangeRate
 
 	if err := transfer.Validate(); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -94,7 +110,7 @@ func (s *TransferService) Create(ctx context.Context, input CreateTransferInput)
 
 	// Calculate new balances
 	fromNewBalance := fromWallet.Balance.Sub(totalDeducted)
-	toNewBalance := toWallet.Balance.Add(input.Amount)
+	toNewBalance := toWallet.Balance.Add(transfer.ReceivedAmount())
 
 	// Execute in transaction (ATOMIC)
 	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
@@ -103,13 +119,15 @@ func (s *TransferService) Create(ctx context.Context, input CreateTransferInput)
 			return fmt.Errorf("failed to create transfer: %w", err)
 		}
 
+		change := models.BalanceChange{Reason: models.BalanceReasonTransfer, ReferenceID: &transfer.ID}
+
 		// Update source wallet
-		if err := s.walletRepo.UpdateBalance(ctx, fromWallet.ID, fromNewBalance); err != nil {
+		if err := s.walletRepo.UpdateBalance(ctx, fromWallet.ID, fromNewBalance, change); err != nil {
 			return fmt.Errorf("failed to update source balance: %w", err)
 		}
 
 		// Update destination wallet
-		if err := s.walletRepo.UpdateBalance(ctx, toWallet.ID, toNewBalance); err != nil {
+		if err := s.walletRepo.UpdateBalance(ctx, toWallet.ID, toNewBalance, change); err != nil {
 			return fmt.Errorf("failed to update destination balance: %w", err)
 		}
 
@@ -162,4 +180,8 @@ type CreateTransferInput struct {
 	Amount       decimal.Decimal
 	Fee          decimal.Decimal
 	Note         string
+
+	// ExchangeRate wajib jika currency kedua wallet berbeda: wallet tujuan
+	// menerima Amount * ExchangeRate. Diabaikan jika currency sama.
+	ExchangeRate decimal.Decimal
 }
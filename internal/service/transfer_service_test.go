@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+func TestTransferService_Create(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewTransferService(repos.transfer, repos.wallet, repos.txManager)
+	bca := repos.createWallet(t, "BCA", 1000000)
+	gopay := repos.createWallet(t, "GoPay", 50000)
+
+	transfer, err := svc.Create(ctx, CreateTransferInput{
+		FromWalletID: bca.ID,
+		ToWalletID:   gopay.ID,
+		Amount:       decimal.NewFromInt(500000),
+		Fee:          decimal.NewFromInt(6500),
+		Note:         "Top up GoPay",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Fee hanya dipotong dari wallet asal
+	if got := repos.balanceOf(t, bca); !got.Equal(decimal.NewFromInt(493500)) {
+		t.Errorf("source balance = %s, want 493500", got)
+	}
+	if got := repos.balanceOf(t, gopay); !got.Equal(decimal.NewFromInt(550000)) {
+		t.Errorf("destination balance = %s, want 550000", got)
+	}
+
+	transfers, err := svc.GetByWallet(ctx, gopay.ID, repository.ListParams{})
+	if err != nil {
+		t.Fatalf("GetByWallet() error = %v", err)
+	}
+	if len(transfers) != 1 || transfers[0].ID != transfer.ID {
+		t.Errorf("GetByWallet() = %d transfers, want the created transfer", len(transfers))
+	}
+}
+
+func TestTransferService_Create_CrossCurrency(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewTransferService(repos.transfer, repos.wallet, repos.txManager)
+
+	wise := models.NewWallet("Wise", models.WalletTypeBank)
+	wise.Currency = "USD"
+	wise.Balance = decimal.NewFromInt(500)
+	if err := repos.wallet.Create(ctx, wise); err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	bca := repos.createWallet(t, "BCA", 0)
+
+	input := CreateTransferInput{
+		FromWalletID: wise.ID,
+		ToWalletID:   bca.ID,
+		Amount:       decimal.NewFromInt(100),
+		Fee:          decimal.NewFromInt(1),
+	}
+	if _, err := svc.Create(ctx, input); !errors.Is(err, ErrCrossCurrencyTransfer) {
+		t.Fatalf("Create() without rate error = %v, want ErrCrossCurrencyTransfer", err)
+	}
+	if got := repos.balanceOf(t, wise); !got.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("source balance after rejected transfer = %s, want 500", got)
+	}
+
+	input.ExchangeRate = decimal.NewFromInt(16250)
+	transfer, err := svc.Create(ctx, input)
+	if err != nil {
+		t.Fatalf("Create() with rate error = %v", err)
+	}
+	if transfer.ExchangeRate == nil || !transfer.ExchangeRate.Equal(input.ExchangeRate) {
+		t.Errorf("ExchangeRate = %v, want 16250", transfer.ExchangeRate)
+	}
+	if got := repos.balanceOf(t, wise); !got.Equal(decimal.NewFromInt(399)) {
+		t.Errorf("source balance = %s, want 399", got)
+	}
+	if got := repos.balanceOf(t, bca); !got.Equal(decimal.NewFromInt(1625000)) {
+		t.Errorf("destination balance = %s, want 1625000", got)
+	}
+}
+
+func TestTransferService_Create_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int64
+		fee     int64
+		same    bool
+		wantErr error
+	}{
+		{"same wallet", 100, 0, true, nil},
+		{"insufficient balance", 1000, 0, false, ErrInsufficientBalance},
+		{"fee makes balance insufficient", 950, 100, false, ErrInsufficientBalance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newMemoryRepos()
+			svc := NewTransferService(repos.transfer, repos.wallet, repos.txManager)
+			from := repos.createWallet(t, "BCA", 999)
+			to := repos.createWallet(t, "GoPay", 0)
+			if tt.same {
+				to = from
+			}
+
+			_, err := svc.Create(context.Background(), CreateTransferInput{
+				FromWalletID: from.ID,
+				ToWalletID:   to.ID,
+				Amount:       decimal.NewFromInt(tt.amount),
+				Fee:          decimal.NewFromInt(tt.fee),
+			})
+			if err == nil {
+				t.Fatal("Create() should return error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := repos.balanceOf(t, from); !got.Equal(decimal.NewFromInt(999)) {
+				t.Errorf("source balance = %s, want 999 (unchanged)", got)
+			}
+		})
+	}
+}
+
+func TestTransferService_NotInIncomeExpenseSummary(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	transfers := NewTransferService(repos.transfer, repos.wallet, repos.txManager)
+	transactions := newTestTransactionService(repos)
+	wallets := NewWalletService(repos.wallet, repos.transfer, repos.txManager)
+	bca := repos.createWallet(t, "BCA", 1000000)
+	cash := repos.createWallet(t, "Cash", 0)
+
+	if _, err := transactions.Create(ctx, CreateTransactionInput{
+		WalletID: bca.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(100000),
+	}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := transfers.Create(ctx, CreateTransferInput{
+		FromWalletID: bca.ID,
+		ToWalletID:   cash.ID,
+		Amount:       decimal.NewFromInt(300000),
+		Fee:          decimal.NewFromInt(2500),
+	}); err != nil {
+		t.Fatalf("transfer Create() error = %v", err)
+	}
+
+	summary, err := transactions.GetSummary(ctx, repository.TransactionFilter{})
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if summary.Count != 1 || !summary.TotalIncome.IsZero() || !summary.TotalExpense.Equal(decimal.NewFromInt(100000)) {
+		t.Errorf("summary = %+v, want only the 100000 expense", summary)
+	}
+
+	// Amount hanya berpindah; total berkurang sebesar expense + fee
+	total, err := wallets.GetTotalBalance(ctx)
+	if err != nil {
+		t.Fatalf("GetTotalBalance() error = %v", err)
+	}
+	if want := decimal.NewFromInt(897500); !total.Equal(want) {
+		t.Errorf("GetTotalBalance() = %s, want %s", total, want)
+	}
+}
+
+func TestTransactionService_Create_RejectsTransferTag(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := newTestTransactionService(repos)
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	_, err := svc.Create(context.Background(), CreateTransactionInput{
+		WalletID: wallet.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(500),
+		Tags:     []string{"Transfer"},
+	})
+	if !errors.Is(err, ErrTransferAsTransaction) {
+		t.Errorf("Create() error = %v, want ErrTransferAsTransaction", err)
+	}
+	if got := repos.balanceOf(t, wallet); !got.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("balance = %s, want unchanged 1000", got)
+	}
+}
+
+func TestWalletService_Transfer(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewWalletService(repos.wallet, repos.transfer, repos.txManager)
+	cash := repos.createWallet(t, "Cash", 300)
+	bank := repos.createWallet(t, "BCA", 0)
+
+	transfer, err := svc.Transfer(ctx, CreateTransferInput{
+		FromWalletID: cash.ID,
+		ToWalletID:   bank.ID,
+		Amount:       decimal.NewFromInt(200),
+	})
+	if err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+
+	if got := repos.balanceOf(t, cash); !got.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("source balance = %s, want 100", got)
+	}
+	if got := repos.balanceOf(t, bank); !got.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("destination balance = %s, want 200", got)
+	}
+
+	// Transfer row tercatat
+	if _, err := repos.transfer.GetByID(ctx, transfer.ID); err != nil {
+		t.Errorf("transfer row not recorded: %v", err)
+	}
+}
+
+func TestWalletService_Transfer_NotConfigured(t *testing.T) {
+	repos := newMemoryRepos()
+	svc := NewWalletService(repos.wallet, nil, nil)
+
+	_, err := svc.Transfer(context.Background(), CreateTransferInput{
+		FromWalletID: repos.createWallet(t, "Cash", 300).ID,
+		ToWalletID:   repos.createWallet(t, "BCA", 0).ID,
+		Amount:       decimal.NewFromInt(200),
+	})
+	if err == nil {
+		t.Error("Transfer() without transfer repository should return error")
+	}
+}
@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -17,19 +21,53 @@ import (
 // - CRUD operations untuk wallet
 // - Validasi business rules
 // - Menghitung total balance
+// - Transfer antar wallet (dengan transfer record)
+// - Import mutasi bank (OFX) ke wallet
 //
 // WalletService TIDAK langsung update balance.
-// Balance diupdate melalui TransactionService saat ada transaksi.
+// Balance diupdate melalui TransactionService saat ada transaksi,
+// atau melalui Transfer yang mencatat row di tabel transfers.
 type WalletService struct {
-	repo repository.WalletRepository
+	repo         repository.WalletRepository
+	transferRepo repository.TransferRepository
+	txManager    repository.TransactionManager
+
+	// txService dipakai ImportFromBank (lihat SetTransactionService).
+	txService *TransactionService
 }
 
 // NewWalletService membuat WalletService baru.
 //
+// transferRepo dan txManager hanya dipakai oleh Transfer; boleh nil
+// jika caller tidak pernah melakukan transfer (misalnya di test).
+//
 //	walletRepo := postgres.NewWalletRepository(pool)
-//	walletService := service.NewWalletService(walletRepo)
-func NewWalletService(repo repository.WalletRepository) *WalletService {
-	return &WalletService{repo: repo}
+//	transferRepo := postgres.NewTransferRepository(pool)
+//	walletService := service.NewWalletService(walletRepo, transferRepo, txManager)
+func NewWalletService(
+	repo repository.WalletRepository,
+	transferRepo repository.TransferRepository,
+	txManager repository.TransactionManager,
+) *WalletService {
+	return &WalletService{
+		repo:         repo,
+		transferRepo: transferRepo,
+		txManager:    txManager,
+	}
+}
+
+// ErrWalletModified dikembalikan Update jika wallet masih diubah proses
+// lain setelah update dicoba ulang.
+var ErrWalletModified = errors.New("the wallet was modified by another process, please retry")
+
+// conflictRetries adalah berapa kali Update wallet/goal dibaca ulang dan
+// dicoba lagi setelah repository.ErrConflict.
+const conflictRetries = 1
+
+// SetTransactionService mengatur TransactionService yang dipakai
+// ImportFromBank untuk menyimpan transaksi (dan meng-update saldo).
+func (s *WalletService) SetTransactionService(txService *TransactionService) {
+	s.txService = txService
 }
 
 // Create membuat wallet baru.
@@ -48,14 +86,17 @@ func NewWalletService(repo repository.WalletRepository) *WalletService {
 //	})
 func (s *WalletService) Create(ctx context.Context, input CreateWalletInput) (*models.Wallet, error) {
 	wallet := &models.Wallet{
-		BaseModel: models.BaseModel{ID: models.NewID()},
-		Name:      input.Name,
-		Type:      input.Type,
-		Balance:   input.InitialBalance,
-		Currency:  input.Currency,
-		Color:     input.Color,
-		Icon:      input.Icon,
-		IsActive:  true,
+		BaseModel:   models.BaseModel{ID: models.NewID()},
+		Name:        input.Name,
+		Type:        input.Type,
+		Balance:     input.InitialBalance,
+		Currency:    input.Currency,
+		Color:       input.Color,
+		Icon:        input.Icon,
+		IsActive:    true,
+		OpeningDate: input.OpeningDate,
+
+		LowBalanceThreshold: input.LowBalanceThreshold,
 	}
 
 	// Validate wallet
@@ -89,6 +130,30 @@ func (s *WalletService) List(ctx context.Context, filter repository.WalletFilter
 	return wallets, nil
 }
 
+// GetLowBalanceWallets mengambil wallet aktif yang saldonya di bawah
+// LowBalanceThreshold (lihat Wallet.IsLowBalance), urut nama. Wallet
+// tanpa threshold tidak pernah masuk.
+//
+//	low, err := walletService.GetLowBalanceWallets(ctx)
+//	for _, w := range low {
+//	    fmt.Printf("%s: %s < %s\n", w.Name, w.Balance, w.LowBalanceThreshold)
+//	}
+func (s *WalletService) GetLowBalanceWallets(ctx context.Context) ([]*models.Wallet, error) {
+	isActive := true
+	wallets, err := s.List(ctx, repository.WalletFilter{IsActive: &isActive, SortBy: repository.WalletSortName})
+	if err != nil {
+		return nil, err
+	}
+
+	var low []*models.Wallet
+	for _, w := range wallets {
+		if w.IsLowBalance() {
+			low = append(low, w)
+		}
+	}
+	return low, nil
+}
+
 // ListActive mengambil semua wallet aktif.
 // Shortcut untuk filter IsActive = true.
 func (s *WalletService) ListActive(ctx context.Context) ([]*models.Wallet, error) {
@@ -97,7 +162,24 @@ func (s *WalletService) ListActive(ctx context.Context) ([]*models.Wallet, error
 }
 
 // Update memperbarui wallet.
+//
+// Jika wallet diubah proses lain di antara baca dan tulis
+// (repository.ErrConflict), wallet dibaca ulang dan field di input
+// diterapkan lagi sekali. Jika masih konflik, return ErrWalletModified.
 func (s *WalletService) Update(ctx context.Context, input UpdateWalletInput) (*models.Wallet, error) {
+	for attempt := 0; ; attempt++ {
+		wallet, err := s.update(ctx, input)
+		if !errors.Is(err, repository.ErrConflict) {
+			return wallet, err
+		}
+		if attempt == conflictRetries {
+			return nil, ErrWalletModified
+		}
+	}
+}
+
+// update membaca wallet, menerapkan input, lalu menyimpannya satu kali.
+func (s *WalletService) update(ctx context.Context, input UpdateWalletInput) (*models.Wallet, error) {
 	// Get existing wallet
 	wallet, err := s.repo.GetByID(ctx, input.ID)
 	if err != nil {
@@ -120,6 +202,12 @@ func (s *WalletService) Update(ctx context.Context, input UpdateWalletInput) (*m
 	if input.Icon != nil {
 		wallet.Icon = *input.Icon
 	}
+	if input.LowBalanceThreshold != nil {
+		wallet.LowBalanceThreshold = input.LowBalanceThreshold
+	}
+	if input.ClearLowBalanceThreshold {
+		wallet.LowBalanceThreshold = nil
+	}
 
 	// Validate
 	if err := wallet.Validate(); err != nil {
@@ -142,7 +230,12 @@ func (s *WalletService) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// GetTotalBalance menghitung total saldo semua wallet aktif.
+// GetTotalBalance menghitung total saldo semua wallet aktif. Ini angka
+// utama di dashboard dan `wallet balance`; wallet yang diarsipkan tidak
+// ikut (lihat GetTotalBalanceIncludingArchived).
+//
+// Transfer tidak mengubah total ini kecuali fee-nya: amount hanya
+// berpindah antar wallet, sedangkan fee benar-benar keluar dari wallet asal.
 func (s *WalletService) GetTotalBalance(ctx context.Context) (decimal.Decimal, error) {
 	total, err := s.repo.GetTotalBalance(ctx)
 	if err != nil {
@@ -151,6 +244,208 @@ func (s *WalletService) GetTotalBalance(ctx context.Context) (decimal.Decimal, e
 	return total, nil
 }
 
+// GetTotalBalanceExcludingArchived menghitung total saldo wallet yang
+// tidak diarsipkan, langsung dari daftar wallet aktif.
+//
+// Wallet yang di-delete (soft delete, IsActive = false) dianggap diarsipkan
+// dan saldonya tidak ikut dihitung walaupun masih tersimpan.
+// Berbeda dengan GetTotalBalance yang mengandalkan aggregate di repository,
+// method ini tidak bergantung pada implementasi query repository.
+func (s *WalletService) GetTotalBalanceExcludingArchived(ctx context.Context) (decimal.Decimal, error) {
+	wallets, err := s.ListActive(ctx)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get total balance: %w", err)
+	}
+
+	total := decimal.Zero
+	for _, w := range wallets {
+		total = total.Add(w.Balance)
+	}
+	return total, nil
+}
+
+// GetTotalBalanceIncludingArchived menghitung total saldo semua wallet,
+// termasuk yang diarsipkan (soft delete, IsActive = false). Dipakai
+// `wallet balance --include-archived` untuk total sepanjang waktu, misalnya
+// rekening lama yang ditutup tapi saldonya belum dipindahkan.
+func (s *WalletService) GetTotalBalanceIncludingArchived(ctx context.Context) (decimal.Decimal, error) {
+	wallets, err := s.List(ctx, repository.WalletFilter{})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get total balance: %w", err)
+	}
+
+	total := decimal.Zero
+	for _, w := range wallets {
+		total = total.Add(w.Balance)
+	}
+	return total, nil
+}
+
+// Transfer memindahkan saldo antar wallet dan mencatat transfer row.
+//
+// Validasi dan update balance sama dengan TransferService.Create:
+// kedua wallet harus aktif, saldo asal cukup untuk amount + fee,
+// dan semua perubahan dijalankan dalam satu database transaction.
+//
+//	transfer, err := walletService.Transfer(ctx, service.CreateTransferInput{
+//	    FromWalletID: bcaID,
+//	    ToWalletID:   gopayID,
+//	    Amount:       decimal.NewFromInt(500000),
+//	})
+func (s *WalletService) Transfer(ctx context.Context, input CreateTransferInput) (*models.Transfer, error) {
+	if s.transferRepo == nil || s.txManager == nil {
+		return nil, errors.New("wallet service is not configured for transfers")
+	}
+
+	transferService := NewTransferService(s.transferRepo, s.repo, s.txManager)
+	return transferService.Create(ctx, input)
+}
+
+// GetInactiveWallets mengambil wallet nonaktif (soft-deleted) beserta
+// tanggal transaksi terakhirnya, untuk keperluan audit.
+//
+//	infos, err := walletService.GetInactiveWallets(ctx)
+//	for _, info := range infos {
+//	    fmt.Println(info.Name, info.LastTransactionDate)
+//	}
+func (s *WalletService) GetInactiveWallets(ctx context.Context) ([]*InactiveWalletInfo, error) {
+	isActive := false
+	activities, err := s.repo.ListWithLastTransaction(ctx, repository.WalletFilter{IsActive: &isActive})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inactive wallets: %w", err)
+	}
+
+	infos := make([]*InactiveWalletInfo, 0, len(activities))
+	for _, a := range activities {
+		infos = append(infos, &InactiveWalletInfo{
+			Wallet:              a.Wallet,
+			LastTransactionDate: a.LastTransactionDate,
+		})
+	}
+	return infos, nil
+}
+
+// ListBalanceAudit mengambil riwayat perubahan saldo wallet, terbaru
+// dulu. Setiap entry menyebut saldo lama dan baru, alasan, dan ID
+// transaksi/transfer/goal penyebabnya.
+//
+//	entries, err := walletService.ListBalanceAudit(ctx, wallet.ID, repository.ListParams{Limit: 50})
+//	for _, e := range entries {
+//	    fmt.Println(e.CreatedAt, e.Reason, e.Delta())
+//	}
+func (s *WalletService) ListBalanceAudit(ctx context.Context, walletID uuid.UUID, params repository.ListParams) ([]*models.BalanceAudit, error) {
+	entries, err := s.repo.ListBalanceAudit(ctx, walletID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list balance audit: %w", err)
+	}
+	return entries, nil
+}
+
+// ImportFromBank mengimport mutasi bank dari file OFX 2.x (lihat ParseOFX)
+// ke wallet lewat TransactionService.BulkCreate, jadi saldo wallet ikut
+// ter-update. TRNAMT positif menjadi income, negatif menjadi expense.
+//
+// FITID disimpan sebagai ExternalReference; transaksi yang FITID-nya sudah
+// ada di wallet (import sebelumnya, atau dobel di file yang sama) di-skip
+// dan dihitung di ImportResult.AlreadyImported. Jika satu transaksi
+// ditolak (mis. saldo tidak cukup), tidak ada yang tersimpan.
+//
+//	walletService.SetTransactionService(txService)
+//	result, err := walletService.ImportFromBank(ctx, wallet.ID, "bca-januari.ofx")
+func (s *WalletService) ImportFromBank(ctx context.Context, walletID uuid.UUID, ofxFile string) (*ImportResult, error) {
+	if s.txService == nil {
+		return nil, errors.New("wallet service is not configured for bank imports")
+	}
+
+	wallet, err := s.repo.GetByID(ctx, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	file, err := os.Open(ofxFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	statement, err := ParseOFX(file)
+	if err != nil {
+		return nil, err
+	}
+	if statement.Currency != "" && !strings.EqualFold(statement.Currency, wallet.Currency) {
+		return nil, fmt.Errorf("statement currency %s does not match wallet currency %s", statement.Currency, wallet.Currency)
+	}
+
+	refs := make([]string, 0, len(statement.Transactions))
+	for _, tx := range statement.Transactions {
+		refs = append(refs, tx.FITID)
+	}
+	existing, err := s.txService.txRepo.ExistingExternalReferences(ctx, walletID, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check imported transactions: %w", err)
+	}
+	seen := make(map[string]bool, len(statement.Transactions))
+	for _, ref := range existing {
+		seen[ref] = true
+	}
+
+	result := &ImportResult{TotalRows: len(statement.Transactions)}
+	var inputs []CreateTransactionInput
+	for _, tx := range statement.Transactions {
+		switch {
+		case seen[tx.FITID]:
+			result.AlreadyImported++
+			continue
+		case tx.Amount.IsZero():
+			result.SkippedCount++
+			continue
+		}
+		seen[tx.FITID] = true
+
+		txType := models.TransactionTypeIncome
+		if tx.Amount.IsNegative() {
+			txType = models.TransactionTypeExpense
+		}
+		inputs = append(inputs, CreateTransactionInput{
+			WalletID:          walletID,
+			Type:              txType,
+			Amount:            tx.Amount.Abs(),
+			Description:       tx.Description(),
+			Date:              tx.Posted,
+			ExternalReference: tx.FITID,
+		})
+	}
+
+	if len(inputs) > 0 {
+		if _, err := s.txService.BulkCreate(ctx, inputs); err != nil {
+			return nil, err
+		}
+	}
+	result.SuccessCount = len(inputs)
+
+	return result, nil
+}
+
+// InactiveWalletInfo adalah wallet nonaktif beserta kapan terakhir dipakai.
+type InactiveWalletInfo struct {
+	*models.Wallet
+
+	// LastTransactionDate nil jika wallet belum pernah punya transaksi.
+	LastTransactionDate *time.Time
+}
+
+// ImportResult adalah hasil ImportFromBank.
+type ImportResult struct {
+	TotalRows    int
+	SuccessCount int
+
+	// SkippedCount adalah transaksi dengan TRNAMT 0.
+	SkippedCount int
+
+	// AlreadyImported adalah transaksi yang FITID-nya sudah ada di wallet.
+	AlreadyImported int
+}
+
 // CreateWalletInput adalah input untuk membuat wallet baru.
 type CreateWalletInput struct {
 	Name           string
@@ -159,6 +454,13 @@ type CreateWalletInput struct {
 	InitialBalance decimal.Decimal
 	Color          string
 	Icon           string
+
+	// OpeningDate adalah tanggal wallet mulai dipakai (optional).
+	// nil = tanggal wallet dibuat.
+	OpeningDate *time.Time
+
+	// LowBalanceThreshold adalah batas alert saldo rendah (optional).
+	LowBalanceThreshold *decimal.Decimal
 }
 
 // UpdateWalletInput adalah input untuk update wallet.
@@ -170,4 +472,9 @@ type UpdateWalletInput struct {
 	Currency *string
 	Color    *string
 	Icon     *string
+
+	// LowBalanceThreshold mengganti batas alert saldo rendah;
+	// ClearLowBalanceThreshold menghapusnya (tanpa alert).
+	LowBalanceThreshold      *decimal.Decimal
+	ClearLowBalanceThreshold bool
 }
@@ -2,7 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -14,12 +19,14 @@ import (
 // Mock repositories for testing
 
 type mockWalletRepo struct {
-	wallets map[uuid.UUID]*models.Wallet
+	wallets         map[uuid.UUID]*models.Wallet
+	lastTransaction map[uuid.UUID]*time.Time
 }
 
 func newMockWalletRepo() *mockWalletRepo {
 	return &mockWalletRepo{
-		wallets: make(map[uuid.UUID]*models.Wallet),
+		wallets:         make(map[uuid.UUID]*models.Wallet),
+		lastTransaction: make(map[uuid.UUID]*time.Time),
 	}
 }
 
@@ -35,6 +42,11 @@ func (m *mockWalletRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Wal
 	return nil, repository.ErrNotFound
 }
 
+func (m *mockWalletRepo) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	_, ok := m.wallets[id]
+	return ok, nil
+}
+
 func (m *mockWalletRepo) List(ctx context.Context, filter repository.WalletFilter) ([]*models.Wallet, error) {
 	var result []*models.Wallet
 	for _, w := range m.wallets {
@@ -62,7 +74,7 @@ func (m *mockWalletRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return repository.ErrNotFound
 }
 
-func (m *mockWalletRepo) UpdateBalance(ctx context.Context, id uuid.UUID, balance decimal.Decimal) error {
+func (m *mockWalletRepo) UpdateBalance(ctx context.Context, id uuid.UUID, balance decimal.Decimal, change models.BalanceChange) error {
 	if w, ok := m.wallets[id]; ok {
 		w.Balance = balance
 		return nil
@@ -70,6 +82,18 @@ func (m *mockWalletRepo) UpdateBalance(ctx context.Context, id uuid.UUID, balanc
 	return repository.ErrNotFound
 }
 
+func (m *mockWalletRepo) AdjustBalance(ctx context.Context, id uuid.UUID, delta decimal.Decimal, change models.BalanceChange) (decimal.Decimal, error) {
+	if w, ok := m.wallets[id]; ok {
+		w.Balance = w.Balance.Add(delta)
+		return w.Balance, nil
+	}
+	return decimal.Zero, repository.ErrNotFound
+}
+
+func (m *mockWalletRepo) ListBalanceAudit(ctx context.Context, walletID uuid.UUID, params repository.ListParams) ([]*models.BalanceAudit, error) {
+	return nil, nil
+}
+
 func (m *mockWalletRepo) GetTotalBalance(ctx context.Context) (decimal.Decimal, error) {
 	total := decimal.Zero
 	for _, w := range m.wallets {
@@ -80,11 +104,23 @@ func (m *mockWalletRepo) GetTotalBalance(ctx context.Context) (decimal.Decimal,
 	return total, nil
 }
 
+func (m *mockWalletRepo) ListWithLastTransaction(ctx context.Context, filter repository.WalletFilter) ([]*repository.WalletActivity, error) {
+	wallets, _ := m.List(ctx, filter)
+	var result []*repository.WalletActivity
+	for _, w := range wallets {
+		result = append(result, &repository.WalletActivity{
+			Wallet:              w,
+			LastTransactionDate: m.lastTransaction[w.ID],
+		})
+	}
+	return result, nil
+}
+
 // Tests
 
 func TestWalletService_Create(t *testing.T) {
 	repo := newMockWalletRepo()
-	svc := NewWalletService(repo)
+	svc := NewWalletService(repo, nil, nil)
 
 	tests := []struct {
 		name    string
@@ -149,7 +185,7 @@ func TestWalletService_Create(t *testing.T) {
 
 func TestWalletService_GetTotalBalance(t *testing.T) {
 	repo := newMockWalletRepo()
-	svc := NewWalletService(repo)
+	svc := NewWalletService(repo, nil, nil)
 	ctx := context.Background()
 
 	// Create wallets
@@ -178,9 +214,49 @@ func TestWalletService_GetTotalBalance(t *testing.T) {
 	}
 }
 
+func TestWalletService_GetTotalBalanceExcludingArchived(t *testing.T) {
+	repo := newMockWalletRepo()
+	svc := NewWalletService(repo, nil, nil)
+	ctx := context.Background()
+
+	bca, _ := svc.Create(ctx, CreateWalletInput{
+		Name:           "BCA",
+		Type:           models.WalletTypeBank,
+		Currency:       "IDR",
+		InitialBalance: decimal.NewFromInt(1000000),
+	})
+	old, _ := svc.Create(ctx, CreateWalletInput{
+		Name:           "Old Account",
+		Type:           models.WalletTypeBank,
+		Currency:       "IDR",
+		InitialBalance: decimal.NewFromInt(250000),
+	})
+
+	// Arsipkan wallet lama
+	if err := svc.Delete(ctx, old.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	total, err := svc.GetTotalBalanceExcludingArchived(ctx)
+	if err != nil {
+		t.Fatalf("GetTotalBalanceExcludingArchived() error = %v", err)
+	}
+	if !total.Equal(bca.Balance) {
+		t.Errorf("GetTotalBalanceExcludingArchived() = %v, want %v", total, bca.Balance)
+	}
+
+	total, err = svc.GetTotalBalanceIncludingArchived(ctx)
+	if err != nil {
+		t.Fatalf("GetTotalBalanceIncludingArchived() error = %v", err)
+	}
+	if want := bca.Balance.Add(old.Balance); !total.Equal(want) {
+		t.Errorf("GetTotalBalanceIncludingArchived() = %v, want %v", total, want)
+	}
+}
+
 func TestWalletService_Delete(t *testing.T) {
 	repo := newMockWalletRepo()
-	svc := NewWalletService(repo)
+	svc := NewWalletService(repo, nil, nil)
 	ctx := context.Background()
 
 	// Create wallet
@@ -202,3 +278,321 @@ func TestWalletService_Delete(t *testing.T) {
 		t.Error("Expected wallet to be inactive after delete")
 	}
 }
+
+func TestWalletService_GetInactiveWallets(t *testing.T) {
+	repo := newMockWalletRepo()
+	svc := NewWalletService(repo, nil, nil)
+	ctx := context.Background()
+
+	active, _ := svc.Create(ctx, CreateWalletInput{Name: "Active", Type: models.WalletTypeCash, Currency: "IDR"})
+	used, _ := svc.Create(ctx, CreateWalletInput{Name: "Old Bank", Type: models.WalletTypeBank, Currency: "IDR"})
+	unused, _ := svc.Create(ctx, CreateWalletInput{Name: "Never Used", Type: models.WalletTypeEWallet, Currency: "IDR"})
+
+	lastUsed := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo.lastTransaction[used.ID] = &lastUsed
+
+	_ = svc.Delete(ctx, used.ID)
+	_ = svc.Delete(ctx, unused.ID)
+
+	infos, err := svc.GetInactiveWallets(ctx)
+	if err != nil {
+		t.Fatalf("GetInactiveWallets() error = %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("got %d inactive wallets, want 2", len(infos))
+	}
+
+	for _, info := range infos {
+		switch info.ID {
+		case active.ID:
+			t.Error("active wallet should not be listed")
+		case used.ID:
+			if info.LastTransactionDate == nil || !info.LastTransactionDate.Equal(lastUsed) {
+				t.Errorf("LastTransactionDate = %v, want %v", info.LastTransactionDate, lastUsed)
+			}
+		case unused.ID:
+			if info.LastTransactionDate != nil {
+				t.Errorf("LastTransactionDate = %v, want nil", info.LastTransactionDate)
+			}
+		}
+	}
+}
+
+func TestWalletService_ListBalanceAudit(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	bca := repos.createWallet(t, "BCA", 1000000)
+	gopay := repos.createWallet(t, "GoPay", 0)
+
+	txService := newTestTransactionService(repos)
+	tx, err := txService.Create(ctx, CreateTransactionInput{
+		WalletID: bca.ID,
+		Type:     models.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(50000),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := txService.Delete(ctx, tx.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	transfer, err := NewTransferService(repos.transfer, repos.wallet, repos.txManager).Create(ctx, CreateTransferInput{
+		FromWalletID: bca.ID,
+		ToWalletID:   gopay.ID,
+		Amount:       decimal.NewFromInt(200000),
+	})
+	if err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+
+	svc := NewWalletService(repos.wallet, repos.transfer, repos.txManager)
+	entries, err := svc.ListBalanceAudit(ctx, bca.ID, repository.ListParams{})
+	if err != nil {
+		t.Fatalf("ListBalanceAudit() error = %v", err)
+	}
+
+	want := []struct {
+		reason   models.BalanceChangeReason
+		ref      uuid.UUID
+		from, to int64
+	}{
+		{models.BalanceReasonTransfer, transfer.ID, 1000000, 800000},
+		{models.BalanceReasonTransactionDeleted, tx.ID, 950000, 1000000},
+		{models.BalanceReasonTransactionCreated, tx.ID, 1000000, 950000},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Reason != w.reason || e.ReferenceID == nil || *e.ReferenceID != w.ref ||
+			!e.OldBalance.Equal(decimal.NewFromInt(w.from)) || !e.NewBalance.Equal(decimal.NewFromInt(w.to)) {
+			t.Errorf("entry %d = %s %s → %s, want %s %d → %d", i, e.Reason, e.OldBalance, e.NewBalance, w.reason, w.from, w.to)
+		}
+	}
+
+	// Transfer juga tercatat di wallet tujuan
+	received, _ := svc.ListBalanceAudit(ctx, gopay.ID, repository.ListParams{})
+	if len(received) != 1 || received[0].Reason != models.BalanceReasonTransfer || !received[0].Delta().Equal(decimal.NewFromInt(200000)) {
+		t.Errorf("destination audit = %v, want one +200000 transfer entry", received)
+	}
+}
+
+func TestWalletService_ImportFromBank(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	wallet := repos.createWallet(t, "BCA", 0)
+
+	svc := NewWalletService(repos.wallet, repos.transfer, repos.txManager)
+	svc.SetTransactionService(newTestTransactionService(repos))
+
+	ofxFile := filepath.Join(t.TempDir(), "bca.ofx")
+	if err := os.WriteFile(ofxFile, []byte(testOFX), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := svc.ImportFromBank(ctx, wallet.ID, ofxFile)
+	if err != nil {
+		t.Fatalf("ImportFromBank() error = %v", err)
+	}
+	if result.TotalRows != 2 || result.SuccessCount != 2 || result.AlreadyImported != 0 {
+		t.Errorf("result = %+v, want 2 rows imported", result)
+	}
+	// Gaji (5.000.000) masuk dulu, baru belanja 45.000,50
+	if got, want := repos.balanceOf(t, wallet), decimal.RequireFromString("4954999.50"); !got.Equal(want) {
+		t.Errorf("balance = %s, want %s", got, want)
+	}
+
+	txs, err := repos.transaction.List(ctx, repository.TransactionFilter{WalletID: &wallet.ID}, repository.ListParams{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs := map[string]models.TransactionType{}
+	for _, tx := range txs {
+		refs[tx.ExternalReference] = tx.Type
+	}
+	if refs["TX-001"] != models.TransactionTypeIncome || refs["TX-002"] != models.TransactionTypeExpense {
+		t.Errorf("imported transactions = %v, want TX-001 income and TX-002 expense", refs)
+	}
+
+	// Import ulang file yang sama tidak membuat transaksi dobel
+	result, err = svc.ImportFromBank(ctx, wallet.ID, ofxFile)
+	if err != nil {
+		t.Fatalf("second ImportFromBank() error = %v", err)
+	}
+	if result.SuccessCount != 0 || result.AlreadyImported != 2 {
+		t.Errorf("second result = %+v, want everything already imported", result)
+	}
+}
+
+func TestWalletService_ImportFromBank_CurrencyMismatch(t *testing.T) {
+	repos := newMemoryRepos()
+	wallet := models.NewWallet("Wise", models.WalletTypeBank)
+	wallet.Currency = "USD"
+	if err := repos.wallet.Create(context.Background(), wallet); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewWalletService(repos.wallet, repos.transfer, repos.txManager)
+	svc.SetTransactionService(newTestTransactionService(repos))
+
+	ofxFile := filepath.Join(t.TempDir(), "wise.ofx")
+	if err := os.WriteFile(ofxFile, []byte(testOFX), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.ImportFromBank(context.Background(), wallet.ID, ofxFile); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("ImportFromBank() error = %v, want currency mismatch", err)
+	}
+}
+
+// racingWalletRepo menjalankan concurrent setelah GetByID sebanyak races
+// kali, mensimulasikan proses lain yang mengubah wallet di antara baca
+// dan tulis WalletService.Update.
+type racingWalletRepo struct {
+	repository.WalletRepository
+	races      int
+	concurrent func()
+}
+
+func (r *racingWalletRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Wallet, error) {
+	w, err := r.WalletRepository.GetByID(ctx, id)
+	if err == nil && r.races > 0 {
+		r.races--
+		r.concurrent()
+	}
+	return w, err
+}
+
+func TestWalletService_Update_RetriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	// Transaksi dari proses lain mengubah saldo setelah Update membaca wallet
+	racing := &racingWalletRepo{WalletRepository: repos.wallet, races: 1}
+	racing.concurrent = func() {
+		if err := repos.wallet.UpdateBalance(ctx, wallet.ID, decimal.NewFromInt(750), models.BalanceChange{Reason: models.BalanceReasonAdjustment}); err != nil {
+			t.Fatalf("UpdateBalance() error = %v", err)
+		}
+	}
+	svc := NewWalletService(racing, nil, nil)
+
+	name := "BCA Tabungan"
+	updated, err := svc.Update(ctx, UpdateWalletInput{ID: wallet.ID, Name: &name})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, _ := repos.wallet.GetByID(ctx, wallet.ID)
+	if got.Name != name {
+		t.Errorf("Name = %q, want %q", got.Name, name)
+	}
+	if !got.Balance.Equal(decimal.NewFromInt(750)) {
+		t.Errorf("Balance = %s, want 750 (concurrent change overwritten)", got.Balance)
+	}
+	// Create = 1, UpdateBalance = 2, Update = 3
+	if got.Version != 3 || updated.Version != 3 {
+		t.Errorf("Version = %d (returned %d), want 3", got.Version, updated.Version)
+	}
+}
+
+func TestWalletService_Update_ConflictPersists(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	wallet := repos.createWallet(t, "BCA", 1000)
+
+	balance := int64(1000)
+	racing := &racingWalletRepo{WalletRepository: repos.wallet, races: 2}
+	racing.concurrent = func() {
+		balance -= 100
+		if err := repos.wallet.UpdateBalance(ctx, wallet.ID, decimal.NewFromInt(balance), models.BalanceChange{Reason: models.BalanceReasonAdjustment}); err != nil {
+			t.Fatalf("UpdateBalance() error = %v", err)
+		}
+	}
+	svc := NewWalletService(racing, nil, nil)
+
+	name := "BCA Tabungan"
+	_, err := svc.Update(ctx, UpdateWalletInput{ID: wallet.ID, Name: &name})
+	if !errors.Is(err, ErrWalletModified) {
+		t.Fatalf("Update() error = %v, want ErrWalletModified", err)
+	}
+
+	got, _ := repos.wallet.GetByID(ctx, wallet.ID)
+	if got.Name != "BCA" {
+		t.Errorf("Name = %q, want unchanged", got.Name)
+	}
+	if !got.Balance.Equal(decimal.NewFromInt(800)) {
+		t.Errorf("Balance = %s, want 800", got.Balance)
+	}
+}
+
+func TestWalletService_GetLowBalanceWallets(t *testing.T) {
+	ctx := context.Background()
+	repos := newMemoryRepos()
+	svc := NewWalletService(repos.wallet, nil, nil)
+
+	threshold := decimal.NewFromInt(500)
+	for _, spec := range []struct {
+		name      string
+		balance   int64
+		threshold *decimal.Decimal
+	}{
+		{"OVO", 100, &threshold},  // di bawah threshold
+		{"BCA", 1000, &threshold}, // di atas threshold
+		{"Cash", 0, nil},          // tanpa alert
+		{"GoPay", 200, &threshold},
+	} {
+		if _, err := svc.Create(ctx, CreateWalletInput{
+			Name:                spec.name,
+			Type:                models.WalletTypeEWallet,
+			Currency:            "IDR",
+			InitialBalance:      decimal.NewFromInt(spec.balance),
+			LowBalanceThreshold: spec.threshold,
+		}); err != nil {
+			t.Fatalf("Create(%s) error = %v", spec.name, err)
+		}
+	}
+
+	low, err := svc.GetLowBalanceWallets(ctx)
+	if err != nil {
+		t.Fatalf("GetLowBalanceWallets() error = %v", err)
+	}
+	var names []string
+	for _, w := range low {
+		names = append(names, w.Name)
+	}
+	if strings.Join(names, ",") != "GoPay,OVO" {
+		t.Errorf("GetLowBalanceWallets() = %v, want [GoPay OVO]", names)
+	}
+
+	// Hapus alert GoPay, naikkan threshold BCA
+	gopay, bca := low[0], (*models.Wallet)(nil)
+	wallets, _ := svc.ListActive(ctx)
+	for _, w := range wallets {
+		if w.Name == "BCA" {
+			bca = w
+		}
+	}
+	if _, err := svc.Update(ctx, UpdateWalletInput{ID: gopay.ID, ClearLowBalanceThreshold: true}); err != nil {
+		t.Fatalf("Update(clear) error = %v", err)
+	}
+	higher := decimal.NewFromInt(2000)
+	if _, err := svc.Update(ctx, UpdateWalletInput{ID: bca.ID, LowBalanceThreshold: &higher}); err != nil {
+		t.Fatalf("Update(threshold) error = %v", err)
+	}
+
+	low, _ = svc.GetLowBalanceWallets(ctx)
+	names = nil
+	for _, w := range low {
+		names = append(names, w.Name)
+	}
+	if strings.Join(names, ",") != "BCA,OVO" {
+		t.Errorf("GetLowBalanceWallets() after update = %v, want [BCA OVO]", names)
+	}
+
+	negative := decimal.NewFromInt(-1)
+	if _, err := svc.Update(ctx, UpdateWalletInput{ID: bca.ID, LowBalanceThreshold: &negative}); !errors.Is(err, models.ErrWalletNegativeLowBalance) {
+		t.Errorf("Update(negative) error = %v, want ErrWalletNegativeLowBalance", err)
+	}
+}
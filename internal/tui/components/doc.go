@@ -8,6 +8,9 @@
 // - Menu: Navigation menu
 // - Progress: Progress bar untuk budgets dan goals
 // - Chart: ASCII charts untuk visualisasi
+// - StatusBar: Status koneksi, jumlah wallet, dan waktu refresh terakhir
+// - KeyHelp: Help bar berisi key bindings yang di-wrap sesuai lebar layar
+// - NoteEditor: Edit teks multi-line di $EDITOR (lewat tea.ExecProcess)
 //
 // Composing components:
 //
@@ -29,5 +32,3 @@
 //	    )
 //	}
 package components
-
-// TODO: Add component implementations
@@ -0,0 +1,68 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyHelpSeparator memisahkan binding dalam satu baris.
+const keyHelpSeparator = " · "
+
+// KeyBinding adalah satu shortcut keyboard beserta penjelasannya.
+type KeyBinding struct {
+	Key         string
+	Description string
+}
+
+// String memformat binding sebagai "[key] description".
+func (b KeyBinding) String() string {
+	return "[" + b.Key + "] " + b.Description
+}
+
+// KeyHelp adalah help bar di bagian bawah layar yang menampilkan
+// daftar key bindings.
+//
+//	help := components.KeyHelp{
+//	    Bindings: []components.KeyBinding{{Key: "r", Description: "Refresh"}, {Key: "q", Description: "Quit"}},
+//	    Style:    helpStyle,
+//	}
+//	fmt.Println(help.Render(80)) // [r] Refresh · [q] Quit
+type KeyHelp struct {
+	Bindings []KeyBinding
+
+	// Style dipakai untuk me-render seluruh bar (termasuk padding).
+	Style lipgloss.Style
+}
+
+// Render me-render bindings, dibungkus ke beberapa baris supaya setiap
+// baris (termasuk padding/border Style) muat dalam width.
+//
+// Binding tidak pernah dipotong di tengah; binding yang lebih lebar dari
+// width tetap ditaruh di barisnya sendiri. Jika width <= 0, semua binding
+// ditaruh dalam satu baris.
+func (h KeyHelp) Render(width int) string {
+	if len(h.Bindings) == 0 {
+		return ""
+	}
+
+	available := width - h.Style.GetHorizontalFrameSize()
+
+	var lines []string
+	var line strings.Builder
+	for _, b := range h.Bindings {
+		item := b.String()
+		if line.Len() > 0 {
+			if width > 0 && lipgloss.Width(line.String()+keyHelpSeparator+item) > available {
+				lines = append(lines, line.String())
+				line.Reset()
+			} else {
+				line.WriteString(keyHelpSeparator)
+			}
+		}
+		line.WriteString(item)
+	}
+	lines = append(lines, line.String())
+
+	return h.Style.Render(strings.Join(lines, "\n"))
+}
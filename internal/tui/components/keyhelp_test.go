@@ -0,0 +1,88 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestKeyHelp_Render(t *testing.T) {
+	bindings := []KeyBinding{
+		{"← →", "Navigate"},
+		{"1-5", "Jump"},
+		{"r", "Refresh"},
+		{"q", "Quit"},
+	}
+
+	tests := []struct {
+		name  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "single line",
+			width: 80,
+			want:  []string{"[← →] Navigate · [1-5] Jump · [r] Refresh · [q] Quit"},
+		},
+		{
+			name:  "wrapped",
+			width: 30,
+			want: []string{
+				"[← →] Navigate · [1-5] Jump",
+				"[r] Refresh · [q] Quit",
+			},
+		},
+		{
+			name:  "narrower than a binding",
+			width: 5,
+			want:  []string{"[← →] Navigate", "[1-5] Jump", "[r] Refresh", "[q] Quit"},
+		},
+		{
+			name:  "no width",
+			width: 0,
+			want:  []string{"[← →] Navigate · [1-5] Jump · [r] Refresh · [q] Quit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := KeyHelp{Bindings: bindings}.Render(tt.width)
+			if lines := strings.Split(got, "\n"); !equalLines(lines, tt.want) {
+				t.Errorf("Render(%d) = %q, want %q", tt.width, lines, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyHelp_Render_StylePadding(t *testing.T) {
+	help := KeyHelp{
+		Bindings: []KeyBinding{{"r", "Refresh"}, {"q", "Quit"}},
+		Style:    lipgloss.NewStyle().Padding(0, 1),
+	}
+
+	// "[r] Refresh · [q] Quit" = 22 kolom, + padding 2 tidak muat di 23
+	got := help.Render(23)
+	if h := lipgloss.Height(got); h != 2 {
+		t.Errorf("Render(23) height = %d, want 2 (padding counts toward width)", h)
+	}
+	if w := lipgloss.Width(got); w > 23 {
+		t.Errorf("Render(23) width = %d, want <= 23", w)
+	}
+
+	if got := (KeyHelp{}).Render(80); got != "" {
+		t.Errorf("Render() without bindings = %q, want empty", got)
+	}
+}
+
+func equalLines(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if strings.TrimRight(got[i], " ") != want[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultEditor dipakai jika $VISUAL dan $EDITOR kosong.
+const defaultEditor = "vi"
+
+// NoteEditor mengedit teks multi-line (mis. Transaction.Note) di editor
+// eksternal: teks ditulis ke file sementara, editor dijalankan, lalu
+// isi file dibaca kembali.
+//
+// Di Bubble Tea, Cmd dijalankan dengan tea.ExecProcess supaya TUI
+// berhenti sementara selama editor terbuka:
+//
+//	editor, err := components.NewNoteEditor(tx.Note)
+//	if err != nil {
+//	    return m, nil
+//	}
+//	return m, tea.ExecProcess(editor.Cmd(), func(err error) tea.Msg {
+//	    note, err := editor.Result(err)
+//	    return noteEditedMsg{note: note, err: err}
+//	})
+type NoteEditor struct {
+	path string
+}
+
+// NewNoteEditor menulis text ke file sementara untuk diedit.
+func NewNoteEditor(text string) (*NoteEditor, error) {
+	f, err := os.CreateTemp("", "wallet-note-*.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create note file: %w", err)
+	}
+	defer f.Close()
+
+	// Baris terakhir diakhiri newline seperti file teks biasa; whitespace
+	// di ujung note di-trim lagi oleh Transaction.Validate
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if _, err := f.WriteString(text); err != nil {
+		os.Remove(f.Name())
+		return nil, fmt.Errorf("failed to write note file: %w", err)
+	}
+	return &NoteEditor{path: f.Name()}, nil
+}
+
+// Cmd adalah command editor untuk file note: $VISUAL, $EDITOR, atau vi.
+// Editor boleh berisi argumen, contoh EDITOR="code --wait".
+func (e *NoteEditor) Cmd() *exec.Cmd {
+	editor := strings.TrimSpace(os.Getenv("VISUAL"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("EDITOR"))
+	}
+	if editor == "" {
+		editor = defaultEditor
+	}
+
+	args := strings.Fields(editor)
+	return exec.Command(args[0], append(args[1:], e.path)...)
+}
+
+// Result membaca teks hasil edit lalu menghapus file sementara. runErr
+// adalah error dari menjalankan Cmd; jika tidak nil, editor dianggap
+// batal dan perubahan dibuang.
+func (e *NoteEditor) Result(runErr error) (string, error) {
+	defer os.Remove(e.path)
+
+	if runErr != nil {
+		return "", fmt.Errorf("editor failed: %w", runErr)
+	}
+
+	data, err := os.ReadFile(e.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read note file: %w", err)
+	}
+	return string(data), nil
+}
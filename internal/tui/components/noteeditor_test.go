@@ -0,0 +1,106 @@
+package components
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository/memory"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// fakeEditor membuat script editor yang menambahkan line ke file yang
+// diedit, lalu memasangnya sebagai $EDITOR.
+func fakeEditor(t *testing.T, line string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake editor is a shell script")
+	}
+
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	content := "#!/bin/sh\nprintf '%s\\n' '" + line + "' >> \"$1\"\n"
+	if err := os.WriteFile(script, []byte(content), 0o755); err != nil {
+		t.Fatalf("failed to write fake editor: %v", err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", script)
+}
+
+func TestNoteEditor_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	walletRepo := memory.NewWalletRepository(store)
+	txService := service.NewTransactionService(
+		memory.NewTransactionRepository(store),
+		walletRepo,
+		memory.NewCategoryRepository(store),
+		memory.NewTransactionManager(store),
+	)
+
+	wallet := models.NewWallet("BCA", models.WalletTypeBank)
+	wallet.Balance = decimal.NewFromInt(1000)
+	if err := walletRepo.Create(ctx, wallet); err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	tx, err := txService.Create(ctx, service.CreateTransactionInput{
+		WalletID:    wallet.ID,
+		Type:        models.TransactionTypeExpense,
+		Amount:      decimal.NewFromInt(500),
+		Description: "Headphones",
+		Note:        "Bought at the mall",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	fakeEditor(t, "Warranty until 2027")
+
+	editor, err := NewNoteEditor(tx.Note)
+	if err != nil {
+		t.Fatalf("NewNoteEditor() error = %v", err)
+	}
+	note, err := editor.Result(editor.Cmd().Run())
+	if err != nil {
+		t.Fatalf("Result() error = %v", err)
+	}
+	if _, err := os.Stat(editor.path); !os.IsNotExist(err) {
+		t.Errorf("note file %s not removed after Result()", editor.path)
+	}
+
+	if _, err := txService.UpdateNote(ctx, tx.ID, note); err != nil {
+		t.Fatalf("UpdateNote() error = %v", err)
+	}
+
+	saved, err := txService.GetByID(ctx, tx.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	want := "Bought at the mall\nWarranty until 2027"
+	if saved.Note != want {
+		t.Errorf("Note = %q, want %q", saved.Note, want)
+	}
+	if saved.Description != "Headphones" {
+		t.Errorf("Description = %q, want unchanged", saved.Description)
+	}
+}
+
+func TestNoteEditor_EditorFailed(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", filepath.Join(t.TempDir(), "missing-editor"))
+
+	editor, err := NewNoteEditor("keep me")
+	if err != nil {
+		t.Fatalf("NewNoteEditor() error = %v", err)
+	}
+	if _, err := editor.Result(editor.Cmd().Run()); err == nil {
+		t.Error("Result() error = nil, want error when the editor cannot run")
+	}
+	if _, err := os.Stat(editor.path); !os.IsNotExist(err) {
+		t.Errorf("note file %s not removed after a failed edit", editor.path)
+	}
+}
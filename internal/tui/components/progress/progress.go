@@ -0,0 +1,169 @@
+// Package progress berisi komponen progress bar untuk budgets dan goals.
+//
+// Dipakai oleh TUI dashboard dan CLI (wallet budget status, wallet goal list),
+// jadi aturan warna dan perilaku edge case sama di kedua tempat:
+//
+//   - < 80%     → hijau (Normal)
+//   - 80–100%   → kuning (Warning)
+//   - > 100%    → merah (Danger), bar penuh tapi label menampilkan persentase asli
+//   - NaN / < 0 → dianggap 0%
+//
+// Contoh penggunaan:
+//
+//	bar := progress.New(20)
+//	fmt.Println(bar.Render(75)) // ███████████████░░░░░
+//
+//	bar = progress.New(10)
+//	bar.Theme = progress.PlainTheme()
+//	bar.ShowPercent = true
+//	fmt.Println(bar.Render(150)) // ########## 150%
+package progress
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Batas persentase untuk pergantian warna.
+const (
+	// WarningThreshold adalah persentase mulai warna kuning.
+	WarningThreshold = 80.0
+
+	// DangerThreshold adalah persentase di atas mana warna menjadi merah.
+	DangerThreshold = 100.0
+)
+
+// Theme menentukan glyph dan warna progress bar.
+type Theme struct {
+	// FilledGlyph adalah karakter untuk bagian yang sudah terisi.
+	FilledGlyph string
+
+	// EmptyGlyph adalah karakter untuk bagian yang belum terisi.
+	EmptyGlyph string
+
+	// Normal dipakai untuk bagian terisi saat progress < 80%.
+	Normal lipgloss.Style
+
+	// Warning dipakai untuk bagian terisi saat progress 80–100%.
+	Warning lipgloss.Style
+
+	// Danger dipakai untuk bagian terisi saat progress > 100%.
+	Danger lipgloss.Style
+
+	// Empty dipakai untuk bagian yang belum terisi.
+	Empty lipgloss.Style
+}
+
+// DefaultTheme mengembalikan theme berwarna dengan block glyphs.
+func DefaultTheme() Theme {
+	return Theme{
+		FilledGlyph: "█",
+		EmptyGlyph:  "░",
+		Normal:      lipgloss.NewStyle().Foreground(lipgloss.Color("#22C55E")),
+		Warning:     lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")),
+		Danger:      lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")),
+		Empty:       lipgloss.NewStyle().Foreground(lipgloss.Color("#334155")),
+	}
+}
+
+// PlainTheme mengembalikan theme tanpa warna dengan glyph ASCII (# dan -).
+// Dipakai saat output tidak mendukung warna (pipe, NO_COLOR).
+func PlainTheme() Theme {
+	return Theme{
+		FilledGlyph: "#",
+		EmptyGlyph:  "-",
+		Normal:      lipgloss.NewStyle(),
+		Warning:     lipgloss.NewStyle(),
+		Danger:      lipgloss.NewStyle(),
+		Empty:       lipgloss.NewStyle(),
+	}
+}
+
+// Bar adalah progress bar dengan lebar tetap.
+type Bar struct {
+	// Width adalah jumlah glyph bar. Minimal 1.
+	Width int
+
+	// Theme menentukan glyph dan warna.
+	Theme Theme
+
+	// ShowPercent menambahkan label persentase di belakang bar.
+	ShowPercent bool
+}
+
+// New membuat Bar dengan DefaultTheme.
+func New(width int) Bar {
+	return Bar{
+		Width: width,
+		Theme: DefaultTheme(),
+	}
+}
+
+// Render me-render bar untuk persentase percent (0-100, boleh > 100).
+//
+// Bar tidak pernah lebih panjang dari Width. Bagian terisi dibulatkan ke bawah,
+// jadi bar baru penuh tepat di 100% - 99.5% tidak terlihat selesai.
+func (b Bar) Render(percent float64) string {
+	percent = sanitize(percent)
+
+	width := b.Width
+	if width < 1 {
+		width = 1
+	}
+
+	filled := width
+	if percent < DangerThreshold {
+		filled = int(percent / 100 * float64(width))
+	}
+
+	var sb strings.Builder
+	if filled > 0 {
+		sb.WriteString(b.fillStyle(percent).Render(strings.Repeat(b.Theme.FilledGlyph, filled)))
+	}
+	if filled < width {
+		sb.WriteString(b.Theme.Empty.Render(strings.Repeat(b.Theme.EmptyGlyph, width-filled)))
+	}
+
+	if b.ShowPercent {
+		sb.WriteString(" ")
+		sb.WriteString(FormatPercent(percent))
+	}
+
+	return sb.String()
+}
+
+// fillStyle memilih style bagian terisi berdasarkan persentase.
+func (b Bar) fillStyle(percent float64) lipgloss.Style {
+	switch {
+	case percent > DangerThreshold:
+		return b.Theme.Danger
+	case percent >= WarningThreshold:
+		return b.Theme.Warning
+	default:
+		return b.Theme.Normal
+	}
+}
+
+// FormatPercent memformat persentase untuk label, dibulatkan ke bawah
+// (sama seperti bar) supaya 99.5% tidak ditampilkan sebagai 100%.
+//
+//	FormatPercent(150)  // "150%"
+//	FormatPercent(99.5) // "99%"
+func FormatPercent(percent float64) string {
+	percent = sanitize(percent)
+	if math.IsInf(percent, 1) {
+		return "∞%"
+	}
+	return fmt.Sprintf("%.0f%%", math.Floor(percent))
+}
+
+// sanitize mengubah input tidak valid (NaN, negatif) menjadi 0.
+func sanitize(percent float64) float64 {
+	if math.IsNaN(percent) || percent < 0 {
+		return 0
+	}
+	return percent
+}
@@ -0,0 +1,95 @@
+package progress
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBar_Render(t *testing.T) {
+	tests := []struct {
+		name    string
+		width   int
+		percent float64
+		want    string
+	}{
+		{"zero", 10, 0, "---------- 0%"},
+		{"half", 10, 50, "#####----- 50%"},
+		{"almost full is not full", 10, 99.5, "#########- 99%"},
+		{"full", 10, 100, "########## 100%"},
+		{"over budget caps bar", 10, 150, "########## 150%"},
+		{"width 1 half", 1, 50, "- 50%"},
+		{"width 1 full", 1, 100, "# 100%"},
+		{"width 1 over", 1, 150, "# 150%"},
+		{"zero width treated as 1", 0, 100, "# 100%"},
+		{"negative", 10, -20, "---------- 0%"},
+		{"NaN", 10, math.NaN(), "---------- 0%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bar := Bar{Width: tt.width, Theme: PlainTheme(), ShowPercent: true}
+			if got := bar.Render(tt.percent); got != tt.want {
+				t.Errorf("Render(%v) = %q, want %q", tt.percent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBar_Render_WithoutPercent(t *testing.T) {
+	bar := Bar{Width: 4, Theme: PlainTheme()}
+	if got := bar.Render(50); got != "##--" {
+		t.Errorf("Render(50) = %q, want %q", got, "##--")
+	}
+}
+
+func TestBar_FillStyle(t *testing.T) {
+	theme := DefaultTheme()
+	bar := Bar{Width: 10, Theme: theme}
+
+	tests := []struct {
+		name    string
+		percent float64
+		want    string
+	}{
+		{"below warning", 79.9, "normal"},
+		{"at warning", 80, "warning"},
+		{"at limit", 100, "warning"},
+		{"over limit", 100.1, "danger"},
+	}
+
+	styles := map[string]any{
+		"normal":  theme.Normal.GetForeground(),
+		"warning": theme.Warning.GetForeground(),
+		"danger":  theme.Danger.GetForeground(),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bar.fillStyle(tt.percent).GetForeground()
+			if got != styles[tt.want] {
+				t.Errorf("fillStyle(%v) = %v, want %s (%v)", tt.percent, got, tt.want, styles[tt.want])
+			}
+		})
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		percent float64
+		want    string
+	}{
+		{0, "0%"},
+		{99.5, "99%"},
+		{100, "100%"},
+		{150.7, "150%"},
+		{-5, "0%"},
+		{math.NaN(), "0%"},
+		{math.Inf(1), "∞%"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatPercent(tt.percent); got != tt.want {
+			t.Errorf("FormatPercent(%v) = %q, want %q", tt.percent, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StatusBarHeight adalah jumlah baris yang dipakai StatusBar.
+// Dipakai parent view untuk menghitung sisa tinggi konten.
+const StatusBarHeight = 1
+
+// Warna status bar, sama dengan palette dashboard.
+var (
+	statusBarStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#94A3B8")).
+			Background(lipgloss.Color("#1E293B"))
+
+	statusOKStyle   = statusBarStyle.Foreground(lipgloss.Color("#22C55E")).Bold(true)
+	statusDownStyle = statusBarStyle.Foreground(lipgloss.Color("#EF4444")).Bold(true)
+)
+
+// StatusBar adalah bar satu baris di bagian bawah layar yang menampilkan
+// status koneksi database, jumlah wallet, dan waktu refresh terakhir.
+//
+//	bar := components.StatusBar{DBConnected: true, WalletCount: 3, LastRefresh: time.Now(), Width: 80}
+//	fmt.Println(bar.Render()) // ● DB │ 3 wallets          Last updated: 14:05:09
+type StatusBar struct {
+	// DBConnected true jika load data terakhir berhasil.
+	DBConnected bool
+
+	// WalletCount adalah jumlah wallet aktif.
+	WalletCount int
+
+	// LastRefresh adalah waktu load data terakhir yang berhasil.
+	// Zero value ditampilkan sebagai "--:--:--".
+	LastRefresh time.Time
+
+	// Width adalah lebar layar. Jika 0, bar tidak di-padding.
+	Width int
+}
+
+// Render me-render status bar.
+//
+// Bagian kiri berisi indikator DB dan jumlah wallet, bagian kanan
+// waktu refresh. Jika Width cukup, sisa ruang diisi spasi sehingga
+// bagian kanan rata kanan.
+func (s StatusBar) Render() string {
+	indicator := statusDownStyle.Render("✗ DB")
+	if s.DBConnected {
+		indicator = statusOKStyle.Render("● DB")
+	}
+
+	left := indicator + statusBarStyle.Render(" │ "+formatWalletCount(s.WalletCount))
+	right := statusBarStyle.Render("Last updated: " + s.formatRefresh())
+
+	gap := s.Width - lipgloss.Width(left) - lipgloss.Width(right) - 2
+	if gap < 1 {
+		gap = 1
+	}
+
+	return statusBarStyle.Render(" ") + left +
+		statusBarStyle.Render(strings.Repeat(" ", gap)) +
+		right + statusBarStyle.Render(" ")
+}
+
+// formatRefresh memformat LastRefresh sebagai HH:MM:SS.
+func (s StatusBar) formatRefresh() string {
+	if s.LastRefresh.IsZero() {
+		return "--:--:--"
+	}
+	return s.LastRefresh.Format(time.TimeOnly)
+}
+
+// formatWalletCount menghasilkan "1 wallet" / "N wallets".
+func formatWalletCount(n int) string {
+	if n == 1 {
+		return "1 wallet"
+	}
+	return fmt.Sprintf("%d wallets", n)
+}
@@ -0,0 +1,56 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestStatusBar_Render(t *testing.T) {
+	refresh := time.Date(2025, 3, 14, 9, 5, 7, 0, time.Local)
+
+	tests := []struct {
+		name string
+		bar  StatusBar
+		want []string
+	}{
+		{
+			name: "connected",
+			bar:  StatusBar{DBConnected: true, WalletCount: 3, LastRefresh: refresh, Width: 80},
+			want: []string{"● DB", "3 wallets", "Last updated: 09:05:07"},
+		},
+		{
+			name: "disconnected",
+			bar:  StatusBar{DBConnected: false, WalletCount: 1, Width: 80},
+			want: []string{"✗ DB", "1 wallet", "Last updated: --:--:--"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.bar.Render()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Render() = %q, want to contain %q", got, want)
+				}
+			}
+			if w := lipgloss.Width(got); w != tt.bar.Width {
+				t.Errorf("Render() width = %d, want %d", w, tt.bar.Width)
+			}
+			if h := lipgloss.Height(got); h != StatusBarHeight {
+				t.Errorf("Render() height = %d, want %d", h, StatusBarHeight)
+			}
+		})
+	}
+}
+
+func TestStatusBar_Render_Narrow(t *testing.T) {
+	bar := StatusBar{DBConnected: true, WalletCount: 12, Width: 10}
+
+	got := bar.Render()
+	if !strings.Contains(got, "12 wallets") || !strings.Contains(got, "Last updated") {
+		t.Errorf("Render() = %q, want all parts even when narrower than content", got)
+	}
+}
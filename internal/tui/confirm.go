@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// confirmDialog adalah dialog konfirmasi sebelum aksi destruktif
+// (mis. delete wallet). Selama dialog terbuka semua tombol masuk ke
+// HandleKey: hanya ConfirmKey yang menjalankan aksi, tombol lain
+// (termasuk CancelKey) menutup dialog.
+type confirmDialog struct {
+	// Message adalah pertanyaan yang ditampilkan, contoh
+	// "Delete wallet BCA?".
+	Message string
+
+	// ConfirmKey dan CancelKey ditampilkan di tombol dialog.
+	ConfirmKey string
+	CancelKey  string
+}
+
+// newConfirmDialog membuat dialog dengan tombol y/n.
+func newConfirmDialog(message string) *confirmDialog {
+	return &confirmDialog{Message: message, ConfirmKey: "y", CancelKey: "n"}
+}
+
+// Confirmed mengecek apakah key adalah ConfirmKey. Key lain berarti
+// dialog dibatalkan.
+func (d *confirmDialog) Confirmed(key string) bool {
+	return key == d.ConfirmKey
+}
+
+// View me-render pesan dan dua tombol di tengah box.
+func (d *confirmDialog) View() string {
+	buttons := lipgloss.JoinHorizontal(lipgloss.Top,
+		confirmButtonStyle.Render(d.ConfirmKey+" Yes"),
+		"  ",
+		cancelButtonStyle.Render(d.CancelKey+" No"),
+	)
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		cardTitleStyle.Render("⚠️  "+d.Message),
+		buttons,
+		"",
+		hintStyle.Render("any other key cancels"),
+	)
+	return boxStyle.BorderForeground(dangerColor).Render(content)
+}
@@ -3,17 +3,24 @@ package tui
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/Adityanrhm/wallet-twin/internal/app"
 	"github.com/Adityanrhm/wallet-twin/internal/models"
 	"github.com/Adityanrhm/wallet-twin/internal/repository"
-	"github.com/Adityanrhm/wallet-twin/internal/repository/postgres"
 	"github.com/Adityanrhm/wallet-twin/internal/service"
+	"github.com/Adityanrhm/wallet-twin/internal/tui/components"
+	"github.com/Adityanrhm/wallet-twin/internal/tui/components/progress"
+	"github.com/Adityanrhm/wallet-twin/internal/utils"
+	"github.com/Adityanrhm/wallet-twin/internal/version"
 )
 
 // Tab represents the current active tab
@@ -25,142 +32,668 @@ const (
 	TabTransactions
 	TabBudgets
 	TabGoals
+	TabInsights
 )
 
+// tabCount adalah jumlah tab, untuk Tab/Shift+Tab yang berputar.
+const tabCount = TabInsights + 1
+
 func (t Tab) String() string {
-	return []string{"📊 Overview", "💼 Wallets", "📝 Transactions", "📊 Budgets", "🎯 Goals"}[t]
+	return []string{"📊 Overview", "💼 Wallets", "📝 Transactions", "📊 Budgets", "🎯 Goals", "🔥 Insights"}[t]
+}
+
+// Jumlah item di card "Top Expenses" dan "Top Categories" (tab Overview).
+const (
+	topExpensesLimit   = 5
+	topCategoriesLimit = 3
+)
+
+// recentTxLimit adalah jumlah transaksi di tab Transactions.
+const recentTxLimit = 5
+
+// walletSortOrders adalah urutan siklus tombol s di tab Wallets dan
+// Transactions.
+var walletSortOrders = []repository.WalletSortOrder{
+	repository.WalletSortCreatedAt,
+	repository.WalletSortName,
+	repository.WalletSortBalance,
+}
+
+// nextWalletSort mengembalikan urutan setelah order di walletSortOrders.
+func nextWalletSort(order repository.WalletSortOrder) repository.WalletSortOrder {
+	for i, o := range walletSortOrders {
+		if o == order {
+			return walletSortOrders[(i+1)%len(walletSortOrders)]
+		}
+	}
+	return walletSortOrders[0]
 }
 
+// sortLabel adalah label urutan di judul tab, contoh "by balance ↓".
+// Di tab Transactions, name dan balance berarti description dan amount.
+func sortLabel(tab Tab, order repository.WalletSortOrder) string {
+	switch {
+	case order == repository.WalletSortName && tab == TabTransactions:
+		return "by description ↑"
+	case order == repository.WalletSortName:
+		return "by name ↑"
+	case order == repository.WalletSortBalance && tab == TabTransactions:
+		return "by amount ↓"
+	case order == repository.WalletSortBalance:
+		return "by balance ↓"
+	default:
+		return "by created_at ↓"
+	}
+}
+
+// sortTransactions mengurutkan transaksi sesuai order: description A-Z,
+// amount terbesar dulu, atau urutan asal (terbaru dulu).
+func sortTransactions(txs []*models.Transaction, order repository.WalletSortOrder) {
+	switch order {
+	case repository.WalletSortName:
+		sort.SliceStable(txs, func(i, j int) bool {
+			return strings.ToLower(txs[i].Description) < strings.ToLower(txs[j].Description)
+		})
+	case repository.WalletSortBalance:
+		sort.SliceStable(txs, func(i, j int) bool {
+			return txs[i].Amount.GreaterThan(txs[j].Amount)
+		})
+	}
+}
+
+// heatmapDays adalah window (hari ke belakang) untuk heatmap di tab Insights.
+const heatmapDays = 90
+
+// overviewChromeHeight adalah tinggi header, tabs, dan status bar.
+// Ditambah tinggi help bar (bisa lebih dari satu baris di layar sempit),
+// sisa tinggi layar dipakai untuk card di tab Overview.
+const overviewChromeHeight = 3 + components.StatusBarHeight
+
+// maxRecentErrors adalah jumlah error non-kritis terakhir yang disimpan
+// untuk warning strip.
+const maxRecentErrors = 5
+
 // DashboardModel adalah state utama untuk TUI dashboard.
 type DashboardModel struct {
 	app       *app.App
 	activeTab Tab
-	width     int
-	height    int
+
+	// walletSort adalah urutan wallet (dan recent transactions), diganti
+	// dengan tombol s di tab Wallets/Transactions.
+	walletSort repository.WalletSortOrder
+
+	// walletCursor adalah index wallet terpilih di tab Wallets (j/k).
+	walletCursor int
+
+	// txCursor adalah index transaksi terpilih di tab Transactions (j/k).
+	// detail adalah transaksi yang dibuka dengan enter, nil jika detail
+	// view tertutup.
+	txCursor int
+	detail   *models.Transaction
+
+	// budgetCursor adalah index budget terpilih di tab Budgets (j/k).
+	budgetCursor int
+
+	// txFilter membatasi daftar di tab Transactions; diisi dari tab
+	// Wallets dan Budgets (enter), dihapus dengan x.
+	txFilter txFilter
+
+	// ctx adalah parent semua load; cancelLoad membatalkan load yang
+	// sedang berjalan (refresh baru atau dashboard ditutup).
+	ctx        context.Context
+	cancelLoad context.CancelFunc
+
+	width  int
+	height int
 
 	// Data
-	wallets          []*models.Wallet
-	totalBalance     decimal.Decimal
-	recentTxs        []*models.Transaction
-	monthlySummary   *repository.TransactionSummary
-	budgetStatuses   []*repository.BudgetStatus
-	goals            []*models.Goal
+	// refs adalah semua wallet dan category (termasuk yang diarsipkan),
+	// untuk nama dan currency di daftar transaksi.
+	refs           *service.RefData
+	wallets        []*models.Wallet
+	totalBalance   decimal.Decimal
+	recentTxs      []*models.Transaction
+	monthlySummary *repository.TransactionSummary
+	budgetStatuses []*repository.BudgetStatus
+	goals          []*models.Goal
+
+	// overBudgetAlerts adalah budget yang sudah terlewati, ditampilkan
+	// sebagai banner di tab Overview.
+	overBudgetAlerts []service.OverBudgetAlert
+
+	// Top expenses & kategori bulan ini. Di-load terpisah dari loadData,
+	// jadi error di salah satunya tidak menggagalkan seluruh dashboard.
+	topExpenses      []*models.Transaction
+	topExpensesErr   error
+	topCategories    []*repository.CategorySummary
+	topCategoriesErr error
+
+	// Heatmap pengeluaran hari × jam (tab Insights)
+	heatmap    *service.SpendingHeatmap
+	heatmapErr error
+
+	// recentErrors adalah error non-kritis terakhir (budgets, goals gagal
+	// di-load) dan alert saldo rendah yang ditampilkan di warning strip
+	// sampai di-dismiss (x). Yang terbaru di akhir.
+	recentErrors []string
+
+	// private menyembunyikan semua nominal (tombol p, atau --private).
+	// stateDir adalah folder tempat State disimpan; kosong berarti
+	// toggle tidak disimpan.
+	private  bool
+	stateDir string
+
+	// Help overlay
+	help helpModel
+
+	// confirm adalah dialog konfirmasi yang sedang terbuka (nil jika
+	// tidak ada); onConfirm dijalankan jika user menekan ConfirmKey.
+	confirm   *confirmDialog
+	onConfirm tea.Cmd
+
+	// txForm adalah form tambah transaksi yang sedang terbuka (tombol a
+	// di tab Transactions), nil jika tidak ada.
+	txForm *txForm
+
+	// Status bar di bagian bawah layar
+	statusBar components.StatusBar
 
 	// Loading state
 	loading bool
 	err     error
 }
 
-// NewDashboard membuat dashboard model baru.
-func NewDashboard(application *app.App) *DashboardModel {
-	return &DashboardModel{
+// NewDashboard membuat dashboard model baru. Query yang sedang berjalan
+// dibatalkan saat ctx selesai.
+//
+// State (mis. privacy mode) dibaca dari opts.StateDir; opts.Private
+// memaksa dashboard mulai dalam privacy mode.
+func NewDashboard(ctx context.Context, application *app.App, opts Options) *DashboardModel {
+	m := &DashboardModel{
+		ctx:       ctx,
 		app:       application,
 		activeTab: TabOverview,
 		width:     80,
 		height:    24,
 		loading:   true,
+		statusBar: components.StatusBar{Width: 80},
+		private:   opts.Private,
+		stateDir:  opts.StateDir,
 	}
+
+	if opts.StateDir != "" {
+		state, err := LoadState(opts.StateDir)
+		if err != nil {
+			m.addRecentErrors([]string{err.Error()})
+		}
+		m.private = m.private || state.Private
+	}
+	return m
+}
+
+// Options adalah pengaturan awal NewDashboard.
+type Options struct {
+	// StateDir adalah folder file state dashboard (biasanya folder
+	// config). Kosong berarti state tidak dibaca maupun disimpan.
+	StateDir string
+
+	// Private memulai dashboard dengan semua nominal disembunyikan.
+	Private bool
 }
 
 // Init adalah Bubble Tea lifecycle method.
 func (m *DashboardModel) Init() tea.Cmd {
 	return tea.Batch(
-		m.loadData,
+		m.refresh(),
 		tea.SetWindowTitle("💰 Wallet Twin Dashboard"),
 	)
 }
 
+// refresh me-load ulang semua data dashboard secara concurrent.
+// Load sebelumnya yang belum selesai dibatalkan.
+func (m *DashboardModel) refresh() tea.Cmd {
+	m.stopLoading()
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.cancelLoad = cancel
+
+	// Disalin di sini (goroutine Update); load jalan di goroutine tea.Cmd
+	// dan tidak boleh membaca model yang sedang diubah Update
+	params := m.loadParams()
+
+	return tea.Batch(
+		func() tea.Msg { return m.loadData(ctx, params) },
+		func() tea.Msg { return m.loadTopExpenses(ctx, params) },
+		func() tea.Msg { return m.loadTopCategories(ctx, params) },
+		func() tea.Msg { return m.loadHeatmap(ctx) },
+	)
+}
+
+// loadParams adalah salinan state model yang dipakai load.
+type loadParams struct {
+	walletSort repository.WalletSortOrder
+	txFilter   repository.TransactionFilter
+	year       int
+	month      time.Month
+}
+
+// loadParams menyalin sort, filter, dan periode berjalan untuk load.
+// Harus dipanggil dari goroutine Update.
+func (m *DashboardModel) loadParams() loadParams {
+	year, month := m.currentPeriod()
+	return loadParams{
+		walletSort: m.walletSort,
+		txFilter:   m.txFilter.transactionFilter(),
+		year:       year,
+		month:      month,
+	}
+}
+
+// stopLoading membatalkan load yang sedang berjalan.
+func (m *DashboardModel) stopLoading() {
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+	}
+}
+
+// quit membatalkan load yang sedang berjalan lalu keluar.
+func (m *DashboardModel) quit() (tea.Model, tea.Cmd) {
+	m.stopLoading()
+	return m, tea.Quit
+}
+
 // Message types
 type dataLoadedMsg struct {
+	refs           *service.RefData
 	wallets        []*models.Wallet
 	totalBalance   decimal.Decimal
 	recentTxs      []*models.Transaction
 	summary        *repository.TransactionSummary
 	budgetStatuses []*repository.BudgetStatus
 	goals          []*models.Goal
+	overBudget     []service.OverBudgetAlert
+
+	// warnings adalah data yang gagal di-load tanpa menggagalkan dashboard
+	warnings []string
 }
 
 type errMsg struct{ err error }
 
-// loadData mengambil semua data yang diperlukan.
-func (m *DashboardModel) loadData() tea.Msg {
-	ctx := context.Background()
+// walletDeletedMsg dikirim setelah wallet di-delete dari tab Wallets.
+type walletDeletedMsg struct {
+	name string
+	err  error
+}
+
+// anomalyCheckedMsg dikirim setelah input form tambah transaksi dicek
+// terhadap threshold transaksi besar.
+type anomalyCheckedMsg struct {
+	input   service.CreateTransactionInput
+	label   string
+	warning *service.AnomalyWarning
+	err     error
+}
+
+// transactionAddedMsg dikirim setelah transaksi dari form dibuat.
+type transactionAddedMsg struct {
+	tx  *models.Transaction
+	err error
+}
+
+// noteSavedMsg dikirim setelah note selesai diedit di $EDITOR.
+type noteSavedMsg struct {
+	tx  *models.Transaction
+	err error
+}
+
+type topExpensesLoadedMsg struct {
+	transactions []*models.Transaction
+	err          error
+}
+
+type heatmapLoadedMsg struct {
+	heatmap *service.SpendingHeatmap
+	err     error
+}
+
+type topCategoriesLoadedMsg struct {
+	categories []*repository.CategorySummary
+	err        error
+}
 
-	txManager := postgres.NewTransactionManager(m.app.DB.Pool)
+// loadFailed mengubah error load menjadi errMsg. Load yang dibatalkan
+// (refresh baru atau dashboard ditutup) dibuang.
+func loadFailed(ctx context.Context, err error) tea.Msg {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return errMsg{err}
+}
+
+// loadData mengambil semua data yang diperlukan.
+//
+// Query-query tidak saling bergantung, jadi dijalankan bersamaan dalam
+// satu errgroup: waktu load kira-kira sama dengan query paling lambat,
+// bukan jumlah semuanya (terasa di koneksi yang lambat). Query critical
+// yang gagal membatalkan query lain lewat context group. Budgets, goals,
+// dan alert saldo rendah non-critical: error-nya dicatat sebagai warning
+// dan tidak membatalkan group.
+//
+// State model (sort, filter, periode) datang dari params, bukan dibaca
+// dari m, karena loadData jalan di goroutine tea.Cmd.
+func (m *DashboardModel) loadData(ctx context.Context, params loadParams) tea.Msg {
+	txManager := m.app.TxManager
 
 	// Services
-	walletSvc := service.NewWalletService(m.app.Repos.Wallet)
-	txSvc := service.NewTransactionService(m.app.Repos.Transaction, m.app.Repos.Wallet, txManager)
+	walletSvc := service.NewWalletService(m.app.Repos.Wallet, m.app.Repos.Transfer, txManager)
+	txSvc := m.newTransactionService()
 	budgetSvc := service.NewBudgetService(m.app.Repos.Budget, m.app.Repos.Transaction)
-	goalSvc := service.NewGoalService(m.app.Repos.Goal)
+	budgetSvc.SetMonthStartDay(m.app.Config.App.MonthStartDay)
+	goalSvc := service.NewGoalService(m.app.Repos.Goal, m.app.Repos.Wallet, txManager)
 
-	// Get wallets
-	wallets, err := walletSvc.ListActive(ctx)
-	if err != nil {
-		return errMsg{err}
-	}
+	walletSort := params.walletSort
+	txFilter := params.txFilter
+	year, month := params.year, params.month
 
-	// Get total balance
-	totalBalance, err := walletSvc.GetTotalBalance(ctx)
-	if err != nil {
-		return errMsg{err}
-	}
+	var (
+		refs           *service.RefData
+		wallets        []*models.Wallet
+		totalBalance   decimal.Decimal
+		recentTxs      []*models.Transaction
+		summary        *repository.TransactionSummary
+		budgetStatuses []*repository.BudgetStatus
+		goals          []*models.Goal
+		overBudget     []service.OverBudgetAlert
+		low            []*models.Wallet
 
-	// Get recent transactions
-	recentTxs, err := txSvc.GetRecent(ctx, 5)
-	if err != nil {
-		return errMsg{err}
-	}
+		budgetErr, goalErr, overErr, lowErr error
+	)
 
-	// Get monthly summary
-	now := time.Now()
-	summary, err := txSvc.GetMonthlySummary(ctx, now.Year(), now.Month())
-	if err != nil {
-		return errMsg{err}
+	g, gctx := errgroup.WithContext(ctx)
+
+	// Wallets & categories (satu query masing-masing), dipakai bersama
+	// oleh semua card
+	g.Go(func() (err error) {
+		refs, err = service.LoadRefData(gctx, m.app.Repos.Wallet, m.app.Repos.Category)
+		return err
+	})
+
+	// Wallet aktif untuk tab Wallets, sesuai urutan pilihan user
+	g.Go(func() (err error) {
+		active := true
+		wallets, err = m.app.Repos.Wallet.List(gctx, repository.WalletFilter{IsActive: &active, SortBy: walletSort})
+		return err
+	})
+
+	g.Go(func() (err error) {
+		totalBalance, err = walletSvc.GetTotalBalance(gctx)
+		return err
+	})
+
+	// Recent transactions, dibatasi filter tab Transactions
+	g.Go(func() (err error) {
+		recentTxs, err = txSvc.List(gctx, txFilter, repository.ListParams{Limit: recentTxLimit})
+		if err == nil {
+			sortTransactions(recentTxs, walletSort)
+		}
+		return err
+	})
+
+	g.Go(func() (err error) {
+		summary, err = txSvc.GetMonthlySummary(gctx, year, month)
+		return err
+	})
+
+	// Non-critical: selalu return nil supaya tidak membatalkan group
+	g.Go(func() error {
+		budgetStatuses, budgetErr = budgetSvc.GetAllStatus(gctx)
+		return nil
+	})
+	g.Go(func() error {
+		overBudget, overErr = budgetSvc.GetOverBudgetAlerts(gctx)
+		return nil
+	})
+	g.Go(func() error {
+		goals, goalErr = goalSvc.ListActive(gctx)
+		return nil
+	})
+	g.Go(func() error {
+		low, lowErr = walletSvc.GetLowBalanceWallets(gctx)
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return loadFailed(ctx, err)
 	}
 
-	// Get budget statuses
-	budgetStatuses, err := budgetSvc.GetAllStatus(ctx)
-	if err != nil {
-		// Non-critical, continue
+	// Warning disusun setelah Wait supaya urutannya tetap sama
+	var warnings []string
+	if budgetErr != nil {
 		budgetStatuses = nil
+		warnings = append(warnings, "Budgets failed to load: "+budgetErr.Error())
 	}
-
-	// Get goals
-	goals, err := goalSvc.ListActive(ctx)
-	if err != nil {
-		// Non-critical, continue
+	// Query yang sama dengan budgets; error-nya cukup dilaporkan sekali
+	if overErr != nil {
+		overBudget = nil
+		if budgetErr == nil {
+			warnings = append(warnings, "Over-budget alerts failed to load: "+overErr.Error())
+		}
+	}
+	if goalErr != nil {
 		goals = nil
+		warnings = append(warnings, "Goals failed to load: "+goalErr.Error())
+	}
+
+	// Alert saldo rendah. Nominal tidak ditulis supaya pesan sama di
+	// setiap refresh (lihat addRecentErrors) dan tidak bocor di privacy
+	// mode.
+	if lowErr != nil {
+		warnings = append(warnings, "Low balance alerts failed to load: "+lowErr.Error())
+	}
+	for _, w := range low {
+		warnings = append(warnings, "Low balance: "+w.Name+" is below its alert threshold")
 	}
 
 	return dataLoadedMsg{
+		refs:           refs,
 		wallets:        wallets,
 		totalBalance:   totalBalance,
 		recentTxs:      recentTxs,
 		summary:        summary,
 		budgetStatuses: budgetStatuses,
 		goals:          goals,
+		overBudget:     overBudget,
+		warnings:       warnings,
+	}
+}
+
+// loadTopExpenses mengambil expense terbesar bulan ini.
+func (m *DashboardModel) loadTopExpenses(ctx context.Context, params loadParams) tea.Msg {
+	txSvc := m.newTransactionService()
+
+	transactions, err := txSvc.GetTopExpenses(ctx, params.year, params.month, topExpensesLimit)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return topExpensesLoadedMsg{transactions: transactions, err: err}
+}
+
+// loadTopCategories mengambil kategori expense terbesar bulan ini.
+func (m *DashboardModel) loadTopCategories(ctx context.Context, params loadParams) tea.Msg {
+	txSvc := m.newTransactionService()
+
+	categories, err := txSvc.GetTopExpenseCategories(ctx, params.year, params.month, topCategoriesLimit)
+	if ctx.Err() != nil {
+		return nil
 	}
+	return topCategoriesLoadedMsg{categories: categories, err: err}
+}
+
+// loadHeatmap mengambil pola pengeluaran heatmapDays hari terakhir.
+func (m *DashboardModel) loadHeatmap(ctx context.Context) tea.Msg {
+	txSvc := m.newTransactionService()
+
+	since := time.Now().AddDate(0, 0, -heatmapDays)
+	heatmap, err := txSvc.GetHeatmap(ctx, repository.TransactionFilter{StartDate: &since})
+	if ctx.Err() != nil {
+		return nil
+	}
+	return heatmapLoadedMsg{heatmap: heatmap, err: err}
+}
+
+// newTransactionService membuat TransactionService dengan periode bulanan
+// sesuai app.month_start_day.
+func (m *DashboardModel) newTransactionService() *service.TransactionService {
+	txSvc := service.NewTransactionService(m.app.Repos.Transaction, m.app.Repos.Wallet, m.app.Repos.Category, m.app.TxManager)
+	txSvc.SetMonthStartDay(m.app.Config.App.MonthStartDay)
+	return txSvc
+}
+
+// currentPeriod mengembalikan bulan periode yang sedang berjalan.
+func (m *DashboardModel) currentPeriod() (int, time.Month) {
+	return utils.PeriodContaining(m.app.Config.App.MonthStartDay, time.Now())
+}
+
+// monthCardTitle adalah judul card "This Month". Jika bulan tidak mulai
+// tanggal 1, nama periode dan rentang tanggalnya ditampilkan supaya tidak
+// disangka bulan kalender.
+func (m *DashboardModel) monthCardTitle() string {
+	anchor := m.app.Config.App.MonthStartDay
+	if anchor <= 1 {
+		return "📊 This Month"
+	}
+	year, month := m.currentPeriod()
+	return "📊 " + utils.PeriodLabel(anchor, year, month)
 }
 
 // Update handles messages (Elm Architecture).
 func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Dialog konfirmasi menangkap tombol berikutnya: hanya ConfirmKey
+		// yang menjalankan aksi, tombol lain menutup dialog
+		if m.confirm != nil {
+			confirmed, action := m.confirm.Confirmed(msg.String()), m.onConfirm
+			m.confirm, m.onConfirm = nil, nil
+			if confirmed {
+				return m, action
+			}
+			return m, nil
+		}
+
+		// Form tambah transaksi menerima semua tombol sampai disubmit
+		// atau ditutup
+		if m.txForm != nil {
+			if msg.String() == "ctrl+c" {
+				return m.quit()
+			}
+			submit, cancel := m.txForm.HandleKey(msg.String())
+			switch {
+			case cancel:
+				m.txForm = nil
+			case submit:
+				return m, m.submitTxForm()
+			}
+			return m, nil
+		}
+
+		// Selama help terbuka, hanya tombol tutup dan quit yang diproses
+		if m.help.Visible {
+			switch msg.String() {
+			case "q", "ctrl+c":
+				return m.quit()
+			case "?", "esc":
+				m.help.Toggle(m.activeTab)
+			}
+			return m, nil
+		}
+
+		// Detail view: tab lain tidak bisa dipilih sampai ditutup
+		if m.detail != nil {
+			switch msg.String() {
+			case "q", "ctrl+c":
+				return m.quit()
+			case "esc", "enter":
+				m.detail = nil
+			case "e":
+				return m, m.editNote(m.detail)
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "q", "ctrl+c":
-			return m, tea.Quit
+			return m.quit()
+		case "?":
+			m.help.Toggle(m.activeTab)
 		case "left", "h":
 			if m.activeTab > TabOverview {
 				m.activeTab--
 			}
 		case "right", "l":
-			if m.activeTab < TabGoals {
+			if m.activeTab < TabInsights {
 				m.activeTab++
 			}
+		case "tab":
+			m.activeTab = (m.activeTab + 1) % tabCount
+		case "shift+tab":
+			m.activeTab = (m.activeTab + tabCount - 1) % tabCount
 		case "r":
 			m.loading = true
-			return m, m.loadData
+			return m, m.refresh()
+		case "x":
+			// Di tab Transactions, x menghapus filter dulu; warning
+			// di-dismiss dengan x berikutnya
+			if m.activeTab == TabTransactions && !m.txFilter.isEmpty() {
+				return m, m.setTxFilter(txFilter{})
+			}
+			m.recentErrors = nil
+		case "p":
+			m.togglePrivate()
+		case "s":
+			if m.activeTab == TabWallets || m.activeTab == TabTransactions {
+				m.walletSort = nextWalletSort(m.walletSort)
+				m.loading = true
+				return m, m.refresh()
+			}
+		case "up", "k":
+			if m.activeTab == TabTransactions && m.txCursor > 0 {
+				m.txCursor--
+			}
+			if m.activeTab == TabWallets && m.walletCursor > 0 {
+				m.walletCursor--
+			}
+			if m.activeTab == TabBudgets && m.budgetCursor > 0 {
+				m.budgetCursor--
+			}
+		case "down", "j":
+			if m.activeTab == TabTransactions && m.txCursor < len(m.recentTxs)-1 {
+				m.txCursor++
+			}
+			if m.activeTab == TabWallets && m.walletCursor < len(m.wallets)-1 {
+				m.walletCursor++
+			}
+			if m.activeTab == TabBudgets && m.budgetCursor < len(m.budgetStatuses)-1 {
+				m.budgetCursor++
+			}
+		case "a":
+			if m.activeTab == TabTransactions {
+				m.txForm = newTxForm(m.wallets, m.refs, m.txFilter)
+			}
+		case "d":
+			if m.activeTab == TabWallets && m.walletCursor < len(m.wallets) {
+				m.confirmDeleteWallet(m.wallets[m.walletCursor])
+			}
+		case "enter":
+			switch {
+			case m.activeTab == TabTransactions && m.txCursor < len(m.recentTxs):
+				m.detail = m.recentTxs[m.txCursor]
+			case m.activeTab == TabWallets && m.walletCursor < len(m.wallets):
+				m.activeTab = TabTransactions
+				return m, m.setTxFilter(walletTxFilter(m.wallets[m.walletCursor]))
+			case m.activeTab == TabBudgets && m.budgetCursor < len(m.budgetStatuses):
+				m.activeTab = TabTransactions
+				return m, m.setTxFilter(budgetTxFilter(m.budgetStatuses[m.budgetCursor]))
+			}
 		case "1":
 			m.activeTab = TabOverview
 		case "2":
@@ -171,29 +704,209 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.activeTab = TabBudgets
 		case "5":
 			m.activeTab = TabGoals
+		case "6":
+			m.activeTab = TabInsights
 		}
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.statusBar.Width = msg.Width
 
 	case dataLoadedMsg:
 		m.loading = false
+		m.refs = msg.refs
 		m.wallets = msg.wallets
+		m.walletCursor = min(m.walletCursor, max(len(msg.wallets)-1, 0))
 		m.totalBalance = msg.totalBalance
 		m.recentTxs = msg.recentTxs
+		m.txCursor = min(m.txCursor, max(len(msg.recentTxs)-1, 0))
 		m.monthlySummary = msg.summary
 		m.budgetStatuses = msg.budgetStatuses
+		m.budgetCursor = min(m.budgetCursor, max(len(msg.budgetStatuses)-1, 0))
 		m.goals = msg.goals
+		m.overBudgetAlerts = msg.overBudget
+		m.err = nil
+		m.addRecentErrors(msg.warnings)
+
+		m.statusBar.DBConnected = true
+		m.statusBar.WalletCount = len(msg.wallets)
+		m.statusBar.LastRefresh = time.Now()
 
 	case errMsg:
 		m.loading = false
 		m.err = msg.err
+		m.statusBar.DBConnected = false
+
+	case topExpensesLoadedMsg:
+		m.topExpenses = msg.transactions
+		m.topExpensesErr = msg.err
+
+	case topCategoriesLoadedMsg:
+		m.topCategories = msg.categories
+		m.topCategoriesErr = msg.err
+
+	case heatmapLoadedMsg:
+		m.heatmap = msg.heatmap
+		m.heatmapErr = msg.err
+
+	case walletDeletedMsg:
+		if msg.err != nil {
+			m.addRecentErrors([]string{"Wallet " + msg.name + " not deleted: " + msg.err.Error()})
+			break
+		}
+		m.loading = true
+		return m, m.refresh()
+
+	case anomalyCheckedMsg:
+		if m.txForm == nil {
+			break
+		}
+		if msg.err != nil {
+			m.txForm.err = msg.err
+			break
+		}
+		create := m.createTransaction(msg.input)
+		if msg.warning == nil {
+			return m, create
+		}
+		// Form tetap terbuka: jika dibatalkan, input tidak hilang
+		m.confirm = newConfirmDialog(msg.warning.Message(msg.label, msg.input.Type) + ". Continue?")
+		m.onConfirm = create
+
+	case transactionAddedMsg:
+		if msg.err != nil {
+			if m.txForm != nil {
+				m.txForm.err = msg.err
+			}
+			break
+		}
+		m.txForm = nil
+		m.loading = true
+		return m, m.refresh()
+
+	case noteSavedMsg:
+		if msg.err != nil {
+			m.addRecentErrors([]string{"Note not saved: " + msg.err.Error()})
+			break
+		}
+		m.detail = msg.tx
+		for i, tx := range m.recentTxs {
+			if tx.ID == msg.tx.ID {
+				m.recentTxs[i] = msg.tx
+			}
+		}
 	}
 
 	return m, nil
 }
 
+// setTxFilter mengganti filter tab Transactions lalu me-load ulang
+// daftarnya dari transaksi pertama.
+func (m *DashboardModel) setTxFilter(f txFilter) tea.Cmd {
+	m.txFilter = f
+	m.txCursor = 0
+	m.loading = true
+	return m.refresh()
+}
+
+// editNote membuka note tx di $EDITOR (TUI berhenti sementara) lalu
+// menyimpan hasilnya. Editor yang gagal atau keluar dengan error tidak
+// mengubah note.
+func (m *DashboardModel) editNote(tx *models.Transaction) tea.Cmd {
+	editor, err := components.NewNoteEditor(tx.Note)
+	if err != nil {
+		m.addRecentErrors([]string{"Note not saved: " + err.Error()})
+		return nil
+	}
+
+	id := tx.ID
+	return tea.ExecProcess(editor.Cmd(), func(err error) tea.Msg {
+		note, err := editor.Result(err)
+		if err != nil {
+			return noteSavedMsg{err: err}
+		}
+		saved, err := m.newTransactionService().UpdateNote(m.ctx, id, note)
+		return noteSavedMsg{tx: saved, err: err}
+	})
+}
+
+// togglePrivate menyalakan/mematikan privacy mode tanpa load ulang data,
+// lalu menyimpannya ke file state.
+func (m *DashboardModel) togglePrivate() {
+	m.private = !m.private
+	if m.stateDir == "" {
+		return
+	}
+	if err := SaveState(m.stateDir, State{Private: m.private}); err != nil {
+		m.addRecentErrors([]string{err.Error()})
+	}
+}
+
+// submitTxForm memvalidasi form lalu mengecek transaksi besar dengan
+// threshold yang sama seperti `tx add`. Hasilnya dikirim sebagai
+// anomalyCheckedMsg.
+func (m *DashboardModel) submitTxForm() tea.Cmd {
+	input, err := m.txForm.Input()
+	if err != nil {
+		m.txForm.err = err
+		return nil
+	}
+	m.txForm.err = nil
+	label := m.txForm.CategoryLabel()
+	validation := m.app.Config.Validation
+
+	return func() tea.Msg {
+		absolute, err := validation.LargeAbsoluteAmount()
+		if err != nil {
+			return anomalyCheckedMsg{err: err}
+		}
+		warning, err := m.newTransactionService().CheckAnomaly(m.ctx, input, service.AnomalyThresholds{
+			Multiplier: validation.LargeMultiplier,
+			Absolute:   absolute,
+		})
+		return anomalyCheckedMsg{input: input, label: label, warning: warning, err: err}
+	}
+}
+
+// createTransaction membuat transaksi dari input form.
+func (m *DashboardModel) createTransaction(input service.CreateTransactionInput) tea.Cmd {
+	return func() tea.Msg {
+		tx, err := m.newTransactionService().Create(m.ctx, input)
+		return transactionAddedMsg{tx: tx, err: err}
+	}
+}
+
+// confirmDeleteWallet membuka dialog konfirmasi; wallet baru di-delete
+// (soft delete, sama seperti `wallet wallet delete`) jika user menekan y.
+func (m *DashboardModel) confirmDeleteWallet(w *models.Wallet) {
+	m.confirm = newConfirmDialog(fmt.Sprintf("Delete wallet %s?", w.Name))
+
+	id, name := w.ID, w.Name
+	m.onConfirm = func() tea.Msg {
+		walletSvc := service.NewWalletService(m.app.Repos.Wallet, m.app.Repos.Transfer, m.app.TxManager)
+		return walletDeletedMsg{name: name, err: walletSvc.Delete(m.ctx, id)}
+	}
+}
+
+// addRecentErrors menambahkan warnings ke recentErrors. Error yang sama
+// (mis. tabel yang sama gagal di setiap refresh) hanya disimpan sekali,
+// dan hanya maxRecentErrors terakhir yang disimpan.
+func (m *DashboardModel) addRecentErrors(warnings []string) {
+	for _, warning := range warnings {
+		for i, existing := range m.recentErrors {
+			if existing == warning {
+				m.recentErrors = append(m.recentErrors[:i], m.recentErrors[i+1:]...)
+				break
+			}
+		}
+		m.recentErrors = append(m.recentErrors, warning)
+	}
+	if n := len(m.recentErrors); n > maxRecentErrors {
+		m.recentErrors = m.recentErrors[n-maxRecentErrors:]
+	}
+}
+
 // View renders the UI (Elm Architecture).
 func (m *DashboardModel) View() string {
 	if m.loading {
@@ -205,18 +918,13 @@ func (m *DashboardModel) View() string {
 	}
 
 	// Build layout
-	header := m.renderHeader()
-	tabs := m.renderTabs()
-	content := m.renderContent()
-	help := m.renderHelp()
-
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		header,
-		tabs,
-		content,
-		help,
-	)
+	sections := []string{m.renderHeader(), m.renderTabs()}
+	if warnings := m.renderWarnings(); warnings != "" {
+		sections = append(sections, warnings)
+	}
+	sections = append(sections, m.renderContent(), m.renderHelp(), m.statusBar.Render())
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
 func (m *DashboardModel) renderLoading() string {
@@ -235,13 +943,49 @@ func (m *DashboardModel) renderError() string {
 	)
 }
 
+// renderWarnings menampilkan error non-kritis terbaru di bawah tabs,
+// atau "" jika tidak ada (atau sudah di-dismiss).
+func (m *DashboardModel) renderWarnings() string {
+	n := len(m.recentErrors)
+	if n == 0 {
+		return ""
+	}
+
+	suffix := " · x dismiss"
+	if n > 1 {
+		suffix = fmt.Sprintf(" (+%d more)", n-1) + suffix
+	}
+
+	// Pesan dipotong supaya strip tetap satu baris
+	prefix := "⚠️  "
+	room := m.width - warningStripStyle.GetHorizontalFrameSize() - lipgloss.Width(prefix+suffix)
+	return warningStripStyle.Render(prefix + truncate(m.recentErrors[n-1], room) + suffix)
+}
+
+// warningsHeight adalah tinggi warning strip (0 jika tidak tampil).
+func (m *DashboardModel) warningsHeight() int {
+	if len(m.recentErrors) == 0 {
+		return 0
+	}
+	return lipgloss.Height(m.renderWarnings())
+}
+
 func (m *DashboardModel) renderHeader() string {
-	title := "💰 Wallet Twin Dashboard"
-	return headerStyle.Render(title)
+	title := "💰 Wallet Twin Dashboard " + version.String()
+	parts := []string{headerStyle.Render(title)}
+	if m.app.Demo {
+		// Demo mode harus jelas terlihat supaya data sample
+		// tidak dikira data asli
+		parts = append(parts, demoBadgeStyle.Render("DEMO MODE"))
+	}
+	if m.private {
+		parts = append(parts, demoBadgeStyle.Render("PRIVATE"))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
 }
 
 func (m *DashboardModel) renderTabs() string {
-	tabs := []Tab{TabOverview, TabWallets, TabTransactions, TabBudgets, TabGoals}
+	tabs := []Tab{TabOverview, TabWallets, TabTransactions, TabBudgets, TabGoals, TabInsights}
 	var renderedTabs []string
 
 	for _, tab := range tabs {
@@ -249,24 +993,65 @@ func (m *DashboardModel) renderTabs() string {
 		if tab == m.activeTab {
 			style = activeTabStyle
 		}
-		renderedTabs = append(renderedTabs, style.Render(tab.String()))
+		renderedTabs = append(renderedTabs, style.Render(m.tabTitle(tab)))
+	}
+
+	// Filter aktif tampil sebagai chip di kanan tab
+	for _, chip := range m.txFilter.chips(m.formatDate) {
+		renderedTabs = append(renderedTabs, " ", filterChipStyle.Render(chip+" ✕"))
 	}
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, renderedTabs...)
 }
 
+// tabTitle adalah label tab. Tab Wallets dan Transactions yang sedang
+// aktif menampilkan urutannya, contoh "💼 Wallets (by balance ↓)".
+func (m *DashboardModel) tabTitle(tab Tab) string {
+	if tab == m.activeTab && (tab == TabWallets || tab == TabTransactions) {
+		return fmt.Sprintf("%s (%s)", tab, sortLabel(tab, m.walletSort))
+	}
+	return tab.String()
+}
+
 func (m *DashboardModel) renderContent() string {
+	if m.help.Visible || m.confirm != nil || m.txForm != nil {
+		overlay := m.help.View()
+		switch {
+		case m.confirm != nil:
+			overlay = m.confirm.View()
+		case m.txForm != nil:
+			overlay = m.txForm.View()
+		}
+
+		// Header, tabs, dan help bar masing-masing 1-2 baris,
+		// ditambah status bar di paling bawah
+		height := m.height - 4 - components.StatusBarHeight - m.warningsHeight()
+		if height < 0 {
+			height = 0
+		}
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			overlay,
+		)
+	}
+
 	switch m.activeTab {
 	case TabOverview:
 		return m.renderOverview()
 	case TabWallets:
 		return m.renderWallets()
 	case TabTransactions:
+		if m.detail != nil {
+			return m.renderTransactionDetail()
+		}
 		return m.renderTransactions()
 	case TabBudgets:
 		return m.renderBudgets()
 	case TabGoals:
 		return m.renderGoals()
+	case TabInsights:
+		return m.renderInsights()
 	default:
 		return ""
 	}
@@ -276,7 +1061,7 @@ func (m *DashboardModel) renderOverview() string {
 	// Total Balance Card
 	balanceCard := cardStyle.Render(
 		cardTitleStyle.Render("💰 Total Balance") + "\n\n" +
-			moneyStyle.Render(formatMoney(m.totalBalance)),
+			moneyStyle.Render(m.formatMoney(m.totalBalance)),
 	)
 
 	// Monthly Summary Card
@@ -284,16 +1069,16 @@ func (m *DashboardModel) renderOverview() string {
 	if m.monthlySummary != nil {
 		summaryContent = fmt.Sprintf(
 			"%s\n%s\n%s",
-			incomeStyle.Render("📈 Income:  "+formatMoney(m.monthlySummary.TotalIncome)),
-			expenseStyle.Render("📉 Expense: "+formatMoney(m.monthlySummary.TotalExpense)),
-			moneyStyle.Render("💵 Net:     "+formatMoney(m.monthlySummary.Net)),
+			incomeStyle.Render("📈 Income:  "+m.formatMoney(m.monthlySummary.TotalIncome)),
+			expenseStyle.Render("📉 Expense: "+m.formatMoney(m.monthlySummary.TotalExpense)),
+			moneyStyle.Render("💵 Net:     "+m.formatMoney(m.monthlySummary.Net)),
 		)
 	} else {
 		summaryContent = "No data"
 	}
 
 	summaryCard := cardStyle.Render(
-		cardTitleStyle.Render("📊 This Month") + "\n\n" + summaryContent,
+		cardTitleStyle.Render(m.monthCardTitle()) + "\n\n" + summaryContent,
 	)
 
 	// Goals Preview
@@ -303,9 +1088,13 @@ func (m *DashboardModel) renderOverview() string {
 			if i >= 3 { // Show max 3
 				break
 			}
-			progress := g.GetProgress()
-			bar := renderProgressBar(progress, 20)
-			goalsContent += fmt.Sprintf("%s %s %.0f%%\n", g.Icon, g.Name, progress)
+			pct := g.GetProgress()
+			bar := progress.Bar{Width: 20, Theme: progressTheme}.Render(pct)
+			name := g.Name
+			if g.IsNetWorth() {
+				name += hintStyle.Render(" · net worth")
+			}
+			goalsContent += fmt.Sprintf("%s %s %s\n", models.DisplayIcon(g.Icon), name, progress.FormatPercent(pct))
 			goalsContent += bar + "\n\n"
 		}
 	} else {
@@ -316,7 +1105,114 @@ func (m *DashboardModel) renderOverview() string {
 		cardTitleStyle.Render("🎯 Goals Progress") + "\n\n" + goalsContent,
 	)
 
-	return lipgloss.JoinVertical(lipgloss.Left, balanceCard, summaryCard, goalsCard)
+	// Banner over budget selalu di paling atas
+	banner := m.renderOverBudgetBanner()
+	layout := func(cards ...string) string {
+		if banner != "" {
+			cards = append([]string{banner}, cards...)
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, cards...)
+	}
+
+	overview := layout(balanceCard, summaryCard, m.renderTopExpenses(), m.renderTopCategories(), goalsCard)
+
+	// Terminal pendek: top expenses & categories diringkas jadi satu baris
+	if lipgloss.Height(overview) > m.height-overviewChromeHeight-m.warningsHeight()-lipgloss.Height(m.renderHelp()) {
+		overview = layout(balanceCard, summaryCard, m.renderTopCompact(), goalsCard)
+	}
+
+	return overview
+}
+
+// renderOverBudgetBanner me-render banner budget yang sudah terlewati,
+// satu baris per budget. Kosong jika tidak ada yang over budget.
+func (m *DashboardModel) renderOverBudgetBanner() string {
+	if len(m.overBudgetAlerts) == 0 {
+		return ""
+	}
+
+	lines := []string{cardTitleStyle.Render("⚠️ Over budget")}
+	for _, a := range m.overBudgetAlerts {
+		lines = append(lines, fmt.Sprintf("%s %s  %s / %s  (+%s, %s)",
+			models.DisplayIcon(a.CategoryIcon),
+			a.CategoryName,
+			m.formatMoney(a.Spent),
+			m.formatMoney(a.Budget),
+			m.formatMoney(a.OverBy),
+			progress.FormatPercent(a.OverByPercent),
+		))
+	}
+	return overBudgetBannerStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderTopExpenses me-render card expense terbesar bulan ini.
+func (m *DashboardModel) renderTopExpenses() string {
+	var content string
+	switch {
+	case m.topExpensesErr != nil:
+		content = expenseStyle.Render("⚠️ Failed to load top expenses")
+	case len(m.topExpenses) == 0:
+		content = "No expenses this month"
+	default:
+		for i, tx := range m.topExpenses {
+			content += fmt.Sprintf("%d. %s  %s · %s\n",
+				i+1,
+				expenseStyle.Render(m.formatMoneyIn(tx.Amount, m.walletCurrency(tx.WalletID))),
+				truncate(tx.Description, 20),
+				m.formatDate(tx.TransactionDate),
+			)
+		}
+	}
+
+	return cardStyle.Render(
+		cardTitleStyle.Render("💸 Top Expenses") + "\n\n" + content + "\n" +
+			hintStyle.Render("press 3 for all transactions"),
+	)
+}
+
+// renderTopCategories me-render card kategori expense terbesar bulan ini
+// beserta porsinya dari total expense.
+func (m *DashboardModel) renderTopCategories() string {
+	var content string
+	switch {
+	case m.topCategoriesErr != nil:
+		content = expenseStyle.Render("⚠️ Failed to load top categories")
+	case len(m.topCategories) == 0:
+		content = "No categorized expenses this month"
+	default:
+		for _, c := range m.topCategories {
+			content += fmt.Sprintf("%s %4s  %s\n",
+				utils.PadRight(truncate(c.CategoryName, 16), 16),
+				progress.FormatPercent(c.Percentage),
+				m.formatMoney(c.Total),
+			)
+		}
+	}
+
+	return cardStyle.Render(
+		cardTitleStyle.Render("🏷️ Top Categories") + "\n\n" + content + "\n" +
+			hintStyle.Render("press 4 for budgets"),
+	)
+}
+
+// renderTopCompact meringkas top expense dan top category dalam satu card
+// tanpa padding, untuk terminal yang terlalu pendek.
+func (m *DashboardModel) renderTopCompact() string {
+	expense := "no expenses"
+	if len(m.topExpenses) > 0 {
+		tx := m.topExpenses[0]
+		expense = m.formatMoneyIn(tx.Amount, m.walletCurrency(tx.WalletID)) + " " + truncate(tx.Description, 16)
+	}
+
+	category := "-"
+	if len(m.topCategories) > 0 {
+		c := m.topCategories[0]
+		category = c.CategoryName + " " + progress.FormatPercent(c.Percentage)
+	}
+
+	return compactCardStyle.Render(
+		fmt.Sprintf("💸 Top: %s · 🏷️ %s\n%s", expense, category, hintStyle.Render("press 3 for all transactions")),
+	)
 }
 
 func (m *DashboardModel) renderWallets() string {
@@ -325,43 +1221,100 @@ func (m *DashboardModel) renderWallets() string {
 	}
 
 	var content string
-	for _, w := range m.wallets {
+	for i, w := range m.wallets {
 		status := "✅"
 		if !w.IsActive {
 			status = "❌"
 		}
-		content += fmt.Sprintf("%s %s %s\n   %s %s\n\n",
-			w.Icon, w.Name, status,
-			w.Currency, moneyStyle.Render(formatMoney(w.Balance)),
+		marker := "  "
+		if i == m.walletCursor {
+			marker = selectedStyle.Render("▶ ")
+		}
+		content += fmt.Sprintf("%s%s %s %s\n     %s\n\n",
+			marker,
+			models.DisplayIcon(w.Icon), walletNameStyle(w.Color).Render(w.Name), status,
+			moneyStyle.Render(m.formatMoneyIn(w.Balance, w.Currency)),
 		)
 	}
 
 	return cardStyle.Render(
-		cardTitleStyle.Render("💼 Your Wallets") + "\n\n" + content,
+		cardTitleStyle.Render("💼 Your Wallets") + "\n\n" + content +
+			hintStyle.Render("j/k select · enter transactions · d delete"),
 	)
 }
 
 func (m *DashboardModel) renderTransactions() string {
+	hint := "j/k select · enter details"
+	if !m.txFilter.isEmpty() {
+		hint += " · x clear filter"
+	}
+
 	if len(m.recentTxs) == 0 {
+		if !m.txFilter.isEmpty() {
+			return cardStyle.Render("No transactions match the filter\n\n" + hintStyle.Render("x clear filter"))
+		}
 		return cardStyle.Render("No recent transactions")
 	}
 
 	var content string
-	for _, tx := range m.recentTxs {
+	for i, tx := range m.recentTxs {
 		icon := "📈"
 		if tx.Type == models.TransactionTypeExpense {
 			icon = "📉"
 		}
-		content += fmt.Sprintf("%s %s | %s\n   %s\n\n",
+		marker := "  "
+		if i == m.txCursor {
+			marker = selectedStyle.Render("▶ ")
+		}
+		content += fmt.Sprintf("%s%s %s | %s\n     %s\n     %s\n\n",
+			marker,
 			icon,
-			tx.TransactionDate.Format("02 Jan"),
-			formatMoney(tx.Amount),
+			m.formatDate(tx.TransactionDate),
+			m.formatMoneyIn(tx.Amount, m.walletCurrency(tx.WalletID)),
 			truncate(tx.Description, 40),
+			hintStyle.Render(m.refs.WalletName(tx.WalletID)+" · "+m.refs.CategoryName(tx.CategoryID)),
 		)
 	}
 
 	return cardStyle.Render(
-		cardTitleStyle.Render("📝 Recent Transactions") + "\n\n" + content,
+		cardTitleStyle.Render("📝 Recent Transactions") + "\n\n" + content +
+			hintStyle.Render(hint),
+	)
+}
+
+// renderTransactionDetail me-render transaksi yang dibuka dengan enter,
+// termasuk note lengkapnya (note tidak tampil di daftar).
+func (m *DashboardModel) renderTransactionDetail() string {
+	tx := m.detail
+
+	icon := "📈"
+	if tx.Type == models.TransactionTypeExpense {
+		icon = "📉"
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s %s · %s\n\n", icon, tx.Type, utils.FormatLongDate(tx.TransactionDate, m.app.Config.App.Locale)))
+	b.WriteString(fmt.Sprintf("Amount:      %s\n", moneyStyle.Render(m.formatMoneyIn(tx.Amount, m.walletCurrency(tx.WalletID)))))
+	b.WriteString(fmt.Sprintf("Description: %s\n", tx.Description))
+	b.WriteString(fmt.Sprintf("Wallet:      %s\n", m.refs.WalletName(tx.WalletID)))
+	b.WriteString(fmt.Sprintf("Category:    %s\n", m.refs.CategoryName(tx.CategoryID)))
+	if len(tx.Tags) > 0 {
+		b.WriteString(fmt.Sprintf("Tags:        %s\n", strings.Join(tx.Tags, ", ")))
+	}
+	if tx.Attachment != "" {
+		b.WriteString(fmt.Sprintf("Attachment:  %s\n", tx.Attachment))
+	}
+
+	b.WriteString("\n" + cardTitleStyle.Render("🗒️ Note") + "\n")
+	if tx.Note == "" {
+		b.WriteString(hintStyle.Render("No note yet") + "\n")
+	} else {
+		b.WriteString(tx.Note + "\n")
+	}
+
+	return cardStyle.Render(
+		cardTitleStyle.Render("📄 Transaction") + "\n\n" + b.String() + "\n" +
+			hintStyle.Render("e edit note in $EDITOR · esc back"),
 	)
 }
 
@@ -371,21 +1324,27 @@ func (m *DashboardModel) renderBudgets() string {
 	}
 
 	var content string
-	for _, s := range m.budgetStatuses {
-		bar := renderProgressBar(s.Progress, 20)
+	for i, s := range m.budgetStatuses {
+		bar := progress.Bar{Width: 20, Theme: progressTheme, ShowPercent: true}.Render(s.Progress)
 		status := ""
 		if s.IsOverBudget {
 			status = " ⚠️ OVER"
 		}
 
-		content += fmt.Sprintf("%s %s%s\n", s.CategoryIcon, s.CategoryName, status)
-		content += fmt.Sprintf("%s %.0f%%\n", bar, s.Progress)
+		marker := "  "
+		if i == m.budgetCursor {
+			marker = selectedStyle.Render("▶ ")
+		}
+
+		content += fmt.Sprintf("%s%s %s%s\n", marker, models.DisplayIcon(s.CategoryIcon), s.CategoryName, status)
+		content += bar + "\n"
 		content += fmt.Sprintf("Spent: %s / %s\n\n",
-			formatMoney(s.Spent), formatMoney(s.Budget.Amount))
+			m.formatMoney(s.Spent), m.formatMoney(s.Budget.Amount))
 	}
 
 	return cardStyle.Render(
-		cardTitleStyle.Render("📊 Budget Status") + "\n\n" + content,
+		cardTitleStyle.Render("📊 Budget Status") + "\n\n" + content +
+			hintStyle.Render("j/k select · enter transactions"),
 	)
 }
 
@@ -396,19 +1355,23 @@ func (m *DashboardModel) renderGoals() string {
 
 	var content string
 	for _, g := range m.goals {
-		progress := g.GetProgress()
-		bar := renderProgressBar(progress, 25)
+		pct := g.GetProgress()
+		bar := progress.Bar{Width: 25, Theme: progressTheme}.Render(pct)
 
 		status := "🔄 In Progress"
-		if g.IsCompleted() {
+		switch {
+		case g.IsCompleted():
 			status = "✅ Completed!"
+		case g.IsNetWorth():
+			// Net worth mengikuti total saldo, bukan kontribusi
+			status = "🌐 Net worth"
 		}
 
-		content += fmt.Sprintf("%s %s\n", g.Icon, g.Name)
-		content += fmt.Sprintf("%s %.1f%%\n", bar, progress)
+		content += fmt.Sprintf("%s %s\n", models.DisplayIcon(g.Icon), g.Name)
+		content += fmt.Sprintf("%s %.1f%%\n", bar, pct)
 		content += fmt.Sprintf("%s / %s | %s\n\n",
-			formatMoney(g.CurrentAmount),
-			formatMoney(g.TargetAmount),
+			m.formatMoney(g.CurrentAmount),
+			m.formatMoney(g.TargetAmount),
 			status,
 		)
 	}
@@ -418,18 +1381,122 @@ func (m *DashboardModel) renderGoals() string {
 	)
 }
 
+// renderInsights me-render heatmap pengeluaran hari × jam: satu baris per
+// hari (Senin dulu), satu cell per jam, warna makin pekat makin besar.
+func (m *DashboardModel) renderInsights() string {
+	title := cardTitleStyle.Render(fmt.Sprintf("🔥 Spending Heatmap · last %d days", heatmapDays))
+
+	switch {
+	case m.heatmapErr != nil:
+		return cardStyle.Render(title + "\n\n" + expenseStyle.Render("⚠️ Failed to load spending heatmap"))
+	case m.heatmap == nil || m.heatmap.Total.IsZero():
+		return cardStyle.Render(title + "\n\nNo expenses in this period")
+	}
+	h := m.heatmap
+
+	// Label jam setiap 6 jam; satu jam = 2 kolom
+	var b strings.Builder
+	b.WriteString("    ")
+	for hour := 0; hour < 24; hour += 6 {
+		b.WriteString(fmt.Sprintf("%-12d", hour))
+	}
+	b.WriteString("\n")
+
+	peakDay, peakHour := time.Sunday, -1
+	for _, day := range heatmapWeekdays {
+		b.WriteString(day.String()[:3] + " ")
+		for hour := 0; hour < 24; hour++ {
+			value := h.Data[day][hour]
+			b.WriteString(heatmapCell(value, h.Max))
+			if peakHour < 0 || value.GreaterThan(h.Data[peakDay][peakHour]) {
+				peakDay, peakHour = day, hour
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	legend := "\nless "
+	for level := range heatmapLevels {
+		legend += heatmapLevels[level].Render("█")
+	}
+	legend += " more\n"
+	b.WriteString(hintStyle.Render(legend))
+
+	if h.Max.IsPositive() {
+		b.WriteString(fmt.Sprintf("\nPeak: %s %02d:00 · %s\n",
+			peakDay.String()[:3], peakHour, expenseStyle.Render(m.formatMoney(h.Max))))
+	}
+
+	var unknown decimal.Decimal
+	for _, v := range h.UnknownHour {
+		unknown = unknown.Add(v)
+	}
+	if unknown.IsPositive() {
+		b.WriteString(hintStyle.Render(fmt.Sprintf("%s recorded after the day (time unknown) not shown", m.formatMoney(unknown))))
+	}
+
+	return cardStyle.Render(title + "\n\n" + b.String())
+}
+
+// heatmapWeekdays adalah urutan baris heatmap, mulai Senin.
+var heatmapWeekdays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+	time.Friday, time.Saturday, time.Sunday,
+}
+
+// heatmapCell me-render satu cell heatmap (2 kolom). Cell kosong memakai
+// titik redup; cell lain diberi warna sesuai proporsinya dari peak.
+func heatmapCell(value, peak decimal.Decimal) string {
+	if !value.IsPositive() || !peak.IsPositive() {
+		return heatmapEmptyStyle.Render("· ")
+	}
+
+	// level 0..len-1, cell terbesar selalu level tertinggi
+	n := int64(len(heatmapLevels))
+	level := value.Mul(decimal.NewFromInt(n)).Div(peak).Ceil().IntPart() - 1
+	level = min(max(level, 0), n-1)
+	return heatmapLevels[level].Render("██")
+}
+
 func (m *DashboardModel) renderHelp() string {
-	return helpStyle.Render("← → Navigate | 1-5 Jump | r Refresh | q Quit")
+	return components.KeyHelp{Bindings: dashboardKeyBindings, Style: helpStyle}.Render(m.width)
 }
 
-// Helper functions
-func formatMoney(d decimal.Decimal) string {
-	return "Rp " + d.StringFixed(0)
+// formatDate memformat tanggal sesuai locale dan pilihan absolute_dates di config.
+func (m *DashboardModel) formatDate(t time.Time) string {
+	cfg := m.app.Config.App
+	return utils.FormatDate(t, time.Now(), cfg.Locale, cfg.AbsoluteDates)
 }
 
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
+// formatMoney memformat nominal dalam currency default (app.currency di config).
+// Dipakai untuk total, summary, budget, dan goal.
+func (m *DashboardModel) formatMoney(d decimal.Decimal) string {
+	return m.formatMoneyIn(d, m.app.Config.App.Currency)
+}
+
+// privateMask menggantikan angka nominal di privacy mode.
+const privateMask = "•••••"
+
+// formatMoneyIn memformat nominal dalam currency tertentu. Semua nominal
+// di dashboard lewat sini, jadi di privacy mode hanya simbol currency
+// yang tampil, contoh "Rp •••••". Persentase dan progress bar tetap
+// tampil karena tidak membocorkan nilai absolut.
+func (m *DashboardModel) formatMoneyIn(d decimal.Decimal, currency string) string {
+	if m.private {
+		return utils.CurrencySymbol(currency) + privateMask
 	}
-	return s[:max-3] + "..."
+	return utils.FormatMoney(d, currency)
+}
+
+// walletCurrency mengembalikan currency wallet, atau currency default
+// jika wallet tidak ditemukan.
+func (m *DashboardModel) walletCurrency(id uuid.UUID) string {
+	return m.refs.WalletCurrency(id, m.app.Config.App.Currency)
+}
+
+// Helper functions
+
+// truncate memotong s ke max kolom (lebar tampilan, bukan byte).
+func truncate(s string, max int) string {
+	return utils.TruncateWidth(s, max)
 }
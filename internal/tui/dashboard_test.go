@@ -0,0 +1,375 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/app"
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// visibleMoney mencocokkan nominal yang angkanya terlihat, contoh
+// "Rp 50.000" atau "-$12.50".
+var visibleMoney = regexp.MustCompile(`(Rp |\$)\d`)
+
+// loadedDashboard membuat dashboard dengan data demo yang sudah di-load.
+func loadedDashboard(t *testing.T, opts Options) *DashboardModel {
+	t.Helper()
+
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	ctx := context.Background()
+	m := NewDashboard(ctx, demo, opts)
+	m.Update(tea.WindowSizeMsg{Width: 200, Height: 80})
+	m.Update(m.loadData(ctx, m.loadParams()))
+	m.Update(m.loadTopExpenses(ctx, m.loadParams()))
+	m.Update(m.loadTopCategories(ctx, m.loadParams()))
+	m.Update(m.loadHeatmap(ctx))
+	return m
+}
+
+func TestDashboard_PrivacyMode(t *testing.T) {
+	dir := t.TempDir()
+	m := loadedDashboard(t, Options{StateDir: dir})
+
+	m.activeTab = TabWallets
+	if !visibleMoney.MatchString(m.View()) {
+		t.Fatal("amounts should be visible before privacy mode is on")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+
+	for tab := TabOverview; tab < tabCount; tab++ {
+		m.activeTab = tab
+		view := m.View()
+		if match := visibleMoney.FindString(view); match != "" {
+			t.Errorf("%s: amount %q visible in privacy mode", tab, match)
+		}
+		if tab != TabInsights && !strings.Contains(view, "Rp "+privateMask) {
+			t.Errorf("%s: masked amount missing:\n%s", tab, view)
+		}
+	}
+
+	// Persentase goal tidak membocorkan nilai absolut
+	m.activeTab = TabGoals
+	if !strings.Contains(m.View(), "%") {
+		t.Error("goal progress percentages should stay visible in privacy mode")
+	}
+
+	state, err := LoadState(dir)
+	if err != nil || !state.Private {
+		t.Errorf("LoadState() = %+v, %v; want the toggle saved", state, err)
+	}
+	if !loadedDashboard(t, Options{StateDir: dir}).private {
+		t.Error("a new dashboard should start in the saved privacy mode")
+	}
+}
+
+// press mengirim satu tombol ke m dan menjalankan load yang dipicu,
+// seperti Bubble Tea.
+func press(t *testing.T, m *DashboardModel, key string) tea.Cmd {
+	t.Helper()
+
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	if key == "enter" {
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	_, cmd := m.Update(msg)
+	if cmd != nil {
+		m.Update(m.loadData(m.ctx, m.loadParams()))
+	}
+	return cmd
+}
+
+func TestDashboard_WalletFilter(t *testing.T) {
+	m := loadedDashboard(t, Options{})
+
+	m.activeTab = TabWallets
+	press(t, m, "j")
+	wallet := m.wallets[m.walletCursor]
+
+	if cmd := press(t, m, "enter"); cmd == nil {
+		t.Fatal("enter on the Wallets tab should reload transactions")
+	}
+	if m.activeTab != TabTransactions {
+		t.Fatalf("activeTab = %s, want Transactions", m.activeTab)
+	}
+	if m.txFilter.walletID == nil || *m.txFilter.walletID != wallet.ID {
+		t.Fatalf("txFilter = %+v, want wallet %s", m.txFilter, wallet.Name)
+	}
+	for _, tx := range m.recentTxs {
+		if tx.WalletID != wallet.ID {
+			t.Errorf("transaction %q from another wallet shown", tx.Description)
+		}
+	}
+	if chip := "Wallet: " + wallet.Name + " ✕"; !strings.Contains(m.View(), chip) {
+		t.Errorf("filter chip %q missing from the tab header", chip)
+	}
+
+	// Filter tetap saat pindah tab, dan x di tab lain tidak menghapusnya
+	m.recentErrors = []string{"Goals failed to load"}
+	press(t, m, "1")
+	press(t, m, "x")
+	if m.txFilter.isEmpty() || m.recentErrors != nil {
+		t.Fatalf("x outside Transactions: filter = %+v, warnings = %v; want the filter kept and warnings dismissed", m.txFilter, m.recentErrors)
+	}
+
+	m.recentErrors = []string{"Goals failed to load"}
+	press(t, m, "3")
+	if cmd := press(t, m, "x"); cmd == nil {
+		t.Fatal("clearing the filter should reload transactions")
+	}
+	if !m.txFilter.isEmpty() {
+		t.Errorf("txFilter = %+v after x, want cleared", m.txFilter)
+	}
+	if len(m.recentErrors) != 1 {
+		t.Error("the first x on a filtered Transactions tab should only clear the filter")
+	}
+	if strings.Contains(m.View(), "Wallet: ") {
+		t.Error("filter chip still shown after clearing")
+	}
+}
+
+func TestDashboard_BudgetDrillDown(t *testing.T) {
+	m := loadedDashboard(t, Options{})
+	if len(m.budgetStatuses) == 0 {
+		t.Fatal("demo data should have budgets")
+	}
+
+	m.activeTab = TabBudgets
+	status := m.budgetStatuses[0]
+	press(t, m, "enter")
+
+	if m.activeTab != TabTransactions {
+		t.Fatalf("activeTab = %s, want Transactions", m.activeTab)
+	}
+	f := m.txFilter
+	if f.walletID != nil || f.categoryID == nil || *f.categoryID != status.Budget.CategoryID {
+		t.Fatalf("txFilter = %+v, want only category %s", f, status.CategoryName)
+	}
+	if f.start == nil || !f.start.Equal(status.PeriodStart) || f.end == nil || !f.end.Before(status.PeriodEnd) {
+		t.Errorf("txFilter period = %v – %v, want the budget period %v – %v", f.start, f.end, status.PeriodStart, status.PeriodEnd)
+	}
+	for _, tx := range m.recentTxs {
+		if tx.CategoryID == nil || *tx.CategoryID != status.Budget.CategoryID ||
+			tx.TransactionDate.Before(status.PeriodStart) || !tx.TransactionDate.Before(status.PeriodEnd) {
+			t.Errorf("transaction %q outside the budget's category or period", tx.Description)
+		}
+	}
+	if !strings.Contains(m.View(), "Category: "+status.CategoryName+" ✕") {
+		t.Error("category chip missing from the tab header")
+	}
+
+	// Filter dari Wallets mengganti filter budget, bukan menambahnya
+	press(t, m, "2")
+	press(t, m, "enter")
+	if m.txFilter.categoryID != nil || m.txFilter.start != nil || m.txFilter.walletID == nil {
+		t.Errorf("txFilter = %+v, want only the wallet", m.txFilter)
+	}
+}
+
+func TestDashboard_OverBudgetBanner(t *testing.T) {
+	m := loadedDashboard(t, Options{})
+	if len(m.budgetStatuses) == 0 {
+		t.Fatal("demo data should have budgets")
+	}
+	m.activeTab = TabOverview
+	m.overBudgetAlerts = nil
+	if strings.Contains(m.View(), "Over budget") {
+		t.Fatal("banner should be hidden without over-budget alerts")
+	}
+
+	// Habiskan budget pertama plus 10%
+	status := m.budgetStatuses[0]
+	tx := models.NewTransaction(m.wallets[0].ID, models.TransactionTypeExpense, status.Budget.Amount.Mul(decimal.NewFromFloat(1.1)))
+	tx.SetCategory(status.Budget.CategoryID)
+	tx.Description = "Over budget test"
+	if err := m.app.Repos.Transaction.Create(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	m.Update(m.loadData(m.ctx, m.loadParams()))
+
+	var alert *service.OverBudgetAlert
+	for i := range m.overBudgetAlerts {
+		if m.overBudgetAlerts[i].CategoryName == status.CategoryName {
+			alert = &m.overBudgetAlerts[i]
+		}
+	}
+	if alert == nil {
+		t.Fatalf("overBudgetAlerts = %+v, want %s", m.overBudgetAlerts, status.CategoryName)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "⚠️ Over budget") || !strings.Contains(view, status.CategoryName+"  "+m.formatMoney(alert.Spent)) {
+		t.Errorf("banner for %s missing from Overview:\n%s", status.CategoryName, view)
+	}
+}
+
+// errDown adalah error database palsu untuk test load.
+var errDown = errors.New("database is down")
+
+type failingGoalRepo struct{ repository.GoalRepository }
+
+func (failingGoalRepo) List(context.Context, repository.GoalFilter) ([]*models.Goal, error) {
+	return nil, errDown
+}
+
+type failingBalanceRepo struct{ repository.WalletRepository }
+
+func (failingBalanceRepo) GetTotalBalance(context.Context) (decimal.Decimal, error) {
+	return decimal.Zero, errDown
+}
+
+func TestDashboard_LoadData_Failures(t *testing.T) {
+	demo, err := app.NewDemo("")
+	if err != nil {
+		t.Fatalf("NewDemo() error = %v", err)
+	}
+	ctx := context.Background()
+	m := NewDashboard(ctx, demo, Options{})
+
+	// Goals non-critical: dashboard tetap ter-load dengan warning
+	demo.Repos.Goal = failingGoalRepo{demo.Repos.Goal}
+	got := m.loadData(ctx, m.loadParams())
+	msg, ok := got.(dataLoadedMsg)
+	if !ok {
+		t.Fatalf("loadData() with failing goals = %#v, want dataLoadedMsg", got)
+	}
+	if len(msg.wallets) == 0 || msg.summary == nil || msg.goals != nil {
+		t.Errorf("loadData() = %d wallets, summary %v, goals %v; want data without goals", len(msg.wallets), msg.summary, msg.goals)
+	}
+	if len(msg.warnings) == 0 || !strings.HasPrefix(msg.warnings[0], "Goals failed to load") {
+		t.Errorf("warnings = %v, want the goals failure", msg.warnings)
+	}
+
+	// Total balance critical: seluruh load gagal
+	demo.Repos.Wallet = failingBalanceRepo{demo.Repos.Wallet}
+	if got, ok := m.loadData(ctx, m.loadParams()).(errMsg); !ok || !errors.Is(got.err, errDown) {
+		t.Errorf("loadData() with failing balance = %#v, want errMsg", got)
+	}
+
+	// Load yang dibatalkan dibuang
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if got := m.loadData(cancelled, m.loadParams()); got != nil {
+		t.Errorf("loadData() after cancel = %T, want nil", got)
+	}
+}
+
+func TestDashboard_AddTransaction_LargeWarning(t *testing.T) {
+	m := loadedDashboard(t, Options{})
+	m.app.Config.Validation.LargeAbsolute = "1000000"
+	m.activeTab = TabTransactions
+
+	// fill membuka form income (tidak dibatasi saldo wallet), mengetik
+	// amount lalu submit sampai anomali dicek
+	fill := func(amount string) {
+		t.Helper()
+		press(t, m, "a")
+		if m.txForm == nil {
+			t.Fatal("a on the Transactions tab should open the add form")
+		}
+		m.Update(tea.KeyMsg{Type: tea.KeyRight})
+		m.txForm.focus = txFieldAmount
+		for _, r := range amount {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil {
+			t.Fatalf("enter with amount %s should check the transaction", amount)
+		}
+		_, cmd = m.Update(cmd())
+		if cmd != nil {
+			m.Update(cmd())
+		}
+	}
+	count := func() int {
+		t.Helper()
+		txs, err := m.app.Repos.Transaction.List(m.ctx, repository.TransactionFilter{}, repository.ListParams{})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		return len(txs)
+	}
+	before := count()
+
+	fill("2500000")
+	if m.confirm == nil || !strings.Contains(m.confirm.Message, "large transaction threshold of 1000000") {
+		t.Fatalf("confirm = %+v, want the large transaction warning", m.confirm)
+	}
+
+	// Batal: transaksi tidak dibuat dan input tetap di form
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.txForm == nil || m.txForm.amount != "2500000" || count() != before {
+		t.Fatalf("after cancelling: form = %+v, %d transactions; want the form kept and nothing created", m.txForm, count()-before)
+	}
+
+	// Konfirmasi: transaksi dibuat dan form ditutup
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	fill("2500000")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("y should create the transaction")
+	}
+	m.Update(cmd())
+	if m.txForm != nil || count() != before+1 {
+		t.Fatalf("after confirming: form open = %v, %d new transactions; want 1 created", m.txForm != nil, count()-before)
+	}
+
+	// Di bawah threshold: langsung dibuat tanpa dialog
+	fill("25000")
+	if m.confirm != nil || m.txForm != nil || count() != before+2 {
+		t.Errorf("small amount: confirm = %+v, %d new transactions; want created without a warning", m.confirm, count()-before)
+	}
+}
+
+// Load jalan di goroutine tea.Cmd sementara Update mengganti sort;
+// dijalankan dengan -race untuk memastikan load tidak membaca model.
+func TestDashboard_RefreshWhileSorting(t *testing.T) {
+	m := loadedDashboard(t, Options{})
+	m.activeTab = TabWallets
+
+	batch, ok := m.refresh()().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("refresh() should return a batch of loads")
+	}
+
+	var wg sync.WaitGroup
+	for _, cmd := range batch {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = cmd()
+		}()
+	}
+	for i := 0; i < 5; i++ {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	}
+	wg.Wait()
+}
+
+func BenchmarkDashboard_LoadData(b *testing.B) {
+	demo, err := app.NewDemo("")
+	if err != nil {
+		b.Fatalf("NewDemo() error = %v", err)
+	}
+	ctx := context.Background()
+	m := NewDashboard(ctx, demo, Options{})
+
+	for b.Loop() {
+		if _, ok := m.loadData(ctx, m.loadParams()).(dataLoadedMsg); !ok {
+			b.Fatal("loadData() failed")
+		}
+	}
+}
@@ -8,7 +8,7 @@
 // Struktur TUI:
 //
 //	┌─────────────────────────────────────────────────────┐
-//	│  💰 Wallet Twin Dashboard                           │
+//	│  💰 Wallet Twin Dashboard v1.2.0                    │
 //	├─────────────────────────────────────────────────────┤
 //	│                                                     │
 //	│  📊 Overview          💼 Wallets    📝 Transactions │
@@ -29,7 +29,7 @@
 //
 // Usage:
 //
-//	model := tui.NewDashboard(app)
+//	model := tui.NewDashboard(ctx, app, tui.Options{StateDir: "./config"})
 //	p := tea.NewProgram(model)
 //	if _, err := p.Run(); err != nil {
 //	    log.Fatal(err)
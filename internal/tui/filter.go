@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/repository"
+)
+
+// txFilter adalah filter tab Transactions yang dipakai bersama antar tab:
+// tab Wallets (enter) mengisi wallet, drill-down tab Budgets (enter)
+// mengisi category dan periode budget. Filter tampil sebagai chip di
+// baris tab dan dihapus dengan x di tab Transactions.
+type txFilter struct {
+	walletID   *uuid.UUID
+	walletName string
+
+	categoryID   *uuid.UUID
+	categoryName string
+
+	// start dan end adalah periode budget; end nil berarti tanpa batas.
+	start *time.Time
+	end   *time.Time
+}
+
+// walletTxFilter membuat filter transaksi wallet w.
+func walletTxFilter(w *models.Wallet) txFilter {
+	id := w.ID
+	return txFilter{walletID: &id, walletName: w.Name}
+}
+
+// budgetTxFilter membuat filter pengeluaran yang dihitung di status
+// budget: category budget di periode berjalan, paling lambat EndDate.
+func budgetTxFilter(s *repository.BudgetStatus) txFilter {
+	id, start := s.Budget.CategoryID, s.PeriodStart
+	// EndDate filter inklusif, jadi berhenti tepat sebelum periode berikutnya
+	end := s.PeriodEnd.Add(-time.Nanosecond)
+	if s.Budget.EndDate != nil && s.Budget.EndDate.Before(end) {
+		end = *s.Budget.EndDate
+	}
+	return txFilter{categoryID: &id, categoryName: s.CategoryName, start: &start, end: &end}
+}
+
+// isEmpty true jika tidak ada filter aktif.
+func (f txFilter) isEmpty() bool {
+	return f.walletID == nil && f.categoryID == nil && f.start == nil && f.end == nil
+}
+
+// transactionFilter mengubah filter menjadi filter repository.
+func (f txFilter) transactionFilter() repository.TransactionFilter {
+	return repository.TransactionFilter{
+		WalletID:   f.walletID,
+		CategoryID: f.categoryID,
+		StartDate:  f.start,
+		EndDate:    f.end,
+	}
+}
+
+// chips mengembalikan label filter aktif, contoh "Wallet: BCA".
+// formatDate dipakai untuk tanggal periode.
+func (f txFilter) chips(formatDate func(time.Time) string) []string {
+	var chips []string
+	if f.walletID != nil {
+		chips = append(chips, "Wallet: "+f.walletName)
+	}
+	if f.categoryID != nil {
+		chips = append(chips, "Category: "+f.categoryName)
+	}
+	switch {
+	case f.start != nil && f.end != nil:
+		chips = append(chips, "Period: "+formatDate(*f.start)+" – "+formatDate(*f.end))
+	case f.start != nil:
+		chips = append(chips, "Since: "+formatDate(*f.start))
+	case f.end != nil:
+		chips = append(chips, "Until: "+formatDate(*f.end))
+	}
+	return chips
+}
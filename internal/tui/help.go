@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Adityanrhm/wallet-twin/internal/tui/components"
+)
+
+// dashboardKeyBindings ditampilkan di help bar bawah dashboard.
+var dashboardKeyBindings = []components.KeyBinding{
+	{Key: "Tab/Shift+Tab", Description: "Navigate"},
+	{Key: "1-6", Description: "Jump"},
+	{Key: "r", Description: "Refresh"},
+	{Key: "?", Description: "Help"},
+	{Key: "q", Description: "Quit"},
+}
+
+// globalKeyBindings berlaku di semua tab.
+var globalKeyBindings = []components.KeyBinding{
+	{Key: "Tab / Shift+Tab", Description: "Next / previous tab (wraps around)"},
+	{Key: "← → / h l", Description: "Previous / next tab"},
+	{Key: "1-6", Description: "Jump to tab"},
+	{Key: "x", Description: "Dismiss load warnings"},
+	{Key: "p", Description: "Hide / show amounts (privacy mode, remembered)"},
+	{Key: "?", Description: "Toggle this help"},
+	{Key: "q", Description: "Quit"},
+}
+
+// Key bindings per tab.
+var (
+	overviewKeyBindings = []components.KeyBinding{
+		{Key: "r", Description: "Refresh balance, summary, top expenses and goals"},
+	}
+
+	walletsKeyBindings = []components.KeyBinding{
+		{Key: "r", Description: "Refresh wallet balances"},
+		{Key: "s", Description: "Sort by created_at, name or balance"},
+		{Key: "↑ ↓ / k j", Description: "Select a wallet"},
+		{Key: "enter", Description: "Show the selected wallet's transactions"},
+		{Key: "d", Description: "Delete the selected wallet (asks to confirm)"},
+	}
+
+	transactionsKeyBindings = []components.KeyBinding{
+		{Key: "r", Description: "Refresh recent transactions"},
+		{Key: "a", Description: "Add a transaction (warns if unusually large)"},
+		{Key: "s", Description: "Sort by date, description or amount"},
+		{Key: "↑ ↓ / k j", Description: "Select a transaction"},
+		{Key: "enter", Description: "Show details and note"},
+		{Key: "e", Description: "Edit the note in $EDITOR (in details)"},
+		{Key: "esc", Description: "Close details"},
+		{Key: "x", Description: "Clear the wallet / category filter"},
+	}
+
+	budgetsKeyBindings = []components.KeyBinding{
+		{Key: "r", Description: "Refresh budget status"},
+		{Key: "↑ ↓ / k j", Description: "Select a budget"},
+		{Key: "enter", Description: "Show the budget's expenses for its period"},
+	}
+
+	goalsKeyBindings = []components.KeyBinding{
+		{Key: "r", Description: "Refresh goal progress"},
+	}
+
+	insightsKeyBindings = []components.KeyBinding{
+		{Key: "r", Description: "Refresh spending heatmap"},
+	}
+)
+
+// keyBindings mengembalikan key bindings khusus untuk tab.
+func (t Tab) keyBindings() []components.KeyBinding {
+	switch t {
+	case TabOverview:
+		return overviewKeyBindings
+	case TabWallets:
+		return walletsKeyBindings
+	case TabTransactions:
+		return transactionsKeyBindings
+	case TabBudgets:
+		return budgetsKeyBindings
+	case TabGoals:
+		return goalsKeyBindings
+	case TabInsights:
+		return insightsKeyBindings
+	default:
+		return nil
+	}
+}
+
+// helpCloseBar adalah petunjuk di bawah overlay bantuan.
+var helpCloseBar = components.KeyHelp{
+	Bindings: []components.KeyBinding{{Key: "? / esc", Description: "Close"}},
+	Style:    helpStyle,
+}
+
+// helpModel adalah overlay bantuan yang dibuka dengan "?".
+type helpModel struct {
+	// Visible menentukan apakah overlay ditampilkan.
+	Visible bool
+
+	// tab adalah tab aktif saat overlay dibuka.
+	tab Tab
+}
+
+// Toggle membuka/menutup overlay untuk tab tertentu.
+func (h *helpModel) Toggle(tab Tab) {
+	h.Visible = !h.Visible
+	h.tab = tab
+}
+
+// View me-render daftar key bindings tab aktif dan global.
+func (h helpModel) View() string {
+	var sb strings.Builder
+
+	sb.WriteString(cardTitleStyle.Render("⌨️  Keyboard Shortcuts — " + h.tab.String()))
+	sb.WriteString("\n")
+
+	if bindings := h.tab.keyBindings(); len(bindings) > 0 {
+		sb.WriteString(renderKeyBindings(bindings))
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(renderKeyBindings(globalKeyBindings))
+	sb.WriteString("\n\n")
+	sb.WriteString(helpCloseBar.Render(0))
+
+	return boxStyle.Render(sb.String())
+}
+
+// renderKeyBindings me-render bindings sebagai dua kolom rata kiri.
+func renderKeyBindings(bindings []components.KeyBinding) string {
+	keyWidth := 0
+	for _, b := range bindings {
+		if w := lipgloss.Width(b.Key); w > keyWidth {
+			keyWidth = w
+		}
+	}
+
+	lines := make([]string, len(bindings))
+	for i, b := range bindings {
+		key := keyStyle.Width(keyWidth).Render(b.Key)
+		lines[i] = fmt.Sprintf("%s  %s", key, b.Description)
+	}
+	return strings.Join(lines, "\n")
+}
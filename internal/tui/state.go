@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// stateFileName adalah nama file state dashboard di folder config.
+const stateFileName = "tui-state.json"
+
+// State adalah preferensi dashboard yang disimpan antar sesi.
+type State struct {
+	// Private menyembunyikan semua nominal (tombol p).
+	Private bool `json:"private"`
+}
+
+// LoadState membaca state dari folder dir. File yang belum ada berarti
+// state default.
+func LoadState(dir string) (State, error) {
+	var s State
+
+	data, err := os.ReadFile(filepath.Join(dir, stateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("failed to read dashboard state: %w", err)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return State{}, fmt.Errorf("failed to parse dashboard state: %w", err)
+	}
+	return s, nil
+}
+
+// SaveState menulis state ke folder dir. Folder dibuat jika belum ada.
+func SaveState(dir string, s State) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode dashboard state: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create config folder: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, stateFileName), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write dashboard state: %w", err)
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package tui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Adityanrhm/wallet-twin/internal/tui/components/progress"
 )
 
 // Colors - Professional dark theme
@@ -13,10 +15,10 @@ var (
 	dangerColor    = lipgloss.Color("#EF4444") // Red
 
 	// Neutral colors
-	bgColor       = lipgloss.Color("#0F172A") // Dark blue
-	surfaceColor  = lipgloss.Color("#1E293B") // Lighter dark
-	borderColor   = lipgloss.Color("#334155") // Border
-	textColor     = lipgloss.Color("#F8FAFC") // White
+	bgColor        = lipgloss.Color("#0F172A") // Dark blue
+	surfaceColor   = lipgloss.Color("#1E293B") // Lighter dark
+	borderColor    = lipgloss.Color("#334155") // Border
+	textColor      = lipgloss.Color("#F8FAFC") // White
 	textMutedColor = lipgloss.Color("#94A3B8") // Muted
 
 	// Money colors
@@ -39,6 +41,19 @@ var (
 			Padding(0, 2).
 			Width(60)
 
+	// Badge di header saat berjalan dengan data sample (--demo)
+	demoBadgeStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(bgColor).
+			Background(accentColor).
+			Padding(0, 1)
+
+	// Chip filter tab Transactions di kanan baris tab
+	filterChipStyle = lipgloss.NewStyle().
+			Foreground(bgColor).
+			Background(primaryColor).
+			Padding(0, 1)
+
 	// Tab styles
 	activeTabStyle = lipgloss.NewStyle().
 			Bold(true).
@@ -58,11 +73,37 @@ var (
 			Padding(1, 2).
 			Width(56)
 
+	// Card satu-dua baris untuk terminal pendek
+	compactCardStyle = cardStyle.Padding(0, 2)
+
 	cardTitleStyle = lipgloss.NewStyle().
 			Bold(true).
 			Foreground(primaryColor).
 			MarginBottom(1)
 
+	// Modal box (help overlay)
+	boxStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(primaryColor).
+			Padding(1, 2)
+
+	// Key di help overlay
+	keyStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(accentColor)
+
+	// Tombol di dialog konfirmasi (confirmDialog)
+	confirmButtonStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(textColor).
+				Background(dangerColor).
+				Padding(0, 2)
+
+	cancelButtonStyle = lipgloss.NewStyle().
+				Foreground(textColor).
+				Background(surfaceColor).
+				Padding(0, 2)
+
 	// Money styles
 	moneyStyle = lipgloss.NewStyle().
 			Bold(true).
@@ -74,34 +115,63 @@ var (
 	expenseStyle = lipgloss.NewStyle().
 			Foreground(expenseColor)
 
+	// Penanda wallet/transaksi terpilih di tab Wallets dan Transactions
+	selectedStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(primaryColor)
+
+	// Petunjuk kecil di bawah card ("press 3 for ...")
+	hintStyle = lipgloss.NewStyle().
+			Foreground(textMutedColor).
+			Italic(true)
+
+	// Warning strip di bawah tabs (data yang gagal di-load)
+	warningStripStyle = lipgloss.NewStyle().
+				Foreground(accentColor).
+				Padding(0, 1)
+
+	// Banner over budget di atas tab Overview
+	overBudgetBannerStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(dangerColor).
+				Foreground(expenseColor).
+				Padding(0, 1)
+
 	// Help bar
 	helpStyle = lipgloss.NewStyle().
 			Foreground(textMutedColor).
 			Padding(0, 1)
 
-	// Progress bar colors
-	progressFullStyle  = lipgloss.NewStyle().Foreground(secondaryColor)
-	progressEmptyStyle = lipgloss.NewStyle().Foreground(borderColor)
+	// Progress bar theme untuk budgets dan goals
+	progressTheme = progress.Theme{
+		FilledGlyph: "█",
+		EmptyGlyph:  "░",
+		Normal:      lipgloss.NewStyle().Foreground(secondaryColor),
+		Warning:     lipgloss.NewStyle().Foreground(accentColor),
+		Danger:      lipgloss.NewStyle().Foreground(dangerColor),
+		Empty:       lipgloss.NewStyle().Foreground(borderColor),
+	}
 )
 
-// renderProgressBar membuat visual progress bar.
-func renderProgressBar(percent float64, width int) string {
-	filled := int(percent / 100.0 * float64(width))
-	if filled > width {
-		filled = width
-	}
-	if filled < 0 {
-		filled = 0
-	}
+// Heatmap pengeluaran (tab Insights): cell kosong dan gradasi dari
+// sedikit ke banyak.
+var (
+	heatmapEmptyStyle = lipgloss.NewStyle().Foreground(borderColor)
 
-	bar := ""
-	for i := 0; i < width; i++ {
-		if i < filled {
-			bar += progressFullStyle.Render("█")
-		} else {
-			bar += progressEmptyStyle.Render("░")
-		}
+	heatmapLevels = []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#7F1D1D")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#B91C1C")),
+		lipgloss.NewStyle().Foreground(expenseColor),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#FCA5A5")),
 	}
+)
 
-	return bar
+// walletNameStyle mengembalikan style nama wallet dengan warna wallet
+// (models.Wallet.Color, #RRGGBB). Wallet tanpa warna memakai textColor.
+func walletNameStyle(color string) lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(true).Foreground(textColor)
+	if color != "" {
+		style = style.Foreground(lipgloss.Color(color))
+	}
+	return style
 }
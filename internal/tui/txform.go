@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shopspring/decimal"
+
+	"github.com/Adityanrhm/wallet-twin/internal/models"
+	"github.com/Adityanrhm/wallet-twin/internal/service"
+)
+
+// Field di txForm, sesuai urutan tampil.
+const (
+	txFieldType = iota
+	txFieldWallet
+	txFieldCategory
+	txFieldAmount
+	txFieldDescription
+	txFieldCount
+)
+
+// txForm adalah form tambah transaksi di tab Transactions (tombol a).
+// Type, wallet dan category dipilih dengan ← →, amount dan description
+// diketik. Submit menjalankan pengecekan transaksi besar yang sama dengan
+// `tx add` sebelum transaksi dibuat.
+type txForm struct {
+	focus int
+
+	txType      models.TransactionType
+	wallets     []*models.Wallet
+	wallet      int
+	categories  []*models.Category
+	category    int // 0 = tanpa kategori, i = categories[i-1]
+	amount      string
+	description string
+
+	// allCategories adalah semua kategori; categories disaring dari sini
+	// sesuai txType.
+	allCategories []*models.Category
+
+	// err adalah error validasi atau create terakhir, ditampilkan di form.
+	err error
+}
+
+// errFormAmount dikembalikan Input jika amount kosong atau bukan angka.
+var errFormAmount = errors.New("amount must be a positive number")
+
+// newTxForm membuat form expense untuk wallets. Wallet dan category yang
+// sedang difilter di tab Transactions dipilih lebih dulu.
+func newTxForm(wallets []*models.Wallet, refs *service.RefData, filter txFilter) *txForm {
+	f := &txForm{txType: models.TransactionTypeExpense, wallets: wallets}
+	if refs != nil {
+		for _, c := range refs.CategoriesByID {
+			f.allCategories = append(f.allCategories, c)
+		}
+		sort.Slice(f.allCategories, func(i, j int) bool {
+			return strings.ToLower(f.allCategories[i].Name) < strings.ToLower(f.allCategories[j].Name)
+		})
+	}
+	f.filterCategories()
+
+	for i, w := range wallets {
+		if filter.walletID != nil && w.ID == *filter.walletID {
+			f.wallet = i
+		}
+	}
+	for i, c := range f.categories {
+		if filter.categoryID != nil && c.ID == *filter.categoryID {
+			f.category = i + 1
+		}
+	}
+	return f
+}
+
+// filterCategories menyaring kategori sesuai txType dan mereset pilihan.
+func (f *txForm) filterCategories() {
+	f.categories = f.categories[:0]
+	for _, c := range f.allCategories {
+		if string(c.Type) == string(f.txType) {
+			f.categories = append(f.categories, c)
+		}
+	}
+	f.category = 0
+}
+
+// HandleKey memproses satu tombol. submit true jika user menekan enter,
+// cancel true jika esc.
+func (f *txForm) HandleKey(key string) (submit, cancel bool) {
+	switch key {
+	case "esc":
+		return false, true
+	case "enter":
+		return true, false
+	case "tab", "down":
+		f.focus = (f.focus + 1) % txFieldCount
+	case "shift+tab", "up":
+		f.focus = (f.focus + txFieldCount - 1) % txFieldCount
+	case "left", "right":
+		step := 1
+		if key == "left" {
+			step = -1
+		}
+		f.cycle(step)
+	case "backspace":
+		switch f.focus {
+		case txFieldAmount:
+			f.amount = dropLastRune(f.amount)
+		case txFieldDescription:
+			f.description = dropLastRune(f.description)
+		}
+	default:
+		f.typeText(key)
+	}
+	return false, false
+}
+
+// cycle mengganti pilihan field type, wallet atau category.
+func (f *txForm) cycle(step int) {
+	switch f.focus {
+	case txFieldType:
+		if f.txType == models.TransactionTypeExpense {
+			f.txType = models.TransactionTypeIncome
+		} else {
+			f.txType = models.TransactionTypeExpense
+		}
+		f.filterCategories()
+	case txFieldWallet:
+		if n := len(f.wallets); n > 0 {
+			f.wallet = (f.wallet + step + n) % n
+		}
+	case txFieldCategory:
+		n := len(f.categories) + 1
+		f.category = (f.category + step + n) % n
+	}
+}
+
+// typeText menambahkan karakter yang diketik ke field teks. Amount hanya
+// menerima angka dan titik desimal.
+func (f *txForm) typeText(key string) {
+	switch f.focus {
+	case txFieldAmount:
+		for _, r := range key {
+			if (r < '0' || r > '9') && r != '.' {
+				return
+			}
+		}
+		f.amount += key
+	case txFieldDescription:
+		if key == "space" {
+			key = " "
+		}
+		// Nama tombol seperti "ctrl+a" atau "pgup" bukan teks
+		if len([]rune(key)) == 1 || key == " " {
+			f.description += key
+		}
+	}
+}
+
+// Input mengubah isi form menjadi input TransactionService.Create.
+func (f *txForm) Input() (service.CreateTransactionInput, error) {
+	if len(f.wallets) == 0 {
+		return service.CreateTransactionInput{}, errors.New("no active wallet")
+	}
+	amount, err := decimal.NewFromString(f.amount)
+	if err != nil || !amount.IsPositive() {
+		return service.CreateTransactionInput{}, errFormAmount
+	}
+
+	input := service.CreateTransactionInput{
+		WalletID:    f.wallets[f.wallet].ID,
+		Type:        f.txType,
+		Amount:      amount,
+		Description: strings.TrimSpace(f.description),
+	}
+	if f.category > 0 {
+		id := f.categories[f.category-1].ID
+		input.CategoryID = &id
+	}
+	return input, nil
+}
+
+// CategoryLabel adalah nama kategori terpilih untuk pesan warning.
+func (f *txForm) CategoryLabel() string {
+	if f.category == 0 {
+		return "uncategorized"
+	}
+	return f.categories[f.category-1].Name
+}
+
+// View me-render form di dalam box.
+func (f *txForm) View() string {
+	wallet := "(none)"
+	if len(f.wallets) > 0 {
+		wallet = f.wallets[f.wallet].Name
+	}
+	category := "(none)"
+	if f.category > 0 {
+		category = f.categories[f.category-1].Name
+	}
+
+	rows := []struct{ label, value string }{
+		{"Type", "‹ " + string(f.txType) + " ›"},
+		{"Wallet", "‹ " + wallet + " ›"},
+		{"Category", "‹ " + category + " ›"},
+		{"Amount", f.amount},
+		{"Description", f.description},
+	}
+
+	lines := []string{cardTitleStyle.Render("➕ Add Transaction"), ""}
+	for i, row := range rows {
+		label := keyStyle.Width(12).Render(row.label)
+		value := row.value
+		if i == f.focus {
+			if i == txFieldAmount || i == txFieldDescription {
+				value += "▏"
+			}
+			value = selectedStyle.Render(value)
+		}
+		lines = append(lines, label+"  "+value)
+	}
+	if f.err != nil {
+		lines = append(lines, "", expenseStyle.Render("❌ "+f.err.Error()))
+	}
+	lines = append(lines, "", hintStyle.Render("tab/↑↓ field · ←→ choose · enter save · esc cancel"))
+
+	return boxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// dropLastRune menghapus karakter terakhir s.
+func dropLastRune(s string) string {
+	r := []rune(s)
+	if len(r) == 0 {
+		return s
+	}
+	return string(r[:len(r)-1])
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrNoClipboard dikembalikan SystemClipboard jika tidak ada tool clipboard
+// yang terpasang.
+var ErrNoClipboard = errors.New("no clipboard tool found (pbcopy, wl-copy, xclip, xsel or clip.exe)")
+
+// Clipboard menyalin teks ke clipboard sistem.
+type Clipboard interface {
+	Copy(text string) error
+}
+
+// commandClipboard menyalin teks lewat command eksternal yang membaca stdin.
+type commandClipboard struct {
+	name string
+	args []string
+}
+
+// Copy menjalankan command dengan text sebagai stdin.
+func (c commandClipboard) Copy(text string) error {
+	cmd := exec.Command(c.name, c.args...)
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", c.name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// clipboardCommands adalah kandidat command per OS, dicoba berurutan.
+// clip.exe juga dicoba di Linux supaya jalan di WSL.
+var clipboardCommands = map[string][]commandClipboard{
+	"darwin":  {{name: "pbcopy"}},
+	"windows": {{name: "clip.exe"}, {name: "clip"}},
+	"linux": {
+		{name: "wl-copy"},
+		{name: "xclip", args: []string{"-selection", "clipboard"}},
+		{name: "xsel", args: []string{"--clipboard", "--input"}},
+		{name: "clip.exe"},
+	},
+}
+
+// SystemClipboard mengembalikan clipboard untuk OS ini, atau ErrNoClipboard
+// jika tidak ada tool clipboard yang ditemukan di PATH.
+//
+//	clip, err := utils.SystemClipboard()
+//	if err != nil {
+//	    fmt.Println(text) // fallback ke stdout
+//	}
+//	err = clip.Copy(text)
+func SystemClipboard() (Clipboard, error) {
+	return findClipboard(runtime.GOOS, exec.LookPath)
+}
+
+// findClipboard memilih command pertama untuk goos yang ada di PATH.
+func findClipboard(goos string, lookPath func(string) (string, error)) (Clipboard, error) {
+	candidates, ok := clipboardCommands[goos]
+	if !ok {
+		candidates = clipboardCommands["linux"]
+	}
+
+	for _, c := range candidates {
+		if _, err := lookPath(c.name); err == nil {
+			return c, nil
+		}
+	}
+	return nil, ErrNoClipboard
+}
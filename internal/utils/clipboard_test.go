@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindClipboard(t *testing.T) {
+	tests := []struct {
+		goos      string
+		installed []string
+		want      string
+		wantErr   error
+	}{
+		{"darwin", []string{"pbcopy"}, "pbcopy", nil},
+		{"linux", []string{"xsel", "xclip"}, "xclip", nil},
+		{"linux", []string{"xsel"}, "xsel", nil},
+		{"linux", []string{"clip.exe"}, "clip.exe", nil}, // WSL
+		{"windows", []string{"clip.exe"}, "clip.exe", nil},
+		{"freebsd", []string{"xclip"}, "xclip", nil},
+		{"linux", nil, "", ErrNoClipboard},
+	}
+
+	for _, tt := range tests {
+		lookPath := func(name string) (string, error) {
+			for _, installed := range tt.installed {
+				if installed == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+
+		got, err := findClipboard(tt.goos, lookPath)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("findClipboard(%s, %v) error = %v, want %v", tt.goos, tt.installed, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if name := got.(commandClipboard).name; name != tt.want {
+			t.Errorf("findClipboard(%s, %v) = %s, want %s", tt.goos, tt.installed, name, tt.want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// NoColorEnv adalah environment variable standar untuk mematikan warna
+// (https://no-color.org).
+const NoColorEnv = "NO_COLOR"
+
+// colorDisabled di-set lewat SetColorDisabled (flag --no-color).
+var colorDisabled atomic.Bool
+
+// SetColorDisabled mematikan (atau menyalakan lagi) warna untuk seluruh
+// proses, misalnya dari flag --no-color.
+func SetColorDisabled(disabled bool) {
+	colorDisabled.Store(disabled)
+}
+
+// IsColorDisabled mengembalikan true jika warna dimatikan lewat
+// SetColorDisabled atau NO_COLOR di-set dan tidak kosong.
+//
+// Tidak mengecek apakah stdout terminal; itu keputusan caller (CLI
+// mematikan warna juga saat output di-pipe, TUI tidak).
+func IsColorDisabled() bool {
+	return colorDisabled.Load() || os.Getenv(NoColorEnv) != ""
+}
@@ -0,0 +1,31 @@
+package utils
+
+import "testing"
+
+func TestIsColorDisabled(t *testing.T) {
+	t.Cleanup(func() { SetColorDisabled(false) })
+
+	tests := []struct {
+		name    string
+		flag    bool
+		noColor string
+		want    bool
+	}{
+		{"default", false, "", false},
+		{"flag", true, "", true},
+		{"NO_COLOR", false, "1", true},
+		{"NO_COLOR empty is ignored", false, "", false},
+		{"both", true, "1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(NoColorEnv, tt.noColor)
+			SetColorDisabled(tt.flag)
+
+			if got := IsColorDisabled(); got != tt.want {
+				t.Errorf("IsColorDisabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
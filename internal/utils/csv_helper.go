@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// csvDelimiters adalah delimiter yang dikenali DetectCSVDelimiter, urut
+// prioritas jika jumlahnya sama.
+var csvDelimiters = []rune{',', ';', '\t'}
+
+// DetectCSVDelimiter menebak delimiter CSV dari baris pertama (header):
+// `,`, `;` atau tab, mana yang paling sering muncul di luar tanda kutip.
+// Export spreadsheet dengan locale Eropa biasanya memakai `;`.
+//
+// Setelah membaca, r di-seek kembali ke awal supaya bisa langsung dipakai
+// csv.Reader. Jika tidak ada delimiter yang ditemukan, hasilnya `,`.
+//
+//	comma, err := utils.DetectCSVDelimiter(file)
+//	reader := csv.NewReader(file)
+//	reader.Comma = comma
+func DetectCSVDelimiter(r io.ReadSeeker) (rune, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("failed to read first line: %w", err)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to rewind reader: %w", err)
+	}
+
+	counts := make(map[rune]int, len(csvDelimiters))
+	quoted := false
+	for _, c := range line {
+		if c == '"' {
+			quoted = !quoted
+			continue
+		}
+		if !quoted {
+			counts[c]++
+		}
+	}
+
+	best := csvDelimiters[0]
+	for _, d := range csvDelimiters[1:] {
+		if counts[d] > counts[best] {
+			best = d
+		}
+	}
+	return best, nil
+}
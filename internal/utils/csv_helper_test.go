@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDetectCSVDelimiter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  rune
+	}{
+		{name: "comma", input: "Date,Type,Amount\n2024-01-01,expense,100\n", want: ','},
+		{name: "semicolon", input: "Date;Type;Amount;Description\n2024-01-01;expense;1.234,56;Lunch\n", want: ';'},
+		{name: "tab", input: "Date\tType\tAmount\n", want: '\t'},
+		{name: "quoted comma", input: "\"Date, local\";\"Type\";\"Amount\"\n", want: ';'},
+		{name: "no newline", input: "Date;Type;Amount", want: ';'},
+		{name: "single column", input: "Date\n", want: ','},
+		{name: "empty", input: "", want: ','},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := strings.NewReader(tt.input)
+			got, err := DetectCSVDelimiter(r)
+			if err != nil {
+				t.Fatalf("DetectCSVDelimiter() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectCSVDelimiter() = %q, want %q", got, tt.want)
+			}
+
+			rest, _ := io.ReadAll(r)
+			if string(rest) != tt.input {
+				t.Errorf("reader not rewound: read %q, want %q", rest, tt.input)
+			}
+		})
+	}
+}
@@ -3,12 +3,15 @@
 // Package ini berisi utilities yang tidak spesifik ke domain:
 // - formatter.go: Format currency, date, numbers
 // - validator.go: Input validation helpers
+// - mask.go: Menyamarkan data sensitif (password) sebelum di-log
 // - crypto.go: Encryption utilities untuk backup
+// - period.go: Rentang periode bulanan dengan tanggal awal custom
+// - width.go: Lebar tampilan string (emoji, CJK) untuk alignment kolom
+// - csv_helper.go: Deteksi delimiter CSV (`,`, `;`, tab)
+// - color.go: Cek apakah warna dimatikan (--no-color, NO_COLOR)
 //
 // Best practices untuk utils:
 // 1. Keep functions pure (no side effects)
 // 2. Well-tested karena digunakan di banyak tempat
 // 3. Jangan taruh business logic di sini
 package utils
-
-// TODO: Add utility functions
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ISODateLayout adalah format tanggal absolut (YYYY-MM-DD).
+// Dipakai oleh export dan oleh user yang memilih absolute dates.
+const ISODateLayout = "2006-01-02"
+
+// dateLocale berisi nama-nama yang dipakai FormatRelativeDate.
+type dateLocale struct {
+	today     string
+	yesterday string
+	weekdays  [7]string  // Index = time.Weekday (Minggu/Sunday = 0)
+	months    [12]string // Index = time.Month - 1
+
+	// monthFirst menaruh bulan sebelum tanggal di FormatLongDate
+	// ("Jan 02, 2006" vs "02 Jan 2006").
+	monthFirst bool
+}
+
+var (
+	localeEN = dateLocale{
+		today:      "Today",
+		yesterday:  "Yesterday",
+		weekdays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		months:     [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		monthFirst: true,
+	}
+
+	localeID = dateLocale{
+		today:     "Hari ini",
+		yesterday: "Kemarin",
+		weekdays:  [7]string{"Min", "Sen", "Sel", "Rab", "Kam", "Jum", "Sab"},
+		months:    [12]string{"Jan", "Feb", "Mar", "Apr", "Mei", "Jun", "Jul", "Agu", "Sep", "Okt", "Nov", "Des"},
+	}
+)
+
+// lookupLocale memilih nama hari/bulan berdasarkan locale config.
+// "id-ID" (atau "id") memakai Bahasa Indonesia, selain itu English.
+func lookupLocale(locale string) dateLocale {
+	lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	if lang == "id" {
+		return localeID
+	}
+	return localeEN
+}
+
+// FormatRelativeDate memformat tanggal relatif terhadap now.
+//
+// Aturan (dibandingkan per tanggal kalender):
+//   - hari yang sama        → "Today" / "Hari ini"
+//   - kemarin               → "Yesterday" / "Kemarin"
+//   - 2-6 hari yang lalu    → nama hari singkat ("Mon" / "Sen")
+//   - tahun yang sama       → "02 Jan"
+//   - selain itu            → "02 Jan 2025"
+//
+// Tanggal di masa depan tidak pernah dianggap relatif.
+//
+//	utils.FormatRelativeDate(tx.TransactionDate, time.Now(), cfg.App.Locale)
+func FormatRelativeDate(t, now time.Time, locale string) string {
+	loc := lookupLocale(locale)
+
+	// Tanggal transaksi disimpan sebagai DATE (tanpa jam), jadi pakai
+	// tanggal kalender t apa adanya tanpa konversi timezone.
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// Hitung selisih hari via tanggal kalender, bukan durasi,
+	// supaya perpindahan DST tidak menggeser hasil.
+	daysAgo := 0
+	for d := day; d.Before(today) && daysAgo < 7; d = d.AddDate(0, 0, 1) {
+		daysAgo++
+	}
+
+	switch {
+	case day.Equal(today):
+		return loc.today
+	case day.Before(today) && daysAgo == 1:
+		return loc.yesterday
+	case day.Before(today) && daysAgo < 7:
+		return loc.weekdays[day.Weekday()]
+	}
+
+	month := loc.months[day.Month()-1]
+	if day.Year() == today.Year() {
+		return fmt.Sprintf("%02d %s", day.Day(), month)
+	}
+	return fmt.Sprintf("%02d %s %d", day.Day(), month, day.Year())
+}
+
+// FormatDate memformat tanggal untuk ditampilkan di list.
+// Jika absolute true, tanggal selalu dalam format ISO (YYYY-MM-DD).
+func FormatDate(t, now time.Time, locale string, absolute bool) string {
+	if absolute {
+		return t.Format(ISODateLayout)
+	}
+	return FormatRelativeDate(t, now, locale)
+}
+
+// FormatLongDate memformat tanggal lengkap sesuai locale, untuk teks
+// yang tidak boleh relatif (judul report, deadline, "last used"):
+//
+//	utils.FormatLongDate(t, "id-ID") // "02 Mei 2025"
+//	utils.FormatLongDate(t, "en-US") // "May 02, 2025"
+func FormatLongDate(t time.Time, locale string) string {
+	loc := lookupLocale(locale)
+	month := loc.months[t.Month()-1]
+	if loc.monthFirst {
+		return fmt.Sprintf("%s %02d, %d", month, t.Day(), t.Year())
+	}
+	return fmt.Sprintf("%02d %s %d", t.Day(), month, t.Year())
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatRelativeDate(t *testing.T) {
+	// Rabu, 15 Januari 2025 siang
+	now := time.Date(2025, time.January, 15, 14, 30, 0, 0, time.Local)
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		locale string
+		want   string
+	}{
+		{"today en", date(2025, time.January, 15), "en-US", "Today"},
+		{"today id", date(2025, time.January, 15), "id-ID", "Hari ini"},
+		{"yesterday en", date(2025, time.January, 14), "en-US", "Yesterday"},
+		{"yesterday id", date(2025, time.January, 14), "id-ID", "Kemarin"},
+		{"weekday en", date(2025, time.January, 13), "en-US", "Mon"},
+		{"weekday id", date(2025, time.January, 13), "id-ID", "Sen"},
+		{"six days ago", date(2025, time.January, 9), "en-US", "Thu"},
+		{"seven days ago", date(2025, time.January, 8), "en-US", "08 Jan"},
+		{"same year id", date(2025, time.January, 2), "id-ID", "02 Jan"},
+		{"last year en", date(2024, time.December, 2), "en-US", "02 Dec 2024"},
+		{"last year id", date(2024, time.December, 2), "id-ID", "02 Des 2024"},
+		{"future same year", date(2025, time.January, 16), "en-US", "16 Jan"},
+		{"future next year", date(2026, time.August, 17), "id-ID", "17 Agu 2026"},
+		{"unknown locale falls back to english", date(2025, time.January, 14), "fr-FR", "Yesterday"},
+		{"bare language code", date(2025, time.January, 14), "id", "Kemarin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatRelativeDate(tt.t, now, tt.locale); got != tt.want {
+				t.Errorf("FormatRelativeDate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRelativeDate_NewYear(t *testing.T) {
+	// 1 Januari: kemarin sudah tahun lalu tapi tetap "Yesterday"
+	now := time.Date(2026, time.January, 1, 8, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC), "Yesterday"},
+		{time.Date(2025, time.December, 26, 0, 0, 0, 0, time.UTC), "Fri"},
+		{time.Date(2025, time.December, 25, 0, 0, 0, 0, time.UTC), "25 Dec 2025"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatRelativeDate(tt.t, now, "en-US"); got != tt.want {
+			t.Errorf("FormatRelativeDate(%s) = %q, want %q", tt.t.Format(ISODateLayout), got, tt.want)
+		}
+	}
+}
+
+func TestFormatDate_Absolute(t *testing.T) {
+	now := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.Local)
+	d := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	if got := FormatDate(d, now, "id-ID", true); got != "2025-01-15" {
+		t.Errorf("FormatDate(absolute) = %q, want %q", got, "2025-01-15")
+	}
+	if got := FormatDate(d, now, "id-ID", false); got != "Hari ini" {
+		t.Errorf("FormatDate(relative) = %q, want %q", got, "Hari ini")
+	}
+}
+
+func TestFormatLongDate(t *testing.T) {
+	d := time.Date(2025, time.May, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"id-ID", "02 Mei 2025"},
+		{"id", "02 Mei 2025"},
+		{"en-US", "May 02, 2025"},
+		{"fr-FR", "May 02, 2025"},
+		{"", "May 02, 2025"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatLongDate(d, tt.locale); got != tt.want {
+			t.Errorf("FormatLongDate(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
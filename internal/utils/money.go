@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCurrencyDecimals adalah jumlah desimal untuk currency yang
+// tidak ada di tabel currencyFormats (kebanyakan currency memakai sen).
+const DefaultCurrencyDecimals int32 = 2
+
+// currencyFormat menentukan cara menampilkan nominal satu currency.
+type currencyFormat struct {
+	decimals  int32
+	thousands string
+	decimal   string
+	symbol    string // Prefix, termasuk spasi jika perlu ("Rp ", "$")
+}
+
+// defaultCurrencyFormat dipakai untuk currency yang tidak dikenal:
+// 2 desimal, pemisah ribuan koma, dan kode currency sebagai prefix.
+var defaultCurrencyFormat = currencyFormat{
+	decimals:  DefaultCurrencyDecimals,
+	thousands: ",",
+	decimal:   ".",
+}
+
+// currencyFormats berisi currency yang formatnya berbeda dari default
+// atau punya simbol sendiri.
+//
+// IDR ditampilkan tanpa desimal walaupun ISO 4217 mendefinisikan 2,
+// karena sen rupiah tidak dipakai sehari-hari.
+var currencyFormats = map[string]currencyFormat{
+	// Tanpa desimal
+	"IDR": {decimals: 0, thousands: ".", decimal: ",", symbol: "Rp "},
+	"JPY": {decimals: 0, thousands: ",", decimal: ".", symbol: "¥"},
+	"KRW": {decimals: 0, thousands: ",", decimal: ".", symbol: "₩"},
+	"VND": {decimals: 0, thousands: ".", decimal: ",", symbol: "₫"},
+
+	// 2 desimal dengan simbol
+	"USD": {decimals: 2, thousands: ",", decimal: ".", symbol: "$"},
+	"EUR": {decimals: 2, thousands: ",", decimal: ".", symbol: "€"},
+	"GBP": {decimals: 2, thousands: ",", decimal: ".", symbol: "£"},
+	"SGD": {decimals: 2, thousands: ",", decimal: ".", symbol: "S$"},
+	"MYR": {decimals: 2, thousands: ",", decimal: ".", symbol: "RM "},
+
+	// 3 desimal
+	"BHD": {decimals: 3, thousands: ",", decimal: "."},
+	"KWD": {decimals: 3, thousands: ",", decimal: "."},
+	"OMR": {decimals: 3, thousands: ",", decimal: "."},
+	"JOD": {decimals: 3, thousands: ",", decimal: "."},
+}
+
+// lookupCurrency mencari format currency (case-insensitive).
+func lookupCurrency(currency string) (currencyFormat, string) {
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	if f, ok := currencyFormats[code]; ok {
+		return f, code
+	}
+	return defaultCurrencyFormat, code
+}
+
+// CurrencyDecimals mengembalikan jumlah desimal untuk currency.
+//
+//	utils.CurrencyDecimals("IDR") // 0
+//	utils.CurrencyDecimals("USD") // 2
+func CurrencyDecimals(currency string) int32 {
+	f, _ := lookupCurrency(currency)
+	return f.decimals
+}
+
+// FormatAmount memformat nominal dengan pemisah ribuan dan jumlah desimal
+// sesuai currency, tanpa simbol.
+//
+// Nominal dibulatkan (half away from zero) ke jumlah desimal currency,
+// jadi nilai yang disimpan dengan sen tidak hilang untuk currency 2 desimal.
+//
+//	utils.FormatAmount(decimal.RequireFromString("1234.56"), "USD") // "1,234.56"
+//	utils.FormatAmount(decimal.NewFromInt(1234), "IDR")             // "1.234"
+func FormatAmount(d decimal.Decimal, currency string) string {
+	f, _ := lookupCurrency(currency)
+	return formatAmount(d, f)
+}
+
+// CurrencySymbol mengembalikan prefix yang dipakai FormatMoney: simbol
+// currency, atau kode ISO plus spasi untuk currency tanpa simbol.
+//
+//	utils.CurrencySymbol("USD") // "$"
+//	utils.CurrencySymbol("IDR") // "Rp "
+//	utils.CurrencySymbol("CHF") // "CHF "
+func CurrencySymbol(currency string) string {
+	f, code := lookupCurrency(currency)
+	if f.symbol == "" {
+		return code + " "
+	}
+	return f.symbol
+}
+
+// FormatMoney memformat nominal lengkap dengan simbol currency.
+// Currency tanpa simbol memakai kode ISO sebagai prefix.
+//
+//	utils.FormatMoney(decimal.RequireFromString("-1234.5"), "USD") // "-$1,234.50"
+//	utils.FormatMoney(decimal.NewFromInt(50000), "IDR")            // "Rp 50.000"
+//	utils.FormatMoney(decimal.NewFromInt(10), "CHF")               // "CHF 10.00"
+func FormatMoney(d decimal.Decimal, currency string) string {
+	f, _ := lookupCurrency(currency)
+	symbol := CurrencySymbol(currency)
+
+	amount := formatAmount(d.Abs(), f)
+	if d.Round(f.decimals).IsNegative() {
+		return "-" + symbol + amount
+	}
+	return symbol + amount
+}
+
+// formatAmount membulatkan d lalu menyisipkan pemisah ribuan.
+func formatAmount(d decimal.Decimal, f currencyFormat) string {
+	s := d.StringFixed(f.decimals)
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteString(f.thousands)
+		}
+		sb.WriteRune(r)
+	}
+	if fracPart != "" {
+		sb.WriteString(f.decimal)
+		sb.WriteString(fracPart)
+	}
+
+	// "-0" setelah pembulatan ditampilkan sebagai "0"
+	out := sb.String()
+	if sign != "" && strings.Trim(s, "0.") == "" {
+		return out[1:]
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     string
+	}{
+		{"1234.56", "USD", "1,234.56"},
+		{"1234.5", "usd", "1,234.50"},
+		{"0.01", "USD", "0.01"},
+		{"1234567.89", "EUR", "1,234,567.89"},
+		{"1234", "IDR", "1.234"},
+		{"1234567", "IDR", "1.234.567"},
+		{"1234.6", "IDR", "1.235"},
+		{"999", "IDR", "999"},
+		{"-1234.56", "USD", "-1,234.56"},
+		{"-0.001", "USD", "0.00"},
+		{"1.2345", "KWD", "1.235"},
+		{"1234.56", "CHF", "1,234.56"},
+		{"1500", "JPY", "1,500"},
+	}
+
+	for _, tt := range tests {
+		got := FormatAmount(decimal.RequireFromString(tt.amount), tt.currency)
+		if got != tt.want {
+			t.Errorf("FormatAmount(%s, %s) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestFormatAmount_NoPrecisionLoss(t *testing.T) {
+	// Setiap nilai sen harus tampil apa adanya untuk currency 2 desimal.
+	for cents := int64(0); cents < 100; cents++ {
+		d := decimal.New(123400+cents, -2) // 1234.00 .. 1234.99
+		got := FormatAmount(d, "USD")
+
+		back, err := decimal.NewFromString(removeThousands(got))
+		if err != nil {
+			t.Fatalf("FormatAmount(%s) = %q, not parseable: %v", d, got, err)
+		}
+		if !back.Equal(d) {
+			t.Errorf("FormatAmount(%s) = %q, lost precision", d, got)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     string
+	}{
+		{"1234.56", "USD", "$1,234.56"},
+		{"-1234.5", "USD", "-$1,234.50"},
+		{"50000", "IDR", "Rp 50.000"},
+		{"10", "CHF", "CHF 10.00"},
+	}
+
+	for _, tt := range tests {
+		got := FormatMoney(decimal.RequireFromString(tt.amount), tt.currency)
+		if got != tt.want {
+			t.Errorf("FormatMoney(%s, %s) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestCurrencyDecimals(t *testing.T) {
+	tests := map[string]int32{"IDR": 0, "JPY": 0, "USD": 2, "eur": 2, "KWD": 3, "XYZ": 2}
+	for currency, want := range tests {
+		if got := CurrencyDecimals(currency); got != want {
+			t.Errorf("CurrencyDecimals(%s) = %d, want %d", currency, got, want)
+		}
+	}
+}
+
+func removeThousands(s string) string {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r != ',' {
+			out = append(out, r)
+		}
+	}
+	return string(out)
+}
+
+func TestCurrencySymbol(t *testing.T) {
+	tests := map[string]string{
+		"USD": "$",
+		"usd": "$",
+		"IDR": "Rp ",
+		"EUR": "€",
+		"CHF": "CHF ",
+		"KWD": "KWD ",
+	}
+	for currency, want := range tests {
+		if got := CurrencySymbol(currency); got != want {
+			t.Errorf("CurrencySymbol(%q) = %q, want %q", currency, got, want)
+		}
+	}
+}
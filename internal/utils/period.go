@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxMonthStartDay adalah anchor terbesar yang diizinkan untuk
+// app.month_start_day. Dibatasi 28 supaya setiap bulan (termasuk
+// Februari) punya tanggal tersebut.
+const MaxMonthStartDay = 28
+
+// normalizeAnchor mengembalikan anchorDay, atau 1 jika di luar 1-28.
+func normalizeAnchor(anchorDay int) int {
+	if anchorDay < 1 || anchorDay > MaxMonthStartDay {
+		return 1
+	}
+	return anchorDay
+}
+
+// PeriodBounds mengembalikan rentang "bulan" year/month jika bulan
+// dimulai di tanggal anchorDay (app.month_start_day).
+//
+// start inklusif, end eksklusif (awal periode berikutnya). Periode diberi
+// nama sesuai bulan tempat periode itu berakhir, jadi dengan anchor 25
+// periode January 2026 adalah 25 Dec 2025 – 24 Jan 2026. Anchor 1 (atau
+// di luar 1-28) berarti bulan kalender biasa.
+//
+//	start, end := utils.PeriodBounds(25, 2026, time.January, time.Local)
+//	// start = 2025-12-25 00:00, end = 2026-01-25 00:00
+func PeriodBounds(anchorDay, year int, month time.Month, loc *time.Location) (start, end time.Time) {
+	anchorDay = normalizeAnchor(anchorDay)
+	if anchorDay == 1 {
+		start = time.Date(year, month, 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0)
+	}
+
+	// time.Date menormalisasi month 0 menjadi Desember tahun sebelumnya
+	start = time.Date(year, month-1, anchorDay, 0, 0, 0, 0, loc)
+	end = time.Date(year, month, anchorDay, 0, 0, 0, 0, loc)
+	return start, end
+}
+
+// PeriodContaining mengembalikan nama periode (year, month) yang memuat t
+// untuk anchorDay tertentu. Dengan anchor 25, 26 Dec 2025 masuk periode
+// January 2026.
+//
+//	year, month := utils.PeriodContaining(cfg.App.MonthStartDay, time.Now())
+func PeriodContaining(anchorDay int, t time.Time) (int, time.Month) {
+	anchorDay = normalizeAnchor(anchorDay)
+	if anchorDay > 1 && t.Day() >= anchorDay {
+		next := time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		return next.Year(), next.Month()
+	}
+	return t.Year(), t.Month()
+}
+
+// PeriodLabel memformat nama periode. Untuk anchor selain 1 rentang
+// tanggalnya ikut ditampilkan supaya tidak dikira bulan kalender:
+//
+//	utils.PeriodLabel(1, 2026, time.January)  // "January 2026"
+//	utils.PeriodLabel(25, 2026, time.January) // "January 2026 (25 Dec – 24 Jan)"
+func PeriodLabel(anchorDay, year int, month time.Month) string {
+	name := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Format("January 2006")
+	if normalizeAnchor(anchorDay) == 1 {
+		return name
+	}
+
+	start, end := PeriodBounds(anchorDay, year, month, time.UTC)
+	return fmt.Sprintf("%s (%s – %s)", name, start.Format("02 Jan"), end.AddDate(0, 0, -1).Format("02 Jan"))
+}
+
+// normalizeFiscalStart mengembalikan startMonth, atau 1 jika di luar 1-12.
+func normalizeFiscalStart(startMonth int) time.Month {
+	if startMonth < 1 || startMonth > 12 {
+		return time.January
+	}
+	return time.Month(startMonth)
+}
+
+// FiscalYearBounds mengembalikan rentang tahun fiskal fy jika tahun
+// dimulai di bulan startMonth (app.fiscal_year_start_month).
+//
+// start inklusif, end eksklusif. Tahun fiskal diberi nama sesuai tahun
+// awalnya, jadi dengan start April FY2025 adalah 1 Apr 2025 – 31 Mar
+// 2026. Start 1 (atau di luar 1-12) berarti tahun kalender biasa.
+//
+//	start, end := utils.FiscalYearBounds(4, 2025, time.Local)
+//	// start = 2025-04-01 00:00, end = 2026-04-01 00:00
+func FiscalYearBounds(startMonth, fy int, loc *time.Location) (start, end time.Time) {
+	start = time.Date(fy, normalizeFiscalStart(startMonth), 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(1, 0, 0)
+}
+
+// FiscalYearContaining mengembalikan tahun fiskal yang memuat t. Dengan
+// start April, 15 Mar 2026 masuk FY2025 dan 1 Apr 2026 masuk FY2026.
+func FiscalYearContaining(startMonth int, t time.Time) int {
+	if t.Month() < normalizeFiscalStart(startMonth) {
+		return t.Year() - 1
+	}
+	return t.Year()
+}
+
+// FiscalYearToDate mengembalikan rentang dari awal tahun fiskal yang
+// memuat now sampai akhir hari now (end eksklusif, tengah malam
+// berikutnya).
+//
+//	start, end := utils.FiscalYearToDate(4, time.Now())
+func FiscalYearToDate(startMonth int, now time.Time) (start, end time.Time) {
+	start, _ = FiscalYearBounds(startMonth, FiscalYearContaining(startMonth, now), now.Location())
+	end = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	return start, end
+}
+
+// FiscalYearLabel memformat nama tahun fiskal. Untuk start selain
+// Januari rentang bulannya ikut ditampilkan:
+//
+//	utils.FiscalYearLabel(1, 2025) // "2025"
+//	utils.FiscalYearLabel(4, 2025) // "FY2025 (Apr 2025–Mar 2026)"
+func FiscalYearLabel(startMonth, fy int) string {
+	if normalizeFiscalStart(startMonth) == time.January {
+		return fmt.Sprintf("%d", fy)
+	}
+
+	start, end := FiscalYearBounds(startMonth, fy, time.UTC)
+	return fmt.Sprintf("FY%d (%s–%s)", fy, start.Format("Jan 2006"), end.AddDate(0, 0, -1).Format("Jan 2006"))
+}
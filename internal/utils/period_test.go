@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodBounds(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		anchor    int
+		year      int
+		month     time.Month
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{"calendar month", 1, 2026, time.March, date(2026, 3, 1), date(2026, 4, 1)},
+		{"calendar december", 1, 2025, time.December, date(2025, 12, 1), date(2026, 1, 1)},
+		{"anchor 25 across year boundary", 25, 2026, time.January, date(2025, 12, 25), date(2026, 1, 25)},
+		{"anchor 25 december", 25, 2025, time.December, date(2025, 11, 25), date(2025, 12, 25)},
+		{"anchor 25 february", 25, 2026, time.February, date(2026, 1, 25), date(2026, 2, 25)},
+		{"anchor 25 march after short february", 25, 2026, time.March, date(2026, 2, 25), date(2026, 3, 25)},
+		{"anchor 28 leap february", 28, 2024, time.March, date(2024, 2, 28), date(2024, 3, 28)},
+		{"invalid anchor falls back to calendar", 31, 2026, time.February, date(2026, 2, 1), date(2026, 3, 1)},
+		{"zero anchor falls back to calendar", 0, 2026, time.February, date(2026, 2, 1), date(2026, 3, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := PeriodBounds(tt.anchor, tt.year, tt.month, time.UTC)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("PeriodBounds(%d, %d, %s) = %s – %s, want %s – %s",
+					tt.anchor, tt.year, tt.month,
+					start.Format(ISODateLayout), end.Format(ISODateLayout),
+					tt.wantStart.Format(ISODateLayout), tt.wantEnd.Format(ISODateLayout))
+			}
+		})
+	}
+}
+
+func TestPeriodContaining(t *testing.T) {
+	tests := []struct {
+		anchor    int
+		t         time.Time
+		wantYear  int
+		wantMonth time.Month
+	}{
+		{1, time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC), 2025, time.December},
+		{25, time.Date(2025, 12, 24, 23, 59, 0, 0, time.UTC), 2025, time.December},
+		{25, time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), 2026, time.January},
+		{25, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), 2026, time.January},
+		{25, time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC), 2026, time.January},
+		{25, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), 2026, time.March},
+		{25, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), 2026, time.February},
+	}
+
+	for _, tt := range tests {
+		year, month := PeriodContaining(tt.anchor, tt.t)
+		if year != tt.wantYear || month != tt.wantMonth {
+			t.Errorf("PeriodContaining(%d, %s) = %d %s, want %d %s",
+				tt.anchor, tt.t.Format(ISODateLayout), year, month, tt.wantYear, tt.wantMonth)
+		}
+
+		// Periode yang dikembalikan harus benar-benar memuat t
+		start, end := PeriodBounds(tt.anchor, year, month, time.UTC)
+		if tt.t.Before(start) || !tt.t.Before(end) {
+			t.Errorf("%s not within period %s – %s", tt.t, start, end)
+		}
+	}
+}
+
+func TestPeriodLabel(t *testing.T) {
+	if got := PeriodLabel(1, 2026, time.January); got != "January 2026" {
+		t.Errorf("PeriodLabel(1) = %q", got)
+	}
+	if got, want := PeriodLabel(25, 2026, time.January), "January 2026 (25 Dec – 24 Jan)"; got != want {
+		t.Errorf("PeriodLabel(25) = %q, want %q", got, want)
+	}
+	if got, want := PeriodLabel(25, 2026, time.March), "March 2026 (25 Feb – 24 Mar)"; got != want {
+		t.Errorf("PeriodLabel(25, March) = %q, want %q", got, want)
+	}
+}
+
+func TestFiscalYearContaining(t *testing.T) {
+	tests := []struct {
+		start  int
+		t      time.Time
+		wantFY int
+	}{
+		{1, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), 2025},
+		{1, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 2026},
+		{4, time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC), 2025},
+		{4, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 2025},
+		{4, time.Date(2026, 3, 31, 23, 59, 0, 0, time.UTC), 2025},
+		{4, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), 2026},
+		{13, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), 2026}, // di luar 1-12 = kalender
+	}
+
+	for _, tt := range tests {
+		fy := FiscalYearContaining(tt.start, tt.t)
+		if fy != tt.wantFY {
+			t.Errorf("FiscalYearContaining(%d, %s) = %d, want %d", tt.start, tt.t.Format(ISODateLayout), fy, tt.wantFY)
+		}
+
+		start, end := FiscalYearBounds(tt.start, fy, time.UTC)
+		if tt.t.Before(start) || !tt.t.Before(end) {
+			t.Errorf("%s not within FY %s – %s", tt.t, start, end)
+		}
+	}
+}
+
+func TestFiscalYearToDate(t *testing.T) {
+	// Hari pertama tahun fiskal: rentangnya satu hari penuh
+	start, end := FiscalYearToDate(4, time.Date(2026, 4, 1, 9, 30, 0, 0, time.UTC))
+	if want := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start = %s, want %s", start, want)
+	}
+	if want := time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("end = %s, want %s", end, want)
+	}
+
+	// Januari masih di tahun fiskal yang dimulai April sebelumnya
+	start, _ = FiscalYearToDate(4, time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC))
+	if want := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start = %s, want %s", start, want)
+	}
+}
+
+func TestFiscalYearLabel(t *testing.T) {
+	if got := FiscalYearLabel(1, 2025); got != "2025" {
+		t.Errorf("FiscalYearLabel(1) = %q, want 2025", got)
+	}
+	if got, want := FiscalYearLabel(4, 2025), "FY2025 (Apr 2025–Mar 2026)"; got != want {
+		t.Errorf("FiscalYearLabel(4) = %q, want %q", got, want)
+	}
+}
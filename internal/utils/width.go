@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// DisplayWidth mengembalikan lebar s di terminal (kolom), bukan jumlah
+// byte atau rune: emoji dan karakter CJK selebar 2 kolom, emoji ZWJ dan
+// bendera dihitung sebagai satu karakter.
+//
+//	utils.DisplayWidth("🍔 Food") // 7
+func DisplayWidth(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// TruncateWidth memotong s supaya lebarnya paling banyak max kolom,
+// dengan "..." di akhir jika dipotong. Grapheme (mis. emoji ZWJ) tidak
+// pernah dipotong di tengah.
+//
+//	utils.TruncateWidth("Groceries & Household", 12) // "Groceries..."
+func TruncateWidth(s string, max int) string {
+	if DisplayWidth(s) <= max {
+		return s
+	}
+	if max <= 0 {
+		return ""
+	}
+	if max <= 3 {
+		return strings.Repeat(".", max)
+	}
+
+	var sb strings.Builder
+	width := 0
+	state := -1
+	rest := s
+	for rest != "" {
+		var cluster string
+		var w int
+		cluster, rest, w, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if width+w > max-3 {
+			break
+		}
+		sb.WriteString(cluster)
+		width += w
+	}
+	return sb.String() + "..."
+}
+
+// PadRight menambahkan spasi di kanan s sampai lebarnya width kolom.
+// Berbeda dengan fmt "%-16s" yang menghitung byte, emoji tidak membuat
+// kolom bergeser.
+//
+//	utils.PadRight("🍔 Food", 10) // "🍔 Food   "
+func PadRight(s string, width int) string {
+	if pad := width - DisplayWidth(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
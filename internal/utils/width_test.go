@@ -0,0 +1,62 @@
+package utils
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{"ascii", "Food", 4},
+		{"emoji", "🍔", 2},
+		{"emoji with variation selector", "🛍️", 2},
+		{"zwj sequence", "👨‍👩‍👧", 2},
+		{"flag", "🇮🇩", 2},
+		{"mixed", "🍔 Food", 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DisplayWidth(tt.in); got != tt.want {
+				t.Errorf("DisplayWidth(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"fits", "Food", 10, "Food"},
+		{"ascii", "Groceries & Household", 12, "Groceries..."},
+		{"does not split emoji", "🍔🍔🍔🍔", 6, "🍔..."},
+		{"does not split zwj sequence", "👨‍👩‍👧 Family", 6, "👨‍👩‍👧 ..."},
+		{"tiny", "Groceries", 2, ".."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateWidth(tt.in, tt.max)
+			if got != tt.want {
+				t.Errorf("TruncateWidth(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+			if w := DisplayWidth(got); w > tt.max {
+				t.Errorf("width = %d, want <= %d", w, tt.max)
+			}
+		})
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	for _, s := range []string{"Food", "🍔 Food", "🇮🇩 Flag", "👨‍👩‍👧 Family"} {
+		if got := DisplayWidth(PadRight(s, 12)); got != 12 {
+			t.Errorf("DisplayWidth(PadRight(%q, 12)) = %d, want 12", s, got)
+		}
+	}
+	if got := PadRight("Long category name", 4); got != "Long category name" {
+		t.Errorf("PadRight() = %q, want input unchanged", got)
+	}
+}
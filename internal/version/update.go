@@ -0,0 +1,125 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LatestReleaseURL adalah endpoint GitHub API untuk release terbaru.
+const LatestReleaseURL = "https://api.github.com/repos/Adityanrhm/wallet-twin/releases/latest"
+
+// DefaultUpdateTimeout adalah batas waktu cek update. Dibuat pendek karena
+// cek update tidak boleh membuat `wallet version` terasa lambat.
+const DefaultUpdateTimeout = 3 * time.Second
+
+// Release adalah bagian response GitHub releases yang dipakai.
+type Release struct {
+	TagName string `json:"tag_name"`
+	Name    string `json:"name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// LatestRelease mengambil release terbaru dari url (biasanya
+// LatestReleaseURL). Jika client nil, dipakai http.Client dengan
+// DefaultUpdateTimeout.
+//
+//	release, err := version.LatestRelease(ctx, nil, version.LatestReleaseURL)
+//	if err == nil && version.IsNewer(release.TagName, version.String()) {
+//	    fmt.Println("update available:", release.TagName)
+//	}
+func LatestRelease(ctx context.Context, client *http.Client, url string) (*Release, error) {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultUpdateTimeout}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid releases URL %q: %w", url, err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("releases API returned %s", resp.Status)
+	}
+
+	return parseRelease(resp.Body)
+}
+
+// parseRelease men-decode satu release dari response GitHub API.
+func parseRelease(r io.Reader) (*Release, error) {
+	var release Release
+	if err := json.NewDecoder(r).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+	if release.TagName == "" {
+		return nil, fmt.Errorf("failed to parse release: missing tag_name")
+	}
+	return &release, nil
+}
+
+// IsNewer mengecek apakah tag latest lebih baru dari current.
+// Keduanya semantic version dengan atau tanpa prefix "v" ("v1.2.0", "1.10.3").
+// Build "devel" atau versi yang tidak bisa di-parse tidak pernah dianggap
+// ketinggalan, supaya build lokal tidak selalu menampilkan notifikasi update.
+func IsNewer(latest, current string) bool {
+	l, ok := parseSemver(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseSemver(current)
+	if !ok {
+		return false
+	}
+
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+// Major mengembalikan major version dari "v1.2.3" (atau "1.2.3").
+// ok false jika s bukan semver, misalnya "devel".
+//
+//	version.Major("v2.0.0-rc1") // 2, true
+func Major(s string) (int, bool) {
+	v, ok := parseSemver(s)
+	return v[0], ok
+}
+
+// parseSemver mem-parse "v1.2.3" menjadi [1 2 3]. Suffix pre-release dan
+// build metadata ("-rc1", "+meta") diabaikan.
+func parseSemver(s string) ([3]int, bool) {
+	var v [3]int
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
@@ -0,0 +1,104 @@
+package version
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseRelease(t *testing.T) {
+	f, err := os.Open("testdata/release_latest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	release, err := parseRelease(f)
+	if err != nil {
+		t.Fatalf("parseRelease() error = %v", err)
+	}
+	if release.TagName != "v1.3.0" {
+		t.Errorf("TagName = %q, want v1.3.0", release.TagName)
+	}
+	if release.HTMLURL != "https://github.com/Adityanrhm/wallet-twin/releases/tag/v1.3.0" {
+		t.Errorf("HTMLURL = %q", release.HTMLURL)
+	}
+
+	for _, body := range []string{`{"message":"Not Found"}`, `{"tag_name":`} {
+		if _, err := parseRelease(strings.NewReader(body)); err == nil {
+			t.Errorf("parseRelease(%s) should return error", body)
+		}
+	}
+}
+
+func TestLatestRelease(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/release_latest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.Error(w, "rate limited", http.StatusForbidden)
+			return
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	release, err := LatestRelease(context.Background(), srv.Client(), srv.URL+"/latest")
+	if err != nil {
+		t.Fatalf("LatestRelease() error = %v", err)
+	}
+	if release.TagName != "v1.3.0" {
+		t.Errorf("TagName = %q, want v1.3.0", release.TagName)
+	}
+
+	if _, err := LatestRelease(context.Background(), srv.Client(), srv.URL+"/missing"); err == nil {
+		t.Error("LatestRelease() on 403 should return error")
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v1.3.0", "v1.2.9", true},
+		{"v1.10.0", "v1.9.0", true},
+		{"1.3.0", "v1.3.0", false},
+		{"v1.3.0", "v1.3.0-rc1", false},
+		{"v1.2.0", "v1.3.0", false},
+		{"v2.0.0", DevelVersion, false},
+		{"nightly", "v1.0.0", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNewer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestMajor(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   int
+		wantOK bool
+	}{
+		{"v1.2.3", 1, true},
+		{"2.0.0-rc1", 2, true},
+		{"v0.0.0-20250101000000-abcdef123456", 0, true},
+		{DevelVersion, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Major(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Major(%q) = %d, %v, want %d, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Package version menyimpan informasi build wallet-twin.
+//
+// Version, Commit dan Date di-inject saat build lewat -ldflags:
+//
+//	go build -ldflags "\
+//	    -X github.com/Adityanrhm/wallet-twin/internal/version.Version=v1.2.0 \
+//	    -X github.com/Adityanrhm/wallet-twin/internal/version.Commit=$(git rev-parse --short HEAD) \
+//	    -X github.com/Adityanrhm/wallet-twin/internal/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)" \
+//	    ./cmd/wallet
+//
+// Jika tidak di-set (go run, go install), nilainya diambil dari
+// runtime/debug.ReadBuildInfo: versi module dan VCS info yang ditanam
+// compiler, atau "devel" jika tidak tersedia.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Diisi lewat -ldflags -X. Kosong berarti pakai fallback dari build info.
+var (
+	Version string
+	Commit  string
+	Date    string
+)
+
+// Fallback jika ldflags maupun build info tidak punya nilai.
+const (
+	DevelVersion = "devel"
+	Unknown      = "unknown"
+)
+
+// Info adalah informasi build yang sudah di-resolve.
+type Info struct {
+	Version   string
+	Commit    string
+	Date      string
+	GoVersion string
+}
+
+// Get mengembalikan informasi build binary ini.
+//
+//	info := version.Get()
+//	fmt.Println(info.Version, info.Commit)
+func Get() Info {
+	bi, _ := debug.ReadBuildInfo() // nil jika binary dibangun tanpa module support
+	return resolve(Version, Commit, Date, bi)
+}
+
+// String mengembalikan versi saja, contoh "v1.2.0" atau "devel".
+// Dipakai di header TUI dan field version pada backup JSON.
+func String() string {
+	return Get().Version
+}
+
+// resolve menggabungkan nilai ldflags dengan build info.
+// Nilai ldflags selalu menang; bi boleh nil.
+func resolve(version, commit, date string, bi *debug.BuildInfo) Info {
+	info := Info{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi != nil {
+		if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+		if bi.GoVersion != "" {
+			info.GoVersion = bi.GoVersion
+		}
+
+		var revision, vcsTime string
+		modified := false
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				revision = s.Value
+			case "vcs.time":
+				vcsTime = s.Value
+			case "vcs.modified":
+				modified = s.Value == "true"
+			}
+		}
+		if info.Commit == "" && revision != "" {
+			info.Commit = shortCommit(revision)
+			if modified {
+				info.Commit += "-dirty"
+			}
+		}
+		if info.Date == "" {
+			info.Date = vcsTime
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = DevelVersion
+	}
+	if info.Commit == "" {
+		info.Commit = Unknown
+	}
+	if info.Date == "" {
+		info.Date = Unknown
+	}
+	return info
+}
+
+// shortCommit memotong hash commit ke 7 karakter seperti git --short.
+func shortCommit(rev string) string {
+	if len(rev) > 7 {
+		return rev[:7]
+	}
+	return rev
+}
@@ -0,0 +1,45 @@
+package version
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestResolve_Fallbacks(t *testing.T) {
+	// Tanpa ldflags dan tanpa build info
+	info := resolve("", "", "", nil)
+	if info.Version != DevelVersion || info.Commit != Unknown || info.Date != Unknown {
+		t.Errorf("resolve() = %+v, want devel/unknown fallbacks", info)
+	}
+	if info.GoVersion == "" {
+		t.Error("GoVersion should fall back to runtime.Version()")
+	}
+
+	// go build dari working tree: Main.Version "(devel)", VCS info ada
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.25.5",
+		Main:      debug.Module{Path: "github.com/Adityanrhm/wallet-twin", Version: "(devel)"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "5550e40c1a2b3c4d5e6f"},
+			{Key: "vcs.time", Value: "2025-02-01T10:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	info = resolve("", "", "", bi)
+	want := Info{Version: DevelVersion, Commit: "5550e40-dirty", Date: "2025-02-01T10:00:00Z", GoVersion: "go1.25.5"}
+	if info != want {
+		t.Errorf("resolve() with build info = %+v, want %+v", info, want)
+	}
+
+	// go install module@v1.2.0
+	bi = &debug.BuildInfo{Main: debug.Module{Version: "v1.2.0"}}
+	if got := resolve("", "", "", bi).Version; got != "v1.2.0" {
+		t.Errorf("Version = %q, want module version v1.2.0", got)
+	}
+
+	// ldflags selalu menang
+	info = resolve("v2.0.0", "abc1234", "2025-03-01", bi)
+	if info.Version != "v2.0.0" || info.Commit != "abc1234" || info.Date != "2025-03-01" {
+		t.Errorf("resolve() with ldflags = %+v, want ldflags values", info)
+	}
+}
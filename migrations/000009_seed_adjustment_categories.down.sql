@@ -0,0 +1,4 @@
+-- Rollback: Remove adjustment categories
+-- Transaksi adjustment tetap ada (category_id menjadi NULL)
+
+DELETE FROM categories WHERE name = 'Adjustment' AND parent_id IS NULL;
@@ -0,0 +1,11 @@
+-- Migration: Seed adjustment categories
+-- Version: 000009
+-- Description: Kategori untuk transaksi koreksi saldo
+--
+-- Transaksi adjustment dibuat saat saldo wallet tidak cocok dengan saldo
+-- sebenarnya. Transaksi ini diberi tag 'adjustment' sehingga reports bisa
+-- mengecualikannya.
+
+INSERT INTO categories (name, type, icon, color, sort_order) VALUES
+    ('Adjustment', 'income', '⚖️', '#94A3B8', 100),
+    ('Adjustment', 'expense', '⚖️', '#94A3B8', 100);
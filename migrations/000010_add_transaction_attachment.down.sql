@@ -0,0 +1,3 @@
+-- Rollback: Remove attachment from transactions
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS attachment;
@@ -0,0 +1,11 @@
+-- Migration: Add attachment to transactions
+-- Version: 000010
+-- Description: Referensi ke bukti transaksi (foto struk)
+--
+-- Attachment berisi path file atau URL, bukan file-nya sendiri.
+-- String kosong berarti transaksi tidak punya attachment.
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS attachment VARCHAR(500) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN transactions.attachment IS 'Path file atau URL bukti transaksi (kosong jika tidak ada)';
@@ -0,0 +1,15 @@
+-- Rollback: Remove investment wallet type
+--
+-- PostgreSQL tidak bisa menghapus value dari ENUM, jadi type dibuat ulang.
+-- Wallet investment diubah menjadi 'bank' supaya datanya tidak hilang.
+
+UPDATE wallets SET type = 'bank' WHERE type = 'investment';
+
+ALTER TYPE wallet_type RENAME TO wallet_type_old;
+CREATE TYPE wallet_type AS ENUM ('cash', 'bank', 'ewallet');
+
+ALTER TABLE wallets ALTER COLUMN type DROP DEFAULT;
+ALTER TABLE wallets ALTER COLUMN type TYPE wallet_type USING type::text::wallet_type;
+ALTER TABLE wallets ALTER COLUMN type SET DEFAULT 'cash';
+
+DROP TYPE wallet_type_old;
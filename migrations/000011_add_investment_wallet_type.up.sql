@@ -0,0 +1,9 @@
+-- Migration: Add investment wallet type
+-- Version: 000011
+-- Description: Wallet untuk aset dengan nilai pasar (reksa dana, emas, saham)
+--
+-- Perubahan nilai pasar dicatat sebagai transaksi dengan tag
+-- 'revaluation' + 'adjustment', jadi tidak perlu tabel baru dan
+-- otomatis dikecualikan dari laporan income/expense.
+
+ALTER TYPE wallet_type ADD VALUE IF NOT EXISTS 'investment';
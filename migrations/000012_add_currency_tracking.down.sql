@@ -0,0 +1,5 @@
+-- Rollback: Remove currency tracking columns
+
+ALTER TABLE transfers DROP COLUMN IF EXISTS exchange_rate;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS original_currency;
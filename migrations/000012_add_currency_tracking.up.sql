@@ -0,0 +1,20 @@
+-- Migration: Track currencies on imported transactions and transfers
+-- Version: 000012
+-- Description: Original currency transaksi import dan kurs transfer
+--
+-- original_currency diisi saat import CSV yang punya kolom Currency.
+-- NULL berarti transaksi tidak di-import atau currency-nya tidak diketahui.
+-- Jika berbeda dengan currency wallet, amount sudah dikonversi dengan
+-- --convert-with-rate dan `wallet doctor` akan melaporkannya.
+--
+-- exchange_rate diisi untuk transfer antar wallet beda currency:
+-- wallet tujuan menerima amount * exchange_rate.
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS original_currency CHAR(3);
+
+ALTER TABLE transfers
+    ADD COLUMN IF NOT EXISTS exchange_rate NUMERIC(20, 8) CHECK (exchange_rate > 0);
+
+COMMENT ON COLUMN transactions.original_currency IS 'Currency asli row import (NULL jika tidak diketahui)';
+COMMENT ON COLUMN transfers.exchange_rate IS 'Kurs untuk transfer beda currency (NULL jika currency sama)';
@@ -0,0 +1,3 @@
+-- Rollback: Remove wallet opening date
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS opening_date;
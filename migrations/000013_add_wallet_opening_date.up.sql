@@ -0,0 +1,13 @@
+-- Migration: Add wallet opening date
+-- Version: 000013
+-- Description: Tanggal mulai wallet untuk validasi tanggal transaksi
+--
+-- opening_date dipakai untuk wallet lama yang baru dicatat di aplikasi.
+-- NULL berarti wallet dimulai saat created_at. Jika
+-- validation.reject_before_wallet_date aktif, transaksi sebelum tanggal
+-- ini ditolak.
+
+ALTER TABLE wallets
+    ADD COLUMN IF NOT EXISTS opening_date DATE;
+
+COMMENT ON COLUMN wallets.opening_date IS 'Tanggal mulai wallet (NULL = created_at)';
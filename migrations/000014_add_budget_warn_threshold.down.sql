@@ -0,0 +1,3 @@
+-- Rollback: Remove budget warn threshold
+
+ALTER TABLE budgets DROP COLUMN IF EXISTS warn_threshold;
@@ -0,0 +1,12 @@
+-- Migration: Add budget warn threshold
+-- Version: 000014
+-- Description: Persentase warning per budget
+--
+-- warn_threshold adalah persentase budget terpakai saat alert pertama
+-- muncul (alert kedua selalu di 100%). Budget lama mendapat default 80.
+
+ALTER TABLE budgets
+    ADD COLUMN IF NOT EXISTS warn_threshold NUMERIC(5, 2) NOT NULL DEFAULT 80
+        CHECK (warn_threshold > 0 AND warn_threshold < 100);
+
+COMMENT ON COLUMN budgets.warn_threshold IS 'Persentase alert pertama (alert kedua di 100%)';
@@ -0,0 +1,16 @@
+-- Migration: Add idempotency key to transactions
+-- Version: 000015
+-- Description: Key unik supaya request yang diulang tidak membuat transaksi dobel
+--
+-- idempotency_key diisi oleh pemanggil (script, recurring processor).
+-- NULL berarti transaksi dibuat tanpa key; unique index hanya berlaku
+-- untuk row yang punya key.
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_idempotency_key
+    ON transactions(idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+COMMENT ON COLUMN transactions.idempotency_key IS 'Key dari pemanggil untuk mencegah transaksi dobel (NULL jika tidak ada)';
@@ -0,0 +1,16 @@
+-- Migration: Add external reference to transactions
+-- Version: 000016
+-- Description: ID transaksi dari bank (OFX FITID) untuk import tanpa dobel
+--
+-- external_reference diisi oleh import bank (wallet import bank). FITID
+-- hanya unik per rekening, jadi unique index per wallet. NULL berarti
+-- transaksi tidak berasal dari file bank.
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS external_reference VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_external_reference
+    ON transactions(wallet_id, external_reference)
+    WHERE external_reference IS NOT NULL;
+
+COMMENT ON COLUMN transactions.external_reference IS 'ID transaksi dari bank, mis. OFX FITID (NULL jika tidak ada)';
@@ -0,0 +1,3 @@
+-- Rollback: Remove sort order from goals
+
+ALTER TABLE goals DROP COLUMN IF EXISTS sort_order;
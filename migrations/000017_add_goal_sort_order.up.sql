@@ -0,0 +1,11 @@
+-- Migration: Add sort order to goals
+-- Version: 000017
+-- Description: Urutan prioritas goal (wallet goal reorder)
+--
+-- Goal di-list berdasarkan sort_order ASC lalu created_at DESC. Goal yang
+-- belum pernah diurutkan bernilai 0.
+
+ALTER TABLE goals
+    ADD COLUMN IF NOT EXISTS sort_order INT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN goals.sort_order IS 'Urutan prioritas goal, kecil dulu (0 jika belum diurutkan)';
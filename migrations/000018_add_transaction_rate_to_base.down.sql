@@ -0,0 +1,3 @@
+-- Rollback: Remove exchange rate snapshot from transactions
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS rate_to_base;
@@ -0,0 +1,13 @@
+-- Migration: Add exchange rate snapshot to transactions
+-- Version: 000018
+-- Description: Kurs currency wallet ke app.currency saat transaksi dibuat
+--
+-- rate_to_base diisi saat transaksi dibuat jika kurs dikonfigurasi
+-- (app.rates) dan currency wallet berbeda dengan app.currency. Laporan
+-- lintas currency menjumlahkan amount * rate_to_base, jadi transaksi lama
+-- tetap memakai kurs saat itu. NULL berarti tidak dikonversi.
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS rate_to_base NUMERIC(20, 8) CHECK (rate_to_base > 0);
+
+COMMENT ON COLUMN transactions.rate_to_base IS 'Kurs currency wallet ke app.currency saat transaksi dibuat (NULL jika tidak dikonversi)';
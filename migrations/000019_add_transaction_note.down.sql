@@ -0,0 +1,3 @@
+-- Rollback: Remove note from transactions
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS note;
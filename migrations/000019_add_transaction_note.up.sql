@@ -0,0 +1,11 @@
+-- Migration: Add note to transactions
+-- Version: 000019
+-- Description: Catatan panjang (multi-line) terpisah dari description
+--
+-- Description tetap satu baris untuk tabel; note untuk info tambahan
+-- seperti garansi atau pembagian tagihan. String kosong berarti tanpa note.
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS note TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN transactions.note IS 'Catatan panjang transaksi, maksimal 4096 byte (kosong jika tidak ada)';
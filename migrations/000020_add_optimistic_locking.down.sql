@@ -0,0 +1,4 @@
+-- Rollback: Remove version from wallets and goals
+
+ALTER TABLE goals DROP COLUMN IF EXISTS version;
+ALTER TABLE wallets DROP COLUMN IF EXISTS version;
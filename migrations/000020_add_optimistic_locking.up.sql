@@ -0,0 +1,16 @@
+-- Migration: Add version to wallets and goals
+-- Version: 000020
+-- Description: Optimistic locking untuk update wallet dan goal
+--
+-- Setiap UPDATE menaikkan version. Update dari service memakai
+-- WHERE id = $1 AND version = $n; jika tidak ada row yang berubah,
+-- row sudah diubah proses lain sejak dibaca.
+
+ALTER TABLE wallets
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE goals
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN wallets.version IS 'Naik setiap update, untuk optimistic locking';
+COMMENT ON COLUMN goals.version IS 'Naik setiap update, untuk optimistic locking';
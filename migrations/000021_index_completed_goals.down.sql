@@ -0,0 +1,3 @@
+-- Rollback: Remove completed goals index
+
+DROP INDEX IF EXISTS idx_goals_completed_updated_at;
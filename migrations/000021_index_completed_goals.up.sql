@@ -0,0 +1,9 @@
+-- Migration: Index goals.updated_at for completed goals
+-- Version: 000021
+-- Description: Laporan goal yang tercapai per periode (`wallet goal completed`)
+--
+-- Tanggal selesai goal diambil dari updated_at goal berstatus completed.
+-- Partial index, sama seperti idx_goals_active untuk goal aktif.
+
+CREATE INDEX IF NOT EXISTS idx_goals_completed_updated_at
+    ON goals(updated_at) WHERE status = 'completed';
@@ -0,0 +1,3 @@
+-- Rollback: Remove wallet low balance threshold
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS low_balance_threshold;
@@ -0,0 +1,11 @@
+-- Migration: Add wallet low balance threshold
+-- Version: 000022
+-- Description: Alert saat saldo wallet di bawah batas tertentu
+--
+-- NULL berarti tidak ada alert. Alert muncul jika balance < threshold.
+
+ALTER TABLE wallets
+    ADD COLUMN IF NOT EXISTS low_balance_threshold NUMERIC(15, 2)
+        CHECK (low_balance_threshold IS NULL OR low_balance_threshold >= 0);
+
+COMMENT ON COLUMN wallets.low_balance_threshold IS 'Batas saldo rendah untuk alert (NULL jika tidak ada)';
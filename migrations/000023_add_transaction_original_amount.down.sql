@@ -0,0 +1,3 @@
+-- Rollback: Remove original amount from transactions
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS original_amount;
@@ -0,0 +1,13 @@
+-- Migration: Add original amount to transactions
+-- Version: 000023
+-- Description: Nominal asli transaksi dalam currency asing
+--
+-- original_amount diisi jika transaksi dicatat dalam currency lain
+-- (original_currency) lalu dikonversi ke currency wallet. amount tetap
+-- nominal dalam currency wallet, yang dipakai untuk saldo. NULL berarti
+-- tidak dikonversi.
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS original_amount NUMERIC(15, 2) CHECK (original_amount > 0);
+
+COMMENT ON COLUMN transactions.original_amount IS 'Nominal dalam original_currency sebelum dikonversi (NULL jika tidak dikonversi)';
@@ -0,0 +1,3 @@
+-- Rollback: Remove goal kind
+
+ALTER TABLE goals DROP COLUMN IF EXISTS kind;
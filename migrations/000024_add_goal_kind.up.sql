@@ -0,0 +1,13 @@
+-- Migration: Add goal kind
+-- Version: 000024
+-- Description: Jenis goal, tabungan biasa atau net worth
+--
+-- Goal net_worth tidak menerima kontribusi: current_amount dihitung live
+-- dari total saldo wallet saat dibaca, jadi kolom current_amount-nya
+-- tetap 0.
+
+ALTER TABLE goals
+    ADD COLUMN IF NOT EXISTS kind VARCHAR(20) NOT NULL DEFAULT 'savings'
+        CHECK (kind IN ('savings', 'net_worth'));
+
+COMMENT ON COLUMN goals.kind IS 'savings (kontribusi manual) atau net_worth (total saldo wallet)';
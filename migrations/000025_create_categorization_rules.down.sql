@@ -0,0 +1,4 @@
+-- Rollback: Drop categorization rules table
+
+DROP INDEX IF EXISTS idx_categorization_rules_priority;
+DROP TABLE IF EXISTS categorization_rules CASCADE;
@@ -0,0 +1,46 @@
+-- Migration: Create categorization rules table
+-- Version: 000025
+-- Description: Rule untuk mengisi kategori transaksi secara otomatis
+--
+-- Transaksi yang dicatat tanpa kategori diberi kategori dari rule
+-- pertama yang cocok (priority terkecil dulu).
+--
+-- Contoh:
+-- - wallet = Gojek → Transport
+-- - description mengandung "spotify" → Entertainment
+
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    -- Primary key UUID
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    -- Urutan pengecekan (kecil dulu)
+    priority INTEGER NOT NULL DEFAULT 0,
+
+    -- Cara mencocokkan transaksi
+    match_type VARCHAR(30) NOT NULL
+        CHECK (match_type IN ('wallet', 'description_contains', 'description_regex', 'tag')),
+
+    -- Teks, regex, atau tag yang dicocokkan (kosong untuk rule wallet)
+    pattern VARCHAR(200) NOT NULL DEFAULT '',
+
+    -- Wallet untuk rule wallet
+    wallet_id UUID REFERENCES wallets(id) ON DELETE CASCADE,
+
+    -- Kategori yang diberikan
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Constraint: rule wallet harus punya wallet, rule lain harus punya pattern
+    CONSTRAINT valid_rule_target CHECK (
+        (match_type = 'wallet' AND wallet_id IS NOT NULL)
+        OR (match_type <> 'wallet' AND pattern <> '')
+    )
+);
+
+-- Index untuk urutan pengecekan
+CREATE INDEX idx_categorization_rules_priority ON categorization_rules(priority, created_at);
+
+-- Komentar dokumentasi
+COMMENT ON TABLE categorization_rules IS 'Rule kategori otomatis untuk transaksi tanpa kategori';
@@ -0,0 +1,3 @@
+-- Rollback: Remove recurring day of month
+
+ALTER TABLE recurring_transactions DROP COLUMN IF EXISTS day_of_month;
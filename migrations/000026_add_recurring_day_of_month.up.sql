@@ -0,0 +1,17 @@
+-- Migration: Add recurring day of month
+-- Version: 000026
+-- Description: Tanggal jatuh tempo asli untuk recurring monthly
+--
+-- Recurring tanggal 31 jatuh di 28/29 Feb lalu kembali ke 31 Mar.
+-- Tanpa kolom ini tanggal asli hilang setelah dimajukan ke bulan yang
+-- lebih pendek. 0 = belum diisi (diambil dari next_due).
+
+ALTER TABLE recurring_transactions
+    ADD COLUMN IF NOT EXISTS day_of_month SMALLINT NOT NULL DEFAULT 0
+        CHECK (day_of_month BETWEEN 0 AND 31);
+
+UPDATE recurring_transactions
+SET day_of_month = EXTRACT(DAY FROM next_due)
+WHERE frequency = 'monthly' AND day_of_month = 0;
+
+COMMENT ON COLUMN recurring_transactions.day_of_month IS 'Tanggal jatuh tempo asli untuk frequency monthly (0 = pakai next_due)';
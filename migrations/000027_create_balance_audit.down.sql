@@ -0,0 +1,5 @@
+-- Rollback: Drop balance audit table
+
+DROP INDEX IF EXISTS idx_balance_audit_reference;
+DROP INDEX IF EXISTS idx_balance_audit_wallet;
+DROP TABLE IF EXISTS balance_audit CASCADE;
@@ -0,0 +1,39 @@
+-- Migration: Create balance audit table
+-- Version: 000027
+-- Description: Riwayat setiap perubahan saldo wallet
+--
+-- Setiap UPDATE wallets.balance dari aplikasi menulis satu row di sini
+-- dalam statement yang sama, jadi audit selalu ikut commit/rollback
+-- bersama perubahan saldonya.
+--
+-- Contoh:
+-- - transaction_created: 1.000.000 → 950.000 (reference = transaksi)
+-- - transfer: 950.000 → 450.000 (reference = transfer)
+
+CREATE TABLE IF NOT EXISTS balance_audit (
+    -- Primary key UUID
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    -- Wallet yang saldonya berubah
+    wallet_id UUID NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+
+    -- Saldo sebelum dan sesudah
+    old_balance NUMERIC(15, 2) NOT NULL,
+    new_balance NUMERIC(15, 2) NOT NULL,
+
+    -- Alasan perubahan (transaction_created, transfer, adjustment, ...)
+    reason VARCHAR(30) NOT NULL,
+
+    -- Entity penyebab (transaksi, transfer, goal); NULL untuk import
+    reference_id UUID,
+
+    -- clock_timestamp supaya beberapa perubahan dalam satu transaction
+    -- tetap berurutan
+    created_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_audit_wallet ON balance_audit(wallet_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_balance_audit_reference ON balance_audit(reference_id) WHERE reference_id IS NOT NULL;
+
+COMMENT ON TABLE balance_audit IS 'Riwayat perubahan saldo wallet';
+COMMENT ON COLUMN balance_audit.reference_id IS 'ID transaksi, transfer atau goal penyebab perubahan';
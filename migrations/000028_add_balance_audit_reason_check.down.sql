@@ -0,0 +1,3 @@
+-- Rollback: Remove balance audit reason check
+
+ALTER TABLE balance_audit DROP CONSTRAINT IF EXISTS balance_audit_reason_check;
@@ -0,0 +1,15 @@
+-- Migration: Restrict balance audit reasons
+-- Version: 000028
+-- Description: Batasi reason balance_audit ke alasan yang dikenal aplikasi
+--
+-- Menambah reason recurring: recurring yang jatuh tempo diproses
+-- sekaligus, satu row per wallet tanpa reference_id (seperti import).
+
+ALTER TABLE balance_audit
+    ADD CONSTRAINT balance_audit_reason_check
+        CHECK (reason IN (
+            'transaction_created', 'transaction_deleted', 'import', 'recurring',
+            'transfer', 'adjustment', 'revaluation', 'goal_contribution'
+        ));
+
+COMMENT ON COLUMN balance_audit.reason IS 'Alasan perubahan; import dan recurring tidak punya reference_id';
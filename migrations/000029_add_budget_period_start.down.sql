@@ -0,0 +1,3 @@
+-- Rollback: Remove budget period start
+
+ALTER TABLE budgets DROP COLUMN IF EXISTS period_start;
@@ -0,0 +1,15 @@
+-- Migration: Add budget period start
+-- Version: 000029
+-- Description: Awal periode budget yang terakhir di-roll over
+--
+-- Sebelumnya rollover menimpa start_date sehingga tanggal mulai asli
+-- budget hilang. period_start menyimpan awal periode berjalan;
+-- start_date tidak lagi diubah. Budget lama mulai dari start_date.
+
+ALTER TABLE budgets ADD COLUMN IF NOT EXISTS period_start DATE;
+
+UPDATE budgets SET period_start = start_date WHERE period_start IS NULL;
+
+ALTER TABLE budgets ALTER COLUMN period_start SET NOT NULL;
+
+COMMENT ON COLUMN budgets.period_start IS 'Awal periode yang terakhir di-roll over; start_date tetap tanggal mulai budget';
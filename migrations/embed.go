@@ -0,0 +1,11 @@
+// Package migrations meng-embed file SQL migration ke dalam binary,
+// supaya `wallet version` bisa melaporkan versi schema yang diharapkan
+// build ini tanpa membaca folder migrations dari disk.
+package migrations
+
+import "embed"
+
+// FS berisi semua file *.up.sql dan *.down.sql di folder ini.
+//
+//go:embed *.sql
+var FS embed.FS